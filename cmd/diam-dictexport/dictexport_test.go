@@ -0,0 +1,90 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testDict = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+    <application id="16777999" type="auth" name="Example">
+        <vendor id="99999" name="Example"/>
+        <command code="8388999" short="EX" name="Example-Check">
+            <request>
+                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Example-Status" required="false" max="1"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="true" max="1"/>
+            </answer>
+        </command>
+        <avp name="Example-Status" code="5001" must="V" may="P" must-not="M" may-encrypt="N" vendor-id="99999">
+            <data type="Enumerated">
+                <item code="0" name="OK"/>
+                <item code="1" name="BROKEN"/>
+            </data>
+        </avp>
+    </application>
+</diameter>
+`
+
+func TestExport(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "example.xml")
+	if err := os.WriteFile(name, []byte(testDict), 0600); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		opts *options
+		want []string
+	}{
+		{
+			&options{format: "wireshark", files: []string{name}},
+			[]string{
+				`<vendor vendor-id="Example" code="99999" name="Example">`,
+				`<application id="16777999" name="Example">`,
+				`<command name="Example-Check" code="8388999" vendor-id="Example">`,
+				`<avp name="Example-Status" code="5001" vendor-id="Example" mandatory="mustnot" protected="may" may-encrypt="no" vendor-bit="must">`,
+				`<enum name="BROKEN" code="1">`,
+			},
+		},
+		{
+			&options{format: "freediameter", name: "dict_example", apps: "16777999", base: true, files: []string{name}},
+			[]string{
+				`struct dict_application_data data = { 16777999, "Example" };`,
+				`"Enumerated(Example-Status)"`,
+				`add_rule(cmd, 99999, "Example-Status", RULE_OPTIONAL, 0, -1, 1)`,
+				`EXTENSION_ENTRY("dict_example", dict_example_entry);`,
+			},
+		},
+	} {
+		var b bytes.Buffer
+		if err := export(&b, tc.opts); err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(b.String(), want) {
+				t.Fatalf("Missing %s in:\n%s", want, b.String())
+			}
+		}
+	}
+
+	for _, o := range []*options{
+		{format: "wireshark", apps: "1,x"},
+		{format: "wireshark", apps: "16777999"},
+		{format: "pcap"},
+		{format: "wireshark", files: []string{"none.xml"}},
+	} {
+		if err := export(new(bytes.Buffer), o); err == nil {
+			t.Fatalf("Invalid export was accepted: %+v", o)
+		}
+	}
+}
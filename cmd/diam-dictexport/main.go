@@ -0,0 +1,129 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command diam-dictexport converts go-diameter dictionaries to Wireshark
+// dictionary XML or to the C source of a freeDiameter dictionary
+// extension, offline.
+//
+// The dictionary files given as arguments are loaded on top of the
+// embedded default dictionary, or alone with -default=false, and the
+// applications listed in -apps, or all applications but the base one,
+// are written to standard output or to the -o file:
+//
+//	diam-dictexport -apps 16777252 -o Custom.xml myvendor.xml
+//	diam-dictexport -format freediameter -name dict_myvendor -apps 16777252 myvendor.xml
+//
+// A Wireshark dictionary is an XML fragment to be saved in the diameter
+// directory of Wireshark and included from its dictionary.xml, like the
+// Custom.xml file. A freeDiameter extension is built like the dict_*
+// extensions shipped with freeDiameter.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+func main() {
+	format := flag.String("format", "wireshark", "output format wireshark/freediameter")
+	apps := flag.String("apps", "", "comma separated ids of the applications to export, empty for all")
+	name := flag.String("name", "dict_export", "name of the freeDiameter extension")
+	base := flag.Bool("default", true, "load the embedded default dictionary before the files")
+	wireshark := flag.String("wireshark_dir", "", "Wireshark dictionary directory to load before the files (optional)")
+	output := flag.String("o", "", "output file, empty for standard output")
+	flag.Parse()
+
+	var w io.Writer = os.Stdout
+	if len(*output) > 0 {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		w = f
+	}
+	o := &options{
+		format:       *format,
+		apps:         *apps,
+		name:         *name,
+		base:         *base,
+		wiresharkDir: *wireshark,
+		files:        flag.Args(),
+	}
+	if err := export(w, o); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type options struct {
+	format       string
+	apps         string
+	name         string
+	base         bool
+	wiresharkDir string
+	files        []string
+}
+
+// export loads the dictionaries of o and writes the applications of o
+// to w in the format of o.
+func export(w io.Writer, o *options) error {
+	ids, err := parseApps(o.apps)
+	if err != nil {
+		return err
+	}
+	p, err := loadParser(o)
+	if err != nil {
+		return err
+	}
+	switch o.format {
+	case "wireshark":
+		return p.ExportWireshark(w, ids...)
+	case "freediameter":
+		return p.ExportFreeDiameter(w, o.name, ids...)
+	default:
+		return fmt.Errorf("unsupported format: %q", o.format)
+	}
+}
+
+func loadParser(o *options) (*dict.Parser, error) {
+	var p *dict.Parser
+	if o.base {
+		p = dict.Default
+	} else {
+		p, _ = dict.NewParser()
+	}
+	if len(o.wiresharkDir) > 0 {
+		if err := p.LoadWiresharkDir(o.wiresharkDir); err != nil {
+			return nil, err
+		}
+	}
+	for _, f := range o.files {
+		if err := p.LoadFile(f); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+func parseApps(s string) ([]uint32, error) {
+	var ids []uint32
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); len(f) == 0 {
+			continue
+		}
+		id, err := strconv.ParseUint(f, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid application id: %q", f)
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
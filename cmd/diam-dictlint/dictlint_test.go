@@ -0,0 +1,54 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeDict(t *testing.T, name, xml string) string {
+	name = filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(name, []byte(xml), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return name
+}
+
+func TestLint(t *testing.T) {
+	good := writeDict(t, "good.xml", `<diameter>
+<application id="16777998" name="Good">
+	<command code="8388998" short="GD" name="Good">
+		<request><rule avp="Session-Id" required="true" max="1"/></request>
+		<answer><rule avp="Result-Code" required="true" max="1"/></answer>
+	</command>
+</application>
+</diameter>`)
+	bad := writeDict(t, "bad.xml", `<diameter>
+<application id="16777999" name="Bad">
+	<avp name="Bad-Status" code="5001" vendor-id="99999">
+		<data type="Enumerated"/>
+	</avp>
+</application>
+</diameter>`)
+	broken := writeDict(t, "broken.xml", `<diameter><application id="1"><avp name="X" code="1"><data type="Nope"/></avp></application></diameter>`)
+
+	var b bytes.Buffer
+	if status := lint(&b, true, []string{good}); status != 0 || b.Len() != 0 {
+		t.Fatalf("Unexpected status %d: %s", status, b.String())
+	}
+	b.Reset()
+	if status := lint(&b, false, []string{bad}); status != 1 ||
+		b.String() != bad+":3: Enumerated AVP Bad-Status has no items\n" {
+		t.Fatalf("Unexpected status %d: %s", status, b.String())
+	}
+	b.Reset()
+	if status := lint(&b, false, []string{broken}); status != 1 || !strings.HasPrefix(b.String(), broken+": ") {
+		t.Fatalf("Unexpected status %d: %s", status, b.String())
+	}
+}
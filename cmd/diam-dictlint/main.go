@@ -0,0 +1,59 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command diam-dictlint checks go-diameter dictionary files for mistakes,
+// so that dictionary changes can be gated.
+//
+// The files given as arguments are loaded in order on top of the embedded
+// default dictionary, or alone with -default=false, and the problems
+// found in them by dict.Parser.Lint are printed one per line, as in:
+//
+//	myvendor.xml:42: rule of My-Request references unknown AVP My-AVP in application 16777999
+//
+// The exit status is 1 when a file cannot be loaded or has problems,
+// and 0 otherwise.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+func main() {
+	base := flag.Bool("default", true, "load the embedded default dictionary before the files")
+	flag.Parse()
+	os.Exit(lint(os.Stdout, *base, flag.Args()))
+}
+
+// lint loads and lints files, writes their problems to w and returns the
+// exit status.
+func lint(w io.Writer, base bool, files []string) int {
+	p, _ := dict.NewParser()
+	if base {
+		p = dict.Default
+	}
+	for _, f := range files {
+		if err := p.LoadFile(f); err != nil {
+			fmt.Fprintf(w, "%s: %v\n", f, err)
+			return 1
+		}
+	}
+	linted := make(map[string]bool, len(files))
+	for _, f := range files {
+		linted[f] = true
+	}
+	status := 0
+	for _, problem := range p.Lint() {
+		// Problems of the default dictionary are not the user's.
+		if linted[problem.Filename] {
+			fmt.Fprintln(w, problem)
+			status = 1
+		}
+	}
+	return status
+}
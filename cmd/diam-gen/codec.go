@@ -0,0 +1,528 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// Shapes of codec fields.
+const (
+	shapeValue = iota
+	shapePointer
+	shapeSlice
+	shapePointerSlice
+)
+
+// Kinds of the element types of codec fields.
+const (
+	kindString   = iota // Strings and byte slices, empty when of length 0
+	kindNumber          // Numbers, empty when 0
+	kindStruct          // Structs such as time.Time, never empty
+	kindGroup           // Tagged structs, encoded as Grouped AVPs
+	kindRaw             // diam.AVP, kept as it is
+	kindEmbedded        // Embedded tagged structs, without a tag
+)
+
+// datatypeKinds are the kinds of the datatype types, by name.
+var datatypeKinds = map[string]int{
+	"Address":          kindString,
+	"DiameterIdentity": kindString,
+	"DiameterURI":      kindString,
+	"Enumerated":       kindNumber,
+	"Float32":          kindNumber,
+	"Float64":          kindNumber,
+	"Grouped":          kindString,
+	"IPFilterRule":     kindString,
+	"IPv4":             kindString,
+	"IPv6":             kindString,
+	"Integer32":        kindNumber,
+	"Integer64":        kindNumber,
+	"OctetString":      kindString,
+	"QoSFilterRule":    kindString,
+	"Time":             kindStruct,
+	"UTF8String":       kindString,
+	"Unknown":          kindString,
+	"Unsigned32":       kindNumber,
+	"Unsigned64":       kindNumber,
+}
+
+// codecStruct is a struct type with avp tags, and the application in
+// which its tags are resolved.
+type codecStruct struct {
+	name   string
+	appID  uint32
+	fields []*codecField
+}
+
+// codecField is a field of a codecStruct.
+type codecField struct {
+	name  string
+	avp   *dict.AVP // nil for embedded structs
+	omit  bool
+	shape int
+	kind  int
+	elem  string // Go type of the elements of the field
+}
+
+// codec writes the MarshalAVP and UnmarshalAVP methods of the struct
+// types with avp tags of a set of Go files.
+type codec struct {
+	p       *dict.Parser
+	decls   map[string]ast.Expr // Type declarations of the files
+	tagged  map[string]bool     // Struct types with avp tags
+	structs []*codecStruct
+	imports map[string]string // Imports of the files, by name
+	uses    map[string]bool   // Packages used by the methods
+}
+
+// newCodec returns the codec of the tagged structs of files, whose tags
+// are resolved in the application returned by appID for each struct.
+// Structs for which appID returns true get methods even without tags.
+func newCodec(p *dict.Parser, files []*ast.File, appID func(name string) (uint32, bool)) (*codec, error) {
+	c := &codec{
+		p:       p,
+		decls:   make(map[string]ast.Expr),
+		tagged:  make(map[string]bool),
+		imports: make(map[string]string),
+		uses:    make(map[string]bool),
+	}
+	var specs []*ast.TypeSpec
+	for _, f := range files {
+		for _, imp := range f.Imports {
+			path, _ := strconv.Unquote(imp.Path.Value)
+			name := path[strings.LastIndex(path, "/")+1:]
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			for std, stdPath := range packages {
+				if (name == std) != (path == stdPath) {
+					return nil, fmt.Errorf("Import %s %q clashes with the package %s used by the methods", name, path, std)
+				}
+			}
+			c.imports[name] = path
+		}
+		for _, d := range f.Decls {
+			gd, ok := d.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, s := range gd.Specs {
+				ts := s.(*ast.TypeSpec)
+				c.decls[ts.Name.Name] = ts.Type
+				st, ok := ts.Type.(*ast.StructType)
+				if _, all := appID(ts.Name.Name); ok && ts.TypeParams == nil && (all || hasTags(st)) {
+					c.tagged[ts.Name.Name] = true
+					specs = append(specs, ts)
+				}
+			}
+		}
+	}
+	for _, ts := range specs {
+		id, _ := appID(ts.Name.Name)
+		s := &codecStruct{name: ts.Name.Name, appID: id}
+		for _, f := range ts.Type.(*ast.StructType).Fields.List {
+			fields, err := c.fields(s.appID, f)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", s.name, err)
+			}
+			s.fields = append(s.fields, fields...)
+		}
+		c.structs = append(c.structs, s)
+	}
+	return c, nil
+}
+
+// hasTags returns whether a field of st has an avp tag.
+func hasTags(st *ast.StructType) bool {
+	for _, f := range st.Fields.List {
+		if name, _ := fieldTag(f); len(name) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldTag returns the AVP name and omitempty option of the tag of f,
+// as Message.Marshal does.
+func fieldTag(f *ast.Field) (string, bool) {
+	if f.Tag == nil {
+		return "", false
+	}
+	tag, _ := strconv.Unquote(f.Tag.Value)
+	v := reflect.StructTag(tag).Get("avp")
+	if i := strings.Index(v, ","); i != -1 {
+		return v[:i], strings.Contains(v[i+1:], "omitempty")
+	}
+	return v, false
+}
+
+// fields returns the codec fields of the struct field f.
+func (c *codec) fields(appID uint32, f *ast.Field) ([]*codecField, error) {
+	avpName, omit := fieldTag(f)
+	names := make([]string, 0, len(f.Names))
+	for _, n := range f.Names {
+		names = append(names, n.Name)
+	}
+	if len(names) == 0 {
+		// Embedded field, named after its type.
+		name := strings.TrimPrefix(types.ExprString(f.Type), "*")
+		names = append(names, name[strings.LastIndex(name, ".")+1:])
+		if id, ok := f.Type.(*ast.Ident); ok && len(avpName) == 0 && c.tagged[id.Name] {
+			return []*codecField{{name: id.Name, kind: kindEmbedded, elem: id.Name}}, nil
+		}
+	}
+	if len(avpName) == 0 {
+		return nil, nil
+	}
+	a, err := c.p.FindAVP(appID, avpName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", avpName, err)
+	}
+	shape, elem := shapeValue, f.Type
+	switch t := f.Type.(type) {
+	case *ast.StarExpr:
+		shape, elem = shapePointer, t.X
+	case *ast.ArrayType:
+		if t.Len == nil && !isByte(t.Elt) {
+			shape, elem = shapeSlice, t.Elt
+			if p, ok := t.Elt.(*ast.StarExpr); ok {
+				shape, elem = shapePointerSlice, p.X
+			}
+		}
+	}
+	kind, err := c.kind(elem, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", names[0], err)
+	}
+	if _, ok := typeNames[a.Data.Type]; !ok && kind != kindGroup && kind != kindRaw {
+		return nil, fmt.Errorf("%s: unsupported data type %q of %s", names[0], a.Data.TypeName, a.Name)
+	}
+	switch {
+	case kind == kindGroup && a.Data.Type != datatype.GroupedType:
+		return nil, fmt.Errorf("%s: %s is not a Grouped AVP", names[0], a.Name)
+	case kind != kindGroup && kind != kindRaw && a.Data.Type == datatype.GroupedType:
+		return nil, fmt.Errorf("%s: %s is a Grouped AVP", names[0], a.Name)
+	case shape == shapePointerSlice && kind != kindGroup && kind != kindRaw:
+		return nil, fmt.Errorf("%s: unsupported type %s", names[0], types.ExprString(f.Type))
+	}
+	var fields []*codecField
+	for _, name := range names {
+		fields = append(fields, &codecField{
+			name:  name,
+			avp:   a,
+			omit:  omit,
+			shape: shape,
+			kind:  kind,
+			elem:  types.ExprString(elem),
+		})
+	}
+	return fields, nil
+}
+
+func isByte(e ast.Expr) bool {
+	id, ok := e.(*ast.Ident)
+	return ok && (id.Name == "byte" || id.Name == "uint8")
+}
+
+// kind returns the kind of the Go type e.
+func (c *codec) kind(e ast.Expr, depth int) (int, error) {
+	switch t := e.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return kindString, nil
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64":
+			return kindNumber, nil
+		}
+		if c.tagged[t.Name] {
+			return kindGroup, nil
+		}
+		if d, ok := c.decls[t.Name]; ok && depth < 8 {
+			if _, ok := d.(*ast.StructType); !ok {
+				return c.kind(d, depth+1)
+			}
+		}
+	case *ast.SelectorExpr:
+		switch types.ExprString(t) {
+		case "diam.AVP":
+			return kindRaw, nil
+		case "net.IP":
+			return kindString, nil
+		case "time.Time":
+			return kindStruct, nil
+		}
+		if x, ok := t.X.(*ast.Ident); ok && x.Name == "datatype" {
+			if kind, ok := datatypeKinds[t.Sel.Name]; ok {
+				return kind, nil
+			}
+		} else if path := c.imports[x.Name]; ok && packages[x.Name] == "" && strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			// Types of packages out of the standard library are tagged
+			// structs with their own methods, such as those of
+			// diam/tgpp/base.
+			return kindGroup, nil
+		}
+	case *ast.ArrayType:
+		if t.Len == nil && isByte(t.Elt) {
+			return kindString, nil
+		}
+	}
+	return 0, fmt.Errorf("unsupported type %s", types.ExprString(e))
+}
+
+// use records the packages of the Go type e used by the methods.
+func (c *codec) use(e string) {
+	for _, part := range strings.FieldsFunc(e, func(r rune) bool { return r == '[' || r == ']' || r == '*' }) {
+		if i := strings.Index(part, "."); i > 0 {
+			c.uses[part[:i]] = true
+		}
+	}
+}
+
+// write writes the methods of the tagged structs to b.
+func (c *codec) write(b *bytes.Buffer) {
+	for _, s := range c.structs {
+		c.writeMarshal(b, s)
+		c.writeUnmarshal(b, s)
+	}
+}
+
+// avpArgs returns the code, flags and vendor arguments of diam.NewAVP
+// for the AVP a, with the flags Message.Marshal sets.
+func (c *codec) avpArgs(a *dict.AVP) string {
+	var flags []string
+	if strings.Contains(a.Must, "M") {
+		flags = append(flags, "avp.Mbit")
+	}
+	if a.VendorID > 0 {
+		flags = append(flags, "avp.Vbit")
+	}
+	if len(flags) == 0 {
+		return fmt.Sprintf("%d, 0, %d", a.Code, a.VendorID)
+	}
+	c.uses["avp"] = true
+	return fmt.Sprintf("%d, %s, %d", a.Code, strings.Join(flags, "|"), a.VendorID)
+}
+
+// dataType returns the datatype type of the data of the AVP a.
+func dataType(a *dict.AVP) string {
+	return "datatype." + typeNames[a.Data.Type]
+}
+
+func (c *codec) writeMarshal(b *bytes.Buffer, s *codecStruct) {
+	c.uses["diam"] = true
+	fmt.Fprintf(b, "\n// MarshalAVP implements diam.AVPMarshaler.\n")
+	fmt.Fprintf(b, "func (s *%s) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {\n", s.name)
+	fmt.Fprintf(b, "avps := make([]*diam.AVP, 0, %d)\n", len(s.fields))
+	for _, f := range s.fields {
+		v := "s." + f.name
+		if f.kind == kindEmbedded {
+			fmt.Fprintf(b, "{\ne, err := %s.MarshalAVP(m)\nif err != nil {\nreturn nil, err\n}\navps = append(avps, e...)\n}\n", v)
+			continue
+		}
+		args := c.avpArgs(f.avp)
+		switch f.kind {
+		case kindRaw:
+			switch f.shape {
+			case shapeValue:
+				fmt.Fprintf(b, "{\na := %s\navps = append(avps, &a)\n}\n", v)
+			case shapePointer:
+				fmt.Fprintf(b, "if %s != nil {\navps = append(avps, %s)\n}\n", v, v)
+			case shapeSlice:
+				fmt.Fprintf(b, "for i := range %s {\navps = append(avps, &%s[i])\n}\n", v, v)
+			case shapePointerSlice:
+				fmt.Fprintf(b, "avps = append(avps, %s...)\n", v)
+			}
+		case kindGroup:
+			group := "{\ng, err := %s.MarshalAVP(m)\nif err != nil {\nreturn nil, err\n}\n" +
+				"avps = append(avps, diam.NewAVP(" + args + ", &diam.GroupedAVP{AVP: g}))\n}\n"
+			switch f.shape {
+			case shapeValue:
+				fmt.Fprintf(b, group, v)
+			case shapePointer:
+				fmt.Fprintf(b, "if %s != nil ", v)
+				fmt.Fprintf(b, group, v)
+			case shapeSlice:
+				fmt.Fprintf(b, "for i := range %s ", v)
+				fmt.Fprintf(b, group, v+"[i]")
+			case shapePointerSlice:
+				fmt.Fprintf(b, "for _, v := range %s ", v)
+				fmt.Fprintf(b, group, "v")
+			}
+		default:
+			data := dataType(f.avp)
+			c.uses["datatype"] = true
+			switch f.shape {
+			case shapeValue:
+				add := fmt.Sprintf("avps = append(avps, diam.NewAVP(%s, %s(%s)))\n", args, data, v)
+				switch {
+				case f.omit && f.kind == kindString:
+					fmt.Fprintf(b, "if len(%s) > 0 {\n%s}\n", v, add)
+				case f.omit && f.kind == kindNumber:
+					fmt.Fprintf(b, "if %s != 0 {\n%s}\n", v, add)
+				default:
+					b.WriteString(add)
+				}
+			case shapePointer:
+				fmt.Fprintf(b, "if %s != nil {\navps = append(avps, diam.NewAVP(%s, %s(*%s)))\n}\n", v, args, data, v)
+			case shapeSlice:
+				fmt.Fprintf(b, "for _, v := range %s {\navps = append(avps, diam.NewAVP(%s, %s(v)))\n}\n", v, args, data)
+			}
+		}
+	}
+	b.WriteString("return avps, nil\n}\n")
+}
+
+func (c *codec) writeUnmarshal(b *bytes.Buffer, s *codecStruct) {
+	fmt.Fprintf(b, "\n// UnmarshalAVP implements diam.AVPUnmarshaler.\n")
+	fmt.Fprintf(b, "func (s *%s) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {\n", s.name)
+	// Fields are decoded from the first AVP of their code and vendor, or
+	// from all of them for slices.
+	var keys []uint64
+	byKey := make(map[uint64][]*codecField)
+	single := make(map[*codecField]int)
+	for _, f := range s.fields {
+		v := "s." + f.name
+		switch {
+		case f.kind == kindEmbedded:
+			fmt.Fprintf(b, "if err := %s.UnmarshalAVP(m, avps); err != nil {\nreturn err\n}\n", v)
+			continue
+		case f.shape == shapeSlice || f.shape == shapePointerSlice:
+			fmt.Fprintf(b, "%s = %s[:0]\n", v, v)
+		default:
+			single[f] = len(single)
+		}
+		key := uint64(f.avp.VendorID)<<32 | uint64(f.avp.Code)
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], f)
+	}
+	if len(keys) == 0 {
+		b.WriteString("return nil\n}\n")
+		return
+	}
+	if len(single) > 0 {
+		fmt.Fprintf(b, "var seen [%d]bool\n", len(single))
+	}
+	b.WriteString("for _, a := range avps {\nswitch uint64(a.VendorID)<<32 | uint64(a.Code) {\n")
+	for _, key := range keys {
+		fields := byKey[key]
+		a := fields[0].avp
+		if a.VendorID > 0 {
+			fmt.Fprintf(b, "case %d<<32 | %d: // %s\n", a.VendorID, a.Code, a.Name)
+		} else {
+			fmt.Fprintf(b, "case %d: // %s\n", a.Code, a.Name)
+		}
+		for _, f := range fields {
+			c.writeDecode(b, f, single)
+		}
+	}
+	b.WriteString("}\n}\nreturn nil\n}\n")
+}
+
+// writeDecode writes the decoding of the AVP a of the switch of
+// writeUnmarshal into the field f.
+func (c *codec) writeDecode(b *bytes.Buffer, f *codecField, single map[*codecField]int) {
+	v := "s." + f.name
+	i, once := single[f]
+	cond, mark := "", ""
+	if once {
+		cond = fmt.Sprintf(" && !seen[%d]", i)
+		mark = fmt.Sprintf("seen[%d] = true\n", i)
+	}
+	c.use(f.elem)
+	switch f.kind {
+	case kindRaw:
+		if once {
+			fmt.Fprintf(b, "if !seen[%d] {\n%s", i, mark)
+		}
+		switch f.shape {
+		case shapeValue:
+			fmt.Fprintf(b, "%s = *a\n", v)
+		case shapePointer:
+			fmt.Fprintf(b, "%s = a\n", v)
+		case shapeSlice:
+			fmt.Fprintf(b, "%s = append(%s, *a)\n", v, v)
+		case shapePointerSlice:
+			fmt.Fprintf(b, "%s = append(%s, a)\n", v, v)
+		}
+		if once {
+			b.WriteString("}\n")
+		}
+	case kindGroup:
+		fmt.Fprintf(b, "if g, ok := a.Data.(*diam.GroupedAVP); ok%s {\n%s", cond, mark)
+		decode := "if err := %s.UnmarshalAVP(m, g.AVP); err != nil {\nreturn err\n}\n"
+		switch f.shape {
+		case shapeValue:
+			fmt.Fprintf(b, decode, v)
+		case shapePointer:
+			fmt.Fprintf(b, "%s = new(%s)\n", v, f.elem)
+			fmt.Fprintf(b, decode, v)
+		case shapeSlice:
+			fmt.Fprintf(b, "var e %s\n", f.elem)
+			fmt.Fprintf(b, decode, "e")
+			fmt.Fprintf(b, "%s = append(%s, e)\n", v, v)
+		case shapePointerSlice:
+			fmt.Fprintf(b, "e := new(%s)\n", f.elem)
+			fmt.Fprintf(b, decode, "e")
+			fmt.Fprintf(b, "%s = append(%s, e)\n", v, v)
+		}
+		b.WriteString("}\n")
+	default:
+		c.uses["datatype"] = true
+		fmt.Fprintf(b, "if v, ok := a.Data.(%s); ok%s {\n%s", dataType(f.avp), cond, mark)
+		switch f.shape {
+		case shapeValue:
+			fmt.Fprintf(b, "%s = %s(v)\n", v, f.elem)
+		case shapePointer:
+			fmt.Fprintf(b, "e := %s(v)\n%s = &e\n", f.elem, v)
+		case shapeSlice:
+			fmt.Fprintf(b, "%s = append(%s, %s(v))\n", v, v, f.elem)
+		}
+		b.WriteString("}\n")
+	}
+}
+
+// generateCodec returns the gofmt'ed Go source of the MarshalAVP and
+// UnmarshalAVP methods of the tagged structs of the Go files, in their
+// package, with their tags resolved in the application appID.
+func generateCodec(p *dict.Parser, files []string, appID uint32) ([]byte, error) {
+	fset := token.NewFileSet()
+	var parsed []*ast.File
+	for _, name := range files {
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		if len(parsed) > 0 && f.Name.Name != parsed[0].Name.Name {
+			return nil, fmt.Errorf("%s: package %s is not %s", name, f.Name.Name, parsed[0].Name.Name)
+		}
+		parsed = append(parsed, f)
+	}
+	if len(parsed) == 0 {
+		return nil, fmt.Errorf("no source files")
+	}
+	c, err := newCodec(p, parsed, func(string) (uint32, bool) { return appID, false })
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	c.write(&b)
+	return formatFile(parsed[0].Name.Name, c.uses, b.Bytes(), c.imports)
+}
@@ -0,0 +1,261 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+var testDict = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+    <application id="16777999" type="auth" name="Example">
+        <vendor id="99999" name="Example"/>
+        <command code="8388999" short="EX" name="Example-Check">
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Example-Flags" required="true" max="1"/>
+                <rule avp="Example-Info" required="false" max="1"/>
+                <rule avp="Example-Missing" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Result-Code" required="true" max="1"/>
+                <rule avp="Example-Info" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+            </answer>
+        </command>
+        <avp name="Example-Flags" code="5000" must="V" may="P" must-not="M" may-encrypt="N" vendor-id="99999">
+            <data type="Unsigned32">
+                <item code="0" name="FIRST_BIT"/>
+                <item code="3" name="Fourth-Bit"/>
+            </data>
+        </avp>
+        <avp name="Example-Status" code="5001" must="V" may="P" must-not="M" may-encrypt="N" vendor-id="99999">
+            <data type="Enumerated">
+                <item code="0" name="OK"/>
+                <item code="1" name="3GPP-BROKEN"/>
+            </data>
+        </avp>
+        <avp name="Example-Info" code="5002" must="V" may="P" must-not="M" may-encrypt="N" vendor-id="99999">
+            <data type="Grouped">
+                <rule avp="Example-Status" required="true" max="1"/>
+                <rule avp="Example-Info" required="false" max="1"/>
+            </data>
+        </avp>
+    </application>
+</diameter>
+`
+
+func TestGen(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "example.xml")
+	if err := os.WriteFile(name, []byte(testDict), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	o := &options{
+		pkg:   "example",
+		apps:  "16777999",
+		files: []string{filepath.Join("..", "..", "diam", "dict", "testdata", "base.xml"), name},
+	}
+	if err := gen(&b, o); err != nil {
+		t.Fatal(err)
+	}
+	// Compare without the alignment of gofmt.
+	src := strings.Join(strings.Fields(b.String()), " ")
+	for _, want := range []string{
+		"// Code generated by diam-gen. DO NOT EDIT.\n\npackage example\n",
+		"type EXR struct {",
+		"SessionID    datatype.UTF8String       `avp:\"Session-Id\"`",
+		"ExampleFlags ExampleFlags              `avp:\"Example-Flags\"`",
+		"ExampleInfo  *ExampleInfo              `avp:\"Example-Info,omitempty\"`",
+		"// Example-Missing is not in the dictionary.",
+		"func NewEXR(sessionID datatype.UTF8String, originHost datatype.DiameterIdentity, exampleFlags ExampleFlags) *EXR {",
+		"ExampleInfo []ExampleInfo `avp:\"Example-Info,omitempty\"`",
+		"FailedAVP   *diam.AVP     `avp:\"Failed-AVP,omitempty\"`",
+		"// ExampleInfo is the Example-Info grouped AVP (code 5002, vendor 99999).",
+		"ExampleInfo   *ExampleInfo  `avp:\"Example-Info,omitempty\"`",
+		"func NewExampleInfo(exampleStatus ExampleStatus) *ExampleInfo {",
+		"ExampleStatusOk         ExampleStatus = 0",
+		"ExampleStatus3gppBroken ExampleStatus = 1",
+		"return \"3GPP-BROKEN\"",
+		"ExampleFlagsFirstBit  ExampleFlags = 1 << 0",
+		"ExampleFlagsFourthBit ExampleFlags = 1 << 3",
+		"func (f ExampleFlags) Has(mask ExampleFlags) bool {",
+	} {
+		if !strings.Contains(src, strings.Join(strings.Fields(want), " ")) {
+			t.Fatalf("Missing %s in:\n%s", want, b.String())
+		}
+	}
+
+	for _, o := range []*options{
+		{apps: "4", base: true},
+		{pkg: "my-package", apps: "4", base: true},
+		{pkg: "example", apps: "x", base: true},
+		{pkg: "example", apps: "16777999", base: true},
+		{pkg: "example", base: true}, // Short names of commands clash across applications.
+	} {
+		if err := gen(new(bytes.Buffer), o); err == nil {
+			t.Fatalf("Invalid generation was accepted: %+v", o)
+		}
+	}
+}
+
+// TestGenerate_S13 checks that the S13 example is up to date.
+func TestGenerate_S13(t *testing.T) {
+	want, err := os.ReadFile(filepath.Join("..", "..", "examples", "s13", "s13.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(dict.Default, "s13", true, 16777252)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Fatalf("examples/s13/s13.go is out of date, run go generate:\n%s", src)
+	}
+}
+
+func TestNames(t *testing.T) {
+	for _, tc := range [][3]string{
+		{"Session-Id", "SessionID", "sessionID"},
+		{"RAT-Type", "RATType", "ratType"},
+		{"MSISDN", "MSISDN", "msisdn"},
+		{"3GPP-IMSI", "TGPPIMSI", "tgppimsi"},
+		{"E-UTRAN-Vector", "EUTRANVector", "eutranVector"},
+		{"Type", "Type", "typeValue"},
+		{"802.1Q", "X8021Q", "x8021Q"},
+	} {
+		name := goName(tc[0])
+		if name != tc[1] {
+			t.Fatalf("Unexpected Go name of %s: %s", tc[0], name)
+		}
+		if param := paramName(name); param != tc[2] {
+			t.Fatalf("Unexpected parameter name of %s: %s", name, param)
+		}
+	}
+}
+
+var testSource = `package example
+
+import (
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+type Status int32
+
+type Result = base.ExperimentalResult
+
+type Remote struct {
+	Info    *base.ExperimentalResult ` + "`avp:\"Example-Info,omitempty\"`" + `
+	Results []Result                 ` + "`avp:\"Example-Info\"`" + `
+}
+
+type Common struct {
+	OriginHost, OriginRealm string ` + "`avp:\"Origin-Host\"`" + `
+}
+
+type Check struct {
+	Common
+	Status   *Status                     ` + "`avp:\"Example-Status,omitempty\"`" + `
+	Flags    uint32                      ` + "`avp:\"Example-Flags,omitempty\"`" + `
+	Info     []*Info                     ` + "`avp:\"Example-Info\"`" + `
+	Stamp    time.Time                   ` + "`avp:\"Event-Timestamp\"`" + `
+	State    []byte                      ` + "`avp:\"Proxy-State,omitempty\"`" + `
+	Failed   *diam.AVP                   ` + "`avp:\"Failed-AVP\"`" + `
+	Hosts    []datatype.DiameterIdentity ` + "`avp:\"Route-Record\"`" + `
+	untagged int
+}
+
+type Info struct {
+	Status Status ` + "`avp:\"Example-Status\"`" + `
+}
+
+type Client struct {
+	Host string
+}
+`
+
+func TestGenerateCodec(t *testing.T) {
+	dir := t.TempDir()
+	name := filepath.Join(dir, "example.xml")
+	if err := os.WriteFile(name, []byte(testDict), 0600); err != nil {
+		t.Fatal(err)
+	}
+	source := filepath.Join(dir, "example.go")
+	if err := os.WriteFile(source, []byte(testSource), 0600); err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := gen(&b, &options{apps: "16777999", base: true, source: []string{source}, files: []string{name}}); err != nil {
+		t.Fatal(err)
+	}
+	src := strings.Join(strings.Fields(b.String()), " ")
+	for _, want := range []string{
+		"package example",
+		"import ( \"time\" \"github.com/fiorix/go-diameter/v4/diam\" \"github.com/fiorix/go-diameter/v4/diam/avp\" \"github.com/fiorix/go-diameter/v4/diam/datatype\" \"github.com/fiorix/go-diameter/v4/diam/tgpp/base\" )",
+		"func (s *Common) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {",
+		"avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))",
+		"case 264: // Origin-Host if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[0] { seen[0] = true s.OriginHost = string(v) } " +
+			"if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[1] { seen[1] = true s.OriginRealm = string(v) }",
+		"e, err := s.Common.MarshalAVP(m)",
+		"if err := s.Common.UnmarshalAVP(m, avps); err != nil {",
+		"if s.Status != nil { avps = append(avps, diam.NewAVP(5001, avp.Vbit, 99999, datatype.Enumerated(*s.Status))) }",
+		"case 99999<<32 | 5001: // Example-Status if v, ok := a.Data.(datatype.Enumerated); ok && !seen[0] { seen[0] = true e := Status(v) s.Status = &e }",
+		"if s.Flags != 0 {",
+		"for _, v := range s.Info { g, err := v.MarshalAVP(m)",
+		"e := new(Info) if err := e.UnmarshalAVP(m, g.AVP); err != nil { return err } s.Info = append(s.Info, e)",
+		"avps = append(avps, diam.NewAVP(55, avp.Mbit, 0, datatype.Time(s.Stamp)))",
+		"s.Stamp = time.Time(v)",
+		"if len(s.State) > 0 {",
+		"if s.Failed != nil { avps = append(avps, s.Failed) }",
+		"s.Failed = a",
+		"s.Hosts = s.Hosts[:0]",
+		"func (s *Info) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {",
+		"if s.Info != nil { g, err := s.Info.MarshalAVP(m)",
+		"s.Info = new(base.ExperimentalResult) if err := s.Info.UnmarshalAVP(m, g.AVP); err != nil {",
+		"var e Result if err := e.UnmarshalAVP(m, g.AVP); err != nil { return err } s.Results = append(s.Results, e)",
+	} {
+		if !strings.Contains(src, want) {
+			t.Fatalf("Missing %s in:\n%s", want, b.String())
+		}
+	}
+	for _, unwanted := range []string{"untagged", "Client"} {
+		if strings.Contains(src, unwanted) {
+			t.Fatalf("Unexpected %s in:\n%s", unwanted, b.String())
+		}
+	}
+
+	for _, bad := range []string{
+		"package example\ntype X struct { A map[string]int `avp:\"Origin-Host\"` }",
+		"package example\ntype X struct { A string `avp:\"Example-Info\"` }",
+		"package example\ntype Y struct{}\ntype X struct { A Y `avp:\"Example-Info\"` }",
+		"package example\ntype X struct { A string `avp:\"Example-Missing\"` }",
+		"package example\ntype X struct { A []*string `avp:\"Origin-Host\"` }",
+		"package example\nimport \"time\"\ntype X struct { A time.Duration `avp:\"Example-Info\"` }",
+		"package example\nimport dm \"github.com/fiorix/go-diameter/v4/diam\"\ntype X struct { A *dm.AVP `avp:\"Failed-AVP\"` }",
+	} {
+		if err := os.WriteFile(source, []byte(bad), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := gen(new(bytes.Buffer), &options{apps: "16777999", base: true, source: []string{source}, files: []string{name}}); err == nil {
+			t.Fatalf("Invalid source was accepted: %s", bad)
+		}
+	}
+	if err := gen(new(bytes.Buffer), &options{apps: "0,4", source: []string{source}}); err == nil {
+		t.Fatal("-source with several applications was accepted")
+	}
+}
@@ -0,0 +1,540 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// wildcardAVP is the name of the *[ AVP ] rule, which is not a field.
+const wildcardAVP = "AVP"
+
+// typeNames are the names of the datatype types, indexed by their id.
+var typeNames = make(map[datatype.TypeID]string)
+
+func init() {
+	for name, id := range datatype.Available {
+		typeNames[id] = name
+	}
+}
+
+// generator writes the Go types of the commands of a set of dictionary
+// applications and of the AVPs they use.
+type generator struct {
+	p       *dict.Parser
+	names   map[string]string // Go names in use, and what they name
+	types   map[string]string // Go types of AVPs, indexed by AVP name
+	structs []*genStruct      // Commands, in dictionary order
+	grouped []*genStruct      // Grouped AVPs
+	enums   []*dict.AVP       // Enumerated AVPs
+	flags   []*dict.AVP       // Bitmask AVPs
+	queue   []genAVP          // Grouped AVPs waiting for their fields
+	apps    map[string]uint32 // Applications of the struct types
+	imports map[string]bool   // Imported packages
+}
+
+// genAVP is a Grouped AVP of an application.
+type genAVP struct {
+	appID uint32
+	avp   *dict.AVP
+}
+
+// genStruct is a generated struct type and its constructor.
+type genStruct struct {
+	name    string
+	doc     string
+	fields  []*genField
+	missing []string // AVPs of the rules not in the dictionary
+}
+
+// genField is a field of a genStruct.
+type genField struct {
+	name     string
+	typ      string
+	avp      string
+	required bool
+	omit     bool
+}
+
+// generate returns the gofmt'ed Go source of the types of the commands
+// of the applications ids of p, or of all applications but the base one
+// with no ids, in the package pkg, and of their MarshalAVP and
+// UnmarshalAVP methods if codec is set.
+func generate(p *dict.Parser, pkg string, codec bool, ids ...uint32) ([]byte, error) {
+	if !token.IsIdentifier(pkg) {
+		return nil, fmt.Errorf("invalid package name: %q", pkg)
+	}
+	g := &generator{
+		p:       p,
+		names:   make(map[string]string),
+		types:   make(map[string]string),
+		apps:    make(map[string]uint32),
+		imports: make(map[string]bool),
+	}
+	if len(ids) == 0 {
+		seen := make(map[uint32]bool)
+		for _, app := range p.Apps() {
+			if app.ID != 0 && !seen[app.ID] {
+				seen[app.ID] = true
+				ids = append(ids, app.ID)
+			}
+		}
+	}
+	for _, id := range ids {
+		if _, err := p.App(id); err != nil {
+			return nil, fmt.Errorf("application %d: %w", id, err)
+		}
+		if err := g.commands(id); err != nil {
+			return nil, err
+		}
+	}
+	for len(g.queue) > 0 {
+		q := g.queue[0]
+		g.queue = g.queue[1:]
+		s, err := g.newStruct(q.appID, g.types[q.avp.Name], q.avp.Data.Rule)
+		if err != nil {
+			return nil, err
+		}
+		s.doc = fmt.Sprintf("%s is the %s grouped AVP (%s).", s.name, q.avp.Name, avpCode(q.avp))
+		g.grouped = append(g.grouped, s)
+		g.apps[s.name] = q.appID
+	}
+	sort.Slice(g.grouped, func(i, j int) bool { return g.grouped[i].name < g.grouped[j].name })
+	sort.Slice(g.enums, func(i, j int) bool { return g.types[g.enums[i].Name] < g.types[g.enums[j].Name] })
+	sort.Slice(g.flags, func(i, j int) bool { return g.types[g.flags[i].Name] < g.types[g.flags[j].Name] })
+
+	var b bytes.Buffer
+	g.write(&b)
+	if !codec {
+		return formatFile(pkg, g.imports, b.Bytes())
+	}
+	// The methods are those of the tagged structs of the types.
+	f, err := parser.ParseFile(token.NewFileSet(), "", "package "+pkg+"\n"+b.String(), 0)
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %v", err)
+	}
+	c, err := newCodec(p, []*ast.File{f}, func(name string) (uint32, bool) {
+		id, ok := g.apps[name]
+		return id, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.write(&b)
+	for name := range c.uses {
+		g.imports[name] = true
+	}
+	return formatFile(pkg, g.imports, b.Bytes())
+}
+
+// packages are the import paths of the packages used by generated code.
+var packages = map[string]string{
+	"strconv":  "strconv",
+	"diam":     "github.com/fiorix/go-diameter/v4/diam",
+	"avp":      "github.com/fiorix/go-diameter/v4/diam/avp",
+	"datatype": "github.com/fiorix/go-diameter/v4/diam/datatype",
+}
+
+// formatFile returns the gofmt'ed generated file of the package pkg with
+// the declarations of body, importing the packages named in imports,
+// whose paths are in packages or paths.
+func formatFile(pkg string, imports map[string]bool, body []byte, paths ...map[string]string) ([]byte, error) {
+	var std, other []string
+	for name := range imports {
+		path, ok := packages[name]
+		for _, p := range paths {
+			if !ok {
+				path, ok = p[name]
+			}
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown package %s", name)
+		}
+		imp := strconv.Quote(path)
+		if name != path[strings.LastIndex(path, "/")+1:] {
+			imp = name + " " + imp
+		}
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, imp)
+		} else {
+			std = append(std, imp)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	var b bytes.Buffer
+	b.WriteString("// Code generated by diam-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n", pkg)
+	if len(imports) > 0 {
+		b.WriteString("\nimport (\n")
+		for _, imp := range std {
+			fmt.Fprintf(&b, "%s\n", imp)
+		}
+		if len(std) > 0 && len(other) > 0 {
+			b.WriteString("\n")
+		}
+		for _, imp := range other {
+			fmt.Fprintf(&b, "%s\n", imp)
+		}
+		b.WriteString(")\n")
+	}
+	b.Write(body)
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("generated code is invalid: %v", err)
+	}
+	return src, nil
+}
+
+// commands adds the request and answer types of the commands of the
+// application id.
+func (g *generator) commands(id uint32) error {
+	seen := make(map[uint32]bool)
+	for _, app := range g.p.Apps() {
+		if app.ID != id {
+			continue
+		}
+		for _, c := range app.Command {
+			if seen[c.Code] {
+				continue
+			}
+			seen[c.Code] = true
+			// Later definitions replace earlier ones.
+			cmd, err := g.p.FindCommand(id, c.Code)
+			if err != nil {
+				return err
+			}
+			for _, msg := range []struct {
+				suffix string
+				rules  []*dict.Rule
+			}{
+				{"Request", cmd.Request.Rule},
+				{"Answer", cmd.Answer.Rule},
+			} {
+				name := goName(cmd.Name) + msg.suffix
+				if len(cmd.Short) > 0 {
+					name = goName(cmd.Short) + msg.suffix[:1]
+				}
+				if err := g.declare(name, cmd.Name+"-"+msg.suffix); err != nil {
+					return err
+				}
+				s, err := g.newStruct(id, name, msg.rules)
+				if err != nil {
+					return err
+				}
+				s.doc = fmt.Sprintf("%s is the %s-%s (code %d, application %d).",
+					name, cmd.Name, msg.suffix, cmd.Code, id)
+				g.structs = append(g.structs, s)
+				g.apps[name] = id
+			}
+		}
+	}
+	return nil
+}
+
+// declare reserves the Go name for the dictionary item what, and fails
+// if it already names something else.
+func (g *generator) declare(name, what string) error {
+	if prev, ok := g.names[name]; ok && prev != what {
+		return fmt.Errorf("Go name %s of %s is already used by %s", name, what, prev)
+	}
+	g.names[name] = what
+	return nil
+}
+
+// newStruct returns a struct type with a field for each of the rules,
+// resolved in the application appID.
+func (g *generator) newStruct(appID uint32, name string, rules []*dict.Rule) (*genStruct, error) {
+	s := &genStruct{name: name}
+	fields := make(map[string]string)
+	for _, rule := range rules {
+		if rule.AVP == wildcardAVP {
+			continue
+		}
+		a, err := g.p.FindAVP(appID, rule.AVP)
+		if err != nil {
+			s.missing = append(s.missing, rule.AVP)
+			continue
+		}
+		f := &genField{name: goName(a.Name), avp: a.Name, required: rule.Required}
+		if prev, ok := fields[f.name]; ok {
+			if prev == a.Name {
+				continue
+			}
+			return nil, fmt.Errorf("%s: field %s of %s is already used by %s", name, f.name, a.Name, prev)
+		}
+		fields[f.name] = a.Name
+		typ, ref, err := g.goType(appID, a)
+		if err != nil {
+			return nil, err
+		}
+		f.typ, f.omit = typ, !rule.Required
+		switch {
+		case rule.Max != 1:
+			f.typ = "[]" + typ
+		case ref == byPointer, ref == optionalByPointer && !rule.Required:
+			f.typ = "*" + typ
+		}
+		s.fields = append(s.fields, f)
+	}
+	return s, nil
+}
+
+// How single values of AVPs are held in struct fields.
+const (
+	byValue           = iota // Empty values are omitted, like strings
+	byPointer                // Grouped AVPs, which may nest
+	optionalByPointer        // Types whose zero values are valid data
+)
+
+// goType returns the Go type of the values of the AVP a, and how single
+// values of it are held.
+func (g *generator) goType(appID uint32, a *dict.AVP) (string, int, error) {
+	switch a.Data.Type {
+	case datatype.GroupedType:
+		if !hasFields(a.Data.Rule) {
+			g.imports["diam"] = true
+			return "diam.AVP", byPointer, nil
+		}
+		name, added, err := g.avpType(a)
+		if added {
+			g.queue = append(g.queue, genAVP{appID, a})
+		}
+		return name, byPointer, err
+	case datatype.EnumeratedType:
+		if len(a.Data.Enum) > 0 {
+			name, added, err := g.avpType(a)
+			if added {
+				g.enums = append(g.enums, a)
+			}
+			return name, optionalByPointer, err
+		}
+	case datatype.Unsigned32Type, datatype.Unsigned64Type:
+		if len(a.Data.Enum) > 0 || strings.HasSuffix(a.Name, "-Flags") {
+			name, added, err := g.avpType(a)
+			if added {
+				g.flags = append(g.flags, a)
+			}
+			return name, optionalByPointer, err
+		}
+	}
+	name, ok := typeNames[a.Data.Type]
+	if !ok {
+		return "", 0, fmt.Errorf("%s: unsupported data type %q", a.Name, a.Data.TypeName)
+	}
+	g.imports["datatype"] = true
+	switch a.Data.Type {
+	case datatype.AddressType, datatype.IPv4Type, datatype.IPv6Type,
+		datatype.OctetStringType, datatype.UTF8StringType,
+		datatype.DiameterIdentityType, datatype.DiameterURIType,
+		datatype.IPFilterRuleType, datatype.QoSFilterRuleType:
+		return "datatype." + name, byValue, nil
+	}
+	return "datatype." + name, optionalByPointer, nil
+}
+
+// avpType returns the Go type of the AVP a, and whether this call added
+// it, on first use of the AVP.
+func (g *generator) avpType(a *dict.AVP) (string, bool, error) {
+	if name, ok := g.types[a.Name]; ok {
+		return name, false, nil
+	}
+	name := goName(a.Name)
+	if err := g.declare(name, a.Name); err != nil {
+		return "", false, err
+	}
+	g.types[a.Name] = name
+	return name, true, nil
+}
+
+// hasFields returns whether rules has rules other than *[ AVP ].
+func hasFields(rules []*dict.Rule) bool {
+	for _, rule := range rules {
+		if rule.AVP != wildcardAVP {
+			return true
+		}
+	}
+	return false
+}
+
+func avpCode(a *dict.AVP) string {
+	if a.VendorID != 0 {
+		return fmt.Sprintf("code %d, vendor %d", a.Code, a.VendorID)
+	}
+	return fmt.Sprintf("code %d", a.Code)
+}
+
+// write writes the declarations of the types to b.
+func (g *generator) write(b *bytes.Buffer) {
+	if len(g.enums) > 0 {
+		g.imports["strconv"] = true
+	}
+	for _, s := range g.structs {
+		s.write(b)
+	}
+	for _, s := range g.grouped {
+		s.write(b)
+	}
+	for _, a := range g.enums {
+		g.writeEnum(b, a)
+	}
+	for _, a := range g.flags {
+		g.writeFlags(b, a)
+	}
+}
+
+func (s *genStruct) write(b *bytes.Buffer) {
+	fmt.Fprintf(b, "\n// %s\ntype %s struct {\n", s.doc, s.name)
+	for _, f := range s.fields {
+		tag := f.avp
+		if f.omit {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(b, "%s %s `avp:%q`\n", f.name, f.typ, tag)
+	}
+	for _, name := range s.missing {
+		fmt.Fprintf(b, "// %s is not in the dictionary.\n", name)
+	}
+	b.WriteString("}\n")
+
+	var params, values []string
+	for _, f := range s.fields {
+		if f.required {
+			param := paramName(f.name)
+			params = append(params, param+" "+f.typ)
+			values = append(values, f.name+": "+param+",\n")
+		}
+	}
+	fmt.Fprintf(b, "\n// New%s returns a new %s with the given mandatory AVPs.\n", s.name, s.name)
+	fmt.Fprintf(b, "func New%s(%s) *%s {\n", s.name, strings.Join(params, ", "), s.name)
+	if len(values) == 0 {
+		fmt.Fprintf(b, "return &%s{}\n}\n", s.name)
+		return
+	}
+	fmt.Fprintf(b, "return &%s{\n%s}\n}\n", s.name, strings.Join(values, ""))
+}
+
+func (g *generator) writeEnum(b *bytes.Buffer, a *dict.AVP) {
+	name := g.types[a.Name]
+	fmt.Fprintf(b, "\n// %s is the %s enumerated AVP (%s).\ntype %s int32\n", name, a.Name, avpCode(a), name)
+	fmt.Fprintf(b, "\n// %s values.\nconst (\n", name)
+	seen := make(map[string]bool)
+	for _, item := range a.Data.Enum {
+		c := constName(name, item.Name)
+		if seen[c] {
+			c = fmt.Sprintf("%s%d", c, item.Code)
+		}
+		seen[c] = true
+		fmt.Fprintf(b, "%s %s = %d\n", c, name, item.Code)
+	}
+	b.WriteString(")\n")
+	fmt.Fprintf(b, "\n// String returns the dictionary name of v.\nfunc (v %s) String() string {\nswitch v {\n", name)
+	codes := make(map[int32]bool)
+	for _, item := range a.Data.Enum {
+		if !codes[item.Code] {
+			codes[item.Code] = true
+			fmt.Fprintf(b, "case %d:\nreturn %q\n", item.Code, item.Name)
+		}
+	}
+	b.WriteString("}\nreturn strconv.Itoa(int(v))\n}\n")
+}
+
+func (g *generator) writeFlags(b *bytes.Buffer, a *dict.AVP) {
+	name := g.types[a.Name]
+	base := "uint32"
+	if a.Data.Type == datatype.Unsigned64Type {
+		base = "uint64"
+	}
+	fmt.Fprintf(b, "\n// %s is the %s bitmask AVP (%s).\ntype %s %s\n", name, a.Name, avpCode(a), name, base)
+	if len(a.Data.Enum) > 0 {
+		fmt.Fprintf(b, "\n// %s bits.\nconst (\n", name)
+		seen := make(map[string]bool)
+		for _, item := range a.Data.Enum {
+			c := constName(name, item.Name)
+			if seen[c] {
+				c = fmt.Sprintf("%sBit%d", c, item.Code)
+			}
+			seen[c] = true
+			fmt.Fprintf(b, "%s %s = 1 << %d\n", c, name, item.Code)
+		}
+		b.WriteString(")\n")
+	}
+	fmt.Fprintf(b, "\n// Has returns whether all the bits of mask are set in f.\n")
+	fmt.Fprintf(b, "func (f %s) Has(mask %s) bool {\nreturn f&mask == mask\n}\n", name, name)
+}
+
+// goName returns the exported Go name of a dictionary name, such as
+// SessionID for Session-Id or TGPPIMSI for 3GPP-IMSI.
+func goName(name string) string {
+	s := camelCase(name)
+	if len(s) == 0 || !unicode.IsLetter(rune(s[0])) {
+		s = "X" + s
+	}
+	return s
+}
+
+// camelCase joins the words of name, keeping their case.
+func camelCase(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, isSeparator) {
+		switch {
+		case strings.EqualFold(part, "id"):
+			b.WriteString("ID")
+		case b.Len() == 0 && strings.HasPrefix(part, "3GPP"):
+			b.WriteString("TGPP" + part[4:])
+		default:
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// constName returns the name of the constant of the item of the enum or
+// bitmask typ. Upper case item names are turned into camel case, so that
+// STATE_MAINTAINED of Auth-Session-State is AuthSessionStateStateMaintained.
+func constName(typ, item string) string {
+	if strings.ToUpper(item) != item {
+		return typ + camelCase(item)
+	}
+	var b strings.Builder
+	b.WriteString(typ)
+	for _, part := range strings.FieldsFunc(item, isSeparator) {
+		b.WriteString(part[:1] + strings.ToLower(part[1:]))
+	}
+	return b.String()
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) || r > unicode.MaxASCII
+}
+
+// paramName returns the name of the constructor parameter of the field
+// name, such as sessionID for SessionID or ratType for RATType.
+func paramName(name string) string {
+	n := 0
+	for n < len(name) && unicode.IsUpper(rune(name[n])) {
+		n++
+	}
+	if n > 1 && n < len(name) && unicode.IsLower(rune(name[n])) {
+		n-- // Keep the head of the next word.
+	}
+	s := strings.ToLower(name[:n]) + name[n:]
+	if token.IsKeyword(s) || packages[s] != "" {
+		s += "Value"
+	}
+	return s
+}
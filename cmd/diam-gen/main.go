@@ -0,0 +1,163 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command diam-gen generates typed Go models of Diameter messages from
+// go-diameter dictionaries.
+//
+// The dictionary files given as arguments are loaded on top of the
+// embedded default dictionary, or alone with -default=false, and for
+// each command of the applications listed in -apps, or of all
+// applications but the base one, diam-gen writes:
+//
+//   - a struct type for its request and one for its answer, named after
+//     the short name of the command, such as ULR and ULA, with a field
+//     and an avp tag for each AVP of its rules, for use with
+//     Message.Marshal and Message.Unmarshal;
+//   - a struct type for each Grouped AVP they use;
+//   - an int32 type for each Enumerated AVP they use, with a constant
+//     for each of its items;
+//   - a bitmask type for each Unsigned32 or Unsigned64 AVP named *-Flags
+//     or with items they use, with a constant for each item, whose code
+//     is the number of the bit, such as <item code="0" name="..."/> for
+//     bit 0;
+//   - a constructor function for each struct type, taking its mandatory
+//     AVPs.
+//
+// Optional AVPs are tagged omitempty, and those whose zero value is valid
+// data, such as Enumerated ones, are pointers. AVPs that may occur more
+// than once are slices.
+//
+// The code is written to standard output or to the -o file, and is meant
+// for go generate:
+//
+//	//go:generate go run github.com/fiorix/go-diameter/v4/cmd/diam-gen -apps 16777252 -o s13.go
+//
+// The package name is that of the -package flag, or the $GOPACKAGE set by
+// go generate.
+//
+// With -codec, diam-gen also writes the MarshalAVP and UnmarshalAVP
+// methods of the types, which Message.Marshal and Message.Unmarshal
+// prefer to reflection. With -source, it writes only those methods, for
+// the struct types with avp tags of the given Go files, such as the
+// hand-written models of an application:
+//
+//	//go:generate go run github.com/fiorix/go-diameter/v4/cmd/diam-gen -source message.go -apps 16777251 -o message_codec.go
+//
+// Grouped AVPs may use the struct types of other packages, such as those
+// of diam/tgpp/base, which must have these methods as well.
+//
+// The AVP tags are resolved in the application of -apps, and the codes,
+// flags and data types of the AVPs are written in the methods, which do
+// not look up the dictionary of the message. Unlike reflection, they
+// decode an AVP into a field only if its vendor matches, and reset slices
+// before decoding.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+func main() {
+	pkg := flag.String("package", os.Getenv("GOPACKAGE"), "name of the package of the generated code")
+	apps := flag.String("apps", "", "comma separated ids of the applications to generate, empty for all")
+	base := flag.Bool("default", true, "load the embedded default dictionary before the files")
+	codec := flag.Bool("codec", false, "also generate the MarshalAVP and UnmarshalAVP methods of the types")
+	source := flag.String("source", "", "comma separated Go files whose tagged structs get the methods, instead of the types")
+	output := flag.String("o", "", "output file, empty for standard output")
+	flag.Parse()
+
+	o := &options{
+		pkg:   *pkg,
+		apps:  *apps,
+		base:  *base,
+		codec: *codec,
+		files: flag.Args(),
+	}
+	if len(*source) > 0 {
+		o.source = strings.Split(*source, ",")
+	}
+	var b bytes.Buffer
+	if err := gen(&b, o); err != nil {
+		log.Fatal(err)
+	}
+	if len(*output) == 0 {
+		os.Stdout.Write(b.Bytes())
+		return
+	}
+	if err := os.WriteFile(*output, b.Bytes(), 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type options struct {
+	pkg    string
+	apps   string
+	base   bool
+	codec  bool
+	source []string
+	files  []string
+}
+
+// gen loads the dictionaries of o and writes the code of o to w.
+func gen(w io.Writer, o *options) error {
+	ids, err := parseApps(o.apps)
+	if err != nil {
+		return err
+	}
+	var p *dict.Parser
+	if o.base {
+		p = dict.Default
+	} else {
+		p, _ = dict.NewParser()
+	}
+	for _, f := range o.files {
+		if err := p.LoadFile(f); err != nil {
+			return err
+		}
+	}
+	var src []byte
+	switch {
+	case len(o.source) > 0 && len(ids) > 1:
+		return fmt.Errorf("-source takes a single application")
+	case len(o.source) > 0:
+		var id uint32
+		if len(ids) > 0 {
+			id = ids[0]
+		}
+		src, err = generateCodec(p, o.source, id)
+	case len(o.pkg) == 0:
+		return fmt.Errorf("missing -package")
+	default:
+		src, err = generate(p, o.pkg, o.codec, ids...)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(src)
+	return err
+}
+
+func parseApps(s string) ([]uint32, error) {
+	var ids []uint32
+	for _, f := range strings.Split(s, ",") {
+		if f = strings.TrimSpace(f); len(f) == 0 {
+			continue
+		}
+		id, err := strconv.ParseUint(f, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid application id: %q", f)
+		}
+		ids = append(ids, uint32(id))
+	}
+	return ids, nil
+}
@@ -0,0 +1,272 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/aka"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+	"gopkg.in/yaml.v3"
+)
+
+// subscriberFile is the format of the subscriber file.
+type subscriberFile struct {
+	Subscribers []subscriberConfig `json:"subscribers" yaml:"subscribers"`
+}
+
+type subscriberConfig struct {
+	IMSI   string `json:"imsi" yaml:"imsi"`
+	MSISDN string `json:"msisdn,omitempty" yaml:"msisdn,omitempty"`
+
+	// Authentication keys in hex. One of OP or OPc is required.
+	K   string `json:"k" yaml:"k"`
+	OP  string `json:"op,omitempty" yaml:"op,omitempty"`
+	OPc string `json:"opc,omitempty" yaml:"opc,omitempty"`
+	AMF string `json:"amf,omitempty" yaml:"amf,omitempty"` // Defaults to 8000.
+	SQN uint64 `json:"sqn,omitempty" yaml:"sqn,omitempty"` // Last SQN used by the HSS.
+
+	// Access restrictions. Empty lists allow everything.
+	RATs  []string `json:"rats,omitempty" yaml:"rats,omitempty"`   // EUTRAN, UTRAN or GERAN.
+	PLMNs []string `json:"plmns,omitempty" yaml:"plmns,omitempty"` // MCC and MNC, as in "00101".
+
+	AMBR *ambrConfig `json:"ambr,omitempty" yaml:"ambr,omitempty"`
+	APNs []apnConfig `json:"apns" yaml:"apns"` // The first APN is the default.
+}
+
+type ambrConfig struct {
+	UL uint32 `json:"ul" yaml:"ul"` // bits per second
+	DL uint32 `json:"dl" yaml:"dl"`
+}
+
+type apnConfig struct {
+	Name     string      `json:"name" yaml:"name"`
+	PDNType  string      `json:"pdn_type,omitempty" yaml:"pdn_type,omitempty"` // ipv4 (default), ipv6, ipv4v6 or ipv4_or_ipv6.
+	QCI      int32       `json:"qci,omitempty" yaml:"qci,omitempty"`           // Defaults to 9.
+	Priority uint32      `json:"priority,omitempty" yaml:"priority,omitempty"` // ARP priority level, defaults to 15.
+	StaticIP string      `json:"static_ip,omitempty" yaml:"static_ip,omitempty"`
+	AMBR     *ambrConfig `json:"ambr,omitempty" yaml:"ambr,omitempty"`
+}
+
+var pdnTypes = map[string]int32{
+	"":             s6a.PDNTypeIPv4,
+	"ipv4":         s6a.PDNTypeIPv4,
+	"ipv6":         s6a.PDNTypeIPv6,
+	"ipv4v6":       s6a.PDNTypeIPv4v6,
+	"ipv4_or_ipv6": s6a.PDNTypeIPv4OrIPv6,
+}
+
+var ratTypes = map[string]int32{
+	"EUTRAN": s6a.RATEUTRAN,
+	"UTRAN":  s6a.RATUTRAN,
+	"GERAN":  s6a.RATGERAN,
+}
+
+// subscriber is a validated subscriberConfig.
+type subscriber struct {
+	imsi  string
+	k     []byte
+	opc   []byte
+	amf   [2]byte
+	sqn   uint64
+	rats  map[int32]bool                // nil allows all.
+	plmns map[datatype.OctetString]bool // nil allows all.
+	data  s6a.SubscriptionData
+}
+
+// loadSubscribers reads and validates the subscriber file, returning
+// subscribers by IMSI. Files named *.yaml or *.yml are read as YAML, and
+// any other as JSON.
+func loadSubscribers(name string) (map[string]*subscriber, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var f subscriberFile
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(b, &f)
+	default:
+		err = json.Unmarshal(b, &f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	subs := make(map[string]*subscriber, len(f.Subscribers))
+	for i := range f.Subscribers {
+		s, err := f.Subscribers[i].parse()
+		if err != nil {
+			return nil, fmt.Errorf("%s: subscriber %d: %v", name, i, err)
+		}
+		if _, dup := subs[s.imsi]; dup {
+			return nil, fmt.Errorf("%s: duplicate subscriber %s", name, s.imsi)
+		}
+		subs[s.imsi] = s
+	}
+	return subs, nil
+}
+
+func (c *subscriberConfig) parse() (*subscriber, error) {
+	if len(c.IMSI) < 6 || len(c.IMSI) > 15 {
+		return nil, fmt.Errorf("invalid imsi %q", c.IMSI)
+	}
+	s := &subscriber{imsi: c.IMSI, sqn: c.SQN}
+	var err error
+	if s.k, err = hex.DecodeString(c.K); err != nil || len(s.k) != 16 {
+		return nil, fmt.Errorf("invalid k for %s", c.IMSI)
+	}
+	switch {
+	case c.OPc != "":
+		if s.opc, err = hex.DecodeString(c.OPc); err != nil || len(s.opc) != 16 {
+			return nil, fmt.Errorf("invalid opc for %s", c.IMSI)
+		}
+	case c.OP != "":
+		op, err := hex.DecodeString(c.OP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid op for %s", c.IMSI)
+		}
+		if s.opc, err = aka.ComputeOPc(s.k, op); err != nil {
+			return nil, fmt.Errorf("invalid op for %s: %v", c.IMSI, err)
+		}
+	default:
+		return nil, fmt.Errorf("missing op or opc for %s", c.IMSI)
+	}
+	s.amf = aka.DefaultAMF
+	if c.AMF != "" {
+		amf, err := hex.DecodeString(c.AMF)
+		if err != nil || len(amf) != 2 {
+			return nil, fmt.Errorf("invalid amf for %s", c.IMSI)
+		}
+		copy(s.amf[:], amf)
+	}
+	if len(c.RATs) > 0 {
+		s.rats = make(map[int32]bool)
+		for _, name := range c.RATs {
+			rat, ok := ratTypes[strings.ToUpper(name)]
+			if !ok {
+				return nil, fmt.Errorf("invalid rat %q for %s", name, c.IMSI)
+			}
+			s.rats[rat] = true
+		}
+	}
+	if len(c.PLMNs) > 0 {
+		s.plmns = make(map[datatype.OctetString]bool)
+		for _, plmn := range c.PLMNs {
+			if len(plmn) < 5 {
+				return nil, fmt.Errorf("invalid plmn %q for %s", plmn, c.IMSI)
+			}
+			id, err := s6a.EncodePLMNID(plmn[:3], plmn[3:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid plmn %q for %s", plmn, c.IMSI)
+			}
+			s.plmns[id] = true
+		}
+	}
+	if s.data, err = c.subscriptionData(); err != nil {
+		return nil, fmt.Errorf("%v for %s", err, c.IMSI)
+	}
+	return s, nil
+}
+
+// subscriptionData returns the Subscription-Data sent in ULA and IDR.
+func (c *subscriberConfig) subscriptionData() (s6a.SubscriptionData, error) {
+	status := int32(s6a.ServiceGranted)
+	mode := int32(s6a.OnlyPacket)
+	data := s6a.SubscriptionData{
+		SubscriberStatus:  &status,
+		NetworkAccessMode: &mode,
+		AMBR:              c.AMBR.avp(),
+	}
+	if c.MSISDN != "" {
+		msisdn, err := encodeTBCD(c.MSISDN)
+		if err != nil {
+			return data, err
+		}
+		data.MSISDN = msisdn
+	}
+	if len(c.APNs) == 0 {
+		return data, nil
+	}
+	profile := &s6a.APNConfigurationProfile{
+		ContextIdentifier:                     1,
+		AllAPNConfigurationsIncludedIndicator: s6a.AllAPNConfigurationsIncluded,
+	}
+	for i, apn := range c.APNs {
+		if apn.Name == "" {
+			return data, fmt.Errorf("apn %d has no name", i)
+		}
+		pdnType, ok := pdnTypes[strings.ToLower(apn.PDNType)]
+		if !ok {
+			return data, fmt.Errorf("invalid pdn_type %q", apn.PDNType)
+		}
+		qci, priority := apn.QCI, apn.Priority
+		if qci == 0 {
+			qci = 9
+		}
+		if priority == 0 {
+			priority = 15
+		}
+		preemptionCapability := int32(1)    // PRE-EMPTION_CAPABILITY_DISABLED
+		preemptionVulnerability := int32(0) // PRE-EMPTION_VULNERABILITY_ENABLED
+		conf := s6a.APNConfiguration{
+			ContextIdentifier: uint32(i + 1),
+			PDNType:           pdnType,
+			ServiceSelection:  apn.Name,
+			EPSSubscribedQoSProfile: &s6a.EPSSubscribedQoSProfile{
+				QCI: qci,
+				AllocationRetentionPriority: s6a.AllocationRetentionPriority{
+					PriorityLevel:           priority,
+					PreemptionCapability:    &preemptionCapability,
+					PreemptionVulnerability: &preemptionVulnerability,
+				},
+			},
+			AMBR: apn.AMBR.avp(),
+		}
+		if apn.StaticIP != "" {
+			ip := net.ParseIP(apn.StaticIP)
+			if ip == nil {
+				return data, fmt.Errorf("invalid static_ip %q", apn.StaticIP)
+			}
+			if ip4 := ip.To4(); ip4 != nil {
+				ip = ip4
+			}
+			conf.ServedPartyIPAddress = []net.IP{ip}
+		}
+		profile.APNConfiguration = append(profile.APNConfiguration, conf)
+	}
+	data.APNConfigurationProfile = profile
+	return data, nil
+}
+
+func (c *ambrConfig) avp() *s6a.AMBR {
+	if c == nil {
+		return nil
+	}
+	return &s6a.AMBR{MaxRequestedBandwidthUL: c.UL, MaxRequestedBandwidthDL: c.DL}
+}
+
+// encodeTBCD encodes a string of digits as TBCD, as used by MSISDN.
+func encodeTBCD(s string) (datatype.OctetString, error) {
+	b := make([]byte, (len(s)+1)/2)
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return "", fmt.Errorf("invalid msisdn %q", s)
+		}
+		d := s[i] - '0'
+		if i%2 == 0 {
+			b[i/2] = 0xf0 | d
+		} else {
+			b[i/2] = b[i/2]&0x0f | d<<4
+		}
+	}
+	return datatype.OctetString(b), nil
+}
@@ -0,0 +1,257 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"bytes"
+	"log"
+	"reflect"
+	"sync"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/aka"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+)
+
+// maxVectors is the maximum number of vectors returned in one AIA.
+const maxVectors = 5
+
+// hss is the s6a.Backend of the simulator. It answers from the
+// subscribers of the subscriber file and keeps the AKA state of each
+// subscriber across reloads.
+type hss struct {
+	server *s6a.Server
+
+	mu          sync.Mutex
+	subscribers map[string]*subscriber
+	auth        map[string]*aka.Subscriber // By IMSI.
+}
+
+func newHSS(server *s6a.Server, subs map[string]*subscriber) (*hss, error) {
+	h := &hss{server: server}
+	if _, err := h.update(subs); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// update replaces the subscribers and returns the ones that were
+// removed or changed, by IMSI. The AKA state of a subscriber is kept
+// when its keys don't change and its configured SQN is not ahead.
+//
+// The aka.Subscriber of a subscriber may be in use by AIR handlers, so
+// it is never modified: a new one, with the current SQN, replaces it when
+// its AMF changes.
+func (h *hss) update(subs map[string]*subscriber) (map[string]*subscriber, error) {
+	auth := make(map[string]*aka.Subscriber, len(subs))
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for imsi, s := range subs {
+		sqn := s.sqn
+		if prev, ok := h.subscribers[imsi]; ok && bytes.Equal(prev.k, s.k) && bytes.Equal(prev.opc, s.opc) {
+			a := h.auth[imsi]
+			if cur := a.SQN(); cur >= sqn {
+				if a.AMF == s.amf {
+					auth[imsi] = a
+					continue
+				}
+				sqn = cur
+			}
+		}
+		a, err := aka.NewSubscriber(s.k, s.opc, sqn)
+		if err != nil {
+			return nil, err
+		}
+		a.AMF = s.amf
+		auth[imsi] = a
+	}
+	changed := make(map[string]*subscriber)
+	for imsi, prev := range h.subscribers {
+		s, ok := subs[imsi]
+		if !ok {
+			changed[imsi] = nil
+		} else if !reflect.DeepEqual(prev.data, s.data) || !reflect.DeepEqual(prev.rats, s.rats) || !reflect.DeepEqual(prev.plmns, s.plmns) {
+			changed[imsi] = s
+		}
+	}
+	h.subscribers, h.auth = subs, auth
+	return changed, nil
+}
+
+// reload applies a new subscriber file. Registered subscribers that were
+// removed, or can no longer use their current RAT or PLMN, have their
+// location cancelled with a CLR. Registered subscribers whose
+// subscription data changed get an IDR.
+func (h *hss) reload(subs map[string]*subscriber) error {
+	changed, err := h.update(subs)
+	if err != nil {
+		return err
+	}
+	for imsi, s := range changed {
+		ulr, ok := h.server.Registration(imsi)
+		if !ok {
+			continue
+		}
+		if s == nil || h.reject(s, ulr.RATType, ulr.VisitedPLMNID) != nil {
+			log.Printf("Cancelling location of %s at %s", imsi, string(ulr.OriginHost))
+			go h.cancelLocation(imsi)
+			continue
+		}
+		log.Printf("Inserting subscriber data of %s at %s", imsi, string(ulr.OriginHost))
+		go h.insertSubscriberData(imsi, s)
+	}
+	return nil
+}
+
+func (h *hss) cancelLocation(imsi string) {
+	cla, err := h.server.CancelLocation(imsi, s6a.SubscriptionWithdrawal)
+	if err != nil {
+		log.Printf("CLR for %s failed: %v", imsi, err)
+		return
+	}
+	if cla.ResultCode != diam.Success {
+		log.Printf("CLR for %s failed: %+v %+v", imsi, cla.ResultCode, cla.ExperimentalResult)
+	}
+}
+
+func (h *hss) insertSubscriberData(imsi string, s *subscriber) {
+	ida, err := h.server.InsertSubscriberData(imsi, &s6a.IDR{SubscriptionData: s.data})
+	if err != nil {
+		log.Printf("IDR for %s failed: %v", imsi, err)
+		return
+	}
+	if ida.ResultCode != diam.Success {
+		log.Printf("IDR for %s failed: %+v %+v", imsi, ida.ResultCode, ida.ExperimentalResult)
+	}
+}
+
+func (h *hss) subscriber(imsi string) (*subscriber, *aka.Subscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.subscribers[imsi], h.auth[imsi]
+}
+
+// reject returns the Experimental-Result for a subscriber that is not
+// allowed to use the RAT or visited PLMN, or nil.
+func (h *hss) reject(s *subscriber, rat int32, plmn datatype.OctetString) *s6a.ExperimentalResult {
+	if s.rats != nil && !s.rats[rat] {
+		return experimentalResult(s6a.RATNotAllowed)
+	}
+	if s.plmns != nil && !s.plmns[plmn] {
+		return experimentalResult(s6a.RoamingNotAllowed)
+	}
+	return nil
+}
+
+func experimentalResult(code uint32) *s6a.ExperimentalResult {
+	return &s6a.ExperimentalResult{VendorID: s6a.Vendor3GPP, Code: code}
+}
+
+// AuthenticationInformation implements the s6a.Backend interface.
+func (h *hss) AuthenticationInformation(air *s6a.AIR, aia *s6a.AIA) {
+	s, a := h.subscriber(air.UserName)
+	if s == nil {
+		log.Printf("AIR from %s for unknown user %s", string(air.OriginHost), air.UserName)
+		aia.ExperimentalResult = experimentalResult(s6a.UserUnknown)
+		return
+	}
+	info := &s6a.AuthenticationInfo{}
+	if req := air.RequestedEUTRANAuthInfo; req != nil {
+		if err := resynchronize(a, req); err != nil {
+			log.Printf("AIR from %s: resynchronization of %s failed: %v", string(air.OriginHost), air.UserName, err)
+			aia.ExperimentalResult = experimentalResult(s6a.AuthenticationDataUnavailable)
+			return
+		}
+		for i := uint32(0); i < numVectors(req); i++ {
+			v, err := a.EUTRANVector([]byte(air.VisitedPLMNID))
+			if err != nil {
+				log.Printf("AIR from %s: E-UTRAN vector for %s: %v", string(air.OriginHost), air.UserName, err)
+				aia.ResultCode = diam.UnableToComply
+				return
+			}
+			v.ItemNumber = i + 1
+			info.EUTRANVector = append(info.EUTRANVector, *v)
+		}
+	}
+	if req := air.RequestedUTRANGERANAuthInfo; req != nil {
+		if err := resynchronize(a, req); err != nil {
+			log.Printf("AIR from %s: resynchronization of %s failed: %v", string(air.OriginHost), air.UserName, err)
+			aia.ExperimentalResult = experimentalResult(s6a.AuthenticationDataUnavailable)
+			return
+		}
+		for i := uint32(0); i < numVectors(req); i++ {
+			v, err := a.UTRANVector()
+			if err != nil {
+				log.Printf("AIR from %s: UTRAN vector for %s: %v", string(air.OriginHost), air.UserName, err)
+				aia.ResultCode = diam.UnableToComply
+				return
+			}
+			v.ItemNumber = i + 1
+			info.UTRANVector = append(info.UTRANVector, *v)
+		}
+	}
+	log.Printf("AIR from %s for %s: %d E-UTRAN and %d UTRAN vectors, SQN %x",
+		string(air.OriginHost), air.UserName, len(info.EUTRANVector), len(info.UTRANVector), a.SQN())
+	aia.AuthenticationInfo = info
+}
+
+func resynchronize(a *aka.Subscriber, req *s6a.RequestedAuthInfo) error {
+	if len(req.ResynchronizationInfo) == 0 {
+		return nil
+	}
+	return a.ResynchronizationInfo([]byte(req.ResynchronizationInfo))
+}
+
+func numVectors(req *s6a.RequestedAuthInfo) uint32 {
+	switch n := req.NumberOfRequestedVectors; {
+	case n == 0:
+		return 1
+	case n > maxVectors:
+		return maxVectors
+	default:
+		return n
+	}
+}
+
+// UpdateLocation implements the s6a.Backend interface.
+func (h *hss) UpdateLocation(ulr *s6a.ULR, ula *s6a.ULA) {
+	s, _ := h.subscriber(ulr.UserName)
+	if s == nil {
+		log.Printf("ULR from %s for unknown user %s", string(ulr.OriginHost), ulr.UserName)
+		ula.ExperimentalResult = experimentalResult(s6a.UserUnknown)
+		return
+	}
+	if er := h.reject(s, ulr.RATType, ulr.VisitedPLMNID); er != nil {
+		log.Printf("ULR from %s for %s rejected with %d", string(ulr.OriginHost), ulr.UserName, er.Code)
+		ula.ExperimentalResult = er
+		return
+	}
+	log.Printf("ULR from %s for %s", string(ulr.OriginHost), ulr.UserName)
+	if ulr.ULRFlags&s6a.ULRSkipSubscriberData == 0 {
+		data := s.data
+		ula.SubscriptionData = &data
+	}
+}
+
+// PurgeUE implements the s6a.Backend interface.
+func (h *hss) PurgeUE(pur *s6a.PUR, pua *s6a.PUA) {
+	if s, _ := h.subscriber(pur.UserName); s == nil {
+		log.Printf("PUR from %s for unknown user %s", string(pur.OriginHost), pur.UserName)
+		pua.ExperimentalResult = experimentalResult(s6a.UserUnknown)
+		return
+	}
+	log.Printf("PUR from %s for %s", string(pur.OriginHost), pur.UserName)
+}
+
+// Notify implements the s6a.Backend interface.
+func (h *hss) Notify(nor *s6a.NOR, noa *s6a.NOA) {
+	if s, _ := h.subscriber(nor.UserName); s == nil {
+		log.Printf("NOR from %s for unknown user %s", string(nor.OriginHost), nor.UserName)
+		noa.ExperimentalResult = experimentalResult(s6a.UserUnknown)
+		return
+	}
+	log.Printf("NOR from %s for %s: flags %#x", string(nor.OriginHost), nor.UserName, nor.NORFlags)
+}
@@ -0,0 +1,262 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+)
+
+func TestLoadSubscribers(t *testing.T) {
+	subs, err := loadSubscribers("subscribers.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(subs) != 2 {
+		t.Fatalf("Unexpected number of subscribers: %d", len(subs))
+	}
+	s := subs["001010000000001"]
+	if s == nil || s.data.MSISDN != "\x55\x11\x99\x99\x09\x00\xf1" {
+		t.Fatalf("Unexpected subscriber: %+v", s)
+	}
+	profile := s.data.APNConfigurationProfile
+	if profile == nil || len(profile.APNConfiguration) != 2 || profile.APNConfiguration[1].ContextIdentifier != 2 {
+		t.Fatalf("Unexpected APN profile: %+v", profile)
+	}
+	if apn := profile.APNConfiguration[1]; apn.PDNType != s6a.PDNTypeIPv4v6 || apn.EPSSubscribedQoSProfile.QCI != 5 {
+		t.Fatalf("Unexpected APN: %+v", apn)
+	}
+	// OPc of TS 35.208 test set 2, derived from OP.
+	if s = subs["001010000000002"]; s == nil || len(s.opc) != 16 || s.opc[0] != 0x53 {
+		t.Fatalf("Unexpected subscriber: %+v", s)
+	}
+
+	name := filepath.Join(t.TempDir(), "bad.json")
+	if err = os.WriteFile(name, []byte(`{"subscribers":[{"imsi":"001010000000001","k":"00"}]}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = loadSubscribers(name); err == nil {
+		t.Fatal("Invalid key was accepted")
+	}
+
+	// The YAML file holds the same subscribers as the JSON one.
+	yml, err := loadSubscribers("subscribers.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(yml, subs) {
+		t.Fatalf("Unexpected YAML subscribers.\nWant %+v\nHave %+v", subs, yml)
+	}
+	name = filepath.Join(t.TempDir(), "bad.yml")
+	if err = os.WriteFile(name, []byte("subscribers:\n  - imsi: [001010000000001]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = loadSubscribers(name); err == nil {
+		t.Fatal("Invalid YAML was accepted")
+	}
+}
+
+// TestHSS_UpdateAMF checks that reloads changing the AMF of subscribers
+// don't race with the AIRs generating their vectors.
+func TestHSS_UpdateAMF(t *testing.T) {
+	subs, err := loadSubscribers("subscribers.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	h, err := newHSS(&s6a.Server{}, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	started, stop, done := make(chan struct{}), make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := 0; ; n++ {
+			if n == 1 {
+				close(started)
+			}
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			var aia s6a.AIA
+			h.AuthenticationInformation(&s6a.AIR{
+				UserName:                "001010000000001",
+				VisitedPLMNID:           "\x00\xf1\x10",
+				RequestedEUTRANAuthInfo: &s6a.RequestedAuthInfo{NumberOfRequestedVectors: 1},
+			}, &aia)
+		}
+	}()
+	<-started
+	for i := 0; i < 50; i++ {
+		subs, err = loadSubscribers("subscribers.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		subs["001010000000001"].amf = [2]byte{byte(i), 0}
+		if _, err = h.update(subs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(stop)
+	<-done
+	_, a := h.subscriber("001010000000001")
+	if a.AMF != [2]byte{49, 0} || a.SQN() == 0 {
+		t.Fatalf("Unexpected AKA state: AMF %x, SQN %d", a.AMF, a.SQN())
+	}
+}
+
+func TestHSS(t *testing.T) {
+	subs, err := loadSubscribers("subscribers.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &s6a.Server{OriginHost: "hss", OriginRealm: "test", Timeout: time.Second}
+	h, err := newHSS(server, subs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Backend = h
+	mux := sm.New(smtest.Settings("hss"))
+	for _, idx := range []diam.CommandIndex{
+		s6a.AIRIndex, s6a.ULRIndex, s6a.PURIndex, s6a.NORIndex,
+		s6a.CLAIndex, s6a.IDAIndex, s6a.DSAIndex, s6a.RSAIndex,
+	} {
+		mux.HandleIdx(idx, server)
+	}
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	plmn, err := s6a.EncodePLMNID("001", "01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelled := make(chan *s6a.CLR, 1)
+	inserted := make(chan *s6a.IDR, 1)
+	mme := &s6a.Client{
+		OriginHost:             "mme",
+		OriginRealm:            "test",
+		DestinationRealm:       "test",
+		VisitedPLMNID:          plmn,
+		Timeout:                time.Second,
+		OnCancelLocation:       func(clr *s6a.CLR, cla *s6a.CLA) { cancelled <- clr },
+		OnInsertSubscriberData: func(idr *s6a.IDR, ida *s6a.IDA) { inserted <- idr },
+	}
+	cmux := sm.New(smtest.Settings("mme"))
+	for _, idx := range []diam.CommandIndex{
+		s6a.AIAIndex, s6a.ULAIndex, s6a.PUAIndex, s6a.NOAIndex,
+		s6a.CLRIndex, s6a.IDRIndex, s6a.DSRIndex, s6a.RSRIndex,
+	} {
+		cmux.HandleIdx(idx, mme)
+	}
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.TGPP_S6A_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	aia, err := mme.AuthenticationInformation(c, &s6a.AIR{UserName: "001010000000099"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aia.ExperimentalResult == nil || aia.ExperimentalResult.Code != s6a.UserUnknown {
+		t.Fatalf("Unexpected AIA: %+v", aia)
+	}
+	aia, err = mme.AuthenticationInformation(c, &s6a.AIR{
+		UserName:                "001010000000001",
+		RequestedEUTRANAuthInfo: &s6a.RequestedAuthInfo{NumberOfRequestedVectors: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aia.ResultCode != diam.Success || aia.AuthenticationInfo == nil || len(aia.AuthenticationInfo.EUTRANVector) != 2 {
+		t.Fatalf("Unexpected AIA: %+v", aia)
+	}
+	if v := aia.AuthenticationInfo.EUTRANVector; v[0].RAND == v[1].RAND || len(v[0].KASME) != 32 {
+		t.Fatalf("Unexpected vectors: %+v", v)
+	}
+
+	ula, err := mme.UpdateLocation(c, &s6a.ULR{UserName: "001010000000001", RATType: s6a.RATUTRAN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ula.ExperimentalResult == nil || ula.ExperimentalResult.Code != s6a.RATNotAllowed {
+		t.Fatalf("Unexpected ULA: %+v", ula)
+	}
+	wrongPLMN, _ := s6a.EncodePLMNID("999", "99")
+	ula, err = mme.UpdateLocation(c, &s6a.ULR{UserName: "001010000000002", VisitedPLMNID: wrongPLMN})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ula.ExperimentalResult == nil || ula.ExperimentalResult.Code != s6a.RoamingNotAllowed {
+		t.Fatalf("Unexpected ULA: %+v", ula)
+	}
+	for _, imsi := range []string{"001010000000001", "001010000000002"} {
+		ula, err = mme.UpdateLocation(c, &s6a.ULR{UserName: imsi})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ula.ResultCode != diam.Success || ula.SubscriptionData == nil {
+			t.Fatalf("Unexpected ULA: %+v", ula)
+		}
+	}
+
+	// Changing the AMBR of one subscriber and removing the other sends
+	// an IDR and a CLR.
+	subs, err = loadSubscribers("subscribers.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subs["001010000000001"].data.AMBR.MaxRequestedBandwidthDL = 1000
+	delete(subs, "001010000000002")
+	if err = h.reload(subs); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case idr := <-inserted:
+		if idr.UserName != "001010000000001" || idr.SubscriptionData.AMBR == nil || idr.SubscriptionData.AMBR.MaxRequestedBandwidthDL != 1000 {
+			t.Fatalf("Unexpected IDR: %+v", idr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("No IDR")
+	}
+	select {
+	case clr := <-cancelled:
+		if clr.UserName != "001010000000002" || clr.CancellationType != s6a.SubscriptionWithdrawal {
+			t.Fatalf("Unexpected CLR: %+v", clr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("No CLR")
+	}
+
+	pua, err := mme.PurgeUE(c, &s6a.PUR{UserName: "001010000000001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pua.ResultCode != diam.Success {
+		t.Fatalf("Unexpected PUA: %+v", pua)
+	}
+	if users := server.Registrations(); len(users) != 0 {
+		t.Fatalf("Unexpected registrations: %v", users)
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command diam-hss is an HSS simulator for the S6a interface.
+//
+// It loads subscribers from a JSON or YAML file and answers AIR, ULR, PUR and
+// NOR as specified in 3GPP TS 29.272, generating authentication vectors
+// with Milenage. The file is watched for changes: registered subscribers
+// that are removed, or no longer allowed on their RAT or PLMN, have
+// their location cancelled with a CLR, and those whose subscription
+// data changed get an IDR.
+//
+// An example JSON subscriber file:
+//
+//	{
+//	  "subscribers": [{
+//	    "imsi": "001010000000001",
+//	    "msisdn": "5511999990001",
+//	    "k": "465b5ce8b199b49faa5f0a2ee238a6bc",
+//	    "opc": "cd63cb71954a9f4e48a5994e37a02baf",
+//	    "rats": ["EUTRAN"],
+//	    "plmns": ["00101"],
+//	    "ambr": {"ul": 50000000, "dl": 100000000},
+//	    "apns": [{"name": "internet", "qci": 9, "priority": 8}]
+//	  }]
+//	}
+//
+// Only the keys, IMSI and at least OP or OPc are mandatory. The SQN of
+// each subscriber is kept in memory and survives reloads of the file.
+//
+// Files named *.yaml or *.yml are read as YAML, with the same keys:
+//
+//	subscribers:
+//	  - imsi: "001010000000001"
+//	    k: 465b5ce8b199b49faa5f0a2ee238a6bc
+//	    opc: cd63cb71954a9f4e48a5994e37a02baf
+//	    apns:
+//	      - name: internet
+//	        qci: 9
+//	        priority: 8
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+)
+
+func main() {
+	addr := flag.String("addr", ":3868", "address in the form of ip:port to listen on")
+	host := flag.String("diam_host", "hss", "diameter identity host")
+	realm := flag.String("diam_realm", "go-diameter", "diameter identity realm")
+	certFile := flag.String("cert_file", "", "tls certificate file (optional)")
+	keyFile := flag.String("key_file", "", "tls key file (optional)")
+	networkType := flag.String("network_type", "tcp", "protocol type tcp/sctp")
+	subscriberFile := flag.String("subscribers", "subscribers.json", "JSON or YAML subscriber file")
+	watch := flag.Duration("watch", 2*time.Second, "interval to check the subscriber file for changes, 0 to disable")
+	flag.Parse()
+
+	subs, err := loadSubscribers(*subscriberFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := &s6a.Server{
+		OriginHost:  datatype.DiameterIdentity(*host),
+		OriginRealm: datatype.DiameterIdentity(*realm),
+	}
+	h, err := newHSS(server, subs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server.Backend = h
+	log.Printf("Loaded %d subscribers from %s", len(subs), *subscriberFile)
+
+	settings := &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(*host),
+		OriginRealm:      datatype.DiameterIdentity(*realm),
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+	}
+	mux := sm.New(settings)
+	for _, idx := range []diam.CommandIndex{
+		s6a.AIRIndex, s6a.ULRIndex, s6a.PURIndex, s6a.NORIndex,
+		s6a.CLAIndex, s6a.IDAIndex, s6a.DSAIndex, s6a.RSAIndex,
+	} {
+		mux.HandleIdx(idx, server)
+	}
+	server.ErrorReporter = mux
+	go printErrors(mux.ErrorReports())
+
+	if *watch > 0 {
+		go watchFile(*subscriberFile, *watch, h)
+	}
+
+	if err = listen(*networkType, *addr, *certFile, *keyFile, mux); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// watchFile reloads the subscriber file when its modification time
+// changes. Invalid files are logged and ignored.
+func watchFile(name string, interval time.Duration, h *hss) {
+	var last time.Time
+	if fi, err := os.Stat(name); err == nil {
+		last = fi.ModTime()
+	}
+	for range time.Tick(interval) {
+		fi, err := os.Stat(name)
+		if err != nil || fi.ModTime().Equal(last) {
+			continue
+		}
+		last = fi.ModTime()
+		subs, err := loadSubscribers(name)
+		if err != nil {
+			log.Printf("Not reloading subscribers: %v", err)
+			continue
+		}
+		if err = h.reload(subs); err != nil {
+			log.Printf("Not reloading subscribers: %v", err)
+			continue
+		}
+		log.Printf("Reloaded %d subscribers from %s", len(subs), name)
+	}
+}
+
+func printErrors(ec <-chan *diam.ErrorReport) {
+	for err := range ec {
+		log.Println(err)
+	}
+}
+
+func listen(networkType, addr, cert, key string, handler diam.Handler) error {
+	if len(cert) > 0 && len(key) > 0 {
+		log.Println("Starting secure diameter server on", addr)
+		return diam.ListenAndServeNetworkTLS(networkType, addr, cert, key, handler, nil)
+	}
+	log.Println("Starting diameter server on", addr)
+	return diam.ListenAndServeNetwork(networkType, addr, handler, nil)
+}
@@ -0,0 +1,138 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// config is the format of the configuration file.
+type config struct {
+	Rating          ratingConfig       `json:"rating"`
+	FinalUnitAction string             `json:"final_unit_action,omitempty"` // TERMINATE (default), REDIRECT or RESTRICT_ACCESS.
+	FailureHandling string             `json:"failure_handling,omitempty"`  // CCFH sent in every CCA: TERMINATE, CONTINUE or RETRY_AND_TERMINATE.
+	Subscribers     []subscriberConfig `json:"subscribers"`
+	Faults          []*fault           `json:"faults,omitempty"`
+}
+
+// ratingConfig configures a gy.FixedRater.
+type ratingConfig struct {
+	Quota        units            `json:"quota"`                   // Granted when the client requests no specific units.
+	RatingGroups map[uint32]units `json:"rating_groups,omitempty"` // Per rating group quota.
+	ValidityTime uint32           `json:"validity_time,omitempty"` // Seconds.
+}
+
+type subscriberConfig struct {
+	ID       string           `json:"id"` // Subscription-Id-Data or User-Name.
+	Balances map[uint32]units `json:"balances"`
+}
+
+// units is the JSON representation of gy.ServiceUnit.
+type units struct {
+	Time                 uint32 `json:"time,omitempty"`
+	TotalOctets          uint64 `json:"total_octets,omitempty"`
+	InputOctets          uint64 `json:"input_octets,omitempty"`
+	OutputOctets         uint64 `json:"output_octets,omitempty"`
+	ServiceSpecificUnits uint64 `json:"service_specific_units,omitempty"`
+}
+
+func (u units) serviceUnit() gy.ServiceUnit {
+	return gy.ServiceUnit(u)
+}
+
+func fromServiceUnit(u gy.ServiceUnit) units {
+	return units(u)
+}
+
+var finalUnitActions = map[string]int32{
+	"":                gy.Terminate,
+	"TERMINATE":       gy.Terminate,
+	"REDIRECT":        gy.Redirect,
+	"RESTRICT_ACCESS": gy.RestrictAccess,
+}
+
+var failureHandlings = map[string]int32{
+	"TERMINATE":           gy.FailureHandlingTerminate,
+	"CONTINUE":            gy.FailureHandlingContinue,
+	"RETRY_AND_TERMINATE": gy.FailureHandlingRetryAndTerminate,
+}
+
+func loadConfig(name string) (*config, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var c config
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if _, ok := finalUnitActions[strings.ToUpper(c.FinalUnitAction)]; !ok {
+		return nil, fmt.Errorf("%s: invalid final_unit_action %q", name, c.FinalUnitAction)
+	}
+	if _, ok := failureHandlings[strings.ToUpper(c.FailureHandling)]; !ok && c.FailureHandling != "" {
+		return nil, fmt.Errorf("%s: invalid failure_handling %q", name, c.FailureHandling)
+	}
+	for i, s := range c.Subscribers {
+		if s.ID == "" {
+			return nil, fmt.Errorf("%s: subscriber %d has no id", name, i)
+		}
+	}
+	for i, f := range c.Faults {
+		if err = f.validate(); err != nil {
+			return nil, fmt.Errorf("%s: fault %d: %v", name, i, err)
+		}
+	}
+	return &c, nil
+}
+
+// rater returns the Rater of the configuration.
+func (c *config) rater() *gy.FixedRater {
+	r := &gy.FixedRater{
+		Quota:        c.Rating.Quota.serviceUnit(),
+		ValidityTime: c.Rating.ValidityTime,
+	}
+	if len(c.Rating.RatingGroups) > 0 {
+		r.RatingGroup = make(map[uint32]gy.ServiceUnit)
+		for rg, u := range c.Rating.RatingGroups {
+			r.RatingGroup[rg] = u.serviceUnit()
+		}
+	}
+	return r
+}
+
+// failureHandling returns the CCFH of the configuration, or nil.
+func (c *config) failureHandling() *int32 {
+	if c.FailureHandling == "" {
+		return nil
+	}
+	v := failureHandlings[strings.ToUpper(c.FailureHandling)]
+	return &v
+}
+
+// duration is a time.Duration that is a string such as "1.5s" in JSON.
+type duration time.Duration
+
+func (d duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// controlHandler returns the HTTP control API of the OCS:
+//
+//	GET    /sessions[?subscriber=ID]      list active sessions
+//	POST   /rar?session_id=ID             send a RAR to one session
+//	POST   /rar?subscriber=ID             send a RAR to every session of a subscriber
+//	GET    /balances[?subscriber=ID]      list balances
+//	POST   /balances                      set a balance: {"subscriber", "rating_group", "units"}
+//	GET    /faults                        list faults
+//	POST   /faults                        add a fault
+//	DELETE /faults                        remove all faults
+//
+// The RAR endpoint accepts an optional rating_group parameter to only
+// reauthorize that rating group.
+func controlHandler(o *ocs) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, o.sessions(r.FormValue("subscriber")))
+	})
+	mux.HandleFunc("/rar", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		o.handleRAR(w, r)
+	})
+	mux.HandleFunc("/balances", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, o.balances(r.FormValue("subscriber")))
+		case http.MethodPost:
+			var req struct {
+				Subscriber  string `json:"subscriber"`
+				RatingGroup uint32 `json:"rating_group"`
+				Units       units  `json:"units"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Subscriber == "" {
+				http.Error(w, "invalid balance", http.StatusBadRequest)
+				return
+			}
+			o.setBalance(req.Subscriber, req.RatingGroup, req.Units)
+			writeJSON(w, o.balances(req.Subscriber))
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+	mux.HandleFunc("/faults", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			var f fault
+			if err := json.NewDecoder(r.Body).Decode(&f); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if err := f.validate(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			o.faults.add(&f)
+		case http.MethodDelete:
+			o.faults.clear()
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, o.faults.get())
+	})
+	return mux
+}
+
+// rarResult is the outcome of a RAR sent through the control API.
+type rarResult struct {
+	SessionID  string `json:"session_id"`
+	ResultCode uint32 `json:"result_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (o *ocs) handleRAR(w http.ResponseWriter, r *http.Request) {
+	var rg *uint32
+	if v := r.FormValue("rating_group"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			http.Error(w, "invalid rating_group", http.StatusBadRequest)
+			return
+		}
+		u := uint32(n)
+		rg = &u
+	}
+	var ids []string
+	if id := r.FormValue("session_id"); id != "" {
+		ids = []string{id}
+	} else if sub := r.FormValue("subscriber"); sub != "" {
+		for _, s := range o.sessions(sub) {
+			ids = append(ids, s.SessionID)
+		}
+	} else {
+		http.Error(w, "missing session_id or subscriber", http.StatusBadRequest)
+		return
+	}
+	results := make([]rarResult, 0, len(ids))
+	for _, id := range ids {
+		res := rarResult{SessionID: id}
+		raa, err := o.server.ReAuth(id, &gy.RAR{ReAuthRequestType: gy.AuthorizeOnly, RatingGroup: rg})
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.ResultCode = raa.ResultCode
+		}
+		results = append(results, res)
+	}
+	writeJSON(w, results)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,117 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// Fault actions.
+const (
+	actionDelay              = "delay"                // Only delay the answer; the default.
+	actionDrop               = "drop"                 // Don't answer.
+	actionCreditLimitReached = "credit_limit_reached" // Answer DIAMETER_CREDIT_LIMIT_REACHED.
+	actionResultCode         = "result_code"          // Answer with ResultCode.
+)
+
+// A fault changes how the OCS answers the CCRs it matches.
+type fault struct {
+	Subscriber  string   `json:"subscriber,omitempty"`   // Any when empty.
+	RequestType string   `json:"request_type,omitempty"` // INITIAL_REQUEST, UPDATE_REQUEST, ...; any when empty.
+	Action      string   `json:"action,omitempty"`
+	Delay       duration `json:"delay,omitempty"`       // Applied before the action.
+	ResultCode  uint32   `json:"result_code,omitempty"` // For the result_code action.
+	Count       int      `json:"count,omitempty"`       // Number of CCRs to apply to; 0 is unlimited.
+}
+
+func (f *fault) validate() error {
+	switch f.Action {
+	case "", actionDelay, actionDrop, actionCreditLimitReached:
+	case actionResultCode:
+		if f.ResultCode == 0 {
+			return errors.New("result_code action requires result_code")
+		}
+	default:
+		return fmt.Errorf("invalid action %q", f.Action)
+	}
+	if f.RequestType != "" {
+		ok := false
+		for t := gy.InitialRequest; t <= gy.EventRequest; t++ {
+			ok = ok || strings.EqualFold(f.RequestType, t.String())
+		}
+		if !ok {
+			return fmt.Errorf("invalid request_type %q", f.RequestType)
+		}
+	}
+	if f.Count < 0 {
+		return errors.New("count must not be negative")
+	}
+	return nil
+}
+
+func (f *fault) matches(ccr *gy.CCR) bool {
+	if f.Subscriber != "" && f.Subscriber != ccr.Subscriber() {
+		return false
+	}
+	return f.RequestType == "" || strings.EqualFold(f.RequestType, ccr.CCRequestType.String())
+}
+
+// faults is a list of faults, applied in order. It is safe for
+// concurrent use.
+type faults struct {
+	mu   sync.Mutex
+	list []*fault
+}
+
+// add appends f to the list.
+func (fs *faults) add(f *fault) {
+	fs.mu.Lock()
+	fs.list = append(fs.list, f)
+	fs.mu.Unlock()
+}
+
+// clear removes all faults.
+func (fs *faults) clear() {
+	fs.mu.Lock()
+	fs.list = nil
+	fs.mu.Unlock()
+}
+
+// get returns a copy of the list.
+func (fs *faults) get() []fault {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	list := make([]fault, len(fs.list))
+	for i, f := range fs.list {
+		list[i] = *f
+	}
+	return list
+}
+
+// match returns a copy of the first fault that matches ccr, consuming
+// one of its uses, or nil.
+func (fs *faults) match(ccr *gy.CCR) *fault {
+	fs.mu.Lock()
+	defer fs.mu.Unlock()
+	for i, f := range fs.list {
+		if !f.matches(ccr) {
+			continue
+		}
+		m := *f
+		if f.Count > 0 {
+			f.Count--
+			if f.Count == 0 {
+				fs.list = append(fs.list[:i:i], fs.list[i+1:]...)
+			}
+		}
+		return &m
+	}
+	return nil
+}
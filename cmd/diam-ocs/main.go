@@ -0,0 +1,114 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command diam-ocs is an online charging server simulator for Gy/Ro.
+//
+// It grants, debits and releases quota across CCR-Initial, Update and
+// Termination using the balances and rating rules of a JSON file, and
+// offers a local HTTP control API to inspect sessions and balances, send
+// RARs on demand, and inject faults: delayed answers, dropped answers,
+// DIAMETER_CREDIT_LIMIT_REACHED or any other Result-Code. Together with
+// the failure_handling setting, which sets the CCFH of every CCA, this
+// makes it possible to exercise the credit control failure handling of
+// a PGW reproducibly.
+//
+// An example configuration file:
+//
+//	{
+//	  "rating": {
+//	    "quota": {"total_octets": 1048576},
+//	    "rating_groups": {"10": {"time": 600}},
+//	    "validity_time": 3600
+//	  },
+//	  "failure_handling": "CONTINUE",
+//	  "subscribers": [{
+//	    "id": "001010000000001",
+//	    "balances": {"0": {"total_octets": 1073741824}, "10": {"time": 3600}}
+//	  }],
+//	  "faults": [{"request_type": "UPDATE_REQUEST", "action": "drop", "count": 1}]
+//	}
+//
+// Faults are matched in order against each CCR, by subscriber and
+// request type, and apply to count CCRs or, when count is 0, until they
+// are removed. Examples of control API use:
+//
+//	curl localhost:9868/sessions
+//	curl -X POST 'localhost:9868/rar?subscriber=001010000000001'
+//	curl -d '{"action":"delay","delay":"5s"}' localhost:9868/faults
+//	curl -d '{"action":"credit_limit_reached","request_type":"UPDATE_REQUEST"}' localhost:9868/faults
+//	curl -X DELETE localhost:9868/faults
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+func main() {
+	addr := flag.String("addr", ":3868", "address in the form of ip:port to listen on")
+	httpAddr := flag.String("http_addr", "127.0.0.1:9868", "address in the form of ip:port for the HTTP control API")
+	host := flag.String("diam_host", "ocs", "diameter identity host")
+	realm := flag.String("diam_realm", "go-diameter", "diameter identity realm")
+	certFile := flag.String("cert_file", "", "tls certificate file (optional)")
+	keyFile := flag.String("key_file", "", "tls key file (optional)")
+	networkType := flag.String("network_type", "tcp", "protocol type tcp/sctp")
+	configFile := flag.String("config", "ocs.json", "configuration file")
+	flag.Parse()
+
+	conf, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := &gy.Server{
+		OriginHost:  datatype.DiameterIdentity(*host),
+		OriginRealm: datatype.DiameterIdentity(*realm),
+	}
+	o := newOCS(server, conf)
+	log.Printf("Loaded %d subscribers and %d faults from %s", len(conf.Subscribers), len(conf.Faults), *configFile)
+
+	settings := &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(*host),
+		OriginRealm:      datatype.DiameterIdentity(*realm),
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+	}
+	mux := sm.New(settings)
+	mux.HandleIdx(gy.CCRIndex, o)
+	mux.HandleIdx(gy.RAAIndex, o)
+	server.ErrorReporter = mux
+	go printErrors(mux.ErrorReports())
+
+	if len(*httpAddr) > 0 {
+		go func() {
+			log.Println("Starting HTTP control API on", *httpAddr)
+			log.Fatal(http.ListenAndServe(*httpAddr, controlHandler(o)))
+		}()
+	}
+
+	if err = listen(*networkType, *addr, *certFile, *keyFile, mux); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printErrors(ec <-chan *diam.ErrorReport) {
+	for err := range ec {
+		log.Println(err)
+	}
+}
+
+func listen(networkType, addr, cert, key string, handler diam.Handler) error {
+	if len(cert) > 0 && len(key) > 0 {
+		log.Println("Starting secure diameter server on", addr)
+		return diam.ListenAndServeNetworkTLS(networkType, addr, cert, key, handler, nil)
+	}
+	log.Println("Starting diameter server on", addr)
+	return diam.ListenAndServeNetwork(networkType, addr, handler, nil)
+}
@@ -0,0 +1,174 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// ocs wraps a gy.Server with fault injection and keeps track of the
+// rating groups of each subscriber so balances can be listed.
+type ocs struct {
+	server  *gy.Server
+	balance *gy.MemoryBalance
+	faults  faults
+
+	mu           sync.Mutex
+	ratingGroups map[string]map[uint32]bool // By subscriber.
+}
+
+func newOCS(server *gy.Server, c *config) *ocs {
+	o := &ocs{
+		server:       server,
+		balance:      gy.NewMemoryBalance(),
+		ratingGroups: make(map[string]map[uint32]bool),
+	}
+	server.Balance = o.balance
+	server.Rater = c.rater()
+	server.FinalUnitAction = finalUnitActions[strings.ToUpper(c.FinalUnitAction)]
+	server.CreditControlFailureHandling = c.failureHandling()
+	for _, s := range c.Subscribers {
+		for rg, u := range s.Balances {
+			o.setBalance(s.ID, rg, u)
+		}
+	}
+	for _, f := range c.Faults {
+		o.faults.add(f)
+	}
+	return o
+}
+
+// setBalance sets the balance of a subscriber and rating group.
+func (o *ocs) setBalance(subscriber string, rg uint32, u units) {
+	o.mu.Lock()
+	rgs, ok := o.ratingGroups[subscriber]
+	if !ok {
+		rgs = make(map[uint32]bool)
+		o.ratingGroups[subscriber] = rgs
+	}
+	rgs[rg] = true
+	o.mu.Unlock()
+	o.balance.Set(subscriber, rg, u.serviceUnit())
+}
+
+// balances returns the balances of all subscribers by rating group, or
+// of a single one when subscriber is not empty.
+func (o *ocs) balances(subscriber string) map[string]map[uint32]units {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	all := make(map[string]map[uint32]units)
+	for sub, rgs := range o.ratingGroups {
+		if subscriber != "" && sub != subscriber {
+			continue
+		}
+		b := make(map[uint32]units, len(rgs))
+		for rg := range rgs {
+			if u, err := o.balance.Get(sub, rg); err == nil {
+				b[rg] = fromServiceUnit(u)
+			}
+		}
+		all[sub] = b
+	}
+	return all
+}
+
+// sessionInfo describes an active session.
+type sessionInfo struct {
+	SessionID    string           `json:"session_id"`
+	OriginHost   string           `json:"origin_host"`
+	Subscriber   string           `json:"subscriber"`
+	Reservations map[uint32]units `json:"reservations"`
+}
+
+// sessions returns the active sessions, optionally of a single
+// subscriber.
+func (o *ocs) sessions(subscriber string) []sessionInfo {
+	var list []sessionInfo
+	for _, id := range o.server.Sessions() {
+		ccr, ok := o.server.Session(id)
+		if !ok || (subscriber != "" && ccr.Subscriber() != subscriber) {
+			continue
+		}
+		info := sessionInfo{
+			SessionID:    id,
+			OriginHost:   string(ccr.OriginHost),
+			Subscriber:   ccr.Subscriber(),
+			Reservations: make(map[uint32]units),
+		}
+		for _, r := range o.server.Reservations(id) {
+			info.Reservations[r.RatingGroup] = fromServiceUnit(r.Units)
+		}
+		list = append(list, info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].SessionID < list[j].SessionID })
+	return list
+}
+
+// ServeDIAM implements the diam.Handler interface. CCRs matching a fault
+// are delayed, dropped or answered with an error; everything else is
+// handled by the gy.Server.
+func (o *ocs) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		o.server.ServeDIAM(c, m)
+		return
+	}
+	var ccr gy.CCR
+	if err := m.Unmarshal(&ccr); err != nil {
+		o.server.ServeDIAM(c, m)
+		return
+	}
+	log.Printf("%s from %s for %s, session %s", ccr.CCRequestType, string(ccr.OriginHost), ccr.Subscriber(), ccr.SessionID)
+	f := o.faults.match(&ccr)
+	if f == nil {
+		o.server.ServeDIAM(c, m)
+		return
+	}
+	if f.Delay > 0 {
+		log.Printf("Fault: delaying answer to %s by %s", ccr.SessionID, time.Duration(f.Delay))
+		time.Sleep(time.Duration(f.Delay))
+	}
+	switch f.Action {
+	case "", actionDelay:
+		o.server.ServeDIAM(c, m)
+	case actionDrop:
+		log.Printf("Fault: dropping %s of %s", ccr.CCRequestType, ccr.SessionID)
+	case actionCreditLimitReached:
+		o.answerError(c, m, &ccr, gy.CreditLimitReached)
+	case actionResultCode:
+		o.answerError(c, m, &ccr, f.ResultCode)
+	}
+}
+
+// answerError answers ccr with the given Result-Code and no grants.
+func (o *ocs) answerError(c diam.Conn, m *diam.Message, ccr *gy.CCR, code uint32) {
+	log.Printf("Fault: answering %s of %s with %d", ccr.CCRequestType, ccr.SessionID, code)
+	a := m.Answer(0)
+	if code >= 3000 && code < 4000 {
+		a.Header.CommandFlags |= diam.ErrorFlag
+	}
+	err := a.Marshal(&gy.CCA{
+		SessionID:                    ccr.SessionID,
+		ResultCode:                   code,
+		OriginHost:                   o.server.OriginHost,
+		OriginRealm:                  o.server.OriginRealm,
+		AuthApplicationID:            ccr.AuthApplicationID,
+		CCRequestType:                ccr.CCRequestType,
+		CCRequestNumber:              ccr.CCRequestNumber,
+		CreditControlFailureHandling: o.server.CreditControlFailureHandling,
+	})
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil {
+		log.Printf("Failed to write CCA: %v", err)
+	}
+}
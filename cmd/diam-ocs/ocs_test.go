@@ -0,0 +1,232 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+func TestLoadConfig(t *testing.T) {
+	c, err := loadConfig("ocs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Subscribers) != 2 || c.Subscribers[0].Balances[10].Time != 3600 {
+		t.Fatalf("Unexpected subscribers: %+v", c.Subscribers)
+	}
+	r := c.rater()
+	if r.Quota.TotalOctets != 1048576 || r.RatingGroup[10].Time != 600 || r.ValidityTime != 3600 {
+		t.Fatalf("Unexpected rater: %+v", r)
+	}
+	if ccfh := c.failureHandling(); ccfh == nil || *ccfh != gy.FailureHandlingContinue {
+		t.Fatalf("Unexpected CCFH: %v", ccfh)
+	}
+}
+
+func TestFaults_Match(t *testing.T) {
+	var fs faults
+	fs.add(&fault{Subscriber: "alice", RequestType: "UPDATE_REQUEST", Action: actionDrop, Count: 1})
+	fs.add(&fault{Action: actionCreditLimitReached})
+	ccr := &gy.CCR{
+		CCRequestType:  gy.UpdateRequest,
+		SubscriptionID: []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: "alice"}},
+	}
+	if f := fs.match(ccr); f == nil || f.Action != actionDrop {
+		t.Fatalf("Unexpected fault: %+v", f)
+	}
+	// The first fault is used up.
+	if f := fs.match(ccr); f == nil || f.Action != actionCreditLimitReached {
+		t.Fatalf("Unexpected fault: %+v", f)
+	}
+	if n := len(fs.get()); n != 1 {
+		t.Fatalf("Unexpected number of faults: %d", n)
+	}
+	if err := (&fault{Action: "explode"}).validate(); err == nil {
+		t.Fatal("Invalid action was accepted")
+	}
+}
+
+type testPGW struct {
+	conn diam.Conn
+	ccas chan *gy.CCA
+	rars chan *gy.RAR
+	n    uint32
+}
+
+func dialPGW(t *testing.T, addr string) *testPGW {
+	t.Helper()
+	p := &testPGW{ccas: make(chan *gy.CCA, 1), rars: make(chan *gy.RAR, 1)}
+	mux := sm.New(&sm.Settings{
+		OriginHost:       "pgw",
+		OriginRealm:      "test",
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	})
+	mux.HandleFunc("CCA", func(c diam.Conn, m *diam.Message) {
+		var cca gy.CCA
+		if err := m.Unmarshal(&cca); err != nil {
+			t.Error(err)
+		}
+		p.ccas <- &cca
+	})
+	rarIdx := diam.CommandIndex{AppID: diam.CHARGING_CONTROL_APP_ID, Code: diam.ReAuth, Request: true}
+	mux.HandleIdx(rarIdx, diam.HandlerFunc(func(c diam.Conn, m *diam.Message) {
+		var rar gy.RAR
+		if err := m.Unmarshal(&rar); err != nil {
+			t.Error(err)
+		}
+		p.rars <- &rar
+		a := m.Answer(diam.Success)
+		a.Marshal(&gy.RAA{SessionID: rar.SessionID, ResultCode: diam.Success, OriginHost: "pgw", OriginRealm: "test"})
+		a.WriteTo(c)
+	}))
+	cli := &sm.Client{
+		Handler: mux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.CHARGING_CONTROL_APP_ID)),
+		},
+	}
+	var err error
+	if p.conn, err = cli.Dial(addr); err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// send sends a CCR of the given type and returns its answer, or nil if
+// there is none within wait.
+func (p *testPGW) send(t *testing.T, typ gy.RequestType, wait time.Duration, mscc ...gy.MSCC) *gy.CCA {
+	t.Helper()
+	m := diam.NewRequest(diam.CreditControl, diam.CHARGING_CONTROL_APP_ID, dict.Default)
+	err := m.Marshal(&gy.CCR{
+		SessionID:         "pgw;1",
+		OriginHost:        "pgw",
+		OriginRealm:       "test",
+		DestinationRealm:  "test",
+		AuthApplicationID: diam.CHARGING_CONTROL_APP_ID,
+		ServiceContextID:  "32251@3gpp.org",
+		CCRequestType:     typ,
+		CCRequestNumber:   p.n,
+		SubscriptionID:    []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: "001010000000002"}},
+		MSCC:              mscc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.n++
+	if _, err = m.WriteTo(p.conn); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case cca := <-p.ccas:
+		return cca
+	case <-time.After(wait):
+		return nil
+	}
+}
+
+func rg(n uint32) *uint32 { return &n }
+
+func TestOCS(t *testing.T) {
+	conf, err := loadConfig("ocs.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &gy.Server{OriginHost: "ocs", OriginRealm: "test", Timeout: time.Second}
+	o := newOCS(server, conf)
+	mux := sm.New(&sm.Settings{
+		OriginHost:       "ocs",
+		OriginRealm:      "test",
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+	})
+	mux.HandleIdx(gy.CCRIndex, o)
+	mux.HandleIdx(gy.RAAIndex, o)
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+	api := httptest.NewServer(controlHandler(o))
+	defer api.Close()
+
+	pgw := dialPGW(t, srv.Addr)
+	defer pgw.conn.Close()
+
+	cca := pgw.send(t, gy.InitialRequest, time.Second, gy.MSCC{RatingGroup: rg(0), RequestedServiceUnit: &gy.ServiceUnit{}})
+	if cca == nil || cca.ResultCode != diam.Success || len(cca.MSCC) != 1 || cca.MSCC[0].GrantedServiceUnit.TotalOctets != 1048576 {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if cca.CreditControlFailureHandling == nil || *cca.CreditControlFailureHandling != gy.FailureHandlingContinue {
+		t.Fatalf("Unexpected CCFH: %+v", cca)
+	}
+
+	resp, err := http.Post(api.URL+"/rar?subscriber=001010000000002", "", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []rarResult
+	err = json.NewDecoder(resp.Body).Decode(&results)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].SessionID != "pgw;1" || results[0].ResultCode != diam.Success {
+		t.Fatalf("Unexpected RAR results: %+v", results)
+	}
+	if rar := <-pgw.rars; rar.SessionID != "pgw;1" || rar.DestinationHost != "pgw" {
+		t.Fatalf("Unexpected RAR: %+v", rar)
+	}
+
+	for _, f := range []string{
+		`{"request_type":"UPDATE_REQUEST","action":"drop","count":1}`,
+		`{"request_type":"UPDATE_REQUEST","action":"credit_limit_reached","count":1}`,
+	} {
+		resp, err = http.Post(api.URL+"/faults", "application/json", strings.NewReader(f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected status adding fault %s: %s", f, resp.Status)
+		}
+	}
+	used := gy.MSCC{RatingGroup: rg(0), UsedServiceUnit: []gy.ServiceUnit{{TotalOctets: 1000}}, RequestedServiceUnit: &gy.ServiceUnit{}}
+	if cca = pgw.send(t, gy.UpdateRequest, 200*time.Millisecond, used); cca != nil {
+		t.Fatalf("Dropped CCR was answered: %+v", cca)
+	}
+	if cca = pgw.send(t, gy.UpdateRequest, time.Second, used); cca == nil || cca.ResultCode != gy.CreditLimitReached {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if cca = pgw.send(t, gy.UpdateRequest, time.Second, used); cca == nil || cca.ResultCode != diam.Success {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if cca = pgw.send(t, gy.TerminationRequest, time.Second, gy.MSCC{RatingGroup: rg(0), UsedServiceUnit: []gy.ServiceUnit{{TotalOctets: 500}}}); cca == nil || cca.ResultCode != diam.Success {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+
+	// Only the usage of the answered CCRs is debited.
+	want := uint64(10485760 - 1000 - 500)
+	if b := o.balances("001010000000002"); b["001010000000002"][0].TotalOctets != want {
+		t.Fatalf("Unexpected balance. Want %d, have %+v", want, b)
+	}
+	if s := o.sessions(""); len(s) != 0 {
+		t.Fatalf("Unexpected sessions: %+v", s)
+	}
+}
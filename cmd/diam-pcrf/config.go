@@ -0,0 +1,250 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gx"
+)
+
+// config is the format of the configuration file. The policy of a
+// session is the one of its subscriber, or else the one of its APN
+// (Called-Station-Id), or else the default policy.
+type config struct {
+	Default     *policy            `json:"default,omitempty"`
+	APNs        map[string]*policy `json:"apns,omitempty"`
+	Subscribers map[string]*policy `json:"subscribers,omitempty"` // By Subscription-Id-Data.
+}
+
+// policy is what the PCRF provisions in the CCA-Initial of a session.
+type policy struct {
+	ResultCode    uint32             `json:"result_code,omitempty"` // Rejects the session when set.
+	Install       ruleSet            `json:"install"`
+	EventTriggers []string           `json:"event_triggers,omitempty"`
+	QoS           *qos               `json:"qos,omitempty"`
+	Updates       map[string]*change `json:"updates,omitempty"` // By Event-Trigger name.
+}
+
+// change is a policy change sent in the CCA-Update answering a CCR with
+// one of the policy's update triggers, or pushed in a RAR.
+type change struct {
+	Install       ruleSet   `json:"install"`
+	Remove        ruleNames `json:"remove"`
+	EventTriggers []string  `json:"event_triggers,omitempty"`
+	QoS           *qos      `json:"qos,omitempty"`
+}
+
+// ruleNames names predefined PCC rules and rule bases.
+type ruleNames struct {
+	Rules     []string `json:"rules,omitempty"`
+	RuleBases []string `json:"rule_bases,omitempty"`
+}
+
+// ruleSet is a set of predefined and dynamic PCC rules.
+type ruleSet struct {
+	Rules       []string `json:"rules,omitempty"`
+	RuleBases   []string `json:"rule_bases,omitempty"`
+	Definitions []rule   `json:"definitions,omitempty"`
+}
+
+// rule is a dynamic PCC rule.
+type rule struct {
+	Name              string   `json:"name"`
+	Precedence        *uint32  `json:"precedence,omitempty"`
+	RatingGroup       *uint32  `json:"rating_group,omitempty"`
+	ServiceIdentifier *uint32  `json:"service_identifier,omitempty"`
+	Flows             []string `json:"flows,omitempty"` // IPFilterRule, e.g. "permit out ip from any to any".
+	MonitoringKey     string   `json:"monitoring_key,omitempty"`
+	Online            *bool    `json:"online,omitempty"`
+	Offline           *bool    `json:"offline,omitempty"`
+	QoS               *qos     `json:"qos,omitempty"`
+}
+
+// qos is the JSON representation of gx.QoSInformation. Bitrates are in
+// bits per second.
+type qos struct {
+	QCI       *int32 `json:"qci,omitempty"`
+	MBRUL     uint32 `json:"mbr_ul,omitempty"`
+	MBRDL     uint32 `json:"mbr_dl,omitempty"`
+	GBRUL     uint32 `json:"gbr_ul,omitempty"`
+	GBRDL     uint32 `json:"gbr_dl,omitempty"`
+	APNAMBRUL uint32 `json:"apn_ambr_ul,omitempty"`
+	APNAMBRDL uint32 `json:"apn_ambr_dl,omitempty"`
+}
+
+var releaseCauses = map[string]int32{
+	"UNSPECIFIED_REASON":            gx.UnspecifiedReason,
+	"UE_SUBSCRIPTION_REASON":        gx.UESubscriptionReason,
+	"INSUFFICIENT_SERVER_RESOURCES": gx.InsufficientServerResources,
+	"IP_CAN_SESSION_TERMINATION":    gx.IPCANSessionTermination,
+	"UE_IP_ADDRESS_RELEASE":         gx.UEIPAddressReleaseReason,
+}
+
+func loadConfig(name string) (*config, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var c config
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if err = c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return &c, nil
+}
+
+func (c *config) validate() error {
+	if err := c.Default.validate(); err != nil {
+		return fmt.Errorf("default: %v", err)
+	}
+	for apn, p := range c.APNs {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("apn %s: %v", apn, err)
+		}
+	}
+	for id, p := range c.Subscribers {
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("subscriber %s: %v", id, err)
+		}
+	}
+	return nil
+}
+
+// policy returns the policy for a subscriber and APN, and the name
+// of the configuration entry it comes from.
+func (c *config) policy(subscriber, apn string) (string, *policy) {
+	if p, ok := c.Subscribers[subscriber]; ok {
+		return "subscriber " + subscriber, p
+	}
+	if p, ok := c.APNs[apn]; ok {
+		return "apn " + apn, p
+	}
+	if c.Default != nil {
+		return "default", c.Default
+	}
+	return "default", &policy{}
+}
+
+func (p *policy) validate() error {
+	if p == nil {
+		return nil
+	}
+	if _, err := eventTriggers(p.EventTriggers); err != nil {
+		return err
+	}
+	for name, ch := range p.Updates {
+		if _, err := eventTrigger(name); err != nil {
+			return err
+		}
+		if err := ch.validate(); err != nil {
+			return fmt.Errorf("update on %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+func (ch *change) validate() error {
+	if ch == nil {
+		return nil
+	}
+	_, err := eventTriggers(ch.EventTriggers)
+	return err
+}
+
+// eventTrigger parses the dictionary name of an Event-Trigger.
+func eventTrigger(name string) (gx.EventTrigger, error) {
+	name = strings.ToUpper(name)
+	for e := gx.EventTrigger(0); e < 64; e++ {
+		if e.String() == name {
+			return e, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown event trigger %q", name)
+}
+
+func eventTriggers(names []string) ([]gx.EventTrigger, error) {
+	var ets []gx.EventTrigger
+	for _, name := range names {
+		e, err := eventTrigger(name)
+		if err != nil {
+			return nil, err
+		}
+		ets = append(ets, e)
+	}
+	return ets, nil
+}
+
+// install returns the Charging-Rule-Install AVPs of the set, if any.
+func (rs *ruleSet) install() []gx.ChargingRuleInstall {
+	if len(rs.Rules) == 0 && len(rs.RuleBases) == 0 && len(rs.Definitions) == 0 {
+		return nil
+	}
+	cri := gx.ChargingRuleInstall{Name: rs.Rules, BaseName: rs.RuleBases}
+	for _, r := range rs.Definitions {
+		cri.Definition = append(cri.Definition, r.definition())
+	}
+	return []gx.ChargingRuleInstall{cri}
+}
+
+// remove returns the Charging-Rule-Remove AVPs of the names, if any.
+func (rn *ruleNames) remove() []gx.ChargingRuleRemove {
+	if len(rn.Rules) == 0 && len(rn.RuleBases) == 0 {
+		return nil
+	}
+	return []gx.ChargingRuleRemove{{Name: rn.Rules, BaseName: rn.RuleBases}}
+}
+
+func (r *rule) definition() gx.ChargingRuleDefinition {
+	d := gx.ChargingRuleDefinition{
+		Name:              r.Name,
+		Precedence:        r.Precedence,
+		RatingGroup:       r.RatingGroup,
+		ServiceIdentifier: r.ServiceIdentifier,
+		MonitoringKey:     r.MonitoringKey,
+		Online:            enabled(r.Online),
+		Offline:           enabled(r.Offline),
+		QoSInformation:    r.QoS.information(),
+	}
+	for _, f := range r.Flows {
+		d.FlowInformation = append(d.FlowInformation, gx.FlowInformation{
+			FlowDescription: datatype.IPFilterRule(f),
+		})
+	}
+	return d
+}
+
+// enabled converts a flag to the Online and Offline AVP values.
+func enabled(b *bool) *int32 {
+	if b == nil {
+		return nil
+	}
+	var v int32
+	if *b {
+		v = 1
+	}
+	return &v
+}
+
+func (q *qos) information() *gx.QoSInformation {
+	if q == nil {
+		return nil
+	}
+	return &gx.QoSInformation{
+		QCI:                      q.QCI,
+		MaxRequestedBandwidthUL:  q.MBRUL,
+		MaxRequestedBandwidthDL:  q.MBRDL,
+		GuaranteedBitrateUL:      q.GBRUL,
+		GuaranteedBitrateDL:      q.GBRDL,
+		APNAggregateMaxBitrateUL: q.APNAMBRUL,
+		APNAggregateMaxBitrateDL: q.APNAMBRDL,
+	}
+}
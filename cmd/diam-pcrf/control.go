@@ -0,0 +1,117 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// pushRequest is the body of a RAR pushed through the control API.
+type pushRequest struct {
+	change
+	ReleaseCause string `json:"release_cause,omitempty"` // Releases the session, e.g. UNSPECIFIED_REASON.
+}
+
+// controlHandler returns the HTTP control API of the PCRF:
+//
+//	GET  /sessions[?subscriber=ID]   list active sessions
+//	POST /rar?session_id=ID          push a RAR to one session
+//	POST /rar?subscriber=ID          push a RAR to every session of a subscriber
+//
+// The body of a RAR push has the format of a policy update, plus an
+// optional release_cause:
+//
+//	{
+//	  "install": {"rules": [...], "rule_bases": [...], "definitions": [...]},
+//	  "remove": {"rules": [...], "rule_bases": [...]},
+//	  "event_triggers": [...],
+//	  "qos": {...},
+//	  "release_cause": "UNSPECIFIED_REASON"
+//	}
+func controlHandler(p *pcrf) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, p.sessionList(r.FormValue("subscriber")))
+	})
+	mux.HandleFunc("/rar", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		p.handleRAR(w, r)
+	})
+	return mux
+}
+
+// rarResult is the outcome of a RAR sent through the control API.
+type rarResult struct {
+	SessionID  string `json:"session_id"`
+	ResultCode uint32 `json:"result_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (p *pcrf) handleRAR(w http.ResponseWriter, r *http.Request) {
+	var req pushRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := req.validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var ids []string
+	if id := r.FormValue("session_id"); id != "" {
+		ids = []string{id}
+	} else if sub := r.FormValue("subscriber"); sub != "" {
+		for _, s := range p.sessionList(sub) {
+			ids = append(ids, s.SessionID)
+		}
+	} else {
+		http.Error(w, "missing session_id or subscriber", http.StatusBadRequest)
+		return
+	}
+	results := make([]rarResult, 0, len(ids))
+	for _, id := range ids {
+		res := rarResult{SessionID: id}
+		raa, err := p.push(id, &req.change, req.ReleaseCause)
+		if err != nil {
+			res.Error = err.Error()
+		} else {
+			res.ResultCode = raa.ResultCode
+		}
+		results = append(results, res)
+	}
+	writeJSON(w, results)
+}
+
+func (req *pushRequest) validate() error {
+	if err := req.change.validate(); err != nil {
+		return err
+	}
+	if req.ReleaseCause == "" {
+		return nil
+	}
+	req.ReleaseCause = strings.ToUpper(req.ReleaseCause)
+	if _, ok := releaseCauses[req.ReleaseCause]; !ok {
+		return fmt.Errorf("unknown release cause %q", req.ReleaseCause)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(v)
+}
@@ -0,0 +1,111 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command diam-pcrf is a PCRF simulator for Gx.
+//
+// It answers CCR-Initial with the Charging-Rule-Install, Event-Trigger
+// and QoS-Information configured for the subscriber or APN of the
+// session, answers CCR-Update with the policy changes configured for the
+// Event-Triggers it reports, and logs every Event-Trigger it receives.
+// A local HTTP control API lists the active sessions and pushes RARs
+// with rule installs, rule removals or a session release, which makes
+// it possible to run PCEF integration tests offline.
+//
+// An example configuration file:
+//
+//	{
+//	  "default": {
+//	    "install": {"rules": ["default"]},
+//	    "event_triggers": ["RAT_CHANGE"],
+//	    "updates": {"RAT_CHANGE": {"install": {"rules": ["rat-changed"]}}}
+//	  },
+//	  "apns": {
+//	    "ims": {"install": {"definitions": [{"name": "sip", "flows": ["permit out 17 from any to any 5060"]}]}}
+//	  },
+//	  "subscribers": {
+//	    "001010000000002": {"install": {"rule_bases": ["gold"]}},
+//	    "001010000000009": {"result_code": 5003}
+//	  }
+//	}
+//
+// The policy of a session is the one of its subscriber, or else the one
+// of its APN, or else the default. Examples of control API use:
+//
+//	curl localhost:9869/sessions
+//	curl -d '{"install":{"rules":["throttle"]},"remove":{"rule_bases":["gold"]}}' 'localhost:9869/rar?subscriber=001010000000002'
+//	curl -d '{"release_cause":"UNSPECIFIED_REASON"}' 'localhost:9869/rar?session_id=pgw%3B1%3B1'
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gx"
+)
+
+func main() {
+	addr := flag.String("addr", ":3868", "address in the form of ip:port to listen on")
+	httpAddr := flag.String("http_addr", "127.0.0.1:9869", "address in the form of ip:port for the HTTP control API")
+	host := flag.String("diam_host", "pcrf", "diameter identity host")
+	realm := flag.String("diam_realm", "go-diameter", "diameter identity realm")
+	certFile := flag.String("cert_file", "", "tls certificate file (optional)")
+	keyFile := flag.String("key_file", "", "tls key file (optional)")
+	networkType := flag.String("network_type", "tcp", "protocol type tcp/sctp")
+	configFile := flag.String("config", "pcrf.json", "configuration file")
+	flag.Parse()
+
+	conf, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	server := &gx.Server{
+		OriginHost:  datatype.DiameterIdentity(*host),
+		OriginRealm: datatype.DiameterIdentity(*realm),
+	}
+	p := newPCRF(server, conf)
+	log.Printf("Loaded %d APN and %d subscriber policies from %s", len(conf.APNs), len(conf.Subscribers), *configFile)
+
+	settings := &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(*host),
+		OriginRealm:      datatype.DiameterIdentity(*realm),
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+	}
+	mux := sm.New(settings)
+	mux.HandleIdx(gx.CCRIndex, p)
+	mux.HandleIdx(gx.RAAIndex, p)
+	server.ErrorReporter = mux
+	go printErrors(mux.ErrorReports())
+
+	if len(*httpAddr) > 0 {
+		go func() {
+			log.Println("Starting HTTP control API on", *httpAddr)
+			log.Fatal(http.ListenAndServe(*httpAddr, controlHandler(p)))
+		}()
+	}
+
+	if err = listen(*networkType, *addr, *certFile, *keyFile, mux); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func printErrors(ec <-chan *diam.ErrorReport) {
+	for err := range ec {
+		log.Println(err)
+	}
+}
+
+func listen(networkType, addr, cert, key string, handler diam.Handler) error {
+	if len(cert) > 0 && len(key) > 0 {
+		log.Println("Starting secure diameter server on", addr)
+		return diam.ListenAndServeNetworkTLS(networkType, addr, cert, key, handler, nil)
+	}
+	log.Println("Starting diameter server on", addr)
+	return diam.ListenAndServeNetwork(networkType, addr, handler, nil)
+}
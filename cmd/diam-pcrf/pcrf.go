@@ -0,0 +1,256 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"log"
+	"sort"
+	"sync"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gx"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// pcrf makes the policy decisions of a gx.Server from the configuration
+// and keeps track of what was provisioned in each session, until it is
+// terminated or its connection is closed.
+type pcrf struct {
+	server *gx.Server
+	conf   *config
+
+	mu       sync.Mutex
+	sessions map[string]*session
+	watched  map[diam.Conn]bool // Connections of sessions
+}
+
+// session is the state of an IP-CAN session as provisioned by the PCRF.
+type session struct {
+	conn       diam.Conn
+	subscriber string
+	apn        string
+	name       string // Of the policy.
+	policy     *policy
+	rules      map[string]bool
+	bases      map[string]bool
+	triggers   []string
+}
+
+func newPCRF(server *gx.Server, conf *config) *pcrf {
+	p := &pcrf{
+		server:   server,
+		conf:     conf,
+		sessions: make(map[string]*session),
+		watched:  make(map[diam.Conn]bool),
+	}
+	server.Policy = p
+	return p
+}
+
+// ServeDIAM implements the diam.Handler interface. It logs every CCR
+// and its Event-Triggers before handing it to the gx.Server.
+func (p *pcrf) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		p.server.ServeDIAM(c, m)
+		return
+	}
+	var ccr gx.CCR
+	if err := m.Unmarshal(&ccr); err != nil {
+		p.server.ServeDIAM(c, m)
+		return
+	}
+	log.Printf("%s from %s, session %s", ccr.CCRequestType, string(ccr.OriginHost), ccr.SessionID)
+	for _, e := range ccr.EventTrigger {
+		log.Printf("Event-Trigger %s (%d) in session %s", e, int32(e), ccr.SessionID)
+	}
+	p.server.ServeDIAM(c, m)
+	if ccr.CCRequestType != gy.InitialRequest {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if s, ok := p.sessions[ccr.SessionID]; ok && s.conn == nil {
+		s.conn = c
+		p.watch(c)
+	}
+}
+
+// watch forgets the sessions of the connection c when it is closed.
+// It must be called with p.mu held.
+func (p *pcrf) watch(c diam.Conn) {
+	cn, ok := c.(diam.CloseNotifier)
+	if !ok || p.watched[c] {
+		return
+	}
+	p.watched[c] = true
+	go func() {
+		<-cn.CloseNotify()
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(p.watched, c)
+		for id, s := range p.sessions {
+			if s.conn == c {
+				delete(p.sessions, id)
+			}
+		}
+	}()
+}
+
+// Decide implements the gx.Policy interface.
+func (p *pcrf) Decide(ccr *gx.CCR, cca *gx.CCA) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch ccr.CCRequestType {
+	case gy.InitialRequest:
+		sub := ccr.Subscriber()
+		name, pol := p.conf.policy(sub, ccr.CalledStationID)
+		if pol.ResultCode != 0 {
+			log.Printf("Rejecting session %s with %d (%s)", ccr.SessionID, pol.ResultCode, name)
+			cca.ResultCode = pol.ResultCode
+			return
+		}
+		s := &session{
+			subscriber: sub,
+			apn:        ccr.CalledStationID,
+			name:       name,
+			policy:     pol,
+			rules:      make(map[string]bool),
+			bases:      make(map[string]bool),
+		}
+		ch := &change{Install: pol.Install, EventTriggers: pol.EventTriggers, QoS: pol.QoS}
+		addChange(cca, ch)
+		s.apply(ch)
+		p.sessions[ccr.SessionID] = s
+		log.Printf("Session %s of %s uses the %s policy", ccr.SessionID, sub, name)
+	case gy.UpdateRequest:
+		s, ok := p.sessions[ccr.SessionID]
+		if !ok {
+			return
+		}
+		for _, e := range ccr.EventTrigger {
+			if ch := s.policy.Updates[e.String()]; ch != nil {
+				addChange(cca, ch)
+				s.apply(ch)
+			}
+		}
+	case gy.TerminationRequest:
+		delete(p.sessions, ccr.SessionID)
+	}
+}
+
+// addChange adds a policy change to the CCA.
+func addChange(cca *gx.CCA, ch *change) {
+	cca.ChargingRuleRemove = append(cca.ChargingRuleRemove, ch.Remove.remove()...)
+	cca.ChargingRuleInstall = append(cca.ChargingRuleInstall, ch.Install.install()...)
+	if ets, _ := eventTriggers(ch.EventTriggers); len(ets) > 0 {
+		cca.EventTrigger = ets
+	}
+	if q := ch.QoS.information(); q != nil {
+		cca.QoSInformation = q
+	}
+}
+
+// apply records a policy change sent to the PCEF.
+func (s *session) apply(ch *change) {
+	for _, name := range ch.Remove.Rules {
+		delete(s.rules, name)
+	}
+	for _, name := range ch.Remove.RuleBases {
+		delete(s.bases, name)
+	}
+	for _, name := range ch.Install.Rules {
+		s.rules[name] = true
+	}
+	for _, r := range ch.Install.Definitions {
+		s.rules[r.Name] = true
+	}
+	for _, name := range ch.Install.RuleBases {
+		s.bases[name] = true
+	}
+	if len(ch.EventTriggers) > 0 {
+		s.triggers = ch.EventTriggers
+	}
+}
+
+// push sends a RAR with the policy change to the PCEF of a session.
+// When releaseCause is not empty the RAR asks the PCEF to terminate the
+// session instead.
+func (p *pcrf) push(sessionID string, ch *change, releaseCause string) (*gx.RAA, error) {
+	rar := &gx.RAR{
+		ReAuthRequestType:   gx.AuthorizeOnly,
+		ChargingRuleRemove:  ch.Remove.remove(),
+		ChargingRuleInstall: ch.Install.install(),
+		QoSInformation:      ch.QoS.information(),
+	}
+	rar.EventTrigger, _ = eventTriggers(ch.EventTriggers)
+	if releaseCause != "" {
+		cause := releaseCauses[releaseCause]
+		rar.SessionReleaseCause = &cause
+	}
+	raa, err := p.server.ReAuth(sessionID, rar)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("RAR to session %s answered with %d", sessionID, raa.ResultCode)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	switch s := p.sessions[sessionID]; {
+	case s == nil:
+	case raa.ResultCode == diam.UnknownSessionID:
+		delete(p.sessions, sessionID)
+	case raa.ResultCode == diam.Success && releaseCause == "":
+		s.apply(ch)
+	}
+	return raa, nil
+}
+
+// sessionInfo describes an active session.
+type sessionInfo struct {
+	SessionID     string   `json:"session_id"`
+	OriginHost    string   `json:"origin_host"`
+	Subscriber    string   `json:"subscriber"`
+	APN           string   `json:"apn"`
+	Policy        string   `json:"policy"`
+	Rules         []string `json:"rules"`
+	RuleBases     []string `json:"rule_bases"`
+	EventTriggers []string `json:"event_triggers"`
+}
+
+// sessionList returns the active sessions, optionally of a single
+// subscriber.
+func (p *pcrf) sessionList(subscriber string) []sessionInfo {
+	list := []sessionInfo{}
+	for _, id := range p.server.Sessions() {
+		ccr, ok := p.server.Session(id)
+		if !ok || (subscriber != "" && ccr.Subscriber() != subscriber) {
+			continue
+		}
+		info := sessionInfo{
+			SessionID:  id,
+			OriginHost: string(ccr.OriginHost),
+			Subscriber: ccr.Subscriber(),
+			APN:        ccr.CalledStationID,
+		}
+		p.mu.Lock()
+		if s, ok := p.sessions[id]; ok {
+			info.Policy = s.name
+			info.Rules = sortedKeys(s.rules)
+			info.RuleBases = sortedKeys(s.bases)
+			info.EventTriggers = s.triggers
+		}
+		p.mu.Unlock()
+		list = append(list, info)
+	}
+	return list
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
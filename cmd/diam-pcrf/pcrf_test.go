@@ -0,0 +1,197 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gx"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+func TestLoadConfig(t *testing.T) {
+	c, err := loadConfig("pcrf.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		subscriber, apn, want string
+	}{
+		{"001010000000002", "ims", "subscriber 001010000000002"},
+		{"001010000000001", "ims", "apn ims"},
+		{"001010000000001", "internet", "default"},
+	} {
+		if name, _ := c.policy(tc.subscriber, tc.apn); name != tc.want {
+			t.Errorf("Unexpected policy for %s/%s. Want %q, have %q", tc.subscriber, tc.apn, tc.want, name)
+		}
+	}
+	bad := &config{Default: &policy{Updates: map[string]*change{"NO_SUCH_TRIGGER": {}}}}
+	if err = bad.validate(); err == nil {
+		t.Fatal("Unknown event trigger was accepted")
+	}
+}
+
+func pushRAR(t *testing.T, url, body string) []rarResult {
+	t.Helper()
+	resp, err := http.Post(url, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected status: %s", resp.Status)
+	}
+	var results []rarResult
+	if err = json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		t.Fatal(err)
+	}
+	return results
+}
+
+func TestPCRF(t *testing.T) {
+	conf, err := loadConfig("pcrf.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &gx.Server{OriginHost: "pcrf", OriginRealm: "test", Timeout: time.Second}
+	p := newPCRF(server, conf)
+	mux := sm.New(smtest.Settings("pcrf"))
+	mux.HandleIdx(gx.CCRIndex, p)
+	mux.HandleIdx(gx.RAAIndex, p)
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+	api := httptest.NewServer(controlHandler(p))
+	defer api.Close()
+
+	reauth := make(chan *gx.RAR, 1)
+	pcef := &gx.Client{
+		OriginHost:       "pgw",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		OnReAuth: func(s *gx.Session, rar *gx.RAR) uint32 {
+			reauth <- rar
+			return diam.Success
+		},
+	}
+	cmux := sm.New(smtest.Settings("pgw"))
+	cmux.HandleIdx(gx.CCAIndex, pcef)
+	cmux.HandleIdx(gx.RARIndex, pcef)
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.GX_CHARGING_CONTROL_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	establish := func(sub, apn string) (*gx.Session, *gx.CCA) {
+		t.Helper()
+		s, cca, err := pcef.Establish(c, &gx.CCR{
+			SubscriptionID:  []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: sub}},
+			CalledStationID: apn,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return s, cca
+	}
+
+	// APN policy with a dynamic rule.
+	s, cca := establish("001010000000001", "ims")
+	if s == nil {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if d := s.Rule("sip"); d == nil || len(d.FlowInformation) != 2 || *d.QoSInformation.QCI != 5 {
+		t.Fatalf("Unexpected rule: %+v", d)
+	}
+	if !s.HasEventTrigger(gx.LossOfBearer) {
+		t.Fatalf("Unexpected event triggers: %v", s.EventTriggers())
+	}
+	if _, err = s.Terminate(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rejected subscriber.
+	if s, cca = establish("001010000000009", "internet"); s != nil || cca.ResultCode != diam.AuthorizationRejected {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+
+	// Subscriber policy with an update on USAGE_REPORT.
+	s, cca = establish("001010000000002", "internet")
+	if s == nil {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if b := s.RuleBases(); !reflect.DeepEqual(b, []string{"gold"}) {
+		t.Fatalf("Unexpected rule bases: %v", b)
+	}
+	if _, err = s.Update(&gx.CCR{EventTrigger: []gx.EventTrigger{gx.UsageReport}}); err != nil {
+		t.Fatal(err)
+	}
+	if r, b := s.Rules(), s.RuleBases(); !reflect.DeepEqual(r, []string{"throttle"}) || len(b) != 0 {
+		t.Fatalf("Unexpected rules: %v, rule bases: %v", r, b)
+	}
+
+	results := pushRAR(t, api.URL+"/rar?subscriber=001010000000002",
+		`{"install":{"definitions":[{"name":"video","rating_group":20}]},"remove":{"rules":["throttle"]}}`)
+	if len(results) != 1 || results[0].SessionID != s.ID || results[0].ResultCode != diam.Success {
+		t.Fatalf("Unexpected RAR results: %+v", results)
+	}
+	<-reauth
+	if r := s.Rules(); !reflect.DeepEqual(r, []string{"video"}) {
+		t.Fatalf("Unexpected rules: %v", r)
+	}
+	list := p.sessionList("")
+	if len(list) != 1 || list[0].Policy != "subscriber 001010000000002" || !reflect.DeepEqual(list[0].Rules, []string{"video"}) {
+		t.Fatalf("Unexpected sessions: %+v", list)
+	}
+
+	results = pushRAR(t, api.URL+"/rar?session_id="+url.QueryEscape(s.ID), `{"release_cause":"unspecified_reason"}`)
+	if len(results) != 1 || results[0].ResultCode != diam.Success {
+		t.Fatalf("Unexpected RAR results: %+v", results)
+	}
+	if rar := <-reauth; rar.SessionReleaseCause == nil || *rar.SessionReleaseCause != gx.UnspecifiedReason {
+		t.Fatalf("Unexpected RAR: %+v", rar)
+	}
+	if _, err = s.Terminate(nil); err != nil {
+		t.Fatal(err)
+	}
+	if list = p.sessionList(""); len(list) != 0 {
+		t.Fatalf("Unexpected sessions: %+v", list)
+	}
+
+	// Sessions are forgotten when their connection is closed.
+	establish("001010000000002", "internet")
+	c.Close()
+	for deadline := time.Now().Add(time.Second); ; time.Sleep(10 * time.Millisecond) {
+		p.mu.Lock()
+		n := len(p.sessions)
+		p.mu.Unlock()
+		if n == 0 && len(server.Sessions()) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d sessions left after close", n)
+		}
+	}
+}
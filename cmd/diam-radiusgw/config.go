@@ -0,0 +1,87 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// config is the format of the configuration file.
+type config struct {
+	DefaultSecret string            `json:"default_secret,omitempty"`
+	Clients       map[string]string `json:"clients,omitempty"` // Secrets by IP or CIDR.
+
+	nets []client
+}
+
+// client is a RADIUS client network and its shared secret.
+type client struct {
+	net    *net.IPNet
+	secret []byte
+}
+
+func loadConfig(name string) (*config, error) {
+	b, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	var c config
+	if err = json.Unmarshal(b, &c); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	if err = c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", name, err)
+	}
+	return &c, nil
+}
+
+// validate parses the clients of c.
+func (c *config) validate() error {
+	c.nets = nil
+	for addr, secret := range c.Clients {
+		if len(secret) == 0 {
+			return fmt.Errorf("client %s: empty secret", addr)
+		}
+		cidr := addr
+		if !strings.Contains(cidr, "/") {
+			ip := net.ParseIP(addr)
+			if ip == nil {
+				return fmt.Errorf("client %s: invalid address", addr)
+			}
+			if ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("client %s: invalid network", addr)
+		}
+		c.nets = append(c.nets, client{net: n, secret: []byte(secret)})
+	}
+	return nil
+}
+
+// secret returns the shared secret of the RADIUS client at ip: the one
+// of the most specific network containing it, or else the default
+// secret, or nil.
+func (c *config) secret(ip net.IP) []byte {
+	var secret []byte
+	best := -1
+	for _, cl := range c.nets {
+		if ones, _ := cl.net.Mask.Size(); ones > best && cl.net.Contains(ip) {
+			secret, best = cl.secret, ones
+		}
+	}
+	if secret == nil && len(c.DefaultSecret) > 0 {
+		secret = []byte(c.DefaultSecret)
+	}
+	return secret
+}
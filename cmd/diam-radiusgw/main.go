@@ -0,0 +1,172 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command diam-radiusgw is a RADIUS/Diameter translation gateway for the
+// Network Access application.
+//
+// It accepts RADIUS Access-Requests and Accounting-Requests on UDP,
+// forwards them as AARs and ACRs to a Diameter NASREQ server, and
+// translates the answers back to Access-Accept, Access-Challenge,
+// Access-Reject and Accounting-Response, as described in RFC 7155
+// section 9. The connection to the Diameter server is established on
+// the first request and re-established after it is lost.
+//
+// An example configuration file:
+//
+//	{
+//	  "default_secret": "",
+//	  "clients": {
+//	    "127.0.0.1": "testing123",
+//	    "10.0.0.0/8": "nas-secret"
+//	  }
+//	}
+//
+// Clients are IP addresses or networks in CIDR notation, and the secret
+// of a RADIUS client is the one of the most specific entry containing
+// its address, or else the default secret. Packets of clients without a
+// secret are ignored.
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"sync"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/radius"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+func main() {
+	authAddr := flag.String("auth_addr", ":1812", "address in the form of ip:port to listen on for RADIUS authentication")
+	acctAddr := flag.String("acct_addr", ":1813", "address in the form of ip:port to listen on for RADIUS accounting, empty to disable")
+	server := flag.String("server", "localhost:3868", "address in the form of ip:port of the diameter server")
+	host := flag.String("diam_host", "radiusgw", "diameter identity host")
+	realm := flag.String("diam_realm", "go-diameter", "diameter identity realm")
+	destRealm := flag.String("dest_realm", "go-diameter", "diameter destination realm")
+	destHost := flag.String("dest_host", "", "diameter destination host (optional)")
+	networkType := flag.String("network_type", "tcp", "protocol type tcp/sctp")
+	configFile := flag.String("config", "radiusgw.json", "configuration file")
+	flag.Parse()
+
+	conf, err := loadConfig(*configFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Loaded %d RADIUS clients from %s", len(conf.Clients), *configFile)
+
+	gw := &radius.Gateway{
+		OriginHost:       datatype.DiameterIdentity(*host),
+		OriginRealm:      datatype.DiameterIdentity(*realm),
+		DestinationRealm: datatype.DiameterIdentity(*destRealm),
+		DestinationHost:  datatype.DiameterIdentity(*destHost),
+		Secret:           conf.secret,
+	}
+	settings := &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(*host),
+		OriginRealm:      datatype.DiameterIdentity(*realm),
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+	}
+	p := newPeer(gw, settings, *networkType, *server)
+	go printErrors(p.mux.ErrorReports())
+
+	errc := make(chan error, 2)
+	for _, addr := range []string{*authAddr, *acctAddr} {
+		if len(addr) == 0 {
+			continue
+		}
+		pc, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Println("Starting RADIUS gateway on", addr)
+		go func() { errc <- gw.Serve(pc) }()
+	}
+	log.Fatal(<-errc)
+}
+
+// peer is the connection of the gateway to the Diameter server, dialed
+// on demand.
+type peer struct {
+	mux     *sm.StateMachine
+	cli     *sm.Client
+	network string
+	addr    string
+
+	mu sync.Mutex
+	c  diam.Conn
+}
+
+// newPeer returns the peer at addr for gw, and sets the connection and
+// error reporter of gw.
+func newPeer(gw *radius.Gateway, settings *sm.Settings, network, addr string) *peer {
+	mux := sm.New(settings)
+	mux.HandleIdx(radius.AAAIndex, gw)
+	mux.HandleIdx(radius.ACAIndex, gw)
+	p := &peer{
+		mux: mux,
+		cli: &sm.Client{
+			Handler:        mux,
+			MaxRetransmits: 3,
+			EnableWatchdog: true,
+			AuthApplicationID: []*diam.AVP{
+				diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.NETWORK_ACCESS_APP_ID)),
+			},
+		},
+		network: network,
+		addr:    addr,
+	}
+	gw.Conn = p.conn
+	gw.ErrorReporter = mux
+	return p
+}
+
+// conn returns the connection to the Diameter server, dialing it when
+// there is none.
+func (p *peer) conn() (diam.Conn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.c != nil {
+		return p.c, nil
+	}
+	c, err := p.cli.DialNetwork(p.network, p.addr)
+	if err != nil {
+		return nil, err
+	}
+	log.Println("Connected to diameter server", c.RemoteAddr())
+	p.c = c
+	if cn, ok := c.(diam.CloseNotifier); ok {
+		go func() {
+			<-cn.CloseNotify()
+			log.Println("Disconnected from diameter server", c.RemoteAddr())
+			p.mu.Lock()
+			if p.c == c {
+				p.c = nil
+			}
+			p.mu.Unlock()
+		}()
+	}
+	return c, nil
+}
+
+// close closes the connection to the Diameter server, if any.
+func (p *peer) close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.c != nil {
+		p.c.Close()
+		p.c = nil
+	}
+}
+
+func printErrors(ec <-chan *diam.ErrorReport) {
+	for err := range ec {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,143 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package main
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/nasreq"
+	"github.com/fiorix/go-diameter/v4/diam/radius"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	c, err := loadConfig("radiusgw.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		ip     string
+		secret string
+	}{
+		{"127.0.0.1", "testing123"},
+		{"10.9.9.9", "nas-secret"},
+		{"10.1.2.3", "branch-secret"},
+		{"192.168.1.1", ""},
+	} {
+		if s := c.secret(net.ParseIP(tc.ip)); string(s) != tc.secret {
+			t.Fatalf("Unexpected secret for %s: %q", tc.ip, s)
+		}
+	}
+	c.DefaultSecret = "default"
+	if s := c.secret(net.ParseIP("192.168.1.1")); string(s) != "default" {
+		t.Fatalf("Unexpected default secret: %q", s)
+	}
+
+	for _, bad := range []string{
+		`{"clients":{"10.0.0.0/33":"x"}}`,
+		`{"clients":{"localhost":"x"}}`,
+		`{"clients":{"127.0.0.1":""}}`,
+	} {
+		name := filepath.Join(t.TempDir(), "bad.json")
+		if err = os.WriteFile(name, []byte(bad), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = loadConfig(name); err == nil {
+			t.Fatalf("Invalid configuration was accepted: %s", bad)
+		}
+	}
+}
+
+func TestGateway(t *testing.T) {
+	server := &nasreq.Server{
+		OriginHost:  "aaa",
+		OriginRealm: "test",
+		Credentials: nasreq.CredentialsFunc(func(user string) ([]byte, error) {
+			if user == "nemo" {
+				return []byte("arctangent"), nil
+			}
+			return nil, nasreq.ErrUnknownUser
+		}),
+	}
+	mux := sm.New(smtest.Settings("aaa"))
+	mux.HandleIdx(nasreq.AARIndex, server)
+	mux.HandleIdx(nasreq.STRIndex, server)
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	conf, err := loadConfig("radiusgw.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	gw := &radius.Gateway{
+		OriginHost:       "gw",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Secret:           conf.secret,
+		Timeout:          time.Second,
+	}
+	p := newPeer(gw, smtest.Settings("gw"), "tcp", srv.Addr)
+	defer p.close()
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pc.Close()
+	go gw.Serve(pc)
+
+	c, err := net.Dial("udp", pc.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	secret := []byte("testing123")
+	for i, tc := range []struct {
+		password string
+		code     uint8
+	}{
+		{"arctangent", radius.AccessAccept},
+		{"wrong", radius.AccessReject},
+	} {
+		req := &radius.Packet{Code: radius.AccessRequest, Identifier: uint8(i)}
+		copy(req.Authenticator[:], "0123456789abcdef")
+		req.Authenticator[0] = uint8(i)
+		req.Add(radius.AttrUserName, []byte("nemo"))
+		req.Add(radius.AttrUserPassword, radius.HidePassword([]byte(tc.password), secret, req.Authenticator))
+		req.Add(radius.AttrNASIdentifier, []byte("nas"))
+		b, err := req.EncodeRequest(secret)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = c.Write(b); err != nil {
+			t.Fatal(err)
+		}
+		c.SetReadDeadline(time.Now().Add(2 * time.Second))
+		buf := make([]byte, 4096)
+		n, err := c.Read(buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !radius.VerifyReply(buf[:n], req, secret) {
+			t.Fatal("Invalid reply authenticator")
+		}
+		resp, err := radius.Parse(buf[:n])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.Code != tc.code {
+			t.Fatalf("Unexpected reply code for %q: %d", tc.password, resp.Code)
+		}
+	}
+	if n := len(server.Sessions()); n != 1 {
+		t.Fatalf("Unexpected number of sessions: %d", n)
+	}
+}
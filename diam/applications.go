@@ -13,10 +13,15 @@ const (
 	BASE_ACCOUNTING_APP_ID     = 3
 	CHARGING_CONTROL_APP_ID    = 4
 	TGPP_APP_ID                = 4
+	DIAMETER_EAP_APP_ID        = 5
+	TGPP_CX_APP_ID             = 16777216
+	TGPP_SH_APP_ID             = 16777217
 	RX_APP_ID                  = 16777236
 	GX_CHARGING_CONTROL_APP_ID = 16777238
+	TGPP_STA_APP_ID            = 16777250
 	TGPP_S6A_APP_ID            = 16777251
 	TGPP_S13_APP_ID            = 16777252
+	TGPP_SWM_APP_ID            = 16777264
 	TGPP_SWX_APP_ID            = 16777265
 	DIAMETER_SY_APP_ID         = 16777302
 )
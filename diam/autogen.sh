@@ -124,26 +124,27 @@ cat << EOF > $src
 
 package dict
 
-import (
-	"bytes"
-	"fmt"
-)
+import "fmt"
 
 // Default is a Parser object with pre-loaded
 // Base Protocol and Credit Control dictionaries.
 var Default *Parser
 
 func init() {
-	var dictionaries = []struct{ name, xml string }{
-		{"Base", baseXML},
-		{"Credit Control", creditcontrolXML},
-		{"Gx Charging Control", gxcreditcontrolXML},
-		{"Network Access Server", networkaccessserverXML},
-		{"TGPP", tgpprorfXML},
-		{"TGPP_Rx", tgpprxXML},
-		{"TGPP_S6a", tgpps6aXML},
-		{"TGPP_S13", tgpps13XML},
-		{"TGPP_Swx", tgppswxXML},
+	var dictionaries = []struct{ name, file, xml string }{
+		{"Base", "testdata/base.xml", baseXML},
+		{"Credit Control", "testdata/credit_control.xml", creditcontrolXML},
+		{"Gx Charging Control", "testdata/gx_credit_control.xml", gxcreditcontrolXML},
+		{"Network Access Server", "testdata/network_access_server.xml", networkaccessserverXML},
+		{"TGPP", "testdata/tgpp_ro_rf.xml", tgpprorfXML},
+		{"TGPP_Rx", "testdata/tgpp_rx.xml", tgpprxXML},
+		{"TGPP_S6a", "testdata/tgpp_s6a.xml", tgpps6aXML},
+		{"TGPP_S13", "testdata/tgpp_s13.xml", tgpps13XML},
+		{"TGPP_Swx", "testdata/tgpp_swx.xml", tgppswxXML},
+		{"Diameter Sy", "testdata/diameter_sy.xml", diametersyXML},
+		{"TGPP_Cx", "testdata/tgpp_cx.xml", tgppcxXML},
+		{"TGPP_Sh", "testdata/tgpp_sh.xml", tgppshXML},
+		{"Diameter EAP", "testdata/diameter_eap.xml", diametereapXML},
 	}
 	var err error
 	Default, err = NewParser()
@@ -151,7 +152,7 @@ func init() {
 		panic(err)
 	}
 	for _, dict := range dictionaries {
-		err = Default.Load(bytes.NewReader([]byte(dict.xml)))
+		err = Default.load(source{name: dict.file, data: []byte(dict.xml)})
 		if err != nil {
 			panic(fmt.Sprintf("Cannot load %s dictionary: %s", dict.name, err))
 		}
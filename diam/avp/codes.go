@@ -8,6 +8,7 @@ package avp
 
 // Diameter AVP types.
 const (
+	AAAFailureIndication                       = 1518
 	AbortCause                                 = 500
 	AcceptableServiceInfo                      = 526
 	AccessNetworkChargingAddress               = 501
@@ -20,6 +21,7 @@ const (
 	AccessTransferType                         = 2710
 	AccountExpiration                          = 2309
 	AccountingAuthMethod                       = 406
+	AccountingEAPAuthMethod                    = 465
 	AccountingInputOctets                      = 363
 	AccountingInputPackets                     = 365
 	AccountingOutputOctets                     = 364
@@ -53,6 +55,7 @@ const (
 	AFCorrelationInformation                   = 1276
 	AFRequestedData                            = 551
 	AFSignallingProtocol                       = 529
+	AlertReason                                = 1434
 	AllAPNConfigurationsIncludedIndicator      = 1428
 	AllocationRetentionPriority                = 1034
 	AlternateChargedPartyAddress               = 1280
@@ -87,7 +90,10 @@ const (
 	ARAPSecurity                               = 73
 	ARAPSecurityData                           = 74
 	ARAPZoneAccess                             = 72
+	ASNumber                                   = 722
+	AssociatedIdentities                       = 632
 	AssociatedPartyAddress                     = 2035
+	AssociatedRegisteredIdentities             = 647
 	AssociatedURI                              = 856
 	AuthApplicationID                          = 258
 	AuthenticationInfo                         = 1413
@@ -114,6 +120,8 @@ const (
 	CalleeInformation                          = 565
 	CallingPartyAddress                        = 831
 	CallingStationID                           = 31
+	CallReferenceInfo                          = 720
+	CallReferenceNumber                        = 721
 	CancellationType                           = 1420
 	CarrierSelectRoutingInformation            = 2023
 	CauseCode                                  = 861
@@ -140,6 +148,7 @@ const (
 	ChargedParty                               = 857
 	ChargeReasonCode                           = 2118
 	ChargingCharacteristicsSelectionMode       = 2066
+	ChargingInformation                        = 618
 	ChargingRuleBaseName                       = 1004
 	ChargingRuleDefinition                     = 1003
 	ChargingRuleInstall                        = 1001
@@ -177,8 +186,10 @@ const (
 	CSGSubscriptionData                        = 1436
 	CUGInformation                             = 2304
 	CurrencyCode                               = 425
+	CurrentLocation                            = 707
 	CurrentTariff                              = 2056
 	DataCodingScheme                           = 2001
+	DataReference                              = 703
 	DefaultEPSBearerQoS                        = 1049
 	DeferredLocationEventType                  = 1230
 	DeliveryReportRequested                    = 1216
@@ -190,16 +201,26 @@ const (
 	DestinationInterface                       = 2002
 	DestinationRealm                           = 283
 	Diagnostics                                = 2039
+	DigestAlgorithm                            = 111
+	DigestHA1                                  = 121
+	DigestQoP                                  = 110
+	DigestRealm                                = 104
 	DirectDebitingFailureHandling              = 428
 	DisconnectCause                            = 273
 	DomainName                                 = 1200
 	DRMContent                                 = 1221
 	DRMP                                       = 301
 	DSAFlags                                   = 1422
+	DSAITag                                    = 711
 	DSRFlags                                   = 1421
 	DynamicAddressFlag                         = 2051
 	DynamicAddressFlagExtension                = 2068
+	EAPKeyName                                 = 102
+	EAPMasterSessionKey                        = 464
+	EAPPayload                                 = 462
+	EAPReissuedPayload                         = 463
 	EarlyMediaDescription                      = 1272
+	EmergencyServices                          = 1538
 	Envelope                                   = 1266
 	EnvelopeEndTime                            = 1267
 	EnvelopeReporting                          = 1268
@@ -220,6 +241,7 @@ const (
 	ExperimentalResultCode                     = 298
 	ExpirationDate                             = 1439
 	Expires                                    = 888
+	ExpiryTime                                 = 709
 	Exponent                                   = 429
 	ExtendedAPNAMBRDL                          = 2848
 	ExtendedAPNAMBRUL                          = 2849
@@ -272,6 +294,7 @@ const (
 	FramedRoute                                = 22
 	FramedRouting                              = 10
 	FromAddress                                = 2708
+	FullNetworkName                            = 1516
 	GCSIdentifier                              = 538
 	GERANVector                                = 1416
 	GGSNAddress                                = 847
@@ -290,6 +313,8 @@ const (
 	HostIPAddress                              = 257
 	HPLMNODB                                   = 1418
 	ICSIndicator                               = 1491
+	IDAFlags                                   = 1441
+	IdentitySet                                = 708
 	IdleTimeout                                = 28
 	IDRFlags                                   = 1490
 	IMEI                                       = 1402
@@ -303,6 +328,7 @@ const (
 	IMSInformation                             = 876
 	IMSIUnauthenticatedFlag                    = 2308
 	IMSVisitedNetworkIdentifier                = 2713
+	IMSVoiceOverPSSessionsSupported            = 1492
 	InbandSecurityID                           = 299
 	IncomingTrunkGroupID                       = 852
 	IncrementalCost                            = 2062
@@ -325,6 +351,7 @@ const (
 	ItemNumber                                 = 1419
 	KASME                                      = 1450
 	Kc                                         = 1453
+	LastUEActivityTime                         = 1494
 	LCSAPN                                     = 1231
 	LCSClientDialedByMS                        = 1233
 	LCSClientExternalID                        = 1234
@@ -339,6 +366,7 @@ const (
 	LCSPrivacyException                        = 1475
 	LCSRequestorID                             = 1239
 	LCSRequestorIDString                       = 1240
+	LIAFlags                                   = 653
 	LineType                                   = 581
 	LIPAPermission                             = 1618
 	Load                                       = 650
@@ -346,6 +374,7 @@ const (
 	LoadValue                                  = 652
 	LocalGWInsertedIndication                  = 2604
 	LocalSequenceNumber                        = 2063
+	LocalTimeZoneIndication                    = 718
 	LocationEstimate                           = 1242
 	LocationEstimateType                       = 1243
 	LocationType                               = 1244
@@ -357,6 +386,7 @@ const (
 	LoginLATService                            = 34
 	LoginService                               = 15
 	LoginTCPPort                               = 16
+	LooseRouteIndication                       = 638
 	LowBalanceIndication                       = 2020
 	LowPriorityIndicator                       = 2602
 	MAInformation                              = 570
@@ -407,6 +437,7 @@ const (
 	MMSInformation                             = 877
 	MMTelInformation                           = 2030
 	MMTelSServiceType                          = 2031
+	MobileNodeIdentifier                       = 506
 	MOLR                                       = 1485
 	MonitoringKey                              = 1066
 	MPSIdentifier                              = 528
@@ -414,10 +445,14 @@ const (
 	MSCAddress                                 = 3417
 	MSISDN                                     = 701
 	MTCIWFAddress                              = 3406
+	MultipleRegistrationIndication             = 648
 	MultipleServicesCreditControl              = 456
 	MultipleServicesIndicator                  = 455
 	MultiRoundTimeOut                          = 272
 	NASFilterRule                              = 400
+	NASIdentifier                              = 32
+	NASIPAddress                               = 4
+	NASIPv6Address                             = 95
 	NASPort                                    = 5
 	NASPortID                                  = 87
 	NASPortType                                = 61
@@ -459,12 +494,14 @@ const (
 	Offline                                    = 1008
 	OfflineCharging                            = 1278
 	OMCID                                      = 1466
+	OneTimeNotification                        = 712
 	Online                                     = 1009
 	OnlineChargingFlag                         = 2303
 	OperatorDeterminedBarring                  = 1425
 	OptionalCapability                         = 605
 	OriginatingIOI                             = 839
 	OriginatingLineInfo                        = 94
+	OriginatingRequest                         = 633
 	Originator                                 = 864
 	OriginatorAddress                          = 886
 	OriginatorInterface                        = 2009
@@ -490,6 +527,8 @@ const (
 	PDPContext                                 = 1469
 	PDPContextType                             = 1247
 	PDPType                                    = 1470
+	PendingPolicyCounterChangeTime             = 2906
+	PendingPolicyCounterInformation            = 2905
 	PLMNClient                                 = 1482
 	PoCChangeCondition                         = 1261
 	PoCChangeTime                              = 1262
@@ -504,20 +543,28 @@ const (
 	PoCUserRole                                = 1252
 	PoCUserRoleIDs                             = 1253
 	PoCUserRoleinfoUnits                       = 1254
+	PolicyCounterIdentifier                    = 2901
+	PolicyCounterStatus                        = 2902
+	PolicyCounterStatusReport                  = 2903
 	PortLimit                                  = 62
 	PositioningData                            = 1245
+	PPRFlags                                   = 1508
 	Precedence                                 = 1010
 	PreemptionCapability                       = 1047
 	PreemptionControlInfo                      = 553
 	PreemptionVulnerability                    = 1048
 	PreferredAoCCurrency                       = 2315
+	PrepagingSupported                         = 717
 	PresenceReportingAreaIdentifier            = 2821
 	PresenceReportingAreaInformation           = 2822
 	PresenceReportingAreaStatus                = 2823
+	PrimaryChargingCollectionFunctionName      = 621
+	PrimaryEventChargingFunctionName           = 619
 	Priority                                   = 1209
 	PriorityIndication                         = 3006
 	PriorityLevel                              = 1046
 	PrioritySharingIndicator                   = 550
+	PriviledgedSenderIndication                = 652
 	ProductName                                = 269
 	Prompt                                     = 76
 	ProxyHost                                  = 280
@@ -528,6 +575,7 @@ const (
 	PSFurnishChargingInformation               = 865
 	PSInformation                              = 874
 	PUAFlags                                   = 1442
+	PublicIdentity                             = 601
 	PURFlags                                   = 1635
 	QoSClassIdentifier                         = 1028
 	QoSFilterRule                              = 407
@@ -575,14 +623,18 @@ const (
 	ReplyMessage                               = 18
 	ReplyPathRequested                         = 2011
 	ReportingReason                            = 872
+	RepositoryDataID                           = 715
 	RequestedAction                            = 436
+	RequestedDomain                            = 706
 	RequestedEUTRANAuthenticationInfo          = 1408
+	RequestedNodes                             = 713
 	RequestedPartyAddress                      = 1251
 	RequestedServiceUnit                       = 437
 	RequestedUTRANGERANAuthenticationInfo      = 1409
 	RequiredAccessInfo                         = 536
 	RequiredMBMSBearerCapabilities             = 901
 	ReservationPriority                        = 458
+	ResetID                                    = 1670
 	RestrictionFilterRule                      = 438
 	ResultCode                                 = 268
 	ResynchronizationInfo                      = 1411
@@ -598,6 +650,7 @@ const (
 	RuleActivationTime                         = 1043
 	RuleDeactivationTime                       = 1044
 	RxRequestType                              = 533
+	SARFlags                                   = 655
 	ScaleFactor                                = 2059
 	SDPAnswerTimestamp                         = 1275
 	SDPMediaComponent                          = 843
@@ -607,7 +660,11 @@ const (
 	SDPSessionDescription                      = 842
 	SDPTimeStamps                              = 1273
 	SDPType                                    = 2036
+	SecondaryChargingCollectionFunctionName    = 622
+	SecondaryEventChargingFunctionName         = 620
 	SecurityParameterIndex                     = 1056
+	SendDataIndication                         = 710
+	SequenceNumber                             = 716
 	ServedPartyIPAddress                       = 848
 	ServerAssignmentType                       = 614
 	ServerCapabilities                         = 603
@@ -617,6 +674,7 @@ const (
 	ServiceDataContainer                       = 2040
 	ServiceID                                  = 855
 	ServiceIdentifier                          = 439
+	ServiceIndication                          = 704
 	ServiceInformation                         = 873
 	ServiceInfoStatus                          = 527
 	ServiceMode                                = 2032
@@ -631,11 +689,13 @@ const (
 	ServiceTypeIdentity                        = 1484
 	ServiceURN                                 = 525
 	ServingNode                                = 2401
+	ServingNodeIndication                      = 714
 	ServingNodeType                            = 2047
 	SessionBinding                             = 270
 	SessionDirection                           = 2707
 	SessionID                                  = 263
 	SessionPriority                            = 650
+	SessionReleaseCause                        = 1045
 	SessionServerFailover                      = 271
 	SessionTimeout                             = 27
 	SGSNAddress                                = 1228
@@ -644,10 +704,13 @@ const (
 	SGWChange                                  = 2065
 	SharingKeyDL                               = 539
 	SharingKeyUL                               = 540
+	ShortNetworkName                           = 1517
 	SIPAuthDataItem                            = 612
 	SIPAuthenticate                            = 609
+	SIPAuthenticationContext                   = 611
 	SIPAuthenticationScheme                    = 608
 	SIPAuthorization                           = 610
+	SIPDigestAuthenticate                      = 635
 	SIPForkingIndication                       = 523
 	SIPItemNumber                              = 613
 	SIPMethod                                  = 824
@@ -671,6 +734,7 @@ const (
 	SMSResult                                  = 3409
 	SMStatus                                   = 2014
 	SMUserDataHeader                           = 2015
+	SNRequestType                              = 2907
 	SoftwareVersion                            = 1403
 	SourceID                                   = 649
 	SpecificAction                             = 513
@@ -683,6 +747,7 @@ const (
 	SSStatus                                   = 1477
 	StartofCharging                            = 3419
 	StartTime                                  = 2041
+	State                                      = 24
 	StatusASCode                               = 2702
 	STNSR                                      = 1433
 	StopTime                                   = 2042
@@ -695,6 +760,7 @@ const (
 	SubscriptionID                             = 443
 	SubscriptionIDData                         = 444
 	SubscriptionIDType                         = 450
+	SubsReqType                                = 705
 	SupplementaryService                       = 2048
 	SupportedFeatures                          = 628
 	SupportedVendorID                          = 265
@@ -774,7 +840,9 @@ const (
 	TWANIdentifier                             = 29
 	TWANUserLocationInfo                       = 2714
 	TypeNumber                                 = 1204
+	UARFlags                                   = 637
 	UDPSourcePort                              = 2806
+	UDRFlags                                   = 719
 	UELocalIPAddress                           = 2805
 	UESRVCCCapability                          = 1615
 	ULAFlags                                   = 1406
@@ -787,12 +855,16 @@ const (
 	UsageMonitoringReport                      = 1069
 	UsageMonitoringSupport                     = 1070
 	UsedServiceUnit                            = 446
+	UserAuthorizationType                      = 623
 	UserCSGInformation                         = 2319
 	UserData                                   = 606
+	UserDataAlreadyAvailable                   = 624
+	UserDataSh                                 = 702
 	UserEquipmentInfo                          = 458
 	UserEquipmentInfoType                      = 459
 	UserEquipmentInfoValue                     = 460
 	UserID                                     = 1444
+	UserIdentity                               = 700
 	UserLocationInfoTime                       = 2812
 	UserName                                   = 1
 	UserParticipatingType                      = 1279
@@ -812,6 +884,8 @@ const (
 	VolumeQuotaThreshold                       = 869
 	VPLMNDynamicAddressAllowed                 = 1432
 	VPLMNLIPAAllowed                           = 1617
+	WildcardedIMPU                             = 636
+	WildcardedPublicIdentity                   = 634
 	WirelineUserLocationInfo                   = 578
 	X5GMMCause                                 = 573
 	X5GSMCause                                 = 574
@@ -8,28 +8,37 @@ package diam
 
 // Diameter command codes.
 const (
-	AA                        = 265
-	AbortSession              = 274
-	Accounting                = 271
-	AuthenticationInformation = 318
-	CancelLocation            = 317
-	CapabilitiesExchange      = 257
-	CreditControl             = 272
-	DeleteSubscriberData      = 320
-	DeviceWatchdog            = 280
-	DisconnectPeer            = 282
-	InsertSubscriberData      = 319
-	MEIdentityCheck           = 324
-	MultimediaAuth            = 303
-	Notify                    = 323
-	PurgeUE                   = 321
-	ReAuth                    = 258
-	RegistrationTermination   = 304
-	Reset                     = 322
-	ServerAssignment          = 301
-	SessionTermination        = 275
-	SpendingLimit             = 8388635
-	UpdateLocation            = 316
+	AA                         = 265
+	AbortSession               = 274
+	Accounting                 = 271
+	AuthenticationInformation  = 318
+	CancelLocation             = 317
+	CapabilitiesExchange       = 257
+	CreditControl              = 272
+	DeleteSubscriberData       = 320
+	DeviceWatchdog             = 280
+	DiameterEAP                = 268
+	DisconnectPeer             = 282
+	InsertSubscriberData       = 319
+	LocationInfo               = 302
+	MEIdentityCheck            = 324
+	MultimediaAuth             = 303
+	Notify                     = 323
+	ProfileUpdate              = 307
+	PurgeUE                    = 321
+	PushNotification           = 309
+	PushProfile                = 305
+	ReAuth                     = 258
+	RegistrationTermination    = 304
+	Reset                      = 322
+	ServerAssignment           = 301
+	SessionTermination         = 275
+	SpendingLimit              = 8388635
+	SpendingStatusNotification = 8388636
+	SubscribeNotifications     = 308
+	UpdateLocation             = 316
+	UserAuthorization          = 300
+	UserData                   = 306
 )
 
 // Short Command Names
@@ -48,6 +57,8 @@ const (
 	CER = "CER"
 	CLA = "CLA"
 	CLR = "CLR"
+	DEA = "DEA"
+	DER = "DER"
 	DPA = "DPA"
 	DPR = "DPR"
 	DSA = "DSA"
@@ -58,10 +69,16 @@ const (
 	ECR = "ECR"
 	IDA = "IDA"
 	IDR = "IDR"
+	LIA = "LIA"
+	LIR = "LIR"
 	MAA = "MAA"
 	MAR = "MAR"
 	NOA = "NOA"
 	NOR = "NOR"
+	PNA = "PNA"
+	PNR = "PNR"
+	PPA = "PPA"
+	PPR = "PPR"
 	PUA = "PUA"
 	PUR = "PUR"
 	RAA = "RAA"
@@ -74,8 +91,14 @@ const (
 	SAR = "SAR"
 	SLA = "SLA"
 	SLR = "SLR"
+	SNA = "SNA"
+	SNR = "SNR"
 	STA = "STA"
 	STR = "STR"
+	UAA = "UAA"
+	UAR = "UAR"
+	UDA = "UDA"
+	UDR = "UDR"
 	ULA = "ULA"
 	ULR = "ULR"
 )
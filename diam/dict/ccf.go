@@ -0,0 +1,392 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Command Code Format (CCF) parser.  Part of go-diameter.
+
+package dict
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+var (
+	// ccfDef matches the head of a command or Grouped AVP definition,
+	// such as <ULR> ::= < Diameter Header: 316, REQ, PXY, 16777251 >
+	// or Subscription-Data ::= < AVP Header: 1400 10415 >.
+	ccfDef = regexp.MustCompile(`(?i)<?[ \t]*([A-Za-z0-9][\w-]*)[ \t]*>?\s*::=\s*<\s*(Diameter|AVP)[\s-]*Header\s*:([^>]*)>`)
+	// ccfRule matches a rule at the start of a definition body, such
+	// as < Session-Id >, { Origin-Host } or 1*5 [ Route-Record ].
+	ccfRule = regexp.MustCompile(`^\s*(?:(\d*)[ \t]*(\*)[ \t]*(\d*))?[ \t]*([<{\[])\s*([^\s<>{}\[\]]+)\s*([>}\]])`)
+	ccfName = regexp.MustCompile(`^[A-Za-z0-9][\w-]*$`)
+	ccfCode = regexp.MustCompile(`^\d+$`)
+	// ccfSection matches the section column of AVP tables.
+	ccfSection = regexp.MustCompile(`^\d+(\.\d+)*$`)
+)
+
+var ccfClose = map[string]string{"<": ">", "{": "}", "[": "]"}
+
+// LoadCCF loads the commands, Grouped AVPs and AVP tables of r, in
+// Command Code Format, as application appID. See ReadCCF.
+func (p *Parser) LoadCCF(r io.Reader, appID, vendorID uint32) error {
+	app, err := ReadCCF(r, appID, vendorID)
+	if err != nil {
+		return err
+	}
+	b, err := xml.Marshal(&File{App: []*App{app}})
+	if err != nil {
+		return err
+	}
+	return p.load(source{data: b})
+}
+
+// ReadCCF reads the definitions of r, in the Command Code Format of RFC
+// 6733 section 3.2 as used by IETF and 3GPP specifications, and returns
+// them as the application appID:
+//
+//	<ULR> ::= < Diameter Header: 316, REQ, PXY, 16777251 >
+//	          < Session-Id >
+//	          { Origin-Host }
+//	          *[ Proxy-Info ]
+//
+//	Subscription-Data ::= < AVP Header: 1400 10415 >
+//	          [ Subscriber-Status ]
+//	          *[ AVP ]
+//
+// A definition ends with the first text that is not a rule, so they can
+// be read from specifications copied as text. Fixed AVPs (< >) get
+// positions from the head of the message, or from its tail when they
+// follow other rules, and are required like those in braces. Rules
+// without qualifier allow a single AVP, and *n qualifiers set the
+// minimum and maximum counts, unlimited counts being 0.
+//
+// Request and answer definitions with the same code form a command.
+// Their names lose the -Request and -Answer suffixes, or the final R and
+// A of abbreviations such as ULR and ULA. Commands of other applications
+// are rejected.
+//
+// AVP tables are read from rows with cells separated by tabs or |, with
+// the columns of 3GPP specifications: name, code, optionally the section,
+// type, and the must, may, should not, must not and may encrypt flag
+// rules. The name, code, section and type may also be separated by
+// spaces, as in RFC tables. Other lines are ignored. AVPs with the V
+// flag belong to vendorID unless their Grouped definition says
+// otherwise, and AVPs only defined by their Grouped definition are
+// mandatory.
+func ReadCCF(r io.Reader, appID, vendorID uint32) (*App, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	c := &ccfReader{
+		app:      &App{ID: appID},
+		vendorID: vendorID,
+		avps:     make(map[string]*AVP),
+		cmds:     make(map[uint32]*Command),
+		msgs:     make(map[ccfMsg]bool),
+	}
+	if vendorID != 0 {
+		c.app.Vendor = []*Vendor{{ID: vendorID}}
+	}
+	if err = c.readTables(b); err != nil {
+		return nil, err
+	}
+	if err = c.readDefs(b); err != nil {
+		return nil, err
+	}
+	return c.app, nil
+}
+
+type ccfReader struct {
+	app      *App
+	vendorID uint32
+	avps     map[string]*AVP     // AVPs by name
+	cmds     map[uint32]*Command // Commands by code
+	msgs     map[ccfMsg]bool     // Requests and answers defined
+}
+
+type ccfMsg struct {
+	code    uint32
+	request bool
+}
+
+// readTables adds the AVPs of the table rows of b to the application.
+func (c *ccfReader) readTables(b []byte) error {
+	s := bufio.NewScanner(bytes.NewReader(b))
+	for line := 1; s.Scan(); line++ {
+		avp, err := c.tableRow(s.Text())
+		if err != nil {
+			return fmt.Errorf("line %d: %v", line, err)
+		}
+		if avp == nil {
+			continue
+		}
+		if _, exist := c.avps[avp.Name]; exist {
+			return fmt.Errorf("line %d: AVP %s is already defined", line, avp.Name)
+		}
+		c.avps[avp.Name] = avp
+		c.app.AVP = append(c.app.AVP, avp)
+	}
+	return s.Err()
+}
+
+// tableRow returns the AVP defined by a row of an AVP table, or nil if
+// the row does not define one.
+func (c *ccfReader) tableRow(row string) (*AVP, error) {
+	cells := strings.Fields(row)
+	if strings.ContainsAny(row, "|\t") {
+		cells = strings.Split(strings.Replace(row, "\t", "|", -1), "|")
+		for i := range cells {
+			cells[i] = strings.TrimSpace(cells[i])
+		}
+		if f := strings.Fields(cells[0]); len(f) > 1 {
+			// Name, code, section and type separated by spaces.
+			cells = append(f, cells[1:]...)
+		} else if len(cells[0]) == 0 {
+			cells = cells[1:]
+		}
+	}
+	if len(cells) < 3 || !ccfName.MatchString(cells[0]) || !ccfCode.MatchString(cells[1]) {
+		return nil, nil
+	}
+	name, code, cells := cells[0], cells[1], cells[2:]
+	if ccfSection.MatchString(cells[0]) {
+		cells = cells[1:]
+	}
+	if len(cells) == 0 {
+		return nil, nil
+	}
+	typeName := cells[0]
+	if _, ok := datatype.Available[typeName]; !ok {
+		return nil, nil
+	}
+	n, err := strconv.ParseUint(code, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("AVP %s: invalid code %s", name, code)
+	}
+	flags := make([]string, 5) // Must, may, should not, must not, encrypt.
+	copy(flags, cells[1:])
+	var must, may, mustNot []string
+	for i, list := range []*[]string{&must, &may, &mustNot, &mustNot} {
+		for _, f := range strings.FieldsFunc(flags[i], func(r rune) bool { return r == ',' || r == ' ' }) {
+			switch f = strings.ToUpper(f); f {
+			case "V", "M", "P":
+				*list = append(*list, f)
+			case "-":
+			default:
+				return nil, fmt.Errorf("AVP %s: invalid flag %s", name, f)
+			}
+		}
+	}
+	avp := &AVP{
+		Name:       name,
+		Code:       uint32(n),
+		Must:       flagList(must),
+		May:        flagList(may),
+		MustNot:    flagList(mustNot),
+		MayEncrypt: "-",
+		Data:       Data{TypeName: typeName},
+	}
+	switch strings.ToUpper(flags[4]) {
+	case "Y", "YES":
+		avp.MayEncrypt = "Y"
+	case "N", "NO":
+		avp.MayEncrypt = "N"
+	}
+	if hasFlag(must, "V") || hasFlag(may, "V") {
+		if c.vendorID == 0 {
+			return nil, fmt.Errorf("AVP %s has the V flag but no vendor", name)
+		}
+		avp.VendorID = c.vendorID
+	}
+	return avp, nil
+}
+
+func hasFlag(flags []string, flag string) bool {
+	for _, f := range flags {
+		if f == flag {
+			return true
+		}
+	}
+	return false
+}
+
+// readDefs adds the command and Grouped AVP definitions of b to the
+// application.
+func (c *ccfReader) readDefs(b []byte) error {
+	defs := ccfDef.FindAllSubmatchIndex(b, -1)
+	for i, m := range defs {
+		end := len(b)
+		if i+1 < len(defs) {
+			end = defs[i+1][0]
+		}
+		line := 1 + bytes.Count(b[:m[0]], []byte("\n"))
+		name := string(b[m[2]:m[3]])
+		header := strings.Split(string(b[m[6]:m[7]]), ",")
+		rules, err := ccfRules(b[m[1]:end])
+		if err == nil {
+			if strings.EqualFold(string(b[m[4]:m[5]]), "AVP") {
+				err = c.groupedDef(name, header, rules)
+			} else {
+				err = c.commandDef(name, header, rules)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: %s: %v", line, name, err)
+		}
+	}
+	return nil
+}
+
+// ccfRules returns the rules at the start of body.
+func ccfRules(body []byte) ([]*Rule, error) {
+	var (
+		rules []*Rule
+		head  = true // No required or optional rule seen
+		tail  []*Rule
+	)
+	for {
+		m := ccfRule.FindSubmatch(body)
+		if m == nil {
+			break
+		}
+		body = body[len(m[0]):]
+		open, name, close := string(m[4]), string(m[5]), string(m[6])
+		if ccfClose[open] != close {
+			return nil, fmt.Errorf("rule %s%s%s is not closed properly", open, name, close)
+		}
+		rule := &Rule{AVP: name, Required: open != "[", Max: 1}
+		if len(m[2]) > 0 {
+			rule.Min, _ = strconv.Atoi(string(m[1]))
+			rule.Max, _ = strconv.Atoi(string(m[3]))
+		}
+		switch {
+		case open != "<":
+			head = false
+		case head:
+			rule.Position = len(rules) + 1
+		default:
+			tail = append(tail, rule)
+		}
+		rules = append(rules, rule)
+	}
+	for i, rule := range tail {
+		rule.Position = i - len(tail)
+	}
+	return rules, nil
+}
+
+func (c *ccfReader) groupedDef(name string, header []string, rules []*Rule) error {
+	f := strings.Fields(strings.Join(header, " "))
+	if len(f) == 0 || len(f) > 2 {
+		return fmt.Errorf("invalid AVP header")
+	}
+	var codes [2]uint32
+	for i, s := range f {
+		n, err := strconv.ParseUint(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid AVP header")
+		}
+		codes[i] = uint32(n)
+	}
+	avp, exist := c.avps[name]
+	switch {
+	case !exist:
+		avp = &AVP{
+			Name:       name,
+			Code:       codes[0],
+			Must:       "M",
+			May:        "P",
+			MustNot:    "-",
+			MayEncrypt: "N",
+			VendorID:   codes[1],
+			Data:       Data{TypeName: "Grouped"},
+		}
+		if avp.VendorID != 0 {
+			avp.Must = "V,M"
+		}
+		c.avps[name] = avp
+		c.app.AVP = append(c.app.AVP, avp)
+	case avp.Data.TypeName != "Grouped":
+		return fmt.Errorf("AVP is %s in the AVP table", avp.Data.TypeName)
+	case avp.Code != codes[0]:
+		return fmt.Errorf("AVP has code %d in the AVP table", avp.Code)
+	case len(avp.Data.Rule) > 0:
+		return fmt.Errorf("AVP is already defined")
+	case len(f) == 2:
+		avp.VendorID = codes[1]
+	}
+	if avp.VendorID != 0 && !hasVendor(c.app.Vendor, avp.VendorID) {
+		c.app.Vendor = append(c.app.Vendor, &Vendor{ID: avp.VendorID})
+	}
+	avp.Data.Rule = rules
+	return nil
+}
+
+func (c *ccfReader) commandDef(name string, header []string, rules []*Rule) error {
+	code, err := strconv.ParseUint(strings.TrimSpace(header[0]), 10, 32)
+	if err != nil {
+		return fmt.Errorf("invalid command code %q", header[0])
+	}
+	var request bool
+	for _, f := range header[1:] {
+		switch f = strings.TrimSpace(f); strings.ToUpper(f) {
+		case "REQ":
+			request = true
+		case "PXY", "ERR":
+		default:
+			id, err := strconv.ParseUint(f, 10, 32)
+			if err != nil {
+				return fmt.Errorf("invalid header field %q", f)
+			}
+			if uint32(id) != c.app.ID {
+				return fmt.Errorf("command of application %d, not %d", id, c.app.ID)
+			}
+		}
+	}
+	cmdName, short := ccfCommandName(name, request)
+	cmd, exist := c.cmds[uint32(code)]
+	if !exist {
+		cmd = &Command{Code: uint32(code), Name: cmdName, Short: short}
+		c.cmds[cmd.Code] = cmd
+		c.app.Command = append(c.app.Command, cmd)
+	} else if cmd.Name != cmdName {
+		return fmt.Errorf("command %d is named %s", code, cmd.Name)
+	}
+	if c.msgs[ccfMsg{cmd.Code, request}] {
+		return fmt.Errorf("command is already defined")
+	}
+	c.msgs[ccfMsg{cmd.Code, request}] = true
+	if request {
+		cmd.Request.Rule = rules
+	} else {
+		cmd.Answer.Rule = rules
+	}
+	return nil
+}
+
+// ccfCommandName returns the command name and abbreviation of the
+// request or answer definition name.
+func ccfCommandName(name string, request bool) (string, string) {
+	suffix := "-Answer"
+	if request {
+		suffix = "-Request"
+	}
+	if len(name) > len(suffix) && strings.EqualFold(name[len(name)-len(suffix):], suffix) {
+		name = name[:len(name)-len(suffix)]
+		return name, shortName(name)
+	}
+	if name == strings.ToUpper(name) && len(name) > 1 && !strings.Contains(name, "-") {
+		name = name[:len(name)-1]
+	}
+	return name, name
+}
@@ -0,0 +1,123 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package dict
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLoadCCF(t *testing.T) {
+	f, err := os.Open("./testdata/ccf/tgpp_s13.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	p, _ := NewParser()
+	if err = p.LoadCCF(f, 16777252, 10415); err != nil {
+		t.Fatal(err)
+	}
+	cmd, err := p.FindCommand(16777252, 324)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Name != "ME-Identity-Check" || cmd.Short != "MIC" {
+		t.Fatalf("Unexpected command: %s", cmd)
+	}
+	// The rules match those transcribed in tgpp_s13.xml.
+	want, err := Default.FindCommand(16777252, 324)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rules := range [][2][]*Rule{
+		{cmd.Request.Rule, want.Request.Rule},
+		{cmd.Answer.Rule, want.Answer.Rule},
+	} {
+		if len(rules[0]) != len(rules[1]) {
+			t.Fatalf("Unexpected rules: %+v", rules[0])
+		}
+		for i, r := range rules[0] {
+			w := rules[1][i]
+			if r.AVP != w.AVP || r.Required != w.Required || r.Min != w.Min || r.Max != w.Max {
+				t.Fatalf("Unexpected rule %+v, want %+v", r, w)
+			}
+			if position := r.AVP == "Session-Id"; position != (r.Position == 1) {
+				t.Fatalf("Unexpected position of %s: %d", r.AVP, r.Position)
+			}
+		}
+	}
+
+	avp, err := p.FindAVPWithVendor(16777252, "Terminal-Information", 10415)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if avp.Code != 1401 || avp.Must != "V,M" || avp.MayEncrypt != "N" || len(avp.Data.Rule) != 4 {
+		t.Fatalf("Unexpected AVP: %+v", avp)
+	}
+	if avp, err = p.FindAVPWithVendor(16777252, uint32(1445), 10415); err != nil || avp.Name != "Equipment-Status" {
+		t.Fatalf("Unexpected AVP: %+v, %v", avp, err)
+	}
+}
+
+func TestReadCCF(t *testing.T) {
+	app, err := ReadCCF(strings.NewReader(`
+<XR> ::= < Diameter Header: 8388999, REQ, PXY >
+         < Session-Id >
+         < Example-Head >
+         2*5 { Example-Count }
+         1* [ Example-Opt ]
+         *3 [ Example-Max ]
+         < Example-Tail >
+Some text.
+         [ Not-A-Rule ]
+
+   Acct-Interim-Interval  85   9.8.2    Unsigned32 | M  |  P  |    |  V  | Y  |
+| Example-Count | 9001 | Unsigned64 | M | P | | V | N |
+`), 16777999, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(app.Command) != 1 || len(app.AVP) != 2 || len(app.Vendor) != 0 {
+		t.Fatalf("Unexpected application: %+v", app)
+	}
+	cmd := app.Command[0]
+	if cmd.Name != "X" || cmd.Short != "X" || cmd.Code != 8388999 || len(cmd.Answer.Rule) != 0 {
+		t.Fatalf("Unexpected command: %s", cmd)
+	}
+	for i, want := range []Rule{
+		{AVP: "Session-Id", Required: true, Max: 1, Position: 1},
+		{AVP: "Example-Head", Required: true, Max: 1, Position: 2},
+		{AVP: "Example-Count", Required: true, Min: 2, Max: 5},
+		{AVP: "Example-Opt", Min: 1},
+		{AVP: "Example-Max", Max: 3},
+		{AVP: "Example-Tail", Required: true, Max: 1, Position: -1},
+	} {
+		if i >= len(cmd.Request.Rule) || *cmd.Request.Rule[i] != want {
+			t.Fatalf("Unexpected rules: %+v", cmd.Request.Rule)
+		}
+	}
+	if avp := app.AVP[0]; avp.Name != "Acct-Interim-Interval" || avp.Code != 85 ||
+		avp.Must != "M" || avp.May != "P" || avp.MustNot != "V" || avp.MayEncrypt != "Y" {
+		t.Fatalf("Unexpected AVP: %+v", avp)
+	}
+	if avp := app.AVP[1]; avp.Name != "Example-Count" || avp.Data.TypeName != "Unsigned64" || avp.MayEncrypt != "N" {
+		t.Fatalf("Unexpected AVP: %+v", avp)
+	}
+
+	for _, bad := range []string{
+		"<XR> ::= < Diameter Header: 1, REQ, 5 >\n< Session-Id >",
+		"<XR> ::= < Diameter Header: 1, REQ >\n< Session-Id }",
+		"<XR> ::= < Diameter Header: x, REQ >",
+		"<X-Request> ::= < Diameter Header: 1, REQ >\n<X-Request> ::= < Diameter Header: 1, REQ >\n{ A }",
+		"X ::= < AVP Header: 1 >\n{ A }\nX	2	Grouped	M",
+		"Y	3	Unsigned32	M, V",
+		"Z	4	Unsigned32	Q",
+	} {
+		if _, err = ReadCCF(strings.NewReader(bad), 16777999, 0); err == nil {
+			t.Fatalf("Invalid CCF was read: %s", bad)
+		}
+	}
+}
@@ -6,26 +6,27 @@
 
 package dict
 
-import (
-	"bytes"
-	"fmt"
-)
+import "fmt"
 
 // Default is a Parser object with pre-loaded
 // Base Protocol and Credit Control dictionaries.
 var Default *Parser
 
 func init() {
-	var dictionaries = []struct{ name, xml string }{
-		{"Base", baseXML},
-		{"Credit Control", creditcontrolXML},
-		{"Gx Charging Control", gxcreditcontrolXML},
-		{"Network Access Server", networkaccessserverXML},
-		{"TGPP", tgpprorfXML},
-		{"TGPP_Rx", tgpprxXML},
-		{"TGPP_S6a", tgpps6aXML},
-		{"TGPP_S13", tgpps13XML},
-		{"TGPP_Swx", tgppswxXML},
+	var dictionaries = []struct{ name, file, xml string }{
+		{"Base", "testdata/base.xml", baseXML},
+		{"Credit Control", "testdata/credit_control.xml", creditcontrolXML},
+		{"Gx Charging Control", "testdata/gx_credit_control.xml", gxcreditcontrolXML},
+		{"Network Access Server", "testdata/network_access_server.xml", networkaccessserverXML},
+		{"TGPP", "testdata/tgpp_ro_rf.xml", tgpprorfXML},
+		{"TGPP_Rx", "testdata/tgpp_rx.xml", tgpprxXML},
+		{"TGPP_S6a", "testdata/tgpp_s6a.xml", tgpps6aXML},
+		{"TGPP_S13", "testdata/tgpp_s13.xml", tgpps13XML},
+		{"TGPP_Swx", "testdata/tgpp_swx.xml", tgppswxXML},
+		{"Diameter Sy", "testdata/diameter_sy.xml", diametersyXML},
+		{"TGPP_Cx", "testdata/tgpp_cx.xml", tgppcxXML},
+		{"TGPP_Sh", "testdata/tgpp_sh.xml", tgppshXML},
+		{"Diameter EAP", "testdata/diameter_eap.xml", diametereapXML},
 	}
 	var err error
 	Default, err = NewParser()
@@ -33,7 +34,7 @@ func init() {
 		panic(err)
 	}
 	for _, dict := range dictionaries {
-		err = Default.Load(bytes.NewReader([]byte(dict.xml)))
+		err = Default.load(source{name: dict.file, data: []byte(dict.xml)})
 		if err != nil {
 			panic(fmt.Sprintf("Cannot load %s dictionary: %s", dict.name, err))
 		}
@@ -81,7 +82,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 
 		<command code="258" short="RA" name="Re-Auth">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
@@ -94,7 +95,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="Route-Record" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Result-Code" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -112,7 +113,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 
 		<command code="271" short="AC" name="Accounting">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
@@ -133,7 +134,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="Route-Record" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Result-Code" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -158,7 +159,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 
 		<command code="274" short="AS" name="Abort-Session">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
@@ -170,7 +171,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="Route-Record" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Result-Code" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -188,7 +189,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 
 		<command code="275" short="ST" name="Session-Termination">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
@@ -202,7 +203,7 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="Route-Record" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Result-Code" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -573,14 +574,14 @@ var baseXML = `<?xml version="1.0" encoding="UTF-8"?>
 var creditcontrolXML = `<?xml version="1.0" encoding="UTF-8"?>
 <diameter>
 
-	<application id="4" type="auth" name="Charging Control">
+	<application id="4" type="auth" parent="1" name="Charging Control">
 		<!-- Diameter Credit Control Application -->
 		<!-- http://tools.ietf.org/html/rfc4006 -->
 
 		<command code="272" short="CC" name="Credit-Control">
 			<request>
 				<!-- http://tools.ietf.org/html/rfc4006#section-3.1 -->
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
@@ -611,7 +612,7 @@ var creditcontrolXML = `<?xml version="1.0" encoding="UTF-8"?>
 			</request>
 			<answer>
 				<!-- http://tools.ietf.org/html/rfc4006#section-3.2 -->
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Result-Code" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -674,6 +675,7 @@ var creditcontrolXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<item code="1" name="INITIAL_REQUEST"/>
 				<item code="2" name="UPDATE_REQUEST"/>
 				<item code="3" name="TERMINATION_REQUEST"/>
+				<item code="4" name="EVENT_REQUEST"/>
 			</data>
 		</avp>
 
@@ -1023,703 +1025,798 @@ var creditcontrolXML = `<?xml version="1.0" encoding="UTF-8"?>
 	</application>
 </diameter>`
 
-var diametersyXML = `<?xml version="1.0" encoding="UTF-8"?>
-<diameter>
-
-	<application id="16777302" type="auth" name="Diameter Sy">
-		<!-- Diameter Credit Control Application -->
-		<!-- http://tools.ietf.org/html/rfc4006 -->
-
-		<command code="8388635" short="SL" name="Spending-Limit">
-			<request>
-				<!-- http://tools.ietf.org/html/rfc4006#section-3.1 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Auth-Application-Id" required="true" max="1"/>
-				<rule avp="Origin-Host" required="true" max="1"/>
-				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="Destination-Realm" required="true" max="1"/>
-				<rule avp="SL-Request-Type" required="true" max="1"/>
-				<rule avp="Destination-Host" required="false" max="1"/>
-				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="Subscription-Id" required="false" max="1"/>
-				<rule avp="Policy-Counter-Identifier" required="false" max="1"/>
-				<rule avp="Proxy-Info" required="false" max="1"/>
-				<rule avp="Route-Record" required="false" max="1"/>
-				<rule avp="Service-Information" required="false" max="1"/>
-			</request>
-			<answer>
-				<!-- http://tools.ietf.org/html/rfc4006#section-3.2 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Result-Code" required="true" max="1"/>
-				<rule avp="Origin-Host" required="true" max="1"/>
-				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="Redirect-Host" required="false" max="1"/>
-				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
-				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
-				<rule avp="Proxy-Info" required="false" max="1"/>
-				<rule avp="Route-Record" required="false" max="1"/>
-				<rule avp="Failed-AVP" required="false" max="1"/>
-			</answer>
-		</command>
-
-		<avp name="SL-Request-Type" code="2904" must="M" may="P" must-not="V" may-encrypt="-">
-			<data type="Enumerated">
-				<item code="0" name="INITIAL_REQUEST"/>
-				<item code="1" name="INTERMEDIATE_REQUEST"/>
-			</data>
-		</avp>
-		
-    </application>
-</diameter>`
-
-var gxcreditcontrolXML = `<?xml version="1.0" encoding="UTF-8"?>
+var diametereapXML = `<?xml version="1.0" encoding="UTF-8"?>
 <diameter>
 
-    <application id="16777238" type="auth" name="Gx Charging Control">
-        <!-- Diameter Gx Credit Control Application -->
-        <!-- 3GPP 29.212 -->
+    <application id="5" type="auth" parent="1" name="Diameter EAP">
+        <!-- Diameter Extensible Authentication Protocol (EAP) Application -->
+        <!-- http://tools.ietf.org/html/rfc4072 -->
+        <!-- NASREQ AVPs are inherited from the Network Access application -->
 
-        <vendor id="10415" name="TGPP"/>
-        <command code="272" short="CC" name="Credit-Control">
+        <command code="268" short="DE" name="Diameter-EAP">
             <request>
-                <!-- 3GPP 29.212 Section 5.6.2 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <!-- http://tools.ietf.org/html/rfc4072#section-3.1 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
                 <rule avp="Origin-Realm" required="true" max="1"/>
                 <rule avp="Destination-Realm" required="true" max="1"/>
-                <rule avp="Auth-Application-Id" required="true" max="1"/>
-                <rule avp="CC-Request-Type" required="true" max="1"/>
-                <rule avp="CC-Request-Number" required="true" max="1"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
+                <rule avp="EAP-Payload" required="true" max="1"/>
                 <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="NAS-Identifier" required="false" max="1"/>
+                <rule avp="NAS-IP-Address" required="false" max="1"/>
+                <rule avp="NAS-IPv6-Address" required="false" max="1"/>
+                <rule avp="NAS-Port" required="false" max="1"/>
+                <rule avp="NAS-Port-Id" required="false" max="1"/>
+                <rule avp="NAS-Port-Type" required="false" max="1"/>
                 <rule avp="Origin-State-Id" required="false" max="1"/>
-                <rule avp="Subscription-Id" required="false" max="1"/>
-                <rule avp="Termination-Cause" required="false" max="1"/>
-                <rule avp="User-Equipment-Info" required="false" max="1"/>
-                <rule avp="Proxy-Info" required="false" max="1"/>
-                <rule avp="Route-Record" required="false" max="1"/>
+                <rule avp="Port-Limit" required="false" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="EAP-Key-Name" required="false" max="1"/>
+                <rule avp="Service-Type" required="false" max="1"/>
+                <rule avp="State" required="false" max="1"/>
+                <rule avp="Authorization-Lifetime" required="false" max="1"/>
+                <rule avp="Auth-Grace-Period" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="false" max="1"/>
+                <rule avp="Callback-Number" required="false" max="1"/>
+                <rule avp="Called-Station-Id" required="false" max="1"/>
+                <rule avp="Calling-Station-Id" required="false" max="1"/>
+                <rule avp="Originating-Line-Info" required="false" max="1"/>
+                <rule avp="Connect-Info" required="false" max="1"/>
+                <rule avp="Framed-Compression" required="false"/>
+                <rule avp="Framed-Interface-Id" required="false" max="1"/>
                 <rule avp="Framed-IP-Address" required="false" max="1"/>
                 <rule avp="Framed-IPv6-Prefix" required="false"/>
-                <rule avp="IP-CAN-Type" required="false" max="1"/>
-                <rule avp="Called-Station-Id" required="false" max="1"/>
-                <rule avp="RAT-Type" required="false" max="1"/>
-                <rule avp="Network-Request-Support" required="false" max="1"/>
-                <rule avp="Default-EPS-Bearer-QoS" required="false" max="1"/>
-                <rule avp="AN-GW-Address" required="false" max="2"/>
-                <rule avp="Bearer-Usage" required="false" max="1"/>
-                <rule avp="Online" required="false" max="1"/>
-                <rule avp="Offline" required="false" max="1"/>
-                <rule avp="Access-Network-Charging-Identifier-Gx" required="false"/>
-                <rule avp="TGPP-SGSN-Address" required="false" max="1"/>
-                <rule avp="TGPP-GGSN-Address" required="false" max="1"/>
-                <rule avp="Supported-Features" required="false"/>
-                <rule avp="Access-Network-Charging-Address" required="false" max="1"/>
-                <rule avp="TGPP-MS-TimeZone" required="false" max="1"/>
-                <rule avp="TGPP-Selection-Mode" required="false" max="1"/>
-                <rule avp="QoS-Information" required="false" max="1"/>
-                <rule avp="TGPP-SGSN-MCC-MNC" required="false" max="1"/>
-                <rule avp="TGPP-User-Location-Info" required="false" max="1"/>
+                <rule avp="Framed-IP-Netmask" required="false" max="1"/>
+                <rule avp="Framed-MTU" required="false" max="1"/>
+                <rule avp="Framed-Protocol" required="false" max="1"/>
+                <rule avp="Tunneling" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
             </request>
             <answer>
-                <!-- 3GPP 29.212 Section 5.6.3 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <!-- http://tools.ietf.org/html/rfc4072#section-3.2 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
                 <rule avp="Result-Code" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
                 <rule avp="Origin-Realm" required="true" max="1"/>
-                <rule avp="CC-Request-Type" required="true" max="1"/>
-                <rule avp="CC-Request-Number" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="EAP-Payload" required="false" max="1"/>
+                <rule avp="EAP-Reissued-Payload" required="false" max="1"/>
+                <rule avp="EAP-Master-Session-Key" required="false" max="1"/>
+                <rule avp="EAP-Key-Name" required="false" max="1"/>
+                <rule avp="Multi-Round-Time-Out" required="false" max="1"/>
+                <rule avp="Accounting-EAP-Auth-Method" required="false"/>
+                <rule avp="Service-Type" required="false" max="1"/>
+                <rule avp="Class" required="false"/>
+                <rule avp="Configuration-Token" required="false"/>
+                <rule avp="Acct-Interim-Interval" required="false" max="1"/>
+                <rule avp="Error-Message" required="false" max="1"/>
+                <rule avp="Error-Reporting-Host" required="false" max="1"/>
+                <rule avp="Failed-AVP" required="false"/>
+                <rule avp="Idle-Timeout" required="false" max="1"/>
+                <rule avp="Authorization-Lifetime" required="false" max="1"/>
+                <rule avp="Auth-Grace-Period" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="false" max="1"/>
+                <rule avp="Re-Auth-Request-Type" required="false" max="1"/>
+                <rule avp="Session-Timeout" required="false" max="1"/>
+                <rule avp="State" required="false" max="1"/>
+                <rule avp="Reply-Message" required="false"/>
                 <rule avp="Origin-State-Id" required="false" max="1"/>
-                <rule avp="Proxy-Info" required="false" max="1"/>
-                <rule avp="Route-Record" required="false" max="1"/>
-                <rule avp="Failed-AVP" required="false" max="1"/>
-                <rule avp="Charging-Rule-Install" required="false"/>
-                <rule avp="Charging-Rule-Remove" required="false"/>
-                <rule avp="Usage-Monitoring-Information" required="false"/>
-                <rule avp="Event-Trigger" required="false"/>
-                <rule avp="Revalidation-Time" required="false"/>
+                <rule avp="Filter-Id" required="false"/>
+                <rule avp="Port-Limit" required="false" max="1"/>
+                <rule avp="Callback-Id" required="false" max="1"/>
+                <rule avp="Callback-Number" required="false" max="1"/>
+                <rule avp="Framed-Compression" required="false"/>
+                <rule avp="Framed-Interface-Id" required="false" max="1"/>
+                <rule avp="Framed-IP-Address" required="false" max="1"/>
+                <rule avp="Framed-IPv6-Prefix" required="false"/>
+                <rule avp="Framed-IPv6-Pool" required="false" max="1"/>
+                <rule avp="Framed-IPv6-Route" required="false"/>
+                <rule avp="Framed-IP-Netmask" required="false" max="1"/>
+                <rule avp="Framed-MTU" required="false" max="1"/>
+                <rule avp="Framed-Pool" required="false" max="1"/>
+                <rule avp="Framed-Protocol" required="false" max="1"/>
+                <rule avp="Framed-Route" required="false"/>
+                <rule avp="Framed-Routing" required="false" max="1"/>
+                <rule avp="NAS-Filter-Rule" required="false"/>
+                <rule avp="Tunneling" required="false"/>
+                <rule avp="Redirect-Host" required="false"/>
+                <rule avp="Redirect-Host-Usage" required="false" max="1"/>
+                <rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
             </answer>
         </command>
 
-        <command code="258" short="RA" name="Re-Auth">
+        <avp name="EAP-Payload" code="462" must="M" may="P" must-not="V" may-encrypt="Y">
+            <!-- http://tools.ietf.org/html/rfc4072#section-4.1.1 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="EAP-Reissued-Payload" code="463" must="M" may="P" must-not="V" may-encrypt="Y">
+            <!-- http://tools.ietf.org/html/rfc4072#section-4.1.2 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="EAP-Master-Session-Key" code="464" must="-" may="P" must-not="V,M" may-encrypt="Y">
+            <!-- http://tools.ietf.org/html/rfc4072#section-4.1.3 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="EAP-Key-Name" code="102" must="M" may="P" must-not="V" may-encrypt="Y">
+            <!-- http://tools.ietf.org/html/rfc4072#section-4.1.4 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Accounting-EAP-Auth-Method" code="465" must="M" may="P" must-not="V" may-encrypt="Y">
+            <!-- http://tools.ietf.org/html/rfc4072#section-4.1.5 -->
+            <data type="Unsigned64"/>
+        </avp>
+    </application>
+
+    <application id="16777250" type="auth" parent="5" name="TGPP STa">
+        <!--
+            3GPP TS 29.273 Section 5: STa, between a trusted non-3GPP
+            access network and the 3GPP AAA server. EAP and NASREQ AVPs
+            are inherited from the Diameter EAP application.
+        -->
+        <vendor id="10415" name="TGPP"/>
+
+        <command code="268" short="DE" name="Diameter-EAP">
             <request>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <!-- 3GPP TS 29.273 Section 5.2.2.1.1 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="DRMP" required="false" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
                 <rule avp="Origin-Realm" required="true" max="1"/>
                 <rule avp="Destination-Realm" required="true" max="1"/>
-                <rule avp="Destination-Host" required="true" max="1"/>
-                <rule avp="Auth-Application-Id" required="true" max="1"/>
-                <rule avp="Re-Auth-Request-Type" required="true" max="1"/>
-                <rule avp="QoS-Information" required="false" max="1"/>
-                <rule avp="Origin-State-Id" required="false" max="1"/>
-                <rule avp="Proxy-Info" required="false"/>
-                <rule avp="Route-Record" required="false"/>
-                <rule avp="Event-Trigger" required="false"/>
-                <rule avp="Revalidation-Time" required="false"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
+                <rule avp="EAP-Payload" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Calling-Station-Id" required="false" max="1"/>
+                <rule avp="RAT-Type" required="false" max="1"/>
+                <rule avp="ANID" required="false" max="1"/>
+                <rule avp="Full-Network-Name" required="false" max="1"/>
+                <rule avp="Short-Network-Name" required="false" max="1"/>
+                <rule avp="MIP6-Feature-Vector" required="false" max="1"/>
+                <rule avp="Service-Selection" required="false" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="AAA-Failure-Indication" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="UE-Local-IP-Address" required="false" max="1"/>
+                <rule avp="OC-Supported-Features" required="false" max="1"/>
+                <rule avp="Terminal-Information" required="false" max="1"/>
+                <rule avp="Emergency-Services" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
             </request>
             <answer>
-                <rule avp="Session-Id" required="true" max="1"/>
-                <rule avp="Result-Code" required="true" max="1"/>
+                <!-- 3GPP TS 29.273 Section 5.2.2.1.1 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="DRMP" required="false" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
                 <rule avp="Origin-Realm" required="true" max="1"/>
-                <rule avp="Origin-State-Id" required="false" max="1"/>
-                <rule avp="Error-Message" required="false" max="1"/>
-                <rule avp="Error-Reporting-Host" required="false" max="1"/>
-                <rule avp="Failed-AVP" required="false" max="1"/>
-                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
+                <rule avp="EAP-Payload" required="false" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Session-Timeout" required="false" max="1"/>
+                <rule avp="Acct-Interim-Interval" required="false" max="1"/>
+                <rule avp="EAP-Master-Session-Key" required="false" max="1"/>
+                <rule avp="Context-Identifier" required="false" max="1"/>
+                <rule avp="APN-OI-Replacement" required="false" max="1"/>
+                <rule avp="APN-Configuration" required="false"/>
+                <rule avp="MIP6-Feature-Vector" required="false" max="1"/>
+                <rule avp="Mobile-Node-Identifier" required="false" max="1"/>
+                <rule avp="Trace-Info" required="false" max="1"/>
+                <rule avp="Subscription-Id" required="false" max="1"/>
+                <rule avp="TGPP-Charging-Characteristics" required="false" max="1"/>
+                <rule avp="AN-Trusted" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="OC-Supported-Features" required="false" max="1"/>
+                <rule avp="OC-OLR" required="false" max="1"/>
+                <rule avp="Redirect-Host" required="false"/>
+                <rule avp="AVP" required="false"/>
             </answer>
         </command>
 
-        <avp name="Flow-Description" code="507" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="IPFilterRule"/>
+        <avp name="RAT-Type" code="1032" must="M,V" may="P" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.212 Section 5.3.31 -->
+            <data type="Enumerated">
+                <item code="0" name="WLAN"/>
+                <item code="1" name="VIRTUAL"/>
+                <item code="1000" name="UTRAN"/>
+                <item code="1001" name="GERAN"/>
+                <item code="1002" name="GAN"/>
+                <item code="1003" name="HSPA_EVOLUTION"/>
+                <item code="1004" name="EUTRAN"/>
+                <item code="2000" name="CDMA2000_1X"/>
+                <item code="2001" name="HRPD"/>
+                <item code="2002" name="UMB"/>
+                <item code="2003" name="EHRPD"/>
+            </data>
         </avp>
 
+        <avp name="ANID" code="1504" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.273 Section 5.2.3.7 -->
+            <data type="UTF8String"/>
+        </avp>
 
-        <avp name="Charging-Rule-Install" code="1001" must="M,V" may="P" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.2 -->
-            <data type="Grouped">
-                <rule avp="Charging-Rule-Name" required="false"/>
-                <rule avp="Charging-Rule-Base-Name" required="false"/>
-                <rule avp="Charging-Rule-Definition" required="false"/>
-                <rule avp="Rule-Activation-Time" required="false"/>
-                <rule avp="Rule-Deactivation-Time" required="false"/>
-                <!-- *[ AVP ]-->
+        <avp name="AN-Trusted" code="1503" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.273 Section 5.2.3.9 -->
+            <data type="Enumerated">
+                <item code="0" name="TRUSTED"/>
+                <item code="1" name="UNTRUSTED"/>
             </data>
         </avp>
 
-        <avp name="Charging-Rule-Remove" code="1002" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.3 -->
-            <data type="Grouped">
-                <rule avp="Charging-Rule-Name" required="false"/>
-                <rule avp="Charging-Rule-Base-Name" required="false"/>
-                <!-- *[ AVP ]-->
-            </data>
+        <avp name="Full-Network-Name" code="1516" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.273 Section 5.2.3.14 -->
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="Charging-Rule-Definition" code="1003" must="M,V" may="P" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Grouped">
-                <rule avp="Charging-Rule-Name" required="true" max="1"/>
-                <rule avp="Rating-Group" required="false" max="1"/>
-                <rule avp="Service-Identifier" required="false" max="1"/>
-                <rule avp="Flow-Information" required="false"/>
-                <rule avp="Flow-Description" required="false"/>
-                <rule avp="Precedence" required="false" max="1"/>
-                <rule avp="Monitoring-Key" required="false" max="1"/>
-                <rule avp="Redirect-Information" required="false" max="1"/>
-                <!-- *[ AVP ]-->
-            </data>
+        <avp name="Short-Network-Name" code="1517" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.273 Section 5.2.3.15 -->
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="Charging-Rule-Base-Name" code="1004" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.6 -->
-            <data type="UTF8String"/>
+        <avp name="AAA-Failure-Indication" code="1518" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.273 Section 8.2.3.21 -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="Charging-Rule-Name" code="1005" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.6 -->
+        <avp name="Emergency-Services" code="1538" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.273 Section 9.2.3.1.4 -->
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="UE-Local-IP-Address" code="2805" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.212 Section 5.3.106 -->
+            <data type="Address"/>
+        </avp>
+
+        <avp name="Visited-Network-Identifier" code="600" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.1 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Event-Trigger" code="1006" must="M,V" map="P" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.7 -->
-            <data type="Enumerated">
-                <item code="0" name="SGSN_CHANGE"/>
-                <item code="1" name="QOS_CHANGE"/>
-                <item code="2" name="RAT_CHANGE"/>
-                <item code="3" name="TFT_CHANGE"/>
-                <item code="4" name="PLMN_CHANGE"/>
-                <item code="5" name="LOSS_OF_BEARER"/>
-                <item code="6" name="RECOVERY_OF_BEARER"/>
-                <item code="7" name="IP-CAN_CHANGE"/>
-                <item code="11" name="QOS_CHANGE_EXCEEDING_AUTHORIZATION"/>
-                <item code="12" name="RAI_CHANGE"/>
-                <item code="13" name="USER_LOCATION_CHANGE"/>
-                <item code="14" name="NO_EVENT_TRIGGERS"/>
-                <item code="15" name="OUT_OF_CREDIT"/>
-                <item code="16" name="REALLOCATION_OF_CREDIT"/>
-                <item code="17" name="REVALIDATION_TIMEOUT"/>
-                <item code="18" name="UE_IP_ADDRESS_ALLOCATE"/>
-                <item code="19" name="UE_IP_ADDRESS_RELEASE"/>
-                <item code="20" name="DEFAULT_EPS_BEARER_QOS_CHANGE"/>
-                <item code="21" name="AN_GW_CHANGE"/>
-                <item code="22" name="SUCCESSFUL_RESOURCE_ALLOCATION"/>
-                <item code="23" name="RESOURCE_MODIFICATION_REQUEST"/>
-                <item code="24" name="PGW_TRACE_CONTROL"/>
-                <item code="25" name="UE_TIME_ZONE_CHANGE"/>
-                <item code="26" name="TAI_CHANGE"/>
-                <item code="27" name="ECGI_CHANGE"/>
-                <item code="28" name="CHARGING_CORRELATION_EXCHANGE"/>
-                <item code="29" name="APN-AMBR_MODIFICATION_FAILURE"/>
-                <item code="30" name="USER_CSG_INFORMATION_CHANGE"/>
-                <item code="33" name="USAGE_REPORT"/>
-            </data>
-        </avp>
-
-        <avp name="Revalidation-Time" code="1042" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212  Section 5.3.41 -->
-            <data type="Time"/>
+        <avp name="Service-Selection" code="493" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <!-- http://tools.ietf.org/html/rfc5778#section-6.2 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="Precedence" code="1010" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Unsigned32"/>
+        <avp name="MIP6-Feature-Vector" code="124" must="M" may="P" may-encrypt="N" vendor-id="0">
+            <!-- http://tools.ietf.org/html/rfc5447#section-4.2.5 -->
+            <data type="Unsigned64"/>
         </avp>
 
-        <avp name="ToS-Traffic-Class" code="1014" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.15 -->
-            <data type="Unsigned32"/>
+        <avp name="Mobile-Node-Identifier" code="506" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <!-- 3GPP TS 29.273 Section 5.2.3.2, defined in RFC 5779 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="IP-CAN-Type" code="1027" must="M,V" map="P" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.27 -->
-            <data type="Enumerated">
-                <item code="0" name="3GPP-GPRS"/>
-                <item code="1" name="DOCSIS"/>
-                <item code="2" name="xDSL"/>
-                <item code="3" name="WiMAX"/>
-                <item code="4" name="3GPP2"/>
-                <item code="5" name="3GPP-EPS"/>
-                <item code="6" name="Non-3GPP-EPS"/>
-                <item code="7" name="FBA"/>
-                <item code="8" name="3GPP-5GS"/>
-                <item code="9" name="Non-3GPP-5GS"/>
+        <avp name="Terminal-Information" code="1401" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.3 -->
+            <data type="Grouped">
+                <rule avp="IMEI" required="false" max="1"/>
+                <rule avp="TGPP2-MEID" required="false" max="1"/>
+                <rule avp="Software-Version" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="Rule-Activation-Time" code="1043" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Time"/>
-        </avp>
-
-        <avp name="Rule-Deactivation-Time" code="1044" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Time"/>
+        <avp name="IMEI" code="1402" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.4 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="Security-Parameter-Index" code="1056" must="V" must-not="M" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.51 -->
+        <avp name="TGPP2-MEID" code="1471" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.6 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Flow-Label" code="1057" must="V" must-not="M" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.52 -->
-            <data type="OctetString"/>
+        <avp name="Software-Version" code="1403" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.5 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="Flow-Information" code="1058" must="V" must-not="M" may="P" may-encryp="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.53 -->
+        <avp name="Supported-Features" code="628" vendor-id="10415" must="V" may="M" may-encrypt="N">
+            <!-- 3GPP TS 29.229 Section 6.3.29 -->
             <data type="Grouped">
-                <rule avp="Flow-Description" required="false" max="1"/>
-                <rule avp="Packet-Filter-Identifier" required="false" max="1"/>
-                <rule avp="Packet-Filter-Usage" required="false" max="1"/>
-                <rule avp="ToS-Traffic-Class" required="false" max="1"/>
-                <rule avp="Security-Parameter-Index" required="false" max="1"/>
-                <rule avp="Flow-Label" required="false" max="1"/>
-                <rule avp="Flow-Direction" required="false" max="1"/>
+                <rule avp="Vendor-Id" required="true" max="1"/>
+                <rule avp="Feature-List-ID" required="true" max="1"/>
+                <rule avp="Feature-List" required="true" max="1"/>
             </data>
         </avp>
 
-        <avp name="Packet-Filter-Identifier" code="1060" must="V" must-not="M" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.55 -->
-            <data type="OctetString"/>
+        <avp name="Feature-List-ID" code="629" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.30 -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="Monitoring-Key" code="1066" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="OctetString"/>
+        <avp name="Feature-List" code="630" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.31 -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="Usage-Monitoring-Information" code="1067" must="V" may="P" must-not="M,V" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Grouped">
-                <rule avp="Monitoring-Key" required="false" max="1"/>
-                <rule avp="Granted-Service-Unit" required="false" max="2"/>
-                <rule avp="Used-Service-Unit" required="false" max="2"/>
-                <rule avp="Usage-Monitoring-Level" required="false" max="1"/>
-                <rule avp="Usage-Monitoring-Report" required="false" max="1"/>
-                <rule avp="Usage-Monitoring-Support" required="false" max="1"/>
-            </data>
+        <avp name="Context-Identifier" code="1423" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.27 -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="Usage-Monitoring-Level" code="1068" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Enumerated">
-                <item code="0" name="SESSION_LEVEL"/>
-                <item code="1" name="PCC_RULE_LEVEL"/>
-            </data>
+        <avp name="APN-OI-Replacement" code="1427" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.32 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="Usage-Monitoring-Report" code="1069" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Enumerated">
-                <item code="0" name="USAGE_MONITORING_REPORT"/>
-            </data>
+        <avp name="TGPP-Charging-Characteristics" code="13" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.061 Section 16.4.7.2 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="Usage-Monitoring-Support" code="1070" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 -->
-            <data type="Enumerated">
-                <item code="0" name="USAGE_MONITORING_SUPPORT"/>
+        <avp name="Subscription-Id" code="443" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <!-- http://tools.ietf.org/html/rfc4006#section-8.46 -->
+            <data type="Grouped">
+                <rule avp="Subscription-Id-Type" required="true" max="1"/>
+                <rule avp="Subscription-Id-Data" required="true" max="1"/>
             </data>
         </avp>
 
-        <avp name="Packet-Filter-Usage" code="1072" must="V" must-not="M" map="P" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.66 -->
+        <avp name="Subscription-Id-Type" code="450" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <!-- http://tools.ietf.org/html/rfc4006#section-8.47 -->
             <data type="Enumerated">
-                <item code="1" name="SEND_TO_UE"/>
+                <item code="0" name="END_USER_E164"/>
+                <item code="1" name="END_USER_IMSI"/>
+                <item code="2" name="END_USER_SIP_URI"/>
+                <item code="3" name="END_USER_NAI"/>
+                <item code="4" name="END_USER_PRIVATE"/>
             </data>
         </avp>
 
-        <avp name="Flow-Direction" code="1080" must="V" must-not="M" map="P" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.65 -->
-            <data type="Enumerated">
-                <item code="0" name="UNSPECIFIED"/>
-                <item code="1" name="DOWNLINK"/>
-                <item code="2" name="UPLINK"/>
-                <item code="3" name="BIDIRECTIONAL"/>
-            </data>
+        <avp name="Subscription-Id-Data" code="444" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
+            <!-- http://tools.ietf.org/html/rfc4006#section-8.48 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="Redirect-Information" code="1085" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.82 -->
+        <avp name="APN-Configuration" code="1430" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.35 -->
             <data type="Grouped">
-                <rule avp="Redirect-Support" required="true" max="1"/>
-                <rule avp="Redirect-Address-Type" required="false" max="1"/>
-                <rule avp="Redirect-Server-Address" required="false" max="1"/>
-                <!-- *[ AVP ]-->
+                <rule avp="Context-Identifier" required="true" max="1"/>
+                <rule avp="Served-Party-IP-Address" required="false" max="2"/>
+                <rule avp="PDN-Type" required="true" max="1"/>
+                <rule avp="Service-Selection" required="true" max="1"/>
+                <rule avp="EPS-Subscribed-QoS-Profile" required="false" max="1"/>
+                <rule avp="VPLMN-Dynamic-Address-Allowed" required="false" max="1"/>
+                <rule avp="MIP6-Agent-Info" required="false" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="PDN-GW-Allocation-Type" required="false" max="1"/>
+                <rule avp="TGPP-Charging-Characteristics" required="false" max="1"/>
+                <rule avp="AMBR" required="false" max="1"/>
+                <rule avp="Specific-APN-Info" required="false"/>
+                <rule avp="APN-OI-Replacement" required="false" max="1"/>
+                <rule avp="SIPTO-Permission" required="false" max="1"/>
+                <rule avp="LIPA-Permission" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="Redirect-Support" code="1086" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.83 -->
-            <data type="Enumerated">
-                <item code="0" name="REDIRECTION_DISABLED"/>
-                <item code="1" name="REDIRECTION_ENABLED"/>
-            </data>
+        <avp name="Served-Party-IP-Address" code="848" must="M,V" may="P" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 32.299 [8] -->
+            <data type="Address"/>
         </avp>
 
-        <avp name="Network-Request-Support" code="1024" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.24 -->
+        <avp name="PDN-Type" code="1456" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.62 -->
             <data type="Enumerated">
-                <item code="0" name="NETWORK_REQUEST_NOT_SUPPORTED"/>
-                <item code="1" name="NETWORK_REQUEST_SUPPORTED"/>
+                <item code="0" name="IPv4"/>
+                <item code="1" name="IPv6"/>
+                <item code="2" name="IPv4v6"/>
+                <item code="3" name="IPv4_OR_IPv6"/>
             </data>
         </avp>
 
-        <avp name="Offline" code="1008" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.9 -->
-            <data type="Enumerated">
-                <item code="0" name="DISABLE_OFFLINE"/>
-                <item code="1" name="ENABLE_OFFLINE"/>
+        <avp name="EPS-Subscribed-QoS-Profile" code="1431" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.37 -->
+            <data type="Grouped">
+                <rule avp="QoS-Class-Identifier" required="true" max="1"/>
+                <rule avp="Allocation-Retention-Priority" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="Online" code="1009" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.10 -->
+        <avp name="QoS-Class-Identifier" code="1028" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.212 [10] -->
             <data type="Enumerated">
-                <item code="0" name="DISABLE_ONLINE"/>
-                <item code="1" name="ENABLE_ONLINE"/>
+                <item code="1" name="QCI_1"/>
+                <item code="2" name="QCI_2"/>
+                <item code="3" name="QCI_3"/>
+                <item code="4" name="QCI_4"/>
+                <item code="5" name="QCI_5"/>
+                <item code="6" name="QCI_6"/>
+                <item code="7" name="QCI_7"/>
+                <item code="8" name="QCI_8"/>
+                <item code="9" name="QCI_9"/>
+                <item code="65" name="QCI_65"/>
+                <item code="66" name="QCI_66"/>
+                <item code="69" name="QCI_69"/>
+                <item code="70" name="QCI_70"/>
+                <item code="75" name="QCI_75"/>
+                <item code="79" name="QCI_79"/>
             </data>
         </avp>
 
-        <avp name="Default-EPS-Bearer-QoS" code="1049" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.48 -->
+        <avp name="Allocation-Retention-Priority" code="1034" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.212 [10] -->
             <data type="Grouped">
-                <rule avp="QoS-Class-Identifier" required="false" max="1"/>
-                <rule avp="Allocation-Retention-Priority" required="false" max="1"/>
-                <!-- *[ AVP ]-->
+                <rule avp="Priority-Level" required="true" max="1"/>
+                <rule avp="Pre-emption-Capability" required="false" max="1"/>
+                <rule avp="Pre-emption-Vulnerability" required="false" max="1"/>
             </data>
         </avp>
 
-        <avp name="AN-GW-Address" code="1050" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.49 -->
-            <data type="Address"/>
+        <avp name="Priority-Level" code="1046" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.212 [10] -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="Bearer-Usage" code="1000" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.1 -->
+        <avp name="Pre-emption-Capability" code="1047" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.212 [10] -->
             <data type="Enumerated">
-                <item code="0" name="GENERAL"/>
-                <item code="1" name="IMS_SIGNALLING"/>
+                <item code="0" name="PRE-EMPTION_CAPABILITY_ENABLED"/>
+                <item code="1" name="PRE-EMPTION_CAPABILITY_DISABLED"/>
             </data>
         </avp>
 
-        <avp name="Access-Network-Charging-Identifier-Gx" code="1022" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 Section 5.3.22 -->
-            <data type="Grouped">
-                <rule avp="Access-Network-Charging-Identifier-Value" required="true" max="1"/>
-                <rule avp="Charging-Rule-Base-Name" required="false"/>
-                <rule avp="Charging-Rule-Name" required="false"/>
-                <!-- *[ AVP ]-->
+        <avp name="Pre-emption-Vulnerability" code="1048" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.212 [10] -->
+            <data type="Enumerated">
+                <item code="0" name="PRE-EMPTION_VULNERABILITY_ENABLED"/>
+                <item code="1" name="PRE-EMPTION_VULNERABILITY_DISABLED"/>
             </data>
         </avp>
 
-        <avp name="TGPP-SGSN-Address" code="6" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.061 Table 9a -->
+        <avp name="VPLMN-Dynamic-Address-Allowed" code="1432" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.38 -->
+            <data type="Enumerated">
+                <item code="0" name="NOTALLOWED"/>
+                <item code="1" name="ALLOWED"/>
+            </data>
+        </avp>
+
+        <avp name="PDN-GW-Allocation-Type" code="1438" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.44 -->
+            <data type="Enumerated">
+                <item code="0" name="STATIC"/>
+                <item code="1" name="DYNAMIC"/>
+            </data>
+        </avp>
+
+        <avp name="Specific-APN-Info" code="1472" vendor-id="10415" must="M,V" may-encrypt="N">
+            <!-- 3GPP TS 29.272 Section 7.3.82 -->
+            <data type="Grouped">
+                <rule avp="Service-Selection" required="true" max="1"/>
+                <rule avp="MIP6-Agent-Info" required="true" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="SIPTO-Permission" code="1613" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.135 -->
+            <data type="Enumerated">
+                <item code="0" name="SIPTO_ALLOWED"/>
+                <item code="1" name="SIPTO_NOTALLOWED"/>
+            </data>
+        </avp>
+
+        <avp name="LIPA-Permission" code="1618" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.132 -->
+            <data type="Enumerated">
+                <item code="0" name="LIPA-PROHIBITED"/>
+                <item code="1" name="LIPA-ONLY"/>
+                <item code="2" name="LIPA-CONDITIONAL"/>
+            </data>
+        </avp>
+
+        <avp name="AMBR" code="1435" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.41 -->
+            <data type="Grouped">
+                <rule avp="Max-Requested-Bandwidth-UL" required="true" max="1"/>
+                <rule avp="Max-Requested-Bandwidth-DL" required="true" max="1"/>
+                <rule avp="Extended-Max-Requested-BW-UL" required="true" max="1"/>
+                <rule avp="Extended-Max-Requested-BW-DL" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="Max-Requested-Bandwidth-DL" code="515" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.214 [11] -->
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="Max-Requested-Bandwidth-UL" code="516" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP TS 29.214 [11] -->
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="Extended-Max-Requested-BW-DL" code="554" must="V"    may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="Extended-Max-Requested-BW-UL" code="555" must="V"    may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="MIP6-Agent-Info" code="486" must="M" may="P" must-not="V" may-encrypt="Y">
+            <data type="Grouped">
+                <rule avp="MIP-Home-Agent-Address" required="false" max="2"/>
+                <rule avp="MIP-Home-Agent-Host" required="false" max="1"/>
+                <rule avp="MIP6-Home-Link-Prefix" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="Trace-Info" code="1505" must="V" must-not="M" vendor-id="10415">
+            <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.3.13 -->
+            <data type="Grouped">
+                <rule avp="Trace-Data" required="false" max="1"/>
+                <rule avp="Trace-Reference" required="false" max="1"/>
+            </data>
+        </avp>
+
+        <avp name="Trace-Data" code="1458" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.63 -->
+            <data type="Grouped">
+                <rule avp="Trace-Reference" required="true" max="1"/>
+                <rule avp="Trace-Depth" required="true" max="1"/>
+                <rule avp="Trace-NE-Type-List" required="true" max="1"/>
+                <rule avp="Trace-Interface-List" required="false" max="1"/>
+                <rule avp="Trace-Event-List" required="true" max="1"/>
+                <rule avp="OMC-Id" required="false" max="1"/>
+                <rule avp="Trace-Collection-Entity" required="true" max="1"/>
+                <rule avp="MDT-Configuration" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="Trace-Reference" code="1459" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.64 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="TGPP-GGSN-Address" code="7" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.061 Table 9a -->
+        <avp name="Trace-Depth" code="1462" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.67 -->
+            <data type="Enumerated">
+                <item code="0" name="LIPA-PROHIBITED"/>
+                <item code="1" name="LIPA-ONLY"/>
+                <item code="2" name="LIPA-CONDITIONAL"/>
+            </data>
+        </avp>
+
+        <avp name="Trace-NE-Type-List" code="1463" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.68 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Access-Network-Charging-Address" code="501" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP TS 29.214 Section 5.3.2 -->
-            <data type="Address"/>
+        <avp name="Trace-Interface-List" code="1464" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.69 -->
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="TGPP-MS-TimeZone" code="23" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.061 Table 9a -->
+        <avp name="Trace-Event-List" code="1465" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.70 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="TFT-Filter" code="1012" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 5.3.13-->
-            <data type="IPFilterRule"/>
+        <avp name="OMC-Id" code="1466" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.71 -->
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="TFT-Packet-Filter-Information" code="1013" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
-            <!-- 3GPP 29.212 5.3.14-->
+        <avp name="Trace-Collection-Entity" code="1452" must="M,V" may="P" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.98 -->
+            <data type="Address"/>
+        </avp>
+
+        <avp name="MDT-Configuration" code="1622" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.136 -->
             <data type="Grouped">
-                <rule avp="Precedence" required="false" max="1"/>
-                <rule avp="TFT-Filter" required="false" max="1"/>
-                <rule avp="ToS-Traffic-Class" required="false" max="1"/>
-                <rule avp="Security-Parameter-Index" required="false" max="1"/>
-                <rule avp="Flow-Label" required="false" max="1"/>
-                <rule avp="Flow-Direction" required="false" max="1"/>
-                <!-- *[ AVP ]-->
+                <rule avp="QoS-Class-Identifier" required="true" max="1"/>
+                <rule avp="Allocation-Retention-Priority" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="MIP-Home-Agent-Address" code="334" must="M" must-not="V">
+            <data type="Address"/>
+        </avp>
+
+        <avp name="MIP-Home-Agent-Host" code="348" must="M" may="P" must-not="V" may-encrypt="Y">
+            <data type="Grouped">
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
+        <avp name="MIP6-Home-Link-Prefix" code="125">
+            <data type="OctetString"/>
+        </avp>
+    </application>
+
+    <application id="16777264" type="auth" parent="16777250" name="TGPP SWm">
+        <!--
+            3GPP TS 29.273 Section 7: SWm, between the ePDG and the 3GPP
+            AAA server. AVPs are inherited from the STa application.
+        -->
+        <vendor id="10415" name="TGPP"/>
+
+        <command code="268" short="DE" name="Diameter-EAP">
+            <request>
+                <!-- 3GPP TS 29.273 Section 7.2.2.1.1 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="DRMP" required="false" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
+                <rule avp="EAP-Payload" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="RAT-Type" required="false" max="1"/>
+                <rule avp="Service-Selection" required="false" max="1"/>
+                <rule avp="MIP6-Feature-Vector" required="false" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="AAA-Failure-Indication" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="UE-Local-IP-Address" required="false" max="1"/>
+                <rule avp="OC-Supported-Features" required="false" max="1"/>
+                <rule avp="Terminal-Information" required="false" max="1"/>
+                <rule avp="Emergency-Services" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </request>
+            <answer>
+                <!-- 3GPP TS 29.273 Section 7.2.2.1.1 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="DRMP" required="false" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Auth-Request-Type" required="true" max="1"/>
+                <rule avp="EAP-Payload" required="false" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Session-Timeout" required="false" max="1"/>
+                <rule avp="Acct-Interim-Interval" required="false" max="1"/>
+                <rule avp="EAP-Master-Session-Key" required="false" max="1"/>
+                <rule avp="Context-Identifier" required="false" max="1"/>
+                <rule avp="APN-OI-Replacement" required="false" max="1"/>
+                <rule avp="APN-Configuration" required="false"/>
+                <rule avp="MIP6-Feature-Vector" required="false" max="1"/>
+                <rule avp="Mobile-Node-Identifier" required="false" max="1"/>
+                <rule avp="Trace-Info" required="false" max="1"/>
+                <rule avp="Subscription-Id" required="false" max="1"/>
+                <rule avp="TGPP-Charging-Characteristics" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="OC-Supported-Features" required="false" max="1"/>
+                <rule avp="OC-OLR" required="false" max="1"/>
+                <rule avp="Redirect-Host" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </answer>
+        </command>
     </application>
 </diameter>`
 
-var networkaccessserverXML = `<?xml version="1.0" encoding="UTF-8"?>
+var diametersyXML = `<?xml version="1.0" encoding="UTF-8"?>
 <diameter>
 
-	<application id="1" type="auth" name="Network Access">
-		<!-- Diameter Network Access Server Application -->
-		<!-- http://tools.ietf.org/html/rfc7155 -->
+	<application id="16777302" type="auth" name="Diameter Sy">
+		<!-- Diameter Sy Application -->
+		<!-- 3GPP TS 29.219 -->
+		<vendor id="10415" name="TGPP"/>
 
-		<command code="265" short="AA" name="AA">
+		<command code="8388635" short="SL" name="Spending-Limit">
 			<request>
-				<!-- https://tools.ietf.org/html/rfc7155#section-3.1 -->
-				<rule avp="Session-Id" required="true" max="1"/>
+				<!-- 3GPP TS 29.219 section 5.6.2 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Auth-Application-Id" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
-				<rule avp="Auth-Request-Type" required="true" max="1"/>
+				<rule avp="SL-Request-Type" required="true" max="1"/>
 				<rule avp="Destination-Host" required="false" max="1"/>
-				<rule avp="NAS-Identifier" required="false" max="1"/>
-				<rule avp="NAS-IP-Address" required="true" max="1"/>
-				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
-				<rule avp="NAS-Port" required="false" max="1"/>
-				<rule avp="NAS-Port-Id" required="false" max="1"/>
-				<rule avp="NAS-Port-Type" required="false" max="1"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
 				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="Port-Limit" required="false" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="User-Password" required="false" max="1"/>
-				<rule avp="Service-Type" required="false" max="1"/>
-				<rule avp="State" required="false" max="1"/>
-				<rule avp="Authorization-Lifetime" required="false" max="1"/>
-				<rule avp="Auth-Grace-Period" required="false" max="1"/>
-				<rule avp="Auth-Session-State" required="false" max="1"/>
-				<rule avp="Callback-Number" required="false" max="1"/>
-				<rule avp="Called-Station-Id" required="false" max="1"/>
-				<rule avp="Calling-Station-Id" required="false" max="1"/>
-				<rule avp="Originating-Line-Info" required="false" max="1"/>
-				<rule avp="Connect-Info" required="false" max="1"/>
-				<rule avp="CHAP-Auth" required="false" max="1"/>
-				<rule avp="CHAP-Challenge" required="false" max="1"/>
-				<rule avp="Framed-Compression" required="false"/>
-				<rule avp="Framed-Interface-Id" required="false" max="1"/>
-				<rule avp="Framed-IP-Address" required="false" max="1"/>
-				<rule avp="Framed-IPv6-Prefix" required="false"/>
-				<rule avp="Framed-IP-Netmask" required="false" max="1"/>
-				<rule avp="Framed-MTU" required="false" max="1"/>
-				<rule avp="Framed-Protocol" required="false" max="1"/>
-				<rule avp="ARAP-Password" required="false" max="1"/>
-				<rule avp="ARAP-Security" required="false" max="1"/>
-				<rule avp="ARAP-Security-Data" required="false"/>
-				<rule avp="Login-IP-Host" required="false"/>
-				<rule avp="Login-IPv6-Host" required="false"/>
-				<rule avp="Login-LAT-Group" required="false" max="1"/>
-				<rule avp="Login-LAT-Node" required="false" max="1"/>
-				<rule avp="Login-LAT-Port" required="false" max="1"/>
-				<rule avp="Login-LAT-Service" required="false" max="1"/>
-				<rule avp="Tunneling" required="false"/>
+				<rule avp="Subscription-Id" required="false"/>
+				<rule avp="Policy-Counter-Identifier" required="false"/>
+				<rule avp="Supported-Features" required="false"/>
 				<rule avp="Proxy-Info" required="false"/>
 				<rule avp="Route-Record" required="false"/>
+				<rule avp="Service-Information" required="false" max="1"/>
 			</request>
 			<answer>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.2 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Auth-Application-Id" required="true" max="1"/>
-				<rule avp="Auth-Request-Type" required="true" max="1"/>
-				<rule avp="Result-Code" required="true" max="1"/>
+				<!-- 3GPP TS 29.219 section 5.6.3 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Service-Type" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
-				<rule avp="Configuration-Token" required="false"/>
-				<rule avp="Acct-Interim-Interval" required="false" max="1"/>
-				<rule avp="Error-Message" required="false" max="1"/>
-				<rule avp="Error-Reporting-Host" required="false" max="1"/>
-				<rule avp="Failed-AVP" required="false"/>
-				<rule avp="Idle-Timeout" required="false" max="1"/>
-				<rule avp="Authorization-Lifetime" required="false" max="1"/>
-				<rule avp="Auth-Grace-Period" required="false" max="1"/>
-				<rule avp="Auth-Session-State" required="false" max="1"/>
-				<rule avp="Re-Auth-Request-Type" required="false" max="1"/>
-				<rule avp="Multi-Round-Time-Out" required="false" max="1"/>
-				<rule avp="Session-Timeout" required="false" max="1"/>
-				<rule avp="State" required="false" max="1"/>
-				<rule avp="Reply-Message" required="false"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Result-Code" required="false" max="1"/>
+				<rule avp="Experimental-Result" required="false" max="1"/>
+				<rule avp="Policy-Counter-Status-Report" required="false"/>
 				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="Filter-Id" required="false"/>
-				<rule avp="Password-Retry" required="false" max="1"/>
-				<rule avp="Port-Limit" required="false" max="1"/>
-				<rule avp="Prompt" required="false" max="1"/>
-				<rule avp="ARAP-Challenge-Response" required="false" max="1"/>
-				<rule avp="ARAP-Features" required="false" max="1"/>
-				<rule avp="ARAP-Security" required="false" max="1"/>
-				<rule avp="ARAP-Security-Data" required="false"/>
-				<rule avp="ARAP-Zone-Access" required="false" max="1"/>
-				<rule avp="Callback-Id" required="false" max="1"/>
-				<rule avp="Callback-Number" required="false" max="1"/>
-				<rule avp="Framed-Appletalk-Link" required="false" max="1"/>
-				<rule avp="Framed-Appletalk-Network" required="false"/>
-				<rule avp="Framed-Appletalk-Zone" required="false" max="1"/>
-				<rule avp="Framed-Compression" required="false"/>
-				<rule avp="Framed-Interface-Id" required="false" max="1"/>
-				<rule avp="Framed-IP-Address" required="false" max="1"/>
-				<rule avp="Framed-IPv6-Prefix" required="false"/>
-				<rule avp="Framed-IPv6-Pool" required="false" max="1"/>
-				<rule avp="Framed-IPv6-Route" required="false"/>
-				<rule avp="Framed-IP-Netmask" required="false" max="1"/>
-				<rule avp="Framed-Route" required="false"/>
-				<rule avp="Framed-Pool" required="false" max="1"/>
-				<rule avp="Framed-IPX-Network" required="false" max="1"/>
-				<rule avp="Framed-MTU" required="false" max="1"/>
-				<rule avp="Framed-Protocol" required="false" max="1"/>
-				<rule avp="Framed-Routing" required="false" max="1"/>
-				<rule avp="Login-IP-Host" required="false"/>
-				<rule avp="Login-IPv6-Host" required="false"/>
-				<rule avp="Login-LAT-Group" required="false" max="1"/>
-				<rule avp="Login-LAT-Node" required="false" max="1"/>
-				<rule avp="Login-LAT-Port" required="false" max="1"/>
-				<rule avp="Login-LAT-Service" required="false" max="1"/>
-				<rule avp="Login-Service" required="false" max="1"/>
-				<rule avp="Login-TCP-Port" required="false" max="1"/>
-				<rule avp="NAS-Filter-Rule" required="false"/>
-				<rule avp="QoS-Filter-Rule" required="false"/>
-				<rule avp="Tunneling" required="false"/>
+				<rule avp="Supported-Features" required="false"/>
 				<rule avp="Redirect-Host" required="false"/>
 				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
 				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
 				<rule avp="Proxy-Info" required="false"/>
+				<rule avp="Route-Record" required="false"/>
+				<rule avp="Failed-AVP" required="false" max="1"/>
 			</answer>
 		</command>
 
-		<command code="258" short="RA" name="Re-Auth">
+		<command code="8388636" short="SN" name="Spending-Status-Notification">
 			<request>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.3 -->
-				<rule avp="Session-Id" required="true" max="1"/>
+				<!-- 3GPP TS 29.219 section 5.6.4 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="DRMP" required="false" max="1"/>
+				<rule avp="Auth-Application-Id" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
 				<rule avp="Destination-Host" required="true" max="1"/>
-				<rule avp="Auth-Application-Id" required="true" max="1"/>
-				<rule avp="Re-Auth-Request-Type" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
 				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="NAS-Identifier" required="false" max="1"/>
-				<rule avp="NAS-IP-Address" required="true" max="1"/>
-				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
-				<rule avp="NAS-Port" required="false" max="1"/>
-				<rule avp="NAS-Port-Id" required="false" max="1"/>
-				<rule avp="NAS-Port-Type" required="false" max="1"/>
-				<rule avp="Service-Type" required="false" max="1"/>
-				<rule avp="Framed-IP-Address" required="false" max="1"/>
-				<rule avp="Framed-IPv6-Prefix" required="false" max="1"/>
-				<rule avp="Framed-Interface-Id" required="false" max="1"/>
-				<rule avp="Called-Station-Id" required="false" max="1"/>
-				<rule avp="Calling-Station-Id" required="false" max="1"/>
-				<rule avp="Originating-Line-Info" required="false" max="1"/>
-				<rule avp="Acct-Session-Id" required="false" max="1"/>
-				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
-				<rule avp="State" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
-				<rule avp="Reply-Message" required="false" max="1"/>
+				<rule avp="Policy-Counter-Status-Report" required="false"/>
+				<rule avp="SN-Request-Type" required="false" max="1"/>
 				<rule avp="Proxy-Info" required="false"/>
 				<rule avp="Route-Record" required="false"/>
 			</request>
 			<answer>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.4 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Result-Code" required="true" max="1"/>
+				<!-- 3GPP TS 29.219 section 5.6.5 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Result-Code" required="false" max="1"/>
+				<rule avp="Experimental-Result" required="false" max="1"/>
 				<rule avp="Origin-State-Id" required="false" max="1"/>
 				<rule avp="Error-Message" required="false" max="1"/>
 				<rule avp="Error-Reporting-Host" required="false" max="1"/>
-				<rule avp="Failed-AVP" required="false"/>
-				<rule avp="Redirect-Host" required="false"/>
-				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
-				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
-				<rule avp="Service-Type" required="false" max="1"/>
-				<rule avp="Configuration-Token" required="false"/>
-				<rule avp="Idle-Timeout" required="false" max="1"/>
-				<rule avp="Authorization-Lifetime" required="false" max="1"/>
-				<rule avp="Auth-Grace-Period" required="false" max="1"/>
-				<rule avp="Re-Auth-Request-Type" required="false" max="1"/>
-				<rule avp="State" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
-				<rule avp="Reply-Message" required="false"/>
-				<rule avp="Prompt" required="false" max="1"/>
+				<rule avp="Failed-AVP" required="false" max="1"/>
 				<rule avp="Proxy-Info" required="false"/>
 			</answer>
 		</command>
 
 		<command code="275" short="ST" name="Session-Termination">
 			<request>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.5 -->
-				<rule avp="Session-Id" required="true" max="1"/>
+				<!-- 3GPP TS 29.219 section 5.6.6 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
 				<rule avp="Auth-Application-Id" required="true" max="1"/>
 				<rule avp="Termination-Cause" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
 				<rule avp="Destination-Host" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
 				<rule avp="Origin-State-Id" required="false" max="1"/>
 				<rule avp="Proxy-Info" required="false"/>
 				<rule avp="Route-Record" required="false"/>
 			</request>
 			<answer>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.6 -->
-				<rule avp="Session-Id" required="true" max="1"/>
+				<!-- 3GPP TS 29.219 section 5.6.7 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Result-Code" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
 				<rule avp="Error-Message" required="false" max="1"/>
 				<rule avp="Error-Reporting-Host" required="false" max="1"/>
-				<rule avp="Failed-AVP" required="false"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Failed-AVP" required="false" max="1"/>
 				<rule avp="Origin-State-Id" required="false" max="1"/>
 				<rule avp="Redirect-Host" required="false"/>
 				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
@@ -1728,741 +1825,2095 @@ var networkaccessserverXML = `<?xml version="1.0" encoding="UTF-8"?>
 			</answer>
 		</command>
 
-		<command code="274" short="AS" name="Abort-Session">
-			<request>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.7 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Origin-Host" required="true" max="1"/>
-				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="Destination-Realm" required="true" max="1"/>
-				<rule avp="Destination-Host" required="true" max="1"/>
-				<rule avp="Auth-Application-Id" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
-				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="NAS-Identifier" required="false" max="1"/>
-				<rule avp="NAS-IP-Address" required="true" max="1"/>
-				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
-				<rule avp="NAS-Port" required="false" max="1"/>
-				<rule avp="NAS-Port-Id" required="false" max="1"/>
-				<rule avp="NAS-Port-Type" required="false" max="1"/>
-				<rule avp="Service-Type" required="false" max="1"/>
-				<rule avp="Framed-IP-Address" required="false" max="1"/>
-				<rule avp="Framed-IPv6-Prefix" required="false" max="1"/>
-				<rule avp="Framed-Interface-Id" required="false" max="1"/>
-				<rule avp="Called-Station-Id" required="false" max="1"/>
-				<rule avp="Calling-Station-Id" required="false" max="1"/>
-				<rule avp="Originating-Line-Info" required="false" max="1"/>
-				<rule avp="Acct-Session-Id" required="false" max="1"/>
-				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
-				<rule avp="State" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
-				<rule avp="Reply-Message" required="false"/>
-				<rule avp="Proxy-Info" required="false"/>
-				<rule avp="Route-Record" required="false"/>
-			</request>
-			<answer>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.8 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Result-Code" required="true" max="1"/>
-				<rule avp="Origin-Host" required="true" max="1"/>
-				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
-				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="State" required="false" max="1"/>
-				<rule avp="Error-Message" required="false" max="1"/>
-				<rule avp="Error-Reporting-Host" required="false" max="1"/>
-				<rule avp="Failed-AVP" required="false"/>
-				<rule avp="Redirect-Host" required="false"/>
-				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
-				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
-				<rule avp="Proxy-Info" required="false"/>
-			</answer>
-		</command>
+		<avp name="Policy-Counter-Identifier" code="2901" vendor-id="10415" must="M,V" may="P" may-encrypt="Y">
+			<data type="UTF8String"/>
+		</avp>
 
-		<command code="271" short="AC" name="Accounting">
-			<request>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.9 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Origin-Host" required="true" max="1"/>
-				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="Destination-Realm" required="true" max="1"/>
-				<rule avp="Accounting-Record-Type" required="true" max="1"/>
-				<rule avp="Accounting-Record-Number" required="true" max="1"/>
-				<rule avp="Acct-Application-Id" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Accounting-Sub-Session-Id" required="false" max="1"/>
-				<rule avp="Acct-Session-Id" required="false" max="1"/>
-				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
-				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="Destination-Host" required="false" max="1"/>
-				<rule avp="Event-Timestamp" required="false" max="1"/>
-				<rule avp="Acct-Delay-Time" required="false" max="1"/>
-				<rule avp="NAS-Identifier" required="false" max="1"/>
-				<rule avp="NAS-IP-Address" required="true" max="1"/>
-				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
-				<rule avp="NAS-Port" required="false" max="1"/>
-				<rule avp="NAS-Port-Id" required="false" max="1"/>
-				<rule avp="NAS-Port-Type" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
-				<rule avp="Service-Type" required="false" max="1"/>
-				<rule avp="Termination-Cause" required="false" max="1"/>
-				<rule avp="Accounting-Input-Octets" required="false" max="1"/>
-				<rule avp="Accounting-Input-Packets" required="false" max="1"/>
-				<rule avp="Accounting-Output-Octets" required="false" max="1"/>
-				<rule avp="Accounting-Output-Packets" required="false" max="1"/>
-				<rule avp="Acct-Authentic" required="false" max="1"/>
-				<rule avp="Accounting-Auth-Method" required="false" max="1"/>
-				<rule avp="Acct-Link-Count" required="false" max="1"/>
-				<rule avp="Acct-Session-Time" required="false" max="1"/>
-				<rule avp="Acct-Tunnel-Connection" required="false" max="1"/>
-				<rule avp="Acct-Tunnel-Packets-Lost" required="false" max="1"/>
-				<rule avp="Callback-Id" required="false" max="1"/>
-				<rule avp="Callback-Number" required="false" max="1"/>
-				<rule avp="Called-Station-Id" required="false" max="1"/>
-				<rule avp="Calling-Station-Id" required="false" max="1"/>
-				<rule avp="Connection-Info" required="false"/>
-				<rule avp="Originating-Line-Info" required="false" max="1"/>
-				<rule avp="Authorization-Lifetime" required="false" max="1"/>
-				<rule avp="Session-Timeout" required="false" max="1"/>
-				<rule avp="Idle-Timeout" required="false" max="1"/>
-				<rule avp="Port-Limit" required="false" max="1"/>
-				<rule avp="Accounting-Realtime-Required" required="false" max="1"/>
-				<rule avp="Acct-Interim-Interval" required="false" max="1"/>
-				<rule avp="Filter-Id" required="false"/>
-				<rule avp="NAS-Filter-Rule" required="false"/>
-				<rule avp="QoS-Filter-Rule" required="false"/>
-				<rule avp="Framed-Appletalk-Link" required="false" max="1"/>
-				<rule avp="Framed-Appletalk-Network" required="false" max="1"/>
-				<rule avp="Framed-Appletalk-Zone" required="false" max="1"/>
-				<rule avp="Framed-Compression" required="false" max="1"/>
-				<rule avp="Framed-Interface-Id" required="false" max="1"/>
-				<rule avp="Framed-IP-Address" required="false" max="1"/>
-				<rule avp="Framed-IP-Netmask" required="false" max="1"/>
-				<rule avp="Framed-IPv6-Prefix" required="false"/>
-				<rule avp="Framed-IPv6-Pool" required="false" max="1"/>
-				<rule avp="Framed-IPv6-Route" required="false"/>
-				<rule avp="Framed-IPX-Network" required="false" max="1"/>
-				<rule avp="Framed-MTU" required="false" max="1"/>
-				<rule avp="Framed-Pool" required="false" max="1"/>
-				<rule avp="Framed-Protocol" required="false" max="1"/>
-				<rule avp="Framed-Route" required="false"/>
-				<rule avp="Framed-Routing" required="false" max="1"/>
-				<rule avp="Login-IP-Host" required="false"/>
-				<rule avp="Login-IPv6-Host" required="false"/>
-				<rule avp="Login-LAT-Group" required="false" max="1"/>
-				<rule avp="Login-LAT-Node" required="false" max="1"/>
-				<rule avp="Login-LAT-Port" required="false" max="1"/>
-				<rule avp="Login-LAT-Service" required="false" max="1"/>
-				<rule avp="Login-Service" required="false" max="1"/>
-				<rule avp="Login-TCP-Port" required="false" max="1"/>
-				<rule avp="Tunneling" required="false"/>
-				<rule avp="Proxy-Info" required="false"/>
-				<rule avp="Route-Record" required="false"/>
-			</request>
-			<answer>
-				<!-- http://tools.ietf.org/html/rfc7155#section-3.10 -->
-				<rule avp="Session-Id" required="true" max="1"/>
-				<rule avp="Result-Code" required="true" max="1"/>
-				<rule avp="Origin-Host" required="true" max="1"/>
-				<rule avp="Origin-Realm" required="true" max="1"/>
-				<rule avp="Accounting-Record-Type" required="true" max="1"/>
-				<rule avp="Accounting-Record-Number" required="true" max="1"/>
-				<rule avp="Acct-Application-Id" required="true" max="1"/>
-				<rule avp="User-Name" required="false" max="1"/>
-				<rule avp="Accounting-Sub-Session-Id" required="false" max="1"/>
-				<rule avp="Acct-Session-Id" required="false" max="1"/>
-				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
-				<rule avp="Event-Timestamp" required="false" max="1"/>
-				<rule avp="Error-Message" required="false" max="1"/>
-				<rule avp="Error-Reporting-Host" required="false" max="1"/>
-				<rule avp="Failed-AVP" required="false"/>
-				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
-				<rule avp="Origin-State-Id" required="false" max="1"/>
-				<rule avp="NAS-Identifier" required="false" max="1"/>
-				<rule avp="NAS-IP-Address" required="true" max="1"/>
-				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
-				<rule avp="NAS-Port" required="false" max="1"/>
-				<rule avp="NAS-Port-Id" required="false" max="1"/>
-				<rule avp="NAS-Port-Type" required="false" max="1"/>
-				<rule avp="Service-Type" required="false" max="1"/>
-				<rule avp="Termination-Cause" required="false" max="1"/>
-				<rule avp="Accounting-Realtime-Required" required="false" max="1"/>
-				<rule avp="Acct-Interim-Interval" required="false" max="1"/>
-				<rule avp="Class" required="false"/>
-				<rule avp="Proxy-Info" required="false"/>
-			</answer>
-		</command>
-
-
-
-		<avp name="NAS-Port" code="5" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.2 -->
-			<data type="Unsigned32"/>
-		</avp>
-
-		<avp name="NAS-Port-Id" code="87" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.3 -->
+		<avp name="Policy-Counter-Status" code="2902" vendor-id="10415" must="M,V" may="P" may-encrypt="Y">
 			<data type="UTF8String"/>
 		</avp>
 
-		<avp name="NAS-Port-Type" code="61" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.4 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-13 -->
-				<item code="0" name="Async"/>
-				<item code="1" name="Sync"/>
-				<item code="2" name="ISDN Sync"/>
-				<item code="3" name="ISDN Async V.120"/>
-				<item code="4" name="ISDN Async V.110"/>
-				<item code="5" name="Virtual"/>
-				<item code="6" name="PIAFS"/>
-				<item code="7" name="HDLC Clear Channel"/>
-				<item code="8" name="X.25"/>
-				<item code="9" name="X.75"/>
-				<item code="10" name="G.3 Fax"/>
-				<item code="11" name="SDSL - Symmetric DSL"/>
-				<item code="12" name="ADSL-CAP - Asymmetric DSL, Carrierless Amplitude Phase Modulation"/>
-				<item code="13" name="ADSL-DMT - Asymmetric DSL, Discrete Multi-Tone"/>
-				<item code="14" name="IDSL - ISDN Digital Subscriber Line"/>
-				<item code="15" name="Ethernet"/>
-				<item code="16" name="xDSL - Digital Subscriber Line of unknown type"/>
-				<item code="17" name="Cable"/>
-				<item code="18" name="Wireless - Other"/>
-				<item code="19" name="Wireless - IEEE 802.11"/>
-				<item code="20" name="Token-Ring"/>
-				<item code="21" name="FDDI"/>
-				<item code="22" name="Wireless - CDMA2000"/>
-				<item code="23" name="Wireless - UMTS"/>
-				<item code="24" name="Wireless - 1X-EV"/>
-				<item code="25" name="IAPP"/>
-				<item code="26" name="FTTP - Fiber to the Premises"/>
-				<item code="27" name="Wireless - IEEE 802.16"/>
-				<item code="28" name="Wireless - IEEE 802.20"/>
-				<item code="29" name="Wireless - IEEE 802.22"/>
-				<item code="30" name="PPPoA - PPP over ATM"/>
-				<item code="31" name="PPPoEoA - PPP over Ethernet over ATM"/>
-				<item code="32" name="PPPoEoE - PPP over Ethernet over Ethernet"/>
-				<item code="33" name="PPPoEoVLAN - PPP over Ethernet over VLAN"/>
-				<item code="34" name="PPPoEoQinQ - PPP over Ethernet over IEEE 802.1QinQ"/>
-				<item code="35" name="xPON - Passive Optical Network"/>
-				<item code="36" name="Wireless - XGP"/>
-				<item code="37" name="WiMAX Pre-Release 8 IWK Function"/>
-				<item code="38" name="WIMAX-WIFI-IWK: WiMAX WIFI Interworking"/>
-				<item code="39" name="WIMAX-SFF: Signaling Forwarding Function for LTE/3GPP2"/>
-				<item code="40" name="WIMAX-HA-LMA: WiMAX HA and or LMA function"/>
-				<item code="41" name="WIMAX-DHCP: WIMAX DCHP service"/>
-				<item code="42" name="WIMAX-LBS: WiMAX location based service"/>
-				<item code="43" name="WIMAX-WVS: WiMAX voice service"/>
+		<avp name="Policy-Counter-Status-Report" code="2903" vendor-id="10415" must="M,V" may="P" may-encrypt="Y">
+			<data type="Grouped">
+				<rule avp="Policy-Counter-Identifier" required="true" max="1"/>
+				<rule avp="Policy-Counter-Status" required="true" max="1"/>
+				<rule avp="Pending-Policy-Counter-Information" required="false"/>
 			</data>
 		</avp>
 
-		<avp name="Called-Station-Id" code="30" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.5 -->
-			<data type="UTF8String"/>
-		</avp>
-
-		<avp name="Calling-Station-Id" code="31" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.6 -->
-			<data type="UTF8String"/>
-		</avp>
-
-		<avp name="Connect-Info" code="77" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.7 -->
-			<data type="UTF8String"/>
-		</avp>
-
-		<avp name="Originating-Line-Info" code="94" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.8 -->
-			<data type="OctetString"/>
-		</avp>
-
-		<avp name="Reply-Message" code="18" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.9 -->
-			<data type="UTF8String"/>
-		</avp>
-
-		<avp name="User-Password" code="2" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.1 -->
-			<data type="OctetString"/>
-		</avp>
-
-		<avp name="Password-Retry" code="75" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.2 -->
-			<data type="Unsigned32"/>
+		<!-- SL-Request-Type without vendor, as in earlier versions of this
+		     dictionary, so that peers still sending it can be decoded. It
+		     must come before the 3GPP definition, which is the one found
+		     by name and sent. -->
+		<avp name="SL-Request-Type" code="2904" must="M" may="P" must-not="V" may-encrypt="-">
+			<data type="Enumerated">
+				<item code="0" name="INITIAL_REQUEST"/>
+				<item code="1" name="INTERMEDIATE_REQUEST"/>
+			</data>
 		</avp>
 
-		<avp name="Prompt" code="76" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.3 -->
+		<avp name="SL-Request-Type" code="2904" vendor-id="10415" must="M,V" may="P" may-encrypt="Y">
 			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-17 -->
-				<item code="0" name="No Echo"/>
-				<item code="1" name="Echo"/>
+				<item code="0" name="INITIAL_REQUEST"/>
+				<item code="1" name="INTERMEDIATE_REQUEST"/>
 			</data>
 		</avp>
 
-		<avp name="CHAP-Auth" code="402" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.4 -->
+		<avp name="Pending-Policy-Counter-Information" code="2905" vendor-id="10415" must="M,V" may="P" may-encrypt="Y">
 			<data type="Grouped">
-				<rule avp="CHAP-Algorithm" required="true" max="1"/>
-				<rule avp="CHAP-Ident" required="true" max="1"/>
-				<rule avp="CHAP-Response" required="true" max="1"/>
+				<rule avp="Policy-Counter-Status" required="true" max="1"/>
+				<rule avp="Pending-Policy-Counter-Change-Time" required="true" max="1"/>
 			</data>
 		</avp>
 
+		<avp name="Pending-Policy-Counter-Change-Time" code="2906" vendor-id="10415" must="M,V" may="P" may-encrypt="Y">
+			<data type="Time"/>
+		</avp>
 
-		<avp name="CHAP-Algorithm" code="403" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.5 -->
+		<avp name="SN-Request-Type" code="2907" vendor-id="10415" must="V" may="P" must-not="M" may-encrypt="Y">
 			<data type="Enumerated">
-				<item code="5" name="CHAP with MD5"/>
+				<item code="0" name="NORMAL_REQUEST"/>
+				<item code="1" name="AGGREGATED_REQUEST"/>
 			</data>
 		</avp>
 
-		<avp name="CHAP-Ident" code="404" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.6 -->
-			<data type="OctetString"/>
-		</avp>
-
-		<avp name="CHAP-Response" code="405" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.7 -->
-			<data type="OctetString"/>
-		</avp>
-
-		<avp name="CHAP-Challenge" code="60" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.8 -->
-			<data type="OctetString"/>
-		</avp>
-
-		<avp name="ARAP-Password" code="70" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.9 -->
-			<data type="OctetString"/>
-		</avp>
-
-		<avp name="ARAP-Challenge-Response" code="84" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.10 -->
-			<data type="OctetString"/>
-		</avp>
-
-		<avp name="ARAP-Security" code="73" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.11 -->
-			<data type="Unsigned32"/>
+		<avp name="Subscription-Id" code="443" must="M" may="P" must-not="V" may-encrypt="Y">
+			<data type="Grouped">
+				<rule avp="Subscription-Id-Type" required="true" max="1"/>
+				<rule avp="Subscription-Id-Data" required="true" max="1"/>
+			</data>
 		</avp>
 
-		<avp name="ARAP-Security-Data" code="74" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.12 -->
-			<data type="OctetString"/>
+		<avp name="Subscription-Id-Data" code="444" must="M" may="P" must-not="V" may-encrypt="Y">
+			<data type="UTF8String"/>
 		</avp>
 
-		<avp name="Service-Type" code="6" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.1 -->
+		<avp name="Subscription-Id-Type" code="450" must="M" may="P" must-not="V" may-encrypt="Y">
 			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-4 -->
-				<item code="1" name="Login"/>
-				<item code="2" name="Framed"/>
-				<item code="3" name="Callback Login"/>
-				<item code="4" name="Callback Framed"/>
-				<item code="5" name="Outbound"/>
-				<item code="6" name="Administrative"/>
-				<item code="7" name="NAS Prompt"/>
-				<item code="8" name="Authenticate Only"/>
-				<item code="9" name="Callback NAS Prompt"/>
-				<item code="10" name="Call Check"/>
-				<item code="11" name="Callback Administrative"/>
-				<item code="12" name="Voice"/>
-				<item code="13" name="Fax"/>
-				<item code="14" name="Modem Relay"/>
-				<item code="15" name="IAPP-Register"/>
-				<item code="16" name="IAPP-AP-Check"/>
-				<item code="17" name="Authorize Only"/>
-				<item code="18" name="Framed-Management"/>
-				<item code="19" name="Additional-Authorization"/>
+				<item code="0" name="END_USER_E164"/>
+				<item code="1" name="END_USER_IMSI"/>
+				<item code="2" name="END_USER_SIP_URI"/>
+				<item code="3" name="END_USER_NAI"/>
+				<item code="4" name="END_USER_PRIVATE"/>
 			</data>
 		</avp>
 
-		<avp name="Callback-Number" code="19" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.2 -->
-			<data type="UTF8String"/>
-		</avp>
-
-		<avp name="Callback-Id" code="20" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.3 -->
-			<data type="UTF8String"/>
+		<avp name="Supported-Features" code="628" vendor-id="10415" must="V" may="M" may-encrypt="N">
+			<data type="Grouped">
+				<rule avp="Vendor-Id" required="true" max="1"/>
+				<rule avp="Feature-List-ID" required="true" max="1"/>
+				<rule avp="Feature-List" required="true" max="1"/>
+			</data>
 		</avp>
 
-		<avp name="Idle-Timeout" code="28" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.4 -->
+		<avp name="Feature-List-ID" code="629" vendor-id="10415" must="V" may="M" may-encrypt="N">
 			<data type="Unsigned32"/>
 		</avp>
 
-		<avp name="Port-Limit" code="62" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.5 -->
+		<avp name="Feature-List" code="630" vendor-id="10415" must="V" may="M" may-encrypt="N">
 			<data type="Unsigned32"/>
 		</avp>
 
-		<avp name="NAS-Filter-Rule" code="400" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.6 -->
-			<data type="IPFilterRule"/>
-		</avp>
+	</application>
+</diameter>`
 
-		<avp name="Filter-Id" code="11" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.7 -->
-			<data type="UTF8String"/>
-		</avp>
+var gxcreditcontrolXML = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+
+    <application id="16777238" type="auth" parent="4" name="Gx Charging Control">
+        <!-- Diameter Gx Credit Control Application -->
+        <!-- 3GPP 29.212 -->
+
+        <vendor id="10415" name="TGPP"/>
+        <command code="272" short="CC" name="Credit-Control">
+            <request>
+                <!-- 3GPP 29.212 Section 5.6.2 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="CC-Request-Type" required="true" max="1"/>
+                <rule avp="CC-Request-Number" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Subscription-Id" required="false" max="1"/>
+                <rule avp="Termination-Cause" required="false" max="1"/>
+                <rule avp="User-Equipment-Info" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false" max="1"/>
+                <rule avp="Route-Record" required="false" max="1"/>
+                <rule avp="Framed-IP-Address" required="false" max="1"/>
+                <rule avp="Framed-IPv6-Prefix" required="false"/>
+                <rule avp="IP-CAN-Type" required="false" max="1"/>
+                <rule avp="Called-Station-Id" required="false" max="1"/>
+                <rule avp="RAT-Type" required="false" max="1"/>
+                <rule avp="Network-Request-Support" required="false" max="1"/>
+                <rule avp="Default-EPS-Bearer-QoS" required="false" max="1"/>
+                <rule avp="AN-GW-Address" required="false" max="2"/>
+                <rule avp="Bearer-Usage" required="false" max="1"/>
+                <rule avp="Online" required="false" max="1"/>
+                <rule avp="Offline" required="false" max="1"/>
+                <rule avp="Access-Network-Charging-Identifier-Gx" required="false"/>
+                <rule avp="TGPP-SGSN-Address" required="false" max="1"/>
+                <rule avp="TGPP-GGSN-Address" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Access-Network-Charging-Address" required="false" max="1"/>
+                <rule avp="TGPP-MS-TimeZone" required="false" max="1"/>
+                <rule avp="TGPP-Selection-Mode" required="false" max="1"/>
+                <rule avp="QoS-Information" required="false" max="1"/>
+                <rule avp="TGPP-SGSN-MCC-MNC" required="false" max="1"/>
+                <rule avp="TGPP-User-Location-Info" required="false" max="1"/>
+                <rule avp="Event-Trigger" required="false"/>
+                <rule avp="Usage-Monitoring-Information" required="false"/>
+            </request>
+            <answer>
+                <!-- 3GPP 29.212 Section 5.6.3 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Result-Code" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="CC-Request-Type" required="true" max="1"/>
+                <rule avp="CC-Request-Number" required="true" max="1"/>
+                <rule avp="Auth-Application-Id" required="false" max="1"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false" max="1"/>
+                <rule avp="Route-Record" required="false" max="1"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Charging-Rule-Install" required="false"/>
+                <rule avp="Charging-Rule-Remove" required="false"/>
+                <rule avp="Usage-Monitoring-Information" required="false"/>
+                <rule avp="Event-Trigger" required="false"/>
+                <rule avp="Revalidation-Time" required="false"/>
+                <rule avp="QoS-Information" required="false" max="1"/>
+            </answer>
+        </command>
+
+        <command code="258" short="RA" name="Re-Auth">
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="Auth-Application-Id" required="true" max="1"/>
+                <rule avp="Re-Auth-Request-Type" required="true" max="1"/>
+                <rule avp="Session-Release-Cause" required="false" max="1"/>
+                <rule avp="QoS-Information" required="false" max="1"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+                <rule avp="Event-Trigger" required="false"/>
+                <rule avp="Revalidation-Time" required="false"/>
+                <rule avp="Charging-Rule-Install" required="false"/>
+                <rule avp="Charging-Rule-Remove" required="false"/>
+                <rule avp="Usage-Monitoring-Information" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Result-Code" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Origin-State-Id" required="false" max="1"/>
+                <rule avp="Error-Message" required="false" max="1"/>
+                <rule avp="Error-Reporting-Host" required="false" max="1"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+            </answer>
+        </command>
+
+        <avp name="Flow-Description" code="507" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="IPFilterRule"/>
+        </avp>
+
+
+        <avp name="Charging-Rule-Install" code="1001" must="M,V" may="P" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.2 -->
+            <data type="Grouped">
+                <rule avp="Charging-Rule-Name" required="false"/>
+                <rule avp="Charging-Rule-Base-Name" required="false"/>
+                <rule avp="Charging-Rule-Definition" required="false"/>
+                <rule avp="Rule-Activation-Time" required="false"/>
+                <rule avp="Rule-Deactivation-Time" required="false"/>
+                <!-- *[ AVP ]-->
+            </data>
+        </avp>
+
+        <avp name="Charging-Rule-Remove" code="1002" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.3 -->
+            <data type="Grouped">
+                <rule avp="Charging-Rule-Name" required="false"/>
+                <rule avp="Charging-Rule-Base-Name" required="false"/>
+                <!-- *[ AVP ]-->
+            </data>
+        </avp>
+
+        <avp name="Charging-Rule-Definition" code="1003" must="M,V" may="P" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Grouped">
+                <rule avp="Charging-Rule-Name" required="true" max="1"/>
+                <rule avp="Rating-Group" required="false" max="1"/>
+                <rule avp="Service-Identifier" required="false" max="1"/>
+                <rule avp="Flow-Information" required="false"/>
+                <rule avp="Flow-Description" required="false"/>
+                <rule avp="Precedence" required="false" max="1"/>
+                <rule avp="Monitoring-Key" required="false" max="1"/>
+                <rule avp="Redirect-Information" required="false" max="1"/>
+                <rule avp="QoS-Information" required="false" max="1"/>
+                <rule avp="Online" required="false" max="1"/>
+                <rule avp="Offline" required="false" max="1"/>
+                <!-- *[ AVP ]-->
+            </data>
+        </avp>
+
+        <avp name="Charging-Rule-Base-Name" code="1004" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.6 -->
+            <data type="UTF8String"/>
+        </avp>
+
+        <avp name="Charging-Rule-Name" code="1005" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.6 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Event-Trigger" code="1006" must="M,V" map="P" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.7 -->
+            <data type="Enumerated">
+                <item code="0" name="SGSN_CHANGE"/>
+                <item code="1" name="QOS_CHANGE"/>
+                <item code="2" name="RAT_CHANGE"/>
+                <item code="3" name="TFT_CHANGE"/>
+                <item code="4" name="PLMN_CHANGE"/>
+                <item code="5" name="LOSS_OF_BEARER"/>
+                <item code="6" name="RECOVERY_OF_BEARER"/>
+                <item code="7" name="IP-CAN_CHANGE"/>
+                <item code="11" name="QOS_CHANGE_EXCEEDING_AUTHORIZATION"/>
+                <item code="12" name="RAI_CHANGE"/>
+                <item code="13" name="USER_LOCATION_CHANGE"/>
+                <item code="14" name="NO_EVENT_TRIGGERS"/>
+                <item code="15" name="OUT_OF_CREDIT"/>
+                <item code="16" name="REALLOCATION_OF_CREDIT"/>
+                <item code="17" name="REVALIDATION_TIMEOUT"/>
+                <item code="18" name="UE_IP_ADDRESS_ALLOCATE"/>
+                <item code="19" name="UE_IP_ADDRESS_RELEASE"/>
+                <item code="20" name="DEFAULT_EPS_BEARER_QOS_CHANGE"/>
+                <item code="21" name="AN_GW_CHANGE"/>
+                <item code="22" name="SUCCESSFUL_RESOURCE_ALLOCATION"/>
+                <item code="23" name="RESOURCE_MODIFICATION_REQUEST"/>
+                <item code="24" name="PGW_TRACE_CONTROL"/>
+                <item code="25" name="UE_TIME_ZONE_CHANGE"/>
+                <item code="26" name="TAI_CHANGE"/>
+                <item code="27" name="ECGI_CHANGE"/>
+                <item code="28" name="CHARGING_CORRELATION_EXCHANGE"/>
+                <item code="29" name="APN-AMBR_MODIFICATION_FAILURE"/>
+                <item code="30" name="USER_CSG_INFORMATION_CHANGE"/>
+                <item code="33" name="USAGE_REPORT"/>
+            </data>
+        </avp>
+
+        <avp name="Revalidation-Time" code="1042" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212  Section 5.3.41 -->
+            <data type="Time"/>
+        </avp>
+
+        <avp name="Session-Release-Cause" code="1045" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.33 -->
+            <data type="Enumerated">
+                <item code="0" name="UNSPECIFIED_REASON"/>
+                <item code="1" name="UE_SUBSCRIPTION_REASON"/>
+                <item code="2" name="INSUFFICIENT_SERVER_RESOURCES"/>
+                <item code="3" name="IP_CAN_SESSION_TERMINATION"/>
+                <item code="4" name="UE_IP_ADDRESS_RELEASE"/>
+            </data>
+        </avp>
+
+        <avp name="Precedence" code="1010" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="ToS-Traffic-Class" code="1014" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.15 -->
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="IP-CAN-Type" code="1027" must="M,V" map="P" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.27 -->
+            <data type="Enumerated">
+                <item code="0" name="3GPP-GPRS"/>
+                <item code="1" name="DOCSIS"/>
+                <item code="2" name="xDSL"/>
+                <item code="3" name="WiMAX"/>
+                <item code="4" name="3GPP2"/>
+                <item code="5" name="3GPP-EPS"/>
+                <item code="6" name="Non-3GPP-EPS"/>
+                <item code="7" name="FBA"/>
+                <item code="8" name="3GPP-5GS"/>
+                <item code="9" name="Non-3GPP-5GS"/>
+            </data>
+        </avp>
+
+        <avp name="Rule-Activation-Time" code="1043" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Time"/>
+        </avp>
+
+        <avp name="Rule-Deactivation-Time" code="1044" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Time"/>
+        </avp>
+
+        <avp name="Security-Parameter-Index" code="1056" must="V" must-not="M" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.51 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Flow-Label" code="1057" must="V" must-not="M" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.52 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Flow-Information" code="1058" must="V" must-not="M" may="P" may-encryp="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.53 -->
+            <data type="Grouped">
+                <rule avp="Flow-Description" required="false" max="1"/>
+                <rule avp="Packet-Filter-Identifier" required="false" max="1"/>
+                <rule avp="Packet-Filter-Usage" required="false" max="1"/>
+                <rule avp="ToS-Traffic-Class" required="false" max="1"/>
+                <rule avp="Security-Parameter-Index" required="false" max="1"/>
+                <rule avp="Flow-Label" required="false" max="1"/>
+                <rule avp="Flow-Direction" required="false" max="1"/>
+            </data>
+        </avp>
+
+        <avp name="Packet-Filter-Identifier" code="1060" must="V" must-not="M" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.55 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Monitoring-Key" code="1066" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Usage-Monitoring-Information" code="1067" must="V" may="P" must-not="M,V" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Grouped">
+                <rule avp="Monitoring-Key" required="false" max="1"/>
+                <rule avp="Granted-Service-Unit" required="false" max="2"/>
+                <rule avp="Used-Service-Unit" required="false" max="2"/>
+                <rule avp="Usage-Monitoring-Level" required="false" max="1"/>
+                <rule avp="Usage-Monitoring-Report" required="false" max="1"/>
+                <rule avp="Usage-Monitoring-Support" required="false" max="1"/>
+            </data>
+        </avp>
+
+        <avp name="Usage-Monitoring-Level" code="1068" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Enumerated">
+                <item code="0" name="SESSION_LEVEL"/>
+                <item code="1" name="PCC_RULE_LEVEL"/>
+            </data>
+        </avp>
+
+        <avp name="Usage-Monitoring-Report" code="1069" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Enumerated">
+                <item code="0" name="USAGE_MONITORING_REPORT"/>
+            </data>
+        </avp>
+
+        <avp name="Usage-Monitoring-Support" code="1070" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 -->
+            <data type="Enumerated">
+                <item code="0" name="USAGE_MONITORING_SUPPORT"/>
+            </data>
+        </avp>
+
+        <avp name="Packet-Filter-Usage" code="1072" must="V" must-not="M" map="P" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.66 -->
+            <data type="Enumerated">
+                <item code="1" name="SEND_TO_UE"/>
+            </data>
+        </avp>
+
+        <avp name="Flow-Direction" code="1080" must="V" must-not="M" map="P" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.65 -->
+            <data type="Enumerated">
+                <item code="0" name="UNSPECIFIED"/>
+                <item code="1" name="DOWNLINK"/>
+                <item code="2" name="UPLINK"/>
+                <item code="3" name="BIDIRECTIONAL"/>
+            </data>
+        </avp>
+
+        <avp name="Redirect-Information" code="1085" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.82 -->
+            <data type="Grouped">
+                <rule avp="Redirect-Support" required="true" max="1"/>
+                <rule avp="Redirect-Address-Type" required="false" max="1"/>
+                <rule avp="Redirect-Server-Address" required="false" max="1"/>
+                <!-- *[ AVP ]-->
+            </data>
+        </avp>
+
+        <avp name="Redirect-Support" code="1086" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.83 -->
+            <data type="Enumerated">
+                <item code="0" name="REDIRECTION_DISABLED"/>
+                <item code="1" name="REDIRECTION_ENABLED"/>
+            </data>
+        </avp>
+
+        <avp name="Network-Request-Support" code="1024" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.24 -->
+            <data type="Enumerated">
+                <item code="0" name="NETWORK_REQUEST_NOT_SUPPORTED"/>
+                <item code="1" name="NETWORK_REQUEST_SUPPORTED"/>
+            </data>
+        </avp>
+
+        <avp name="Offline" code="1008" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.9 -->
+            <data type="Enumerated">
+                <item code="0" name="DISABLE_OFFLINE"/>
+                <item code="1" name="ENABLE_OFFLINE"/>
+            </data>
+        </avp>
+
+        <avp name="Online" code="1009" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.10 -->
+            <data type="Enumerated">
+                <item code="0" name="DISABLE_ONLINE"/>
+                <item code="1" name="ENABLE_ONLINE"/>
+            </data>
+        </avp>
+
+        <avp name="Default-EPS-Bearer-QoS" code="1049" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.48 -->
+            <data type="Grouped">
+                <rule avp="QoS-Class-Identifier" required="false" max="1"/>
+                <rule avp="Allocation-Retention-Priority" required="false" max="1"/>
+                <!-- *[ AVP ]-->
+            </data>
+        </avp>
+
+        <avp name="AN-GW-Address" code="1050" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.49 -->
+            <data type="Address"/>
+        </avp>
+
+        <avp name="Bearer-Usage" code="1000" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.1 -->
+            <data type="Enumerated">
+                <item code="0" name="GENERAL"/>
+                <item code="1" name="IMS_SIGNALLING"/>
+            </data>
+        </avp>
+
+        <avp name="Access-Network-Charging-Identifier-Gx" code="1022" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 Section 5.3.22 -->
+            <data type="Grouped">
+                <rule avp="Access-Network-Charging-Identifier-Value" required="true" max="1"/>
+                <rule avp="Charging-Rule-Base-Name" required="false"/>
+                <rule avp="Charging-Rule-Name" required="false"/>
+                <!-- *[ AVP ]-->
+            </data>
+        </avp>
+
+        <avp name="TGPP-SGSN-Address" code="6" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.061 Table 9a -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="TGPP-GGSN-Address" code="7" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.061 Table 9a -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Access-Network-Charging-Address" code="501" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP TS 29.214 Section 5.3.2 -->
+            <data type="Address"/>
+        </avp>
+
+        <avp name="TGPP-MS-TimeZone" code="23" must="V" may="P" must-not="M" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.061 Table 9a -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="TFT-Filter" code="1012" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 5.3.13-->
+            <data type="IPFilterRule"/>
+        </avp>
+
+        <avp name="TFT-Packet-Filter-Information" code="1013" must="M,V" may="P" may-encrypt="y" vendor-id="10415">
+            <!-- 3GPP 29.212 5.3.14-->
+            <data type="Grouped">
+                <rule avp="Precedence" required="false" max="1"/>
+                <rule avp="TFT-Filter" required="false" max="1"/>
+                <rule avp="ToS-Traffic-Class" required="false" max="1"/>
+                <rule avp="Security-Parameter-Index" required="false" max="1"/>
+                <rule avp="Flow-Label" required="false" max="1"/>
+                <rule avp="Flow-Direction" required="false" max="1"/>
+                <!-- *[ AVP ]-->
+            </data>
+        </avp>
+
+    </application>
+</diameter>`
+
+var networkaccessserverXML = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+
+	<application id="1" type="auth" name="Network Access">
+		<!-- Diameter Network Access Server Application -->
+		<!-- http://tools.ietf.org/html/rfc7155 -->
+
+		<command code="265" short="AA" name="AA">
+			<request>
+				<!-- https://tools.ietf.org/html/rfc7155#section-3.1 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Auth-Application-Id" required="true" max="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="Destination-Realm" required="true" max="1"/>
+				<rule avp="Auth-Request-Type" required="true" max="1"/>
+				<rule avp="Destination-Host" required="false" max="1"/>
+				<rule avp="NAS-Identifier" required="false" max="1"/>
+				<rule avp="NAS-IP-Address" required="true" max="1"/>
+				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
+				<rule avp="NAS-Port" required="false" max="1"/>
+				<rule avp="NAS-Port-Id" required="false" max="1"/>
+				<rule avp="NAS-Port-Type" required="false" max="1"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="Port-Limit" required="false" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="User-Password" required="false" max="1"/>
+				<rule avp="Service-Type" required="false" max="1"/>
+				<rule avp="State" required="false" max="1"/>
+				<rule avp="Authorization-Lifetime" required="false" max="1"/>
+				<rule avp="Auth-Grace-Period" required="false" max="1"/>
+				<rule avp="Auth-Session-State" required="false" max="1"/>
+				<rule avp="Callback-Number" required="false" max="1"/>
+				<rule avp="Called-Station-Id" required="false" max="1"/>
+				<rule avp="Calling-Station-Id" required="false" max="1"/>
+				<rule avp="Originating-Line-Info" required="false" max="1"/>
+				<rule avp="Connect-Info" required="false" max="1"/>
+				<rule avp="CHAP-Auth" required="false" max="1"/>
+				<rule avp="CHAP-Challenge" required="false" max="1"/>
+				<rule avp="Framed-Compression" required="false"/>
+				<rule avp="Framed-Interface-Id" required="false" max="1"/>
+				<rule avp="Framed-IP-Address" required="false" max="1"/>
+				<rule avp="Framed-IPv6-Prefix" required="false"/>
+				<rule avp="Framed-IP-Netmask" required="false" max="1"/>
+				<rule avp="Framed-MTU" required="false" max="1"/>
+				<rule avp="Framed-Protocol" required="false" max="1"/>
+				<rule avp="ARAP-Password" required="false" max="1"/>
+				<rule avp="ARAP-Security" required="false" max="1"/>
+				<rule avp="ARAP-Security-Data" required="false"/>
+				<rule avp="Login-IP-Host" required="false"/>
+				<rule avp="Login-IPv6-Host" required="false"/>
+				<rule avp="Login-LAT-Group" required="false" max="1"/>
+				<rule avp="Login-LAT-Node" required="false" max="1"/>
+				<rule avp="Login-LAT-Port" required="false" max="1"/>
+				<rule avp="Login-LAT-Service" required="false" max="1"/>
+				<rule avp="Tunneling" required="false"/>
+				<rule avp="Proxy-Info" required="false"/>
+				<rule avp="Route-Record" required="false"/>
+			</request>
+			<answer>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.2 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Auth-Application-Id" required="true" max="1"/>
+				<rule avp="Auth-Request-Type" required="true" max="1"/>
+				<rule avp="Result-Code" required="true" max="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Service-Type" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Configuration-Token" required="false"/>
+				<rule avp="Acct-Interim-Interval" required="false" max="1"/>
+				<rule avp="Error-Message" required="false" max="1"/>
+				<rule avp="Error-Reporting-Host" required="false" max="1"/>
+				<rule avp="Failed-AVP" required="false"/>
+				<rule avp="Idle-Timeout" required="false" max="1"/>
+				<rule avp="Authorization-Lifetime" required="false" max="1"/>
+				<rule avp="Auth-Grace-Period" required="false" max="1"/>
+				<rule avp="Auth-Session-State" required="false" max="1"/>
+				<rule avp="Re-Auth-Request-Type" required="false" max="1"/>
+				<rule avp="Multi-Round-Time-Out" required="false" max="1"/>
+				<rule avp="Session-Timeout" required="false" max="1"/>
+				<rule avp="State" required="false" max="1"/>
+				<rule avp="Reply-Message" required="false"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="Filter-Id" required="false"/>
+				<rule avp="Password-Retry" required="false" max="1"/>
+				<rule avp="Port-Limit" required="false" max="1"/>
+				<rule avp="Prompt" required="false" max="1"/>
+				<rule avp="ARAP-Challenge-Response" required="false" max="1"/>
+				<rule avp="ARAP-Features" required="false" max="1"/>
+				<rule avp="ARAP-Security" required="false" max="1"/>
+				<rule avp="ARAP-Security-Data" required="false"/>
+				<rule avp="ARAP-Zone-Access" required="false" max="1"/>
+				<rule avp="Callback-Id" required="false" max="1"/>
+				<rule avp="Callback-Number" required="false" max="1"/>
+				<rule avp="Framed-Appletalk-Link" required="false" max="1"/>
+				<rule avp="Framed-Appletalk-Network" required="false"/>
+				<rule avp="Framed-Appletalk-Zone" required="false" max="1"/>
+				<rule avp="Framed-Compression" required="false"/>
+				<rule avp="Framed-Interface-Id" required="false" max="1"/>
+				<rule avp="Framed-IP-Address" required="false" max="1"/>
+				<rule avp="Framed-IPv6-Prefix" required="false"/>
+				<rule avp="Framed-IPv6-Pool" required="false" max="1"/>
+				<rule avp="Framed-IPv6-Route" required="false"/>
+				<rule avp="Framed-IP-Netmask" required="false" max="1"/>
+				<rule avp="Framed-Route" required="false"/>
+				<rule avp="Framed-Pool" required="false" max="1"/>
+				<rule avp="Framed-IPX-Network" required="false" max="1"/>
+				<rule avp="Framed-MTU" required="false" max="1"/>
+				<rule avp="Framed-Protocol" required="false" max="1"/>
+				<rule avp="Framed-Routing" required="false" max="1"/>
+				<rule avp="Login-IP-Host" required="false"/>
+				<rule avp="Login-IPv6-Host" required="false"/>
+				<rule avp="Login-LAT-Group" required="false" max="1"/>
+				<rule avp="Login-LAT-Node" required="false" max="1"/>
+				<rule avp="Login-LAT-Port" required="false" max="1"/>
+				<rule avp="Login-LAT-Service" required="false" max="1"/>
+				<rule avp="Login-Service" required="false" max="1"/>
+				<rule avp="Login-TCP-Port" required="false" max="1"/>
+				<rule avp="NAS-Filter-Rule" required="false"/>
+				<rule avp="QoS-Filter-Rule" required="false"/>
+				<rule avp="Tunneling" required="false"/>
+				<rule avp="Redirect-Host" required="false"/>
+				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
+				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
+				<rule avp="Proxy-Info" required="false"/>
+			</answer>
+		</command>
+
+		<command code="258" short="RA" name="Re-Auth">
+			<request>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.3 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="Destination-Realm" required="true" max="1"/>
+				<rule avp="Destination-Host" required="true" max="1"/>
+				<rule avp="Auth-Application-Id" required="true" max="1"/>
+				<rule avp="Re-Auth-Request-Type" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="NAS-Identifier" required="false" max="1"/>
+				<rule avp="NAS-IP-Address" required="true" max="1"/>
+				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
+				<rule avp="NAS-Port" required="false" max="1"/>
+				<rule avp="NAS-Port-Id" required="false" max="1"/>
+				<rule avp="NAS-Port-Type" required="false" max="1"/>
+				<rule avp="Service-Type" required="false" max="1"/>
+				<rule avp="Framed-IP-Address" required="false" max="1"/>
+				<rule avp="Framed-IPv6-Prefix" required="false" max="1"/>
+				<rule avp="Framed-Interface-Id" required="false" max="1"/>
+				<rule avp="Called-Station-Id" required="false" max="1"/>
+				<rule avp="Calling-Station-Id" required="false" max="1"/>
+				<rule avp="Originating-Line-Info" required="false" max="1"/>
+				<rule avp="Acct-Session-Id" required="false" max="1"/>
+				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
+				<rule avp="State" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Reply-Message" required="false" max="1"/>
+				<rule avp="Proxy-Info" required="false"/>
+				<rule avp="Route-Record" required="false"/>
+			</request>
+			<answer>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.4 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Result-Code" required="true" max="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="Error-Message" required="false" max="1"/>
+				<rule avp="Error-Reporting-Host" required="false" max="1"/>
+				<rule avp="Failed-AVP" required="false"/>
+				<rule avp="Redirect-Host" required="false"/>
+				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
+				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
+				<rule avp="Service-Type" required="false" max="1"/>
+				<rule avp="Configuration-Token" required="false"/>
+				<rule avp="Idle-Timeout" required="false" max="1"/>
+				<rule avp="Authorization-Lifetime" required="false" max="1"/>
+				<rule avp="Auth-Grace-Period" required="false" max="1"/>
+				<rule avp="Re-Auth-Request-Type" required="false" max="1"/>
+				<rule avp="State" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Reply-Message" required="false"/>
+				<rule avp="Prompt" required="false" max="1"/>
+				<rule avp="Proxy-Info" required="false"/>
+			</answer>
+		</command>
+
+		<command code="275" short="ST" name="Session-Termination">
+			<request>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.5 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="Destination-Realm" required="true" max="1"/>
+				<rule avp="Auth-Application-Id" required="true" max="1"/>
+				<rule avp="Termination-Cause" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Destination-Host" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="Proxy-Info" required="false"/>
+				<rule avp="Route-Record" required="false"/>
+			</request>
+			<answer>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.6 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Result-Code" required="true" max="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Error-Message" required="false" max="1"/>
+				<rule avp="Error-Reporting-Host" required="false" max="1"/>
+				<rule avp="Failed-AVP" required="false"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="Redirect-Host" required="false"/>
+				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
+				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
+				<rule avp="Proxy-Info" required="false"/>
+			</answer>
+		</command>
+
+		<command code="274" short="AS" name="Abort-Session">
+			<request>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.7 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="Destination-Realm" required="true" max="1"/>
+				<rule avp="Destination-Host" required="true" max="1"/>
+				<rule avp="Auth-Application-Id" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="NAS-Identifier" required="false" max="1"/>
+				<rule avp="NAS-IP-Address" required="true" max="1"/>
+				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
+				<rule avp="NAS-Port" required="false" max="1"/>
+				<rule avp="NAS-Port-Id" required="false" max="1"/>
+				<rule avp="NAS-Port-Type" required="false" max="1"/>
+				<rule avp="Service-Type" required="false" max="1"/>
+				<rule avp="Framed-IP-Address" required="false" max="1"/>
+				<rule avp="Framed-IPv6-Prefix" required="false" max="1"/>
+				<rule avp="Framed-Interface-Id" required="false" max="1"/>
+				<rule avp="Called-Station-Id" required="false" max="1"/>
+				<rule avp="Calling-Station-Id" required="false" max="1"/>
+				<rule avp="Originating-Line-Info" required="false" max="1"/>
+				<rule avp="Acct-Session-Id" required="false" max="1"/>
+				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
+				<rule avp="State" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Reply-Message" required="false"/>
+				<rule avp="Proxy-Info" required="false"/>
+				<rule avp="Route-Record" required="false"/>
+			</request>
+			<answer>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.8 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Result-Code" required="true" max="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="State" required="false" max="1"/>
+				<rule avp="Error-Message" required="false" max="1"/>
+				<rule avp="Error-Reporting-Host" required="false" max="1"/>
+				<rule avp="Failed-AVP" required="false"/>
+				<rule avp="Redirect-Host" required="false"/>
+				<rule avp="Redirect-Host-Usage" required="false" max="1"/>
+				<rule avp="Redirect-Max-Cache-Time" required="false" max="1"/>
+				<rule avp="Proxy-Info" required="false"/>
+			</answer>
+		</command>
+
+		<command code="271" short="AC" name="Accounting">
+			<request>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.9 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="Destination-Realm" required="true" max="1"/>
+				<rule avp="Accounting-Record-Type" required="true" max="1"/>
+				<rule avp="Accounting-Record-Number" required="true" max="1"/>
+				<rule avp="Acct-Application-Id" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Accounting-Sub-Session-Id" required="false" max="1"/>
+				<rule avp="Acct-Session-Id" required="false" max="1"/>
+				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="Destination-Host" required="false" max="1"/>
+				<rule avp="Event-Timestamp" required="false" max="1"/>
+				<rule avp="Acct-Delay-Time" required="false" max="1"/>
+				<rule avp="NAS-Identifier" required="false" max="1"/>
+				<rule avp="NAS-IP-Address" required="true" max="1"/>
+				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
+				<rule avp="NAS-Port" required="false" max="1"/>
+				<rule avp="NAS-Port-Id" required="false" max="1"/>
+				<rule avp="NAS-Port-Type" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Service-Type" required="false" max="1"/>
+				<rule avp="Termination-Cause" required="false" max="1"/>
+				<rule avp="Accounting-Input-Octets" required="false" max="1"/>
+				<rule avp="Accounting-Input-Packets" required="false" max="1"/>
+				<rule avp="Accounting-Output-Octets" required="false" max="1"/>
+				<rule avp="Accounting-Output-Packets" required="false" max="1"/>
+				<rule avp="Acct-Authentic" required="false" max="1"/>
+				<rule avp="Accounting-Auth-Method" required="false" max="1"/>
+				<rule avp="Acct-Link-Count" required="false" max="1"/>
+				<rule avp="Acct-Session-Time" required="false" max="1"/>
+				<rule avp="Acct-Tunnel-Connection" required="false" max="1"/>
+				<rule avp="Acct-Tunnel-Packets-Lost" required="false" max="1"/>
+				<rule avp="Callback-Id" required="false" max="1"/>
+				<rule avp="Callback-Number" required="false" max="1"/>
+				<rule avp="Called-Station-Id" required="false" max="1"/>
+				<rule avp="Calling-Station-Id" required="false" max="1"/>
+				<rule avp="Connection-Info" required="false"/>
+				<rule avp="Originating-Line-Info" required="false" max="1"/>
+				<rule avp="Authorization-Lifetime" required="false" max="1"/>
+				<rule avp="Session-Timeout" required="false" max="1"/>
+				<rule avp="Idle-Timeout" required="false" max="1"/>
+				<rule avp="Port-Limit" required="false" max="1"/>
+				<rule avp="Accounting-Realtime-Required" required="false" max="1"/>
+				<rule avp="Acct-Interim-Interval" required="false" max="1"/>
+				<rule avp="Filter-Id" required="false"/>
+				<rule avp="NAS-Filter-Rule" required="false"/>
+				<rule avp="QoS-Filter-Rule" required="false"/>
+				<rule avp="Framed-Appletalk-Link" required="false" max="1"/>
+				<rule avp="Framed-Appletalk-Network" required="false" max="1"/>
+				<rule avp="Framed-Appletalk-Zone" required="false" max="1"/>
+				<rule avp="Framed-Compression" required="false" max="1"/>
+				<rule avp="Framed-Interface-Id" required="false" max="1"/>
+				<rule avp="Framed-IP-Address" required="false" max="1"/>
+				<rule avp="Framed-IP-Netmask" required="false" max="1"/>
+				<rule avp="Framed-IPv6-Prefix" required="false"/>
+				<rule avp="Framed-IPv6-Pool" required="false" max="1"/>
+				<rule avp="Framed-IPv6-Route" required="false"/>
+				<rule avp="Framed-IPX-Network" required="false" max="1"/>
+				<rule avp="Framed-MTU" required="false" max="1"/>
+				<rule avp="Framed-Pool" required="false" max="1"/>
+				<rule avp="Framed-Protocol" required="false" max="1"/>
+				<rule avp="Framed-Route" required="false"/>
+				<rule avp="Framed-Routing" required="false" max="1"/>
+				<rule avp="Login-IP-Host" required="false"/>
+				<rule avp="Login-IPv6-Host" required="false"/>
+				<rule avp="Login-LAT-Group" required="false" max="1"/>
+				<rule avp="Login-LAT-Node" required="false" max="1"/>
+				<rule avp="Login-LAT-Port" required="false" max="1"/>
+				<rule avp="Login-LAT-Service" required="false" max="1"/>
+				<rule avp="Login-Service" required="false" max="1"/>
+				<rule avp="Login-TCP-Port" required="false" max="1"/>
+				<rule avp="Tunneling" required="false"/>
+				<rule avp="Proxy-Info" required="false"/>
+				<rule avp="Route-Record" required="false"/>
+			</request>
+			<answer>
+				<!-- http://tools.ietf.org/html/rfc7155#section-3.10 -->
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Result-Code" required="true" max="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="Accounting-Record-Type" required="true" max="1"/>
+				<rule avp="Accounting-Record-Number" required="true" max="1"/>
+				<rule avp="Acct-Application-Id" required="true" max="1"/>
+				<rule avp="User-Name" required="false" max="1"/>
+				<rule avp="Accounting-Sub-Session-Id" required="false" max="1"/>
+				<rule avp="Acct-Session-Id" required="false" max="1"/>
+				<rule avp="Acct-Multi-Session-Id" required="false" max="1"/>
+				<rule avp="Event-Timestamp" required="false" max="1"/>
+				<rule avp="Error-Message" required="false" max="1"/>
+				<rule avp="Error-Reporting-Host" required="false" max="1"/>
+				<rule avp="Failed-AVP" required="false"/>
+				<rule avp="Origin-AAA-Protocol" required="false" max="1"/>
+				<rule avp="Origin-State-Id" required="false" max="1"/>
+				<rule avp="NAS-Identifier" required="false" max="1"/>
+				<rule avp="NAS-IP-Address" required="true" max="1"/>
+				<rule avp="NAS-IPv6-Address" required="false" max="1"/>
+				<rule avp="NAS-Port" required="false" max="1"/>
+				<rule avp="NAS-Port-Id" required="false" max="1"/>
+				<rule avp="NAS-Port-Type" required="false" max="1"/>
+				<rule avp="Service-Type" required="false" max="1"/>
+				<rule avp="Termination-Cause" required="false" max="1"/>
+				<rule avp="Accounting-Realtime-Required" required="false" max="1"/>
+				<rule avp="Acct-Interim-Interval" required="false" max="1"/>
+				<rule avp="Class" required="false"/>
+				<rule avp="Proxy-Info" required="false"/>
+			</answer>
+		</command>
+
+
+
+		<avp name="NAS-Identifier" code="32" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc2865#section-5.32 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="NAS-IP-Address" code="4" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc2865#section-5.4 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="NAS-IPv6-Address" code="95" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc3162#section-2.1 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="State" code="24" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc2865#section-5.24 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="NAS-Port" code="5" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.2 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="NAS-Port-Id" code="87" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.3 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="NAS-Port-Type" code="61" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.4 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-13 -->
+				<item code="0" name="Async"/>
+				<item code="1" name="Sync"/>
+				<item code="2" name="ISDN Sync"/>
+				<item code="3" name="ISDN Async V.120"/>
+				<item code="4" name="ISDN Async V.110"/>
+				<item code="5" name="Virtual"/>
+				<item code="6" name="PIAFS"/>
+				<item code="7" name="HDLC Clear Channel"/>
+				<item code="8" name="X.25"/>
+				<item code="9" name="X.75"/>
+				<item code="10" name="G.3 Fax"/>
+				<item code="11" name="SDSL - Symmetric DSL"/>
+				<item code="12" name="ADSL-CAP - Asymmetric DSL, Carrierless Amplitude Phase Modulation"/>
+				<item code="13" name="ADSL-DMT - Asymmetric DSL, Discrete Multi-Tone"/>
+				<item code="14" name="IDSL - ISDN Digital Subscriber Line"/>
+				<item code="15" name="Ethernet"/>
+				<item code="16" name="xDSL - Digital Subscriber Line of unknown type"/>
+				<item code="17" name="Cable"/>
+				<item code="18" name="Wireless - Other"/>
+				<item code="19" name="Wireless - IEEE 802.11"/>
+				<item code="20" name="Token-Ring"/>
+				<item code="21" name="FDDI"/>
+				<item code="22" name="Wireless - CDMA2000"/>
+				<item code="23" name="Wireless - UMTS"/>
+				<item code="24" name="Wireless - 1X-EV"/>
+				<item code="25" name="IAPP"/>
+				<item code="26" name="FTTP - Fiber to the Premises"/>
+				<item code="27" name="Wireless - IEEE 802.16"/>
+				<item code="28" name="Wireless - IEEE 802.20"/>
+				<item code="29" name="Wireless - IEEE 802.22"/>
+				<item code="30" name="PPPoA - PPP over ATM"/>
+				<item code="31" name="PPPoEoA - PPP over Ethernet over ATM"/>
+				<item code="32" name="PPPoEoE - PPP over Ethernet over Ethernet"/>
+				<item code="33" name="PPPoEoVLAN - PPP over Ethernet over VLAN"/>
+				<item code="34" name="PPPoEoQinQ - PPP over Ethernet over IEEE 802.1QinQ"/>
+				<item code="35" name="xPON - Passive Optical Network"/>
+				<item code="36" name="Wireless - XGP"/>
+				<item code="37" name="WiMAX Pre-Release 8 IWK Function"/>
+				<item code="38" name="WIMAX-WIFI-IWK: WiMAX WIFI Interworking"/>
+				<item code="39" name="WIMAX-SFF: Signaling Forwarding Function for LTE/3GPP2"/>
+				<item code="40" name="WIMAX-HA-LMA: WiMAX HA and or LMA function"/>
+				<item code="41" name="WIMAX-DHCP: WIMAX DCHP service"/>
+				<item code="42" name="WIMAX-LBS: WiMAX location based service"/>
+				<item code="43" name="WIMAX-WVS: WiMAX voice service"/>
+			</data>
+		</avp>
+
+		<avp name="Called-Station-Id" code="30" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.5 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Calling-Station-Id" code="31" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.6 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Connect-Info" code="77" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.7 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Originating-Line-Info" code="94" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.8 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Reply-Message" code="18" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.2.9 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="User-Password" code="2" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.1 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Password-Retry" code="75" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.2 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Prompt" code="76" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.3 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-17 -->
+				<item code="0" name="No Echo"/>
+				<item code="1" name="Echo"/>
+			</data>
+		</avp>
+
+		<avp name="CHAP-Auth" code="402" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.4 -->
+			<data type="Grouped">
+				<rule avp="CHAP-Algorithm" required="true" max="1"/>
+				<rule avp="CHAP-Ident" required="true" max="1"/>
+				<rule avp="CHAP-Response" required="true" max="1"/>
+			</data>
+		</avp>
+
+
+		<avp name="CHAP-Algorithm" code="403" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.5 -->
+			<data type="Enumerated">
+				<item code="5" name="CHAP with MD5"/>
+			</data>
+		</avp>
+
+		<avp name="CHAP-Ident" code="404" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.6 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="CHAP-Response" code="405" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.7 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="CHAP-Challenge" code="60" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.8 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="ARAP-Password" code="70" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.9 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="ARAP-Challenge-Response" code="84" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.10 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="ARAP-Security" code="73" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.11 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="ARAP-Security-Data" code="74" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.3.12 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Service-Type" code="6" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.1 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-4 -->
+				<item code="1" name="Login"/>
+				<item code="2" name="Framed"/>
+				<item code="3" name="Callback Login"/>
+				<item code="4" name="Callback Framed"/>
+				<item code="5" name="Outbound"/>
+				<item code="6" name="Administrative"/>
+				<item code="7" name="NAS Prompt"/>
+				<item code="8" name="Authenticate Only"/>
+				<item code="9" name="Callback NAS Prompt"/>
+				<item code="10" name="Call Check"/>
+				<item code="11" name="Callback Administrative"/>
+				<item code="12" name="Voice"/>
+				<item code="13" name="Fax"/>
+				<item code="14" name="Modem Relay"/>
+				<item code="15" name="IAPP-Register"/>
+				<item code="16" name="IAPP-AP-Check"/>
+				<item code="17" name="Authorize Only"/>
+				<item code="18" name="Framed-Management"/>
+				<item code="19" name="Additional-Authorization"/>
+			</data>
+		</avp>
+
+		<avp name="Callback-Number" code="19" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.2 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Callback-Id" code="20" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.3 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Idle-Timeout" code="28" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.4 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Port-Limit" code="62" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.5 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="NAS-Filter-Rule" code="400" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.6 -->
+			<data type="IPFilterRule"/>
+		</avp>
+
+		<avp name="Filter-Id" code="11" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.7 -->
+			<data type="UTF8String"/>
+		</avp>
 
 		<avp name="Configuration-Token" code="78" must="M" may="" must-not="V" may-encrypt="Y">
 			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.8 -->
 			<data type="OctetString"/>
 		</avp>
 
-		<!--avp name="QoS-Filter-Rule" code="407" must="-" may="" must-not="-" may-encrypt="Y"-->
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.9 -->
-			<!--data type="QoSFilterRule"/-->
-		<!--/avp-->
+		<!--avp name="QoS-Filter-Rule" code="407" must="-" may="" must-not="-" may-encrypt="Y"-->
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.9 -->
+			<!--data type="QoSFilterRule"/-->
+		<!--/avp-->
+
+
+		<avp name="Framed-Protocol" code="7" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.1 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-5 -->
+				<item code="1" name="PPP"/>
+				<item code="2" name="SLIP"/>
+				<item code="3" name="AppleTalk Remote Access Protocol (ARAP)"/>
+				<item code="4" name="Gandalf proprietary SingleLink/MultiLink protocol	"/>
+				<item code="5" name="Xylogics proprietary IPX/SLIP"/>
+				<item code="6" name="X.75 Synchronous"/>
+				<item code="7" name="GPRS PDP Context"/>
+			</data>
+		</avp>
+
+		<avp name="Framed-Routing" code="10" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.2 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-6 -->
+				<item code="0" name="None"/>
+				<item code="1" name="Send routing packets"/>
+				<item code="2" name="Listen for routing packets"/>
+				<item code="3" name="Send and Listen"/>
+			</data>
+		</avp>
+
+		<avp name="Framed-MTU" code="12" must="M" may="" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.3 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Framed-Compression" code="13" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.4 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-7 -->
+				<item code="0" name="None"/>
+				<item code="1" name="VJ TCP/IP header compression	"/>
+				<item code="2" name="IPX header compression"/>
+				<item code="3" name="Stac-LZS compression"/>
+			</data>
+		</avp>
+
+		<avp name="Framed-IP-Address" code="8" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.1 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Framed-IP-Netmask" code="9" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.2 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Framed-Route" code="22" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.3 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Framed-Pool" code="88" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.4 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Framed-Interface-Id" code="96" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.5 -->
+			<data type="Unsigned64"/>
+		</avp>
+
+		<avp name="Framed-IPv6-Prefix" code="97" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.6 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Framed-IPv6-Route" code="99" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.7 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Framed-IPv6-Pool" code="100" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.8 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Framed-IPX-Network" code="23" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.6.1-->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Framed-Appletalk-Link" code="37" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.7.1-->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Framed-Appletalk-Network" code="38" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.7.2-->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Framed-Appletalk-Zone" code="39" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.7.3-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="ARAP-Features" code="71" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.8.1-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="ARAP-Zone-Access" code="72" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.8.2 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-16 -->
+				<item code="1" name="Only allow access to default zone"/>
+				<item code="2" name="Use zone filter inclusively"/>
+				<item code="3" name="Not used"/>
+				<item code="4" name="Use zone filter exclusively"/>
+			</data>
+		</avp>
+
+		<avp name="Login-IP-Host" code="14" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.1-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Login-IPv6-Host" code="98" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.2-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Login-Service" code="15" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.3 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-8 -->
+				<item code="0" name="Telnet"/>
+				<item code="1" name="Rlogin"/>
+				<item code="2" name="TCP Clear"/>
+				<item code="3" name="PortMaster (proprietary)"/>
+				<item code="4" name="LAT"/>
+				<item code="5" name="X25-PAD"/>
+				<item code="6" name="X25-T3POS"/>
+				<item code="7" name="Unassigned"/>
+				<item code="8" name="TCP Clear Quiet (suppresses any NAS-generated connect string)"/>
+			</data>
+		</avp>
+
+		<avp name="Login-TCP-Port" code="16" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.4.1-->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Login-LAT-Service" code="34" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.1-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Login-LAT-Node" code="35" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.2-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Login-LAT-Group" code="36" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.3-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Login-LAT-Port" code="63" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.4-->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Tunneling" code="401" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.1-->
+			<data type="Grouped">
+				<rule avp="Tunnel-Type" required="true" max="1"/>
+				<rule avp="Tunnel-Medium-Type" required="true" max="1"/>
+				<rule avp="Tunnel-Client-Endpoint" required="true" max="1"/>
+				<rule avp="Tunnel-Server-Endpoint" required="true" max="1"/>
+				<rule avp="Tunnel-Preference" required="false" max="1"/>
+				<rule avp="Tunnel-Client-Auth-Id" required="false" max="1"/>
+				<rule avp="Tunnel-Server-Auth-Id" required="false" max="1"/>
+				<rule avp="Tunnel-Assignment-Id" required="false" max="1"/>
+				<rule avp="Tunnel-Password" required="false" max="1"/>
+				<rule avp="Tunnel-Private-Group-Id" required="false" max="1"/>
+			</data>
+		</avp>
+
+		<avp name="Tunnel-Type" code="64" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.2 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-14 -->
+				<item code="1" name="Point-to-Point Tunneling Protocol (PPTP)"/>
+				<item code="2" name="Layer Two Forwarding (L2F)"/>
+				<item code="3" name="Layer Two Tunneling Protocol (L2TP)"/>
+				<item code="4" name="Ascend Tunnel Management Protocol (ATMP)"/>
+				<item code="5" name="Virtual Tunneling Protocol (VTP)"/>
+				<item code="6" name="IP Authentication Header in the Tunnel-mode (AH)"/>
+				<item code="7" name="IP-in-IP Encapsulation (IP-IP)"/>
+				<item code="8" name="Minimal IP-in-IP Encapsulation (MIN-IP-IP)"/>
+				<item code="9" name="IP Encapsulating Security Payload in the Tunnel-mode (ESP)"/>
+				<item code="10" name="Generic Route Encapsulation (GRE)"/>
+				<item code="11" name="Bay Dial Virtual Services (DVS)"/>
+				<item code="12" name="IP-in-IP Tunneling"/>
+				<item code="13" name="Virtual LANs (VLAN)"/>
+			</data>
+		</avp>
+
+		<avp name="Tunnel-Medium-Type" code="65" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.3 -->
+			<data type="Enumerated">
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-15 -->
+				<item code="1" name="IPv4 (IP version 4)"/>
+				<item code="2" name="IPv6 (IP version 6)"/>
+				<item code="3" name="NSAP"/>
+				<item code="4" name="HDLC (8-bit multidrop)"/>
+				<item code="5" name="BBN 1822"/>
+				<item code="6" name="802 (includes all 802 media plus Ethernet 'canonical format')"/>
+				<item code="7" name="E.163 (POTS)"/>
+				<item code="8" name="E.164 (SMDS, Frame Relay, ATM)"/>
+				<item code="9" name="F.69 (Telex)"/>
+				<item code="10" name="X.121 (X.25, Frame Relay)"/>
+				<item code="11" name="IPX"/>
+				<item code="12" name="Appletalk"/>
+				<item code="13" name="Decnet IV"/>
+				<item code="14" name="Banyan Vines"/>
+				<item code="15" name="E.164 with NSAP format subaddress"/>
+			</data>
+		</avp>
+
+		<avp name="Tunnel-Client-Endpoint" code="66" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.4 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Tunnel-Server-Endpoint" code="67" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.5 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Tunnel-Password" code="69" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.6 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Tunnel-Private-Group-Id" code="81" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.7 -->
+			<data type="OctetString"/>
+		</avp>
 
+		<avp name="Tunnel-Assignment-Id" code="82" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.8 -->
+			<data type="OctetString"/>
+		</avp>
 
-		<avp name="Framed-Protocol" code="7" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.1 -->
+		<avp name="Tunnel-Preference" code="83" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.9 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Tunnel-Client-Auth-Id" code="90" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.10 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Tunnel-Server-Auth-Id" code="91" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.11 -->
+			<data type="UTF8String"/>
+		</avp>
+
+		<avp name="Accounting-Input-Octets" code="363" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.1 -->
+			<data type="Unsigned64"/>
+		</avp>
+
+		<avp name="Accounting-Output-Octets" code="364" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.2 -->
+			<data type="Unsigned64"/>
+		</avp>
+
+		<avp name="Accounting-Input-Packets" code="365" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.3 -->
+			<data type="Unsigned64"/>
+		</avp>
+
+		<avp name="Accounting-Output-Packets" code="366" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.4 -->
+			<data type="Unsigned64"/>
+		</avp>
+
+		<avp name="Acct-Session-Time" code="46" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.5 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Acct-Authentic" code="45" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.6 -->
 			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-5 -->
-				<item code="1" name="PPP"/>
-				<item code="2" name="SLIP"/>
-				<item code="3" name="AppleTalk Remote Access Protocol (ARAP)"/>
-				<item code="4" name="Gandalf proprietary SingleLink/MultiLink protocol	"/>
-				<item code="5" name="Xylogics proprietary IPX/SLIP"/>
-				<item code="6" name="X.75 Synchronous"/>
-				<item code="7" name="GPRS PDP Context"/>
+				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-11 -->
+				<item code="1" name="RADIUS"/>
+				<item code="2" name="Local"/>
+				<item code="3" name="Remote"/>
+				<item code="4" name="Diameter"/>
 			</data>
 		</avp>
 
-		<avp name="Framed-Routing" code="10" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.2 -->
+		<avp name="Accounting-Auth-Method" code="406" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.7 -->
 			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-6 -->
-				<item code="0" name="None"/>
-				<item code="1" name="Send routing packets"/>
-				<item code="2" name="Listen for routing packets"/>
-				<item code="3" name="Send and Listen"/>
+				<!-- http://www.iana.org/assignments/aaa-parameters/aaa-parameters.xhtml#aaa-parameters-26 -->
+				<item code="1" name="PAP"/>
+				<item code="2" name="CHAP"/>
+				<item code="3" name="MS-CHAP-1"/>
+				<item code="4" name="MS-CHAP-2"/>
+				<item code="5" name="EAP"/>
+				<item code="7" name="None"/>
 			</data>
 		</avp>
 
-		<avp name="Framed-MTU" code="12" must="M" may="" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.3 -->
-			<data type="Unsigned32"/>
-		</avp>
+		<avp name="Acct-Delay-Time" code="41" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.8 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Acct-Link-Count" code="51" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.9 -->
+			<data type="Unsigned32"/>
+		</avp>
+
+		<avp name="Acct-Tunnel-Connection" code="68" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.10 -->
+			<data type="OctetString"/>
+		</avp>
+
+		<avp name="Acct-Tunnel-Packets-Lost" code="86" must="M" may="-" must-not="V" may-encrypt="Y">
+			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.11 -->
+			<data type="Unsigned32"/>
+		</avp>
+		
+	</application>
+</diameter>`
+
+var tgppcxXML = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+    <!--
+        3GPP TS 29.228 and 29.229 (Cx/Dx interface)
+        Between the I-CSCF/S-CSCF and the HSS/SLF
+    -->
+    <application id="16777216" type="auth" name="TGPP CX">
+        <vendor id="10415" name="TGPP"/>
+
+        <command code="300" short="UA" name="User-Authorization">
+            <!-- 3GPP TS 29.229 Section 6.1.1 and 6.1.2 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="User-Name" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Public-Identity" required="true" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="true" max="1"/>
+                <rule avp="User-Authorization-Type" required="false" max="1"/>
+                <rule avp="UAR-Flags" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Server-Name" required="false" max="1"/>
+                <rule avp="Server-Capabilities" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <command code="301" short="SA" name="Server-Assignment">
+            <!-- 3GPP TS 29.229 Section 6.1.3 and 6.1.4 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Public-Identity" required="false"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Server-Name" required="true" max="1"/>
+                <rule avp="Server-Assignment-Type" required="true" max="1"/>
+                <rule avp="User-Data-Already-Available" required="true" max="1"/>
+                <rule avp="Multiple-Registration-Indication" required="false" max="1"/>
+                <rule avp="Session-Priority" required="false" max="1"/>
+                <rule avp="SAR-Flags" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="User-Data" required="false" max="1"/>
+                <rule avp="Charging-Information" required="false" max="1"/>
+                <rule avp="Associated-Identities" required="false" max="1"/>
+                <rule avp="Loose-Route-Indication" required="false" max="1"/>
+                <rule avp="Associated-Registered-Identities" required="false" max="1"/>
+                <rule avp="Server-Name" required="false" max="1"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Priviledged-Sender-Indication" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <command code="302" short="LI" name="Location-Info">
+            <!-- 3GPP TS 29.229 Section 6.1.5 and 6.1.6 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Originating-Request" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Public-Identity" required="true" max="1"/>
+                <rule avp="User-Authorization-Type" required="false" max="1"/>
+                <rule avp="Session-Priority" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Server-Name" required="false" max="1"/>
+                <rule avp="Server-Capabilities" required="false" max="1"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="LIA-Flags" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <command code="303" short="MA" name="Multimedia-Auth">
+            <!-- 3GPP TS 29.229 Section 6.1.7 and 6.1.8 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="User-Name" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Public-Identity" required="true" max="1"/>
+                <rule avp="SIP-Auth-Data-Item" required="true" max="1"/>
+                <rule avp="SIP-Number-Auth-Items" required="true" max="1"/>
+                <rule avp="Server-Name" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Public-Identity" required="false" max="1"/>
+                <rule avp="SIP-Number-Auth-Items" required="false" max="1"/>
+                <rule avp="SIP-Auth-Data-Item" required="false"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <command code="304" short="RT" name="Registration-Termination">
+            <!-- 3GPP TS 29.229 Section 6.1.9 and 6.1.10 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="User-Name" required="true" max="1"/>
+                <rule avp="Associated-Identities" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Public-Identity" required="false"/>
+                <rule avp="Deregistration-Reason" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Associated-Identities" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
 
-		<avp name="Framed-Compression" code="13" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.4 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-7 -->
-				<item code="0" name="None"/>
-				<item code="1" name="VJ TCP/IP header compression	"/>
-				<item code="2" name="IPX header compression"/>
-				<item code="3" name="Stac-LZS compression"/>
-			</data>
-		</avp>
+        <command code="305" short="PP" name="Push-Profile">
+            <!-- 3GPP TS 29.229 Section 6.1.11 and 6.1.12 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="User-Name" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="User-Data" required="false" max="1"/>
+                <rule avp="Charging-Information" required="false" max="1"/>
+                <rule avp="SIP-Auth-Data-Item" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
 
-		<avp name="Framed-IP-Address" code="8" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.1 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Visited-Network-Identifier" code="600" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.1 -->
+            <data type="OctetString"/>
+        </avp>
 
-		<avp name="Framed-IP-Netmask" code="9" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.2 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Public-Identity" code="601" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.2 -->
+            <data type="UTF8String"/>
+        </avp>
 
-		<avp name="Framed-Route" code="22" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.3 -->
-			<data type="UTF8String"/>
-		</avp>
+        <avp name="Server-Name" code="602" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.3 -->
+            <data type="UTF8String"/>
+        </avp>
 
-		<avp name="Framed-Pool" code="88" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.4 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Server-Capabilities" code="603" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.4 -->
+            <data type="Grouped">
+                <rule avp="Mandatory-Capability" required="false"/>
+                <rule avp="Optional-Capability" required="false"/>
+                <rule avp="Server-Name" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="Framed-Interface-Id" code="96" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.5 -->
-			<data type="Unsigned64"/>
-		</avp>
+        <avp name="Mandatory-Capability" code="604" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.5 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Framed-IPv6-Prefix" code="97" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.6 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Optional-Capability" code="605" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.6 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Framed-IPv6-Route" code="99" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.7 -->
-			<data type="UTF8String"/>
-		</avp>
+        <avp name="User-Data" code="606" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.7 -->
+            <data type="OctetString"/>
+        </avp>
 
-		<avp name="Framed-IPv6-Pool" code="100" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.5.8 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="SIP-Number-Auth-Items" code="607" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.8 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Framed-IPX-Network" code="23" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.6.1-->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="SIP-Authentication-Scheme" code="608" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.9 -->
+            <data type="UTF8String"/>
+        </avp>
 
-		<avp name="Framed-Appletalk-Link" code="37" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.7.1-->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="SIP-Authenticate" code="609" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.10 -->
+            <data type="OctetString"/>
+        </avp>
 
-		<avp name="Framed-Appletalk-Network" code="38" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.7.2-->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="SIP-Authorization" code="610" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.11 -->
+            <data type="OctetString"/>
+        </avp>
 
-		<avp name="Framed-Appletalk-Zone" code="39" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.7.3-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="SIP-Authentication-Context" code="611" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.12 -->
+            <data type="OctetString"/>
+        </avp>
 
-		<avp name="ARAP-Features" code="71" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.8.1-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="SIP-Auth-Data-Item" code="612" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.13 -->
+            <data type="Grouped">
+                <rule avp="SIP-Item-Number" required="false" max="1"/>
+                <rule avp="SIP-Authentication-Scheme" required="false" max="1"/>
+                <rule avp="SIP-Authenticate" required="false" max="1"/>
+                <rule avp="SIP-Authorization" required="false" max="1"/>
+                <rule avp="SIP-Authentication-Context" required="false" max="1"/>
+                <rule avp="Confidentiality-Key" required="false" max="1"/>
+                <rule avp="Integrity-Key" required="false" max="1"/>
+                <rule avp="SIP-Digest-Authenticate" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="ARAP-Zone-Access" code="72" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.10.8.2 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-16 -->
-				<item code="1" name="Only allow access to default zone"/>
-				<item code="2" name="Use zone filter inclusively"/>
-				<item code="3" name="Not used"/>
-				<item code="4" name="Use zone filter exclusively"/>
-			</data>
-		</avp>
+        <avp name="SIP-Item-Number" code="613" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.14 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Login-IP-Host" code="14" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.1-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Server-Assignment-Type" code="614" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.15 -->
+            <data type="Enumerated">
+                <item code="0" name="NO_ASSIGNMENT"/>
+                <item code="1" name="REGISTRATION"/>
+                <item code="2" name="RE_REGISTRATION"/>
+                <item code="3" name="UNREGISTERED_USER"/>
+                <item code="4" name="TIMEOUT_DEREGISTRATION"/>
+                <item code="5" name="USER_DEREGISTRATION"/>
+                <item code="6" name="TIMEOUT_DEREGISTRATION_STORE_SERVER_NAME"/>
+                <item code="7" name="USER_DEREGISTRATION_STORE_SERVER_NAME"/>
+                <item code="8" name="ADMINISTRATIVE_DEREGISTRATION"/>
+                <item code="9" name="AUTHENTICATION_FAILURE"/>
+                <item code="10" name="AUTHENTICATION_TIMEOUT"/>
+                <item code="11" name="DEREGISTRATION_TOO_MUCH_DATA"/>
+                <item code="12" name="AAA_USER_DATA_REQUEST"/>
+                <item code="13" name="PGW_UPDATE"/>
+                <item code="14" name="RESTORATION"/>
+            </data>
+        </avp>
 
-		<avp name="Login-IPv6-Host" code="98" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.2-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Deregistration-Reason" code="615" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.16 -->
+            <data type="Grouped">
+                <rule avp="Reason-Code" required="true" max="1"/>
+                <rule avp="Reason-Info" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="Login-Service" code="15" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.3 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-8 -->
-				<item code="0" name="Telnet"/>
-				<item code="1" name="Rlogin"/>
-				<item code="2" name="TCP Clear"/>
-				<item code="3" name="PortMaster (proprietary)"/>
-				<item code="4" name="LAT"/>
-				<item code="5" name="X25-PAD"/>
-				<item code="6" name="X25-T3POS"/>
-				<item code="7" name="Unassigned"/>
-				<item code="8" name="TCP Clear Quiet (suppresses any NAS-generated connect string)"/>
-			</data>
-		</avp>
+        <avp name="Reason-Code" code="616" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.17 -->
+            <data type="Enumerated">
+                <item code="0" name="PERMANENT_TERMINATION"/>
+                <item code="1" name="NEW_SERVER_ASSIGNMENT"/>
+                <item code="2" name="SERVER_CHANGE"/>
+                <item code="3" name="REMOVE_S_CSCF"/>
+            </data>
+        </avp>
 
-		<avp name="Login-TCP-Port" code="16" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.4.1-->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="Reason-Info" code="617" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.18 -->
+            <data type="UTF8String"/>
+        </avp>
 
-		<avp name="Login-LAT-Service" code="34" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.1-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Charging-Information" code="618" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.19 -->
+            <data type="Grouped">
+                <rule avp="Primary-Event-Charging-Function-Name" required="false" max="1"/>
+                <rule avp="Secondary-Event-Charging-Function-Name" required="false" max="1"/>
+                <rule avp="Primary-Charging-Collection-Function-Name" required="false" max="1"/>
+                <rule avp="Secondary-Charging-Collection-Function-Name" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="Login-LAT-Node" code="35" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.2-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Primary-Event-Charging-Function-Name" code="619" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.20 -->
+            <data type="DiameterURI"/>
+        </avp>
 
-		<avp name="Login-LAT-Group" code="36" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.3-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Secondary-Event-Charging-Function-Name" code="620" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.21 -->
+            <data type="DiameterURI"/>
+        </avp>
 
-		<avp name="Login-LAT-Port" code="63" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.4.11.5.4-->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Primary-Charging-Collection-Function-Name" code="621" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.22 -->
+            <data type="DiameterURI"/>
+        </avp>
 
-		<avp name="Tunneling" code="401" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.1-->
-			<data type="Grouped">
-				<rule avp="Tunnel-Type" required="true" max="1"/>
-				<rule avp="Tunnel-Medium-Type" required="true" max="1"/>
-				<rule avp="Tunnel-Client-Endpoint" required="true" max="1"/>
-				<rule avp="Tunnel-Server-Endpoint" required="true" max="1"/>
-				<rule avp="Tunnel-Preference" required="false" max="1"/>
-				<rule avp="Tunnel-Client-Auth-Id" required="false" max="1"/>
-				<rule avp="Tunnel-Server-Auth-Id" required="false" max="1"/>
-				<rule avp="Tunnel-Assignment-Id" required="false" max="1"/>
-				<rule avp="Tunnel-Password" required="false" max="1"/>
-				<rule avp="Tunnel-Private-Group-Id" required="false" max="1"/>
-			</data>
-		</avp>
+        <avp name="Secondary-Charging-Collection-Function-Name" code="622" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.23 -->
+            <data type="DiameterURI"/>
+        </avp>
 
-		<avp name="Tunnel-Type" code="64" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.2 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-14 -->
-				<item code="1" name="Point-to-Point Tunneling Protocol (PPTP)"/>
-				<item code="2" name="Layer Two Forwarding (L2F)"/>
-				<item code="3" name="Layer Two Tunneling Protocol (L2TP)"/>
-				<item code="4" name="Ascend Tunnel Management Protocol (ATMP)"/>
-				<item code="5" name="Virtual Tunneling Protocol (VTP)"/>
-				<item code="6" name="IP Authentication Header in the Tunnel-mode (AH)"/>
-				<item code="7" name="IP-in-IP Encapsulation (IP-IP)"/>
-				<item code="8" name="Minimal IP-in-IP Encapsulation (MIN-IP-IP)"/>
-				<item code="9" name="IP Encapsulating Security Payload in the Tunnel-mode (ESP)"/>
-				<item code="10" name="Generic Route Encapsulation (GRE)"/>
-				<item code="11" name="Bay Dial Virtual Services (DVS)"/>
-				<item code="12" name="IP-in-IP Tunneling"/>
-				<item code="13" name="Virtual LANs (VLAN)"/>
-			</data>
-		</avp>
+        <avp name="User-Authorization-Type" code="623" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.24 -->
+            <data type="Enumerated">
+                <item code="0" name="REGISTRATION"/>
+                <item code="1" name="DE_REGISTRATION"/>
+                <item code="2" name="REGISTRATION_AND_CAPABILITIES"/>
+            </data>
+        </avp>
 
-		<avp name="Tunnel-Medium-Type" code="65" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.3 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-15 -->
-				<item code="1" name="IPv4 (IP version 4)"/>
-				<item code="2" name="IPv6 (IP version 6)"/>
-				<item code="3" name="NSAP"/>
-				<item code="4" name="HDLC (8-bit multidrop)"/>
-				<item code="5" name="BBN 1822"/>
-				<item code="6" name="802 (includes all 802 media plus Ethernet 'canonical format')"/>
-				<item code="7" name="E.163 (POTS)"/>
-				<item code="8" name="E.164 (SMDS, Frame Relay, ATM)"/>
-				<item code="9" name="F.69 (Telex)"/>
-				<item code="10" name="X.121 (X.25, Frame Relay)"/>
-				<item code="11" name="IPX"/>
-				<item code="12" name="Appletalk"/>
-				<item code="13" name="Decnet IV"/>
-				<item code="14" name="Banyan Vines"/>
-				<item code="15" name="E.164 with NSAP format subaddress"/>
-			</data>
-		</avp>
+        <avp name="User-Data-Already-Available" code="624" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.26 -->
+            <data type="Enumerated">
+                <item code="0" name="USER_DATA_NOT_AVAILABLE"/>
+                <item code="1" name="USER_DATA_ALREADY_AVAILABLE"/>
+            </data>
+        </avp>
 
-		<avp name="Tunnel-Client-Endpoint" code="66" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.4 -->
-			<data type="UTF8String"/>
-		</avp>
+        <avp name="Confidentiality-Key" code="625" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.27 -->
+            <data type="OctetString"/>
+        </avp>
 
-		<avp name="Tunnel-Server-Endpoint" code="67" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.5 -->
-			<data type="UTF8String"/>
-		</avp>
+        <avp name="Integrity-Key" code="626" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.28 -->
+            <data type="OctetString"/>
+        </avp>
 
-		<avp name="Tunnel-Password" code="69" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.6 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Supported-Features" code="628" vendor-id="10415" must="V" may="M" may-encrypt="N">
+            <!-- 3GPP TS 29.229 Section 6.3.29 -->
+            <data type="Grouped">
+                <rule avp="Vendor-Id" required="true" max="1"/>
+                <rule avp="Feature-List-ID" required="true" max="1"/>
+                <rule avp="Feature-List" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="Tunnel-Private-Group-Id" code="81" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.7 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Feature-List-ID" code="629" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.30 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Tunnel-Assignment-Id" code="82" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.8 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Feature-List" code="630" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.31 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Tunnel-Preference" code="83" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.9 -->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="Associated-Identities" code="632" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.33 -->
+            <data type="Grouped">
+                <rule avp="User-Name" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="Tunnel-Client-Auth-Id" code="90" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.10 -->
-			<data type="UTF8String"/>
-		</avp>
+        <avp name="Originating-Request" code="633" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.34 -->
+            <data type="Enumerated">
+                <item code="0" name="ORIGINATING"/>
+            </data>
+        </avp>
 
-		<avp name="Tunnel-Server-Auth-Id" code="91" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.5.11 -->
-			<data type="UTF8String"/>
-		</avp>
+        <avp name="Wildcarded-Public-Identity" code="634" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.35 -->
+            <data type="UTF8String"/>
+        </avp>
 
-		<avp name="Accounting-Input-Octets" code="363" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.1 -->
-			<data type="Unsigned64"/>
-		</avp>
+        <avp name="SIP-Digest-Authenticate" code="635" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.36 -->
+            <data type="Grouped">
+                <rule avp="Digest-Realm" required="true" max="1"/>
+                <rule avp="Digest-Algorithm" required="false" max="1"/>
+                <rule avp="Digest-QoP" required="true" max="1"/>
+                <rule avp="Digest-HA1" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="Accounting-Output-Octets" code="364" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.2 -->
-			<data type="Unsigned64"/>
-		</avp>
+        <avp name="UAR-Flags" code="637" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.44 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Accounting-Input-Packets" code="365" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.3 -->
-			<data type="Unsigned64"/>
-		</avp>
+        <avp name="Loose-Route-Indication" code="638" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.45 -->
+            <data type="Enumerated">
+                <item code="0" name="LOOSE_ROUTE_NOT_REQUIRED"/>
+                <item code="1" name="LOOSE_ROUTE_REQUIRED"/>
+            </data>
+        </avp>
 
-		<avp name="Accounting-Output-Packets" code="366" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.4 -->
-			<data type="Unsigned64"/>
-		</avp>
+        <avp name="Associated-Registered-Identities" code="647" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.50 -->
+            <data type="Grouped">
+                <rule avp="User-Name" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
 
-		<avp name="Acct-Session-Time" code="46" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.5 -->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="Multiple-Registration-Indication" code="648" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.51 -->
+            <data type="Enumerated">
+                <item code="0" name="NOT_MULTIPLE_REGISTRATION"/>
+                <item code="1" name="MULTIPLE_REGISTRATION"/>
+            </data>
+        </avp>
 
-		<avp name="Acct-Authentic" code="45" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.6 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/radius-types/radius-types.xhtml#radius-types-11 -->
-				<item code="1" name="RADIUS"/>
-				<item code="2" name="Local"/>
-				<item code="3" name="Remote"/>
-				<item code="4" name="Diameter"/>
-			</data>
-		</avp>
+        <avp name="Session-Priority" code="650" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.56 -->
+            <data type="Enumerated">
+                <item code="0" name="PRIORITY-0"/>
+                <item code="1" name="PRIORITY-1"/>
+                <item code="2" name="PRIORITY-2"/>
+                <item code="3" name="PRIORITY-3"/>
+                <item code="4" name="PRIORITY-4"/>
+            </data>
+        </avp>
 
-		<avp name="Accounting-Auth-Method" code="406" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.7 -->
-			<data type="Enumerated">
-				<!-- http://www.iana.org/assignments/aaa-parameters/aaa-parameters.xhtml#aaa-parameters-26 -->
-				<item code="1" name="PAP"/>
-				<item code="2" name="CHAP"/>
-				<item code="3" name="MS-CHAP-1"/>
-				<item code="4" name="MS-CHAP-2"/>
-				<item code="5" name="EAP"/>
-				<item code="7" name="None"/>
-			</data>
-		</avp>
+        <avp name="Priviledged-Sender-Indication" code="652" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.58 -->
+            <data type="Enumerated">
+                <item code="0" name="NOT_PRIVILEDGED_SENDER"/>
+                <item code="1" name="PRIVILEDGED_SENDER"/>
+            </data>
+        </avp>
 
-		<avp name="Acct-Delay-Time" code="41" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.8 -->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="LIA-Flags" code="653" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.59 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Acct-Link-Count" code="51" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.9 -->
-			<data type="Unsigned32"/>
-		</avp>
+        <avp name="SAR-Flags" code="655" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.62 -->
+            <data type="Unsigned32"/>
+        </avp>
 
-		<avp name="Acct-Tunnel-Connection" code="68" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.10 -->
-			<data type="OctetString"/>
-		</avp>
+        <avp name="Digest-Realm" code="104" must="M" must-not="V" may-encrypt="N">
+            <!-- RFC 4740 Section 9.5 -->
+            <data type="UTF8String"/>
+        </avp>
 
-		<avp name="Acct-Tunnel-Packets-Lost" code="86" must="M" may="-" must-not="V" may-encrypt="Y">
-			<!-- http://tools.ietf.org/html/rfc7155#section-4.6.11 -->
-			<data type="Unsigned32"/>
-		</avp>
-		
-	</application>
+        <avp name="Digest-QoP" code="110" must="M" must-not="V" may-encrypt="N">
+            <!-- RFC 4740 Section 9.5 -->
+            <data type="UTF8String"/>
+        </avp>
+
+        <avp name="Digest-Algorithm" code="111" must="M" must-not="V" may-encrypt="N">
+            <!-- RFC 4740 Section 9.5 -->
+            <data type="UTF8String"/>
+        </avp>
+
+        <avp name="Digest-HA1" code="121" must="M" must-not="V" may-encrypt="N">
+            <!-- RFC 4740 Section 9.5 -->
+            <data type="UTF8String"/>
+        </avp>
+    </application>
 </diameter>`
 
 var tgpprorfXML = `<?xml version="1.0" encoding="UTF-8"?>
 <diameter>
-	<application id="4" type="auth" name="TGPP">
+	<application id="4" type="auth" parent="1" name="TGPP">
 		<vendor id="10415" name="TGPP"/>
 
 		<avp name="TGPP-Charging-Characteristics" code="13" must="V" may="P" must-not="M" may-encrypt="Y" vendor-id="10415">
@@ -2721,7 +4172,7 @@ var tgpprorfXML = `<?xml version="1.0" encoding="UTF-8"?>
 			<data type="Grouped">
 				<rule avp="Application-Server" required="false" max="1"/>
 				<rule avp="Application-Provided-Called-Party-Address" required="false"/>
-				<rule avp="Status- AS-Code" required="false" max="1"/>
+				<rule avp="Status-AS-Code" required="false" max="1"/>
 			</data>
 		</avp>
 
@@ -4981,7 +6432,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
         <vendor id="10415" name="TGPP"/>
         <command code="265" short="AA" name="AA">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Auth-Application-Id" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
@@ -4994,7 +6445,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="Media-Component-Description" required="false"/>
 				<rule avp="Service-Info-Status" required="false" max="1"/>
 				<rule avp="AF-Charging-Identifier" required="false" max="1"/>
-				<rule avp="SIP-Forking-Indication " required="false" max="1"/>
+				<rule avp="SIP-Forking-Indication" required="false" max="1"/>
 				<rule avp="Specific-Action" required="false"/>
 				<rule avp="Subscription-Id" required="false"/>
 				<rule avp="OC-Supported-Features" required="false" max="1"/>
@@ -5004,7 +6455,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="Framed-Ipv6-Prefix" required="false" max="1"/>
 				<rule avp="Called-Station-Id" required="false" max="1"/>
 				<rule avp="Service-URN" required="false" max="1"/>
-				<rule avp="Sponsored-Connectivity-Data " required="false" max="1"/>
+				<rule avp="Sponsored-Connectivity-Data" required="false" max="1"/>
 				<rule avp="MPS-Identifier" required="false" max="1"/>
 				<rule avp="GCS-Identifier" required="false" max="1"/>
 				<rule avp="MCPTT-Identifier" required="false" max="1"/>
@@ -5024,7 +6475,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="AVP" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Auth-Application-Id" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
@@ -5066,13 +6517,14 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 		</command>
 		<command code="258" short="RA" name="Re-Auth">
 			<request>
-				<rule avp="Session-Id " required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="Destination-Realm" required="true" max="1"/>
 				<rule avp="Destination-Host" required="true" max="1"/>
 				<rule avp="Auth-Application-Id" required="true" max="1"/>
+				<rule avp="Re-Auth-Request-Type" required="true" max="1"/>
 				<rule avp="Specific-Action" required="true"/>
 				<rule avp="OC-Supported-Features" required="false" max="1"/>
 				<rule avp="Access-Network-Charging-Identifier" required="false"/>
@@ -5106,7 +6558,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="AVP" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -5130,7 +6582,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 		</command>
 		<command code="275" short="ST" name="Session-Termination">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -5147,7 +6599,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="AVP" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -5183,7 +6635,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 		</command>
 		<command code="274" short="AS" name="Abort-Session">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -5198,7 +6650,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="AVP" required="false"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="DRMP" required="false" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
@@ -5340,7 +6792,7 @@ var tgpprxXML = `<?xml version="1.0" encoding="UTF-8"?>
 				<rule avp="Pre-emption-Capability" required="false" max="1"/>
 				<rule avp="Pre-emption-Vulnerability" required="false" max="1"/>
 				<rule avp="Reservation-Priority" required="false" max="1"/>
-				<rule avp="RS-Bandwidth " required="false" max="1"/>
+				<rule avp="RS-Bandwidth" required="false" max="1"/>
 				<rule avp="RR-Bandwidth" required="false" max="1"/>
 				<rule avp="Codec-Data" required="false"/>
 				<rule avp="Sharing-Key-DL" required="false" max="1"/>
@@ -6144,7 +7596,7 @@ var tgpps13XML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <request>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Auth-Session-State" required="true" max="1" />
                 <rule avp="Origin-Host" required="true" max="1" />
@@ -6173,7 +7625,7 @@ var tgpps13XML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <answer>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Result-Code" required="false" max="1" />
                 <rule avp="Experimental-Result" required="false" max="1" />
@@ -6225,11 +7677,11 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
         3GPP TS 29.272
         See: http://www.etsi.org/deliver/etsi_ts/129200_129299/129272/12.06.00_60/ts_129272v120600p.pdf
     -->
-    <application id="16777251" type="auth" name="TGPP S6A">
+    <application id="16777251" type="auth" parent="4" name="TGPP S6A">
         <vendor id="10415" name="TGPP"/>
         <command code="316" short="UL" name="Update-Location">
             <request>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Auth-Session-State" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
@@ -6252,7 +7704,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 <rule avp="Route-Record" required="false"/>
             </request>
             <answer>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Result-Code" required="false" max="1"/>
                 <rule avp="Experimental-Result" required="false" max="1"/>
@@ -6272,7 +7724,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
 
         <command code="317" short="CL" name="Cancel-Location">
             <request>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Auth-Session-State" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
@@ -6288,7 +7740,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 <rule avp="Route-Record" required="false"/>
             </request>
             <answer>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Supported-Features" required="false"/>
                 <rule avp="Result-Code" required="false" max="1"/>
@@ -6305,7 +7757,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
 
         <command code="319" short="ID" name="Insert-Subscriber-Data">
             <request>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Auth-Session-State" required="true" max="1"/>
@@ -6323,7 +7775,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 <rule avp="Route-Record" required="false"/>
             </request>
             <answer>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                  <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Supported-Features" required="false"/>
@@ -6332,9 +7784,9 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 <rule avp="Auth-Session-State" required="false" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
                 <rule avp="Origin-Realm" required="true" max="1"/>
-                <rule avp="IMS-Voice-Over-PS-Sessions" required="false" max="1"/>
-                <rule avp="Last-UE-Activity-time" required="false" max="1"/>
-                <rule avp="RAT-Type" required="true" max="1"/>
+                <rule avp="IMS-Voice-Over-PS-Sessions-Supported" required="false" max="1"/>
+                <rule avp="Last-UE-Activity-Time" required="false" max="1"/>
+                <rule avp="RAT-Type" required="false" max="1"/>
                 <rule avp="IDA-Flags" required="false" max="1"/>
                 <rule avp="EPS-User-State" required="false" max="1" />
                 <rule avp="EPS-Location-Information" required="false" max="1" />
@@ -6351,7 +7803,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
 
         <command code="318" short="AI" name="Authentication-Information">
             <request>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Auth-Session-State" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
@@ -6368,7 +7820,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 <rule avp="Route-Record" required="false"/>
             </request>
             <answer>
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1"/>
                 <rule avp="Result-Code" required="false" max="1"/>
                 <rule avp="Experimental-Result" required="false" max="1"/>
@@ -6406,7 +7858,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <request>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Auth-Session-State" required="true" max="1" />
@@ -6444,7 +7896,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <answer>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Supported-Features" required="false" />
@@ -6494,7 +7946,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <request>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Auth-Session-State" required="true" max="1" />
@@ -6540,7 +7992,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <answer>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Result-Code" required="false" max="1" />
@@ -6575,7 +8027,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <request>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Auth-Session-State" required="true" max="1" />
                 <rule avp="Origin-Host" required="true" max="1" />
@@ -6603,7 +8055,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <answer>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Supported-Features" required="false" />
                 <rule avp="Result-Code" required="false" max="1" />
@@ -6641,7 +8093,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <request>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Auth-Session-State" required="true" max="1" />
@@ -6678,7 +8130,7 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
                 *[ Route-Record ]
             -->
             <answer>
-                <rule avp="Session-Id" required="true" max="1" />
+                <rule avp="Session-Id" required="true" max="1" position="1" />
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="false" max="1" />
                 <rule avp="Supported-Features" required="false" />
@@ -7012,400 +8464,846 @@ var tgpps6aXML = `<?xml version="1.0" encoding="UTF-8"?>
             </data>
         </avp>avp>
 
-        <avp name="Trace-Reference" code="1459" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Trace-Reference" code="1459" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Trace-Depth" code="1462" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="LIPA-PROHIBITED"/>
+                <item code="1" name="LIPA-ONLY"/>
+                <item code="2" name="LIPA-CONDITIONAL"/>
+            </data>
+        </avp>
+
+        <avp name="Trace-NE-Type-List" code="1463" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Trace-Interface-List" code="1464" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Trace-Event-List" code="1465" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="OMC-Id" code="1466" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Trace-Event-List" code="1465" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Trace-Collection-Entity" code="1452" must="M,V" may="P" may-encrypt="N" vendor-id="10415">
+            <data type="Address"/>
+        </avp>
+
+        <avp name="MDT-Configuration" code="1622" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="QoS-Class-Identifier" required="true" max="1"/>
+                <rule avp="Allocation-Retention-Priority" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="GPRS-Subscription-Data" code="1467" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Complete-Data-List-Included-Indicator" required="true" max="1"/>
+                <rule avp="PDP-Context" required="true" max="50"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="Complete-Data-List-Included-Indicator" code="1468" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="All_PDP_CONTEXTS_INCLUDED"/>
+                <item code="1" name="MODIFIED/ADDED_PDP CONTEXTS_INCLUDED"/>
+            </data>
+        </avp>
+
+        <avp name="PDP-Context" code="1469" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Context-Identifier" required="true" max="1"/>
+                <rule avp="PDP-Type" required="true" max="1"/>
+                <rule avp="PDP-Address" required="false" max="1"/>
+                <rule avp="QoS-Subscribed" required="true" max="1"/>
+                <rule avp="VPLMN-Dynamic-Address-Allowed" required="false" max="1"/>
+                <rule avp="Service-Selection" required="true" max="1"/>
+                <rule avp="TGPP-Charging-Characteristics" required="false" max="1"/>
+                <rule avp="Ext-PDP-Type" required="false" max="1"/>
+                <rule avp="Ext-PDP-Address" required="false" max="1"/>
+                <rule avp="AMBR" required="false" max="1"/>
+                <rule avp="SIPTO-Permission" required="false" max="1"/>
+                <rule avp="LIPA-Permission" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="PDP-Type" code="1470" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="QoS-Subscribed" code="1404" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="VPLMN-Dynamic-Address-Allowed" code="1432" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="NOTALLOWED"/>
+                <item code="1" name="ALLOWED"/>
+            </data>
+        </avp>
+
+        <avp name="Ext-PDP-Type" code="1620" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Ext-PDP-Address" code="1621" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+            <data type="Address"/>
+        </avp>
+
+        <avp name="SIPTO-Permission" code="1613" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="SIPTO_ALLOWED"/>
+                <item code="1" name="SIPTO_NOTALLOWED"/>
+            </data>
+        </avp>
+
+        <avp name="CSG-Subscription-Data" code="1436" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="CSG-Id" required="true" max="1"/>
+                <rule avp="Expiration-Date" required="false" max="1"/>
+                <rule avp="Service-Selection" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="Expiration-Date" code="1439" must="V,M" may-encrypt="N" vendor-id="10415">
+            <data type="Time"/>
+        </avp>
+
+        <avp name="Roaming-Restricted-Due-To-Unsupported-Feature" code="1457" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="Roaming-Restricted-Due-To-Unsupported-Feature"/>
+            </data>
+        </avp>
+
+        <avp name="Subscribed-Periodic-RAU-TAU-Timer" code="1619" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="MPS-Priority" code="1616" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="VPLMN-LIPA-Allowed" code="1617" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="LIPA-NOTALLOWED"/>
+                <item code="1" name="LIPA-ALLOWED"/>
+            </data>
+        </avp>
+
+        <avp name="Relay-Node-Indicator" code="1633" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="NOT_RELAY_NODE"/>
+                <item code="1" name="RELAY_NODE"/>
+            </data>
+        </avp>
+
+        <avp name="MDT-User-Consent" code="1634" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="CONSENT_NOT_GIVEN"/>
+                <item code="1" name="CONSENT_GIVEN"/>
+            </data>
+        </avp>
+
+        <avp name="Requested-EUTRAN-Authentication-Info" code="1408" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Grouped">
+                <rule avp="Number-Of-Requested-Vectors" required="false" max="1"/>
+                <rule avp="Immediate-Response-Preferred" required="false" max="1"/>
+                <rule avp="Re-synchronization-Info" required="false" max="1"/>
+            </data>
+        </avp>
+
+        <avp name="Number-Of-Requested-Vectors" code="1410" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="Re-synchronization-Info" code="1411" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Immediate-Response-Preferred" code="1412" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="Requested-UTRAN-GERAN-Authentication-Info" code="1409" vendor-id="10415" must="M,V" may-encrypt="N">
+            <data type="Grouped">
+                <rule avp="Number-Of-Requested-Vectors" required="false" max="1"/>
+                <rule avp="Immediate-Response-Preferred" required="false" max="1"/>
+                <rule avp="Re-synchronization-Info" required="false" max="1"/>
+            </data>
+        </avp>
+
+        <avp name="Visited-PLMN-Id" code="1407" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Error-Diagnostic" code="1614" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="GPRS_DATA_SUBSCRIBED"/>
+                <item code="1" name="NO_GPRS_DATA_SUBSCRIBED"/>
+                <item code="2" name="ODB-ALL-APN"/>
+                <item code="3" name="ODB-HPLMN-APN"/>
+                <item code="4" name="ODB-VPLMN-APN"/>
+            </data>
+        </avp>
+
+        <avp name="Authentication-Info" code="1413" vendor-id="10415" must="M,V" may-encrypt="N">
+            <data type="Grouped">
+                <rule avp="E-UTRAN-Vector" required="false"/>
+                <rule avp="UTRAN-Vector" required="false"/>
+                <rule avp="GERAN-Vector" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="E-UTRAN-Vector" code="1414" vendor-id="10415" must="M,V" may-encrypt="N">
+            <data type="Grouped">
+                <rule avp="Item-Number" required="false" max="1"/>
+                <rule avp="RAND" required="true" max="1"/>
+                <rule avp="XRES" required="true" max="1"/>
+                <rule avp="AUTN" required="true" max="1"/>
+                <rule avp="KASME" required="true" max="1"/>
+            </data>
+        </avp>
+
+        <avp name="UTRAN-Vector" code="1415" vendor-id="10415" must="M,V" may-encrypt="N">
+            <data type="Grouped">
+                <rule avp="Item-Number" required="false"/>
+                <rule avp="RAND" required="true"/>
+                <rule avp="XRES" required="true"/>
+                <rule avp="AUTN" required="true"/>
+                <rule avp="Confidentiality-Key" required="true"/>
+                <rule avp="Integrity-Key" required="true"/>
+            </data>
+        </avp>
+
+        <avp name="GERAN-Vector" code="1416" vendor-id="10415" must="M,V" may-encrypt="N">
+            <data type="Grouped">
+                <rule avp="Item-Number" required="false" max="1"/>
+                <rule avp="RAND" required="true" max="1"/>
+                <rule avp="SRES" required="true" max="1"/>
+                <rule avp="Kc" required="true" max="1"/>
+            </data>
+        </avp>
+
+        <avp name="Item-Number" code="1419" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="Cancellation-Type" code="1420" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="MME_UPDATE_PROCEDURE"/>
+                <item code="1" name="SGSN_UDPATE_PROCEDURE"/>
+                <item code="2" name="SUBSCRIPTION_WITHDRAWAL"/>
+                <item code="3" name="UPDATE_PROCEDURE_IWF"/>
+                <item code="4" name="INITIAL_ATTACH_PROCEDURE"/>
+            </data>
+        </avp>
+
+        <avp name="RAND" code="1447" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="XRES" code="1448" must="M,V" may-encrypt="N" vendor-id="10415">
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Trace-Depth" code="1462" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Enumerated">
-                <item code="0" name="LIPA-PROHIBITED"/>
-                <item code="1" name="LIPA-ONLY"/>
-                <item code="2" name="LIPA-CONDITIONAL"/>
-            </data>
+        <avp name="AUTN" code="1449" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="Trace-NE-Type-List" code="1463" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="KASME" code="1450" must="M,V" may-encrypt="N" vendor-id="10415">
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Trace-Interface-List" code="1464" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Kc" code="1453" must="M,V" may-encrypt="N" vendor-id="10415">
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Trace-Event-List" code="1465" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="SRES" code="1454" must="M,V" may-encrypt="N" vendor-id="10415">
             <data type="OctetString"/>
         </avp>
 
-        <avp name="OMC-Id" code="1466" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Confidentiality-Key" code="625" must="M,V" may-encrypt="N" vendor-id="10415">
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Trace-Event-List" code="1465" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Integrity-Key" code="626" must="M,V" may-encrypt="N" vendor-id="10415">
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Trace-Collection-Entity" code="1452" must="M,V" may="P" may-encrypt="N" vendor-id="10415">
-            <data type="Address"/>
+        <avp name="ULR-Flags" code="1405" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="MDT-Configuration" code="1622" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Grouped">
-                <rule avp="QoS-Class-Identifier" required="true" max="1"/>
-                <rule avp="Allocation-Retention-Priority" required="true" max="1"/>
-                <rule avp="AVP" required="false"/>
-            </data>
+        <avp name="ULA-Flags" code="1406" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="GPRS-Subscription-Data" code="1467" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Grouped">
-                <rule avp="Complete-Data-List-Included-Indicator" required="true" max="1"/>
-                <rule avp="PDP-Context" required="true" max="50"/>
-                <rule avp="AVP" required="false"/>
+        <avp name="CLR-Flags" code="1638" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="IDR-Flags" code="1490" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="UE-SRVCC-Capability" code="1615" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="UE-SRVCC-NOT-SUPPORTED"/>
+                <item code="1" name="UE-SRVCC-SUPPORTED"/>
             </data>
         </avp>
 
-        <avp name="Complete-Data-List-Included-Indicator" code="1468" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Homogeneous-Support-of-IMS-Voice-Over-PS-Sessions" code="1493" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
             <data type="Enumerated">
-                <item code="0" name="All_PDP_CONTEXTS_INCLUDED"/>
-                <item code="1" name="MODIFIED/ADDED_PDP CONTEXTS_INCLUDED"/>
+                <item code="0" name="NOT-SUPPORTED"/>
+                <item code="1" name="SUPPORTED"/>
             </data>
         </avp>
 
-        <avp name="PDP-Context" code="1469" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Active-APN" code="1612" vendor-id="10415" must="M,V" may-encrypt="N">
             <data type="Grouped">
                 <rule avp="Context-Identifier" required="true" max="1"/>
-                <rule avp="PDP-Type" required="true" max="1"/>
-                <rule avp="PDP-Address" required="false" max="1"/>
-                <rule avp="QoS-Subscribed" required="true" max="1"/>
-                <rule avp="VPLMN-Dynamic-Address-Allowed" required="false" max="1"/>
+                <rule avp="Service-Selection" required="false" max="1"/>
+                <rule avp="MIP6-Agent-Info" required="false" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="Specific-APN-Info" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
+        </avp>
+
+        <avp name="Specific-APN-Info" code="1472" vendor-id="10415" must="M,V" may-encrypt="N">
+            <data type="Grouped">
                 <rule avp="Service-Selection" required="true" max="1"/>
-                <rule avp="TGPP-Charging-Characteristics" required="false" max="1"/>
-                <rule avp="Ext-PDP-Type" required="false" max="1"/>
-                <rule avp="Ext-PDP-Address" required="false" max="1"/>
-                <rule avp="AMBR" required="false" max="1"/>
-                <rule avp="SIPTO-Permission" required="false" max="1"/>
-                <rule avp="LIPA-Permission" required="false" max="1"/>
+                <rule avp="MIP6-Agent-Info" required="true" max="1"/>
+                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
                 <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="PDP-Type" code="1470" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
+        <avp name="Context-Identifier" code="1423" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="QoS-Subscribed" code="1404" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
+        <avp name="PUR-Flags" code="1635" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32" />
         </avp>
 
-        <avp name="VPLMN-Dynamic-Address-Allowed" code="1432" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Enumerated">
-                <item code="0" name="NOTALLOWED"/>
-                <item code="1" name="ALLOWED"/>
-            </data>
+        <avp name="PUA-Flags" code="1442" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32" />
         </avp>
 
-        <avp name="Ext-PDP-Type" code="1620" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
+        <avp name="NOR-Flags" code="1443" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="Unsigned32" />
         </avp>
 
-        <avp name="Ext-PDP-Address" code="1621" must="V,M" may="P" must-not="-" may-encrypt="Y" vendor-id="10415">
+        <avp name="Subscribed-VSRVCC" code="1636" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Enumerated">
+                <item code="0" name="VSRVCC_SUBSCRIBED" />
+            </data>
+        </avp>
+
+        <!-- RFC 4004 -->
+        <avp name="MIP-Home-Agent-Address" code="334" must="M" must-not="V">
             <data type="Address"/>
         </avp>
 
-        <avp name="SIPTO-Permission" code="1613" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
-            <data type="Enumerated">
-                <item code="0" name="SIPTO_ALLOWED"/>
-                <item code="1" name="SIPTO_NOTALLOWED"/>
+        <!-- RFC 4004 -->
+        <avp name="MIP-Home-Agent-Host" code="348" must="M" may="P" must-not="V" may-encrypt="Y">
+            <data type="Grouped">
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="CSG-Subscription-Data" code="1436" must="M,V" may-encrypt="N" vendor-id="10415">
+        <!-- RFC 5447 Diameter Mobile IPv6: Support for Network Access Server to Diameter Server Interaction -->
+        <avp name="MIP6-Agent-Info" code="486" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="10415">
             <data type="Grouped">
-                <rule avp="CSG-Id" required="true" max="1"/>
-                <rule avp="Expiration-Date" required="false" max="1"/>
-                <rule avp="Service-Selection" required="false"/>
+                <rule avp="MIP-Home-Agent-Address" required="false" max="2"/>
+                <rule avp="MIP-Home-Agent-Host" required="false" max="1"/>
+                <rule avp="MIP6-Home-Link-Prefix" required="false" max="1"/>
                 <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="Expiration-Date" code="1439" must="V,M" may-encrypt="N" vendor-id="10415">
-            <data type="Time"/>
+        <avp name="Service-Selection" code="493" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="10415">
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="Roaming-Restricted-Due-To-Unsupported-Feature" code="1457" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Enumerated">
-                <item code="0" name="Roaming-Restricted-Due-To-Unsupported-Feature"/>
-            </data>
+        <avp name="Visited-Network-Identifier" code="600" must="M,V" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="Subscribed-Periodic-RAU-TAU-Timer" code="1619" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32"/>
+        <!-- RFC 5447 Diameter Mobile IPv6: Support for Network Access Server to Diameter Server Interaction -->
+        <avp name="MIP6-Home-Link-Prefix" code="125">
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="MPS-Priority" code="1616" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="User-Id" code="1444" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="UTF8String"/>
+        </avp>
+
+        <avp name="IDA-Flags" code="1441" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
             <data type="Unsigned32"/>
         </avp>
 
-        <avp name="VPLMN-LIPA-Allowed" code="1617" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="Alert-Reason" code="1434" must="M,V" may-encrypt="N" vendor-id="10415">
             <data type="Enumerated">
-                <item code="0" name="LIPA-NOTALLOWED"/>
-                <item code="1" name="LIPA-ALLOWED"/>
+                <item code="0" name="UE_PRESENT"/>
+                <item code="1" name="UE_MEMORY_AVAILABLE"/>
             </data>
         </avp>
 
-        <avp name="Relay-Node-Indicator" code="1633" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="IMS-Voice-Over-PS-Sessions-Supported" code="1492" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
             <data type="Enumerated">
-                <item code="0" name="NOT_RELAY_NODE"/>
-                <item code="1" name="RELAY_NODE"/>
+                <item code="0" name="NOT_SUPPORTED"/>
+                <item code="1" name="SUPPORTED"/>
             </data>
         </avp>
 
-        <avp name="MDT-User-Consent" code="1634" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
-            <data type="Enumerated">
-                <item code="0" name="CONSENT_NOT_GIVEN"/>
-                <item code="1" name="CONSENT_GIVEN"/>
-            </data>
+        <avp name="Last-UE-Activity-Time" code="1494" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="Time"/>
+        </avp>
+
+        <avp name="Reset-ID" code="1670" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="Requested-EUTRAN-Authentication-Info" code="1408" must="M,V" may-encrypt="N" vendor-id="10415">
+    </application>
+</diameter>`
+
+var tgppshXML = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+    <!--
+        3GPP TS 29.328 and 29.329 (Sh/Dh interface)
+        Between the IMS application servers and the HSS/SLF
+    -->
+    <application id="16777217" type="auth" name="TGPP SH">
+        <vendor id="10415" name="TGPP"/>
+
+        <command code="306" short="UD" name="User-Data">
+            <!-- 3GPP TS 29.329 Section 6.1.1 and 6.1.2 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="User-Identity" required="true" max="1"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Wildcarded-IMPU" required="false" max="1"/>
+                <rule avp="Server-Name" required="false" max="1"/>
+                <rule avp="Service-Indication" required="false"/>
+                <rule avp="Data-Reference" required="true"/>
+                <rule avp="Identity-Set" required="false"/>
+                <rule avp="Requested-Domain" required="false" max="1"/>
+                <rule avp="Current-Location" required="false" max="1"/>
+                <rule avp="DSAI-Tag" required="false"/>
+                <rule avp="Session-Priority" required="false" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Requested-Nodes" required="false" max="1"/>
+                <rule avp="Serving-Node-Indication" required="false" max="1"/>
+                <rule avp="Pre-paging-Supported" required="false" max="1"/>
+                <rule avp="Local-Time-Zone-Indication" required="false" max="1"/>
+                <rule avp="UDR-Flags" required="false" max="1"/>
+                <rule avp="Call-Reference-Info" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Wildcarded-IMPU" required="false" max="1"/>
+                <rule avp="User-Data-Sh" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <command code="307" short="PU" name="Profile-Update">
+            <!-- 3GPP TS 29.329 Section 6.1.3 and 6.1.4 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="User-Identity" required="true" max="1"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Wildcarded-IMPU" required="false" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="Data-Reference" required="true" max="1"/>
+                <rule avp="User-Data-Sh" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Wildcarded-IMPU" required="false" max="1"/>
+                <rule avp="Repository-Data-ID" required="false" max="1"/>
+                <rule avp="Data-Reference" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <command code="308" short="SN" name="Subscribe-Notifications">
+            <!-- 3GPP TS 29.329 Section 6.1.5 and 6.1.6 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="false" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="User-Identity" required="true" max="1"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Wildcarded-IMPU" required="false" max="1"/>
+                <rule avp="Service-Indication" required="false"/>
+                <rule avp="Send-Data-Indication" required="false" max="1"/>
+                <rule avp="Server-Name" required="false" max="1"/>
+                <rule avp="Subs-Req-Type" required="true" max="1"/>
+                <rule avp="Data-Reference" required="true"/>
+                <rule avp="Identity-Set" required="false"/>
+                <rule avp="Expiry-Time" required="false" max="1"/>
+                <rule avp="DSAI-Tag" required="false"/>
+                <rule avp="One-Time-Notification" required="false" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Wildcarded-IMPU" required="false" max="1"/>
+                <rule avp="User-Data-Sh" required="false" max="1"/>
+                <rule avp="Expiry-Time" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <command code="309" short="PN" name="Push-Notification">
+            <!-- 3GPP TS 29.329 Section 6.1.7 and 6.1.8 -->
+            <request>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="User-Identity" required="true" max="1"/>
+                <rule avp="Wildcarded-Public-Identity" required="false" max="1"/>
+                <rule avp="Wildcarded-IMPU" required="false" max="1"/>
+                <rule avp="User-Name" required="false" max="1"/>
+                <rule avp="User-Data-Sh" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </request>
+            <answer>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="AVP" required="false"/>
+                <rule avp="Failed-AVP" required="false" max="1"/>
+                <rule avp="Proxy-Info" required="false"/>
+                <rule avp="Route-Record" required="false"/>
+            </answer>
+        </command>
+
+        <avp name="User-Identity" code="700" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.1 -->
             <data type="Grouped">
-                <rule avp="Number-Of-Requested-Vectors" required="false" max="1"/>
-                <rule avp="Immediate-Response-Preferred" required="false" max="1"/>
-                <rule avp="Re-synchronization-Info" required="false" max="1"/>
+                <rule avp="Public-Identity" required="false" max="1"/>
+                <rule avp="MSISDN" required="false" max="1"/>
+                <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="Number-Of-Requested-Vectors" code="1410" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32"/>
-        </avp>
-
-        <avp name="Re-synchronization-Info" code="1411" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="MSISDN" code="701" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.2 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Immediate-Response-Preferred" code="1412" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32"/>
+        <avp name="User-Data-Sh" code="702" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.3 -->
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="Requested-UTRAN-GERAN-Authentication-Info" code="1409" vendor-id="10415" must="M,V" may-encrypt="N">
-            <data type="Grouped">
-                <rule avp="Number-Of-Requested-Vectors" required="false" max="1"/>
-                <rule avp="Immediate-Response-Preferred" required="false" max="1"/>
-                <rule avp="Re-synchronization-Info" required="false" max="1"/>
+        <avp name="Data-Reference" code="703" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.4 -->
+            <data type="Enumerated">
+                <item code="0" name="RepositoryData"/>
+                <item code="10" name="IMSPublicIdentity"/>
+                <item code="11" name="IMSUserState"/>
+                <item code="12" name="S-CSCFName"/>
+                <item code="13" name="InitialFilterCriteria"/>
+                <item code="14" name="LocationInformation"/>
+                <item code="15" name="UserState"/>
+                <item code="16" name="ChargingInformation"/>
+                <item code="17" name="MSISDN"/>
+                <item code="18" name="PSIActivation"/>
+                <item code="19" name="DSAI"/>
+                <item code="21" name="ServiceLevelTraceInfo"/>
+                <item code="22" name="IPAddressSecureBindingInformation"/>
+                <item code="23" name="ServicePriorityLevel"/>
+                <item code="24" name="SMSRegistrationInfo"/>
+                <item code="25" name="UEReachabilityForIP"/>
+                <item code="26" name="TADSinformation"/>
+                <item code="27" name="STN-SR"/>
+                <item code="28" name="UE-SRVCC-Capability"/>
+                <item code="29" name="ExtendedPriority"/>
+                <item code="30" name="CSRN"/>
+                <item code="31" name="ReferenceLocationInformation"/>
+                <item code="32" name="IMSI"/>
+                <item code="33" name="IMSPrivateUserIdentity"/>
+                <item code="34" name="IMEISV"/>
+                <item code="35" name="UE-5G-SRVCC-Capability"/>
             </data>
         </avp>
 
-        <avp name="Visited-PLMN-Id" code="1407" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Service-Indication" code="704" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.5 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Error-Diagnostic" code="1614" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="Subs-Req-Type" code="705" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.6 -->
             <data type="Enumerated">
-                <item code="0" name="GPRS_DATA_SUBSCRIBED"/>
-                <item code="1" name="NO_GPRS_DATA_SUBSCRIBED"/>
-                <item code="2" name="ODB-ALL-APN"/>
-                <item code="3" name="ODB-HPLMN-APN"/>
-                <item code="4" name="ODB-VPLMN-APN"/>
-            </data>
-        </avp>
-
-        <avp name="Authentication-Info" code="1413" vendor-id="10415" must="M,V" may-encrypt="N">
-            <data type="Grouped">
-                <rule avp="E-UTRAN-Vector" required="false"/>
-                <rule avp="UTRAN-Vector" required="false"/>
-                <rule avp="GERAN-Vector" required="false"/>
+                <item code="0" name="Subscribe"/>
+                <item code="1" name="Unsubscribe"/>
             </data>
         </avp>
 
-        <avp name="E-UTRAN-Vector" code="1414" vendor-id="10415" must="M,V" may-encrypt="N">
-            <data type="Grouped">
-                <rule avp="Item-Number" required="false" max="1"/>
-                <rule avp="RAND" required="true" max="1"/>
-                <rule avp="XRES" required="true" max="1"/>
-                <rule avp="AUTN" required="true" max="1"/>
-                <rule avp="KASME" required="true" max="1"/>
+        <avp name="Requested-Domain" code="706" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.7 -->
+            <data type="Enumerated">
+                <item code="0" name="CS-Domain"/>
+                <item code="1" name="PS-Domain"/>
             </data>
         </avp>
 
-        <avp name="UTRAN-Vector" code="1415" vendor-id="10415" must="M,V" may-encrypt="N">
-            <data type="Grouped">
-                <rule avp="Item-Number" required="false"/>
-                <rule avp="RAND" required="true"/>
-                <rule avp="XRES" required="true"/>
-                <rule avp="AUTN" required="true"/>
-                <rule avp="Confidentiality-Key" required="true"/>
-                <rule avp="Integrity-Key" required="true"/>
+        <avp name="Current-Location" code="707" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.8 -->
+            <data type="Enumerated">
+                <item code="0" name="DoNotNeedInitiateActiveLocationRetrieval"/>
+                <item code="1" name="InitiateActiveLocationRetrieval"/>
             </data>
         </avp>
 
-        <avp name="GERAN-Vector" code="1416" vendor-id="10415" must="M,V" may-encrypt="N">
-            <data type="Grouped">
-                <rule avp="Item-Number" required="false" max="1"/>
-                <rule avp="RAND" required="true" max="1"/>
-                <rule avp="SRES" required="true" max="1"/>
-                <rule avp="Kc" required="true" max="1"/>
+        <avp name="Identity-Set" code="708" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.10 -->
+            <data type="Enumerated">
+                <item code="0" name="ALL_IDENTITIES"/>
+                <item code="1" name="REGISTERED_IDENTITIES"/>
+                <item code="2" name="IMPLICIT_IDENTITIES"/>
+                <item code="3" name="ALIAS_IDENTITIES"/>
             </data>
         </avp>
 
-        <avp name="Item-Number" code="1419" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32"/>
+        <avp name="Expiry-Time" code="709" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.16 -->
+            <data type="Time"/>
         </avp>
 
-        <avp name="Cancellation-Type" code="1420" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Send-Data-Indication" code="710" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.17 -->
             <data type="Enumerated">
-                <item code="0" name="MME_UPDATE_PROCEDURE"/>
-                <item code="1" name="SGSN_UDPATE_PROCEDURE"/>
-                <item code="2" name="SUBSCRIPTION_WITHDRAWAL"/>
-                <item code="3" name="UPDATE_PROCEDURE_IWF"/>
-                <item code="4" name="INITIAL_ATTACH_PROCEDURE"/>
+                <item code="0" name="USER_DATA_NOT_REQUESTED"/>
+                <item code="1" name="USER_DATA_REQUESTED"/>
             </data>
         </avp>
 
-        <avp name="RAND" code="1447" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
-        </avp>
-
-        <avp name="XRES" code="1448" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
-        </avp>
-
-        <avp name="AUTN" code="1449" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
-        </avp>
-
-        <avp name="KASME" code="1450" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
-        </avp>
-
-        <avp name="Kc" code="1453" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
-        </avp>
-
-        <avp name="SRES" code="1454" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
-        </avp>
-
-        <avp name="Confidentiality-Key" code="625" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="DSAI-Tag" code="711" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.18 -->
             <data type="OctetString"/>
         </avp>
 
-        <avp name="Integrity-Key" code="626" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
+        <avp name="One-Time-Notification" code="712" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.22 -->
+            <data type="Enumerated">
+                <item code="0" name="ONE_TIME_NOTIFICATION_REQUESTED"/>
+            </data>
         </avp>
 
-        <avp name="ULR-Flags" code="1405" must="M,V" may-encrypt="N" vendor-id="10415">
+        <avp name="Requested-Nodes" code="713" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.7A -->
             <data type="Unsigned32"/>
         </avp>
 
-        <avp name="ULA-Flags" code="1406" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32"/>
+        <avp name="Serving-Node-Indication" code="714" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.23 -->
+            <data type="Enumerated">
+                <item code="0" name="ONLY_SERVING_NODES_REQUIRED"/>
+            </data>
         </avp>
 
-        <avp name="CLR-Flags" code="1638" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32"/>
+        <avp name="Repository-Data-ID" code="715" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.24 -->
+            <data type="Grouped">
+                <rule avp="Service-Indication" required="true" max="1"/>
+                <rule avp="Sequence-Number" required="true" max="1"/>
+                <rule avp="AVP" required="false"/>
+            </data>
         </avp>
 
-        <avp name="IDR-Flags" code="1490" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="Sequence-Number" code="716" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.25 -->
             <data type="Unsigned32"/>
         </avp>
 
-        <avp name="UE-SRVCC-Capability" code="1615" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="Pre-paging-Supported" code="717" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.26 -->
             <data type="Enumerated">
-                <item code="0" name="UE-SRVCC-NOT-SUPPORTED"/>
-                <item code="1" name="UE-SRVCC-SUPPORTED"/>
+                <item code="0" name="PREPAGING_NOT_SUPPORTED"/>
+                <item code="1" name="PREPAGING_SUPPORTED"/>
             </data>
         </avp>
 
-        <avp name="Homogeneous-Support-of-IMS-Voice-Over-PS-Sessions" code="1493" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="Local-Time-Zone-Indication" code="718" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.27 -->
             <data type="Enumerated">
-                <item code="0" name="NOT-SUPPORTED"/>
-                <item code="1" name="SUPPORTED"/>
+                <item code="0" name="ONLY_LOCAL_TIME_ZONE_REQUESTED"/>
+                <item code="1" name="LOCAL_TIME_ZONE_WITH_LOCATION_INFO_REQUESTED"/>
             </data>
         </avp>
 
-        <avp name="Active-APN" code="1612" vendor-id="10415" must="M,V" may-encrypt="N">
-            <data type="Grouped">
-                <rule avp="Context-Identifier" required="true" max="1"/>
-                <rule avp="Service-Selection" required="false" max="1"/>
-                <rule avp="MIP6-Agent-Info" required="false" max="1"/>
-                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
-                <rule avp="Specific-APN-Info" required="false" max="1"/>
-                <rule avp="AVP" required="false"/>
-            </data>
+        <avp name="UDR-Flags" code="719" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.28 -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="Specific-APN-Info" code="1472" vendor-id="10415" must="M,V" may-encrypt="N">
+        <avp name="Call-Reference-Info" code="720" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.29 -->
             <data type="Grouped">
-                <rule avp="Service-Selection" required="true" max="1"/>
-                <rule avp="MIP6-Agent-Info" required="true" max="1"/>
-                <rule avp="Visited-Network-Identifier" required="false" max="1"/>
+                <rule avp="Call-Reference-Number" required="true" max="1"/>
+                <rule avp="AS-Number" required="true" max="1"/>
                 <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="Context-Identifier" code="1423" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32"/>
-        </avp>
-
-        <avp name="PUR-Flags" code="1635" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32" />
-        </avp>
-
-        <avp name="PUA-Flags" code="1442" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32" />
-        </avp>
-
-        <avp name="NOR-Flags" code="1443" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="Unsigned32" />
+        <avp name="Call-Reference-Number" code="721" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.30 -->
+            <data type="OctetString"/>
         </avp>
 
-        <avp name="Subscribed-VSRVCC" code="1636" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
-            <data type="Enumerated">
-                <item code="0" name="VSRVCC_SUBSCRIBED" />
-            </data>
+        <avp name="AS-Number" code="722" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.329 Section 6.3.31 -->
+            <data type="OctetString"/>
         </avp>
 
-        <!-- RFC 4004 -->
-        <avp name="MIP-Home-Agent-Address" code="334" must="M" must-not="V">
-            <data type="Address"/>
+        <avp name="Public-Identity" code="601" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.2 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <!-- RFC 4004 -->
-        <avp name="MIP-Home-Agent-Host" code="348" must="M" may="P" must-not="V" may-encrypt="Y">
-            <data type="Grouped">
-                <rule avp="Destination-Realm" required="true" max="1"/>
-                <rule avp="Destination-Host" required="true" max="1"/>
-                <rule avp="AVP" required="false"/>
-            </data>
+        <avp name="Server-Name" code="602" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.3 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <!-- RFC 5447 Diameter Mobile IPv6: Support for Network Access Server to Diameter Server Interaction -->
-        <avp name="MIP6-Agent-Info" code="486" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="10415">
+        <avp name="Supported-Features" code="628" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.29 -->
             <data type="Grouped">
-                <rule avp="MIP-Home-Agent-Address" required="false" max="2"/>
-                <rule avp="MIP-Home-Agent-Host" required="false" max="1"/>
-                <rule avp="MIP6-Home-Link-Prefix" required="false" max="1"/>
+                <rule avp="Vendor-Id" required="true" max="1"/>
+                <rule avp="Feature-List-ID" required="true" max="1"/>
+                <rule avp="Feature-List" required="true" max="1"/>
                 <rule avp="AVP" required="false"/>
             </data>
         </avp>
 
-        <avp name="Service-Selection" code="493" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="10415">
-            <data type="UTF8String"/>
+        <avp name="Feature-List-ID" code="629" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.30 -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <avp name="Visited-Network-Identifier" code="600" must="M,V" may-encrypt="N" vendor-id="10415">
-            <data type="OctetString"/>
+        <avp name="Feature-List" code="630" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.31 -->
+            <data type="Unsigned32"/>
         </avp>
 
-        <!-- RFC 5447 Diameter Mobile IPv6: Support for Network Access Server to Diameter Server Interaction -->
-        <avp name="MIP6-Home-Link-Prefix" code="125">
-            <data type="OctetString"/>
+        <avp name="Wildcarded-Public-Identity" code="634" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.35 -->
+            <data type="UTF8String"/>
         </avp>
 
-        <avp name="User-Id" code="1444" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+        <avp name="Wildcarded-IMPU" code="636" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.43 -->
             <data type="UTF8String"/>
         </avp>
 
+        <avp name="Session-Priority" code="650" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.56 -->
+            <data type="Enumerated">
+                <item code="0" name="PRIORITY-0"/>
+                <item code="1" name="PRIORITY-1"/>
+                <item code="2" name="PRIORITY-2"/>
+                <item code="3" name="PRIORITY-3"/>
+                <item code="4" name="PRIORITY-4"/>
+            </data>
+        </avp>
     </application>
 </diameter>`
 
@@ -7420,7 +9318,7 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
         <command code="303" short="MA" name="Multimedia-Auth">
             <request>
                 <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.2.1 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
                 <rule avp="Auth-Session-State" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
@@ -7439,7 +9337,7 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
             </request>
             <answer>
                 <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.2.1 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
                 <rule avp="Result-Code" required="false" max="1"/>
                 <rule avp="Experimental-Result" required="false" max="1"/>
@@ -7457,7 +9355,7 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
         <command code="301" short="SA" name="Server-Assignment">
             <request>
                 <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.2.3 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
                 <rule avp="Auth-Session-State" required="true" max="1"/>
                 <rule avp="Origin-Host" required="true" max="1"/>
@@ -7475,7 +9373,7 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
             </request>
             <answer>
                 <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.2.3 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
                 <rule avp="Result-Code" required="false" max="1"/>
                 <rule avp="Experimental-Result" required="false" max="1"/>
@@ -7492,7 +9390,7 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
         <command code="304" short="RT" name="Registration-Termination">
             <request>
                 <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.2.4 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
                 <rule avp="Auth-Session-State" required="true" max="1"/>
@@ -7507,7 +9405,39 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
             </request>
             <answer>
                 <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.2.4 -->
-                <rule avp="Session-Id" required="true" max="1"/>
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="DRMP" required="false" max="1" />
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Result-Code" required="false" max="1"/>
+                <rule avp="Experimental-Result" required="false" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </answer>
+        </command>
+
+        <command code="305" short="PP" name="Push-Profile">
+            <request>
+                <!-- 3GPP TS 29.273 Section 8.2.2.4 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
+                <rule avp="DRMP" required="false" max="1" />
+                <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
+                <rule avp="Auth-Session-State" required="true" max="1"/>
+                <rule avp="Origin-Host" required="true" max="1"/>
+                <rule avp="Origin-Realm" required="true" max="1"/>
+                <rule avp="Destination-Host" required="true" max="1"/>
+                <rule avp="Destination-Realm" required="true" max="1"/>
+                <rule avp="User-Name" required="true" max="1"/>
+                <rule avp="Non-3GPP-User-Data" required="false" max="1"/>
+                <rule avp="PPR-Flags" required="false" max="1"/>
+                <rule avp="Supported-Features" required="false"/>
+                <rule avp="AVP" required="false"/>
+            </request>
+            <answer>
+                <!-- 3GPP TS 29.273 Section 8.2.2.4 -->
+                <rule avp="Session-Id" required="true" max="1" position="1"/>
                 <rule avp="DRMP" required="false" max="1" />
                 <rule avp="Vendor-Specific-Application-Id" required="true" max="1"/>
                 <rule avp="Result-Code" required="false" max="1"/>
@@ -7520,6 +9450,11 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
             </answer>
         </command>
 
+        <avp name="PPR-Flags" code="1508" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.273 Section 8.2.3.16 -->
+            <data type="Unsigned32"/>
+        </avp>
+
         <avp name="RAT-Type" code="1032" must="M,V" may="P" may-encrypt="Y" vendor-id="10415">
             <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 5.2.3.6 -->
             <data type="Enumerated">
@@ -7557,6 +9492,21 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
             </data>
         </avp>
 
+        <avp name="IMEI" code="1402" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.4 -->
+            <data type="UTF8String"/>
+        </avp>
+
+        <avp name="TGPP2-MEID" code="1471" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.6 -->
+            <data type="OctetString"/>
+        </avp>
+
+        <avp name="Software-Version" code="1403" must="M,V" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.272 Section 7.3.5 -->
+            <data type="UTF8String"/>
+        </avp>
+
         <avp name="SIP-Auth-Data-Item" code="612" must="M,V" may-encrypt="N" vendor-id="10415">
             <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 8.2.3.9-->
             <data type="Grouped">
@@ -7619,6 +9569,16 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
             </data>
         </avp>
 
+        <avp name="Feature-List-ID" code="629" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.30 -->
+            <data type="Unsigned32"/>
+        </avp>
+
+        <avp name="Feature-List" code="630" must="V" must-not="M" may-encrypt="N" vendor-id="10415">
+            <!-- 3GPP TS 29.229 Section 6.3.31 -->
+            <data type="Unsigned32"/>
+        </avp>
+
         <avp name="Service-Selection" code="493" must="M" may="P" must-not="V" may-encrypt="Y" vendor-id="0">
             <!-- http://www.qtc.jp/3GPP/Specs/29273-920.pdf Section 5.2.3.5 -->
             <data type="UTF8String"/>
@@ -7694,7 +9654,7 @@ var tgppswxXML = `<?xml version="1.0" encoding="UTF-8"?>
                 <rule avp="Session-Timeout" required="false" max="1"/>
                 <rule avp="MIP6-Feature-Vector" required="false" max="1"/>
                 <rule avp="AMBR" required="false" max="1"/>
-                <rule avp="3GPP-Charging-Characteristics" required="false" max="1"/>
+                <rule avp="TGPP-Charging-Characteristics" required="false" max="1"/>
                 <rule avp="Context-Identifier" required="false" max="1"/>
                 <rule avp="APN-OI-Replacement" required="false" max="1"/>
                 <rule avp="APN-Configuration" required="false"/>
@@ -0,0 +1,526 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Dictionary exporters.  Part of go-diameter.
+
+package dict
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// exportApps returns the applications id of ix, merging the definitions
+// of each of them across dictionary files and keeping only the AVPs in
+// effect. With no ids, all applications but the base one are returned.
+func (ix *index) exportApps(ids []uint32) ([]*App, error) {
+	if len(ids) == 0 {
+		seen := make(map[uint32]bool)
+		for _, f := range ix.file {
+			for _, app := range f.App {
+				if app.ID != 0 && !seen[app.ID] {
+					seen[app.ID] = true
+					ids = append(ids, app.ID)
+				}
+			}
+		}
+	}
+	var apps []*App
+	for _, id := range ids {
+		if _, ok := ix.appcode[id]; !ok {
+			return nil, fmt.Errorf("application %d: %w", id, ErrApplicationUnsupported)
+		}
+		app := &App{ID: id, Parent: ix.parent[id]}
+		avps := make(map[*AVP]bool)
+		for _, f := range ix.file {
+			for _, src := range f.App {
+				if src.ID != id {
+					continue
+				}
+				if len(app.Name) == 0 {
+					app.Name, app.Type = src.Name, src.Type
+				}
+				for _, v := range src.Vendor {
+					if !hasVendor(app.Vendor, v.ID) {
+						app.Vendor = append(app.Vendor, v)
+					}
+				}
+				app.Command = append(app.Command, src.Command...)
+				for _, avp := range src.AVP {
+					// Later definitions replace earlier ones.
+					avp = ix.avpcode[codeIdx{id, avp.Code, avp.VendorID}]
+					if !avps[avp] {
+						avps[avp] = true
+						app.AVP = append(app.AVP, avp)
+					}
+				}
+			}
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+func hasVendor(vendors []*Vendor, id uint32) bool {
+	for _, v := range vendors {
+		if v.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// vendorNames returns the names of the vendors declared in ix, by code.
+func (ix *index) vendorNames() map[uint32]string {
+	names := make(map[uint32]string)
+	for _, f := range ix.file {
+		for _, app := range f.App {
+			for _, v := range app.Vendor {
+				if len(names[v.ID]) == 0 {
+					names[v.ID] = v.Name
+				}
+			}
+		}
+	}
+	return names
+}
+
+// ruleVendor returns the vendor of the AVP of a rule of application id.
+func (ix *index) ruleVendor(id uint32, name string) uint32 {
+	if avp, err := ix.findAVP(id, name, UndefinedVendorID); err == nil {
+		return avp.VendorID
+	}
+	return 0
+}
+
+// flagRule returns how a dictionary AVP uses one of its flags, as must,
+// may or mustnot.
+func flagRule(avp *AVP, flag string) string {
+	switch {
+	case strings.Contains(avp.Must, flag):
+		return "must"
+	case strings.Contains(avp.MustNot, flag):
+		return "mustnot"
+	}
+	return "may"
+}
+
+var xmlNameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// wiresharkTypes maps go-diameter data types to the Wireshark ones
+// when their names differ.
+var wiresharkTypes = map[string]string{
+	"Address": "IPAddress",
+	"IPv4":    "OctetString",
+	"IPv6":    "OctetString",
+}
+
+// ExportWireshark writes the applications with the given ids, or all
+// applications but the base one when there is none, as a Wireshark
+// dictionary fragment. The fragment is meant to be included from the
+// dictionary.xml of Wireshark with an external entity, or pasted in its
+// Custom.xml file, and can be read back with ReadWireshark.
+//
+// Vendors are declared with their dictionary names as vendor-id, which
+// for 3GPP (TGPP) matches the one of Wireshark's own dictionaries.
+func (p *Parser) ExportWireshark(w io.Writer, ids ...uint32) error {
+	ix := p.current()
+	apps, err := ix.exportApps(ids)
+	if err != nil {
+		return err
+	}
+	names := ix.vendorNames()
+	vendorID := func(code uint32) string {
+		if code == 0 {
+			return ""
+		}
+		if name := xmlNameChars.ReplaceAllString(names[code], ""); len(name) > 0 {
+			return name
+		}
+		return "Vendor" + strconv.FormatUint(uint64(code), 10)
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(bw, "<!-- Exported by go-diameter. -->\n")
+	enc := xml.NewEncoder(bw)
+	enc.Indent("", "\t")
+	var vendors []uint32
+	for _, app := range apps {
+		for _, v := range app.Vendor {
+			vendors = append(vendors, v.ID)
+		}
+		for _, avp := range app.AVP {
+			vendors = append(vendors, avp.VendorID)
+		}
+	}
+	sort.Slice(vendors, func(i, j int) bool { return vendors[i] < vendors[j] })
+	for i, code := range vendors {
+		if code == 0 || (i > 0 && vendors[i-1] == code) {
+			continue
+		}
+		v := &wsVendor{ID: vendorID(code), Code: code, Name: names[code]}
+		if len(v.Name) == 0 {
+			v.Name = v.ID
+		}
+		if err = enc.EncodeElement(v, xml.StartElement{Name: xml.Name{Local: "vendor"}}); err != nil {
+			return err
+		}
+	}
+	for _, app := range apps {
+		wa := &wsApp{ID: app.ID, Name: app.Name}
+		cmdVendor := "None"
+		if len(app.Vendor) > 0 {
+			cmdVendor = vendorID(app.Vendor[0].ID)
+		}
+		for _, cmd := range app.Command {
+			wa.Command = append(wa.Command, &wsCommand{
+				Name:     cmd.Name,
+				Code:     cmd.Code,
+				VendorID: cmdVendor,
+				Request:  wiresharkRules(cmd.Request.Rule),
+				Answer:   wiresharkRules(cmd.Answer.Rule),
+			})
+		}
+		for _, avp := range app.AVP {
+			wa.AVP = append(wa.AVP, wiresharkAVP(avp, vendorID(avp.VendorID)))
+		}
+		if err = enc.EncodeElement(wa, xml.StartElement{Name: xml.Name{Local: "application"}}); err != nil {
+			return err
+		}
+	}
+	if err = enc.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintln(bw)
+	return bw.Flush()
+}
+
+func wiresharkAVP(avp *AVP, vendorID string) *wsAVP {
+	wa := &wsAVP{
+		Name:       avp.Name,
+		Code:       avp.Code,
+		VendorID:   vendorID,
+		Mandatory:  flagRule(avp, "M"),
+		Protected:  flagRule(avp, "P"),
+		MayEncrypt: "yes",
+		VendorBit:  flagRule(avp, "V"),
+	}
+	if strings.EqualFold(avp.MayEncrypt, "N") || avp.MayEncrypt == "-" {
+		wa.MayEncrypt = "no"
+	}
+	if avp.Data.Type == datatype.GroupedType {
+		wa.Grouped = &wsGrouped{}
+		for _, r := range avp.Data.Rule {
+			if r.AVP != wildcardAVP {
+				wa.Grouped.GAVP = append(wa.Grouped.GAVP, wiresharkRule(r))
+			}
+		}
+		return wa
+	}
+	typ := avp.Data.TypeName
+	if t, ok := wiresharkTypes[typ]; ok {
+		typ = t
+	}
+	wa.Type = &wsType{Name: typ}
+	for _, e := range avp.Data.Enum {
+		wa.Enum = append(wa.Enum, &wsEnum{Name: e.Name, Code: strconv.Itoa(int(e.Code))})
+	}
+	return wa
+}
+
+// wildcardAVP is the name rules use for any AVP, as in *[ AVP ].
+const wildcardAVP = "AVP"
+
+func wiresharkRules(rules []*Rule) wsRules {
+	var fixed, required, optional []*Rule
+	for _, rule := range rules {
+		switch {
+		case rule.AVP == wildcardAVP:
+		case rule.Position > 0:
+			fixed = append(fixed, rule)
+		case rule.Required:
+			required = append(required, rule)
+		default:
+			optional = append(optional, rule)
+		}
+	}
+	// Wireshark has no tail positions: fixed rules are those of the head.
+	sort.SliceStable(fixed, func(i, j int) bool { return fixed[i].Position < fixed[j].Position })
+	return wsRules{
+		Fixed:    wiresharkRuleList(fixed),
+		Required: wiresharkRuleList(required),
+		Optional: wiresharkRuleList(optional),
+	}
+}
+
+func wiresharkRuleList(rules []*Rule) *wsRuleList {
+	if len(rules) == 0 {
+		return nil
+	}
+	l := &wsRuleList{}
+	for _, rule := range rules {
+		l.Rule = append(l.Rule, wiresharkRule(rule))
+	}
+	return l
+}
+
+func wiresharkRule(rule *Rule) *wsRule {
+	r := &wsRule{Name: rule.AVP}
+	if rule.Min > 0 {
+		r.Min = strconv.Itoa(rule.Min)
+	}
+	if rule.Max > 0 {
+		r.Max = strconv.Itoa(rule.Max)
+	}
+	return r
+}
+
+// freeDiameter AVP base types of go-diameter data types, and the names
+// of the derived types of the freeDiameter base dictionary.
+var freeDiameterTypes = map[datatype.TypeID]struct{ base, derived string }{
+	datatype.AddressType:          {"AVP_TYPE_OCTETSTRING", "Address"},
+	datatype.DiameterIdentityType: {"AVP_TYPE_OCTETSTRING", "DiameterIdentity"},
+	datatype.DiameterURIType:      {"AVP_TYPE_OCTETSTRING", "DiameterURI"},
+	datatype.EnumeratedType:       {"AVP_TYPE_INTEGER32", ""},
+	datatype.Float32Type:          {"AVP_TYPE_FLOAT32", ""},
+	datatype.Float64Type:          {"AVP_TYPE_FLOAT64", ""},
+	datatype.GroupedType:          {"AVP_TYPE_GROUPED", ""},
+	datatype.IPFilterRuleType:     {"AVP_TYPE_OCTETSTRING", "IPFilterRule"},
+	datatype.IPv4Type:             {"AVP_TYPE_OCTETSTRING", ""},
+	datatype.IPv6Type:             {"AVP_TYPE_OCTETSTRING", ""},
+	datatype.Integer32Type:        {"AVP_TYPE_INTEGER32", ""},
+	datatype.Integer64Type:        {"AVP_TYPE_INTEGER64", ""},
+	datatype.OctetStringType:      {"AVP_TYPE_OCTETSTRING", ""},
+	datatype.QoSFilterRuleType:    {"AVP_TYPE_OCTETSTRING", ""},
+	datatype.TimeType:             {"AVP_TYPE_OCTETSTRING", "Time"},
+	datatype.UTF8StringType:       {"AVP_TYPE_OCTETSTRING", "UTF8String"},
+	datatype.Unsigned32Type:       {"AVP_TYPE_UNSIGNED32", ""},
+	datatype.Unsigned64Type:       {"AVP_TYPE_UNSIGNED64", ""},
+}
+
+// freeDiameterHeader declares the helpers of the exported extensions.
+const freeDiameterHeader = `/* Exported by go-diameter. */
+
+#include <freeDiameter/extension.h>
+
+#define CHECK_dict_new(_type, _data, _parent, _ref) \
+	CHECK_FCT(fd_dict_new(fd_g_config->cnf_dict, (_type), (_data), (_parent), (_ref)));
+
+#define CHECK_dict_search(_type, _criteria, _what, _result) \
+	CHECK_FCT(fd_dict_search(fd_g_config->cnf_dict, (_type), (_criteria), (_what), (_result), ENOENT));
+
+/* Adds the rule for the AVP name of vendor to parent. */
+static int add_rule(struct dict_object *parent, vendor_id_t vendor, char *name,
+		enum rule_position position, unsigned order, int min, int max)
+{
+	struct dict_avp_request req = { .avp_vendor = vendor, .avp_name = name };
+	struct dict_rule_data data = { NULL, position, order, min, max };
+
+	CHECK_FCT(fd_dict_search(fd_g_config->cnf_dict, DICT_AVP, AVP_BY_NAME_AND_VENDOR, &req, &data.rule_avp, ENOENT));
+	CHECK_FCT(fd_dict_new(fd_g_config->cnf_dict, DICT_RULE, &data, parent, NULL));
+	return 0;
+}
+
+/* Returns the AVP name of vendor in avp. */
+static int find_avp(vendor_id_t vendor, char *name, struct dict_object **avp)
+{
+	struct dict_avp_request req = { .avp_vendor = vendor, .avp_name = name };
+
+	CHECK_FCT(fd_dict_search(fd_g_config->cnf_dict, DICT_AVP, AVP_BY_NAME_AND_VENDOR, &req, avp, ENOENT));
+	return 0;
+}
+`
+
+var cIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ExportFreeDiameter writes the applications with the given ids, or all
+// applications but the base one when there is none, as the C source of a
+// freeDiameter dictionary extension named name, such as dict_myvendor.
+//
+// AVPs of the base protocol referenced in rules are expected to be
+// defined by the freeDiameter base dictionary, and derived types such as
+// UTF8String by the extensions that define them.
+func (p *Parser) ExportFreeDiameter(w io.Writer, name string, ids ...uint32) error {
+	if !cIdentifier.MatchString(name) {
+		return fmt.Errorf("Invalid extension name: %q", name)
+	}
+	ix := p.current()
+	apps, err := ix.exportApps(ids)
+	if err != nil {
+		return err
+	}
+	names := ix.vendorNames()
+	bw := bufio.NewWriter(w)
+	fmt.Fprint(bw, freeDiameterHeader)
+	fmt.Fprintf(bw, "\nstatic int %s_entry(char *conffile)\n{\n", name)
+	fmt.Fprintf(bw, "\tstruct dict_object *vendor = NULL, *app = NULL, *type = NULL, *cmd = NULL, *avp = NULL;\n")
+	defined := make(map[uint32]bool)
+	for _, app := range apps {
+		for _, v := range app.Vendor {
+			if defined[v.ID] || v.ID == 0 {
+				continue
+			}
+			defined[v.ID] = true
+			fmt.Fprintf(bw, "\n\t/* Vendor %d */\n\t{\n", v.ID)
+			fmt.Fprintf(bw, "\t\tstruct dict_vendor_data data = { %d, %s };\n", v.ID, cQuote(names[v.ID]))
+			fmt.Fprintf(bw, "\t\tCHECK_dict_new(DICT_VENDOR, &data, NULL, NULL);\n\t}\n")
+		}
+	}
+	for _, app := range apps {
+		fmt.Fprintf(bw, "\n\t/* Application %d */\n\t{\n", app.ID)
+		fmt.Fprintf(bw, "\t\tstruct dict_application_data data = { %d, %s };\n", app.ID, cQuote(app.Name))
+		fmt.Fprintf(bw, "\t\tvendor = NULL;\n")
+		if len(app.Vendor) > 0 && app.Vendor[0].ID != 0 {
+			fmt.Fprintf(bw, "\t\tCHECK_dict_search(DICT_VENDOR, VENDOR_BY_ID, &(vendor_id_t){ %d }, &vendor);\n", app.Vendor[0].ID)
+		}
+		fmt.Fprintf(bw, "\t\tCHECK_dict_new(DICT_APPLICATION, &data, vendor, &app);\n\t}\n")
+
+		for _, avp := range app.AVP {
+			freeDiameterAVP(bw, avp)
+		}
+		for _, avp := range app.AVP {
+			if avp.Data.Type != datatype.GroupedType || len(avp.Data.Rule) == 0 {
+				continue
+			}
+			fmt.Fprintf(bw, "\n\t/* %s rules */\n", avp.Name)
+			fmt.Fprintf(bw, "\tCHECK_FCT(find_avp(%d, %s, &avp));\n", avp.VendorID, cQuote(avp.Name))
+			for _, r := range avp.Data.Rule {
+				freeDiameterRule(bw, "avp", r, avp.Data.Rule, ix.ruleVendor(app.ID, r.AVP))
+			}
+		}
+		for _, cmd := range app.Command {
+			for _, req := range []bool{true, false} {
+				rules, suffix, flags := cmd.Request.Rule, "Request", "CMD_FLAG_REQUEST | CMD_FLAG_PROXIABLE"
+				if !req {
+					rules, suffix, flags = cmd.Answer.Rule, "Answer", "CMD_FLAG_PROXIABLE"
+				}
+				fmt.Fprintf(bw, "\n\t/* %s-%s */\n\t{\n", cmd.Name, suffix)
+				fmt.Fprintf(bw, "\t\tstruct dict_cmd_data data = { %d, %s, CMD_FLAG_REQUEST | CMD_FLAG_PROXIABLE | CMD_FLAG_ERROR, %s };\n",
+					cmd.Code, cQuote(cmd.Name+"-"+suffix), flags)
+				fmt.Fprintf(bw, "\t\tCHECK_dict_new(DICT_COMMAND, &data, app, &cmd);\n\t}\n")
+				for _, r := range rules {
+					freeDiameterRule(bw, "cmd", r, rules, ix.ruleVendor(app.ID, r.AVP))
+				}
+			}
+		}
+	}
+	fmt.Fprintf(bw, "\n\tLOG_D(\"Extension '%s' initialized\");\n\treturn 0;\n}\n", name)
+	fmt.Fprintf(bw, "\nEXTENSION_ENTRY(%s, %s_entry);\n", cQuote(name), name)
+	return bw.Flush()
+}
+
+func freeDiameterAVP(w io.Writer, avp *AVP) {
+	t := freeDiameterTypes[avp.Data.Type]
+	if len(t.base) == 0 {
+		t.base = "AVP_TYPE_OCTETSTRING"
+	}
+	var mask, val []string
+	for _, f := range []struct{ flag, name string }{
+		{"V", "AVP_FLAG_VENDOR"},
+		{"M", "AVP_FLAG_MANDATORY"},
+	} {
+		switch flagRule(avp, f.flag) {
+		case "must":
+			mask, val = append(mask, f.name), append(val, f.name)
+		case "mustnot":
+			mask = append(mask, f.name)
+		}
+	}
+	fmt.Fprintf(w, "\n\t/* %s */\n\t{\n", avp.Name)
+	fmt.Fprintf(w, "\t\tstruct dict_avp_data data = { %d, %d, %s, %s, %s, %s };\n",
+		avp.Code, avp.VendorID, cQuote(avp.Name), cFlags(mask), cFlags(val), t.base)
+	fmt.Fprintf(w, "\t\ttype = NULL;\n")
+	enumerated := len(avp.Data.Enum) > 0 &&
+		(t.base == "AVP_TYPE_INTEGER32" || t.base == "AVP_TYPE_UNSIGNED32")
+	switch {
+	case enumerated:
+		fmt.Fprintf(w, "\t\tstruct dict_type_data tdata = { %s, %s, NULL, NULL, NULL };\n",
+			t.base, cQuote("Enumerated("+avp.Name+")"))
+		fmt.Fprintf(w, "\t\tCHECK_dict_new(DICT_TYPE, &tdata, NULL, &type);\n")
+		member := "i32"
+		if t.base == "AVP_TYPE_UNSIGNED32" {
+			member = "u32"
+		}
+		for _, e := range avp.Data.Enum {
+			v := strconv.Itoa(int(e.Code))
+			if member == "u32" {
+				v = strconv.FormatUint(uint64(uint32(e.Code)), 10)
+			}
+			fmt.Fprintf(w, "\t\tCHECK_dict_new(DICT_ENUMVAL, &(struct dict_enumval_data){ %s, { .%s = %s } }, type, NULL);\n",
+				cQuote(e.Name), member, v)
+		}
+	case len(t.derived) > 0:
+		fmt.Fprintf(w, "\t\tCHECK_dict_search(DICT_TYPE, TYPE_BY_NAME, %s, &type);\n", cQuote(t.derived))
+	}
+	fmt.Fprintf(w, "\t\tCHECK_dict_new(DICT_AVP, &data, type, NULL);\n\t}\n")
+}
+
+// freeDiameterRule writes the call adding rule, one of rules, to the
+// object in parent. Unlimited counts are -1, as are the minimum counts of
+// rules without one, which freeDiameter then derives from the rule
+// position. Rules for any AVP are implicit in freeDiameter and skipped.
+func freeDiameterRule(w io.Writer, parent string, rule *Rule, rules []*Rule, vendorID uint32) {
+	if rule.AVP == wildcardAVP {
+		return
+	}
+	position, order := "RULE_OPTIONAL", 0
+	switch {
+	case rule.Position > 0:
+		position, order = "RULE_FIXED_HEAD", rule.Position
+	case rule.Position < 0:
+		// freeDiameter orders the tail from its first AVP.
+		position, order = "RULE_FIXED_TAIL", rule.Position+1
+		for _, r := range rules {
+			if r.Position < 0 {
+				order++
+			}
+		}
+	case rule.Required:
+		position = "RULE_REQUIRED"
+	}
+	min, max := -1, -1
+	if rule.Min > 0 {
+		min = rule.Min
+	}
+	if rule.Max > 0 {
+		max = rule.Max
+	}
+	fmt.Fprintf(w, "\tCHECK_FCT(add_rule(%s, %d, %s, %s, %d, %d, %d));\n",
+		parent, vendorID, cQuote(rule.AVP), position, order, min, max)
+}
+
+func cFlags(flags []string) string {
+	if len(flags) == 0 {
+		return "0"
+	}
+	return strings.Join(flags, " | ")
+}
+
+// cQuote returns s as a C string literal.
+func cQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '"' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 0x20 || c >= 0x7f:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
@@ -0,0 +1,111 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package dict
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+func TestExportWireshark(t *testing.T) {
+	dir := t.TempDir()
+	var b bytes.Buffer
+	if err := Default.ExportWireshark(&b, 16777252); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "S13.xml"), b.Bytes(), 0600); err != nil {
+		t.Fatal(err)
+	}
+	dictionary := `<!DOCTYPE dictionary [<!ENTITY S13 SYSTEM "S13.xml">]><dictionary>&S13;</dictionary>`
+	if err := os.WriteFile(filepath.Join(dir, "dictionary.xml"), []byte(dictionary), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := NewParser()
+	if err := p.LoadWiresharkDir(dir); err != nil {
+		t.Fatalf("%v\n%s", err, b.String())
+	}
+
+	avp, err := p.FindAVPWithVendor(16777252, uint32(1445), 10415)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if avp.Name != "Equipment-Status" || avp.Data.Type != datatype.EnumeratedType ||
+		avp.Must != "V,M" || avp.MayEncrypt != "N" || len(avp.Data.Enum) != 3 {
+		t.Fatalf("Unexpected AVP: %+v", avp)
+	}
+	if avp, err = p.FindAVPWithVendor(16777252, "Terminal-Information", 10415); err != nil {
+		t.Fatal(err)
+	}
+	if avp.Data.Type != datatype.GroupedType || len(avp.Data.Rule) != 3 {
+		t.Fatalf("Unexpected grouped AVP: %+v", avp.Data.Rule)
+	}
+	cmd, err := p.FindCommand(16777252, 324)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCmd, _ := Default.FindCommand(16777252, 324)
+	want := make(map[string]*Rule)
+	for _, r := range wantCmd.Request.Rule {
+		if r.AVP != "AVP" {
+			want[r.AVP] = r
+		}
+	}
+	if len(cmd.Request.Rule) != len(want) {
+		t.Fatalf("Unexpected request rules: %+v", cmd.Request.Rule)
+	}
+	for _, r := range cmd.Request.Rule {
+		if w := want[r.AVP]; w == nil || r.Required != w.Required || r.Max != w.Max || r.Position != w.Position {
+			t.Fatalf("Unexpected rule %+v, want %+v", r, w)
+		}
+	}
+	if bytes.Contains(b.Bytes(), []byte("<gavp name=\"AVP\"")) {
+		t.Fatalf("Wildcard rule was exported:\n%s", b.String())
+	}
+}
+
+func TestExportFreeDiameter(t *testing.T) {
+	var b bytes.Buffer
+	if err := Default.ExportFreeDiameter(&b, "dict_s13", 16777252); err != nil {
+		t.Fatal(err)
+	}
+	out := b.String()
+	for _, want := range []string{
+		`struct dict_vendor_data data = { 10415, "TGPP" };`,
+		`{ 1445, 10415, "Equipment-Status", AVP_FLAG_VENDOR | AVP_FLAG_MANDATORY, AVP_FLAG_VENDOR | AVP_FLAG_MANDATORY, AVP_TYPE_INTEGER32 }`,
+		`"Enumerated(Equipment-Status)"`,
+		`{ "BLACKLISTED", { .i32 = 1 } }`,
+		`add_rule(avp, 10415, "IMEI", RULE_OPTIONAL, 0, -1, 1)`,
+		`"ME-Identity-Check-Request", CMD_FLAG_REQUEST | CMD_FLAG_PROXIABLE | CMD_FLAG_ERROR, CMD_FLAG_REQUEST | CMD_FLAG_PROXIABLE`,
+		`add_rule(cmd, 0, "Session-Id", RULE_FIXED_HEAD, 1, -1, 1)`,
+		`add_rule(cmd, 10415, "Terminal-Information", RULE_REQUIRED, 0, -1, 1)`,
+		`EXTENSION_ENTRY("dict_s13", dict_s13_entry);`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("Missing %s in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, `"AVP"`) {
+		t.Fatalf("Wildcard rule was exported:\n%s", out)
+	}
+}
+
+func TestExport_UnknownApp(t *testing.T) {
+	var b bytes.Buffer
+	if err := Default.ExportWireshark(&b, 1, 999); !errors.Is(err, ErrApplicationUnsupported) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := Default.ExportFreeDiameter(&b, "dict_x", 999); !errors.Is(err, ErrApplicationUnsupported) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := Default.ExportFreeDiameter(&b, "dict x", 1); err == nil {
+		t.Fatal("Invalid extension name was accepted")
+	}
+}
@@ -0,0 +1,264 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Dictionary linter.  Part of go-diameter.
+
+package dict
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Problem is a mistake found in a dictionary by Lint.
+type Problem struct {
+	Filename string // Empty for dictionaries loaded with Load
+	Line     int    // Line of the element at fault, 0 when unknown
+	Message  string
+}
+
+func (p *Problem) String() string {
+	return fmt.Sprintf("%s: %s", position(p.Filename, p.Line), p.Message)
+}
+
+func position(filename string, line int) string {
+	if len(filename) == 0 {
+		filename = "<input>"
+	}
+	return fmt.Sprintf("%s:%d", filename, line)
+}
+
+// Lint checks the dictionaries loaded in the Parser for mistakes that
+// Load accepts, and returns them ordered by dictionary and line:
+//
+//   - rules of commands and Grouped AVPs referencing unknown AVPs
+//   - AVPs with the same code and vendor but different types
+//   - Enumerated AVPs without items
+//   - Grouped AVPs without rules
+//   - commands with the same code but different names
+//
+// Rules may reference AVPs of the parent applications and of the base
+// application, so dictionaries extending others should be linted after
+// loading those.
+func (p *Parser) Lint() []*Problem {
+	ix := p.current()
+	l := &linter{
+		ix:  ix,
+		avp: make(map[codeIdx]lintDef),
+		cmd: make(map[uint32]lintDef),
+	}
+	for i, f := range ix.file {
+		l.file(ix.source[i], f)
+	}
+	sort.SliceStable(l.problems, func(i, j int) bool {
+		a, b := l.problems[i], l.problems[j]
+		if a.source != b.source {
+			return a.source < b.source
+		}
+		return a.Line < b.Line
+	})
+	problems := make([]*Problem, len(l.problems))
+	for i, lp := range l.problems {
+		problems[i] = &lp.Problem
+	}
+	return problems
+}
+
+type linter struct {
+	ix       *index
+	avp      map[codeIdx]lintDef // First AVP definitions by code and vendor
+	cmd      map[uint32]lintDef  // First command definitions by code
+	problems []*lintProblem
+	source   int      // Index of the dictionary being linted
+	filename string   // Its file name
+	pos      *lintPos // And the positions of its elements
+}
+
+type lintProblem struct {
+	Problem
+	source int
+}
+
+// lintDef is the first definition of an AVP or command code.
+type lintDef struct {
+	name     string
+	typeName string
+	filename string
+	line     int
+}
+
+func (l *linter) report(line int, format string, a ...interface{}) {
+	l.problems = append(l.problems, &lintProblem{
+		Problem: Problem{
+			Filename: l.filename,
+			Line:     line,
+			Message:  fmt.Sprintf(format, a...),
+		},
+		source: l.source,
+	})
+}
+
+func (l *linter) file(s source, f *File) {
+	l.source++
+	l.filename = s.filename
+	if len(l.filename) == 0 {
+		l.filename = s.name
+	}
+	l.pos = lintPositions(s.data)
+	for i, app := range f.App {
+		pos := l.pos.app(i)
+		for j, cmd := range app.Command {
+			l.command(app, cmd, pos.command(j))
+		}
+		for j, avp := range app.AVP {
+			l.avpDef(app, avp, pos.avp(j))
+		}
+	}
+}
+
+func (l *linter) command(app *App, cmd *Command, pos *lintCmdPos) {
+	if def, exist := l.cmd[cmd.Code]; !exist {
+		l.cmd[cmd.Code] = lintDef{name: cmd.Name, filename: l.filename, line: pos.line}
+	} else if def.name != cmd.Name {
+		l.report(pos.line, "command %s reuses code %d of command %s at %s",
+			cmd.Name, cmd.Code, def.name, position(def.filename, def.line))
+	}
+	for i, rule := range cmd.Request.Rule {
+		l.rule(app, rule, pos.request.line(i), cmd.Name+"-Request")
+	}
+	for i, rule := range cmd.Answer.Rule {
+		l.rule(app, rule, pos.answer.line(i), cmd.Name+"-Answer")
+	}
+}
+
+func (l *linter) avpDef(app *App, avp *AVP, pos *lintAVPPos) {
+	idx := codeIdx{code: avp.Code, vendorID: avp.VendorID}
+	if def, exist := l.avp[idx]; !exist {
+		l.avp[idx] = lintDef{name: avp.Name, typeName: avp.Data.TypeName, filename: l.filename, line: pos.line}
+	} else if def.typeName != avp.Data.TypeName {
+		l.report(pos.line, "AVP %s (code %d, vendor %d) is %s but %s at %s is %s",
+			avp.Name, avp.Code, avp.VendorID, avp.Data.TypeName, def.name, position(def.filename, def.line), def.typeName)
+	}
+	switch avp.Data.TypeName {
+	case "Enumerated":
+		if len(avp.Data.Enum) == 0 {
+			l.report(pos.line, "Enumerated AVP %s has no items", avp.Name)
+		}
+	case "Grouped":
+		if len(avp.Data.Rule) == 0 {
+			l.report(pos.line, "Grouped AVP %s has no rules", avp.Name)
+		}
+	}
+	for i, rule := range avp.Data.Rule {
+		l.rule(app, rule, pos.rule.line(i), avp.Name)
+	}
+}
+
+func (l *linter) rule(app *App, rule *Rule, line int, parent string) {
+	if rule.AVP == wildcardAVP {
+		return
+	}
+	if _, err := l.ix.findAVP(app.ID, rule.AVP, UndefinedVendorID); err != nil {
+		l.report(line, "rule of %s references unknown AVP %s in application %d",
+			parent, rule.AVP, app.ID)
+	}
+}
+
+// lintPos holds the lines of the elements of a dictionary, in the order
+// they are decoded into its File.
+type lintPos struct {
+	apps []*lintAppPos
+}
+
+type lintAppPos struct {
+	line int
+	cmds []*lintCmdPos
+	avps []*lintAVPPos
+}
+
+type lintCmdPos struct {
+	line            int
+	request, answer lintLines
+}
+
+type lintAVPPos struct {
+	line int
+	rule lintLines
+}
+
+type lintLines []int
+
+func (l lintLines) line(i int) int {
+	if i < len(l) {
+		return l[i]
+	}
+	return 0
+}
+
+func (p *lintPos) app(i int) *lintAppPos {
+	if i < len(p.apps) {
+		return p.apps[i]
+	}
+	return &lintAppPos{}
+}
+
+func (p *lintAppPos) command(i int) *lintCmdPos {
+	if i < len(p.cmds) {
+		return p.cmds[i]
+	}
+	return &lintCmdPos{}
+}
+
+func (p *lintAppPos) avp(i int) *lintAVPPos {
+	if i < len(p.avps) {
+		return p.avps[i]
+	}
+	return &lintAVPPos{}
+}
+
+// lintPositions walks the elements of the dictionary data and records
+// their lines. Malformed data stops the walk: it was accepted by Load.
+func lintPositions(data []byte) *lintPos {
+	pos := &lintPos{}
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var (
+		path []string // Names of the open elements
+		app  *lintAppPos
+		cmd  *lintCmdPos
+		avp  *lintAVPPos
+	)
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			return pos
+		}
+		switch t := tok.(type) {
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		case xml.StartElement:
+			line, _ := d.InputPos()
+			path = append(path, t.Name.Local)
+			switch strings.Join(path[1:], "/") {
+			case "application":
+				app = &lintAppPos{line: line}
+				pos.apps = append(pos.apps, app)
+			case "application/command":
+				cmd = &lintCmdPos{line: line}
+				app.cmds = append(app.cmds, cmd)
+			case "application/avp":
+				avp = &lintAVPPos{line: line}
+				app.avps = append(app.avps, avp)
+			case "application/command/request/rule":
+				cmd.request = append(cmd.request, line)
+			case "application/command/answer/rule":
+				cmd.answer = append(cmd.answer, line)
+			case "application/avp/data/rule":
+				avp.rule = append(avp.rule, line)
+			}
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package dict
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+var lintBase = `<diameter>
+<application id="0">
+	<command code="257" short="CE" name="Capabilities-Exchange">
+		<request><rule avp="Origin-Host" required="true" max="1"/></request>
+	</command>
+	<avp name="Origin-Host" code="264"><data type="DiameterIdentity"/></avp>
+</application>
+</diameter>`
+
+var lintDict = `<diameter>
+<application id="16777999" name="Example">
+	<command code="257" short="EX" name="Example">
+		<request>
+			<rule avp="Origin-Host" required="true" max="1"/>
+			<rule avp="Unknown-Thing" required="false" max="1"/>
+		</request>
+		<answer>
+			<rule avp="AVP" required="false"/>
+		</answer>
+	</command>
+	<avp name="Origin-Host" code="264"><data type="OctetString"/></avp>
+	<avp name="Example-Status" code="5001" vendor-id="99999">
+		<data type="Enumerated"/>
+	</avp>
+	<avp name="Example-Group" code="5002" vendor-id="99999">
+		<data type="Grouped"/>
+	</avp>
+	<avp name="Example-Info" code="5003" vendor-id="99999">
+		<data type="Grouped">
+			<rule avp="Example-Status" required="false" max="1"/>
+			<rule avp="Example-Missing" required="false" max="1"/>
+		</data>
+	</avp>
+</application>
+</diameter>`
+
+func TestParser_Lint(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "example.xml")
+	if err := os.WriteFile(name, []byte(lintDict), 0600); err != nil {
+		t.Fatal(err)
+	}
+	p, _ := NewParser()
+	if err := p.Load(bytes.NewReader([]byte(lintBase))); err != nil {
+		t.Fatal(err)
+	}
+	if problems := p.Lint(); len(problems) != 0 {
+		t.Fatalf("Unexpected problems: %v", problems)
+	}
+	if err := p.LoadFile(name); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		name + ":3: command Example reuses code 257 of command Capabilities-Exchange at <input>:3",
+		name + ":6: rule of Example-Request references unknown AVP Unknown-Thing in application 16777999",
+		name + ":12: AVP Origin-Host (code 264, vendor 0) is OctetString but Origin-Host at <input>:6 is DiameterIdentity",
+		name + ":13: Enumerated AVP Example-Status has no items",
+		name + ":16: Grouped AVP Example-Group has no rules",
+		name + ":22: rule of Example-Info references unknown AVP Example-Missing in application 16777999",
+	}
+	problems := p.Lint()
+	if len(problems) != len(want) {
+		t.Fatalf("Unexpected problems: %v", problems)
+	}
+	for i, problem := range problems {
+		if s := fmt.Sprint(problem); s != want[i] {
+			t.Fatalf("Unexpected problem %d:\n%s\nwant:\n%s", i, s, want[i])
+		}
+	}
+}
+
+// lintLineNumber matches the line numbers of the definitions referenced
+// by problems.
+var lintLineNumber = regexp.MustCompile(`(\.xml):[0-9]+`)
+
+// TestDefault_Lint checks the problems of the Default dictionaries
+// against the list in testdata/default_lint.txt, without line numbers.
+func TestDefault_Lint(t *testing.T) {
+	b, err := os.ReadFile("testdata/default_lint.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	known := make(map[string]int)
+	for _, line := range strings.Split(string(b), "\n") {
+		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			known[line]++
+		}
+	}
+	for _, problem := range Default.Lint() {
+		if len(problem.Filename) == 0 {
+			t.Fatalf("Problem without file name: %s", problem)
+		}
+		s := problem.Filename + ": " + lintLineNumber.ReplaceAllString(problem.Message, "$1")
+		if known[s] == 0 {
+			t.Errorf("New problem: %s", problem)
+			continue
+		}
+		known[s]--
+	}
+	for s, n := range known {
+		if n > 0 {
+			t.Errorf("Fixed problem, remove it from testdata/default_lint.txt: %s", s)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"io"
 	"os"
 	"sync"
+	"sync/atomic"
 
 	"github.com/fiorix/go-diameter/v4/diam/datatype"
 )
@@ -31,15 +32,13 @@ const (
 // multiple applications that are composed by multiple AVPs.
 //
 // The Parser element has an index to make pre-loaded AVPs searcheable per App.
+// The index is an immutable snapshot replaced atomically by each Load and
+// Reload, so lookups never block and may run concurrently with loading:
+// they see the dictionaries either before or after the load, never a
+// partially loaded one.
 type Parser struct {
-	file    []*File               // Dict supports multiple XML dictionaries
-	appcode map[uint32]*App       // Application index by code
-	apptype map[appIdTypeIdx]*App // Application index by code and type
-	avpname map[nameIdx]*AVP      // AVP index by name
-	avpcode map[codeIdx]*AVP      // AVP index by code
-	command map[codeIdx]*Command  // Command index
-	mu      sync.Mutex            // Protects all maps
-	once    sync.Once
+	mu    sync.Mutex            // Serializes Load and Reload
+	index atomic.Pointer[index] // Current snapshot, nil until first used
 
 	// Strict indicates whether an error should be returned when one  or more
 	// AVPs are invalid/empty and cannot be properly decoded.
@@ -48,6 +47,38 @@ type Parser struct {
 	// parsing process will be stored in the Message's DecodeErr field which is
 	// accessible from a request handler.
 	Strict bool
+
+	// CheckPositions indicates whether decoded messages with AVPs out of
+	// the fixed positions of their command, such as a Session-Id not
+	// following the header, are reported as decoding errors. Those are
+	// handled according to Strict.
+	//
+	// Defaults to false, accepting them from peers that don't place them.
+	// Messages are always serialized with the fixed AVPs in place.
+	CheckPositions bool
+}
+
+// index is a snapshot of the loaded dictionaries. It is never modified
+// after being published by the Parser.
+type index struct {
+	file    []*File               // Dict supports multiple XML dictionaries
+	source  []source              // Dictionaries loaded, in order
+	appcode map[uint32]*App       // Application index by code
+	apptype map[appIdTypeIdx]*App // Application index by code and type
+	avpname map[nameIdx]*AVP      // AVP index by name
+	avpcode map[codeIdx]*AVP      // AVP index by code
+	command map[codeIdx]*Command  // Command index
+	parent  map[uint32]uint32     // Parent application index by code
+	cache   sync.Map              // Values derived from the snapshot, see Cache
+}
+
+// source is a dictionary loaded in a Parser, and the name of its file
+// when loaded with LoadFile or Reload. Embedded dictionaries, which
+// Reload keeps, only have a name to report problems with.
+type source struct {
+	filename string
+	name     string
+	data     []byte
 }
 
 type codeIdx struct {
@@ -67,6 +98,50 @@ type appIdTypeIdx struct {
 	typ   string
 }
 
+func newIndex() *index {
+	return &index{
+		appcode: make(map[uint32]*App),
+		apptype: make(map[appIdTypeIdx]*App),
+		avpname: make(map[nameIdx]*AVP),
+		avpcode: make(map[codeIdx]*AVP),
+		command: make(map[codeIdx]*Command),
+		parent:  make(map[uint32]uint32),
+	}
+}
+
+// clone returns a copy of ix that can be modified without affecting it.
+func (ix *index) clone() *index {
+	c := &index{
+		file:    append([]*File(nil), ix.file...),
+		source:  append([]source(nil), ix.source...),
+		appcode: make(map[uint32]*App, len(ix.appcode)),
+		apptype: make(map[appIdTypeIdx]*App, len(ix.apptype)),
+		avpname: make(map[nameIdx]*AVP, len(ix.avpname)),
+		avpcode: make(map[codeIdx]*AVP, len(ix.avpcode)),
+		command: make(map[codeIdx]*Command, len(ix.command)),
+		parent:  make(map[uint32]uint32, len(ix.parent)),
+	}
+	for k, v := range ix.appcode {
+		c.appcode[k] = v
+	}
+	for k, v := range ix.apptype {
+		c.apptype[k] = v
+	}
+	for k, v := range ix.avpname {
+		c.avpname[k] = v
+	}
+	for k, v := range ix.avpcode {
+		c.avpcode[k] = v
+	}
+	for k, v := range ix.command {
+		c.command[k] = v
+	}
+	for k, v := range ix.parent {
+		c.parent[k] = v
+	}
+	return c
+}
+
 // NewParser allocates a new Parser optionally loading dictionary XML files.
 func NewParser(filename ...string) (*Parser, error) {
 	p := new(Parser)
@@ -80,55 +155,121 @@ func NewParser(filename ...string) (*Parser, error) {
 	return p, nil
 }
 
+// current returns the current snapshot of p.
+func (p *Parser) current() *index {
+	if ix := p.index.Load(); ix != nil {
+		return ix
+	}
+	// Each Parser gets its own empty snapshot, and so its own Cache.
+	p.index.CompareAndSwap(nil, newIndex())
+	return p.index.Load()
+}
+
+// Cache returns a map for values derived from the current dictionaries of
+// p, such as the compiled struct plans of Message.Marshal. Each Load and
+// Reload starts with an empty map, so the values are never stale. Keys
+// should be of unexported types, to avoid clashes between packages.
+func (p *Parser) Cache() *sync.Map {
+	return &p.current().cache
+}
+
 // LoadFile loads a dictionary XML file. May be used multiple times.
+// The file can be re-read later with Reload.
 func (p *Parser) LoadFile(filename string) error {
-	fd, err := os.Open(filename)
+	b, err := os.ReadFile(filename)
 	if err != nil {
 		return err
 	}
-	defer fd.Close()
-	return p.Load(fd)
+	return p.load(source{filename: filename, data: b})
 }
 
 // Load loads a dictionary from byte array. May be used multiple times.
 func (p *Parser) Load(r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	return p.load(source{data: b})
+}
+
+// load publishes a new snapshot with the dictionary s added, or leaves
+// the current one untouched when s is invalid.
+func (p *Parser) load(s source) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ix := p.current().clone()
+	if err := ix.load(s); err != nil {
+		return err
+	}
+	p.index.Store(ix)
+	return nil
+}
+
+// Reload replaces the dictionary files loaded with LoadFile, or by a
+// previous Reload, with the given files, keeping the dictionaries loaded
+// with Load. The new set is validated before being published: on error
+// the Parser is left untouched. Lookups made after Reload returns, such
+// as those decoding the next messages of existing connections, use the
+// new dictionaries.
+func (p *Parser) Reload(filename ...string) error {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	p.once.Do(func() {
-		p.appcode = make(map[uint32]*App)
-		p.apptype = make(map[appIdTypeIdx]*App)
-		p.avpname = make(map[nameIdx]*AVP)
-		p.avpcode = make(map[codeIdx]*AVP)
-		p.command = make(map[codeIdx]*Command)
-	})
+	ix := newIndex()
+	for _, s := range p.current().source {
+		if len(s.filename) > 0 {
+			continue
+		}
+		if err := ix.load(s); err != nil {
+			return err
+		}
+	}
+	for _, name := range filename {
+		b, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err = ix.load(source{filename: name, data: b}); err != nil {
+			return fmt.Errorf("%s: %v", name, err)
+		}
+	}
+	p.index.Store(ix)
+	return nil
+}
+
+// load parses and indexes the dictionary s into ix.
+func (ix *index) load(s source) error {
 	f := new(File)
-	d := xml.NewDecoder(r)
+	d := xml.NewDecoder(bytes.NewReader(s.data))
 	if err := d.Decode(f); err != nil {
 		return err
 	}
-	p.file = append(p.file, f)
+	if err := ix.linkParents(f); err != nil {
+		return err
+	}
+	ix.file = append(ix.file, f)
+	ix.source = append(ix.source, s)
 	for _, app := range f.App {
 		// Cache supported applications by ID.
-		p.appcode[app.ID] = app
-		p.apptype[appIdTypeIdx{app.ID, app.Type}] = app
+		ix.appcode[app.ID] = app
+		ix.apptype[appIdTypeIdx{app.ID, app.Type}] = app
 		// Cache commands.
 		for _, cmd := range app.Command {
 			idx := codeIdx{app.ID, cmd.Code, UndefinedVendorID}
-			_, exist := p.command[idx]
+			_, exist := ix.command[idx]
 			if exist {
 				return fmt.Errorf("Command: %s cannot be added: index exists", cmd)
 			}
-			p.command[idx] = cmd
+			ix.command[idx] = cmd
 		}
 		// Cache AVPs.
 		for _, avp := range app.AVP {
 			// Link AVP to its Application
 			avp.App = app
-			p.avpname[nameIdx{app.ID, avp.Name, avp.VendorID}] = avp
-			p.avpcode[codeIdx{app.ID, avp.Code, avp.VendorID}] = avp
+			ix.avpname[nameIdx{app.ID, avp.Name, avp.VendorID}] = avp
+			ix.avpcode[codeIdx{app.ID, avp.Code, avp.VendorID}] = avp
 			// Index without vendorId
-			p.avpname[nameIdx{app.ID, avp.Name, UndefinedVendorID}] = avp
-			p.avpcode[codeIdx{app.ID, avp.Code, UndefinedVendorID}] = avp
+			ix.avpname[nameIdx{app.ID, avp.Name, UndefinedVendorID}] = avp
+			ix.avpcode[codeIdx{app.ID, avp.Code, UndefinedVendorID}] = avp
 			// Check the AVP type.
 			if err := updateType(avp); err != nil {
 				return err
@@ -137,7 +278,43 @@ func (p *Parser) Load(r io.Reader) error {
 	}
 	// Pre-merge inherited AVPs so that lookups for child apps resolve in a
 	// single map access instead of walking the parent chain at runtime.
-	p.mergeInheritedAVPs()
+	ix.mergeInheritedAVPs()
+	return nil
+}
+
+// linkParents records the parent applications declared in f, failing
+// when they conflict with the ones already loaded or form a loop.
+// Applications of parentAppIds without a parent attribute get their
+// default parent.
+func (ix *index) linkParents(f *File) error {
+	parent := make(map[uint32]uint32, len(ix.parent))
+	for id, pid := range ix.parent {
+		parent[id] = pid
+	}
+	for _, app := range f.App {
+		if app.Parent == 0 {
+			if pid, ok := parentAppIds[app.ID]; ok {
+				if _, exist := parent[app.ID]; !exist {
+					parent[app.ID] = pid
+				}
+			}
+			continue
+		}
+		if pid, exist := parent[app.ID]; exist && pid != app.Parent {
+			return fmt.Errorf("Application %d cannot inherit from %d: already inherits from %d", app.ID, app.Parent, pid)
+		}
+		parent[app.ID] = app.Parent
+	}
+	for id := range parent {
+		seen := map[uint32]bool{id: true}
+		for cur := parent[id]; cur != 0; cur = parent[cur] {
+			if seen[cur] {
+				return fmt.Errorf("Application %d cannot be added: inheritance loop through %d", id, cur)
+			}
+			seen[cur] = true
+		}
+	}
+	ix.parent = parent
 	return nil
 }
 
@@ -145,12 +322,13 @@ func (p *Parser) Load(r io.Reader) error {
 // each child application's index. This eliminates the runtime fallback
 // loop in FindAVPByCode: every lookup becomes a single map access.
 //
-// For each application that has a parent chain (via parentAppIds) or
+// For each application that has a parent chain (via the parent attribute
+// of its declaration) or
 // inherits from the base app (id=0), entries are copied only if the
 // child does not already define an AVP with the same code and vendorID.
 //
 // The iteration uses a pre-computed per-app snapshot of the index rather
-// than ranging over the live p.avpcode / p.avpname maps, so writes made
+// than ranging over the live ix.avpcode / ix.avpname maps, so writes made
 // during the merge cannot affect iteration order or content within a
 // single call. Note that on repeated Load calls the snapshot reads the
 // current live index, which already contains entries synthesized by
@@ -160,20 +338,20 @@ func (p *Parser) Load(r io.Reader) error {
 //
 // Memory: base AVPs are duplicated per child app at Load time,
 // not per message; bounded by dictionary size.
-func (p *Parser) mergeInheritedAVPs() {
+func (ix *index) mergeInheritedAVPs() {
 	// Collect every declared application so apps that inherit all their
 	// AVPs from an ancestor (and define none of their own) are still
 	// processed. Also include any appIDs that only appear as AVP owners.
-	apps := make(map[uint32]bool, len(p.appcode))
-	for appID := range p.appcode {
+	apps := make(map[uint32]bool, len(ix.appcode))
+	for appID := range ix.appcode {
 		apps[appID] = true
 	}
-	for idx := range p.avpcode {
+	for idx := range ix.avpcode {
 		apps[idx.appID] = true
 	}
 
 	// Snapshot the current index grouped by owning appID. Subsequent
-	// writes to p.avpcode / p.avpname during the merge will not appear
+	// writes to ix.avpcode / ix.avpname during the merge will not appear
 	// in these snapshots, so iteration order and content are stable.
 	type codeEntry struct {
 		idx codeIdx
@@ -184,11 +362,11 @@ func (p *Parser) mergeInheritedAVPs() {
 		avp *AVP
 	}
 	codeByApp := make(map[uint32][]codeEntry, len(apps))
-	for idx, avp := range p.avpcode {
+	for idx, avp := range ix.avpcode {
 		codeByApp[idx.appID] = append(codeByApp[idx.appID], codeEntry{idx, avp})
 	}
 	nameByApp := make(map[uint32][]nameEntry, len(apps))
-	for idx, avp := range p.avpname {
+	for idx, avp := range ix.avpname {
 		nameByApp[idx.appID] = append(nameByApp[idx.appID], nameEntry{idx, avp})
 	}
 
@@ -201,7 +379,7 @@ func (p *Parser) mergeInheritedAVPs() {
 		var ancestors []uint32
 		cur := appID
 		for {
-			parent, hasParent := parentAppIds[cur]
+			parent, hasParent := ix.parent[cur]
 			if hasParent {
 				ancestors = append(ancestors, parent)
 				cur = parent
@@ -217,14 +395,14 @@ func (p *Parser) mergeInheritedAVPs() {
 		for _, ancestorID := range ancestors {
 			for _, e := range codeByApp[ancestorID] {
 				childIdx := codeIdx{appID, e.idx.code, e.idx.vendorID}
-				if _, exists := p.avpcode[childIdx]; !exists {
-					p.avpcode[childIdx] = e.avp
+				if _, exists := ix.avpcode[childIdx]; !exists {
+					ix.avpcode[childIdx] = e.avp
 				}
 			}
 			for _, e := range nameByApp[ancestorID] {
 				childIdx := nameIdx{appID, e.idx.name, e.idx.vendorID}
-				if _, exists := p.avpname[childIdx]; !exists {
-					p.avpname[childIdx] = e.avp
+				if _, exists := ix.avpname[childIdx]; !exists {
+					ix.avpname[childIdx] = e.avp
 				}
 			}
 		}
@@ -243,7 +421,7 @@ func updateType(a *AVP) error {
 // String returns the Parser represented in a human readable form.
 func (p *Parser) String() string {
 	var b bytes.Buffer
-	for _, f := range p.file {
+	for _, f := range p.current().file {
 		for _, app := range f.App {
 			fmt.Fprintf(&b, "Application Id: %d\n", app.ID)
 			fmt.Fprintf(&b, "\tVendors:\n")
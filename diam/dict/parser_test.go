@@ -6,6 +6,10 @@ package dict
 
 import (
 	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
 )
 
@@ -49,3 +53,187 @@ func TestLoad(t *testing.T) {
 		}
 	}
 }
+
+func TestLoad_Inheritance(t *testing.T) {
+	p, err := NewParser("./testdata/base.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = p.Load(strings.NewReader(`<diameter>
+  <application id="16777300" parent="16777299" name="Child" />
+  <application id="16777299" name="Parent">
+    <avp name="Parent-AVP" code="9999" must="M" may="P" must-not="V" may-encrypt="N">
+      <data type="Unsigned32" />
+    </avp>
+  </application>
+</diameter>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, code := range []interface{}{uint32(9999), "Parent-AVP", "Session-Id"} {
+		if _, err = p.FindAVP(16777300, code); err != nil {
+			t.Fatalf("Inherited AVP %v not found: %v", code, err)
+		}
+	}
+	if _, err = p.FindAVPByCode(16777300, 9999, 0); err != nil {
+		t.Fatalf("Inherited AVP not merged: %v", err)
+	}
+	if _, err = p.FindAVP(16777299, "Parent-AVP"); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, xml := range map[string]string{
+		"loop":     `<diameter><application id="16777299" parent="16777300" name="Parent" /></diameter>`,
+		"self":     `<diameter><application id="16777301" parent="16777301" name="Self" /></diameter>`,
+		"conflict": `<diameter><application id="16777300" parent="4" name="Child" /></diameter>`,
+	} {
+		if err = p.Load(strings.NewReader(xml)); err == nil {
+			t.Fatalf("Invalid %s inheritance was accepted", name)
+		}
+	}
+	// Failed loads leave the hierarchy unchanged.
+	if _, err = p.FindAVP(16777300, "Parent-AVP"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestLoad_DefaultParent checks that the well-known applications inherit
+// from their default parents when loaded without the parent attribute.
+func TestLoad_DefaultParent(t *testing.T) {
+	p, err := NewParser("./testdata/base.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = p.Load(strings.NewReader(`<diameter>
+  <application id="4" name="Charging Control">
+    <avp name="CC-Request-Number" code="415" must="M" may="P" must-not="V" may-encrypt="Y">
+      <data type="Unsigned32" />
+    </avp>
+  </application>
+  <application id="16777251" name="TGPP S6A" />
+</diameter>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.FindAVP(16777251, "CC-Request-Number"); err != nil {
+		t.Fatalf("AVP of the default parent not found: %v", err)
+	}
+	if _, err = p.FindAVPByCode(16777251, 415, 0); err != nil {
+		t.Fatalf("AVP of the default parent not merged: %v", err)
+	}
+}
+
+func testVendorDict(name string, code int) string {
+	return `<diameter>
+  <application id="16777299" name="Roaming">
+    <avp name="` + name + `" code="` + strconv.Itoa(code) + `" must="M" may="P" must-not="V" may-encrypt="N">
+      <data type="Unsigned32" />
+    </avp>
+  </application>
+</diameter>`
+}
+
+func TestParser_Reload(t *testing.T) {
+	base, err := os.ReadFile("./testdata/base.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, _ := NewParser()
+	if err = p.Load(strings.NewReader(string(base))); err != nil {
+		t.Fatal(err)
+	}
+	name := filepath.Join(t.TempDir(), "roaming.xml")
+	if err = os.WriteFile(name, []byte(testVendorDict("Partner-A", 9001)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = p.LoadFile(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.FindAVP(16777299, "Partner-A"); err != nil {
+		t.Fatal(err)
+	}
+	type cacheKey struct{}
+	var empty Parser
+	empty.Cache().Store(cacheKey{}, "empty")
+	if _, ok := new(Parser).Cache().Load(cacheKey{}); ok {
+		t.Fatal("Empty parsers share their cache")
+	}
+	p.Cache().Store(cacheKey{}, "Partner-A")
+	if v, _ := p.Cache().Load(cacheKey{}); v != "Partner-A" {
+		t.Fatalf("Unexpected cached value: %v", v)
+	}
+
+	if err = os.WriteFile(name, []byte(testVendorDict("Partner-B", 9002)), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = p.Reload(name); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.Cache().Load(cacheKey{}); ok {
+		t.Fatal("Reload kept a cached value")
+	}
+	if _, err = p.FindAVP(16777299, "Partner-A"); err == nil {
+		t.Fatal("Reloaded dictionary kept a removed AVP")
+	}
+	if _, err = p.FindAVP(16777299, "Partner-B"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.FindAVP(16777299, "Session-Id"); err != nil {
+		t.Fatalf("Reload dropped the base dictionary: %v", err)
+	}
+
+	// An invalid set is not published.
+	if err = os.WriteFile(name, []byte("<diameter><application"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = p.Reload(name); err == nil {
+		t.Fatal("Invalid dictionary was reloaded")
+	}
+	if _, err = p.FindAVP(16777299, "Partner-B"); err != nil {
+		t.Fatal(err)
+	}
+	if err = p.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if n := len(p.Apps()); n != 2 {
+		t.Fatalf("Unexpected number of apps after reload: %d", n)
+	}
+}
+
+func TestParser_ConcurrentLoad(t *testing.T) {
+	p, err := NewParser("./testdata/base.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if _, err := p.FindAVPByCode(0, 263, UndefinedVendorID); err != nil {
+					t.Error(err)
+					return
+				}
+				p.FindAVP(16777299, "Partner-A")
+				p.Apps()
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		if err = p.Load(strings.NewReader(testVendorDict("Partner-A", 9001))); err != nil {
+			t.Fatal(err)
+		}
+		if err = p.Reload("./testdata/base.xml"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(done)
+	wg.Wait()
+}
@@ -21,12 +21,13 @@ type File struct {
 
 // App defines a diameter application in XML and its multiple AVPs.
 type App struct {
-	ID      uint32     `xml:"id,attr"`   // Application Id
-	Type    string     `xml:"type,attr"` // Application type
-	Name    string     `xml:"name,attr"` // Application name
-	Vendor  []*Vendor  `xml:"vendor"`    // Support for multiple vendors
-	Command []*Command `xml:"command"`   // Diameter commands
-	AVP     []*AVP     `xml:"avp"`       // Each application support multiple AVPs
+	ID      uint32     `xml:"id,attr"`     // Application Id
+	Type    string     `xml:"type,attr"`   // Application type
+	Name    string     `xml:"name,attr"`   // Application name
+	Parent  uint32     `xml:"parent,attr"` // Application Id AVPs are inherited from, see parentAppIds
+	Vendor  []*Vendor  `xml:"vendor"`      // Support for multiple vendors
+	Command []*Command `xml:"command"`     // Diameter commands
+	AVP     []*AVP     `xml:"avp"`         // Each application support multiple AVPs
 }
 
 // Vendor defines diameter vendors in XML, that can be used to translate
@@ -92,4 +93,8 @@ type Rule struct {
 	Required bool   `xml:"required,attr"`
 	Min      int    `xml:"min,attr"`
 	Max      int    `xml:"max,attr"`
+	// Position of fixed AVPs, such as < Session-Id > in CCF: counted
+	// from 1 at the head of the message, or from -1 at its tail. Zero
+	// for AVPs that may appear anywhere.
+	Position int `xml:"position,attr,omitempty"`
 }
@@ -13,21 +13,22 @@ import (
 	"github.com/fiorix/go-diameter/v4/diam/datatype"
 )
 
-// parentAppIds map allows for hierarchical AVP search dependencies
-// If an AVP code was not found in the app's dictionary, the search will continue in the parent app
-// dictionary and only then in base diameter dictionary
-// Note: care must be taken to avoid creating parent-child loops
-var parentAppIds map[uint32]uint32 = map[uint32]uint32{
+// parentAppIds holds the parents of the applications that inherited AVPs
+// before dictionaries could declare it with the parent attribute. They
+// are used for applications loaded without that attribute.
+var parentAppIds = map[uint32]uint32{
 	16777251: 4,
 	16777238: 4,
 	4:        1,
+	5:        1,
+	16777250: 5,
+	16777264: 16777250,
 }
 
 // Apps return a list of all applications loaded in the Parser object.
-// Apps must never be called concurrently with LoadFile or Load.
 func (p *Parser) Apps() []*App {
 	var apps []*App
-	for _, f := range p.file {
+	for _, f := range p.current().file {
 		for _, app := range f.App {
 			apps = append(apps, app)
 		}
@@ -36,16 +37,17 @@ func (p *Parser) Apps() []*App {
 }
 
 // App returns a dictionary application for the given application code
-// if exists. App must never be called concurrently with LoadFile or Load.
+// if exists.
 func (p *Parser) App(code uint32, typ ...string) (*App, error) {
+	ix := p.current()
 	var app *App
 	if len(typ) > 0 {
-		app = p.apptype[appIdTypeIdx{code, typ[0]}]
+		app = ix.apptype[appIdTypeIdx{code, typ[0]}]
 	}
 	if app != nil {
 		return app, nil
 	}
-	app = p.appcode[code]
+	app = ix.appcode[code]
 	if app != nil && (len(app.Type) == 0 || len(typ) == 0 || app.Type == typ[0]) {
 		return app, nil
 	}
@@ -74,12 +76,17 @@ func MakeUnknownAVP(appid, code, vendorID uint32) *AVP {
 
 // FindAVPWithVendor is a helper function that returns a pre-loaded AVP from the Parser, considering vendorID as filter.
 // For no vendorID filter, use UndefinedVendorID constant
-// If the AVP code is not found for the given appid it tries with appid=0
+// If the AVP code is not found for the given appid it tries with its parent
+// applications, as declared in the dictionary or by default for the
+// well-known applications, and then with appid=0
 // before returning an error.
 // Code can be either the AVP code (int, uint32) or name (string).
-//
-// FindAVPWithVendor must never be called concurrently with LoadFile or Load.
 func (p *Parser) FindAVPWithVendor(appid uint32, code interface{}, vendorID uint32) (*AVP, error) {
+	return p.current().findAVP(appid, code, vendorID)
+}
+
+// findAVP implements FindAVPWithVendor on a single snapshot.
+func (ix *index) findAVP(appid uint32, code interface{}, vendorID uint32) (*AVP, error) {
 	var (
 		avp *AVP
 		ok  bool
@@ -89,17 +96,17 @@ func (p *Parser) FindAVPWithVendor(appid uint32, code interface{}, vendorID uint
 retry:
 	switch codeVal := code.(type) {
 	case string:
-		avp, ok = p.avpname[nameIdx{appid, codeVal, vendorID}]
+		avp, ok = ix.avpname[nameIdx{appid, codeVal, vendorID}]
 		if !ok && appid == 0 {
 			err = fmt.Errorf("Could not find AVP %T(%q) for Vendor: %d", codeVal, codeVal, vendorID)
 		}
 	case uint32:
-		avp, ok = p.avpcode[codeIdx{appid, codeVal, vendorID}]
+		avp, ok = ix.avpcode[codeIdx{appid, codeVal, vendorID}]
 		if !ok && appid == 0 {
 			err = fmt.Errorf("Could not find AVP %T(%d) for Vendor: %d", codeVal, codeVal, vendorID)
 		}
 	case int:
-		avp, ok = p.avpcode[codeIdx{appid, uint32(codeVal), vendorID}]
+		avp, ok = ix.avpcode[codeIdx{appid, uint32(codeVal), vendorID}]
 		if !ok && appid == 0 {
 			err = fmt.Errorf("Could not find AVP %T(%d) for Vendor: %d", codeVal, codeVal, vendorID)
 		}
@@ -109,7 +116,7 @@ retry:
 	if ok {
 		return avp, nil
 	} else if appid != 0 {
-		parentAppId, isScoppedApp := parentAppIds[appid]
+		parentAppId, isScoppedApp := ix.parent[appid]
 		if isScoppedApp {
 			// Try searching 'parent' dictionary
 			appid = parentAppId
@@ -121,7 +128,7 @@ retry:
 	} else {
 		if codeU32, isUint32 := code.(uint32); isUint32 {
 			if vendorID != UndefinedVendorID {
-				avp, err = p.FindAVP(origAppID, codeU32)
+				avp, err = ix.findAVP(origAppID, codeU32, UndefinedVendorID)
 				if err == nil {
 					return avp, nil
 				}
@@ -139,12 +146,10 @@ retry:
 //
 // Because inherited AVPs are pre-merged into child app indices at load time,
 // this method resolves most lookups with a single map access.
-//
-// FindAVPByCode must never be called concurrently with LoadFile or Load.
 func (p *Parser) FindAVPByCode(appid, code, vendorID uint32) (*AVP, error) {
 	// Exact (appid, code, vendorID) match; inherited AVPs are pre-merged by mergeInheritedAVPs().
 	// An absent vendor AVP resolves to Unknown, never cross-vendor (RFC 6733 §4.1/§11.1.1).
-	if avp, ok := p.avpcode[codeIdx{appid, code, vendorID}]; ok {
+	if avp, ok := p.current().avpcode[codeIdx{appid, code, vendorID}]; ok {
 		return avp, nil
 	}
 	return MakeUnknownAVP(appid, code, vendorID),
@@ -155,8 +160,6 @@ func (p *Parser) FindAVPByCode(appid, code, vendorID uint32) (*AVP, error) {
 // If the AVP code is not found for the given appid it tries with appid=0
 // before returning an error.
 // Code can be either the AVP code (int, uint32) or name (string).
-//
-// FindAVP must never be called concurrently with LoadFile or Load.
 func (p *Parser) FindAVP(appid uint32, code interface{}) (*AVP, error) {
 	return p.FindAVPWithVendor(appid, code, UndefinedVendorID)
 }
@@ -167,28 +170,25 @@ func (p *Parser) FindAVP(appid uint32, code interface{}) (*AVP, error) {
 //
 // ScanAVP is 20x or more slower than FindAVP. Use with care.
 // Code can be either the AVP code (uint32) or name (string).
-//
-// ScanAVP must never be called concurrently with LoadFile or Load.
 func (p *Parser) ScanAVP(code interface{}) (*AVP, error) {
-	//p.mu.Lock()
-	//defer p.mu.Unlock()
+	ix := p.current()
 	switch code.(type) {
 	case string:
-		for idx, avp := range p.avpname {
+		for idx, avp := range ix.avpname {
 			if idx.name == code.(string) {
 				return avp, nil
 			}
 		}
 		return nil, fmt.Errorf("Could not find AVP %s", code.(string))
 	case uint32:
-		for idx, avp := range p.avpcode {
+		for idx, avp := range ix.avpcode {
 			if idx.code == code.(uint32) {
 				return avp, nil
 			}
 		}
 		return nil, fmt.Errorf("Could not find AVP code %d", code.(uint32))
 	case int:
-		for idx, avp := range p.avpcode {
+		for idx, avp := range ix.avpcode {
 			if idx.code == uint32(code.(int)) {
 				return avp, nil
 			}
@@ -199,14 +199,11 @@ func (p *Parser) ScanAVP(code interface{}) (*AVP, error) {
 }
 
 // FindCommand returns a pre-loaded Command from the Parser.
-//
-// FindCommand must never be called concurrently with LoadFile or Load.
 func (p *Parser) FindCommand(appid, code uint32) (*Command, error) {
-	//p.mu.Lock()
-	//defer p.mu.Unlock()
-	if cmd, ok := p.command[codeIdx{appid, code, UndefinedVendorID}]; ok {
+	ix := p.current()
+	if cmd, ok := ix.command[codeIdx{appid, code, UndefinedVendorID}]; ok {
 		return cmd, nil
-	} else if cmd, ok = p.command[codeIdx{0, code, UndefinedVendorID}]; ok {
+	} else if cmd, ok = ix.command[codeIdx{0, code, UndefinedVendorID}]; ok {
 		// Always fall back to base dict.
 		return cmd, nil
 	}
@@ -215,8 +212,6 @@ func (p *Parser) FindCommand(appid, code uint32) (*Command, error) {
 
 // Enum is a helper function that returns a pre-loaded Enum item for the
 // given AVP appid, code and n. (n is the enum code in the dictionary)
-//
-// Enum must never be called concurrently with LoadFile or Load.
 func (p *Parser) Enum(appid, code uint32, n int32) (*Enum, error) {
 	avp, err := p.FindAVP(appid, code)
 	if err != nil {
@@ -239,8 +234,6 @@ func (p *Parser) Enum(appid, code uint32, n int32) (*Enum, error) {
 
 // Rule is a helper function that returns a pre-loaded Rule item for the
 // given AVP code and name.
-//
-// Rule must never be called concurrently with LoadFile or Load.
 func (p *Parser) Rule(appid, code uint32, n string) (*Rule, error) {
 	avp, err := p.FindAVP(appid, code)
 	if err != nil {
@@ -13,8 +13,8 @@ import (
 
 func TestApps(t *testing.T) {
 	apps := Default.Apps()
-	if len(apps) != 10 {
-		t.Fatalf("Unexpected # of apps. Want 10, have %d", len(apps))
+	if len(apps) != 16 {
+		t.Fatalf("Unexpected # of apps. Want 16, have %d", len(apps))
 	}
 	// Base protocol.
 	if apps[0].ID != 0 {
@@ -51,6 +51,26 @@ func TestApps(t *testing.T) {
 	if apps[9].ID != 16777265 {
 		t.Fatalf("Unexpected app.ID. Want 16777265, have %d", apps[9].ID)
 	}
+	// 3GPP Sy application
+	if apps[10].ID != 16777302 {
+		t.Fatalf("Unexpected app.ID. Want 16777302, have %d", apps[10].ID)
+	}
+	// 3GPP Cx/Dx application
+	if apps[11].ID != 16777216 {
+		t.Fatalf("Unexpected app.ID. Want 16777216, have %d", apps[11].ID)
+	}
+	if apps[12].ID != 16777217 {
+		t.Fatalf("Unexpected app.ID. Want 16777217, have %d", apps[12].ID)
+	}
+	if apps[13].ID != 5 {
+		t.Fatalf("Unexpected app.ID. Want 5, have %d", apps[13].ID)
+	}
+	if apps[14].ID != 16777250 {
+		t.Fatalf("Unexpected app.ID. Want 16777250, have %d", apps[14].ID)
+	}
+	if apps[15].ID != 16777264 {
+		t.Fatalf("Unexpected app.ID. Want 16777264, have %d", apps[15].ID)
+	}
 }
 
 func TestApp(t *testing.T) {
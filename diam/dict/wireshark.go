@@ -0,0 +1,499 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Wireshark dictionary loader.  Part of go-diameter.
+
+package dict
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// wsFile is the root element of a Wireshark dictionary, once its
+// entities are expanded. Vendor, typedefn and AVP definitions may
+// appear at the top level of included files.
+type wsFile struct {
+	XMLName  xml.Name      `xml:"dictionary"`
+	Base     wsApp         `xml:"base"`
+	App      []*wsApp      `xml:"application"`
+	Vendor   []*wsVendor   `xml:"vendor"`
+	Typedefn []*wsTypedefn `xml:"typedefn"`
+	AVP      []*wsAVP      `xml:"avp"`
+}
+
+type wsVendor struct {
+	ID   string `xml:"vendor-id,attr"` // Symbolic name referenced by AVPs
+	Code uint32 `xml:"code,attr"`
+	Name string `xml:"name,attr"`
+}
+
+type wsTypedefn struct {
+	Name   string `xml:"type-name,attr"`
+	Parent string `xml:"type-parent,attr,omitempty"`
+}
+
+type wsApp struct {
+	ID       uint32        `xml:"id,attr"`
+	Name     string        `xml:"name,attr"`
+	Typedefn []*wsTypedefn `xml:"typedefn"`
+	Command  []*wsCommand  `xml:"command"`
+	AVP      []*wsAVP      `xml:"avp"`
+}
+
+type wsCommand struct {
+	Name     string  `xml:"name,attr"`
+	Code     uint32  `xml:"code,attr"`
+	VendorID string  `xml:"vendor-id,attr,omitempty"`
+	Request  wsRules `xml:"requestrules"`
+	Answer   wsRules `xml:"answerrules"`
+}
+
+type wsRules struct {
+	Fixed    *wsRuleList `xml:"fixed"`
+	Required *wsRuleList `xml:"required"`
+	Optional *wsRuleList `xml:"optional"`
+}
+
+// wsRuleList is a pointer in its parents so that empty lists are not
+// written back as empty elements.
+type wsRuleList struct {
+	Rule []*wsRule `xml:"avprule"`
+}
+
+func (l *wsRuleList) rules() []*wsRule {
+	if l == nil {
+		return nil
+	}
+	return l.Rule
+}
+
+type wsRule struct {
+	Name string `xml:"name,attr"`
+	Min  string `xml:"minimum,attr,omitempty"`
+	Max  string `xml:"maximum,attr,omitempty"`
+}
+
+type wsAVP struct {
+	Name       string     `xml:"name,attr"`
+	Code       uint32     `xml:"code,attr"`
+	VendorID   string     `xml:"vendor-id,attr,omitempty"`
+	Mandatory  string     `xml:"mandatory,attr,omitempty"`
+	Protected  string     `xml:"protected,attr,omitempty"`
+	MayEncrypt string     `xml:"may-encrypt,attr,omitempty"`
+	VendorBit  string     `xml:"vendor-bit,attr,omitempty"`
+	Type       *wsType    `xml:"type"`
+	Enum       []*wsEnum  `xml:"enum"`
+	Grouped    *wsGrouped `xml:"grouped"`
+}
+
+type wsType struct {
+	Name string `xml:"type-name,attr"`
+}
+
+type wsEnum struct {
+	Name string `xml:"name,attr"`
+	Code string `xml:"code,attr"`
+}
+
+type wsGrouped struct {
+	GAVP     []*wsRule   `xml:"gavp"`
+	Required *wsRuleList `xml:"required"`
+	Optional *wsRuleList `xml:"optional"`
+}
+
+// wsTypes maps Wireshark type names whose typedefn parent is not the
+// go-diameter data type they represent.
+var wsTypes = map[string]string{
+	"IPAddress": "Address",
+}
+
+var (
+	wsComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+	wsProlog  = regexp.MustCompile(`(?s)^\s*<\?xml.*?\?>`)
+	wsDoctype = regexp.MustCompile(`(?s)<!DOCTYPE[^\[>]*(\[(.*?)\])?\s*>`)
+	wsEntity  = regexp.MustCompile(`<!ENTITY\s+([\w.-]+)\s+(SYSTEM\s+)?(?:"([^"]*)"|'([^']*)')\s*>`)
+	wsRef     = regexp.MustCompile(`&([\w.-]+);`)
+)
+
+// maxEntityDepth limits the nesting of entities in Wireshark dictionaries.
+const maxEntityDepth = 8
+
+// LoadWiresharkDir loads the Wireshark dictionary of a directory, such as
+// /usr/share/wireshark/diameter, starting at its dictionary.xml file. See
+// ReadWireshark for how it is converted.
+//
+// The dictionary is kept by Reload, like those loaded with Load.
+func (p *Parser) LoadWiresharkDir(dir string) error {
+	f, err := ReadWireshark(filepath.Join(dir, "dictionary.xml"))
+	if err != nil {
+		return err
+	}
+	b, err := xml.Marshal(f)
+	if err != nil {
+		return err
+	}
+	return p.load(source{data: b})
+}
+
+// ReadWireshark reads a Wireshark dictionary file and the files it
+// includes through external entities, relative to its directory, and
+// converts them to a dictionary File.
+//
+// AVPs of the base protocol, and those defined outside of applications,
+// belong to the base application (id 0). Data types defined with
+// typedefn resolve to their go-diameter type through their parents;
+// unknown types are decoded as OctetString. Grouped AVPs and commands
+// get the rules of their gavp and avprule elements, fixed rules being
+// required and placed at the head of messages. The first definition of
+// an AVP or command wins over those repeated in the same application.
+func ReadWireshark(filename string) (*File, error) {
+	b, err := expandWireshark(filename)
+	if err != nil {
+		return nil, err
+	}
+	ws := new(wsFile)
+	if err = xml.Unmarshal(b, ws); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	f, err := ws.convert()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+	return f, nil
+}
+
+// expandWireshark returns the contents of a Wireshark dictionary file
+// without comments and DOCTYPE, and with the entities it declares
+// replaced by their values or the contents of their files.
+func expandWireshark(filename string) ([]byte, error) {
+	b, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	b = wsComment.ReplaceAll(b, nil)
+	b = wsProlog.ReplaceAll(b, nil)
+	entities := make(map[string][]byte)
+	files := make(map[string]string)
+	if m := wsDoctype.FindSubmatch(b); m != nil {
+		for _, e := range wsEntity.FindAllSubmatch(m[2], -1) {
+			value := string(e[3]) + string(e[4])
+			if len(e[2]) > 0 {
+				files[string(e[1])] = filepath.Join(filepath.Dir(filename), value)
+			} else {
+				entities[string(e[1])] = []byte(value)
+			}
+		}
+		b = wsDoctype.ReplaceAll(b, nil)
+	}
+	for name, path := range files {
+		v, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: entity %s: %v", filename, name, err)
+		}
+		v = wsComment.ReplaceAll(v, nil)
+		entities[name] = wsProlog.ReplaceAll(v, nil)
+	}
+	return expandEntities(b, entities, 0)
+}
+
+// expandEntities replaces the references to entities in b.
+func expandEntities(b []byte, entities map[string][]byte, depth int) ([]byte, error) {
+	if depth > maxEntityDepth {
+		return nil, fmt.Errorf("entities nested too deep")
+	}
+	var err error
+	b = wsRef.ReplaceAllFunc(b, func(ref []byte) []byte {
+		v, ok := entities[string(ref[1:len(ref)-1])]
+		if !ok || err != nil {
+			return ref
+		}
+		var x []byte
+		if x, err = expandEntities(v, entities, depth+1); err != nil {
+			return ref
+		}
+		return x
+	})
+	return b, err
+}
+
+// convert returns the go-diameter dictionary of ws.
+func (ws *wsFile) convert() (*File, error) {
+	vendors := make(map[string]uint32)
+	names := make(map[uint32]string)
+	for _, v := range ws.Vendor {
+		vendors[v.ID] = v.Code
+		names[v.Code] = v.Name
+	}
+	types := make(map[string]string)
+	for _, t := range ws.Base.Typedefn {
+		types[t.Name] = t.Parent
+	}
+	for _, t := range ws.Typedefn {
+		types[t.Name] = t.Parent
+	}
+	for _, app := range ws.App {
+		for _, t := range app.Typedefn {
+			types[t.Name] = t.Parent
+		}
+	}
+	c := &wsConverter{vendors: vendors, names: names, types: types, apps: make(map[uint32]*App)}
+
+	base := c.app(0, "Base")
+	for _, v := range ws.Vendor {
+		c.addVendor(base, v.Code)
+	}
+	for _, a := range append(ws.Base.AVP, ws.AVP...) {
+		if err := c.addAVP(base, a); err != nil {
+			return nil, err
+		}
+	}
+	for _, wa := range ws.App {
+		app := c.app(wa.ID, wa.Name)
+		for _, cmd := range wa.Command {
+			if err := c.addCommand(app, cmd); err != nil {
+				return nil, err
+			}
+		}
+		for _, a := range wa.AVP {
+			if err := c.addAVP(app, a); err != nil {
+				return nil, err
+			}
+		}
+	}
+	f := &File{}
+	for _, id := range c.order {
+		f.App = append(f.App, c.apps[id])
+	}
+	return f, nil
+}
+
+// wsConverter holds the state of the conversion of a Wireshark
+// dictionary.
+type wsConverter struct {
+	vendors map[string]uint32 // Vendor code by symbolic name
+	names   map[uint32]string // Vendor name by code
+	types   map[string]string // Typedefn parent by type name
+	apps    map[uint32]*App
+	order   []uint32
+}
+
+// app returns the application id, creating it when needed.
+func (c *wsConverter) app(id uint32, name string) *App {
+	if app, ok := c.apps[id]; ok {
+		return app
+	}
+	app := &App{ID: id, Name: name}
+	c.apps[id] = app
+	c.order = append(c.order, id)
+	return app
+}
+
+// addVendor adds the vendor code to the vendors of app.
+func (c *wsConverter) addVendor(app *App, code uint32) {
+	if code == 0 {
+		return
+	}
+	for _, v := range app.Vendor {
+		if v.ID == code {
+			return
+		}
+	}
+	app.Vendor = append(app.Vendor, &Vendor{ID: code, Name: c.names[code]})
+}
+
+// vendor returns the code of a vendor-id attribute, which is a vendor
+// name or, in some dictionaries, a number.
+func (c *wsConverter) vendor(id string) (uint32, error) {
+	if len(id) == 0 || id == "None" {
+		return 0, nil
+	}
+	if code, ok := c.vendors[id]; ok {
+		return code, nil
+	}
+	code, err := strconv.ParseUint(id, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("unknown vendor %q", id)
+	}
+	return uint32(code), nil
+}
+
+// typeName returns the go-diameter data type of a Wireshark type.
+func (c *wsConverter) typeName(name string) string {
+	for i := 0; i < maxEntityDepth && len(name) > 0; i++ {
+		if _, ok := datatype.Available[name]; ok {
+			return name
+		}
+		if t, ok := wsTypes[name]; ok {
+			return t
+		}
+		name = c.types[name]
+	}
+	return "OctetString"
+}
+
+func (c *wsConverter) addCommand(app *App, wc *wsCommand) error {
+	for _, cmd := range app.Command {
+		if cmd.Code == wc.Code {
+			return nil
+		}
+	}
+	vendorID, err := c.vendor(wc.VendorID)
+	if err != nil {
+		return fmt.Errorf("command %s: %v", wc.Name, err)
+	}
+	c.addVendor(app, vendorID)
+	cmd := &Command{Code: wc.Code, Name: wc.Name, Short: shortName(wc.Name)}
+	for _, r := range []struct {
+		src *wsRules
+		dst *CommandRule
+	}{
+		{&wc.Request, &cmd.Request},
+		{&wc.Answer, &cmd.Answer},
+	} {
+		if r.dst.Rule, err = appendRules(r.dst.Rule, r.src.Fixed.rules(), true); err != nil {
+			return fmt.Errorf("command %s: %v", wc.Name, err)
+		}
+		for i, rule := range r.dst.Rule {
+			rule.Position = i + 1
+		}
+		if r.dst.Rule, err = appendRules(r.dst.Rule, r.src.Required.rules(), true); err != nil {
+			return fmt.Errorf("command %s: %v", wc.Name, err)
+		}
+		if r.dst.Rule, err = appendRules(r.dst.Rule, r.src.Optional.rules(), false); err != nil {
+			return fmt.Errorf("command %s: %v", wc.Name, err)
+		}
+	}
+	app.Command = append(app.Command, cmd)
+	return nil
+}
+
+func (c *wsConverter) addAVP(app *App, wa *wsAVP) error {
+	vendorID, err := c.vendor(wa.VendorID)
+	if err != nil {
+		return fmt.Errorf("avp %s: %v", wa.Name, err)
+	}
+	for _, avp := range app.AVP {
+		if avp.Code == wa.Code && avp.VendorID == vendorID {
+			return nil
+		}
+	}
+	c.addVendor(app, vendorID)
+	avp := &AVP{
+		Name:       wa.Name,
+		Code:       wa.Code,
+		VendorID:   vendorID,
+		MayEncrypt: "Y",
+	}
+	if wa.MayEncrypt == "no" {
+		avp.MayEncrypt = "N"
+	}
+	vendorBit := wa.VendorBit
+	if len(vendorBit) == 0 && vendorID != 0 {
+		vendorBit = "must"
+	}
+	var must, may, mustNot []string
+	for _, f := range []struct{ flag, value, def string }{
+		{"V", vendorBit, "mustnot"},
+		{"M", wa.Mandatory, "may"},
+		{"P", wa.Protected, "may"},
+	} {
+		if len(f.value) == 0 {
+			f.value = f.def
+		}
+		switch f.value {
+		case "must":
+			must = append(must, f.flag)
+		case "may":
+			may = append(may, f.flag)
+		default:
+			mustNot = append(mustNot, f.flag)
+		}
+	}
+	avp.Must, avp.May, avp.MustNot = flagList(must), flagList(may), flagList(mustNot)
+
+	switch {
+	case wa.Grouped != nil:
+		avp.Data.TypeName = "Grouped"
+		g := wa.Grouped
+		if avp.Data.Rule, err = appendRules(avp.Data.Rule, g.GAVP, false); err == nil {
+			if avp.Data.Rule, err = appendRules(avp.Data.Rule, g.Required.rules(), true); err == nil {
+				avp.Data.Rule, err = appendRules(avp.Data.Rule, g.Optional.rules(), false)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("avp %s: %v", wa.Name, err)
+		}
+	case wa.Type != nil:
+		avp.Data.TypeName = c.typeName(wa.Type.Name)
+	default:
+		avp.Data.TypeName = "OctetString"
+	}
+	for _, e := range wa.Enum {
+		code, err := strconv.ParseInt(e.Code, 0, 64)
+		if err != nil {
+			return fmt.Errorf("avp %s: invalid enum code %q", wa.Name, e.Code)
+		}
+		avp.Data.Enum = append(avp.Data.Enum, &Enum{Code: int32(code), Name: e.Name})
+	}
+	app.AVP = append(app.AVP, avp)
+	return nil
+}
+
+// appendRules appends the rules of src to dst.
+func appendRules(dst []*Rule, src []*wsRule, required bool) ([]*Rule, error) {
+	for _, r := range src {
+		min, err := ruleCount(r.Min)
+		if err != nil {
+			return nil, err
+		}
+		max, err := ruleCount(r.Max)
+		if err != nil {
+			return nil, err
+		}
+		dst = append(dst, &Rule{AVP: r.Name, Required: required, Min: min, Max: max})
+	}
+	return dst, nil
+}
+
+// ruleCount parses the minimum and maximum of a Wireshark rule, where
+// "none" means there is no limit.
+func ruleCount(s string) (int, error) {
+	if len(s) == 0 || s == "none" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid rule count %q", s)
+	}
+	return n, nil
+}
+
+// flagList returns the AVP flags attribute of a list of flags.
+func flagList(flags []string) string {
+	if len(flags) == 0 {
+		return "-"
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(flags)))
+	return strings.Join(flags, ",")
+}
+
+// shortName returns the abbreviation of a command name, such as CC for
+// Credit-Control.
+func shortName(name string) string {
+	var b bytes.Buffer
+	for _, w := range strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == ' ' }) {
+		b.WriteByte(w[0])
+	}
+	return strings.ToUpper(b.String())
+}
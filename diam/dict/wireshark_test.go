@@ -0,0 +1,114 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package dict
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+func TestLoadWiresharkDir(t *testing.T) {
+	p, _ := NewParser()
+	if err := p.LoadWiresharkDir("./testdata/wireshark"); err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		app  uint32
+		name string
+		typ  datatype.TypeID
+	}{
+		{0, "Session-Id", datatype.UTF8StringType},
+		{0, "Host-IP-Address", datatype.AddressType},
+		{0, "Vendor-Id", datatype.Unsigned32Type},
+		{0, "Vendor-Specific-Application-Id", datatype.GroupedType},
+		{1, "Framed-IP-Address", datatype.AddressType},
+		{16777251, "RAT-Type", datatype.EnumeratedType},
+		{16777251, "Origin-Host", datatype.DiameterIdentityType},
+	} {
+		avp, err := p.FindAVP(tc.app, tc.name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if avp.Data.Type != tc.typ {
+			t.Fatalf("Unexpected type of %s: %v", tc.name, avp.Data.Type)
+		}
+	}
+
+	avp, err := p.FindAVPWithVendor(16777251, uint32(1032), 10415)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if avp.Must != "V" || avp.May != "P" || avp.MustNot != "M" || avp.MayEncrypt != "N" {
+		t.Fatalf("Unexpected flags: must=%q may=%q must-not=%q may-encrypt=%q",
+			avp.Must, avp.May, avp.MustNot, avp.MayEncrypt)
+	}
+	if len(avp.Data.Enum) != 2 || avp.Data.Enum[1].Name != "EUTRAN" || avp.Data.Enum[1].Code != 1004 {
+		t.Fatalf("Unexpected enum: %+v", avp.Data.Enum)
+	}
+	if _, err = p.Rule(16777251, 1400, "Context-Identifier"); err != nil {
+		t.Fatal(err)
+	}
+	if avp, err = p.FindAVP(0, "Subscription-Data"); err != nil || avp.Must != "V,M" || avp.MustNot != "P" {
+		t.Fatalf("Unexpected AVP: %+v, %v", avp, err)
+	}
+
+	cmd, err := p.FindCommand(16777251, 316)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cmd.Short != "UL" || len(cmd.Request.Rule) != 2 || !cmd.Request.Rule[0].Required || cmd.Request.Rule[0].AVP != "Session-Id" {
+		t.Fatalf("Unexpected command: %s %+v", cmd, cmd.Request.Rule)
+	}
+	if cmd, err = p.FindCommand(1, 257); err != nil || cmd.Short != "CE" {
+		t.Fatalf("Unexpected command: %s, %v", cmd, err)
+	}
+	if r := cmd.Request.Rule[2]; r.AVP != "Host-IP-Address" || r.Min != 1 || r.Max != 0 || !r.Required {
+		t.Fatalf("Unexpected rule: %+v", r)
+	}
+	app, err := p.App(16777251)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(app.Vendor) != 1 || app.Vendor[0].ID != 10415 {
+		t.Fatalf("Unexpected vendors: %+v", app.Vendor)
+	}
+
+	if err = p.Reload(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.FindAVP(16777251, "Subscription-Data"); err != nil {
+		t.Fatalf("Reload dropped the Wireshark dictionary: %v", err)
+	}
+}
+
+func TestReadWireshark_Entities(t *testing.T) {
+	dir := t.TempDir()
+	for name, xml := range map[string]string{
+		"missing.xml": `<!DOCTYPE dictionary [<!ENTITY x SYSTEM "none.xml">]><dictionary>&x;</dictionary>`,
+		"loop.xml":    `<!DOCTYPE dictionary [<!ENTITY a "&b;"><!ENTITY b "&a;">]><dictionary>&a;</dictionary>`,
+		"vendor.xml":  `<dictionary><avp name="X" code="1" vendor-id="Nobody"><type type-name="Unsigned32"/></avp></dictionary>`,
+		"literal.xml": `<!DOCTYPE dictionary [<!ENTITY v '<vendor vendor-id="V" code="9" name="V"/>'>]>` +
+			`<dictionary>&v;<avp name="X" code="1" vendor-id="V"><type type-name="Custom"/></avp></dictionary>`,
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(xml), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"missing.xml", "loop.xml", "vendor.xml"} {
+		if _, err := ReadWireshark(filepath.Join(dir, name)); err == nil {
+			t.Fatalf("Invalid dictionary %s was read", name)
+		}
+	}
+	f, err := ReadWireshark(filepath.Join(dir, "literal.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if avp := f.App[0].AVP[0]; avp.VendorID != 9 || avp.Data.TypeName != "OctetString" {
+		t.Fatalf("Unexpected AVP: %+v", avp)
+	}
+}
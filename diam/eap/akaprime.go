@@ -0,0 +1,262 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package eap
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/swx"
+)
+
+// ErrInvalidVector is returned by AKAPrime when an authentication vector
+// has the wrong length.
+var ErrInvalidVector = errors.New("eap: invalid EAP-AKA' vector")
+
+// EAP-AKA subtypes. See RFC 4187 section 11.
+const (
+	akaChallenge              = 1
+	akaAuthenticationReject   = 2
+	akaSynchronizationFailure = 4
+	akaClientError            = 14
+)
+
+// EAP-AKA attribute types. See RFC 4187 section 11 and RFC 5448
+// section 3.
+const (
+	atRAND     = 1
+	atAUTN     = 2
+	atRES      = 3
+	atAUTS     = 4
+	atMAC      = 11
+	atKDFInput = 23
+	atKDF      = 24
+)
+
+// kdfCKIKPrime is the only key derivation function defined for
+// EAP-AKA'. See RFC 5448 section 3.2.
+const kdfCKIKPrime = 1
+
+// AKAPrime is the EAP-AKA' method of RFC 5448, for 3GPP access over
+// non-3GPP networks. It obtains authentication vectors from Vector, in
+// the form returned by the HSS in an SWx MAA or generated by
+// aka.Subscriber.EAPAKAPrimeVector, and exports the MSK on success.
+//
+// The peer is challenged straight away; the identity exchange with
+// AT_IDENTITY is not supported, so Identity must be the one the peer
+// sent in its EAP-Response/Identity.
+type AKAPrime struct {
+	Identity    string
+	NetworkName string // Access network identity sent in AT_KDF_INPUT, e.g. "WLAN".
+
+	// Vector returns a new authentication vector for Identity.
+	Vector func() (*swx.SIPAuthDataItem, error)
+
+	// Resynchronize, if non-nil, is called with RAND and AUTS when the
+	// peer reports a synchronisation failure, before a new vector is
+	// requested. Without it such reports fail the authentication.
+	Resynchronize func(rand, auts []byte) error
+
+	rand, xres      []byte
+	kAut, msk, emsk []byte
+	resynchronized  bool
+}
+
+// Type implements the Method interface.
+func (m *AKAPrime) Type() uint8 { return TypeAKAPrime }
+
+// Start implements the Method interface.
+func (m *AKAPrime) Start(req *Packet) error {
+	return m.challenge(req)
+}
+
+// challenge gets a new vector and fills in req with AKA'-Challenge.
+func (m *AKAPrime) challenge(req *Packet) error {
+	v, err := m.Vector()
+	if err != nil {
+		return err
+	}
+	if len(v.SIPAuthenticate) != 32 || len(v.ConfidentialityKey) != 16 || len(v.IntegrityKey) != 16 ||
+		len(v.SIPAuthorization) < 4 || len(v.SIPAuthorization) > 16 {
+		return ErrInvalidVector
+	}
+	m.rand = []byte(v.SIPAuthenticate[:16])
+	m.xres = []byte(v.SIPAuthorization)
+	m.deriveKeys([]byte(v.IntegrityKey), []byte(v.ConfidentialityKey))
+
+	data := []byte{akaChallenge, 0, 0}
+	data = appendAttr(data, atRAND, append([]byte{0, 0}, m.rand...))
+	data = appendAttr(data, atAUTN, append([]byte{0, 0}, v.SIPAuthenticate[16:]...))
+	data = appendAttr(data, atKDF, []byte{0, kdfCKIKPrime})
+	name := []byte(m.NetworkName)
+	data = appendAttr(data, atKDFInput, append([]byte{byte(len(name) >> 8), byte(len(name))}, name...))
+	data = appendAttr(data, atMAC, make([]byte, 18))
+	req.Data = data
+	return m.sign(req)
+}
+
+// deriveKeys derives K_aut, MSK and EMSK from IK' and CK'.
+// See RFC 5448 section 3.3.
+func (m *AKAPrime) deriveKeys(ikPrime, ckPrime []byte) {
+	key := append(append([]byte(nil), ikPrime...), ckPrime...)
+	mk := prfPrime(key, []byte("EAP-AKA'"+m.Identity), 208)
+	m.kAut = mk[16:48]
+	m.msk = mk[80:144]
+	m.emsk = mk[144:208]
+}
+
+// Process implements the Method interface.
+func (m *AKAPrime) Process(resp, req *Packet) (bool, error) {
+	if len(resp.Data) < 3 {
+		return false, ErrInvalidPacket
+	}
+	attrs, err := parseAttrs(resp.Data[3:])
+	if err != nil {
+		return false, err
+	}
+	switch resp.Data[0] {
+	case akaChallenge:
+		if _, ok := attrs[atKDF]; ok {
+			// The peer asks for another key derivation function.
+			return false, ErrAuthenticationFailed
+		}
+		if err := m.verify(resp, attrs); err != nil {
+			return false, err
+		}
+		res := attrs[atRES]
+		if len(res) < 2 {
+			return false, ErrAuthenticationFailed
+		}
+		n := (int(res[0])<<8 | int(res[1])) / 8
+		if n != len(m.xres) || len(res) < 2+n || subtle.ConstantTimeCompare(res[2:2+n], m.xres) != 1 {
+			return false, ErrAuthenticationFailed
+		}
+		return true, nil
+	case akaSynchronizationFailure:
+		auts := attrs[atAUTS]
+		if m.Resynchronize == nil || m.resynchronized || len(auts) != 14 {
+			return false, ErrAuthenticationFailed
+		}
+		if err := m.Resynchronize(m.rand, auts); err != nil {
+			return false, err
+		}
+		m.resynchronized = true
+		return false, m.challenge(req)
+	}
+	// AKA'-Authentication-Reject, AKA'-Client-Error and unexpected
+	// subtypes.
+	return false, ErrAuthenticationFailed
+}
+
+// MSK implements the Method interface.
+func (m *AKAPrime) MSK() []byte { return m.msk }
+
+// EMSK returns the Extended Master Session Key after a successful
+// authentication.
+func (m *AKAPrime) EMSK() []byte { return m.emsk }
+
+// sign computes the AT_MAC of req, whose value must be zeroed.
+func (m *AKAPrime) sign(p *Packet) error {
+	off, err := macOffset(p.Data)
+	if err != nil {
+		return err
+	}
+	copy(p.Data[off:off+16], packetMAC(m.kAut, p.Serialize()))
+	return nil
+}
+
+// verify checks the AT_MAC of resp.
+func (m *AKAPrime) verify(resp *Packet, attrs map[uint8][]byte) error {
+	mac := attrs[atMAC]
+	if len(mac) != 18 {
+		return ErrAuthenticationFailed
+	}
+	off, err := macOffset(resp.Data)
+	if err != nil {
+		return err
+	}
+	b := resp.Serialize()
+	zero := b[5+off : 5+off+16]
+	for i := range zero {
+		zero[i] = 0
+	}
+	if !hmac.Equal(mac[2:], packetMAC(m.kAut, b)) {
+		return ErrAuthenticationFailed
+	}
+	return nil
+}
+
+// packetMAC computes HMAC-SHA-256-128 of the packet b with the MAC
+// zeroed. See RFC 5448 section 3.4.
+func packetMAC(kAut, b []byte) []byte {
+	h := hmac.New(sha256.New, kAut)
+	h.Write(b)
+	return h.Sum(nil)[:16]
+}
+
+// prfPrime is the PRF' function of RFC 5448 section 3.4.1, which returns
+// n bytes of keying material.
+func prfPrime(key, s []byte, n int) []byte {
+	var out, t []byte
+	for i := byte(1); len(out) < n; i++ {
+		h := hmac.New(sha256.New, key)
+		h.Write(t)
+		h.Write(s)
+		h.Write([]byte{i})
+		t = h.Sum(nil)
+		out = append(out, t...)
+	}
+	return out[:n]
+}
+
+// appendAttr appends an attribute whose value starts with its two
+// reserved or length bytes, padding it to a multiple of 4 bytes.
+// See RFC 4187 section 8.1.
+func appendAttr(b []byte, typ uint8, value []byte) []byte {
+	n := (2 + len(value) + 3) / 4
+	b = append(b, typ, byte(n))
+	b = append(b, value...)
+	for i := 2 + len(value); i < n*4; i++ {
+		b = append(b, 0)
+	}
+	return b
+}
+
+// parseAttrs parses the attributes of an EAP-AKA packet, returning their
+// values including the two reserved or length bytes.
+func parseAttrs(b []byte) (map[uint8][]byte, error) {
+	attrs := make(map[uint8][]byte)
+	for len(b) > 0 {
+		if len(b) < 4 {
+			return nil, ErrInvalidPacket
+		}
+		n := int(b[1]) * 4
+		if n == 0 || n > len(b) {
+			return nil, ErrInvalidPacket
+		}
+		attrs[b[0]] = b[2:n]
+		b = b[n:]
+	}
+	return attrs, nil
+}
+
+// macOffset returns the offset of the AT_MAC value in the Type-Data of
+// an EAP-AKA packet.
+func macOffset(data []byte) (int, error) {
+	off := 3
+	for off+4 <= len(data) {
+		n := int(data[off+1]) * 4
+		if n == 0 || off+n > len(data) {
+			break
+		}
+		if data[off] == atMAC && n == 20 {
+			return off + 4, nil
+		}
+		off += n
+	}
+	return 0, ErrInvalidPacket
+}
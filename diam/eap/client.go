@@ -0,0 +1,112 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package eap
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+)
+
+// DefaultTimeout is how long the Client waits for answers when no
+// Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+// Client is the NAS side of Diameter EAP: the access point of RFC 4072,
+// the trusted non-3GPP access network of STa or the ePDG of SWm. It
+// relays the EAP packets of a peer to the server in DERs.
+//
+// Requests are completed by the Client before being sent: Session-Id is
+// generated when empty, and the routing AVPs, Auth-Application-Id and
+// Auth-Request-Type are filled in. Every DER of a conversation must use
+// the Session-Id of the first one.
+//
+// Client implements the diam.Handler interface and must be registered
+// for DEAIndex, STaDEAIndex or SWmDEAIndex on the connection's handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// ApplicationID is DIAMETER_EAP_APP_ID when zero, or
+	// TGPP_STA_APP_ID or TGPP_SWM_APP_ID.
+	ApplicationID uint32
+
+	pending pending.Table
+}
+
+// DiameterEAP sends a DER built from der over c and waits for the DEA.
+// Auth-Request-Type defaults to AUTHORIZE_AUTHENTICATE. With the Diameter
+// EAP application only the embedded DER is sent.
+func (cli *Client) DiameterEAP(c diam.Conn, der *TGPPDER) (*TGPPDEA, error) {
+	appID := cli.ApplicationID
+	if appID == 0 {
+		appID = diam.DIAMETER_EAP_APP_ID
+	}
+	if len(der.SessionID) == 0 {
+		der.SessionID = sessionid.New(cli.OriginHost)
+	}
+	der.AuthApplicationID = appID
+	der.OriginHost = cli.OriginHost
+	der.OriginRealm = cli.OriginRealm
+	der.DestinationRealm = cli.DestinationRealm
+	der.DestinationHost = cli.DestinationHost
+	if der.AuthRequestType == 0 {
+		der.AuthRequestType = AuthorizeAuthenticate
+	}
+	m := diam.NewRequest(diam.DiameterEAP, appID, c.Dictionary())
+	var err error
+	if appID == diam.DIAMETER_EAP_APP_ID {
+		err = m.Marshal(&der.DER)
+	} else {
+		err = m.Marshal(der)
+	}
+	if err != nil {
+		return nil, err
+	}
+	a, err := cli.pending.Exchange(c, m, cli.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	var dea TGPPDEA
+	if appID == diam.DIAMETER_EAP_APP_ID {
+		err = a.Unmarshal(&dea.DEA)
+	} else {
+		err = a.Unmarshal(&dea)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &dea, nil
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+	}
+}
+
+// answer writes the answer v to the request m.
+func answer(c diam.Conn, m *diam.Message, v interface{}, er diam.ErrorReporter) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && er != nil {
+		er.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
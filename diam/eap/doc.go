@@ -0,0 +1,43 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package eap implements the Diameter EAP application of RFC 4072 and
+// the DER/DEA exchanges of the 3GPP STa and SWm applications, as
+// specified in 3GPP TS 29.273.
+//
+// It provides typed DER/DEA messages, an EAP packet codec, and a Server
+// that relays each EAP conversation across DER/DEA round trips keyed by
+// Session-Id. EAP methods plug in behind the Method interface; MD5 (RFC
+// 3748) and AKAPrime (EAP-AKA', RFC 5448) are provided. A Client sends
+// DERs on behalf of the NAS, trusted non-3GPP access network or ePDG.
+//
+// A 3GPP AAA server for SWm authenticating with vectors from the HSS:
+//
+//	type backend struct{ hss *swx.Client; conn diam.Conn }
+//
+//	func (b *backend) Method(identity string, der *eap.TGPPDER) (eap.Method, error) {
+//		return &eap.AKAPrime{
+//			Identity:    identity,
+//			NetworkName: "WLAN",
+//			Vector: func() (*swx.SIPAuthDataItem, error) {
+//				maa, err := b.hss.MultimediaAuth(b.conn, &swx.MAR{UserName: identity})
+//				if err != nil {
+//					return nil, err
+//				}
+//				if len(maa.SIPAuthDataItem) == 0 {
+//					return nil, eap.ErrAuthenticationFailed
+//				}
+//				return &maa.SIPAuthDataItem[0], nil
+//			},
+//		}, nil
+//	}
+//
+//	func (b *backend) Authorize(der *eap.TGPPDER, dea *eap.TGPPDEA) {
+//		dea.APNConfiguration = ...
+//	}
+//
+//	aaa := &eap.Server{OriginHost: "aaa.example.com", OriginRealm: "example.com", Backend: b}
+//	mux := sm.New(settings)
+//	mux.HandleIdx(eap.SWmDERIndex, aaa)
+package eap
@@ -0,0 +1,376 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package eap
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/aka"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/swx"
+)
+
+const testUser = "60010100000000001@nai.epc.mnc001.mcc001.3gppnetwork.org"
+
+var (
+	testK   = []byte{0x46, 0x5b, 0x5c, 0xe8, 0xb1, 0x99, 0xb4, 0x9f, 0xaa, 0x5f, 0x0a, 0x2e, 0xe2, 0x38, 0xa6, 0xbc}
+	testOPc = []byte{0xcd, 0x63, 0xcb, 0x71, 0x95, 0x4a, 0x9f, 0x4e, 0x48, 0xa5, 0x99, 0x4e, 0x37, 0xa0, 0x2b, 0xaf}
+)
+
+func TestPacket(t *testing.T) {
+	p := &Packet{Code: CodeResponse, Identifier: 7, Type: TypeIdentity, Data: []byte("user@example.com")}
+	b := p.Serialize()
+	if len(b) != p.Len() || len(b) != 21 {
+		t.Fatalf("Unexpected length: %d", len(b))
+	}
+	q, err := ParsePacket(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Code != p.Code || q.Identifier != p.Identifier || q.Type != p.Type || !bytes.Equal(q.Data, p.Data) {
+		t.Fatalf("Unexpected packet: %s", q)
+	}
+	s, err := ParsePacket([]byte{CodeSuccess, 8, 0, 4})
+	if err != nil || s.Code != CodeSuccess || s.Identifier != 8 || s.Len() != 4 {
+		t.Fatalf("Unexpected packet: %v, %v", s, err)
+	}
+	for _, b := range [][]byte{
+		{CodeRequest, 1, 0},
+		{CodeRequest, 1, 0, 4},
+		{CodeSuccess, 1, 0, 5, 0},
+		{CodeResponse, 1, 0, 9, 1},
+		{9, 1, 0, 4},
+	} {
+		if _, err := ParsePacket(b); err != ErrInvalidPacket {
+			t.Fatalf("Unexpected error for %x: %v", b, err)
+		}
+	}
+}
+
+type testBackend struct {
+	password   []byte
+	subscriber *aka.Subscriber
+	resyncs    int
+}
+
+func (b *testBackend) Method(identity string, der *TGPPDER) (Method, error) {
+	switch {
+	case identity == "md5@example.com":
+		return &MD5{Password: b.password, Name: "nas"}, nil
+	case identity == testUser && der.RATType != nil && *der.RATType == RATTypeWLAN:
+		return &AKAPrime{
+			Identity:    identity,
+			NetworkName: "WLAN",
+			Vector: func() (*swx.SIPAuthDataItem, error) {
+				return b.subscriber.EAPAKAPrimeVector("WLAN")
+			},
+			Resynchronize: func(rand, auts []byte) error {
+				b.resyncs++
+				return b.subscriber.Resynchronize(rand, auts)
+			},
+		}, nil
+	}
+	return nil, errors.New("unknown user")
+}
+
+func (b *testBackend) Authorize(der *TGPPDER, dea *TGPPDEA) {
+	dea.SessionTimeout = 3600
+	if der.AuthApplicationID == diam.TGPP_SWM_APP_ID {
+		dea.APNOIReplacement = "mnc001.mcc001.gprs"
+		dea.MobileNodeIdentifier = testUser
+		dea.APNConfiguration = []s6a.APNConfiguration{{
+			ContextIdentifier: 1,
+			PDNType:           s6a.PDNTypeIPv4,
+			ServiceSelection:  "internet",
+		}}
+	}
+}
+
+// dial starts a Server and returns a Client connected to it.
+func dial(t *testing.T, backend Backend, appID uint32) (*Server, *Client, diam.Conn, func()) {
+	t.Helper()
+	aaa := &Server{OriginHost: "aaa", OriginRealm: "test", Backend: backend}
+	nas := &Client{
+		OriginHost:       "nas",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		ApplicationID:    appID,
+	}
+	// Diameter EAP is an IETF application, and STa and SWm are 3GPP ones.
+	vendorID := uint32(base.Vendor3GPP)
+	if appID == diam.DIAMETER_EAP_APP_ID {
+		vendorID = 0
+	}
+	c, done := smtest.Connect(t,
+		smtest.Peer{Host: "aaa", Handler: aaa, Commands: []diam.CommandIndex{DERIndex, STaDERIndex, SWmDERIndex}},
+		smtest.Peer{Host: "nas", Handler: nas, Commands: []diam.CommandIndex{DEAIndex, STaDEAIndex, SWmDEAIndex}},
+		vendorID, appID)
+	return aaa, nas, c, done
+}
+
+// identity returns the EAP-Response/Identity of user.
+func identity(user string) datatype.OctetString {
+	p := &Packet{Code: CodeResponse, Identifier: 0, Type: TypeIdentity, Data: []byte(user)}
+	return datatype.OctetString(p.Serialize())
+}
+
+func TestClientServer_MD5(t *testing.T) {
+	aaa, nas, c, done := dial(t, &testBackend{password: []byte("secret")}, diam.DIAMETER_EAP_APP_ID)
+	defer done()
+
+	for _, password := range []string{"secret", "wrong"} {
+		t.Run(password, func(t *testing.T) {
+			// Start with an empty EAP-Payload.
+			dea, err := nas.DiameterEAP(c, &TGPPDER{DER: DER{NASIdentifier: "ap1"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if dea.ResultCode != diam.MultiRoundAuth || dea.MultiRoundTimeOut == 0 || dea.AuthApplicationID != diam.DIAMETER_EAP_APP_ID {
+				t.Fatalf("Unexpected DEA: %+v", dea)
+			}
+			req, err := ParsePacket([]byte(dea.EAPPayload))
+			if err != nil || req.Code != CodeRequest || req.Type != TypeIdentity {
+				t.Fatalf("Unexpected request: %v, %v", req, err)
+			}
+			session := dea.SessionID
+
+			resp := &Packet{Code: CodeResponse, Identifier: req.Identifier, Type: TypeIdentity, Data: []byte("md5@example.com")}
+			dea, err = nas.DiameterEAP(c, &TGPPDER{DER: DER{SessionID: session, EAPPayload: datatype.OctetString(resp.Serialize())}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			req, err = ParsePacket([]byte(dea.EAPPayload))
+			if err != nil || dea.ResultCode != diam.MultiRoundAuth || req.Type != TypeMD5Challenge {
+				t.Fatalf("Unexpected DEA: %+v, %v", dea, err)
+			}
+			if !bytes.HasSuffix(req.Data, []byte("nas")) {
+				t.Fatalf("Unexpected challenge: %x", req.Data)
+			}
+			if aaa.Conversations() != 1 {
+				t.Fatalf("Unexpected # of conversations: %d", aaa.Conversations())
+			}
+
+			if resp, err = MD5Response(req, []byte(password)); err != nil {
+				t.Fatal(err)
+			}
+			dea, err = nas.DiameterEAP(c, &TGPPDER{DER: DER{SessionID: session, EAPPayload: datatype.OctetString(resp.Serialize())}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			p, err := ParsePacket([]byte(dea.EAPPayload))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if password == "secret" {
+				if dea.ResultCode != diam.Success || p.Code != CodeSuccess || dea.UserName != "md5@example.com" ||
+					dea.SessionTimeout != 3600 || dea.AccountingEAPAuthMethod != TypeMD5Challenge || len(dea.EAPMasterSessionKey) != 0 {
+					t.Fatalf("Unexpected DEA: %+v", dea)
+				}
+			} else if dea.ResultCode != diam.AuthenticationRejected || p.Code != CodeFailure {
+				t.Fatalf("Unexpected DEA: %+v", dea)
+			}
+			if aaa.Conversations() != 0 {
+				t.Fatalf("Unexpected # of conversations: %d", aaa.Conversations())
+			}
+		})
+	}
+
+	// Unknown users are rejected after the identity.
+	t.Run("UnknownUser", func(t *testing.T) {
+		dea, err := nas.DiameterEAP(c, &TGPPDER{DER: DER{EAPPayload: identity("nobody@example.com")}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if dea.ResultCode != diam.AuthenticationRejected {
+			t.Fatalf("Unexpected DEA: %+v", dea)
+		}
+	})
+}
+
+// testPeer is the peer side of EAP-AKA', backed by a USIM with the
+// given key and sequence number.
+type testPeer struct {
+	t        *testing.T
+	milenage *aka.Milenage
+	sqn      uint64
+	identity string
+	msk      []byte
+}
+
+// respond returns the response of the peer to an AKA'-Challenge.
+func (p *testPeer) respond(req *Packet) *Packet {
+	t := p.t
+	if req.Type != TypeAKAPrime || len(req.Data) < 3 || req.Data[0] != akaChallenge {
+		t.Fatalf("Unexpected request: %s", req)
+	}
+	attrs, err := parseAttrs(req.Data[3:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	rand, autn := attrs[atRAND][2:], attrs[atAUTN][2:]
+	kdfInput := attrs[atKDFInput]
+	if len(rand) != 16 || len(autn) != 16 || len(kdfInput) < 2 || !bytes.Equal(attrs[atKDF], []byte{0, kdfCKIKPrime}) {
+		t.Fatalf("Unexpected attributes: %x", req.Data)
+	}
+	name := string(kdfInput[2 : 2+(int(kdfInput[0])<<8|int(kdfInput[1]))])
+	res, ck, ik, ak, err := p.milenage.F2345(rand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqn := append([]byte(nil), autn[:6]...)
+	for i := range sqn {
+		sqn[i] ^= ak[i]
+	}
+	var n uint64
+	for _, v := range sqn {
+		n = n<<8 | uint64(v)
+	}
+	resp := &Packet{Code: CodeResponse, Identifier: req.Identifier, Type: TypeAKAPrime}
+	if n <= p.sqn {
+		// Report a synchronisation failure with AUTS.
+		ueSQN := []byte{byte(p.sqn >> 40), byte(p.sqn >> 32), byte(p.sqn >> 24), byte(p.sqn >> 16), byte(p.sqn >> 8), byte(p.sqn)}
+		akStar, err := p.milenage.F5Star(rand)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, macS, err := p.milenage.F1(rand, ueSQN, []byte{0, 0})
+		if err != nil {
+			t.Fatal(err)
+		}
+		auts := append([]byte(nil), ueSQN...)
+		for i := range auts {
+			auts[i] ^= akStar[i]
+		}
+		auts = append(auts, macS...)
+		resp.Data = appendAttr([]byte{akaSynchronizationFailure, 0, 0}, atAUTS, auts[:14])
+		return resp
+	}
+	p.sqn = n
+	ckPrime, ikPrime, err := aka.CKIKPrime(ck, ik, name, autn[:6])
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := &AKAPrime{Identity: p.identity}
+	keys.deriveKeys(ikPrime, ckPrime)
+	if err := keys.verify(req, attrs); err != nil {
+		t.Fatalf("Request MAC: %v", err)
+	}
+	p.msk = keys.msk
+
+	data := []byte{akaChallenge, 0, 0}
+	data = appendAttr(data, atRES, append([]byte{0, byte(len(res) * 8)}, res...))
+	data = appendAttr(data, atMAC, make([]byte, 18))
+	resp.Data = data
+	if err := keys.sign(resp); err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func TestClientServer_AKAPrime(t *testing.T) {
+	sub, err := aka.NewSubscriber(testK, testOPc, 0x20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	backend := &testBackend{subscriber: sub}
+	_, nas, c, done := dial(t, backend, diam.TGPP_SWM_APP_ID)
+	defer done()
+
+	m, err := aka.NewMilenage(testK, testOPc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The USIM is ahead of the HSS, so the first challenge is answered
+	// with a synchronisation failure.
+	peer := &testPeer{t: t, milenage: m, sqn: 0x1000, identity: testUser}
+	rat := int32(RATTypeWLAN)
+	der := &TGPPDER{
+		DER:                 DER{EAPPayload: identity(testUser), UserName: testUser},
+		RATType:             &rat,
+		ServiceSelection:    "internet",
+		TerminalInformation: &s6a.TerminalInformation{IMEI: "35358601234567"},
+	}
+	dea, err := nas.DiameterEAP(c, der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for rounds := 0; dea.ResultCode == diam.MultiRoundAuth; rounds++ {
+		if rounds > 2 {
+			t.Fatal("Too many rounds")
+		}
+		req, err := ParsePacket([]byte(dea.EAPPayload))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp := peer.respond(req)
+		dea, err = nas.DiameterEAP(c, &TGPPDER{
+			DER:     DER{SessionID: der.SessionID, EAPPayload: datatype.OctetString(resp.Serialize())},
+			RATType: &rat,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if dea.ResultCode != diam.Success || dea.AuthApplicationID != diam.TGPP_SWM_APP_ID {
+		t.Fatalf("Unexpected DEA: %+v", dea)
+	}
+	if backend.resyncs != 1 || sub.SQN() <= 0x1000 {
+		t.Fatalf("Unexpected resynchronisation: %d, SQN %x", backend.resyncs, sub.SQN())
+	}
+	if p, err := ParsePacket([]byte(dea.EAPPayload)); err != nil || p.Code != CodeSuccess {
+		t.Fatalf("Unexpected EAP-Payload: %v, %v", p, err)
+	}
+	if len(dea.EAPMasterSessionKey) != 64 || !bytes.Equal([]byte(dea.EAPMasterSessionKey), peer.msk) {
+		t.Fatalf("Unexpected MSK: %x", dea.EAPMasterSessionKey)
+	}
+	if dea.APNOIReplacement != "mnc001.mcc001.gprs" || dea.MobileNodeIdentifier != testUser ||
+		len(dea.APNConfiguration) != 1 || dea.APNConfiguration[0].ServiceSelection != "internet" {
+		t.Fatalf("Unexpected authorization: %+v", dea)
+	}
+}
+
+func TestAKAPrime_Reject(t *testing.T) {
+	sub, err := aka.NewSubscriber(testK, testOPc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := &AKAPrime{
+		Identity:    testUser,
+		NetworkName: "WLAN",
+		Vector: func() (*swx.SIPAuthDataItem, error) {
+			return sub.EAPAKAPrimeVector("WLAN")
+		},
+	}
+	req := &Packet{Code: CodeRequest, Identifier: 1, Type: TypeAKAPrime}
+	if err = m.Start(req); err != nil {
+		t.Fatal(err)
+	}
+	// A response with a bad MAC.
+	resp := &Packet{Code: CodeResponse, Identifier: 1, Type: TypeAKAPrime}
+	data := []byte{akaChallenge, 0, 0}
+	data = appendAttr(data, atRES, append([]byte{0, 64}, make([]byte, 8)...))
+	data = appendAttr(data, atMAC, make([]byte, 18))
+	resp.Data = data
+	if _, err = m.Process(resp, &Packet{}); err != ErrAuthenticationFailed {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Synchronisation failures need Resynchronize.
+	resp.Data = appendAttr([]byte{akaSynchronizationFailure, 0, 0}, atAUTS, make([]byte, 14))
+	if _, err = m.Process(resp, &Packet{}); err != ErrAuthenticationFailed {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	resp.Data = []byte{akaAuthenticationReject, 0, 0}
+	if _, err = m.Process(resp, &Packet{}); err != ErrAuthenticationFailed {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package eap
+
+import (
+	"net"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+)
+
+// Command indexes of the Diameter EAP, STa and SWm applications, for use
+// with ServeMux.HandleIdx.
+var (
+	DERIndex    = diam.CommandIndex{AppID: diam.DIAMETER_EAP_APP_ID, Code: diam.DiameterEAP, Request: true}
+	DEAIndex    = diam.CommandIndex{AppID: diam.DIAMETER_EAP_APP_ID, Code: diam.DiameterEAP, Request: false}
+	STaDERIndex = diam.CommandIndex{AppID: diam.TGPP_STA_APP_ID, Code: diam.DiameterEAP, Request: true}
+	STaDEAIndex = diam.CommandIndex{AppID: diam.TGPP_STA_APP_ID, Code: diam.DiameterEAP, Request: false}
+	SWmDERIndex = diam.CommandIndex{AppID: diam.TGPP_SWM_APP_ID, Code: diam.DiameterEAP, Request: true}
+	SWmDEAIndex = diam.CommandIndex{AppID: diam.TGPP_SWM_APP_ID, Code: diam.DiameterEAP, Request: false}
+)
+
+// Auth-Request-Type values. See RFC 6733 section 8.7.
+const (
+	AuthenticateOnly      = 1
+	AuthorizeOnly         = 2
+	AuthorizeAuthenticate = 3
+)
+
+// RAT-Type values used on STa and SWm. See 3GPP TS 29.212 section 5.3.31.
+const (
+	RATTypeWLAN    = 0
+	RATTypeVirtual = 1
+	RATTypeEHRPD   = 2003
+)
+
+// AN-Trusted values. See 3GPP TS 29.273 section 5.2.3.9.
+const (
+	Trusted   = 0
+	Untrusted = 1
+)
+
+// DER is a Diameter-EAP-Request message, sent by the NAS to carry an EAP
+// response to the Diameter EAP server. See RFC 4072 section 3.1.
+type DER struct {
+	SessionID         string                    `avp:"Session-Id"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host,omitempty"`
+	AuthRequestType   int32                     `avp:"Auth-Request-Type"`
+	EAPPayload        datatype.OctetString      `avp:"EAP-Payload"`
+	UserName          string                    `avp:"User-Name,omitempty"`
+	NASIdentifier     string                    `avp:"NAS-Identifier,omitempty"`
+	NASIPAddress      net.IP                    `avp:"NAS-IP-Address,omitempty"`
+	NASIPv6Address    net.IP                    `avp:"NAS-IPv6-Address,omitempty"`
+	NASPortType       *int32                    `avp:"NAS-Port-Type"`
+	ServiceType       *int32                    `avp:"Service-Type"`
+	State             datatype.OctetString      `avp:"State,omitempty"`
+	CalledStationID   string                    `avp:"Called-Station-Id,omitempty"`
+	CallingStationID  string                    `avp:"Calling-Station-Id,omitempty"`
+}
+
+// DEA is a Diameter-EAP-Answer message, sent by the Diameter EAP server
+// with the next EAP request, or with EAP-Success and the keying material
+// or EAP-Failure at the end of the conversation. See RFC 4072 section 3.2.
+type DEA struct {
+	SessionID               string                    `avp:"Session-Id"`
+	AuthApplicationID       uint32                    `avp:"Auth-Application-Id"`
+	AuthRequestType         int32                     `avp:"Auth-Request-Type"`
+	ResultCode              uint32                    `avp:"Result-Code,omitempty"`
+	OriginHost              datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm             datatype.DiameterIdentity `avp:"Origin-Realm"`
+	UserName                string                    `avp:"User-Name,omitempty"`
+	EAPPayload              datatype.OctetString      `avp:"EAP-Payload,omitempty"`
+	EAPMasterSessionKey     datatype.OctetString      `avp:"EAP-Master-Session-Key,omitempty"`
+	MultiRoundTimeOut       uint32                    `avp:"Multi-Round-Time-Out,omitempty"`
+	AccountingEAPAuthMethod uint64                    `avp:"Accounting-EAP-Auth-Method,omitempty"`
+	ErrorMessage            string                    `avp:"Error-Message,omitempty"`
+	SessionTimeout          uint32                    `avp:"Session-Timeout,omitempty"`
+	AcctInterimInterval     uint32                    `avp:"Acct-Interim-Interval,omitempty"`
+	State                   datatype.OctetString      `avp:"State,omitempty"`
+}
+
+// TGPPDER is the DER of the STa and SWm applications, which adds the
+// 3GPP AVPs of 3GPP TS 29.273 sections 5.2.2.1.1 and 7.2.2.1.1 to DER.
+// All of them are optional, and only the embedded DER is used with the
+// Diameter EAP application.
+type TGPPDER struct {
+	DER
+	RATType                  *int32                   `avp:"RAT-Type,omitempty"`
+	ANID                     string                   `avp:"ANID,omitempty"`
+	FullNetworkName          datatype.OctetString     `avp:"Full-Network-Name,omitempty"`
+	ShortNetworkName         datatype.OctetString     `avp:"Short-Network-Name,omitempty"`
+	ServiceSelection         string                   `avp:"Service-Selection,omitempty"`
+	MIP6FeatureVector        uint64                   `avp:"MIP6-Feature-Vector,omitempty"`
+	VisitedNetworkIdentifier datatype.OctetString     `avp:"Visited-Network-Identifier,omitempty"`
+	AAAFailureIndication     *uint32                  `avp:"AAA-Failure-Indication,omitempty"`
+	SupportedFeatures        []base.SupportedFeatures `avp:"Supported-Features,omitempty"`
+	UELocalIPAddress         net.IP                   `avp:"UE-Local-IP-Address,omitempty"`
+	TerminalInformation      *s6a.TerminalInformation `avp:"Terminal-Information,omitempty"`
+	EmergencyServices        *uint32                  `avp:"Emergency-Services,omitempty"`
+}
+
+// TGPPDEA is the DEA of the STa and SWm applications, which adds the
+// 3GPP AVPs of 3GPP TS 29.273 sections 5.2.2.1.1 and 7.2.2.1.1 to DEA.
+// All of them are optional, and only the embedded DEA is used with the
+// Diameter EAP application.
+type TGPPDEA struct {
+	DEA
+	ExperimentalResult          *base.ExperimentalResult `avp:"Experimental-Result,omitempty"`
+	ContextIdentifier           uint32                   `avp:"Context-Identifier,omitempty"`
+	APNOIReplacement            string                   `avp:"APN-OI-Replacement,omitempty"`
+	APNConfiguration            []s6a.APNConfiguration   `avp:"APN-Configuration,omitempty"`
+	MIP6FeatureVector           uint64                   `avp:"MIP6-Feature-Vector,omitempty"`
+	MobileNodeIdentifier        string                   `avp:"Mobile-Node-Identifier,omitempty"`
+	SubscriptionID              *gy.SubscriptionID       `avp:"Subscription-Id,omitempty"`
+	TGPPChargingCharacteristics string                   `avp:"TGPP-Charging-Characteristics,omitempty"`
+	ANTrusted                   *int32                   `avp:"AN-Trusted,omitempty"`
+	SupportedFeatures           []base.SupportedFeatures `avp:"Supported-Features,omitempty"`
+}
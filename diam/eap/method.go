@@ -0,0 +1,107 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package eap
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+)
+
+// ErrAuthenticationFailed is returned by a Method when the peer fails to
+// authenticate.
+var ErrAuthenticationFailed = errors.New("eap: authentication failed")
+
+// A Method is the server side of an EAP authentication method. A new
+// Method is used for each conversation, and its methods are never
+// called concurrently.
+type Method interface {
+	// Type returns the EAP method type.
+	Type() uint8
+
+	// Start fills in the Data of req, the first request of the method.
+	// Its Code, Identifier and Type are set by the Server.
+	Start(req *Packet) error
+
+	// Process handles resp, a response of the method type. It returns
+	// true when the peer is authenticated, or fills in the Data of req,
+	// the next request, and returns false. Authentication failures are
+	// reported as errors.
+	Process(resp, req *Packet) (done bool, err error)
+
+	// MSK returns the Master Session Key exported by the method after a
+	// successful authentication, or nil when the method derives no keys.
+	MSK() []byte
+}
+
+// MD5 is the MD5-Challenge method of RFC 3748 section 5.4. It verifies
+// that the peer knows Password and derives no keys.
+type MD5 struct {
+	Password []byte
+	Name     string    // Optional, sent in the challenge.
+	Rand     io.Reader // Source of challenges. Defaults to crypto/rand.
+
+	id        uint8
+	challenge []byte
+}
+
+// Type implements the Method interface.
+func (m *MD5) Type() uint8 { return TypeMD5Challenge }
+
+// Start implements the Method interface.
+func (m *MD5) Start(req *Packet) error {
+	r := m.Rand
+	if r == nil {
+		r = rand.Reader
+	}
+	m.challenge = make([]byte, 16)
+	if _, err := io.ReadFull(r, m.challenge); err != nil {
+		return err
+	}
+	m.id = req.Identifier
+	req.Data = make([]byte, 0, 1+len(m.challenge)+len(m.Name))
+	req.Data = append(req.Data, byte(len(m.challenge)))
+	req.Data = append(req.Data, m.challenge...)
+	req.Data = append(req.Data, m.Name...)
+	return nil
+}
+
+// Process implements the Method interface.
+func (m *MD5) Process(resp, req *Packet) (bool, error) {
+	if len(resp.Data) < 1 || int(resp.Data[0]) != md5.Size || len(resp.Data) < 1+md5.Size {
+		return false, ErrInvalidPacket
+	}
+	want := md5Response(m.id, m.Password, m.challenge)
+	if subtle.ConstantTimeCompare(resp.Data[1:1+md5.Size], want) != 1 {
+		return false, ErrAuthenticationFailed
+	}
+	return true, nil
+}
+
+// MSK implements the Method interface. MD5-Challenge derives no keys.
+func (m *MD5) MSK() []byte { return nil }
+
+// MD5Response returns the peer's response to the MD5-Challenge request
+// req, for the given password.
+func MD5Response(req *Packet, password []byte) (*Packet, error) {
+	if req.Code != CodeRequest || req.Type != TypeMD5Challenge || len(req.Data) < 1 || len(req.Data) < 1+int(req.Data[0]) {
+		return nil, ErrInvalidPacket
+	}
+	challenge := req.Data[1 : 1+int(req.Data[0])]
+	data := append([]byte{md5.Size}, md5Response(req.Identifier, password, challenge)...)
+	return &Packet{Code: CodeResponse, Identifier: req.Identifier, Type: TypeMD5Challenge, Data: data}, nil
+}
+
+// md5Response computes MD5(Identifier || password || challenge).
+// See RFC 1994 section 4.1.
+func md5Response(id uint8, password, challenge []byte) []byte {
+	h := md5.New()
+	h.Write([]byte{id})
+	h.Write(password)
+	h.Write(challenge)
+	return h.Sum(nil)
+}
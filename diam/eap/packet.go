@@ -0,0 +1,105 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package eap
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPacket is returned when an EAP packet cannot be parsed.
+var ErrInvalidPacket = errors.New("eap: invalid packet")
+
+// EAP packet codes. See RFC 3748 section 4.
+const (
+	CodeRequest  = 1
+	CodeResponse = 2
+	CodeSuccess  = 3
+	CodeFailure  = 4
+)
+
+// EAP method types. See RFC 3748 section 5 and the IANA EAP registry.
+const (
+	TypeIdentity     = 1
+	TypeNotification = 2
+	TypeNak          = 3
+	TypeMD5Challenge = 4
+	TypeAKA          = 23
+	TypeAKAPrime     = 50
+)
+
+// Packet is an EAP packet, as carried in the EAP-Payload AVP.
+// See RFC 3748 section 4.
+type Packet struct {
+	Code       uint8
+	Identifier uint8
+	Type       uint8  // Requests and responses only.
+	Data       []byte // Type-Data.
+}
+
+// ParsePacket parses the EAP packet b.
+func ParsePacket(b []byte) (*Packet, error) {
+	if len(b) < 4 {
+		return nil, ErrInvalidPacket
+	}
+	n := int(binary.BigEndian.Uint16(b[2:4]))
+	if n < 4 || n > len(b) {
+		return nil, ErrInvalidPacket
+	}
+	p := &Packet{Code: b[0], Identifier: b[1]}
+	switch p.Code {
+	case CodeRequest, CodeResponse:
+		if n < 5 {
+			return nil, ErrInvalidPacket
+		}
+		p.Type = b[4]
+		p.Data = append([]byte(nil), b[5:n]...)
+	case CodeSuccess, CodeFailure:
+		if n != 4 {
+			return nil, ErrInvalidPacket
+		}
+	default:
+		return nil, ErrInvalidPacket
+	}
+	return p, nil
+}
+
+// Len returns the length of the packet in bytes.
+func (p *Packet) Len() int {
+	if p.Code == CodeSuccess || p.Code == CodeFailure {
+		return 4
+	}
+	return 5 + len(p.Data)
+}
+
+// Serialize returns the packet in wire format.
+func (p *Packet) Serialize() []byte {
+	n := p.Len()
+	b := make([]byte, 4, n)
+	b[0] = p.Code
+	b[1] = p.Identifier
+	binary.BigEndian.PutUint16(b[2:4], uint16(n))
+	if n > 4 {
+		b = append(b, p.Type)
+		b = append(b, p.Data...)
+	}
+	return b
+}
+
+// String returns a short description of the packet, for logging.
+func (p *Packet) String() string {
+	switch p.Code {
+	case CodeRequest:
+		return fmt.Sprintf("EAP-Request id=%d type=%d len=%d", p.Identifier, p.Type, p.Len())
+	case CodeResponse:
+		return fmt.Sprintf("EAP-Response id=%d type=%d len=%d", p.Identifier, p.Type, p.Len())
+	case CodeSuccess:
+		return fmt.Sprintf("EAP-Success id=%d", p.Identifier)
+	case CodeFailure:
+		return fmt.Sprintf("EAP-Failure id=%d", p.Identifier)
+	}
+	return fmt.Sprintf("EAP code=%d id=%d", p.Code, p.Identifier)
+}
@@ -0,0 +1,238 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package eap
+
+import (
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// DefaultMultiRoundTimeout is how long the Server keeps a conversation
+// waiting for the next DER when no MultiRoundTimeout is configured.
+const DefaultMultiRoundTimeout = 30 * time.Second
+
+// A Backend selects the EAP method of each conversation and authorizes
+// authenticated users.
+//
+// With the Diameter EAP application only the embedded DER and DEA are
+// decoded and sent; the 3GPP AVPs are used with STa and SWm.
+type Backend interface {
+	// Method returns the method used to authenticate identity, taken
+	// from the peer's EAP-Response/Identity. An error rejects the
+	// authentication.
+	Method(identity string, der *TGPPDER) (Method, error)
+
+	// Authorize is called once the method succeeds, with the last DER
+	// and its answer, which carries DIAMETER_SUCCESS, User-Name and the
+	// EAP-Master-Session-Key. It may add authorization AVPs, or reject
+	// the user by changing the Result-Code or setting an
+	// Experimental-Result, in which case the EAP-Success is replaced by
+	// an EAP-Failure.
+	Authorize(der *TGPPDER, dea *TGPPDEA)
+}
+
+// Server is the Diameter EAP server of RFC 4072, also used as the 3GPP
+// AAA server of STa and SWm. It relays the EAP conversation of each
+// Session-Id across DER/DEA round trips, running the Method returned by
+// its Backend.
+//
+// The first DER of a session must carry an EAP-Response/Identity, or an
+// empty EAP-Payload, in which case an EAP-Request/Identity is sent.
+// Intermediate answers have DIAMETER_MULTI_ROUND_AUTH and the final one
+// DIAMETER_SUCCESS with an EAP-Success, or DIAMETER_AUTHENTICATION_REJECTED
+// with an EAP-Failure. Conversations are dropped when no DER arrives
+// within MultiRoundTimeout.
+//
+// Server implements the diam.Handler interface and must be registered
+// for DERIndex, STaDERIndex or SWmDERIndex on the connection's handler.
+type Server struct {
+	OriginHost        datatype.DiameterIdentity
+	OriginRealm       datatype.DiameterIdentity
+	Backend           Backend
+	MultiRoundTimeout time.Duration // Defaults to DefaultMultiRoundTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	mu            sync.Mutex
+	conversations map[string]*conversation // By Session-Id.
+}
+
+type conversation struct {
+	mu       sync.Mutex
+	identity string
+	method   Method  // Nil until the identity is known.
+	last     *Packet // Last request sent.
+	expires  time.Time
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 || m.Header.CommandCode != diam.DiameterEAP {
+		return
+	}
+	tgpp := m.Header.ApplicationID == diam.TGPP_STA_APP_ID || m.Header.ApplicationID == diam.TGPP_SWM_APP_ID
+	var der TGPPDER
+	dea := &TGPPDEA{}
+	var err error
+	if tgpp {
+		err = m.Unmarshal(&der)
+	} else {
+		err = m.Unmarshal(&der.DER)
+	}
+	if err != nil {
+		dea.ResultCode = diam.UnableToComply
+	} else {
+		s.serve(&der, dea)
+	}
+	dea.SessionID = der.SessionID
+	dea.AuthApplicationID = m.Header.ApplicationID
+	dea.AuthRequestType = der.AuthRequestType
+	dea.OriginHost = s.OriginHost
+	dea.OriginRealm = s.OriginRealm
+	if dea.ExperimentalResult != nil {
+		dea.ResultCode = 0
+	}
+	if tgpp {
+		answer(c, m, dea, s.ErrorReporter)
+	} else {
+		answer(c, m, &dea.DEA, s.ErrorReporter)
+	}
+}
+
+// serve runs one round of the conversation of der.
+func (s *Server) serve(der *TGPPDER, dea *TGPPDEA) {
+	conv := s.conversation(der.SessionID)
+	conv.mu.Lock()
+	defer conv.mu.Unlock()
+	if len(der.EAPPayload) == 0 {
+		if conv.method != nil {
+			s.fail(der.SessionID, conv, dea, 0)
+			return
+		}
+		s.challenge(conv, dea, &Packet{Code: CodeRequest, Identifier: 0, Type: TypeIdentity})
+		return
+	}
+	resp, err := ParsePacket([]byte(der.EAPPayload))
+	if err != nil || resp.Code != CodeResponse {
+		s.drop(der.SessionID)
+		dea.ResultCode = diam.InvalidAVPValue
+		return
+	}
+	if conv.last != nil && resp.Identifier != conv.last.Identifier {
+		// Not an answer to our last request: send it again.
+		s.challenge(conv, dea, conv.last)
+		return
+	}
+	req := &Packet{Code: CodeRequest, Identifier: resp.Identifier + 1}
+	if conv.method == nil {
+		if resp.Type != TypeIdentity || s.Backend == nil {
+			s.fail(der.SessionID, conv, dea, resp.Identifier)
+			return
+		}
+		conv.identity = string(resp.Data)
+		if len(conv.identity) == 0 {
+			conv.identity = der.UserName
+		}
+		conv.method, err = s.Backend.Method(conv.identity, der)
+		if err != nil || conv.method == nil {
+			conv.method = nil
+			s.fail(der.SessionID, conv, dea, resp.Identifier)
+			return
+		}
+		req.Type = conv.method.Type()
+		if err = conv.method.Start(req); err != nil {
+			s.fail(der.SessionID, conv, dea, resp.Identifier)
+			return
+		}
+		s.challenge(conv, dea, req)
+		return
+	}
+	if resp.Type != conv.method.Type() {
+		// Including Nak: a single method is offered.
+		s.fail(der.SessionID, conv, dea, resp.Identifier)
+		return
+	}
+	req.Type = conv.method.Type()
+	done, err := conv.method.Process(resp, req)
+	switch {
+	case err != nil:
+		s.fail(der.SessionID, conv, dea, resp.Identifier)
+	case !done:
+		s.challenge(conv, dea, req)
+	default:
+		s.drop(der.SessionID)
+		dea.ResultCode = diam.Success
+		dea.UserName = conv.identity
+		dea.EAPMasterSessionKey = datatype.OctetString(conv.method.MSK())
+		dea.AccountingEAPAuthMethod = uint64(conv.method.Type())
+		s.Backend.Authorize(der, dea)
+		if dea.ResultCode != diam.Success || dea.ExperimentalResult != nil {
+			dea.EAPMasterSessionKey = ""
+			dea.EAPPayload = datatype.OctetString((&Packet{Code: CodeFailure, Identifier: resp.Identifier}).Serialize())
+			return
+		}
+		dea.EAPPayload = datatype.OctetString((&Packet{Code: CodeSuccess, Identifier: resp.Identifier}).Serialize())
+	}
+}
+
+// challenge answers with the request req and keeps the conversation.
+func (s *Server) challenge(conv *conversation, dea *TGPPDEA, req *Packet) {
+	timeout := s.MultiRoundTimeout
+	if timeout <= 0 {
+		timeout = DefaultMultiRoundTimeout
+	}
+	conv.last = req
+	conv.expires = time.Now().Add(timeout)
+	dea.ResultCode = diam.MultiRoundAuth
+	dea.EAPPayload = datatype.OctetString(req.Serialize())
+	dea.MultiRoundTimeOut = uint32(timeout / time.Second)
+}
+
+// fail answers with an EAP-Failure and drops the conversation.
+func (s *Server) fail(sessionID string, conv *conversation, dea *TGPPDEA, id uint8) {
+	s.drop(sessionID)
+	dea.ResultCode = diam.AuthenticationRejected
+	dea.UserName = conv.identity
+	dea.EAPPayload = datatype.OctetString((&Packet{Code: CodeFailure, Identifier: id}).Serialize())
+}
+
+// conversation returns the conversation of a Session-Id, creating it if
+// needed, and drops expired ones.
+func (s *Server) conversation(sessionID string) *conversation {
+	now := time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.conversations == nil {
+		s.conversations = make(map[string]*conversation)
+	}
+	for id, conv := range s.conversations {
+		if !conv.expires.IsZero() && now.After(conv.expires) {
+			delete(s.conversations, id)
+		}
+	}
+	conv, ok := s.conversations[sessionID]
+	if !ok {
+		conv = &conversation{}
+		s.conversations[sessionID] = conv
+	}
+	return conv
+}
+
+func (s *Server) drop(sessionID string) {
+	s.mu.Lock()
+	delete(s.conversations, sessionID)
+	s.mu.Unlock()
+}
+
+// Conversations returns the number of EAP conversations in progress.
+func (s *Server) Conversations() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.conversations)
+}
@@ -0,0 +1,95 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package pending matches Diameter answers with outstanding requests.
+//
+// It is used by the application libraries to offer blocking
+// request/answer exchanges on top of the asynchronous diam.Conn.
+package pending
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+)
+
+var (
+	// ErrTimeout is returned by Exchange when no answer arrives in time.
+	ErrTimeout = errors.New("timeout waiting for answer")
+
+	// ErrConnClosed is returned by Exchange when the connection goes
+	// away before the answer arrives.
+	ErrConnClosed = errors.New("connection closed")
+)
+
+// DefaultTimeout is how long Exchange waits for answers when no timeout
+// is given. The application libraries export it as their own.
+const DefaultTimeout = 10 * time.Second
+
+type key struct {
+	hopByHop uint32
+	endToEnd uint32
+}
+
+// Table holds the requests waiting for an answer, indexed by their
+// Hop-by-Hop and End-to-End identifiers. The zero value is ready to use.
+type Table struct {
+	mu sync.Mutex
+	m  map[key]chan *diam.Message
+}
+
+// Exchange writes the request m to c and waits up to timeout, or
+// DefaultTimeout when it is not positive, for its answer, which must be
+// handed over with Deliver.
+func (t *Table) Exchange(c diam.Conn, m *diam.Message, timeout time.Duration) (*diam.Message, error) {
+	k := key{m.Header.HopByHopID, m.Header.EndToEndID}
+	ch := make(chan *diam.Message, 1)
+	t.mu.Lock()
+	if t.m == nil {
+		t.m = make(map[key]chan *diam.Message)
+	}
+	t.m[k] = ch
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.m, k)
+		t.mu.Unlock()
+	}()
+	if _, err := m.WriteTo(c); err != nil {
+		return nil, err
+	}
+	var closed <-chan struct{}
+	if cn, ok := c.(diam.CloseNotifier); ok {
+		closed = cn.CloseNotify()
+	}
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case a := <-ch:
+		return a, nil
+	case <-closed:
+		return nil, ErrConnClosed
+	case <-timer.C:
+		return nil, ErrTimeout
+	}
+}
+
+// Deliver hands the answer m to the Exchange waiting for it, and reports
+// whether there was one.
+func (t *Table) Deliver(m *diam.Message) bool {
+	k := key{m.Header.HopByHopID, m.Header.EndToEndID}
+	t.mu.Lock()
+	ch, ok := t.m[k]
+	delete(t.m, k)
+	t.mu.Unlock()
+	if ok {
+		ch <- m
+	}
+	return ok
+}
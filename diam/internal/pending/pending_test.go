@@ -0,0 +1,52 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package pending
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+func TestExchange(t *testing.T) {
+	var drop atomic.Bool
+	srv := diamtest.NewServer(diam.HandlerFunc(func(c diam.Conn, m *diam.Message) {
+		if !drop.Load() {
+			m.Answer(diam.Success).WriteTo(c)
+		}
+	}), dict.Default)
+	defer srv.Close()
+
+	var tbl Table
+	c, err := diam.Dial(srv.Addr, diam.HandlerFunc(func(c diam.Conn, m *diam.Message) {
+		tbl.Deliver(m)
+	}), dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	req := diam.NewRequest(diam.DeviceWatchdog, 0, dict.Default)
+	a, err := tbl.Exchange(c, req, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Header.HopByHopID != req.Header.HopByHopID || a.Header.CommandFlags&diam.RequestFlag != 0 {
+		t.Fatalf("Unexpected answer: %s", a)
+	}
+
+	drop.Store(true)
+	req = diam.NewRequest(diam.DeviceWatchdog, 0, dict.Default)
+	if _, err = tbl.Exchange(c, req, 50*time.Millisecond); err != ErrTimeout {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrTimeout, err)
+	}
+	if tbl.Deliver(req.Answer(diam.Success)) {
+		t.Fatal("Late answer was delivered")
+	}
+}
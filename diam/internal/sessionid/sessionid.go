@@ -0,0 +1,24 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package sessionid generates the Session-Id of the sessions started by
+// the application libraries.
+package sessionid
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+var seq uint32
+
+// New returns a new Session-Id for the host, in the form
+// <DiameterIdentity>;<high 32 bits>;<low 32 bits> of RFC 6733 section
+// 8.8, with the current time and a sequence number.
+func New(host datatype.DiameterIdentity) string {
+	return fmt.Sprintf("%s;%d;%d", string(host), time.Now().Unix(), atomic.AddUint32(&seq, 1))
+}
@@ -0,0 +1,20 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sessionid
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	a, b := New("client"), New("client")
+	if a == b {
+		t.Fatalf("Duplicate Session-Id: %s", a)
+	}
+	if parts := strings.Split(a, ";"); len(parts) != 3 || parts[0] != "client" {
+		t.Fatalf("Unexpected Session-Id: %s", a)
+	}
+}
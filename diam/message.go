@@ -169,6 +169,11 @@ func (m *Message) decodeAVPs(b []byte) error {
 		m.AVP = append(m.AVP, a)
 		n += a.Len()
 	}
+	if m.Dictionary().CheckPositions {
+		if err = m.checkPositions(); err != nil {
+			decodeErrs = append(decodeErrs, err.Error())
+		}
+	}
 	if len(decodeErrs) > 0 {
 		// Depending on the settings, this will be thrown by the state machine or passed to the best handler
 		m.DecodeErr = fmt.Errorf("Failed to decode one or more AVPs: {%s}", strings.Join(decodeErrs, "; "))
@@ -371,11 +376,13 @@ func (m *Message) Serialize() ([]byte, error) {
 	return b, nil
 }
 
-// SerializeTo writes the serialized bytes of the Message into b.
+// SerializeTo writes the serialized bytes of the Message into b, with its
+// fixed position AVPs in place. See Normalize. The Message is not
+// modified.
 func (m *Message) SerializeTo(b []byte) (err error) {
 	m.Header.SerializeTo(b[0:HeaderLength])
 	offset := HeaderLength
-	for _, avp := range m.AVP {
+	for _, avp := range m.normalized() {
 		if err = avp.SerializeTo(b[offset:]); err != nil {
 			return err
 		}
@@ -0,0 +1,189 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package nasreq
+
+import (
+	"crypto/md5"
+	"fmt"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+)
+
+// DefaultTimeout is how long the Client and the Server wait for answers
+// when no Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+// NewCHAPAuth returns the CHAP-Auth of a user answering challenge with
+// the CHAP identifier ident. The challenge must be sent along in the
+// CHAP-Challenge of the AAR.
+func NewCHAPAuth(ident uint8, password, challenge []byte) *CHAPAuth {
+	return &CHAPAuth{
+		CHAPAlgorithm: CHAPAlgorithmMD5,
+		CHAPIdent:     datatype.OctetString([]byte{ident}),
+		CHAPResponse:  datatype.OctetString(chapResponse(ident, password, challenge)),
+	}
+}
+
+// chapResponse computes MD5(ident || password || challenge).
+// See RFC 1994 section 4.1.
+func chapResponse(ident uint8, password, challenge []byte) []byte {
+	h := md5.New()
+	h.Write([]byte{ident})
+	h.Write(password)
+	h.Write(challenge)
+	return h.Sum(nil)
+}
+
+// Client is the NAS side of the Network Access application. It sends
+// AAR and STR to the server and answers the RAR and ASR it sends.
+//
+// Requests are completed by the Client before being sent: Session-Id is
+// generated when empty, and the routing AVPs and Auth-Application-Id are
+// filled in. The AARs and the STR of a session must use the Session-Id
+// of the first AAR.
+//
+// Client implements the diam.Handler interface and must be registered
+// for AAAIndex, STAIndex, RARIndex and ASRIndex on the connection's
+// handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// The following functions, if non-nil, are called for requests sent
+	// by the server. The answer is filled in with DIAMETER_SUCCESS on
+	// entry and may be changed. They run on the connection's read
+	// goroutine and must not block: the AAR of a re-authorization or the
+	// STR of an aborted session must be sent from another goroutine.
+	OnReAuth       func(rar *RAR, raa *RAA)
+	OnAbortSession func(asr *ASR, asa *ASA)
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending pending.Table
+}
+
+// AA sends an AAR built from aar over c and waits for the AAA.
+// Auth-Request-Type defaults to AUTHORIZE_AUTHENTICATE. Users are
+// authenticated with PAP by setting User-Password, or with CHAP by
+// setting CHAP-Auth, see NewCHAPAuth, and CHAP-Challenge.
+func (cli *Client) AA(c diam.Conn, aar *AAR) (*AAA, error) {
+	if len(aar.SessionID) == 0 {
+		aar.SessionID = sessionid.New(cli.OriginHost)
+	}
+	aar.AuthApplicationID = diam.NETWORK_ACCESS_APP_ID
+	aar.OriginHost = cli.OriginHost
+	aar.OriginRealm = cli.OriginRealm
+	aar.DestinationRealm = cli.DestinationRealm
+	aar.DestinationHost = cli.DestinationHost
+	if aar.AuthRequestType == 0 {
+		aar.AuthRequestType = AuthorizeAuthenticate
+	}
+	var aaa AAA
+	if err := cli.exchange(c, diam.AA, aar, &aaa); err != nil {
+		return nil, err
+	}
+	return &aaa, nil
+}
+
+// SessionTermination sends an STR built from str over c and waits for
+// the STA. Termination-Cause defaults to DIAMETER_LOGOUT.
+func (cli *Client) SessionTermination(c diam.Conn, str *STR) (*STA, error) {
+	str.AuthApplicationID = diam.NETWORK_ACCESS_APP_ID
+	str.OriginHost = cli.OriginHost
+	str.OriginRealm = cli.OriginRealm
+	str.DestinationRealm = cli.DestinationRealm
+	str.DestinationHost = cli.DestinationHost
+	if str.TerminationCause == 0 {
+		str.TerminationCause = Logout
+	}
+	var sta STA
+	if err := cli.exchange(c, diam.SessionTermination, str, &sta); err != nil {
+		return nil, err
+	}
+	return &sta, nil
+}
+
+func (cli *Client) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.NETWORK_ACCESS_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := cli.pending.Exchange(c, m, cli.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.ReAuth:
+		var rar RAR
+		raa := &RAA{}
+		if decode(m, &rar, &raa.ResultCode) {
+			raa.UserName = rar.UserName
+			if cli.OnReAuth != nil {
+				cli.OnReAuth(&rar, raa)
+			}
+		}
+		raa.SessionID = rar.SessionID
+		raa.OriginHost = cli.OriginHost
+		raa.OriginRealm = cli.OriginRealm
+		answer(c, m, raa, cli.ErrorReporter)
+	case diam.AbortSession:
+		var asr ASR
+		asa := &ASA{}
+		if decode(m, &asr, &asa.ResultCode) {
+			asa.UserName = asr.UserName
+			if cli.OnAbortSession != nil {
+				cli.OnAbortSession(&asr, asa)
+			}
+		}
+		asa.SessionID = asr.SessionID
+		asa.OriginHost = cli.OriginHost
+		asa.OriginRealm = cli.OriginRealm
+		answer(c, m, asa, cli.ErrorReporter)
+	}
+}
+
+// decode decodes the request m into req and sets the Result-Code of
+// its answer, reporting whether the request is valid.
+func decode(m *diam.Message, req interface{}, resultCode *uint32) bool {
+	if err := m.Unmarshal(req); err != nil {
+		*resultCode = diam.UnableToComply
+		return false
+	}
+	*resultCode = diam.Success
+	return true
+}
+
+// answer writes the answer v to the request m.
+func answer(c diam.Conn, m *diam.Message, v interface{}, er diam.ErrorReporter) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && er != nil {
+		er.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
@@ -0,0 +1,35 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package nasreq implements the Diameter Network Access Server
+// application (NASREQ) of RFC 7155.
+//
+// It provides typed AAR/AAA, RAR/RAA, STR/STA and ASR/ASA messages, a
+// Server that authenticates users with PAP or CHAP against pluggable
+// Credentials, authorizes them with a Policy callback and keeps track of
+// their authorization sessions, and a Client for the NAS side.
+//
+// A server assigning addresses and filters to its users:
+//
+//	srv := &nasreq.Server{
+//		OriginHost:  "aaa.example.com",
+//		OriginRealm: "example.com",
+//		Credentials: nasreq.CredentialsFunc(func(user string) ([]byte, error) {
+//			if p, ok := passwords[user]; ok {
+//				return p, nil
+//			}
+//			return nil, nasreq.ErrUnknownUser
+//		}),
+//		Policy: func(aar *nasreq.AAR, aaa *nasreq.AAA) {
+//			aaa.FramedIPAddress = pool.Get(aar.UserName)
+//			aaa.FilterID = []string{"users"}
+//		},
+//		AuthorizationLifetime: time.Hour,
+//		AuthGracePeriod:       time.Minute,
+//	}
+//	mux := sm.New(settings)
+//	for _, idx := range []diam.CommandIndex{nasreq.AARIndex, nasreq.STRIndex, nasreq.RAAIndex, nasreq.ASAIndex} {
+//		mux.HandleIdx(idx, srv)
+//	}
+package nasreq
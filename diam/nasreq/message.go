@@ -0,0 +1,214 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package nasreq
+
+import (
+	"net"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// Command indexes of the Network Access application, for use with
+// ServeMux.HandleIdx.
+var (
+	AARIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.AA, Request: true}
+	AAAIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.AA, Request: false}
+	RARIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.ReAuth, Request: true}
+	RAAIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.ReAuth, Request: false}
+	STRIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.SessionTermination, Request: true}
+	STAIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.SessionTermination, Request: false}
+	ASRIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.AbortSession, Request: true}
+	ASAIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.AbortSession, Request: false}
+)
+
+// Auth-Request-Type values. See RFC 6733 section 8.7.
+const (
+	AuthenticateOnly      = 1
+	AuthorizeOnly         = 2
+	AuthorizeAuthenticate = 3
+)
+
+// Auth-Session-State values. See RFC 6733 section 8.11.
+const (
+	StateMaintained   = 0
+	NoStateMaintained = 1
+)
+
+// Re-Auth-Request-Type values. See RFC 6733 section 8.12.
+const (
+	ReAuthAuthorizeOnly         = 0
+	ReAuthAuthorizeAuthenticate = 1
+)
+
+// Termination-Cause values. See RFC 6733 section 8.15.
+const (
+	Logout             = 1
+	ServiceNotProvided = 2
+	BadAnswer          = 3
+	Administrative     = 4
+	LinkBroken         = 5
+	AuthExpired        = 6
+	UserMoved          = 7
+	SessionTimeout     = 8
+)
+
+// Service-Type values. See RFC 2865 section 5.6.
+const (
+	ServiceTypeLogin  = 1
+	ServiceTypeFramed = 2
+)
+
+// Framed-Protocol values. See RFC 2865 section 5.7.
+const (
+	FramedProtocolPPP  = 1
+	FramedProtocolSLIP = 2
+)
+
+// CHAPAlgorithmMD5 is the only CHAP-Algorithm value, CHAP with MD5.
+// See RFC 7155 section 4.3.3.
+const CHAPAlgorithmMD5 = 5
+
+// CHAPAuth is the CHAP-Auth grouped AVP, the CHAP identifier and
+// response of the user. See RFC 7155 section 4.3.2.
+type CHAPAuth struct {
+	CHAPAlgorithm int32                `avp:"CHAP-Algorithm"`
+	CHAPIdent     datatype.OctetString `avp:"CHAP-Ident"`
+	CHAPResponse  datatype.OctetString `avp:"CHAP-Response,omitempty"`
+}
+
+// AAR is an AA-Request message, sent by the NAS to authenticate and
+// authorize a user. See RFC 7155 section 3.1.
+type AAR struct {
+	SessionID             string                    `avp:"Session-Id"`
+	AuthApplicationID     uint32                    `avp:"Auth-Application-Id"`
+	OriginHost            datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm           datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm      datatype.DiameterIdentity `avp:"Destination-Realm"`
+	AuthRequestType       int32                     `avp:"Auth-Request-Type"`
+	DestinationHost       datatype.DiameterIdentity `avp:"Destination-Host,omitempty"`
+	NASIdentifier         string                    `avp:"NAS-Identifier,omitempty"`
+	NASIPAddress          net.IP                    `avp:"NAS-IP-Address,omitempty"`
+	NASIPv6Address        net.IP                    `avp:"NAS-IPv6-Address,omitempty"`
+	NASPort               uint32                    `avp:"NAS-Port,omitempty"`
+	NASPortID             string                    `avp:"NAS-Port-Id,omitempty"`
+	NASPortType           *int32                    `avp:"NAS-Port-Type,omitempty"`
+	UserName              string                    `avp:"User-Name,omitempty"`
+	UserPassword          datatype.OctetString      `avp:"User-Password,omitempty"`
+	ServiceType           *int32                    `avp:"Service-Type,omitempty"`
+	State                 datatype.OctetString      `avp:"State,omitempty"`
+	AuthorizationLifetime *uint32                   `avp:"Authorization-Lifetime,omitempty"`
+	AuthGracePeriod       *uint32                   `avp:"Auth-Grace-Period,omitempty"`
+	AuthSessionState      *int32                    `avp:"Auth-Session-State,omitempty"`
+	CalledStationID       string                    `avp:"Called-Station-Id,omitempty"`
+	CallingStationID      string                    `avp:"Calling-Station-Id,omitempty"`
+	CHAPAuth              *CHAPAuth                 `avp:"CHAP-Auth,omitempty"`
+	CHAPChallenge         datatype.OctetString      `avp:"CHAP-Challenge,omitempty"`
+	FramedIPAddress       net.IP                    `avp:"Framed-IP-Address,omitempty"`
+	FramedProtocol        *int32                    `avp:"Framed-Protocol,omitempty"`
+}
+
+// AAA is an AA-Answer message, sent by the server with the result of
+// the authentication and the authorization of the user. See RFC 7155
+// section 3.2.
+type AAA struct {
+	SessionID             string                    `avp:"Session-Id"`
+	AuthApplicationID     uint32                    `avp:"Auth-Application-Id"`
+	AuthRequestType       int32                     `avp:"Auth-Request-Type"`
+	ResultCode            uint32                    `avp:"Result-Code"`
+	OriginHost            datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm           datatype.DiameterIdentity `avp:"Origin-Realm"`
+	UserName              string                    `avp:"User-Name,omitempty"`
+	ServiceType           *int32                    `avp:"Service-Type,omitempty"`
+	Class                 []datatype.OctetString    `avp:"Class,omitempty"`
+	ErrorMessage          string                    `avp:"Error-Message,omitempty"`
+	IdleTimeout           uint32                    `avp:"Idle-Timeout,omitempty"`
+	AuthorizationLifetime *uint32                   `avp:"Authorization-Lifetime,omitempty"`
+	AuthGracePeriod       *uint32                   `avp:"Auth-Grace-Period,omitempty"`
+	AuthSessionState      *int32                    `avp:"Auth-Session-State,omitempty"`
+	SessionTimeout        uint32                    `avp:"Session-Timeout,omitempty"`
+	State                 datatype.OctetString      `avp:"State,omitempty"`
+	ReplyMessage          []string                  `avp:"Reply-Message,omitempty"`
+	FilterID              []string                  `avp:"Filter-Id,omitempty"`
+	FramedIPAddress       net.IP                    `avp:"Framed-IP-Address,omitempty"`
+	FramedIPNetmask       net.IP                    `avp:"Framed-IP-Netmask,omitempty"`
+	FramedMTU             uint32                    `avp:"Framed-MTU,omitempty"`
+	FramedProtocol        *int32                    `avp:"Framed-Protocol,omitempty"`
+}
+
+// RAR is a Re-Auth-Request message, sent by the server to make the NAS
+// re-authorize a session. See RFC 7155 section 3.3.
+type RAR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	ReAuthRequestType int32                     `avp:"Re-Auth-Request-Type"`
+	UserName          string                    `avp:"User-Name,omitempty"`
+	State             datatype.OctetString      `avp:"State,omitempty"`
+	Class             []datatype.OctetString    `avp:"Class,omitempty"`
+	ReplyMessage      string                    `avp:"Reply-Message,omitempty"`
+}
+
+// RAA is a Re-Auth-Answer message. See RFC 7155 section 3.4.
+type RAA struct {
+	SessionID    string                    `avp:"Session-Id"`
+	ResultCode   uint32                    `avp:"Result-Code"`
+	OriginHost   datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm  datatype.DiameterIdentity `avp:"Origin-Realm"`
+	UserName     string                    `avp:"User-Name,omitempty"`
+	ErrorMessage string                    `avp:"Error-Message,omitempty"`
+}
+
+// STR is a Session-Termination-Request message, sent by the NAS when a
+// session ends. See RFC 7155 section 3.5.
+type STR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	TerminationCause  int32                     `avp:"Termination-Cause"`
+	UserName          string                    `avp:"User-Name,omitempty"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host,omitempty"`
+	Class             []datatype.OctetString    `avp:"Class,omitempty"`
+}
+
+// STA is a Session-Termination-Answer message. See RFC 7155 section 3.6.
+type STA struct {
+	SessionID    string                    `avp:"Session-Id"`
+	ResultCode   uint32                    `avp:"Result-Code"`
+	OriginHost   datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm  datatype.DiameterIdentity `avp:"Origin-Realm"`
+	UserName     string                    `avp:"User-Name,omitempty"`
+	Class        []datatype.OctetString    `avp:"Class,omitempty"`
+	ErrorMessage string                    `avp:"Error-Message,omitempty"`
+}
+
+// ASR is an Abort-Session-Request message, sent by the server to make
+// the NAS stop a session. See RFC 7155 section 3.7.
+type ASR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	UserName          string                    `avp:"User-Name,omitempty"`
+	State             datatype.OctetString      `avp:"State,omitempty"`
+	ReplyMessage      []string                  `avp:"Reply-Message,omitempty"`
+}
+
+// ASA is an Abort-Session-Answer message. See RFC 7155 section 3.8.
+type ASA struct {
+	SessionID    string                    `avp:"Session-Id"`
+	ResultCode   uint32                    `avp:"Result-Code"`
+	OriginHost   datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm  datatype.DiameterIdentity `avp:"Origin-Realm"`
+	UserName     string                    `avp:"User-Name,omitempty"`
+	ErrorMessage string                    `avp:"Error-Message,omitempty"`
+}
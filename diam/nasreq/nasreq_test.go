@@ -0,0 +1,276 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package nasreq
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+)
+
+var testPasswords = map[string][]byte{
+	"alice": []byte("secret"),
+	"bob":   []byte("hunter2"),
+}
+
+// dial starts a Server and returns a Client connected to it.
+func dial(t *testing.T, srv *Server, nas *Client) (diam.Conn, func()) {
+	t.Helper()
+	srv.OriginHost = "aaa"
+	srv.OriginRealm = "test"
+	if srv.Credentials == nil {
+		srv.Credentials = CredentialsFunc(func(user string) ([]byte, error) {
+			if p, ok := testPasswords[user]; ok {
+				return p, nil
+			}
+			return nil, ErrUnknownUser
+		})
+	}
+	nas.OriginHost = "nas"
+	nas.OriginRealm = "test"
+	nas.DestinationRealm = "test"
+	nas.Timeout = time.Second
+	return smtest.Connect(t,
+		smtest.Peer{Host: "aaa", Handler: srv, Commands: []diam.CommandIndex{AARIndex, STRIndex, RAAIndex, ASAIndex}},
+		smtest.Peer{Host: "nas", Handler: nas, Commands: []diam.CommandIndex{AAAIndex, STAIndex, RARIndex, ASRIndex}},
+		0, diam.NETWORK_ACCESS_APP_ID)
+}
+
+func testPolicy(aar *AAR, aaa *AAA) {
+	if aar.UserName == "bob" {
+		aaa.ResultCode = diam.AuthorizationRejected
+		return
+	}
+	aaa.FramedIPAddress = net.ParseIP("10.0.0.1").To4()
+	aaa.FilterID = []string{"users", "web-only"}
+}
+
+func TestClientServer_PAP(t *testing.T) {
+	srv := &Server{Policy: testPolicy, AuthorizationLifetime: time.Hour, AuthGracePeriod: time.Minute}
+	nas := &Client{}
+	c, done := dial(t, srv, nas)
+	defer done()
+
+	aaa, err := nas.AA(c, &AAR{
+		UserName:     "alice",
+		UserPassword: "secret",
+		NASIPAddress: net.ParseIP("192.168.0.1").To4(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aaa.ResultCode != diam.Success || aaa.UserName != "alice" || aaa.AuthRequestType != AuthorizeAuthenticate {
+		t.Fatalf("Unexpected AAA: %+v", aaa)
+	}
+	if !aaa.FramedIPAddress.Equal(net.ParseIP("10.0.0.1")) || !reflect.DeepEqual(aaa.FilterID, []string{"users", "web-only"}) {
+		t.Fatalf("Unexpected authorization: %v %v", aaa.FramedIPAddress, aaa.FilterID)
+	}
+	if aaa.AuthorizationLifetime == nil || *aaa.AuthorizationLifetime != 3600 ||
+		aaa.AuthGracePeriod == nil || *aaa.AuthGracePeriod != 60 ||
+		aaa.AuthSessionState == nil || *aaa.AuthSessionState != StateMaintained {
+		t.Fatalf("Unexpected lifetimes: %+v", aaa)
+	}
+	if s := srv.Sessions(); len(s) != 1 || s[0] != aaa.SessionID {
+		t.Fatalf("Unexpected sessions: %v", s)
+	}
+	if aar, last, ok := srv.Session(aaa.SessionID); !ok || aar.UserName != "alice" || last.FilterID[0] != "users" {
+		t.Fatalf("Unexpected session: %v %v %v", aar, last, ok)
+	}
+
+	// Wrong password, unknown user, rejected by the policy, no
+	// credentials.
+	for _, tc := range []struct {
+		aar  *AAR
+		code uint32
+	}{
+		{&AAR{UserName: "alice", UserPassword: "wrong"}, diam.AuthenticationRejected},
+		{&AAR{UserName: "carol", UserPassword: "secret"}, diam.AuthenticationRejected},
+		{&AAR{UserName: "bob", UserPassword: "hunter2"}, diam.AuthorizationRejected},
+		{&AAR{UserName: "alice"}, diam.MissingAVP},
+	} {
+		aaa, err := nas.AA(c, tc.aar)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if aaa.ResultCode != tc.code || aaa.FramedIPAddress != nil {
+			t.Fatalf("Unexpected AAA for %s: %+v", tc.aar.UserName, aaa)
+		}
+	}
+	if s := srv.Sessions(); len(s) != 1 {
+		t.Fatalf("Unexpected sessions: %v", s)
+	}
+
+	// Authentication only: no authorization, no session.
+	aaa, err = nas.AA(c, &AAR{UserName: "bob", UserPassword: "hunter2", AuthRequestType: AuthenticateOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aaa.ResultCode != diam.Success || aaa.AuthorizationLifetime != nil || len(srv.Sessions()) != 1 {
+		t.Fatalf("Unexpected AAA: %+v", aaa)
+	}
+
+	sta, err := nas.SessionTermination(c, &STR{SessionID: srv.Sessions()[0], UserName: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sta.ResultCode != diam.Success || len(srv.Sessions()) != 0 {
+		t.Fatalf("Unexpected STA: %+v", sta)
+	}
+	if sta, err = nas.SessionTermination(c, &STR{SessionID: sta.SessionID}); err != nil {
+		t.Fatal(err)
+	}
+	if sta.ResultCode != diam.UnknownSessionID {
+		t.Fatalf("Unexpected STA: %+v", sta)
+	}
+}
+
+func TestClientServer_CHAP(t *testing.T) {
+	srv := &Server{Policy: testPolicy}
+	nas := &Client{}
+	c, done := dial(t, srv, nas)
+	defer done()
+
+	challenge := []byte("0123456789abcdef")
+	for _, tc := range []struct {
+		password string
+		code     uint32
+	}{
+		{"secret", diam.Success},
+		{"wrong", diam.AuthenticationRejected},
+	} {
+		aaa, err := nas.AA(c, &AAR{
+			UserName:      "alice",
+			CHAPAuth:      NewCHAPAuth(7, []byte(tc.password), challenge),
+			CHAPChallenge: datatype.OctetString(challenge),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if aaa.ResultCode != tc.code {
+			t.Fatalf("Unexpected AAA with password %q: %+v", tc.password, aaa)
+		}
+	}
+	// Without a lifetime the session never expires.
+	if s := srv.Sessions(); len(s) != 1 {
+		t.Fatalf("Unexpected sessions: %v", s)
+	}
+
+	aaa, err := nas.AA(c, &AAR{UserName: "alice", CHAPAuth: NewCHAPAuth(7, []byte("secret"), challenge)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aaa.ResultCode != diam.MissingAVP {
+		t.Fatalf("Unexpected AAA without challenge: %+v", aaa)
+	}
+}
+
+func TestServer_Lifetime(t *testing.T) {
+	srv := &Server{AuthorizationLifetime: time.Hour}
+	nas := &Client{}
+	c, done := dial(t, srv, nas)
+	defer done()
+
+	// The NAS asks for a shorter lifetime than the server grants.
+	lifetime := uint32(1)
+	aaa, err := nas.AA(c, &AAR{UserName: "alice", UserPassword: "secret", AuthorizationLifetime: &lifetime})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aaa.ResultCode != diam.Success || aaa.AuthorizationLifetime == nil || *aaa.AuthorizationLifetime != 1 {
+		t.Fatalf("Unexpected AAA: %+v", aaa)
+	}
+
+	// Stateless sessions are not kept.
+	state := int32(NoStateMaintained)
+	stateless, err := nas.AA(c, &AAR{UserName: "bob", UserPassword: "hunter2", AuthSessionState: &state})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stateless.ResultCode != diam.Success || *stateless.AuthSessionState != NoStateMaintained {
+		t.Fatalf("Unexpected AAA: %+v", stateless)
+	}
+	if s := srv.Sessions(); len(s) != 1 {
+		t.Fatalf("Unexpected sessions: %v", s)
+	}
+
+	// Re-authorization extends the session.
+	reauth, err := nas.AA(c, &AAR{SessionID: aaa.SessionID, AuthRequestType: AuthorizeOnly, AuthorizationLifetime: &lifetime})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reauth.ResultCode != diam.Success || reauth.UserName != "alice" {
+		t.Fatalf("Unexpected AAA: %+v", reauth)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if s := srv.Sessions(); len(s) != 0 {
+		t.Fatalf("Unexpected sessions: %v", s)
+	}
+	reauth, err = nas.AA(c, &AAR{SessionID: aaa.SessionID, AuthRequestType: AuthorizeOnly})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reauth.ResultCode != diam.AuthorizationRejected {
+		t.Fatalf("Unexpected AAA for an expired session: %+v", reauth)
+	}
+}
+
+func TestServer_ReAuthAbortSession(t *testing.T) {
+	srv := &Server{Policy: testPolicy}
+	reauths := make(chan *RAR, 1)
+	aborts := make(chan *ASR, 1)
+	nas := &Client{
+		OnReAuth:       func(rar *RAR, raa *RAA) { reauths <- rar },
+		OnAbortSession: func(asr *ASR, asa *ASA) { aborts <- asr },
+	}
+	c, done := dial(t, srv, nas)
+	defer done()
+
+	if _, err := srv.ReAuth("unknown"); err != ErrUnknownSession {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	aaa, err := nas.AA(c, &AAR{UserName: "alice", UserPassword: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aaa.ResultCode != diam.Success {
+		t.Fatalf("Unexpected AAA: %+v", aaa)
+	}
+
+	raa, err := srv.ReAuth(aaa.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raa.ResultCode != diam.Success || raa.UserName != "alice" {
+		t.Fatalf("Unexpected RAA: %+v", raa)
+	}
+	rar := <-reauths
+	if rar.SessionID != aaa.SessionID || rar.ReAuthRequestType != ReAuthAuthorizeOnly || rar.DestinationHost != "nas" {
+		t.Fatalf("Unexpected RAR: %+v", rar)
+	}
+
+	asa, err := srv.AbortSession(aaa.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asa.ResultCode != diam.Success {
+		t.Fatalf("Unexpected ASA: %+v", asa)
+	}
+	if asr := <-aborts; asr.SessionID != aaa.SessionID || asr.UserName != "alice" {
+		t.Fatalf("Unexpected ASR: %+v", asr)
+	}
+	sta, err := nas.SessionTermination(c, &STR{SessionID: aaa.SessionID, TerminationCause: Administrative})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sta.ResultCode != diam.Success || len(srv.Sessions()) != 0 {
+		t.Fatalf("Unexpected STA: %+v", sta)
+	}
+}
@@ -0,0 +1,353 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package nasreq
+
+import (
+	"crypto/subtle"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+)
+
+var (
+	// ErrUnknownUser is returned by Credentials for users without a
+	// password.
+	ErrUnknownUser = errors.New("nasreq: unknown user")
+
+	// ErrUnknownSession is returned by the Server for sessions that are
+	// not authorized.
+	ErrUnknownSession = errors.New("nasreq: unknown session")
+)
+
+// Credentials are the passwords the Server checks PAP and CHAP
+// authentications against.
+type Credentials interface {
+	// Password returns the cleartext password of user, or
+	// ErrUnknownUser. Any error rejects the authentication.
+	Password(user string) ([]byte, error)
+}
+
+// The CredentialsFunc type is an adapter to allow the use of ordinary
+// functions as Credentials.
+type CredentialsFunc func(user string) ([]byte, error)
+
+// Password calls f(user).
+func (f CredentialsFunc) Password(user string) ([]byte, error) {
+	return f(user)
+}
+
+// Server is the Diameter server of the Network Access application. It
+// authenticates the users of AARs with their Credentials, authorizes
+// them with its Policy, and keeps track of the authorization sessions
+// until they are terminated by an STR or their lifetime expires.
+//
+// Users are authenticated with PAP when the AAR carries User-Password,
+// and with CHAP when it carries CHAP-Auth and CHAP-Challenge. An AAR of
+// type AUTHORIZE_ONLY re-authorizes an existing session, without
+// credentials, after a RAR.
+//
+// Server implements the diam.Handler interface and must be registered
+// for AARIndex, STRIndex, RAAIndex and ASAIndex on the connection's
+// handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Credentials Credentials
+
+	// Policy, if non-nil, is called for each authorization, with the AAR
+	// and its answer. The answer is filled in with DIAMETER_SUCCESS,
+	// User-Name and the granted lifetimes on entry. Policy may add
+	// authorization AVPs such as Framed-IP-Address and Filter-Id, change
+	// the lifetimes, or reject the user by changing the Result-Code.
+	Policy func(aar *AAR, aaa *AAA)
+
+	// AuthorizationLifetime and AuthGracePeriod, if non-zero, are
+	// granted to new authorizations. A shorter Authorization-Lifetime
+	// requested by the NAS is honoured. Without a lifetime, sessions are
+	// kept until terminated or their Session-Timeout expires.
+	AuthorizationLifetime time.Duration
+	AuthGracePeriod       time.Duration
+
+	Timeout time.Duration // Of RAR and ASR. Defaults to DefaultTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	sessions map[string]*session // By Session-Id.
+}
+
+type session struct {
+	conn    diam.Conn
+	aar     *AAR // First AAR of the session.
+	aaa     *AAA // Last authorization.
+	started time.Time
+	expires time.Time // Zero when the session never expires.
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.AA:
+		var aar AAR
+		aaa := &AAA{}
+		if decode(m, &aar, &aaa.ResultCode) {
+			s.aa(c, &aar, aaa)
+		}
+		aaa.SessionID = aar.SessionID
+		aaa.AuthApplicationID = diam.NETWORK_ACCESS_APP_ID
+		aaa.AuthRequestType = aar.AuthRequestType
+		aaa.OriginHost = s.OriginHost
+		aaa.OriginRealm = s.OriginRealm
+		answer(c, m, aaa, s.ErrorReporter)
+	case diam.SessionTermination:
+		var str STR
+		sta := &STA{}
+		if decode(m, &str, &sta.ResultCode) {
+			s.mu.Lock()
+			if _, ok := s.sessions[str.SessionID]; ok {
+				delete(s.sessions, str.SessionID)
+			} else {
+				sta.ResultCode = diam.UnknownSessionID
+			}
+			s.mu.Unlock()
+			sta.UserName = str.UserName
+			sta.Class = str.Class
+		}
+		sta.SessionID = str.SessionID
+		sta.OriginHost = s.OriginHost
+		sta.OriginRealm = s.OriginRealm
+		answer(c, m, sta, s.ErrorReporter)
+	}
+}
+
+// aa authenticates and authorizes the user of aar.
+func (s *Server) aa(c diam.Conn, aar *AAR, aaa *AAA) {
+	aaa.UserName = aar.UserName
+	switch aar.AuthRequestType {
+	case AuthorizeOnly:
+		prev, ok := s.session(aar.SessionID)
+		if !ok || (len(aar.UserName) > 0 && aar.UserName != prev.aar.UserName) {
+			aaa.ResultCode = diam.AuthorizationRejected
+			return
+		}
+		aar.UserName = prev.aar.UserName
+		aaa.UserName = aar.UserName
+	case AuthenticateOnly, AuthorizeAuthenticate:
+		if aaa.ResultCode = s.authenticate(aar); aaa.ResultCode != diam.Success {
+			return
+		}
+		if aar.AuthRequestType == AuthenticateOnly {
+			return
+		}
+	default:
+		aaa.ResultCode = diam.InvalidAVPValue
+		return
+	}
+	s.authorize(c, aar, aaa)
+}
+
+// authenticate checks the PAP or CHAP credentials of aar and returns the
+// Result-Code of its answer.
+func (s *Server) authenticate(aar *AAR) uint32 {
+	if len(aar.UserName) == 0 {
+		return diam.MissingAVP
+	}
+	if s.Credentials == nil {
+		return diam.UnableToComply
+	}
+	password, err := s.Credentials.Password(aar.UserName)
+	if err != nil {
+		return diam.AuthenticationRejected
+	}
+	switch {
+	case len(aar.UserPassword) > 0:
+		if subtle.ConstantTimeCompare([]byte(aar.UserPassword), password) != 1 {
+			return diam.AuthenticationRejected
+		}
+	case aar.CHAPAuth != nil:
+		if aar.CHAPAuth.CHAPAlgorithm != CHAPAlgorithmMD5 || len(aar.CHAPAuth.CHAPIdent) != 1 {
+			return diam.InvalidAVPValue
+		}
+		if len(aar.CHAPChallenge) == 0 {
+			return diam.MissingAVP
+		}
+		want := chapResponse(aar.CHAPAuth.CHAPIdent[0], password, []byte(aar.CHAPChallenge))
+		if subtle.ConstantTimeCompare([]byte(aar.CHAPAuth.CHAPResponse), want) != 1 {
+			return diam.AuthenticationRejected
+		}
+	default:
+		return diam.MissingAVP
+	}
+	return diam.Success
+}
+
+// authorize grants the lifetimes, calls the Policy and records the
+// session of a successful authorization.
+func (s *Server) authorize(c diam.Conn, aar *AAR, aaa *AAA) {
+	if s.AuthorizationLifetime > 0 {
+		lifetime := uint32(s.AuthorizationLifetime / time.Second)
+		if aar.AuthorizationLifetime != nil && *aar.AuthorizationLifetime < lifetime {
+			lifetime = *aar.AuthorizationLifetime
+		}
+		aaa.AuthorizationLifetime = &lifetime
+		if s.AuthGracePeriod > 0 {
+			grace := uint32(s.AuthGracePeriod / time.Second)
+			aaa.AuthGracePeriod = &grace
+		}
+	}
+	state := int32(StateMaintained)
+	if aar.AuthSessionState != nil && *aar.AuthSessionState == NoStateMaintained {
+		state = NoStateMaintained
+	}
+	aaa.AuthSessionState = &state
+	if s.Policy != nil {
+		s.Policy(aar, aaa)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.sessions[aar.SessionID]
+	if aaa.ResultCode != diam.Success || *aaa.AuthSessionState == NoStateMaintained {
+		delete(s.sessions, aar.SessionID)
+		return
+	}
+	now := time.Now()
+	sess := &session{conn: c, aar: aar, aaa: aaa, started: now}
+	if prev != nil {
+		sess.aar = prev.aar
+		sess.started = prev.started
+	}
+	if aaa.AuthorizationLifetime != nil {
+		grace := time.Duration(0)
+		if aaa.AuthGracePeriod != nil {
+			grace = time.Duration(*aaa.AuthGracePeriod) * time.Second
+		}
+		sess.expires = now.Add(time.Duration(*aaa.AuthorizationLifetime)*time.Second + grace)
+	}
+	if aaa.SessionTimeout > 0 {
+		end := sess.started.Add(time.Duration(aaa.SessionTimeout) * time.Second)
+		if sess.expires.IsZero() || end.Before(sess.expires) {
+			sess.expires = end
+		}
+	}
+	if s.sessions == nil {
+		s.sessions = make(map[string]*session)
+	}
+	s.sessions[aar.SessionID] = sess
+}
+
+// session returns an authorized session, dropping expired ones.
+func (s *Server) session(sessionID string) (*session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	sess, ok := s.sessions[sessionID]
+	return sess, ok
+}
+
+// expire drops expired sessions. It must be called with s.mu held.
+func (s *Server) expire() {
+	now := time.Now()
+	for id, sess := range s.sessions {
+		if !sess.expires.IsZero() && now.After(sess.expires) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// Sessions returns the Session-Id of all authorized sessions, sorted.
+func (s *Server) Sessions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.expire()
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Session returns the AAR that started an authorized session and the
+// answer of its last authorization.
+func (s *Server) Session(sessionID string) (*AAR, *AAA, bool) {
+	sess, ok := s.session(sessionID)
+	if !ok {
+		return nil, nil, false
+	}
+	return sess.aar, sess.aaa, true
+}
+
+// ReAuth sends a RAR of type AUTHORIZE_ONLY for an authorized session
+// to its NAS and waits for the answer. On success the NAS is expected
+// to send an AAR of type AUTHORIZE_ONLY.
+func (s *Server) ReAuth(sessionID string) (*RAA, error) {
+	sess, ok := s.session(sessionID)
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	rar := &RAR{
+		SessionID:         sessionID,
+		OriginHost:        s.OriginHost,
+		OriginRealm:       s.OriginRealm,
+		DestinationRealm:  sess.aar.OriginRealm,
+		DestinationHost:   sess.aar.OriginHost,
+		AuthApplicationID: diam.NETWORK_ACCESS_APP_ID,
+		ReAuthRequestType: ReAuthAuthorizeOnly,
+		UserName:          sess.aar.UserName,
+	}
+	var raa RAA
+	if err := s.exchange(sess.conn, diam.ReAuth, rar, &raa); err != nil {
+		return nil, err
+	}
+	return &raa, nil
+}
+
+// AbortSession sends an ASR for an authorized session to its NAS and
+// waits for the answer. The session is kept until the NAS terminates
+// it with an STR, or its lifetime expires.
+func (s *Server) AbortSession(sessionID string) (*ASA, error) {
+	sess, ok := s.session(sessionID)
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	asr := &ASR{
+		SessionID:         sessionID,
+		OriginHost:        s.OriginHost,
+		OriginRealm:       s.OriginRealm,
+		DestinationRealm:  sess.aar.OriginRealm,
+		DestinationHost:   sess.aar.OriginHost,
+		AuthApplicationID: diam.NETWORK_ACCESS_APP_ID,
+		UserName:          sess.aar.UserName,
+	}
+	var asa ASA
+	if err := s.exchange(sess.conn, diam.AbortSession, asr, &asa); err != nil {
+		return nil, err
+	}
+	return &asa, nil
+}
+
+func (s *Server) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.NETWORK_ACCESS_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := s.pending.Exchange(c, m, s.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
@@ -0,0 +1,166 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package diam
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+// fixedAVP is an AVP with a fixed position in the messages of a command,
+// such as < Session-Id > which must follow the header (RFC 6733 section
+// 8.8).
+type fixedAVP struct {
+	name     string
+	code     uint32
+	vendorID uint32
+	position int
+}
+
+func (f *fixedAVP) match(a *AVP) bool {
+	return a.Code == f.code && a.VendorID == f.vendorID
+}
+
+// fixedKey is the key of the fixed AVPs of a command in the cache of
+// dictionaries.
+type fixedKey struct {
+	appID   uint32
+	code    uint32
+	request bool
+}
+
+// fixedRules are the fixed AVPs of the messages of a command.
+type fixedRules struct {
+	head, tail []fixedAVP
+}
+
+// fixedAVPs returns the AVPs the dictionary places at the head of the
+// message, in order, and those it places at its tail, in order. They are
+// resolved once per dictionary snapshot and command.
+func (m *Message) fixedAVPs() (head, tail []fixedAVP) {
+	d := m.Dictionary()
+	cache := d.Cache()
+	key := fixedKey{m.Header.ApplicationID, m.Header.CommandCode, m.Header.CommandFlags&RequestFlag == RequestFlag}
+	if v, ok := cache.Load(key); ok {
+		f := v.(*fixedRules)
+		return f.head, f.tail
+	}
+	f := &fixedRules{}
+	if cmd, err := d.FindCommand(key.appID, key.code); err == nil {
+		rules := cmd.Answer.Rule
+		if key.request {
+			rules = cmd.Request.Rule
+		}
+		for _, rule := range rules {
+			if rule.Position == 0 {
+				continue
+			}
+			a, err := d.FindAVPWithVendor(key.appID, rule.AVP, dict.UndefinedVendorID)
+			if err != nil {
+				continue
+			}
+			fa := fixedAVP{a.Name, a.Code, a.VendorID, rule.Position}
+			if fa.position > 0 {
+				f.head = append(f.head, fa)
+			} else {
+				f.tail = append(f.tail, fa)
+			}
+		}
+		sort.Slice(f.head, func(i, j int) bool { return f.head[i].position < f.head[j].position })
+		sort.Slice(f.tail, func(i, j int) bool { return f.tail[i].position < f.tail[j].position })
+	}
+	v, _ := cache.LoadOrStore(key, f)
+	f = v.(*fixedRules)
+	return f.head, f.tail
+}
+
+// misplacedAVP returns the first fixed AVP of avps out of its position,
+// or nil if all of them are in place.
+func misplacedAVP(avps []*AVP, head, tail []fixedAVP) *fixedAVP {
+	i, j := 0, len(avps)
+	for k := range head {
+		for i < j && head[k].match(avps[i]) {
+			i++
+		}
+	}
+	for k := len(tail) - 1; k >= 0; k-- {
+		for j > i && tail[k].match(avps[j-1]) {
+			j--
+		}
+	}
+	for _, a := range avps[i:j] {
+		for k := range head {
+			if head[k].match(a) {
+				return &head[k]
+			}
+		}
+		for k := range tail {
+			if tail[k].match(a) {
+				return &tail[k]
+			}
+		}
+	}
+	return nil
+}
+
+// Normalize moves the AVPs with fixed positions in the dictionary, such
+// as Session-Id, into place: those of the head of the message first and
+// those of its tail last, in the order of their positions. The order of
+// other AVPs is kept. Messages are serialized in this order without
+// being modified, so Normalize is only needed to inspect the AVPs in
+// their final order. It is not safe for concurrent calls.
+func (m *Message) Normalize() {
+	m.AVP = m.normalized()
+}
+
+// normalized returns the AVPs of m in the order of Normalize. It returns
+// m.AVP itself when they are in place, and a new slice otherwise.
+func (m *Message) normalized() []*AVP {
+	head, tail := m.fixedAVPs()
+	if misplacedAVP(m.AVP, head, tail) == nil {
+		return m.AVP
+	}
+	avps := make([]*AVP, 0, len(m.AVP))
+	used := make([]bool, len(m.AVP))
+	take := func(fixed []fixedAVP) {
+		for k := range fixed {
+			for i, a := range m.AVP {
+				if !used[i] && fixed[k].match(a) {
+					used[i] = true
+					avps = append(avps, a)
+				}
+			}
+		}
+	}
+	take(head)
+	for i, a := range m.AVP {
+		if used[i] {
+			continue
+		}
+		fixed := false
+		for k := range tail {
+			fixed = fixed || tail[k].match(a)
+		}
+		if !fixed {
+			avps = append(avps, a)
+		}
+	}
+	take(tail)
+	return avps
+}
+
+// checkPositions returns an error if a fixed AVP of m is misplaced.
+func (m *Message) checkPositions() error {
+	head, tail := m.fixedAVPs()
+	if f := misplacedAVP(m.AVP, head, tail); f != nil {
+		if f.position > 0 {
+			return fmt.Errorf("%s(%d): AVP must be at position %d", f.name, f.code, f.position)
+		}
+		return fmt.Errorf("%s(%d): AVP must be at position %d from the end", f.name, f.code, -f.position)
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package diam
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+var positionDict = `<?xml version="1.0" encoding="UTF-8"?>
+<diameter>
+	<application id="0">
+		<avp name="Session-Id" code="263" must="M" may="P" must-not="V" may-encrypt="Y">
+			<data type="UTF8String"/>
+		</avp>
+		<avp name="Origin-Host" code="264" must="M" may="P" must-not="V" may-encrypt="-">
+			<data type="DiameterIdentity"/>
+		</avp>
+		<avp name="Origin-Realm" code="296" must="M" may="P" must-not="V" may-encrypt="-">
+			<data type="DiameterIdentity"/>
+		</avp>
+		<avp name="Example-Tail" code="9000" must="M" may="P" must-not="V" may-encrypt="-">
+			<data type="Unsigned32"/>
+		</avp>
+	</application>
+	<application id="999">
+		<command code="111" short="EX" name="Example">
+			<request>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+				<rule avp="Origin-Host" required="true" max="1"/>
+				<rule avp="Origin-Realm" required="true" max="1"/>
+				<rule avp="Example-Tail" required="true" max="1" position="-1"/>
+			</request>
+			<answer>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
+			</answer>
+		</command>
+	</application>
+</diameter>`
+
+// serializeAsIs serializes m without moving its fixed AVPs into place.
+func serializeAsIs(t *testing.T, m *Message) []byte {
+	b := make([]byte, m.Len())
+	m.Header.SerializeTo(b[:HeaderLength])
+	offset := HeaderLength
+	for _, a := range m.AVP {
+		if err := a.SerializeTo(b[offset:]); err != nil {
+			t.Fatal(err)
+		}
+		offset += a.Len()
+	}
+	return b
+}
+
+func avpCodes(m *Message) []uint32 {
+	var codes []uint32
+	for _, a := range m.AVP {
+		codes = append(codes, a.Code)
+	}
+	return codes
+}
+
+func TestMessage_Normalize(t *testing.T) {
+	p, _ := dict.NewParser()
+	if err := p.Load(strings.NewReader(positionDict)); err != nil {
+		t.Fatal(err)
+	}
+	m := NewRequest(111, 999, p)
+	m.NewAVP(9000, avp.Mbit, 0, datatype.Unsigned32(1))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("host"))
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("session"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("realm"))
+
+	// Misplaced fixed AVPs are accepted on decode by default.
+	b := serializeAsIs(t, m)
+	rm, err := ReadMessage(bytes.NewReader(b), p)
+	if err != nil || rm.DecodeErr != nil {
+		t.Fatalf("Misplaced AVPs were rejected: %v, %v", err, rm.DecodeErr)
+	}
+
+	// And checked when enabled: rejected when Strict, reported in
+	// DecodeErr otherwise.
+	p.CheckPositions = true
+	if _, err = ReadMessage(bytes.NewReader(b), p); err == nil ||
+		!strings.Contains(err.Error(), "Example-Tail(9000): AVP must be at position 1 from the end") {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	p.Strict = false
+	rm, err = ReadMessage(bytes.NewReader(b), p)
+	if err != nil || rm.DecodeErr == nil {
+		t.Fatalf("Misplaced AVPs were not reported: %v", err)
+	}
+	p.Strict = true
+
+	// And moved into place on serialize, without modifying the message.
+	if b, err = m.Serialize(); err != nil {
+		t.Fatal(err)
+	}
+	want := []uint32{avp.SessionID, avp.OriginHost, avp.OriginRealm, 9000}
+	if codes := avpCodes(m); !equalCodes(codes, []uint32{9000, avp.OriginHost, avp.SessionID, avp.OriginRealm}) {
+		t.Fatalf("Serialize modified the AVP order: %v", codes)
+	}
+	if rm, err = ReadMessage(bytes.NewReader(b), p); err != nil {
+		t.Fatal(err)
+	}
+	if codes := avpCodes(rm); !equalCodes(codes, want) {
+		t.Fatalf("Unexpected AVP order: %v", codes)
+	}
+
+	m.Normalize()
+	if codes := avpCodes(m); !equalCodes(codes, want) {
+		t.Fatalf("Unexpected AVP order: %v", codes)
+	}
+
+	// Messages without fixed AVPs are left as they are.
+	a := m.Answer(0)
+	a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("host"))
+	a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("realm"))
+	a.Normalize()
+	if codes := avpCodes(a); !equalCodes(codes, []uint32{avp.OriginHost, avp.OriginRealm}) {
+		t.Fatalf("Unexpected AVP order: %v", codes)
+	}
+}
+
+// TestMessage_ConcurrentSerialize checks that a message with misplaced
+// AVPs can be written by several goroutines at once.
+func TestMessage_ConcurrentSerialize(t *testing.T) {
+	p, _ := dict.NewParser()
+	if err := p.Load(strings.NewReader(positionDict)); err != nil {
+		t.Fatal(err)
+	}
+	m := NewRequest(111, 999, p)
+	m.NewAVP(9000, avp.Mbit, 0, datatype.Unsigned32(1))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("host"))
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("session"))
+	want, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				b, err := m.Serialize()
+				if err != nil || !bytes.Equal(b, want) {
+					t.Errorf("Unexpected serialization: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func equalCodes(a, b []uint32) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
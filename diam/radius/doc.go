@@ -0,0 +1,31 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package radius implements a RADIUS/Diameter translation agent for the
+// Network Access application, as described in RFC 7155 section 9.
+//
+// It provides a minimal RADIUS packet codec (RFC 2865, RFC 2866 and the
+// Message-Authenticator of RFC 3579) and a Gateway that forwards RADIUS
+// Access-Requests and Accounting-Requests received on UDP as Diameter
+// AARs and ACRs, translating the answers back.
+//
+// A gateway in front of a Diameter NASREQ server:
+//
+//	gw := &radius.Gateway{
+//		OriginHost:       "gw.example.com",
+//		OriginRealm:      "example.com",
+//		DestinationRealm: "example.com",
+//		Secret:           func(ip net.IP) []byte { return []byte("secret") },
+//	}
+//	mux := sm.New(settings)
+//	mux.HandleIdx(radius.AAAIndex, gw)
+//	mux.HandleIdx(radius.ACAIndex, gw)
+//	cli := &sm.Client{Handler: mux, ...}
+//	c, err := cli.DialNetwork("tcp", "aaa.example.com:3868")
+//	...
+//	gw.Conn = func() (diam.Conn, error) { return c, nil }
+//	pc, err := net.ListenPacket("udp", ":1812")
+//	...
+//	log.Fatal(gw.Serve(pc))
+package radius
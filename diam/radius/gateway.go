@@ -0,0 +1,351 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package radius
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+)
+
+// Command indexes of the Network Access answers the Gateway waits for,
+// for use with ServeMux.HandleIdx.
+var (
+	AAAIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.AA, Request: false}
+	ACAIndex = diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.Accounting, Request: false}
+)
+
+const (
+	// DefaultTimeout is how long the Gateway waits for Diameter answers
+	// when no Timeout is configured.
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultDuplicateWindow is how long the Gateway remembers requests
+	// to detect retransmissions when no DuplicateWindow is configured.
+	DefaultDuplicateWindow = 30 * time.Second
+)
+
+// Gateway is a RADIUS/Diameter translation agent for the Network Access
+// application, as described in RFC 7155 section 9. It receives RADIUS
+// Access-Requests and Accounting-Requests, forwards them as AARs and
+// ACRs to a Diameter server, and translates the answers back to
+// Access-Accept, Access-Challenge, Access-Reject and Accounting-Response.
+//
+// RADIUS attributes are translated to the Diameter AVPs of the same
+// code found in the dictionary, except for:
+//
+//	User-Password           revealed and sent in clear in User-Password
+//	CHAP-Password           CHAP-Auth, with the Request Authenticator as
+//	                        CHAP-Challenge when there is none
+//	Proxy-State             Proxy-Info, with the gateway as Proxy-Host
+//	Acct-Status-Type        Accounting-Record-Type
+//	Acct-*-Octets/Packets   64-bit Accounting-*-Octets/Packets
+//	Acct-Terminate-Cause    Termination-Cause, adding 10
+//
+// and AVPs of answers with a code below 256 are translated back. The
+// Diameter Session-Id of an authentication is carried in a State
+// attribute prefixed with "Diameter/", and the one of accounting is
+// derived from the NAS and its Acct-Session-Id. Requests that fail to
+// translate, and those the Diameter server fails to answer, are dropped
+// so that the RADIUS client retries them; so are Accounting-Requests
+// whose ACA is not DIAMETER_SUCCESS. Retransmitted requests are answered
+// with the reply of the original one.
+//
+// Gateway implements the diam.Handler interface and must be registered
+// for AAAIndex and ACAIndex on the connection's handler.
+type Gateway struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+
+	// Secret returns the shared secret of the RADIUS client at ip, or
+	// nil to ignore its packets.
+	Secret func(ip net.IP) []byte
+
+	// Conn returns the connection to the Diameter server.
+	Conn func() (diam.Conn, error)
+
+	Timeout         time.Duration // Defaults to DefaultTimeout.
+	DuplicateWindow time.Duration // Defaults to DefaultDuplicateWindow.
+
+	// ErrorReporter, if non-nil, receives errors forwarding requests.
+	ErrorReporter diam.ErrorReporter
+
+	pending      pending.Table
+	recordNumber uint32
+
+	mu      sync.Mutex
+	replies map[requestKey]*reply
+}
+
+// requestKey identifies a RADIUS request to detect retransmissions.
+// See RFC 5080 section 2.2.2.
+type requestKey struct {
+	addr          string
+	identifier    uint8
+	authenticator [16]byte
+}
+
+type reply struct {
+	b       []byte // Nil while the request is in progress.
+	expires time.Time
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (g *Gateway) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		g.pending.Deliver(m)
+	}
+}
+
+// Serve reads RADIUS requests from pc and handles each of them on its
+// own goroutine, until reading fails.
+func (g *Gateway) Serve(pc net.PacketConn) error {
+	buf := make([]byte, 4096)
+	for {
+		n, addr, err := pc.ReadFrom(buf)
+		if err != nil {
+			return err
+		}
+		b := append([]byte(nil), buf[:n]...)
+		go g.handle(pc, addr, b)
+	}
+}
+
+// handle answers the RADIUS request b sent by addr.
+func (g *Gateway) handle(pc net.PacketConn, addr net.Addr, b []byte) {
+	ua, ok := addr.(*net.UDPAddr)
+	if !ok || g.Secret == nil {
+		return
+	}
+	secret := g.Secret(ua.IP)
+	if len(secret) == 0 {
+		return
+	}
+	req, err := Parse(b)
+	if err != nil || !req.VerifyRequest(b, secret) {
+		return
+	}
+	key := requestKey{addr.String(), req.Identifier, req.Authenticator}
+	if b, dup := g.remember(key); dup {
+		if b != nil {
+			pc.WriteTo(b, addr)
+		}
+		return
+	}
+	var resp *Packet
+	switch req.Code {
+	case AccessRequest:
+		resp = g.access(req, secret)
+	case AccountingRequest:
+		resp = g.accounting(req)
+	}
+	if resp == nil {
+		g.forget(key)
+		return
+	}
+	if b, err = resp.EncodeReply(secret); err != nil {
+		g.forget(key)
+		g.report(nil, nil, err)
+		return
+	}
+	g.store(key, b)
+	pc.WriteTo(b, addr)
+}
+
+// access translates an Access-Request and its answer.
+func (g *Gateway) access(req *Packet, secret []byte) *Packet {
+	c, err := g.Conn()
+	if err != nil {
+		g.report(nil, nil, err)
+		return nil
+	}
+	sessionID := sessionid.New(g.OriginHost)
+	if state := string(req.Get(AttrState)); strings.HasPrefix(state, statePrefix) {
+		sessionID = state[len(statePrefix):]
+	}
+	authRequestType := int32(3) // AUTHORIZE_AUTHENTICATE
+	if st := req.Get(AttrServiceType); len(st) == 4 && binary.BigEndian.Uint32(st) == ServiceTypeAuthorizeOnly {
+		authRequestType = 2 // AUTHORIZE_ONLY
+	}
+	m := diam.NewRequest(diam.AA, diam.NETWORK_ACCESS_APP_ID, c.Dictionary())
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.NETWORK_ACCESS_APP_ID))
+	g.addRouting(m)
+	m.NewAVP(avp.AuthRequestType, avp.Mbit, 0, datatype.Enumerated(authRequestType))
+	addAttributes(m, req)
+	if v := req.Get(AttrUserPassword); v != nil {
+		password, err := RevealPassword(v, secret, req.Authenticator)
+		if err != nil {
+			return nil
+		}
+		m.NewAVP(avp.UserPassword, avp.Mbit, 0, datatype.OctetString(password))
+	}
+	if v := req.Get(AttrCHAPPassword); len(v) == 17 {
+		m.NewAVP(avp.CHAPAuth, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.CHAPAlgorithm, avp.Mbit, 0, datatype.Enumerated(5)), // CHAP with MD5
+				diam.NewAVP(avp.CHAPIdent, avp.Mbit, 0, datatype.OctetString(v[:1])),
+				diam.NewAVP(avp.CHAPResponse, avp.Mbit, 0, datatype.OctetString(v[1:])),
+			},
+		})
+		if req.Get(AttrCHAPChallenge) == nil {
+			m.NewAVP(avp.CHAPChallenge, avp.Mbit, 0, datatype.OctetString(req.Authenticator[:]))
+		}
+	}
+	states := addProxyInfo(m, req, g.OriginHost)
+
+	a, err := g.exchange(c, m)
+	if err != nil {
+		return nil
+	}
+	var resp *Packet
+	switch resultCode(a) {
+	case diam.Success:
+		resp = req.Reply(AccessAccept)
+		addAVPs(resp, a)
+	case diam.MultiRoundAuth:
+		resp = req.Reply(AccessChallenge)
+		addAVPs(resp, a)
+	default:
+		resp = req.Reply(AccessReject)
+		addAVPs(resp, a, AttrReplyMessage)
+	}
+	if resp.Code != AccessReject && resp.Get(AttrState) == nil {
+		resp.Add(AttrState, []byte(statePrefix+sessionID))
+	}
+	addProxyStates(resp, a, g.OriginHost, states)
+	if req.Get(AttrMessageAuthenticator) != nil {
+		resp.Add(AttrMessageAuthenticator, make([]byte, 16))
+	}
+	return resp
+}
+
+// accounting translates an Accounting-Request and its answer.
+func (g *Gateway) accounting(req *Packet) *Packet {
+	status := req.Get(AttrAcctStatusType)
+	if len(status) != 4 {
+		return nil
+	}
+	recType, ok := recordType(binary.BigEndian.Uint32(status))
+	if !ok {
+		return nil
+	}
+	c, err := g.Conn()
+	if err != nil {
+		g.report(nil, nil, err)
+		return nil
+	}
+	sessionID := sessionid.New(g.OriginHost)
+	if acctSessionID := req.Get(AttrAcctSessionID); acctSessionID != nil {
+		nas := string(req.Get(AttrNASIdentifier))
+		if ip := req.Get(AttrNASIPAddress); len(nas) == 0 && len(ip) == 4 {
+			nas = net.IP(ip).String()
+		}
+		sessionID = fmt.Sprintf("%s;%s;%s", string(g.OriginHost), nas, acctSessionID)
+	}
+	m := diam.NewRequest(diam.Accounting, diam.NETWORK_ACCESS_APP_ID, c.Dictionary())
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String(sessionID))
+	g.addRouting(m)
+	m.NewAVP(avp.AccountingRecordType, avp.Mbit, 0, datatype.Enumerated(recType))
+	m.NewAVP(avp.AccountingRecordNumber, avp.Mbit, 0, datatype.Unsigned32(atomic.AddUint32(&g.recordNumber, 1)))
+	m.NewAVP(avp.AcctApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.NETWORK_ACCESS_APP_ID))
+	addAttributes(m, req)
+	addCounters(m, req)
+	if v := req.Get(AttrAcctTerminateCause); len(v) == 4 {
+		m.NewAVP(avp.TerminationCause, avp.Mbit, 0, datatype.Enumerated(binary.BigEndian.Uint32(v)+10))
+	}
+	states := addProxyInfo(m, req, g.OriginHost)
+
+	a, err := g.exchange(c, m)
+	if err != nil || resultCode(a) != diam.Success {
+		return nil
+	}
+	resp := req.Reply(AccountingResponse)
+	addProxyStates(resp, a, g.OriginHost, states)
+	return resp
+}
+
+func (g *Gateway) addRouting(m *diam.Message) {
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, g.OriginHost)
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, g.OriginRealm)
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, g.DestinationRealm)
+	if len(g.DestinationHost) > 0 {
+		m.NewAVP(avp.DestinationHost, avp.Mbit, 0, g.DestinationHost)
+	}
+}
+
+func (g *Gateway) exchange(c diam.Conn, m *diam.Message) (*diam.Message, error) {
+	timeout := g.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	a, err := g.pending.Exchange(c, m, timeout)
+	if err != nil {
+		g.report(c, m, err)
+	}
+	return a, err
+}
+
+func (g *Gateway) report(c diam.Conn, m *diam.Message, err error) {
+	if g.ErrorReporter != nil {
+		g.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("radius: %v", err),
+		})
+	}
+}
+
+// remember records a request in progress, or reports that it is a
+// retransmission along with the reply to send, if any.
+func (g *Gateway) remember(key requestKey) ([]byte, bool) {
+	now := time.Now()
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.replies == nil {
+		g.replies = make(map[requestKey]*reply)
+	}
+	for k, r := range g.replies {
+		if r.b != nil && now.After(r.expires) {
+			delete(g.replies, k)
+		}
+	}
+	if r, ok := g.replies[key]; ok {
+		return r.b, true
+	}
+	g.replies[key] = &reply{}
+	return nil, false
+}
+
+// store records the reply to a request.
+func (g *Gateway) store(key requestKey, b []byte) {
+	window := g.DuplicateWindow
+	if window <= 0 {
+		window = DefaultDuplicateWindow
+	}
+	g.mu.Lock()
+	g.replies[key] = &reply{b: b, expires: time.Now().Add(window)}
+	g.mu.Unlock()
+}
+
+// forget drops a request that got no reply, so that its retransmissions
+// are handled again.
+func (g *Gateway) forget(key requestKey) {
+	g.mu.Lock()
+	delete(g.replies, key)
+	g.mu.Unlock()
+}
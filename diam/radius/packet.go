@@ -0,0 +1,309 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package radius
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPacket is returned when parsing malformed RADIUS packets.
+var ErrInvalidPacket = errors.New("radius: invalid packet")
+
+// RADIUS packet codes. See RFC 2865 section 4 and RFC 2866 section 4.
+const (
+	AccessRequest      = 1
+	AccessAccept       = 2
+	AccessReject       = 3
+	AccountingRequest  = 4
+	AccountingResponse = 5
+	AccessChallenge    = 11
+)
+
+// RADIUS attribute types used by the Gateway. See RFC 2865 section 5,
+// RFC 2866 section 5 and RFC 2869 section 5.
+const (
+	AttrUserName             = 1
+	AttrUserPassword         = 2
+	AttrCHAPPassword         = 3
+	AttrNASIPAddress         = 4
+	AttrServiceType          = 6
+	AttrReplyMessage         = 18
+	AttrState                = 24
+	AttrClass                = 25
+	AttrVendorSpecific       = 26
+	AttrNASIdentifier        = 32
+	AttrProxyState           = 33
+	AttrAcctStatusType       = 40
+	AttrAcctInputOctets      = 42
+	AttrAcctOutputOctets     = 43
+	AttrAcctSessionID        = 44
+	AttrAcctInputPackets     = 47
+	AttrAcctOutputPackets    = 48
+	AttrAcctTerminateCause   = 49
+	AttrAcctInputGigawords   = 52
+	AttrAcctOutputGigawords  = 53
+	AttrEventTimestamp       = 55
+	AttrCHAPChallenge        = 60
+	AttrEAPMessage           = 79
+	AttrMessageAuthenticator = 80
+)
+
+// Acct-Status-Type values. See RFC 2866 section 5.1.
+const (
+	AcctStatusStart         = 1
+	AcctStatusStop          = 2
+	AcctStatusInterimUpdate = 3
+	AcctStatusAccountingOn  = 7
+	AcctStatusAccountingOff = 8
+)
+
+// ServiceTypeAuthorizeOnly is the Service-Type of Access-Requests that
+// do not authenticate the user. See RFC 5176 section 3.2.
+const ServiceTypeAuthorizeOnly = 17
+
+// Attribute is a RADIUS attribute.
+type Attribute struct {
+	Type  uint8
+	Value []byte
+}
+
+// Packet is a RADIUS packet. See RFC 2865 section 3.
+type Packet struct {
+	Code          uint8
+	Identifier    uint8
+	Authenticator [16]byte
+	Attributes    []Attribute
+}
+
+// Parse parses the RADIUS packet in b.
+func Parse(b []byte) (*Packet, error) {
+	if len(b) < 20 {
+		return nil, ErrInvalidPacket
+	}
+	n := int(binary.BigEndian.Uint16(b[2:4]))
+	if n < 20 || n > len(b) || n > 4096 {
+		return nil, ErrInvalidPacket
+	}
+	p := &Packet{Code: b[0], Identifier: b[1]}
+	copy(p.Authenticator[:], b[4:20])
+	for b = b[20:n]; len(b) > 0; {
+		if len(b) < 2 || b[1] < 2 || int(b[1]) > len(b) {
+			return nil, ErrInvalidPacket
+		}
+		p.Attributes = append(p.Attributes, Attribute{Type: b[0], Value: b[2:b[1]]})
+		b = b[b[1]:]
+	}
+	return p, nil
+}
+
+// Serialize returns the wire format of p, without computing any
+// authenticator.
+func (p *Packet) Serialize() ([]byte, error) {
+	n := 20
+	for _, a := range p.Attributes {
+		if len(a.Value) > 253 {
+			return nil, fmt.Errorf("radius: attribute %d too long: %d bytes", a.Type, len(a.Value))
+		}
+		n += 2 + len(a.Value)
+	}
+	if n > 4096 {
+		return nil, fmt.Errorf("radius: packet too long: %d bytes", n)
+	}
+	b := make([]byte, 20, n)
+	b[0] = p.Code
+	b[1] = p.Identifier
+	binary.BigEndian.PutUint16(b[2:4], uint16(n))
+	copy(b[4:20], p.Authenticator[:])
+	for _, a := range p.Attributes {
+		b = append(b, a.Type, byte(2+len(a.Value)))
+		b = append(b, a.Value...)
+	}
+	return b, nil
+}
+
+// Get returns the value of the first attribute of type typ, or nil.
+func (p *Packet) Get(typ uint8) []byte {
+	for _, a := range p.Attributes {
+		if a.Type == typ {
+			return a.Value
+		}
+	}
+	return nil
+}
+
+// Add appends an attribute to p.
+func (p *Packet) Add(typ uint8, value []byte) {
+	p.Attributes = append(p.Attributes, Attribute{Type: typ, Value: value})
+}
+
+// Reply returns an empty reply to p with the given code.
+func (p *Packet) Reply(code uint8) *Packet {
+	return &Packet{Code: code, Identifier: p.Identifier, Authenticator: p.Authenticator}
+}
+
+// EncodeReply returns the wire format of a reply, whose Authenticator
+// must be the one of its request, computing the Message-Authenticator
+// attribute, when present, and the Response Authenticator.
+// See RFC 2865 section 3 and RFC 3579 section 3.2.
+func (p *Packet) EncodeReply(secret []byte) ([]byte, error) {
+	b, err := p.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	if off := p.offset(AttrMessageAuthenticator); off > 0 {
+		copy(b[off:off+16], make([]byte, 16))
+		h := hmac.New(md5.New, secret)
+		h.Write(b)
+		copy(b[off:off+16], h.Sum(nil))
+	}
+	h := md5.New()
+	h.Write(b)
+	h.Write(secret)
+	copy(b[4:20], h.Sum(nil))
+	return b, nil
+}
+
+// EncodeRequest returns the wire format of a request. The Authenticator
+// of Access-Requests is kept, while the one of Accounting-Requests is
+// computed. The Message-Authenticator attribute, when present, is
+// computed too.
+func (p *Packet) EncodeRequest(secret []byte) ([]byte, error) {
+	if p.Code == AccountingRequest {
+		p.Authenticator = [16]byte{}
+	}
+	b, err := p.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	if off := p.offset(AttrMessageAuthenticator); off > 0 {
+		copy(b[off:off+16], make([]byte, 16))
+		h := hmac.New(md5.New, secret)
+		h.Write(b)
+		copy(b[off:off+16], h.Sum(nil))
+	}
+	if p.Code == AccountingRequest {
+		h := md5.New()
+		h.Write(b)
+		h.Write(secret)
+		copy(b[4:20], h.Sum(nil))
+		copy(p.Authenticator[:], b[4:20])
+	}
+	return b, nil
+}
+
+// VerifyRequest checks the Request Authenticator of an Accounting-Request
+// and the Message-Authenticator of any request that carries one, given
+// the wire format b of p. See RFC 2866 section 3 and RFC 3579 section
+// 3.2.
+func (p *Packet) VerifyRequest(b, secret []byte) bool {
+	b = append([]byte(nil), b[:binary.BigEndian.Uint16(b[2:4])]...)
+	if p.Code == AccountingRequest {
+		copy(b[4:20], make([]byte, 16))
+	}
+	if off := p.offset(AttrMessageAuthenticator); off > 0 {
+		mac := append([]byte(nil), b[off:off+16]...)
+		copy(b[off:off+16], make([]byte, 16))
+		h := hmac.New(md5.New, secret)
+		h.Write(b)
+		if !hmac.Equal(mac, h.Sum(nil)) {
+			return false
+		}
+		copy(b[off:off+16], mac)
+	}
+	if p.Code != AccountingRequest {
+		return true
+	}
+	h := md5.New()
+	h.Write(b)
+	h.Write(secret)
+	return hmac.Equal(p.Authenticator[:], h.Sum(nil))
+}
+
+// VerifyReply checks the Response Authenticator of the reply in b, and
+// its Message-Authenticator when present, against the request req.
+func VerifyReply(b []byte, req *Packet, secret []byte) bool {
+	p, err := Parse(b)
+	if err != nil || p.Identifier != req.Identifier {
+		return false
+	}
+	b = append([]byte(nil), b[:binary.BigEndian.Uint16(b[2:4])]...)
+	copy(b[4:20], req.Authenticator[:])
+	if off := p.offset(AttrMessageAuthenticator); off > 0 {
+		mac := append([]byte(nil), b[off:off+16]...)
+		copy(b[off:off+16], make([]byte, 16))
+		h := hmac.New(md5.New, secret)
+		h.Write(b)
+		if !hmac.Equal(mac, h.Sum(nil)) {
+			return false
+		}
+		copy(b[off:off+16], mac)
+	}
+	h := md5.New()
+	h.Write(b)
+	h.Write(secret)
+	return hmac.Equal(p.Authenticator[:], h.Sum(nil))
+}
+
+// offset returns the offset of the value of the first attribute of type
+// typ in the wire format of p, or 0 when there is none with 16 bytes.
+func (p *Packet) offset(typ uint8) int {
+	off := 20
+	for _, a := range p.Attributes {
+		if a.Type == typ && len(a.Value) == 16 {
+			return off + 2
+		}
+		off += 2 + len(a.Value)
+	}
+	return 0
+}
+
+// HidePassword returns the User-Password attribute value of password in
+// a request with the given authenticator. See RFC 2865 section 5.2.
+func HidePassword(password, secret []byte, authenticator [16]byte) []byte {
+	n := (len(password) + 15) / 16 * 16
+	if n == 0 {
+		n = 16
+	}
+	b := make([]byte, n)
+	copy(b, password)
+	prev := authenticator[:]
+	for i := 0; i < n; i += 16 {
+		h := md5.New()
+		h.Write(secret)
+		h.Write(prev)
+		x := h.Sum(nil)
+		for j := 0; j < 16; j++ {
+			b[i+j] ^= x[j]
+		}
+		prev = b[i : i+16]
+	}
+	return b
+}
+
+// RevealPassword returns the password hidden in a User-Password
+// attribute value.
+func RevealPassword(value, secret []byte, authenticator [16]byte) ([]byte, error) {
+	if len(value) < 16 || len(value) > 128 || len(value)%16 != 0 {
+		return nil, ErrInvalidPacket
+	}
+	b := make([]byte, len(value))
+	prev := authenticator[:]
+	for i := 0; i < len(value); i += 16 {
+		h := md5.New()
+		h.Write(secret)
+		h.Write(prev)
+		x := h.Sum(nil)
+		for j := 0; j < 16; j++ {
+			b[i+j] = value[i+j] ^ x[j]
+		}
+		prev = value[i : i+16]
+	}
+	return bytes.TrimRight(b, "\x00"), nil
+}
@@ -0,0 +1,362 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package radius
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/nasreq"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+)
+
+var testSecret = []byte("xyzzy5461")
+
+func TestPassword(t *testing.T) {
+	auth := [16]byte{0x0f, 0x40, 0x3f, 0x94, 0x73, 0x97, 0x80, 0x57, 0xbd, 0x83, 0xd5, 0xcb, 0x98, 0xf4, 0x22, 0x7a}
+	for _, password := range []string{"", "arctangent", "a password longer than sixteen bytes"} {
+		v := HidePassword([]byte(password), testSecret, auth)
+		if len(v)%16 != 0 || len(v) == 0 {
+			t.Fatalf("Unexpected length: %d", len(v))
+		}
+		p, err := RevealPassword(v, testSecret, auth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(p) != password {
+			t.Fatalf("Unexpected password: %q", p)
+		}
+	}
+	// RFC 2865 section 7.1.
+	want := []byte{0x0d, 0xbe, 0x70, 0x8d, 0x93, 0xd4, 0x13, 0xce, 0x31, 0x96, 0xe4, 0x3f, 0x78, 0x2a, 0x0a, 0xee}
+	if v := HidePassword([]byte("arctangent"), testSecret, auth); !bytes.Equal(v, want) {
+		t.Fatalf("Unexpected User-Password: %x", v)
+	}
+}
+
+func TestPacket(t *testing.T) {
+	p := &Packet{Code: AccountingRequest, Identifier: 9}
+	p.Add(AttrAcctStatusType, []byte{0, 0, 0, 1})
+	p.Add(AttrMessageAuthenticator, make([]byte, 16))
+	b, err := p.EncodeRequest(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Code != AccountingRequest || q.Identifier != 9 || len(q.Attributes) != 2 || !q.VerifyRequest(b, testSecret) {
+		t.Fatalf("Unexpected packet: %+v", q)
+	}
+	if q.VerifyRequest(b, []byte("wrong")) {
+		t.Fatal("Request verified with the wrong secret")
+	}
+	r := q.Reply(AccountingResponse)
+	rb, err := r.EncodeReply(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !VerifyReply(rb, q, testSecret) {
+		t.Fatal("Reply not verified")
+	}
+	for _, b := range [][]byte{
+		make([]byte, 19),
+		{1, 1, 0, 21, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		{1, 1, 0, 22, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1},
+	} {
+		if _, err := Parse(b); err != ErrInvalidPacket {
+			t.Fatalf("Unexpected error for %x: %v", b, err)
+		}
+	}
+}
+
+// testServer is the Diameter side: a NASREQ server and an accounting
+// server recording ACRs.
+type testServer struct {
+	mu   sync.Mutex
+	aars int
+	acrs []*diam.Message
+}
+
+func (s *testServer) ServeDIAM(c diam.Conn, m *diam.Message) {
+	s.mu.Lock()
+	s.acrs = append(s.acrs, m)
+	s.mu.Unlock()
+	a := m.Answer(diam.Success)
+	if rt, err := m.FindAVP(avp.AccountingRecordType, 0); err == nil && rt.Data.(datatype.Enumerated) == 1 {
+		a = m.Answer(diam.UnableToComply)
+	}
+	sid, _ := m.FindAVP(avp.SessionID, 0)
+	a.InsertAVP(sid)
+	a.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("aaa"))
+	a.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("test"))
+	// Echo the Proxy-Info AVPs, as required by RFC 6733 section 6.7.3.
+	for _, x := range m.AVP {
+		if x.Code == avp.ProxyInfo {
+			a.AddAVP(x)
+		}
+	}
+	a.WriteTo(c)
+}
+
+// dial starts a Diameter server and a Gateway connected to it, and
+// returns a RADIUS client connection to the Gateway.
+func dial(t *testing.T) (*testServer, net.Conn, func()) {
+	t.Helper()
+	ts := &testServer{}
+	srv := &nasreq.Server{
+		OriginHost:  "aaa",
+		OriginRealm: "test",
+		Credentials: nasreq.CredentialsFunc(func(user string) ([]byte, error) {
+			if user == "nemo" {
+				return []byte("arctangent"), nil
+			}
+			return nil, nasreq.ErrUnknownUser
+		}),
+		Policy: func(aar *nasreq.AAR, aaa *nasreq.AAA) {
+			ts.mu.Lock()
+			ts.aars++
+			ts.mu.Unlock()
+			aaa.FramedIPAddress = net.ParseIP("10.0.0.1").To4()
+			aaa.FilterID = []string{"users"}
+			aaa.SessionTimeout = 3600
+		},
+	}
+	mux := sm.New(smtest.Settings("aaa"))
+	mux.HandleIdx(nasreq.AARIndex, srv)
+	mux.HandleIdx(diam.CommandIndex{AppID: diam.NETWORK_ACCESS_APP_ID, Code: diam.Accounting, Request: true}, ts)
+	ds := diamtest.NewServer(mux, dict.Default)
+
+	gw := &Gateway{
+		OriginHost:       "gw",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		Secret: func(ip net.IP) []byte {
+			if ip.IsLoopback() {
+				return testSecret
+			}
+			return nil
+		},
+	}
+	cmux := sm.New(smtest.Settings("gw"))
+	cmux.HandleIdx(AAAIndex, gw)
+	cmux.HandleIdx(ACAIndex, gw)
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.NETWORK_ACCESS_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(ds.Addr)
+	if err != nil {
+		ds.Close()
+		t.Fatal(err)
+	}
+	gw.Conn = func() (diam.Conn, error) { return c, nil }
+	pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go gw.Serve(pc)
+	rc, err := net.Dial("udp", pc.LocalAddr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts, rc, func() {
+		rc.Close()
+		pc.Close()
+		c.Close()
+		ds.Close()
+	}
+}
+
+// exchange sends the RADIUS request b over c and returns its reply, or
+// nil when there is none.
+func exchange(t *testing.T, c net.Conn, b []byte, req *Packet) *Packet {
+	t.Helper()
+	if _, err := c.Write(b); err != nil {
+		t.Fatal(err)
+	}
+	c.SetReadDeadline(time.Now().Add(500 * time.Millisecond))
+	buf := make([]byte, 4096)
+	n, err := c.Read(buf)
+	if err != nil {
+		return nil
+	}
+	if !VerifyReply(buf[:n], req, testSecret) {
+		t.Fatalf("Invalid reply: %x", buf[:n])
+	}
+	resp, err := Parse(buf[:n])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp
+}
+
+func accessRequest(id uint8, user string) *Packet {
+	p := &Packet{Code: AccessRequest, Identifier: id}
+	copy(p.Authenticator[:], "0123456789abcdef")
+	p.Authenticator[0] = id
+	p.Add(AttrUserName, []byte(user))
+	p.Add(AttrNASIPAddress, net.ParseIP("192.168.1.16").To4())
+	p.Add(5, []byte{0, 0, 0, 3}) // NAS-Port
+	return p
+}
+
+func TestGateway_Access(t *testing.T) {
+	ts, c, done := dial(t)
+	defer done()
+
+	// PAP, with Proxy-State and Message-Authenticator.
+	req := accessRequest(1, "nemo")
+	req.Add(AttrUserPassword, HidePassword([]byte("arctangent"), testSecret, req.Authenticator))
+	req.Add(AttrProxyState, []byte("proxy-1"))
+	req.Add(AttrProxyState, []byte("proxy-2"))
+	req.Add(AttrMessageAuthenticator, make([]byte, 16))
+	b, err := req.EncodeRequest(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := exchange(t, c, b, req)
+	if resp == nil || resp.Code != AccessAccept {
+		t.Fatalf("Unexpected reply: %+v", resp)
+	}
+	if ip := resp.Get(8); !net.IP(ip).Equal(net.ParseIP("10.0.0.1")) {
+		t.Fatalf("Unexpected Framed-IP-Address: %v", ip)
+	}
+	if id := resp.Get(11); string(id) != "users" {
+		t.Fatalf("Unexpected Filter-Id: %q", id)
+	}
+	if st := resp.Get(27); binary.BigEndian.Uint32(st) != 3600 {
+		t.Fatalf("Unexpected Session-Timeout: %x", st)
+	}
+	if !bytes.HasPrefix(resp.Get(AttrState), []byte("Diameter/gw;")) || resp.Get(AttrMessageAuthenticator) == nil {
+		t.Fatalf("Unexpected reply: %+v", resp)
+	}
+	var states []string
+	for _, a := range resp.Attributes {
+		if a.Type == AttrProxyState {
+			states = append(states, string(a.Value))
+		}
+	}
+	if len(states) != 2 || states[0] != "proxy-1" || states[1] != "proxy-2" {
+		t.Fatalf("Unexpected Proxy-State: %q", states)
+	}
+
+	// A retransmission gets the same reply without a new AAR.
+	again := exchange(t, c, b, req)
+	if again == nil || again.Code != AccessAccept || ts.aars != 1 {
+		t.Fatalf("Unexpected reply to retransmission: %+v, %d AARs", again, ts.aars)
+	}
+
+	// CHAP, with the Request Authenticator as challenge.
+	req = accessRequest(2, "nemo")
+	h := md5.New()
+	h.Write([]byte{42})
+	h.Write([]byte("arctangent"))
+	h.Write(req.Authenticator[:])
+	req.Add(AttrCHAPPassword, append([]byte{42}, h.Sum(nil)...))
+	if b, err = req.EncodeRequest(testSecret); err != nil {
+		t.Fatal(err)
+	}
+	if resp = exchange(t, c, b, req); resp == nil || resp.Code != AccessAccept {
+		t.Fatalf("Unexpected reply: %+v", resp)
+	}
+
+	// Wrong password.
+	req = accessRequest(3, "nemo")
+	req.Add(AttrUserPassword, HidePassword([]byte("wrong"), testSecret, req.Authenticator))
+	if b, err = req.EncodeRequest(testSecret); err != nil {
+		t.Fatal(err)
+	}
+	if resp = exchange(t, c, b, req); resp == nil || resp.Code != AccessReject || len(resp.Attributes) != 0 {
+		t.Fatalf("Unexpected reply: %+v", resp)
+	}
+
+	// Bad Message-Authenticator: dropped.
+	req = accessRequest(4, "nemo")
+	req.Add(AttrMessageAuthenticator, make([]byte, 16))
+	if b, err = req.EncodeRequest([]byte("wrong")); err != nil {
+		t.Fatal(err)
+	}
+	if resp = exchange(t, c, b, req); resp != nil {
+		t.Fatalf("Unexpected reply: %+v", resp)
+	}
+}
+
+func TestGateway_Accounting(t *testing.T) {
+	ts, c, done := dial(t)
+	defer done()
+
+	req := &Packet{Code: AccountingRequest, Identifier: 1}
+	req.Add(AttrAcctStatusType, []byte{0, 0, 0, AcctStatusStop})
+	req.Add(AttrUserName, []byte("nemo"))
+	req.Add(AttrNASIPAddress, net.ParseIP("192.168.1.16").To4())
+	req.Add(AttrAcctSessionID, []byte("0000001"))
+	req.Add(AttrAcctInputOctets, []byte{0, 0, 0, 10})
+	req.Add(AttrAcctInputGigawords, []byte{0, 0, 0, 1})
+	req.Add(AttrAcctOutputPackets, []byte{0, 0, 0, 7})
+	req.Add(AttrAcctTerminateCause, []byte{0, 0, 0, 1}) // User Request
+	req.Add(AttrEventTimestamp, []byte{0x5f, 0x5e, 0x10, 0x00})
+	req.Add(AttrProxyState, []byte("proxy"))
+	b, err := req.EncodeRequest(testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp := exchange(t, c, b, req)
+	if resp == nil || resp.Code != AccountingResponse || string(resp.Get(AttrProxyState)) != "proxy" {
+		t.Fatalf("Unexpected reply: %+v", resp)
+	}
+
+	ts.mu.Lock()
+	acr := ts.acrs[0]
+	ts.mu.Unlock()
+	for _, tc := range []struct {
+		code uint32
+		want datatype.Type
+	}{
+		{avp.SessionID, datatype.UTF8String("gw;192.168.1.16;0000001")},
+		{avp.AccountingRecordType, datatype.Enumerated(4)},
+		{avp.AcctApplicationID, datatype.Unsigned32(diam.NETWORK_ACCESS_APP_ID)},
+		{avp.UserName, datatype.UTF8String("nemo")},
+		{avp.AccountingSessionID, datatype.OctetString("0000001")},
+		{avp.AccountingInputOctets, datatype.Unsigned64(1<<32 | 10)},
+		{avp.AccountingOutputPackets, datatype.Unsigned64(7)},
+		{avp.TerminationCause, datatype.Enumerated(11)},
+		{avp.EventTimestamp, datatype.Time(time.Unix(0x5f5e1000, 0))},
+	} {
+		x, err := acr.FindAVP(tc.code, 0)
+		if err != nil {
+			t.Fatalf("Missing AVP %d: %v", tc.code, err)
+		}
+		if !bytes.Equal(x.Data.Serialize(), tc.want.Serialize()) {
+			t.Fatalf("Unexpected AVP %d: %v", tc.code, x.Data)
+		}
+	}
+
+	// Accounting-On is answered with an error, and so dropped.
+	req = &Packet{Code: AccountingRequest, Identifier: 2}
+	req.Add(AttrAcctStatusType, []byte{0, 0, 0, AcctStatusAccountingOn})
+	req.Add(AttrNASIPAddress, net.ParseIP("192.168.1.16").To4())
+	if b, err = req.EncodeRequest(testSecret); err != nil {
+		t.Fatal(err)
+	}
+	if resp = exchange(t, c, b, req); resp != nil {
+		t.Fatalf("Unexpected reply: %+v", resp)
+	}
+}
@@ -0,0 +1,230 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package radius
+
+import (
+	"encoding/binary"
+	"strings"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// statePrefix marks the State attributes that carry a Diameter
+// Session-Id, so that the next Access-Request of the user continues the
+// same session. See RFC 7155 section 9.1.
+const statePrefix = "Diameter/"
+
+// translated lists the attributes that are not translated to the AVP of
+// the same code, because they need a special treatment or have no
+// Diameter equivalent.
+var translated = map[uint8]bool{
+	AttrUserPassword:         true,
+	AttrCHAPPassword:         true,
+	AttrVendorSpecific:       true,
+	AttrProxyState:           true,
+	AttrAcctStatusType:       true,
+	AttrAcctInputOctets:      true,
+	AttrAcctOutputOctets:     true,
+	AttrAcctInputPackets:     true,
+	AttrAcctOutputPackets:    true,
+	AttrAcctTerminateCause:   true,
+	AttrAcctInputGigawords:   true,
+	AttrAcctOutputGigawords:  true,
+	AttrEAPMessage:           true,
+	AttrMessageAuthenticator: true,
+}
+
+// addAttributes appends to m the AVPs translating the attributes of p
+// that have a Diameter AVP of the same code in the dictionary of m.
+// Attributes that are unknown or cannot be decoded are dropped.
+func addAttributes(m *diam.Message, p *Packet) {
+	parser := m.Dictionary()
+	for _, a := range p.Attributes {
+		if translated[a.Type] {
+			continue
+		}
+		if a.Type == AttrState && strings.HasPrefix(string(a.Value), statePrefix) {
+			continue
+		}
+		d, err := parser.FindAVPWithVendor(m.Header.ApplicationID, uint32(a.Type), 0)
+		if err != nil {
+			continue
+		}
+		var data datatype.Type
+		switch d.Data.Type {
+		case datatype.GroupedType:
+			continue
+		case datatype.AddressType:
+			data = datatype.Address(a.Value)
+		case datatype.TimeType:
+			if len(a.Value) != 4 {
+				continue
+			}
+			data = datatype.Time(time.Unix(int64(binary.BigEndian.Uint32(a.Value)), 0))
+		case datatype.Unsigned64Type:
+			if len(a.Value) != 4 {
+				continue
+			}
+			data = datatype.Unsigned64(binary.BigEndian.Uint32(a.Value))
+		default:
+			if data, err = datatype.Decode(d.Data.Type, a.Value); err != nil {
+				continue
+			}
+		}
+		m.NewAVP(d.Code, flags(d.Must), 0, data)
+	}
+}
+
+// flags returns the AVP flags of the must attribute of a dictionary AVP.
+func flags(must string) uint8 {
+	if strings.Contains(must, "M") {
+		return avp.Mbit
+	}
+	return 0
+}
+
+// addProxyInfo carries each Proxy-State attribute of p in a Proxy-Info
+// AVP of m, identifying the gateway as the Proxy-Host, and returns their
+// values.
+func addProxyInfo(m *diam.Message, p *Packet, host datatype.DiameterIdentity) [][]byte {
+	var states [][]byte
+	for _, a := range p.Attributes {
+		if a.Type != AttrProxyState {
+			continue
+		}
+		states = append(states, a.Value)
+		m.NewAVP(avp.ProxyInfo, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.ProxyHost, avp.Mbit, 0, host),
+				diam.NewAVP(avp.ProxyState, avp.Mbit, 0, datatype.OctetString(a.Value)),
+			},
+		})
+	}
+	return states
+}
+
+// addProxyStates appends to the reply p the Proxy-State attributes of
+// the request, taken from the Proxy-Info AVPs of the Diameter answer a
+// identifying the gateway, or else from states, those of the request.
+// See RFC 7155 section 9.1.
+func addProxyStates(p *Packet, a *diam.Message, host datatype.DiameterIdentity, states [][]byte) {
+	var echoed [][]byte
+	for _, x := range a.AVP {
+		g, ok := x.Data.(*diam.GroupedAVP)
+		if x.Code != avp.ProxyInfo || !ok {
+			continue
+		}
+		var proxyHost, state []byte
+		for _, y := range g.AVP {
+			switch y.Code {
+			case avp.ProxyHost:
+				proxyHost = y.Data.Serialize()
+			case avp.ProxyState:
+				state = y.Data.Serialize()
+			}
+		}
+		if string(proxyHost) == string(host) {
+			echoed = append(echoed, state)
+		}
+	}
+	if len(echoed) != len(states) {
+		echoed = states
+	}
+	for _, s := range echoed {
+		p.Add(AttrProxyState, s)
+	}
+}
+
+// addAVPs appends to the reply p the attributes translating the AVPs of
+// the Diameter answer a whose code is a RADIUS attribute type. When
+// only is not empty, other attributes are dropped.
+func addAVPs(p *Packet, a *diam.Message, only ...uint8) {
+	for _, x := range a.AVP {
+		if x.VendorID != 0 || x.Code == 0 || x.Code > 255 || translated[uint8(x.Code)] {
+			continue
+		}
+		if len(only) > 0 && !contains(only, uint8(x.Code)) {
+			continue
+		}
+		var value []byte
+		switch d := x.Data.(type) {
+		case *diam.GroupedAVP, datatype.Unsigned64:
+			continue
+		case datatype.Address:
+			value = []byte(d)
+		case datatype.Time:
+			value = make([]byte, 4)
+			binary.BigEndian.PutUint32(value, uint32(time.Time(d).Unix()))
+		default:
+			value = d.Serialize()
+		}
+		if len(value) > 253 {
+			continue
+		}
+		p.Add(uint8(x.Code), value)
+	}
+}
+
+func contains(types []uint8, typ uint8) bool {
+	for _, t := range types {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// resultCode returns the Result-Code of the Diameter answer a, or zero.
+func resultCode(a *diam.Message) uint32 {
+	x, err := a.FindAVP(avp.ResultCode, 0)
+	if err != nil {
+		return 0
+	}
+	rc, _ := x.Data.(datatype.Unsigned32)
+	return uint32(rc)
+}
+
+// recordType returns the Accounting-Record-Type of an Acct-Status-Type.
+// See RFC 7155 section 9.5.
+func recordType(status uint32) (int32, bool) {
+	switch status {
+	case AcctStatusStart:
+		return 2, true // START_RECORD
+	case AcctStatusInterimUpdate:
+		return 3, true // INTERIM_RECORD
+	case AcctStatusStop:
+		return 4, true // STOP_RECORD
+	case AcctStatusAccountingOn, AcctStatusAccountingOff:
+		return 1, true // EVENT_RECORD
+	}
+	return 0, false
+}
+
+// addCounters appends the 64-bit Accounting octet and packet counters
+// of the 32-bit RADIUS counters and gigawords of p to m.
+func addCounters(m *diam.Message, p *Packet) {
+	for _, c := range []struct {
+		avp       uint32
+		low, high uint8
+	}{
+		{avp.AccountingInputOctets, AttrAcctInputOctets, AttrAcctInputGigawords},
+		{avp.AccountingOutputOctets, AttrAcctOutputOctets, AttrAcctOutputGigawords},
+		{avp.AccountingInputPackets, AttrAcctInputPackets, 0},
+		{avp.AccountingOutputPackets, AttrAcctOutputPackets, 0},
+	} {
+		low := p.Get(c.low)
+		if len(low) != 4 {
+			continue
+		}
+		n := uint64(binary.BigEndian.Uint32(low))
+		if high := p.Get(c.high); c.high != 0 && len(high) == 4 {
+			n |= uint64(binary.BigEndian.Uint32(high)) << 32
+		}
+		m.NewAVP(c.avp, avp.Mbit, 0, datatype.Unsigned64(n))
+	}
+}
@@ -47,8 +47,127 @@ func isEmptyValue(v reflect.Value) bool {
 	return false
 }
 
+// structPlan is the compiled form of a struct type, whose avp tags are
+// resolved in the dictionary of a message and its application. Plans are
+// cached in the dictionary, see planOf.
+type structPlan struct {
+	fields []fieldPlan
+}
+
+// fieldPlan is a field of a struct type and its AVP.
+type fieldPlan struct {
+	index     int
+	embedded  bool // Untagged embedded struct, whose fields are promoted
+	name      string
+	omitEmpty bool
+	avp       *avpPlan
+	err       error // Lookup error, returned only when the field is used
+}
+
+// avpPlan is a dictionary AVP, with its flags and the Go type its data
+// is converted to.
+type avpPlan struct {
+	*dict.AVP
+	flags uint8
+	data  reflect.Type // Nil for Grouped AVPs
+}
+
+// planKey is the key of struct plans in the cache of dictionaries.
+type planKey struct {
+	typ   reflect.Type
+	appID uint32
+}
+
+// dataTypes are the Go types of the dictionary data types.
+var dataTypes = map[datatype.TypeID]reflect.Type{
+	datatype.AddressType:          reflect.TypeOf((*datatype.Address)(nil)).Elem(),
+	datatype.DiameterIdentityType: reflect.TypeOf((*datatype.DiameterIdentity)(nil)).Elem(),
+	datatype.DiameterURIType:      reflect.TypeOf((*datatype.DiameterURI)(nil)).Elem(),
+	datatype.EnumeratedType:       reflect.TypeOf((*datatype.Enumerated)(nil)).Elem(),
+	datatype.Float32Type:          reflect.TypeOf((*datatype.Float32)(nil)).Elem(),
+	datatype.Float64Type:          reflect.TypeOf((*datatype.Float64)(nil)).Elem(),
+	datatype.IPFilterRuleType:     reflect.TypeOf((*datatype.IPFilterRule)(nil)).Elem(),
+	datatype.IPv4Type:             reflect.TypeOf((*datatype.IPv4)(nil)).Elem(),
+	datatype.Integer32Type:        reflect.TypeOf((*datatype.Integer32)(nil)).Elem(),
+	datatype.Integer64Type:        reflect.TypeOf((*datatype.Integer64)(nil)).Elem(),
+	datatype.OctetStringType:      reflect.TypeOf((*datatype.OctetString)(nil)).Elem(),
+	datatype.TimeType:             reflect.TypeOf((*datatype.Time)(nil)).Elem(),
+	datatype.UTF8StringType:       reflect.TypeOf((*datatype.UTF8String)(nil)).Elem(),
+	datatype.Unsigned32Type:       reflect.TypeOf((*datatype.Unsigned32)(nil)).Elem(),
+	datatype.Unsigned64Type:       reflect.TypeOf((*datatype.Unsigned64)(nil)).Elem(),
+}
+
+// planOf returns the plan of the struct type t for the dictionary and
+// application of m. Plans are compiled once per dictionary snapshot, and
+// dropped with it when the dictionary is reloaded.
+func planOf(m *Message, t reflect.Type) *structPlan {
+	cache := m.Dictionary().Cache()
+	key := planKey{t, m.Header.ApplicationID}
+	if sp, ok := cache.Load(key); ok {
+		return sp.(*structPlan)
+	}
+	sp := &structPlan{fields: make([]fieldPlan, 0, t.NumField())}
+	for n := 0; n < t.NumField(); n++ {
+		bt := t.Field(n)
+		if bt.Anonymous && bt.Type.Kind() == reflect.Struct && len(bt.Tag) == 0 {
+			sp.fields = append(sp.fields, fieldPlan{index: n, embedded: true})
+			continue
+		}
+		name, omitEmpty := parseAvpTag(bt.Tag)
+		if len(name) == 0 {
+			continue
+		}
+		fp := fieldPlan{index: n, name: name, omitEmpty: omitEmpty}
+		// Lookup the AVP name (tag) in the dictionary, the dictionary AVP has the code.
+		// Relies on the fact that in the same app will not be AVPs with same code but different vendorId
+		d, err := m.Dictionary().FindAVP(m.Header.ApplicationID, name)
+		if err != nil {
+			fp.err = err
+		} else {
+			fp.avp = newAVPPlan(d)
+		}
+		sp.fields = append(sp.fields, fp)
+	}
+	actual, _ := cache.LoadOrStore(key, sp)
+	return actual.(*structPlan)
+}
+
+func newAVPPlan(d *dict.AVP) *avpPlan {
+	ap := &avpPlan{AVP: d, data: dataTypes[d.Data.Type]}
+	if strings.Contains(d.Must, "M") {
+		ap.flags = avp.Mbit
+	}
+	if d.VendorID > 0 {
+		ap.flags |= avp.Vbit
+	}
+	return ap
+}
+
+// AVPMarshaler is implemented by types that encode themselves into the
+// AVPs of a message, such as those generated by diam-gen -codec. Marshal
+// prefers it to reflection.
+type AVPMarshaler interface {
+	MarshalAVP(m *Message) ([]*AVP, error)
+}
+
+// AVPUnmarshaler is implemented by types that decode themselves from the
+// AVPs of a message, such as those generated by diam-gen -codec.
+// Unmarshal prefers it to reflection.
+type AVPUnmarshaler interface {
+	UnmarshalAVP(m *Message, avps []*AVP) error
+}
+
 // Marshal encodes struct into AVPs
 func (m *Message) Marshal(src interface{}) error {
+	if mv, ok := src.(AVPMarshaler); ok {
+		avps, err := mv.MarshalAVP(m)
+		if err != nil {
+			return err
+		}
+		m.AVP = avps
+		m.Header.MessageLength = uint32(m.Len())
+		return nil
+	}
 	v := reflect.ValueOf(src)
 	if v.Kind() != reflect.Ptr {
 		return errors.New("src is not a pointer to struct")
@@ -63,20 +182,17 @@ func (m *Message) Marshal(src interface{}) error {
 }
 
 func marshalStruct(m *Message, field reflect.Value) (error, []*AVP) {
-	var err error
-	var dictAVP *dict.AVP
-	var avps []*AVP
-
 	base := reflect.Indirect(field)
 	if base.Kind() != reflect.Struct {
 		return errors.New("src is not a pointer to struct"), nil
 	}
 
-	for n := 0; n < base.NumField(); n++ {
-		f := base.Field(n)
-		bt := base.Type().Field(n)
+	sp := planOf(m, base.Type())
+	avps := make([]*AVP, 0, len(sp.fields))
+	for _, fp := range sp.fields {
+		f := base.Field(fp.index)
 
-		if bt.Anonymous && bt.Type.Kind() == reflect.Struct && len(bt.Tag) == 0 {
+		if fp.embedded {
 			err, embeddedAvps := marshalStruct(m, f)
 			if err != nil {
 				return err, nil
@@ -85,20 +201,15 @@ func marshalStruct(m *Message, field reflect.Value) (error, []*AVP) {
 			continue
 		}
 
-		avpName, omitEmpty := parseAvpTag(bt.Tag)
-		if len(avpName) == 0 || (omitEmpty && isEmptyValue(f)) {
+		if fp.omitEmpty && isEmptyValue(f) {
 			// TODO: check the required attribute in AVP rule?
 			continue
 		}
-
-		// Lookup the AVP name (tag) in the dictionary, the dictionary AVP has the code.
-		// Relies on the fact that in the same app will not be AVPs with same code but different vendorId
-		dictAVP, err = m.Dictionary().FindAVP(m.Header.ApplicationID, avpName)
-		if err != nil {
-			return err, nil
+		if fp.err != nil {
+			return fp.err, nil
 		}
 
-		err, avp := marshal(m, f, dictAVP)
+		err, avp := marshal(m, f, fp.avp)
 		if err != nil {
 			return err, nil
 		}
@@ -109,7 +220,7 @@ func marshalStruct(m *Message, field reflect.Value) (error, []*AVP) {
 }
 
 // marshal returns a AVP type of the field
-func marshal(m *Message, field reflect.Value, fieldAVP *dict.AVP) (error, []*AVP) {
+func marshal(m *Message, field reflect.Value, fieldAVP *avpPlan) (error, []*AVP) {
 	var data datatype.Type
 	var avps []*AVP
 	fieldType := field.Type()
@@ -150,38 +261,10 @@ func marshal(m *Message, field reflect.Value, fieldAVP *dict.AVP) (error, []*AVP
 	}
 
 BASIC_TYPE:
-	switch fieldAVP.Data.Type {
-	case datatype.AddressType:
-		t = reflect.TypeOf((*datatype.Address)(nil)).Elem() // get Type of datatype.Address
-	case datatype.DiameterIdentityType:
-		t = reflect.TypeOf((*datatype.DiameterIdentity)(nil)).Elem()
-	case datatype.DiameterURIType:
-		t = reflect.TypeOf((*datatype.DiameterURI)(nil)).Elem()
-	case datatype.EnumeratedType:
-		t = reflect.TypeOf((*datatype.Enumerated)(nil)).Elem()
-	case datatype.Float32Type:
-		t = reflect.TypeOf((*datatype.Float32)(nil)).Elem()
-	case datatype.Float64Type:
-		t = reflect.TypeOf((*datatype.Float64)(nil)).Elem()
-	case datatype.IPFilterRuleType:
-		t = reflect.TypeOf((*datatype.IPFilterRule)(nil)).Elem()
-	case datatype.IPv4Type:
-		t = reflect.TypeOf((*datatype.IPv4)(nil)).Elem()
-	case datatype.Integer32Type:
-		t = reflect.TypeOf((*datatype.Integer32)(nil)).Elem()
-	case datatype.Integer64Type:
-		t = reflect.TypeOf((*datatype.Integer64)(nil)).Elem()
-	case datatype.OctetStringType:
-		t = reflect.TypeOf((*datatype.OctetString)(nil)).Elem()
-	case datatype.TimeType:
-		t = reflect.TypeOf((*datatype.Time)(nil)).Elem()
-	case datatype.UTF8StringType:
-		t = reflect.TypeOf((*datatype.UTF8String)(nil)).Elem()
-	case datatype.Unsigned32Type:
-		t = reflect.TypeOf((*datatype.Unsigned32)(nil)).Elem()
-	case datatype.Unsigned64Type:
-		t = reflect.TypeOf((*datatype.Unsigned64)(nil)).Elem()
-	case datatype.GroupedType:
+	switch {
+	case fieldAVP.data != nil:
+		t = fieldAVP.data
+	case fieldAVP.Data.Type == datatype.GroupedType:
 		if field.Kind() == reflect.Struct {
 			// 1.  diam.AVP
 			// if fieldType.String() == "diam.AVP"
@@ -194,22 +277,18 @@ BASIC_TYPE:
 			}
 
 			// 2. GroupedAVP
-			gAVP := &GroupedAVP{}
-			for n := 0; n < field.NumField(); n++ {
-				f := field.Field(n)
-				bt := field.Type().Field(n)
-				avpname, omitEmpty := parseAvpTag(bt.Tag)
-				if len(avpname) == 0 || (omitEmpty && isEmptyValue(f)) {
+			sp := planOf(m, fieldType)
+			gAVP := &GroupedAVP{AVP: make([]*AVP, 0, len(sp.fields))}
+			for _, fp := range sp.fields {
+				f := field.Field(fp.index)
+				if fp.embedded || (fp.omitEmpty && isEmptyValue(f)) {
 					// TODO: check the required attribute in AVP rule?
 					continue
 				}
-				// Lookup the AVP name (tag) in the dictionary, the dictionary AVP has the code.
-				// Relies on the fact that in the same app will not be AVPs with same code but different vendorId
-				d, err := m.Dictionary().FindAVP(m.Header.ApplicationID, avpname)
-				if err != nil {
-					return err, nil
+				if fp.err != nil {
+					return fp.err, nil
 				}
-				err, avp := marshal(m, f, d)
+				err, avp := marshal(m, f, fp.avp)
 				if err != nil {
 					return err, nil
 				}
@@ -230,18 +309,10 @@ BASIC_TYPE:
 	}
 
 	if data == nil { // basic non-grouped AVP
-		p := reflect.New(t)
-		v := reflect.Indirect(p)
-
-		if fieldType.AssignableTo(t) {
-			// log.Println("assign: ", fieldAVP.Name, " ", fieldType.String(), " => ", t.String())
-			v.Set(field)
-		} else if fieldType.ConvertibleTo(t) {
-			// log.Println("convert: ", fieldAVP.Name, " ", fieldType.String(), " => ", t.String())
-			v.Set(field.Convert(t))
-		} else {
+		if !fieldType.ConvertibleTo(t) {
 			return errors.New(fieldAVP.Name + " AVP type mismatched. " + fieldType.String() + " => " + t.String()), nil
 		}
+		v := field.Convert(t)
 		var ok bool
 		data, ok = v.Interface().(datatype.Type)
 		if !ok {
@@ -249,17 +320,9 @@ BASIC_TYPE:
 		}
 	}
 
-	var avpFlags uint8
-	if strings.Contains(fieldAVP.Must, "M") {
-		avpFlags = avp.Mbit
-	}
-	if fieldAVP.VendorID > 0 {
-		avpFlags |= avp.Vbit
-	}
-
 	avp := &AVP{
 		Code:     fieldAVP.Code,
-		Flags:    avpFlags,
+		Flags:    fieldAVP.flags,
 		VendorID: fieldAVP.VendorID,
 		Data:     data,
 	}
@@ -334,8 +397,15 @@ BASIC_TYPE:
 // just AVP or *AVP, making it easier to re-use them in the answer.
 //
 // Note that decoding values to *AVP is much faster and more efficient than
-// decoding to AVP or the native Go types.
+// decoding to AVP or the native Go types. The avp tags of each struct type
+// are resolved once per dictionary and application, and cached in the
+// dictionary.
+//
+// Types that implement AVPUnmarshaler decode themselves instead.
 func (m *Message) Unmarshal(dst interface{}) error {
+	if u, ok := dst.(AVPUnmarshaler); ok {
+		return u.UnmarshalAVP(m, m.AVP)
+	}
 	v := reflect.ValueOf(dst)
 	if v.Kind() != reflect.Ptr {
 		return errors.New("dst is not a pointer to struct")
@@ -359,29 +429,21 @@ func scanStruct(m *Message, field reflect.Value, avps []*AVP) error {
 		return errors.New("dst is not a pointer to struct")
 	}
 	idx := newIndex(avps)
-	for n := 0; n < base.NumField(); n++ {
-		f := base.Field(n)
-		bt := base.Type().Field(n)
+	for _, fp := range planOf(m, base.Type()).fields {
+		f := base.Field(fp.index)
 
-		if bt.Anonymous && bt.Type.Kind() == reflect.Struct && len(bt.Tag) == 0 {
+		if fp.embedded {
 			if err := scanStruct(m, f, avps); err != nil {
 				return err
 			}
 			continue
 		}
 
-		avpname, _ := parseAvpTag(bt.Tag)
-		if len(avpname) == 0 {
-			continue
-		}
-		// Lookup the AVP name (tag) in the dictionary.
-		// The dictionary AVP has the code.
-		d, err := m.Dictionary().FindAVP(m.Header.ApplicationID, avpname) // Relies on the fact that in the same app will not be AVPs with same code but different vendorId
-		if err != nil {
-			return err
+		if fp.err != nil {
+			return fp.err
 		}
 		// See if this AVP exist in the message.
-		avps, exists := idx[d.Code]
+		avps, exists := idx[fp.avp.Code]
 		if !exists {
 			continue
 		}
@@ -7,9 +7,12 @@ package diam
 import (
 	"bytes"
 	"net"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/fiorix/go-diameter/v4/diam/avp"
 	"github.com/fiorix/go-diameter/v4/diam/datatype"
 	"github.com/fiorix/go-diameter/v4/diam/dict"
 )
@@ -420,3 +423,105 @@ func BenchmarkUnmarshal(b *testing.B) {
 		msg.Unmarshal(&cer)
 	}
 }
+
+func BenchmarkMarshal(b *testing.B) {
+	type CER struct {
+		OriginHost  string `avp:"Origin-Host"`
+		OriginRealm string `avp:"Origin-Realm"`
+		HostIP      net.IP `avp:"Host-IP-Address"`
+		VendorID    int    `avp:"Vendor-Id"`
+		ProductName string `avp:"Product-Name"`
+		StateID     int    `avp:"Origin-State-Id"`
+	}
+	cer := &CER{"test", "localhost", net.ParseIP("10.1.0.1"), 13, "go-diameter", 1}
+	msg := NewRequest(CapabilitiesExchange, 0, dict.Default)
+	for n := 0; n < b.N; n++ {
+		msg.Marshal(cer)
+	}
+}
+
+func testPlanDict(code int) string {
+	return `<diameter>
+  <application id="0">
+    <avp name="Plan-Value" code="` + strconv.Itoa(code) + `" must="M" may="P" must-not="V" may-encrypt="N">
+      <data type="Unsigned32" />
+    </avp>
+  </application>
+</diameter>`
+}
+
+func TestMarshalPlanLoad(t *testing.T) {
+	p, _ := dict.NewParser()
+	if err := p.Load(strings.NewReader(testPlanDict(9001))); err != nil {
+		t.Fatal(err)
+	}
+	type Data struct {
+		Value   int `avp:"Plan-Value"`
+		Missing int `avp:"Plan-Missing,omitempty"`
+	}
+	m := NewRequest(CapabilitiesExchange, 0, p)
+	if err := m.Marshal(&Data{Value: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.AVP) != 1 || m.AVP[0].Code != 9001 {
+		t.Fatalf("Unexpected message: %s", m)
+	}
+	if err := m.Marshal(&Data{Value: 1, Missing: 1}); err == nil {
+		t.Fatal("Marshal accepted an AVP missing from the dictionary")
+	}
+	if err := m.Unmarshal(&Data{}); err == nil {
+		t.Fatal("Unmarshal accepted an AVP missing from the dictionary")
+	}
+
+	// Plans of the previous dictionaries are not used after a load.
+	if err := p.Load(strings.NewReader(testPlanDict(9002))); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Marshal(&Data{Value: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.AVP) != 1 || m.AVP[0].Code != 9002 {
+		t.Fatalf("Unexpected message after load: %s", m)
+	}
+	var d Data
+	if err := m.Unmarshal(&d); err == nil {
+		t.Fatal("Unmarshal accepted an AVP missing from the dictionary")
+	}
+}
+
+// hostCER encodes and decodes its Origin-Host without reflection.
+type hostCER struct {
+	OriginHost datatype.DiameterIdentity `avp:"Product-Name"` // Reflection would use this tag.
+	decoded    int
+}
+
+func (c *hostCER) MarshalAVP(m *Message) ([]*AVP, error) {
+	return []*AVP{NewAVP(avp.OriginHost, avp.Mbit, 0, c.OriginHost)}, nil
+}
+
+func (c *hostCER) UnmarshalAVP(m *Message, avps []*AVP) error {
+	for _, a := range avps {
+		if a.Code == avp.OriginHost {
+			c.OriginHost = a.Data.(datatype.DiameterIdentity)
+			c.decoded++
+		}
+	}
+	return nil
+}
+
+func TestMarshalAVPMarshaler(t *testing.T) {
+	m := NewRequest(CapabilitiesExchange, 0, dict.Default)
+	if err := m.Marshal(&hostCER{OriginHost: "test"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.AVP) != 1 || m.AVP[0].Code != avp.OriginHost || int(m.Header.MessageLength) != m.Len() {
+		t.Fatalf("Unexpected message: %s", m)
+	}
+	var d hostCER
+	if err := m.Unmarshal(&d); err != nil {
+		t.Fatal(err)
+	}
+	if d.OriginHost != "test" || d.decoded != 1 {
+		t.Fatalf("Unexpected value: %+v", d)
+	}
+}
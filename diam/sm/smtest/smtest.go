@@ -0,0 +1,83 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package smtest provides utilities for testing applications built on
+// the sm package.
+package smtest
+
+import (
+	"net"
+	"testing"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+// Settings returns the settings of a peer named host, in the realm
+// "test", on the local loopback interface.
+func Settings(host string) *sm.Settings {
+	return &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity(host),
+		OriginRealm:      "test",
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	}
+}
+
+// Peer is a peer of a client and server test: its host name, and the
+// handler of the commands it receives.
+type Peer struct {
+	Host     string
+	Handler  diam.Handler
+	Commands []diam.CommandIndex
+}
+
+// mux returns a state machine of the peer, handling its commands.
+func (p Peer) mux() *sm.StateMachine {
+	mux := sm.New(Settings(p.Host))
+	for _, idx := range p.Commands {
+		mux.HandleIdx(idx, p.Handler)
+	}
+	return mux
+}
+
+// Connect starts a test server for the peer server, with the default
+// dictionary, and connects the peer client to it. The client advertises
+// the application appID of the vendor vendorID in a
+// Vendor-Specific-Application-Id, or in an Auth-Application-Id when
+// vendorID is 0. It returns the connection of the client and a function
+// closing it and the server.
+func Connect(t testing.TB, server, client Peer, vendorID, appID uint32) (diam.Conn, func()) {
+	t.Helper()
+	srv := diamtest.NewServer(server.mux(), dict.Default)
+	cli := &sm.Client{Handler: client.mux()}
+	id := diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(appID))
+	if vendorID == 0 {
+		cli.AuthApplicationID = []*diam.AVP{id}
+	} else {
+		cli.VendorSpecificApplicationID = []*diam.AVP{
+			diam.NewAVP(avp.VendorSpecificApplicationID, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					id,
+					diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(vendorID)),
+				},
+			}),
+		}
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	return c, func() {
+		c.Close()
+		srv.Close()
+	}
+}
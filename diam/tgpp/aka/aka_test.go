@@ -0,0 +1,276 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package aka
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// Test sets from 3GPP TS 35.208 section 4.3.
+var testSets = []struct {
+	k, rand, sqn, amf, op, opc         string
+	f1, f1star, f2, f5, f3, f4, f5star string
+}{
+	{
+		k:      "465b5ce8b199b49faa5f0a2ee238a6bc",
+		rand:   "23553cbe9637a89d218ae64dae47bf35",
+		sqn:    "ff9bb4d0b607",
+		amf:    "b9b9",
+		op:     "cdc202d5123e20f62b6d676ac72cb318",
+		opc:    "cd63cb71954a9f4e48a5994e37a02baf",
+		f1:     "4a9ffac354dfafb3",
+		f1star: "01cfaf9ec4e871e9",
+		f2:     "a54211d5e3ba50bf",
+		f5:     "aa689c648370",
+		f3:     "b40ba9a3c58b2a05bbf0d987b21bf8cb",
+		f4:     "f769bcd751044604127672711c6d3441",
+		f5star: "451e8beca43b",
+	},
+	{
+		k:      "0396eb317b6d1c36f19c1c84cd6ffd16",
+		rand:   "c00d603103dcee52c4478119494202e8",
+		sqn:    "fd8eef40df7d",
+		amf:    "af17",
+		op:     "ff53bade17df5d4e793073ce9d7579fa",
+		opc:    "53c15671c60a4b731c55b4a441c0bde2",
+		f1:     "5df5b31807e258b0",
+		f1star: "a8c016e51ef4a343",
+		f2:     "d3a628ed988620f0",
+		f5:     "c47783995f72",
+		f3:     "58c433ff7a7082acd424220f2b67c556",
+		f4:     "21a8c1f929702adb3e738488b9f5c5da",
+		f5star: "30f1197061c1",
+	},
+	{
+		k:      "fec86ba6eb707ed08905757b1bb44b8f",
+		rand:   "9f7c8d021accf4db213ccff0c7f71a6a",
+		sqn:    "9d0277595ffc",
+		amf:    "725c",
+		op:     "dbc59adcb6f9a0ef735477b7fadf8374",
+		opc:    "1006020f0a478bf6b699f15c062e42b3",
+		f1:     "9cabc3e99baf7281",
+		f1star: "95814ba2b3044324",
+		f2:     "8011c48c0c214ed2",
+		f5:     "33484dc2136b",
+		f3:     "5dbdbb2954e8f3cde665b046179a5098",
+		f4:     "59a92d3b476a0443487055cf88b2307b",
+		f5star: "deacdd848cc6",
+	},
+}
+
+func unhex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func checkHex(t *testing.T, set int, name string, have []byte, want string) {
+	t.Helper()
+	if hex.EncodeToString(have) != want {
+		t.Errorf("Test set %d: unexpected %s. Want %s, have %x", set+1, name, want, have)
+	}
+}
+
+func TestMilenage(t *testing.T) {
+	for i, ts := range testSets {
+		k := unhex(t, ts.k)
+		opc, err := ComputeOPc(k, unhex(t, ts.op))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkHex(t, i, "OPc", opc, ts.opc)
+		m, err := NewMilenage(k, opc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rand := unhex(t, ts.rand)
+		macA, macS, err := m.F1(rand, unhex(t, ts.sqn), unhex(t, ts.amf))
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkHex(t, i, "f1", macA, ts.f1)
+		checkHex(t, i, "f1*", macS, ts.f1star)
+		res, ck, ik, ak, err := m.F2345(rand)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkHex(t, i, "f2", res, ts.f2)
+		checkHex(t, i, "f3", ck, ts.f3)
+		checkHex(t, i, "f4", ik, ts.f4)
+		checkHex(t, i, "f5", ak, ts.f5)
+		akStar, err := m.F5Star(rand)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkHex(t, i, "f5*", akStar, ts.f5star)
+	}
+}
+
+func TestMilenage_InvalidLength(t *testing.T) {
+	if _, err := NewMilenage(make([]byte, 15), make([]byte, 16)); err != ErrInvalidLength {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrInvalidLength, err)
+	}
+	m, err := NewMilenage(make([]byte, 16), make([]byte, 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = m.F1(make([]byte, 16), make([]byte, 5), make([]byte, 2)); err != ErrInvalidLength {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrInvalidLength, err)
+	}
+}
+
+// TestKASME checks the key derivation of 3GPP TS 33.401 annex A.2 with
+// the CK, IK, SQN and AK of TS 35.208 test set 1 and the PLMN 001/01.
+// S is FC 0x10 || 00f110 || 0003 || SQN xor AK || 0006, and KASME is the
+// HMAC-SHA-256 of S with the key CK || IK, computed apart from this
+// package with
+//
+//	printf 1000f110000355f328b435770006 | xxd -r -p | openssl dgst -sha256 -mac HMAC \
+//		-macopt hexkey:b40ba9a3c58b2a05bbf0d987b21bf8cbf769bcd751044604127672711c6d3441
+func TestKASME(t *testing.T) {
+	ck := unhex(t, "b40ba9a3c58b2a05bbf0d987b21bf8cb")
+	ik := unhex(t, "f769bcd751044604127672711c6d3441")
+	sqnXorAK := unhex(t, "55f328b43577") // ff9bb4d0b607 xor aa689c648370
+	kasme, err := KASME(ck, ik, []byte{0x00, 0xf1, 0x10}, sqnXorAK)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, 0, "KASME", kasme, "48579af8781c742d5120e6ed8ccac13193f38c53ab7aa69396f49ca6e1b0562d")
+	if _, err = KASME(ck, ik, []byte{0x00, 0xf1}, sqnXorAK); err != ErrInvalidLength {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrInvalidLength, err)
+	}
+}
+
+func TestSubscriber_EUTRANVector(t *testing.T) {
+	ts := testSets[0]
+	s, err := NewSubscriber(unhex(t, ts.k), unhex(t, ts.opc), 0xff9bb4d0b5e0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	copy(s.AMF[:], unhex(t, ts.amf))
+	s.Rand = bytes.NewReader(unhex(t, ts.rand))
+	v, err := s.EUTRANVector([]byte{0x00, 0xf1, 0x10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// SQN advances to the next SEQ with IND zero.
+	if sqn := s.SQN(); sqn != 0xff9bb4d0b600 {
+		t.Fatalf("Unexpected SQN: %x", sqn)
+	}
+	checkHex(t, 0, "RAND", []byte(v.RAND), ts.rand)
+	checkHex(t, 0, "XRES", []byte(v.XRES), ts.f2)
+	if len(v.AUTN) != 16 || len(v.KASME) != 32 {
+		t.Fatalf("Unexpected vector: %+v", v)
+	}
+	if amf := hex.EncodeToString([]byte(v.AUTN[6:8])); amf != ts.amf {
+		t.Fatalf("Unexpected AMF in AUTN: %s", amf)
+	}
+
+	// Rand is exhausted.
+	if u, err := s.UTRANVector(); err == nil {
+		t.Fatalf("Expected RAND read error, have vector %+v", u)
+	}
+}
+
+func TestSubscriber_Resynchronize(t *testing.T) {
+	ts := testSets[1]
+	k, opc, rand := unhex(t, ts.k), unhex(t, ts.opc), unhex(t, ts.rand)
+	s, err := NewSubscriber(k, opc, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Compute the AUTS a UE whose SQN is sqnMS would send.
+	m, err := NewMilenage(k, opc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqnMS := uint64(0x0000000012e0)
+	akStar, err := m.F5Star(rand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, macS, err := m.F1(rand, putSQN(sqnMS), []byte{0, 0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	auts := putSQN(sqnMS)
+	xor(auts, akStar)
+	auts = append(auts, macS...)
+
+	bad := append([]byte(nil), auts...)
+	bad[13] ^= 1
+	if err = s.Resynchronize(rand, bad); err != ErrMACFailure {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrMACFailure, err)
+	}
+	if err = s.ResynchronizationInfo(append(rand, auts...)); err != nil {
+		t.Fatal(err)
+	}
+	if sqn := s.SQN(); sqn != sqnMS {
+		t.Fatalf("Unexpected SQN. Want %x, have %x", sqnMS, sqn)
+	}
+	s.Rand = bytes.NewReader(rand)
+	v, err := s.UTRANVector()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqn := s.SQN(); sqn != sqnMS+32 {
+		t.Fatalf("Unexpected SQN. Want %x, have %x", sqnMS+32, sqn)
+	}
+	// SQN xor AK of the AUTN decodes to the new SQN.
+	_, _, _, ak, err := m.F2345(rand)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqn := []byte(v.AUTN[:6])
+	xor(sqn, ak)
+	if getSQN(sqn) != sqnMS+32 {
+		t.Fatalf("Unexpected SQN in AUTN: %x", sqn)
+	}
+	checkHex(t, 1, "CK", []byte(v.ConfidentialityKey), ts.f3)
+	checkHex(t, 1, "IK", []byte(v.IntegrityKey), ts.f4)
+}
+
+func TestCKIKPrime(t *testing.T) {
+	// Test case 1 from RFC 5448 appendix C.
+	ck, ik := unhex(t, "5349fbe098649f948f5d2e973a81c00f"), unhex(t, "9744871ad32bf9bbd1dd5ce54e3e2e5a")
+	ckPrime, ikPrime, err := CKIKPrime(ck, ik, "WLAN", unhex(t, "bb52e91c747a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, 0, "CK'", ckPrime, "0093962d0dd84aa5684b045c9edffa04")
+	checkHex(t, 0, "IK'", ikPrime, "ccfc230ca74fcc96c0a5d61164f5a76c")
+	if _, _, err = CKIKPrime(ck, ik, "", unhex(t, "bb52e91c747a")); err != ErrInvalidLength {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrInvalidLength, err)
+	}
+}
+
+func TestSubscriber_EAPAKAPrimeVector(t *testing.T) {
+	ts := testSets[0]
+	s, err := NewSubscriber(unhex(t, ts.k), unhex(t, ts.opc), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Rand = bytes.NewReader(unhex(t, ts.rand))
+	v, err := s.EAPAKAPrimeVector("WLAN")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(v.SIPAuthenticate) != 32 || len(v.ConfidentialityKey) != 16 || len(v.IntegrityKey) != 16 {
+		t.Fatalf("Unexpected vector: %+v", v)
+	}
+	checkHex(t, 0, "RAND", []byte(v.SIPAuthenticate[:16]), ts.rand)
+	checkHex(t, 0, "XRES", []byte(v.SIPAuthorization), ts.f2)
+	ckPrime, ikPrime, err := CKIKPrime(unhex(t, ts.f3), unhex(t, ts.f4), "WLAN", []byte(v.SIPAuthenticate[16:22]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkHex(t, 0, "CK'", []byte(v.ConfidentialityKey), hex.EncodeToString(ckPrime))
+	checkHex(t, 0, "IK'", []byte(v.IntegrityKey), hex.EncodeToString(ikPrime))
+}
@@ -0,0 +1,28 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package aka generates authentication vectors for UMTS, EPS AKA and
+// EAP-AKA'.
+//
+// It implements the Milenage algorithm set of 3GPP TS 35.206, the KASME
+// derivation of 3GPP TS 33.401, the CK' and IK' derivation of 3GPP TS
+// 33.402 and sequence number management with resynchronisation as
+// described in 3GPP TS 33.102. EPS and UMTS vectors are returned as
+// s6a.EUTRANVector and s6a.UTRANVector, ready to be placed in the
+// Authentication-Info of an AIA:
+//
+//	opc, _ := aka.ComputeOPc(k, op)
+//	sub, _ := aka.NewSubscriber(k, opc, lastSQN)
+//	if info := air.RequestedEUTRANAuthInfo; info != nil && len(info.ResynchronizationInfo) > 0 {
+//		if err := sub.ResynchronizationInfo([]byte(info.ResynchronizationInfo)); err != nil {
+//			...
+//		}
+//	}
+//	v, err := sub.EUTRANVector([]byte(air.VisitedPLMNID))
+//
+// EAP-AKA' vectors are returned as swx.SIPAuthDataItem, as sent in an
+// SWx MAA:
+//
+//	item, err := sub.EAPAKAPrimeVector("WLAN")
+package aka
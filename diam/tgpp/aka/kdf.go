@@ -0,0 +1,54 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package aka
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+)
+
+// KASME derives the 256-bit KASME of EPS AKA from CK, IK, the 3-byte
+// serving network identity (the Visited-PLMN-Id) and SQN xor AK, using
+// the key derivation function of 3GPP TS 33.401 annex A.2.
+func KASME(ck, ik, plmnID, sqnXorAK []byte) ([]byte, error) {
+	if len(ck) != 16 || len(ik) != 16 || len(plmnID) != 3 || len(sqnXorAK) != 6 {
+		return nil, ErrInvalidLength
+	}
+	key := make([]byte, 0, 32)
+	key = append(key, ck...)
+	key = append(key, ik...)
+	s := make([]byte, 0, 14)
+	s = append(s, 0x10)
+	s = append(s, plmnID...)
+	s = append(s, 0x00, 0x03)
+	s = append(s, sqnXorAK...)
+	s = append(s, 0x00, 0x06)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(s)
+	return mac.Sum(nil), nil
+}
+
+// CKIKPrime derives CK' and IK' of EAP-AKA' from CK, IK, the access
+// network identity (for example "WLAN") and SQN xor AK, using the key
+// derivation function of 3GPP TS 33.402 annex A.2.
+// See also RFC 5448 section 3.3.
+func CKIKPrime(ck, ik []byte, networkName string, sqnXorAK []byte) (ckPrime, ikPrime []byte, err error) {
+	if len(ck) != 16 || len(ik) != 16 || len(networkName) == 0 || len(networkName) > 0xffff || len(sqnXorAK) != 6 {
+		return nil, nil, ErrInvalidLength
+	}
+	key := make([]byte, 0, 32)
+	key = append(key, ck...)
+	key = append(key, ik...)
+	s := make([]byte, 0, 11+len(networkName))
+	s = append(s, 0x20)
+	s = append(s, networkName...)
+	s = append(s, byte(len(networkName)>>8), byte(len(networkName)))
+	s = append(s, sqnXorAK...)
+	s = append(s, 0x00, 0x06)
+	mac := hmac.New(sha256.New, key)
+	mac.Write(s)
+	out := mac.Sum(nil)
+	return out[:16], out[16:], nil
+}
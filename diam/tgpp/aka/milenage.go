@@ -0,0 +1,139 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package aka
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+)
+
+// ErrInvalidLength is returned when a key, RAND, SQN or AMF has the
+// wrong length.
+var ErrInvalidLength = errors.New("aka: invalid length")
+
+// Rotation amounts in bytes and constants of the Milenage functions,
+// as specified in 3GPP TS 35.206 section 4.1.
+var (
+	rotations = [5]int{8, 0, 4, 8, 12}
+	constants = [5]byte{0, 1, 2, 4, 8} // Last byte of c1 to c5.
+)
+
+// ComputeOPc derives OPc from the subscriber key K and the operator
+// variant OP: OPc = OP xor E[OP]K.
+func ComputeOPc(k, op []byte) ([]byte, error) {
+	if len(op) != 16 {
+		return nil, ErrInvalidLength
+	}
+	block, err := newCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	opc := make([]byte, 16)
+	block.Encrypt(opc, op)
+	xor(opc, op)
+	return opc, nil
+}
+
+func newCipher(k []byte) (cipher.Block, error) {
+	if len(k) != 16 {
+		return nil, ErrInvalidLength
+	}
+	return aes.NewCipher(k)
+}
+
+// Milenage implements the 3GPP authentication and key generation
+// functions f1, f1*, f2, f3, f4, f5 and f5* of 3GPP TS 35.206 for a
+// subscriber key K and its OPc.
+type Milenage struct {
+	block cipher.Block
+	opc   []byte
+}
+
+// NewMilenage returns a Milenage for the 128-bit K and OPc.
+func NewMilenage(k, opc []byte) (*Milenage, error) {
+	if len(opc) != 16 {
+		return nil, ErrInvalidLength
+	}
+	block, err := newCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return &Milenage{block: block, opc: append([]byte(nil), opc...)}, nil
+}
+
+// temp returns E[RAND xor OPc]K.
+func (m *Milenage) temp(rand []byte) []byte {
+	t := make([]byte, 16)
+	copy(t, rand)
+	xor(t, m.opc)
+	m.block.Encrypt(t, t)
+	return t
+}
+
+// out computes OUTn for n in 1..5 from TEMP and the input x, which is
+// IN1 for n = 1 and TEMP otherwise.
+func (m *Milenage) out(n int, temp, x []byte) []byte {
+	in := make([]byte, 16)
+	copy(in, x)
+	xor(in, m.opc)
+	out := make([]byte, 16)
+	r := rotations[n-1]
+	for i := range out {
+		out[i] = in[(i+r)%16]
+	}
+	out[15] ^= constants[n-1]
+	if n == 1 {
+		xor(out, temp)
+	}
+	m.block.Encrypt(out, out)
+	xor(out, m.opc)
+	return out
+}
+
+// F1 computes the network authentication code MAC-A (f1) and the
+// resynchronisation authentication code MAC-S (f1*) for the 16-byte
+// RAND, 6-byte SQN and 2-byte AMF.
+func (m *Milenage) F1(rand, sqn, amf []byte) (macA, macS []byte, err error) {
+	if len(rand) != 16 || len(sqn) != 6 || len(amf) != 2 {
+		return nil, nil, ErrInvalidLength
+	}
+	in1 := make([]byte, 16)
+	copy(in1[0:], sqn)
+	copy(in1[6:], amf)
+	copy(in1[8:], sqn)
+	copy(in1[14:], amf)
+	out := m.out(1, m.temp(rand), in1)
+	return out[:8], out[8:], nil
+}
+
+// F2345 computes the response RES (f2), the confidentiality key CK (f3),
+// the integrity key IK (f4) and the anonymity key AK (f5) for the
+// 16-byte RAND.
+func (m *Milenage) F2345(rand []byte) (res, ck, ik, ak []byte, err error) {
+	if len(rand) != 16 {
+		return nil, nil, nil, nil, ErrInvalidLength
+	}
+	temp := m.temp(rand)
+	out2 := m.out(2, temp, temp)
+	return out2[8:], m.out(3, temp, temp), m.out(4, temp, temp), out2[:6], nil
+}
+
+// F5Star computes the anonymity key AK used for resynchronisation
+// (f5*) for the 16-byte RAND.
+func (m *Milenage) F5Star(rand []byte) ([]byte, error) {
+	if len(rand) != 16 {
+		return nil, ErrInvalidLength
+	}
+	temp := m.temp(rand)
+	return m.out(5, temp, temp)[:6], nil
+}
+
+// xor sets dst[i] ^= src[i] for each byte of src.
+func xor(dst, src []byte) {
+	for i := range src {
+		dst[i] ^= src[i]
+	}
+}
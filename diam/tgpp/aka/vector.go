@@ -0,0 +1,217 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package aka
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/swx"
+)
+
+// ErrMACFailure is returned by Resynchronize when MAC-S of the AUTS
+// sent by the UE does not verify.
+var ErrMACFailure = errors.New("aka: resynchronisation MAC failure")
+
+// SQN layout, as in 3GPP TS 33.102 annex C.3.2: the 48-bit SQN is made
+// of a SEQ and a 5-bit IND. New vectors are generated with IND zero and
+// the next SEQ.
+const (
+	indBits = 5
+	sqnMask = 1<<48 - 1
+)
+
+// DefaultAMF is the Authentication Management Field of generated vectors
+// unless configured otherwise. It has the separation bit used by EPS
+// set. See 3GPP TS 33.401 annex H.
+var DefaultAMF = [2]byte{0x80, 0x00}
+
+// Subscriber generates authentication vectors for a single subscriber
+// and keeps track of its sequence number. It is safe for concurrent use.
+type Subscriber struct {
+	// AMF is the Authentication Management Field of new vectors.
+	AMF [2]byte
+
+	// Rand is the source of RAND values. Defaults to crypto/rand.
+	Rand io.Reader
+
+	milenage *Milenage
+	mu       sync.Mutex
+	sqn      uint64
+}
+
+// NewSubscriber returns a Subscriber for the key K and OPc whose last
+// used sequence number is sqn.
+func NewSubscriber(k, opc []byte, sqn uint64) (*Subscriber, error) {
+	m, err := NewMilenage(k, opc)
+	if err != nil {
+		return nil, err
+	}
+	return &Subscriber{AMF: DefaultAMF, milenage: m, sqn: sqn & sqnMask}, nil
+}
+
+// SQN returns the last sequence number used by the Subscriber. It is
+// meant to be persisted and given to NewSubscriber.
+func (s *Subscriber) SQN() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sqn
+}
+
+// nextSQN advances the sequence number to the next SEQ.
+func (s *Subscriber) nextSQN() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sqn = ((s.sqn>>indBits + 1) << indBits) & sqnMask
+	return s.sqn
+}
+
+// quintet holds the output of Milenage for one RAND and SQN.
+type quintet struct {
+	rand, res, ck, ik, autn, sqnXorAK []byte
+}
+
+func (s *Subscriber) generate() (*quintet, error) {
+	r := s.Rand
+	if r == nil {
+		r = rand.Reader
+	}
+	q := &quintet{rand: make([]byte, 16)}
+	if _, err := io.ReadFull(r, q.rand); err != nil {
+		return nil, err
+	}
+	sqn := putSQN(s.nextSQN())
+	macA, _, err := s.milenage.F1(q.rand, sqn, s.AMF[:])
+	if err != nil {
+		return nil, err
+	}
+	var ak []byte
+	q.res, q.ck, q.ik, ak, err = s.milenage.F2345(q.rand)
+	if err != nil {
+		return nil, err
+	}
+	q.sqnXorAK = sqn
+	xor(q.sqnXorAK, ak)
+	q.autn = make([]byte, 0, 16)
+	q.autn = append(q.autn, q.sqnXorAK...)
+	q.autn = append(q.autn, s.AMF[:]...)
+	q.autn = append(q.autn, macA...)
+	return q, nil
+}
+
+// EUTRANVector generates a new EPS authentication vector for the serving
+// network identified by the 3-byte plmnID, as sent in Visited-PLMN-Id.
+func (s *Subscriber) EUTRANVector(plmnID []byte) (*s6a.EUTRANVector, error) {
+	q, err := s.generate()
+	if err != nil {
+		return nil, err
+	}
+	kasme, err := KASME(q.ck, q.ik, plmnID, q.sqnXorAK)
+	if err != nil {
+		return nil, err
+	}
+	return &s6a.EUTRANVector{
+		RAND:  datatype.OctetString(q.rand),
+		XRES:  datatype.OctetString(q.res),
+		AUTN:  datatype.OctetString(q.autn),
+		KASME: datatype.OctetString(kasme),
+	}, nil
+}
+
+// UTRANVector generates a new UMTS authentication vector.
+func (s *Subscriber) UTRANVector() (*s6a.UTRANVector, error) {
+	q, err := s.generate()
+	if err != nil {
+		return nil, err
+	}
+	return &s6a.UTRANVector{
+		RAND:               datatype.OctetString(q.rand),
+		XRES:               datatype.OctetString(q.res),
+		AUTN:               datatype.OctetString(q.autn),
+		ConfidentialityKey: datatype.OctetString(q.ck),
+		IntegrityKey:       datatype.OctetString(q.ik),
+	}, nil
+}
+
+// EAPAKAPrimeVector generates a new EAP-AKA' authentication vector for
+// the access network identity networkName, as sent by the HSS in the
+// SIP-Auth-Data-Item of an SWx MAA. See 3GPP TS 29.273 section 8.2.3.9.
+func (s *Subscriber) EAPAKAPrimeVector(networkName string) (*swx.SIPAuthDataItem, error) {
+	q, err := s.generate()
+	if err != nil {
+		return nil, err
+	}
+	ckPrime, ikPrime, err := CKIKPrime(q.ck, q.ik, networkName, q.sqnXorAK)
+	if err != nil {
+		return nil, err
+	}
+	return &swx.SIPAuthDataItem{
+		SIPAuthenticationScheme: swx.SchemeEAPAKAPrime,
+		SIPAuthenticate:         datatype.OctetString(append(q.rand, q.autn...)),
+		SIPAuthorization:        datatype.OctetString(q.res),
+		ConfidentialityKey:      datatype.OctetString(ckPrime),
+		IntegrityKey:            datatype.OctetString(ikPrime),
+	}, nil
+}
+
+// Resynchronize verifies the AUTS that the UE computed for rand after a
+// synchronisation failure and, if it is valid, continues the sequence
+// numbers of the Subscriber from the SQN of the UE.
+func (s *Subscriber) Resynchronize(rand, auts []byte) error {
+	if len(auts) != 14 {
+		return ErrInvalidLength
+	}
+	akStar, err := s.milenage.F5Star(rand)
+	if err != nil {
+		return err
+	}
+	sqnMS := append([]byte(nil), auts[:6]...)
+	xor(sqnMS, akStar)
+	// MAC-S is computed with a dummy AMF of zero. See 3GPP TS 33.102
+	// section 6.3.3.
+	_, macS, err := s.milenage.F1(rand, sqnMS, []byte{0, 0})
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(macS, auts[6:]) != 1 {
+		return ErrMACFailure
+	}
+	s.mu.Lock()
+	s.sqn = getSQN(sqnMS)
+	s.mu.Unlock()
+	return nil
+}
+
+// ResynchronizationInfo is like Resynchronize for the contents of the
+// Re-Synchronization-Info AVP, which is RAND followed by AUTS.
+// See 3GPP TS 29.272 section 7.3.15.
+func (s *Subscriber) ResynchronizationInfo(info []byte) error {
+	if len(info) != 30 {
+		return ErrInvalidLength
+	}
+	return s.Resynchronize(info[:16], info[16:])
+}
+
+func putSQN(sqn uint64) []byte {
+	b := make([]byte, 6)
+	for i := 5; i >= 0; i-- {
+		b[i] = byte(sqn)
+		sqn >>= 8
+	}
+	return b
+}
+
+func getSQN(b []byte) uint64 {
+	var sqn uint64
+	for _, v := range b {
+		sqn = sqn<<8 | uint64(v)
+	}
+	return sqn
+}
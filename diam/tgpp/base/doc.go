@@ -0,0 +1,16 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package base provides the types shared by the 3GPP applications, such
+// as the Experimental-Result and Vendor-Specific-Application-Id grouped
+// AVPs, for use in their messages with Message.Marshal and
+// Message.Unmarshal.
+//
+// The types implement diam.AVPMarshaler and diam.AVPUnmarshaler with
+// methods generated by diam-gen. The AVPs are resolved in the S6a
+// application, whose dictionary defines all of them. Run go generate
+// after changing them.
+package base
+
+//go:generate go run github.com/fiorix/go-diameter/v4/cmd/diam-gen -source message.go -apps 16777251 -o message_codec.go
@@ -0,0 +1,41 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package base
+
+// Vendor3GPP is the Vendor-Id of 3GPP.
+const Vendor3GPP = 10415
+
+// NoStateMaintained is the Auth-Session-State of the 3GPP applications
+// that keep no session state, such as S6a, Cx, Sh and SWx.
+const NoStateMaintained = 1
+
+// ExperimentalResult is the Experimental-Result grouped AVP.
+type ExperimentalResult struct {
+	VendorID uint32 `avp:"Vendor-Id"`
+	Code     uint32 `avp:"Experimental-Result-Code"`
+}
+
+// VendorSpecificApplicationID is the Vendor-Specific-Application-Id
+// grouped AVP.
+type VendorSpecificApplicationID struct {
+	VendorID          uint32 `avp:"Vendor-Id"`
+	AuthApplicationID uint32 `avp:"Auth-Application-Id"`
+}
+
+// NewVendorSpecificApplicationID returns the
+// Vendor-Specific-Application-Id of the 3GPP application appID.
+func NewVendorSpecificApplicationID(appID uint32) *VendorSpecificApplicationID {
+	return &VendorSpecificApplicationID{
+		VendorID:          Vendor3GPP,
+		AuthApplicationID: appID,
+	}
+}
+
+// SupportedFeatures is the Supported-Features grouped AVP.
+type SupportedFeatures struct {
+	VendorID      uint32 `avp:"Vendor-Id"`
+	FeatureListID uint32 `avp:"Feature-List-ID"`
+	FeatureList   uint32 `avp:"Feature-List"`
+}
@@ -0,0 +1,99 @@
+// Code generated by diam-gen. DO NOT EDIT.
+
+package base
+
+import (
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ExperimentalResult) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(266, avp.Mbit, 0, datatype.Unsigned32(s.VendorID)))
+	avps = append(avps, diam.NewAVP(298, avp.Mbit, 0, datatype.Unsigned32(s.Code)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ExperimentalResult) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 266: // Vendor-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.VendorID = uint32(v)
+			}
+		case 298: // Experimental-Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[1] {
+				seen[1] = true
+				s.Code = uint32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *VendorSpecificApplicationID) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(266, avp.Mbit, 0, datatype.Unsigned32(s.VendorID)))
+	avps = append(avps, diam.NewAVP(258, avp.Mbit, 0, datatype.Unsigned32(s.AuthApplicationID)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *VendorSpecificApplicationID) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 266: // Vendor-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.VendorID = uint32(v)
+			}
+		case 258: // Auth-Application-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[1] {
+				seen[1] = true
+				s.AuthApplicationID = uint32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *SupportedFeatures) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	avps = append(avps, diam.NewAVP(266, avp.Mbit, 0, datatype.Unsigned32(s.VendorID)))
+	avps = append(avps, diam.NewAVP(629, avp.Vbit, 10415, datatype.Unsigned32(s.FeatureListID)))
+	avps = append(avps, diam.NewAVP(630, avp.Vbit, 10415, datatype.Unsigned32(s.FeatureList)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *SupportedFeatures) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 266: // Vendor-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.VendorID = uint32(v)
+			}
+		case 10415<<32 | 629: // Feature-List-ID
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[1] {
+				seen[1] = true
+				s.FeatureListID = uint32(v)
+			}
+		case 10415<<32 | 630: // Feature-List
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.FeatureList = uint32(v)
+			}
+		}
+	}
+	return nil
+}
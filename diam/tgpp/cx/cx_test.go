@@ -0,0 +1,224 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package cx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+const (
+	testIMPI     = "alice@ims.test"
+	testIMPU     = "sip:alice@ims.test"
+	testTelURI   = "tel:+15551234567"
+	testSCSCF    = "sip:scscf.ims.test:6060"
+	testUserData = "<IMSSubscription><PrivateID>alice@ims.test</PrivateID></IMSSubscription>"
+)
+
+type testBackend struct{}
+
+func (testBackend) UserAuthorization(uar *UAR, uaa *UAA) {
+	if uar.UserName != testIMPI {
+		uaa.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: UserUnknown}
+		return
+	}
+	code := uint32(SubsequentRegistration)
+	if len(uaa.ServerName) == 0 {
+		code = FirstRegistration
+		uaa.ServerCapabilities = &ServerCapabilities{ServerName: []string{testSCSCF}}
+	}
+	uaa.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: code}
+}
+
+func (testBackend) ServerAssignment(sar *SAR, saa *SAA) {
+	if sar.ServerAssignmentType == Registration {
+		saa.UserData = testUserData
+		saa.ChargingInformation = &ChargingInformation{PrimaryChargingCollectionFunctionName: "aaa://ccf.ims.test"}
+	}
+}
+
+func (testBackend) LocationInfo(lir *LIR, lia *LIA) {
+	if len(lia.ServerName) == 0 {
+		lia.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: IdentityNotRegistered}
+	}
+}
+
+func (testBackend) MultimediaAuth(mar *MAR, maa *MAA) {
+	if mar.SIPAuthDataItem.SIPAuthenticationScheme != SchemeAKAv1MD5 {
+		maa.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: AuthSchemeNotSupported}
+		return
+	}
+	n := uint32(1)
+	maa.SIPNumberAuthItems = 1
+	maa.SIPAuthDataItem = []SIPAuthDataItem{{
+		SIPItemNumber:           &n,
+		SIPAuthenticationScheme: SchemeAKAv1MD5,
+		SIPAuthenticate:         datatype.OctetString(strings.Repeat("r", 32)),
+		SIPAuthorization:        datatype.OctetString("xres0123"),
+		ConfidentialityKey:      datatype.OctetString(strings.Repeat("c", 16)),
+		IntegrityKey:            datatype.OctetString(strings.Repeat("i", 16)),
+	}}
+}
+
+func TestClientServer(t *testing.T) {
+	hss := &Server{OriginHost: "hss", OriginRealm: "test", Backend: testBackend{}, Timeout: time.Second}
+	rtrc := make(chan *RTR, 1)
+	pprc := make(chan *PPR, 1)
+	scscf := &Client{
+		OriginHost:       "scscf",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		ServerName:       testSCSCF,
+		Timeout:          time.Second,
+		OnRegistrationTermination: func(rtr *RTR, rta *RTA) {
+			rtrc <- rtr
+		},
+		OnPushProfile: func(ppr *PPR, ppa *PPA) {
+			pprc <- ppr
+		},
+	}
+	c, done := smtest.Connect(t,
+		smtest.Peer{Host: "hss", Handler: hss, Commands: []diam.CommandIndex{UARIndex, SARIndex, LIRIndex, MARIndex, RTAIndex, PPAIndex}},
+		smtest.Peer{Host: "scscf", Handler: scscf, Commands: []diam.CommandIndex{UAAIndex, SAAIndex, LIAIndex, MAAIndex, RTRIndex, PPRIndex}},
+		base.Vendor3GPP, diam.TGPP_CX_APP_ID)
+	defer done()
+
+	uar := func(t *testing.T) *UAA {
+		t.Helper()
+		uaa, err := scscf.UserAuthorization(c, &UAR{
+			UserName:                 testIMPI,
+			PublicIdentity:           testIMPU,
+			VisitedNetworkIdentifier: "ims.test",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uaa
+	}
+
+	t.Run("UserAuthorization", func(t *testing.T) {
+		uaa := uar(t)
+		if uaa.ExperimentalResult == nil || uaa.ExperimentalResult.Code != FirstRegistration || uaa.ServerCapabilities == nil {
+			t.Fatalf("Unexpected UAA: %+v", uaa)
+		}
+		if !strings.HasPrefix(uaa.SessionID, "scscf;") || uaa.OriginHost != "hss" || uaa.ResultCode != 0 {
+			t.Fatalf("Unexpected UAA: %+v", uaa)
+		}
+	})
+
+	t.Run("MultimediaAuth", func(t *testing.T) {
+		maa, err := scscf.MultimediaAuth(c, &MAR{UserName: testIMPI, PublicIdentity: testIMPU})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if maa.ResultCode != diam.Success || len(maa.SIPAuthDataItem) != 1 || maa.PublicIdentity != testIMPU {
+			t.Fatalf("Unexpected MAA: %+v", maa)
+		}
+		if v := maa.SIPAuthDataItem[0]; v.SIPAuthenticationScheme != SchemeAKAv1MD5 || len(v.SIPAuthenticate) != 32 {
+			t.Fatalf("Unexpected vector: %+v", v)
+		}
+		maa, err = scscf.MultimediaAuth(c, &MAR{
+			UserName:        testIMPI,
+			PublicIdentity:  testIMPU,
+			SIPAuthDataItem: &SIPAuthDataItem{SIPAuthenticationScheme: SchemeSIPDigest},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if maa.ExperimentalResult == nil || maa.ExperimentalResult.Code != AuthSchemeNotSupported {
+			t.Fatalf("Unexpected MAA: %+v", maa)
+		}
+	})
+
+	// The subtests below share the registration made by ServerAssignment.
+	t.Run("ServerAssignment", func(t *testing.T) {
+		saa, err := scscf.ServerAssignment(c, &SAR{
+			UserName:       testIMPI,
+			PublicIdentity: []string{testIMPU, testTelURI},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saa.ResultCode != diam.Success || string(saa.UserData) != testUserData || saa.ChargingInformation == nil {
+			t.Fatalf("Unexpected SAA: %+v", saa)
+		}
+		if users := hss.Registrations(); !reflect.DeepEqual(users, []string{testIMPI}) {
+			t.Fatalf("Unexpected registrations: %v", users)
+		}
+		if uaa := uar(t); uaa.ExperimentalResult.Code != SubsequentRegistration || uaa.ServerName != testSCSCF {
+			t.Fatalf("Unexpected UAA: %+v", uaa)
+		}
+	})
+
+	t.Run("LocationInfo", func(t *testing.T) {
+		lia, err := scscf.LocationInfo(c, &LIR{PublicIdentity: testTelURI})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lia.ResultCode != diam.Success || lia.ServerName != testSCSCF {
+			t.Fatalf("Unexpected LIA: %+v", lia)
+		}
+	})
+
+	t.Run("PushProfile", func(t *testing.T) {
+		ppr := &PPR{UserData: testUserData}
+		ppa, err := hss.PushProfile(testIMPI, ppr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ppr.SessionID != "" || ppr.UserName != "" {
+			t.Fatalf("PushProfile modified the PPR: %+v", ppr)
+		}
+		if ppa.ResultCode != diam.Success || ppa.OriginHost != "scscf" {
+			t.Fatalf("Unexpected PPA: %+v", ppa)
+		}
+		select {
+		case ppr := <-pprc:
+			if ppr.UserName != testIMPI || ppr.DestinationHost != "scscf" || string(ppr.UserData) != testUserData {
+				t.Fatalf("Unexpected PPR: %+v", ppr)
+			}
+		default:
+			t.Fatal("No PPR received")
+		}
+	})
+
+	t.Run("RegistrationTermination", func(t *testing.T) {
+		rta, err := hss.RegistrationTermination(testIMPI, PermanentTermination, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rta.ResultCode != diam.Success {
+			t.Fatalf("Unexpected RTA: %+v", rta)
+		}
+		select {
+		case rtr := <-rtrc:
+			if rtr.UserName != testIMPI || !reflect.DeepEqual(rtr.PublicIdentity, []string{testIMPU, testTelURI}) {
+				t.Fatalf("Unexpected RTR: %+v", rtr)
+			}
+		default:
+			t.Fatal("No RTR received")
+		}
+		if users := hss.Registrations(); len(users) != 0 {
+			t.Fatalf("Unexpected registrations: %v", users)
+		}
+		lia, err := scscf.LocationInfo(c, &LIR{PublicIdentity: testIMPU})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lia.ExperimentalResult == nil || lia.ExperimentalResult.Code != IdentityNotRegistered {
+			t.Fatalf("Unexpected LIA: %+v", lia)
+		}
+		if _, err = hss.PushProfile(testIMPI, &PPR{}); err != ErrUnknownUser {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+}
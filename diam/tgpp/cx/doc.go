@@ -0,0 +1,39 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package cx implements the Cx and Dx applications between the IMS
+// CSCFs and the HSS or SLF, as specified in 3GPP TS 29.228 and 29.229.
+//
+// It provides typed UAR/UAA, SAR/SAA, LIR/LIA, MAR/MAA, RTR/RTA and
+// PPR/PPA messages for use with Message.Marshal and Message.Unmarshal,
+// a CSCF Client and an HSS Server that fill in the mandatory AVPs of
+// the messages they send. User-Data is carried as the raw IMS
+// subscription XML document.
+//
+// An S-CSCF authenticating and registering a subscriber:
+//
+//	scscf := &cx.Client{
+//		OriginHost:       "scscf.ims.example.com",
+//		OriginRealm:      "ims.example.com",
+//		DestinationRealm: "ims.example.com",
+//		ServerName:       "sip:scscf.ims.example.com:6060",
+//	}
+//	mux := sm.New(settings)
+//	for _, idx := range []diam.CommandIndex{
+//		cx.UAAIndex, cx.SAAIndex, cx.LIAIndex, cx.MAAIndex,
+//		cx.RTRIndex, cx.PPRIndex,
+//	} {
+//		mux.HandleIdx(idx, scscf)
+//	}
+//	...
+//	maa, err := scscf.MultimediaAuth(conn, &cx.MAR{
+//		UserName:       "alice@ims.example.com",
+//		PublicIdentity: "sip:alice@ims.example.com",
+//	})
+//	...
+//	saa, err := scscf.ServerAssignment(conn, &cx.SAR{
+//		UserName:       "alice@ims.example.com",
+//		PublicIdentity: []string{"sip:alice@ims.example.com"},
+//	})
+package cx
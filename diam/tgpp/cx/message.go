@@ -0,0 +1,353 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package cx
+
+import (
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// Command indexes of the Cx/Dx application, for use with
+// ServeMux.HandleIdx.
+var (
+	UARIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.UserAuthorization, Request: true}
+	UAAIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.UserAuthorization, Request: false}
+	SARIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.ServerAssignment, Request: true}
+	SAAIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.ServerAssignment, Request: false}
+	LIRIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.LocationInfo, Request: true}
+	LIAIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.LocationInfo, Request: false}
+	MARIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.MultimediaAuth, Request: true}
+	MAAIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.MultimediaAuth, Request: false}
+	RTRIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.RegistrationTermination, Request: true}
+	RTAIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.RegistrationTermination, Request: false}
+	PPRIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.PushProfile, Request: true}
+	PPAIndex = diam.CommandIndex{AppID: diam.TGPP_CX_APP_ID, Code: diam.PushProfile, Request: false}
+)
+
+// Experimental-Result-Code values. See 3GPP TS 29.229 section 6.2.
+const (
+	FirstRegistration          = 2001
+	SubsequentRegistration     = 2002
+	UnregisteredService        = 2003
+	SuccessServerNameNotStored = 2004
+	UserUnknown                = 5001
+	IdentitiesDontMatch        = 5002
+	IdentityNotRegistered      = 5003
+	RoamingNotAllowed          = 5004
+	IdentityAlreadyRegistered  = 5005
+	AuthSchemeNotSupported     = 5006
+	ErrorInAssignmentType      = 5007
+	TooMuchData                = 5008
+	NotSupportedUserData       = 5009
+	FeatureUnsupported         = 5011
+)
+
+// SIP-Authentication-Scheme values. See 3GPP TS 29.228 section 6.3.
+const (
+	SchemeAKAv1MD5    = "Digest-AKAv1-MD5"
+	SchemeAKAv2MD5    = "Digest-AKAv2-MD5"
+	SchemeSIPDigest   = "SIP Digest"
+	SchemeNASSBundled = "NASS-Bundled"
+	SchemeEarlyIMS    = "Early-IMS-Security"
+	SchemeUnknown     = "Unknown"
+)
+
+// User-Authorization-Type values. See 3GPP TS 29.229 section 6.3.24.
+const (
+	AuthorizeRegistration                = 0
+	AuthorizeDeregistration              = 1
+	AuthorizeRegistrationAndCapabilities = 2
+)
+
+// Server-Assignment-Type values. See 3GPP TS 29.229 section 6.3.15.
+const (
+	NoAssignment                         = 0
+	Registration                         = 1
+	ReRegistration                       = 2
+	UnregisteredUser                     = 3
+	TimeoutDeregistration                = 4
+	UserDeregistration                   = 5
+	TimeoutDeregistrationStoreServerName = 6
+	UserDeregistrationStoreServerName    = 7
+	AdministrativeDeregistration         = 8
+	AuthenticationFailure                = 9
+	AuthenticationTimeout                = 10
+	DeregistrationTooMuchData            = 11
+)
+
+// User-Data-Already-Available values. See 3GPP TS 29.229 section 6.3.26.
+const (
+	UserDataNotAvailable     = 0
+	UserDataAlreadyAvailable = 1
+)
+
+// Reason-Code values. See 3GPP TS 29.229 section 6.3.17.
+const (
+	PermanentTermination = 0
+	NewServerAssigned    = 1
+	ServerChange         = 2
+	RemoveSCSCF          = 3
+)
+
+// ServerCapabilities is the Server-Capabilities grouped AVP, which the
+// I-CSCF uses to select an S-CSCF.
+type ServerCapabilities struct {
+	MandatoryCapability []uint32 `avp:"Mandatory-Capability"`
+	OptionalCapability  []uint32 `avp:"Optional-Capability"`
+	ServerName          []string `avp:"Server-Name"`
+}
+
+// SIPDigestAuthenticate is the SIP-Digest-Authenticate grouped AVP.
+type SIPDigestAuthenticate struct {
+	DigestRealm     string `avp:"Digest-Realm"`
+	DigestAlgorithm string `avp:"Digest-Algorithm,omitempty"`
+	DigestQoP       string `avp:"Digest-QoP"`
+	DigestHA1       string `avp:"Digest-HA1"`
+}
+
+// SIPAuthDataItem is the SIP-Auth-Data-Item grouped AVP. In an MAA it
+// carries an authentication vector: for Digest-AKAv1-MD5 SIP-Authenticate
+// is RAND||AUTN and SIP-Authorization is XRES, and for SIP Digest the
+// SIP-Digest-Authenticate is set. In an MAR it carries the
+// resynchronization info as RAND||AUTS in SIP-Authorization.
+type SIPAuthDataItem struct {
+	SIPItemNumber            *uint32                `avp:"SIP-Item-Number"`
+	SIPAuthenticationScheme  string                 `avp:"SIP-Authentication-Scheme,omitempty"`
+	SIPAuthenticate          datatype.OctetString   `avp:"SIP-Authenticate,omitempty"`
+	SIPAuthorization         datatype.OctetString   `avp:"SIP-Authorization,omitempty"`
+	SIPAuthenticationContext datatype.OctetString   `avp:"SIP-Authentication-Context,omitempty"`
+	ConfidentialityKey       datatype.OctetString   `avp:"Confidentiality-Key,omitempty"`
+	IntegrityKey             datatype.OctetString   `avp:"Integrity-Key,omitempty"`
+	SIPDigestAuthenticate    *SIPDigestAuthenticate `avp:"SIP-Digest-Authenticate"`
+}
+
+// DeregistrationReason is the Deregistration-Reason grouped AVP.
+type DeregistrationReason struct {
+	ReasonCode int32  `avp:"Reason-Code"`
+	ReasonInfo string `avp:"Reason-Info,omitempty"`
+}
+
+// ChargingInformation is the Charging-Information grouped AVP.
+type ChargingInformation struct {
+	PrimaryEventChargingFunctionName        datatype.DiameterURI `avp:"Primary-Event-Charging-Function-Name,omitempty"`
+	SecondaryEventChargingFunctionName      datatype.DiameterURI `avp:"Secondary-Event-Charging-Function-Name,omitempty"`
+	PrimaryChargingCollectionFunctionName   datatype.DiameterURI `avp:"Primary-Charging-Collection-Function-Name,omitempty"`
+	SecondaryChargingCollectionFunctionName datatype.DiameterURI `avp:"Secondary-Charging-Collection-Function-Name,omitempty"`
+}
+
+// AssociatedIdentities is the Associated-Identities grouped AVP, and
+// also used for Associated-Registered-Identities.
+type AssociatedIdentities struct {
+	UserName []string `avp:"User-Name"`
+}
+
+// UAR is a User-Authorization-Request message, sent by the I-CSCF on
+// SIP REGISTER. See 3GPP TS 29.229 section 6.1.1.
+type UAR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	PublicIdentity              string                            `avp:"Public-Identity"`
+	VisitedNetworkIdentifier    datatype.OctetString              `avp:"Visited-Network-Identifier"`
+	UserAuthorizationType       *int32                            `avp:"User-Authorization-Type"`
+	UARFlags                    uint32                            `avp:"UAR-Flags,omitempty"`
+}
+
+// UAA is a User-Authorization-Answer message.
+// See 3GPP TS 29.229 section 6.1.2.
+type UAA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	ServerName                  string                            `avp:"Server-Name,omitempty"`
+	ServerCapabilities          *ServerCapabilities               `avp:"Server-Capabilities"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// SAR is a Server-Assignment-Request message, sent by the S-CSCF to
+// register itself for a user and download its profile.
+// See 3GPP TS 29.229 section 6.1.3.
+type SAR struct {
+	SessionID                      string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID    *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState               int32                             `avp:"Auth-Session-State"`
+	OriginHost                     datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                    datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost                datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm               datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	UserName                       string                            `avp:"User-Name,omitempty"`
+	SupportedFeatures              []base.SupportedFeatures          `avp:"Supported-Features"`
+	PublicIdentity                 []string                          `avp:"Public-Identity"`
+	WildcardedPublicIdentity       string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	ServerName                     string                            `avp:"Server-Name"`
+	ServerAssignmentType           int32                             `avp:"Server-Assignment-Type"`
+	UserDataAlreadyAvailable       int32                             `avp:"User-Data-Already-Available"`
+	MultipleRegistrationIndication *int32                            `avp:"Multiple-Registration-Indication"`
+	SessionPriority                *int32                            `avp:"Session-Priority"`
+	SARFlags                       uint32                            `avp:"SAR-Flags,omitempty"`
+}
+
+// SAA is a Server-Assignment-Answer message. User-Data carries the
+// IMS subscription as an XML document.
+// See 3GPP TS 29.229 section 6.1.4.
+type SAA struct {
+	SessionID                      string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID    *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                     uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult             *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState               int32                             `avp:"Auth-Session-State"`
+	OriginHost                     datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                    datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	UserName                       string                            `avp:"User-Name,omitempty"`
+	SupportedFeatures              []base.SupportedFeatures          `avp:"Supported-Features"`
+	UserData                       datatype.OctetString              `avp:"User-Data,omitempty"`
+	ChargingInformation            *ChargingInformation              `avp:"Charging-Information"`
+	AssociatedIdentities           *AssociatedIdentities             `avp:"Associated-Identities"`
+	LooseRouteIndication           *int32                            `avp:"Loose-Route-Indication"`
+	AssociatedRegisteredIdentities *AssociatedIdentities             `avp:"Associated-Registered-Identities"`
+	ServerName                     string                            `avp:"Server-Name,omitempty"`
+	WildcardedPublicIdentity       string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+}
+
+// LIR is a Location-Info-Request message, sent by the I-CSCF to find
+// the S-CSCF serving a public identity. See 3GPP TS 29.229 section 6.1.5.
+type LIR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	OriginatingRequest          *int32                            `avp:"Originating-Request"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	PublicIdentity              string                            `avp:"Public-Identity"`
+	UserAuthorizationType       *int32                            `avp:"User-Authorization-Type"`
+	SessionPriority             *int32                            `avp:"Session-Priority"`
+}
+
+// LIA is a Location-Info-Answer message.
+// See 3GPP TS 29.229 section 6.1.6.
+type LIA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	ServerName                  string                            `avp:"Server-Name,omitempty"`
+	ServerCapabilities          *ServerCapabilities               `avp:"Server-Capabilities"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	LIAFlags                    uint32                            `avp:"LIA-Flags,omitempty"`
+}
+
+// MAR is a Multimedia-Auth-Request message, sent by the S-CSCF to
+// request authentication vectors. See 3GPP TS 29.229 section 6.1.7.
+type MAR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	UserName                    string                            `avp:"User-Name"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	PublicIdentity              string                            `avp:"Public-Identity"`
+	SIPAuthDataItem             *SIPAuthDataItem                  `avp:"SIP-Auth-Data-Item"`
+	SIPNumberAuthItems          uint32                            `avp:"SIP-Number-Auth-Items"`
+	ServerName                  string                            `avp:"Server-Name"`
+}
+
+// MAA is a Multimedia-Auth-Answer message.
+// See 3GPP TS 29.229 section 6.1.8.
+type MAA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	UserName                    string                            `avp:"User-Name,omitempty"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	PublicIdentity              string                            `avp:"Public-Identity,omitempty"`
+	SIPNumberAuthItems          uint32                            `avp:"SIP-Number-Auth-Items,omitempty"`
+	SIPAuthDataItem             []SIPAuthDataItem                 `avp:"SIP-Auth-Data-Item"`
+}
+
+// RTR is a Registration-Termination-Request message, sent by the HSS to
+// deregister a user from its S-CSCF. See 3GPP TS 29.229 section 6.1.9.
+type RTR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	AssociatedIdentities        *AssociatedIdentities             `avp:"Associated-Identities"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	PublicIdentity              []string                          `avp:"Public-Identity"`
+	DeregistrationReason        DeregistrationReason              `avp:"Deregistration-Reason"`
+}
+
+// RTA is a Registration-Termination-Answer message.
+// See 3GPP TS 29.229 section 6.1.10.
+type RTA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	AssociatedIdentities        *AssociatedIdentities             `avp:"Associated-Identities"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// PPR is a Push-Profile-Request message, sent by the HSS to update the
+// profile of a user at its S-CSCF. See 3GPP TS 29.229 section 6.1.11.
+type PPR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	UserData                    datatype.OctetString              `avp:"User-Data,omitempty"`
+	ChargingInformation         *ChargingInformation              `avp:"Charging-Information"`
+	SIPAuthDataItem             *SIPAuthDataItem                  `avp:"SIP-Auth-Data-Item"`
+}
+
+// PPA is a Push-Profile-Answer message.
+// See 3GPP TS 29.229 section 6.1.12.
+type PPA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
@@ -0,0 +1,319 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package cx
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// ErrUnknownUser is returned by the Server when a user is not
+// registered at any S-CSCF.
+var ErrUnknownUser = errors.New("cx: unknown user")
+
+// A Backend answers the requests that CSCFs send to the HSS.
+//
+// Each method is called with the decoded request and its answer, which
+// is filled in with DIAMETER_SUCCESS on entry. Methods may change the
+// Result-Code or set an Experimental-Result, in which case the
+// Result-Code is cleared.
+type Backend interface {
+	UserAuthorization(uar *UAR, uaa *UAA)
+	ServerAssignment(sar *SAR, saa *SAA)
+	LocationInfo(lir *LIR, lia *LIA)
+	MultimediaAuth(mar *MAR, maa *MAA)
+}
+
+// Server is the HSS side of Cx. It answers UAR, SAR, LIR and MAR using
+// its Backend, keeps track of the S-CSCF each user is registered at,
+// and can send RTR and PPR to those S-CSCFs.
+//
+// A user is registered by a successful SAR of type REGISTRATION or
+// RE_REGISTRATION, and deregistered by a successful SAR of any of the
+// deregistration types. The Server-Name of the UAA and LIA for a public
+// identity of a registered user is filled in with its S-CSCF before the
+// Backend is called.
+//
+// Server implements the diam.Handler interface and must be registered
+// for UARIndex, SARIndex, LIRIndex, MARIndex, RTAIndex and PPAIndex on
+// the connection's handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Backend     Backend
+	Timeout     time.Duration // Defaults to DefaultTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending       pending.Table
+	mu            sync.Mutex
+	registrations map[string]*registration // By User-Name.
+	identities    map[string]string        // Public-Identity → User-Name.
+}
+
+type registration struct {
+	conn diam.Conn
+	sar  *SAR
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.UserAuthorization:
+		var uar UAR
+		uaa := &UAA{}
+		if decode(m, &uar, &uaa.ResultCode) {
+			uaa.ServerName = s.serverName(uar.PublicIdentity)
+			s.backend(func(b Backend) { b.UserAuthorization(&uar, uaa) }, &uaa.ResultCode)
+		}
+		uaa.SessionID = uar.SessionID
+		uaa.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		uaa.AuthSessionState = base.NoStateMaintained
+		uaa.OriginHost = s.OriginHost
+		uaa.OriginRealm = s.OriginRealm
+		if uaa.ExperimentalResult != nil {
+			uaa.ResultCode = 0
+		}
+		answer(c, m, uaa, s.ErrorReporter)
+	case diam.ServerAssignment:
+		var sar SAR
+		saa := &SAA{}
+		if decode(m, &sar, &saa.ResultCode) {
+			saa.UserName = sar.UserName
+			s.backend(func(b Backend) { b.ServerAssignment(&sar, saa) }, &saa.ResultCode)
+		}
+		saa.SessionID = sar.SessionID
+		saa.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		saa.AuthSessionState = base.NoStateMaintained
+		saa.OriginHost = s.OriginHost
+		saa.OriginRealm = s.OriginRealm
+		if saa.ExperimentalResult != nil {
+			saa.ResultCode = 0
+		}
+		if saa.ResultCode == diam.Success {
+			s.assign(c, &sar)
+		}
+		answer(c, m, saa, s.ErrorReporter)
+	case diam.LocationInfo:
+		var lir LIR
+		lia := &LIA{}
+		if decode(m, &lir, &lia.ResultCode) {
+			lia.ServerName = s.serverName(lir.PublicIdentity)
+			s.backend(func(b Backend) { b.LocationInfo(&lir, lia) }, &lia.ResultCode)
+		}
+		lia.SessionID = lir.SessionID
+		lia.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		lia.AuthSessionState = base.NoStateMaintained
+		lia.OriginHost = s.OriginHost
+		lia.OriginRealm = s.OriginRealm
+		if lia.ExperimentalResult != nil {
+			lia.ResultCode = 0
+		}
+		answer(c, m, lia, s.ErrorReporter)
+	case diam.MultimediaAuth:
+		var mar MAR
+		maa := &MAA{}
+		if decode(m, &mar, &maa.ResultCode) {
+			maa.UserName = mar.UserName
+			maa.PublicIdentity = mar.PublicIdentity
+			s.backend(func(b Backend) { b.MultimediaAuth(&mar, maa) }, &maa.ResultCode)
+		}
+		maa.SessionID = mar.SessionID
+		maa.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		maa.AuthSessionState = base.NoStateMaintained
+		maa.OriginHost = s.OriginHost
+		maa.OriginRealm = s.OriginRealm
+		if maa.ExperimentalResult != nil {
+			maa.ResultCode = 0
+		}
+		answer(c, m, maa, s.ErrorReporter)
+	}
+}
+
+// backend calls f with the Backend, or answers DIAMETER_UNABLE_TO_COMPLY
+// when there is none.
+func (s *Server) backend(f func(b Backend), resultCode *uint32) {
+	if s.Backend == nil {
+		*resultCode = diam.UnableToComply
+		return
+	}
+	f(s.Backend)
+}
+
+// serverName returns the S-CSCF of the user a public identity belongs
+// to, or an empty string when the user is not registered.
+func (s *Server) serverName(publicIdentity string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.registrations[s.identities[publicIdentity]]
+	if !ok {
+		return ""
+	}
+	return r.sar.ServerName
+}
+
+// assign updates the registration of a user after a successful SAR.
+func (s *Server) assign(c diam.Conn, sar *SAR) {
+	if len(sar.UserName) == 0 {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch sar.ServerAssignmentType {
+	case Registration, ReRegistration:
+		if s.registrations == nil {
+			s.registrations = make(map[string]*registration)
+			s.identities = make(map[string]string)
+		}
+		s.unregister(sar.UserName)
+		s.registrations[sar.UserName] = &registration{conn: c, sar: sar}
+		for _, id := range sar.PublicIdentity {
+			s.identities[id] = sar.UserName
+		}
+	case TimeoutDeregistration, UserDeregistration,
+		TimeoutDeregistrationStoreServerName, UserDeregistrationStoreServerName,
+		AdministrativeDeregistration, DeregistrationTooMuchData:
+		if r, ok := s.registrations[sar.UserName]; ok && r.sar.OriginHost == sar.OriginHost {
+			s.unregister(sar.UserName)
+		}
+	}
+}
+
+// unregister removes the registration of a user. It must be called
+// with s.mu held.
+func (s *Server) unregister(user string) {
+	r, ok := s.registrations[user]
+	if !ok {
+		return
+	}
+	for _, id := range r.sar.PublicIdentity {
+		if s.identities[id] == user {
+			delete(s.identities, id)
+		}
+	}
+	delete(s.registrations, user)
+}
+
+// Registrations returns the User-Name of all registered users, sorted.
+func (s *Server) Registrations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]string, 0, len(s.registrations))
+	for user := range s.registrations {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	return users
+}
+
+// Registration returns the SAR that registered a user.
+func (s *Server) Registration(user string) (*SAR, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.registrations[user]
+	if !ok {
+		return nil, false
+	}
+	return r.sar, true
+}
+
+func (s *Server) registration(user string) (*registration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.registrations[user]
+	if !ok {
+		return nil, ErrUnknownUser
+	}
+	return r, nil
+}
+
+// RegistrationTermination sends an RTR with the given Reason-Code and
+// Reason-Info to the S-CSCF of a registered user and waits for its
+// answer. The user is no longer registered afterwards.
+func (s *Server) RegistrationTermination(user string, reasonCode int32, reasonInfo string) (*RTA, error) {
+	r, err := s.registration(user)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.registrations[user] == r {
+		s.unregister(user)
+	}
+	s.mu.Unlock()
+	rtr := &RTR{
+		SessionID:                   sessionid.New(s.OriginHost),
+		VendorSpecificApplicationID: vendorSpecificApplicationID(),
+		AuthSessionState:            base.NoStateMaintained,
+		OriginHost:                  s.OriginHost,
+		OriginRealm:                 s.OriginRealm,
+		DestinationHost:             r.sar.OriginHost,
+		DestinationRealm:            r.sar.OriginRealm,
+		UserName:                    user,
+		PublicIdentity:              r.sar.PublicIdentity,
+		DeregistrationReason: DeregistrationReason{
+			ReasonCode: reasonCode,
+			ReasonInfo: reasonInfo,
+		},
+	}
+	var rta RTA
+	if err = s.exchange(r.conn, diam.RegistrationTermination, rtr, &rta); err != nil {
+		return nil, err
+	}
+	return &rta, nil
+}
+
+// PushProfile sends a PPR carrying the User-Data or Charging-Information
+// in ppr to the S-CSCF of a registered user and waits for its answer.
+// Session-Id, routing AVPs and User-Name are filled in by the Server.
+func (s *Server) PushProfile(user string, ppr *PPR) (*PPA, error) {
+	r, err := s.registration(user)
+	if err != nil {
+		return nil, err
+	}
+	// Fill in a copy, leaving the caller's PPR untouched.
+	var req PPR
+	if ppr != nil {
+		req = *ppr
+	}
+	ppr = &req
+	ppr.SessionID = sessionid.New(s.OriginHost)
+	ppr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	ppr.AuthSessionState = base.NoStateMaintained
+	ppr.OriginHost = s.OriginHost
+	ppr.OriginRealm = s.OriginRealm
+	ppr.DestinationHost = r.sar.OriginHost
+	ppr.DestinationRealm = r.sar.OriginRealm
+	ppr.UserName = user
+	var ppa PPA
+	if err = s.exchange(r.conn, diam.PushProfile, ppr, &ppa); err != nil {
+		return nil, err
+	}
+	return &ppa, nil
+}
+
+func (s *Server) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.TGPP_CX_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := s.pending.Exchange(c, m, s.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
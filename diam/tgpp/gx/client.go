@@ -0,0 +1,346 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// ErrSessionClosed is returned by Session methods after the session
+// has been terminated.
+var ErrSessionClosed = errors.New("gx: session closed")
+
+// DefaultTimeout is how long the Client and Server wait for answers
+// when no Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+// Client is the PCEF side of Gx. It opens IP-CAN sessions with
+// Establish, keeps track of the PCC rules, event triggers and QoS the
+// PCRF provisioned for each of them, and applies the changes pushed
+// in Re-Auth-Request messages.
+//
+// Client implements the diam.Handler interface and must be registered
+// for CCAIndex and RARIndex on the connection's handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// OnReAuth, if non-nil, is called for every RAR after its changes
+	// were applied to the session. It returns the Result-Code of the
+	// RAA; when nil, the Client answers with DIAMETER_SUCCESS. A RAR
+	// with a Session-Release-Cause asks the PCEF to terminate the
+	// session, which is up to the caller.
+	OnReAuth func(s *Session, rar *RAR) uint32
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// Session is an IP-CAN session established by the Client.
+type Session struct {
+	ID string
+
+	client *Client
+	conn   diam.Conn
+
+	reqMu  sync.Mutex // Serializes CCRs of the session.
+	number uint32
+
+	mu       sync.Mutex
+	closed   bool
+	rules    map[string]*ChargingRuleDefinition // Nil for predefined rules.
+	bases    map[string]struct{}
+	triggers []EventTrigger
+	qos      *QoSInformation
+	umi      map[string]UsageMonitoringInformation
+}
+
+// Establish sends a CCR-Initial built from ccr over c and returns the new
+// session. Session-Id is generated when empty, and the routing AVPs, the
+// Auth-Application-Id, CC-Request-Type and CC-Request-Number are filled
+// in by the Client.
+//
+// If the PCRF rejects the session the returned Session is nil and the
+// Result-Code is available in the CCA.
+func (cli *Client) Establish(c diam.Conn, ccr *CCR) (*Session, *CCA, error) {
+	if ccr == nil {
+		ccr = &CCR{}
+	}
+	if len(ccr.SessionID) == 0 {
+		ccr.SessionID = sessionid.New(cli.OriginHost)
+	}
+	s := &Session{
+		ID:     ccr.SessionID,
+		client: cli,
+		conn:   c,
+		rules:  make(map[string]*ChargingRuleDefinition),
+		bases:  make(map[string]struct{}),
+		umi:    make(map[string]UsageMonitoringInformation),
+	}
+	cli.mu.Lock()
+	if cli.sessions == nil {
+		cli.sessions = make(map[string]*Session)
+	}
+	cli.sessions[s.ID] = s
+	cli.mu.Unlock()
+	cca, err := s.send(gy.InitialRequest, ccr)
+	if err != nil || cca.ResultCode != diam.Success {
+		s.close()
+		return nil, cca, err
+	}
+	return s, cca, nil
+}
+
+// Session returns the active session with the given Session-Id, or nil.
+func (cli *Client) Session(id string) *Session {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	return cli.sessions[id]
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+		return
+	}
+	if m.Header.CommandCode != diam.ReAuth {
+		return
+	}
+	var rar RAR
+	raa := &RAA{
+		OriginHost:  cli.OriginHost,
+		OriginRealm: cli.OriginRealm,
+		ResultCode:  diam.Success,
+	}
+	if err := m.Unmarshal(&rar); err != nil {
+		raa.ResultCode = diam.UnableToComply
+	}
+	raa.SessionID = rar.SessionID
+	if raa.ResultCode == diam.Success {
+		if s := cli.Session(rar.SessionID); s == nil {
+			raa.ResultCode = diam.UnknownSessionID
+		} else {
+			s.apply(rar.ChargingRuleRemove, rar.ChargingRuleInstall,
+				rar.EventTrigger, rar.QoSInformation, rar.UsageMonitoringInformation)
+			if cli.OnReAuth != nil {
+				raa.ResultCode = cli.OnReAuth(s, &rar)
+			}
+		}
+	}
+	a := m.Answer(0)
+	err := a.Marshal(raa)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && cli.ErrorReporter != nil {
+		cli.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write RAA: %v", err),
+		})
+	}
+}
+
+// Update sends a CCR-Update built from ccr, typically to report event
+// triggers or usage, and applies the changes in the answer.
+func (s *Session) Update(ccr *CCR) (*CCA, error) {
+	if ccr == nil {
+		ccr = &CCR{}
+	}
+	return s.send(gy.UpdateRequest, ccr)
+}
+
+// Terminate sends a CCR-Termination built from ccr and closes the
+// session, regardless of the answer.
+func (s *Session) Terminate(ccr *CCR) (*CCA, error) {
+	if ccr == nil {
+		ccr = &CCR{}
+	}
+	defer s.close()
+	return s.send(gy.TerminationRequest, ccr)
+}
+
+func (s *Session) send(typ gy.RequestType, ccr *CCR) (*CCA, error) {
+	s.reqMu.Lock()
+	defer s.reqMu.Unlock()
+	if s.isClosed() {
+		return nil, ErrSessionClosed
+	}
+	cli := s.client
+	ccr.SessionID = s.ID
+	ccr.OriginHost = cli.OriginHost
+	ccr.OriginRealm = cli.OriginRealm
+	ccr.DestinationRealm = cli.DestinationRealm
+	ccr.DestinationHost = cli.DestinationHost
+	ccr.AuthApplicationID = diam.GX_CHARGING_CONTROL_APP_ID
+	ccr.CCRequestType = typ
+	ccr.CCRequestNumber = s.number
+	s.number++
+	m := diam.NewRequest(diam.CreditControl, diam.GX_CHARGING_CONTROL_APP_ID, s.conn.Dictionary())
+	if err := m.Marshal(ccr); err != nil {
+		return nil, err
+	}
+	a, err := cli.pending.Exchange(s.conn, m, cli.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	var cca CCA
+	if err = a.Unmarshal(&cca); err != nil {
+		return nil, err
+	}
+	if cca.ResultCode == diam.Success {
+		s.apply(cca.ChargingRuleRemove, cca.ChargingRuleInstall,
+			cca.EventTrigger, cca.QoSInformation, cca.UsageMonitoringInformation)
+	}
+	return &cca, nil
+}
+
+func (s *Session) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	cli := s.client
+	cli.mu.Lock()
+	if cli.sessions[s.ID] == s {
+		delete(cli.sessions, s.ID)
+	}
+	cli.mu.Unlock()
+}
+
+func (s *Session) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// apply updates the session state with the policy in a CCA or RAR.
+// Removals are processed before installations, and event triggers
+// replace the current ones unless absent.
+func (s *Session) apply(remove []ChargingRuleRemove, install []ChargingRuleInstall,
+	triggers []EventTrigger, qos *QoSInformation, umi []UsageMonitoringInformation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, r := range remove {
+		for _, n := range r.Name {
+			delete(s.rules, n)
+		}
+		for _, n := range r.BaseName {
+			delete(s.bases, n)
+		}
+	}
+	for _, r := range install {
+		for _, n := range r.Name {
+			s.rules[n] = nil
+		}
+		for _, n := range r.BaseName {
+			s.bases[n] = struct{}{}
+		}
+		for i := range r.Definition {
+			d := r.Definition[i]
+			s.rules[d.Name] = &d
+		}
+	}
+	if len(triggers) > 0 {
+		s.triggers = nil
+		for _, t := range triggers {
+			if t != NoEventTriggers {
+				s.triggers = append(s.triggers, t)
+			}
+		}
+	}
+	if qos != nil {
+		s.qos = qos
+	}
+	for _, u := range umi {
+		s.umi[u.MonitoringKey] = u
+	}
+}
+
+// Rules returns the names of the PCC rules active in the session,
+// including predefined rules, sorted.
+func (s *Session) Rules() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.rules))
+	for n := range s.rules {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Rule returns the definition of the dynamic PCC rule with the given
+// name. It returns nil for unknown and predefined rules.
+func (s *Session) Rule(name string) *ChargingRuleDefinition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rules[name]
+}
+
+// RuleBases returns the names of the rule bases active in the session,
+// sorted.
+func (s *Session) RuleBases() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.bases))
+	for n := range s.bases {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EventTriggers returns the event triggers armed by the PCRF.
+func (s *Session) EventTriggers() []EventTrigger {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]EventTrigger(nil), s.triggers...)
+}
+
+// HasEventTrigger reports whether the PCRF armed the event trigger e.
+func (s *Session) HasEventTrigger(e EventTrigger) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, t := range s.triggers {
+		if t == e {
+			return true
+		}
+	}
+	return false
+}
+
+// QoS returns the last QoS-Information authorized by the PCRF, or nil.
+func (s *Session) QoS() *QoSInformation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.qos
+}
+
+// UsageMonitoring returns the last Usage-Monitoring-Information the PCRF
+// sent for the monitoring key.
+func (s *Session) UsageMonitoring(key string) (UsageMonitoringInformation, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	u, ok := s.umi[key]
+	return u, ok
+}
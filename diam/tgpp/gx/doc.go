@@ -0,0 +1,30 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package gx implements the Gx application between the PCEF and the PCRF,
+// as specified in 3GPP TS 29.212.
+//
+// It provides typed CCR, CCA, RAR and RAA messages for use with
+// Message.Marshal and Message.Unmarshal, a PCEF Client that tracks the
+// PCC rules of each IP-CAN session, and a PCRF Server that answers CCRs
+// with a Policy and can push policy changes to active sessions.
+//
+// A PCRF that installs a predefined rule in every session:
+//
+//	pcrf := &gx.Server{
+//		OriginHost:  "pcrf.example.com",
+//		OriginRealm: "example.com",
+//		Policy: gx.PolicyFunc(func(ccr *gx.CCR, cca *gx.CCA) {
+//			if ccr.CCRequestType == gy.InitialRequest {
+//				cca.ChargingRuleInstall = []gx.ChargingRuleInstall{{Name: []string{"default"}}}
+//			}
+//		}),
+//	}
+//	mux := sm.New(settings)
+//	mux.HandleIdx(gx.CCRIndex, pcrf)
+//	mux.HandleIdx(gx.RAAIndex, pcrf)
+//
+// Later, pcrf.ReAuth(sessionID, &gx.RAR{...}) sends a RAR to the PCEF
+// that opened the session.
+package gx
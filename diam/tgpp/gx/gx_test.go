@@ -0,0 +1,253 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gx
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+func u32(v uint32) *uint32 { return &v }
+func i32(v int32) *int32   { return &v }
+
+func TestCCA_MarshalUnmarshal(t *testing.T) {
+	want := &CCA{
+		SessionID:         "pgw;1",
+		AuthApplicationID: diam.GX_CHARGING_CONTROL_APP_ID,
+		OriginHost:        "pcrf",
+		OriginRealm:       "test",
+		ResultCode:        diam.Success,
+		CCRequestType:     gy.InitialRequest,
+		EventTrigger:      []EventTrigger{RATChange, UsageReport},
+		ChargingRuleInstall: []ChargingRuleInstall{{
+			Definition: []ChargingRuleDefinition{{
+				Name:        "video",
+				RatingGroup: u32(10),
+				FlowInformation: []FlowInformation{{
+					FlowDescription: "permit out ip from any to 10.0.0.1",
+					FlowDirection:   i32(FlowDownlink),
+				}},
+				Precedence:    u32(100),
+				MonitoringKey: "mk1",
+			}},
+			Name: []string{"default", "dns"},
+		}},
+		QoSInformation: &QoSInformation{
+			QCI:                      i32(9),
+			AllocationRetentionPrio:  &AllocationRetentionPriority{PriorityLevel: 8},
+			APNAggregateMaxBitrateUL: 1000000,
+			APNAggregateMaxBitrateDL: 5000000,
+		},
+		UsageMonitoringInformation: []UsageMonitoringInformation{{
+			MonitoringKey:        "mk1",
+			GrantedServiceUnit:   &gy.ServiceUnit{TotalOctets: 1 << 30},
+			UsageMonitoringLevel: i32(RuleLevel),
+		}},
+	}
+	m := diam.NewRequest(diam.CreditControl, diam.GX_CHARGING_CONTROL_APP_ID, dict.Default).Answer(0)
+	if err := m.Marshal(want); err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have CCA
+	if err = dec.Unmarshal(&have); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, &have) {
+		t.Fatalf("Unexpected CCA.\nWant %+v\nHave %+v", want, &have)
+	}
+}
+
+func TestEventTrigger_String(t *testing.T) {
+	if s := IPCANChange.String(); s != "IP-CAN_CHANGE" {
+		t.Fatalf("Unexpected name: %q", s)
+	}
+	if s := EventTrigger(1000).String(); s != "UNKNOWN" {
+		t.Fatalf("Unexpected name: %q", s)
+	}
+}
+
+func TestClientServer(t *testing.T) {
+	pcrf := &Server{
+		OriginHost:  "pcrf",
+		OriginRealm: "test",
+		Timeout:     time.Second,
+		Policy: PolicyFunc(func(ccr *CCR, cca *CCA) {
+			switch ccr.CCRequestType {
+			case gy.InitialRequest:
+				if ccr.Subscriber() == "blocked" {
+					cca.ResultCode = diam.AuthorizationRejected
+					return
+				}
+				cca.ChargingRuleInstall = []ChargingRuleInstall{{Name: []string{"default"}}}
+				cca.EventTrigger = []EventTrigger{RATChange}
+			case gy.UpdateRequest:
+				if len(ccr.EventTrigger) != 1 || ccr.EventTrigger[0] != RATChange {
+					cca.ResultCode = diam.UnableToComply
+				}
+			}
+		}),
+	}
+	mux := sm.New(smtest.Settings("pcrf"))
+	mux.HandleIdx(CCRIndex, pcrf)
+	mux.HandleIdx(RAAIndex, pcrf)
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	reauth := make(chan *RAR, 1)
+	pcef := &Client{
+		OriginHost:       "pgw",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		OnReAuth: func(s *Session, rar *RAR) uint32 {
+			reauth <- rar
+			return diam.Success
+		},
+	}
+	cmux := sm.New(smtest.Settings("pgw"))
+	cmux.HandleIdx(CCAIndex, pcef)
+	cmux.HandleIdx(RARIndex, pcef)
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.GX_CHARGING_CONTROL_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s, cca, err := pcef.Establish(c, &CCR{
+		SubscriptionID:  []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: "001010000000001"}},
+		IPCANType:       i32(IPCAN3GPPEPS),
+		CalledStationID: "internet",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil || cca.ResultCode != diam.Success {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if !strings.HasPrefix(s.ID, "pgw;") {
+		t.Fatalf("Unexpected Session-Id: %q", s.ID)
+	}
+	if r := s.Rules(); !reflect.DeepEqual(r, []string{"default"}) {
+		t.Fatalf("Unexpected rules: %v", r)
+	}
+	if !s.HasEventTrigger(RATChange) {
+		t.Fatalf("Unexpected event triggers: %v", s.EventTriggers())
+	}
+	if ids := pcrf.Sessions(); !reflect.DeepEqual(ids, []string{s.ID}) {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	if ccr, ok := pcrf.Session(s.ID); !ok || ccr.CalledStationID != "internet" {
+		t.Fatalf("Unexpected session: %+v", ccr)
+	}
+
+	rar := &RAR{
+		ChargingRuleRemove: []ChargingRuleRemove{{Name: []string{"default"}}},
+		ChargingRuleInstall: []ChargingRuleInstall{{
+			Definition: []ChargingRuleDefinition{{Name: "throttle", Precedence: u32(1)}},
+		}},
+		QoSInformation: &QoSInformation{APNAggregateMaxBitrateDL: 64000},
+	}
+	raa, err := pcrf.ReAuth(s.ID, rar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rar.SessionID != "" || rar.DestinationHost != "" {
+		t.Fatalf("ReAuth modified the RAR: %+v", rar)
+	}
+	if raa.ResultCode != diam.Success || raa.SessionID != s.ID {
+		t.Fatalf("Unexpected RAA: %+v", raa)
+	}
+	select {
+	case rar := <-reauth:
+		if rar.DestinationHost != "pgw" {
+			t.Fatalf("Unexpected RAR: %+v", rar)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnReAuth was not called")
+	}
+	if r := s.Rules(); !reflect.DeepEqual(r, []string{"throttle"}) {
+		t.Fatalf("Unexpected rules: %v", r)
+	}
+	if d := s.Rule("throttle"); d == nil || *d.Precedence != 1 {
+		t.Fatalf("Unexpected rule: %+v", d)
+	}
+	if q := s.QoS(); q == nil || q.APNAggregateMaxBitrateDL != 64000 {
+		t.Fatalf("Unexpected QoS: %+v", q)
+	}
+
+	cca, err = s.Update(&CCR{EventTrigger: []EventTrigger{RATChange}, RATType: i32(1004)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cca.ResultCode != diam.Success || cca.CCRequestNumber != 1 {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+
+	if cca, err = s.Terminate(nil); err != nil {
+		t.Fatal(err)
+	}
+	if cca.CCRequestType != gy.TerminationRequest || cca.CCRequestNumber != 2 {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if ids := pcrf.Sessions(); len(ids) != 0 {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	if _, err = s.Update(nil); err != ErrSessionClosed {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrSessionClosed, err)
+	}
+	if _, err = pcrf.ReAuth(s.ID, nil); err != ErrUnknownSession {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrUnknownSession, err)
+	}
+
+	s, cca, err = pcef.Establish(c, &CCR{
+		SubscriptionID: []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: "blocked"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil || cca.ResultCode != diam.AuthorizationRejected {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+
+	// Sessions are forgotten when their connection is closed.
+	if _, _, err = pcef.Establish(c, nil); err != nil {
+		t.Fatal(err)
+	}
+	if ids := pcrf.Sessions(); len(ids) != 1 {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	c.Close()
+	for deadline := time.Now().Add(time.Second); len(pcrf.Sessions()) > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Unexpected sessions after close: %v", pcrf.Sessions())
+		}
+	}
+}
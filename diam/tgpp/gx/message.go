@@ -0,0 +1,290 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gx
+
+import (
+	"net"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// Command indexes of the Gx application, for use with ServeMux.HandleIdx.
+var (
+	CCRIndex = diam.CommandIndex{AppID: diam.GX_CHARGING_CONTROL_APP_ID, Code: diam.CreditControl, Request: true}
+	CCAIndex = diam.CommandIndex{AppID: diam.GX_CHARGING_CONTROL_APP_ID, Code: diam.CreditControl, Request: false}
+	RARIndex = diam.CommandIndex{AppID: diam.GX_CHARGING_CONTROL_APP_ID, Code: diam.ReAuth, Request: true}
+	RAAIndex = diam.CommandIndex{AppID: diam.GX_CHARGING_CONTROL_APP_ID, Code: diam.ReAuth, Request: false}
+)
+
+// EventTrigger is the value of the Event-Trigger AVP.
+type EventTrigger int32
+
+// Event-Trigger values. See 3GPP TS 29.212 section 5.3.7.
+const (
+	SGSNChange                   EventTrigger = 0
+	QoSChange                    EventTrigger = 1
+	RATChange                    EventTrigger = 2
+	TFTChange                    EventTrigger = 3
+	PLMNChange                   EventTrigger = 4
+	LossOfBearer                 EventTrigger = 5
+	RecoveryOfBearer             EventTrigger = 6
+	IPCANChange                  EventTrigger = 7
+	UserLocationChange           EventTrigger = 13
+	NoEventTriggers              EventTrigger = 14
+	OutOfCredit                  EventTrigger = 15
+	ReallocationOfCredit         EventTrigger = 16
+	RevalidationTimeout          EventTrigger = 17
+	UEIPAddressAllocate          EventTrigger = 18
+	UEIPAddressRelease           EventTrigger = 19
+	DefaultEPSBearerQoSChange    EventTrigger = 20
+	ANGWChange                   EventTrigger = 21
+	SuccessfulResourceAllocation EventTrigger = 22
+	ResourceModificationRequest  EventTrigger = 23
+	UETimeZoneChange             EventTrigger = 25
+	TAIChange                    EventTrigger = 26
+	ECGIChange                   EventTrigger = 27
+	UsageReport                  EventTrigger = 33
+)
+
+var eventTriggerNames = map[EventTrigger]string{
+	SGSNChange:                   "SGSN_CHANGE",
+	QoSChange:                    "QOS_CHANGE",
+	RATChange:                    "RAT_CHANGE",
+	TFTChange:                    "TFT_CHANGE",
+	PLMNChange:                   "PLMN_CHANGE",
+	LossOfBearer:                 "LOSS_OF_BEARER",
+	RecoveryOfBearer:             "RECOVERY_OF_BEARER",
+	IPCANChange:                  "IP-CAN_CHANGE",
+	UserLocationChange:           "USER_LOCATION_CHANGE",
+	NoEventTriggers:              "NO_EVENT_TRIGGERS",
+	OutOfCredit:                  "OUT_OF_CREDIT",
+	ReallocationOfCredit:         "REALLOCATION_OF_CREDIT",
+	RevalidationTimeout:          "REVALIDATION_TIMEOUT",
+	UEIPAddressAllocate:          "UE_IP_ADDRESS_ALLOCATE",
+	UEIPAddressRelease:           "UE_IP_ADDRESS_RELEASE",
+	DefaultEPSBearerQoSChange:    "DEFAULT_EPS_BEARER_QOS_CHANGE",
+	ANGWChange:                   "AN_GW_CHANGE",
+	SuccessfulResourceAllocation: "SUCCESSFUL_RESOURCE_ALLOCATION",
+	ResourceModificationRequest:  "RESOURCE_MODIFICATION_REQUEST",
+	UETimeZoneChange:             "UE_TIME_ZONE_CHANGE",
+	TAIChange:                    "TAI_CHANGE",
+	ECGIChange:                   "ECGI_CHANGE",
+	UsageReport:                  "USAGE_REPORT",
+}
+
+// String returns the name of the event trigger as it appears in the
+// dictionary.
+func (e EventTrigger) String() string {
+	if n, ok := eventTriggerNames[e]; ok {
+		return n
+	}
+	return "UNKNOWN"
+}
+
+// Re-Auth-Request-Type values. See RFC 6733 section 8.12.
+const (
+	AuthorizeOnly         = 0
+	AuthorizeAuthenticate = 1
+)
+
+// Session-Release-Cause values. See 3GPP TS 29.212 section 5.3.33.
+const (
+	UnspecifiedReason           = 0
+	UESubscriptionReason        = 1
+	InsufficientServerResources = 2
+	IPCANSessionTermination     = 3
+	UEIPAddressReleaseReason    = 4
+)
+
+// IP-CAN-Type values. See 3GPP TS 29.212 section 5.3.27.
+const (
+	IPCAN3GPPGPRS   = 0
+	IPCANDOCSIS     = 1
+	IPCANxDSL       = 2
+	IPCANWiMAX      = 3
+	IPCAN3GPP2      = 4
+	IPCAN3GPPEPS    = 5
+	IPCANNon3GPPEPS = 6
+)
+
+// Usage-Monitoring-Level values. See 3GPP TS 29.212 section 5.3.61.
+const (
+	SessionLevel = 0
+	RuleLevel    = 1
+)
+
+// Flow-Direction values. See 3GPP TS 29.212 section 5.3.65.
+const (
+	FlowUnspecified   = 0
+	FlowDownlink      = 1
+	FlowUplink        = 2
+	FlowBidirectional = 3
+)
+
+// AllocationRetentionPriority is the Allocation-Retention-Priority
+// grouped AVP.
+type AllocationRetentionPriority struct {
+	PriorityLevel           uint32 `avp:"Priority-Level"`
+	PreemptionCapability    *int32 `avp:"Pre-emption-Capability"`
+	PreemptionVulnerability *int32 `avp:"Pre-emption-Vulnerability"`
+}
+
+// QoSInformation is the QoS-Information grouped AVP.
+type QoSInformation struct {
+	QCI                      *int32                       `avp:"QoS-Class-Identifier"`
+	MaxRequestedBandwidthUL  uint32                       `avp:"Max-Requested-Bandwidth-UL,omitempty"`
+	MaxRequestedBandwidthDL  uint32                       `avp:"Max-Requested-Bandwidth-DL,omitempty"`
+	GuaranteedBitrateUL      uint32                       `avp:"Guaranteed-Bitrate-UL,omitempty"`
+	GuaranteedBitrateDL      uint32                       `avp:"Guaranteed-Bitrate-DL,omitempty"`
+	BearerIdentifier         datatype.OctetString         `avp:"Bearer-Identifier,omitempty"`
+	AllocationRetentionPrio  *AllocationRetentionPriority `avp:"Allocation-Retention-Priority"`
+	APNAggregateMaxBitrateUL uint32                       `avp:"APN-Aggregate-Max-Bitrate-UL,omitempty"`
+	APNAggregateMaxBitrateDL uint32                       `avp:"APN-Aggregate-Max-Bitrate-DL,omitempty"`
+}
+
+// DefaultEPSBearerQoS is the Default-EPS-Bearer-QoS grouped AVP.
+type DefaultEPSBearerQoS struct {
+	QCI                     *int32                       `avp:"QoS-Class-Identifier"`
+	AllocationRetentionPrio *AllocationRetentionPriority `avp:"Allocation-Retention-Priority"`
+}
+
+// FlowInformation is the Flow-Information grouped AVP.
+type FlowInformation struct {
+	FlowDescription        datatype.IPFilterRule `avp:"Flow-Description,omitempty"`
+	PacketFilterIdentifier datatype.OctetString  `avp:"Packet-Filter-Identifier,omitempty"`
+	ToSTrafficClass        *uint32               `avp:"ToS-Traffic-Class"`
+	FlowDirection          *int32                `avp:"Flow-Direction"`
+}
+
+// ChargingRuleDefinition is the Charging-Rule-Definition grouped AVP,
+// which carries a dynamic PCC rule.
+type ChargingRuleDefinition struct {
+	Name              string            `avp:"Charging-Rule-Name"`
+	ServiceIdentifier *uint32           `avp:"Service-Identifier"`
+	RatingGroup       *uint32           `avp:"Rating-Group"`
+	FlowInformation   []FlowInformation `avp:"Flow-Information"`
+	QoSInformation    *QoSInformation   `avp:"QoS-Information"`
+	Online            *int32            `avp:"Online"`
+	Offline           *int32            `avp:"Offline"`
+	Precedence        *uint32           `avp:"Precedence"`
+	MonitoringKey     string            `avp:"Monitoring-Key,omitempty"`
+}
+
+// ChargingRuleInstall is the Charging-Rule-Install grouped AVP. It
+// activates dynamic rules, predefined rules by name and rule bases.
+type ChargingRuleInstall struct {
+	Definition       []ChargingRuleDefinition `avp:"Charging-Rule-Definition"`
+	Name             []string                 `avp:"Charging-Rule-Name"`
+	BaseName         []string                 `avp:"Charging-Rule-Base-Name"`
+	ActivationTime   *time.Time               `avp:"Rule-Activation-Time"`
+	DeactivationTime *time.Time               `avp:"Rule-Deactivation-Time"`
+}
+
+// ChargingRuleRemove is the Charging-Rule-Remove grouped AVP.
+type ChargingRuleRemove struct {
+	Name     []string `avp:"Charging-Rule-Name"`
+	BaseName []string `avp:"Charging-Rule-Base-Name"`
+}
+
+// UsageMonitoringInformation is the Usage-Monitoring-Information grouped
+// AVP. The PCRF sends thresholds in the Granted-Service-Unit and the PCEF
+// reports consumption in the Used-Service-Unit.
+type UsageMonitoringInformation struct {
+	MonitoringKey          string          `avp:"Monitoring-Key"`
+	GrantedServiceUnit     *gy.ServiceUnit `avp:"Granted-Service-Unit"`
+	UsedServiceUnit        *gy.ServiceUnit `avp:"Used-Service-Unit"`
+	UsageMonitoringLevel   *int32          `avp:"Usage-Monitoring-Level"`
+	UsageMonitoringReport  *int32          `avp:"Usage-Monitoring-Report"`
+	UsageMonitoringSupport *int32          `avp:"Usage-Monitoring-Support"`
+}
+
+// CCR is a Gx Credit-Control-Request message.
+// See 3GPP TS 29.212 section 5.6.2.
+type CCR struct {
+	SessionID                  string                       `avp:"Session-Id"`
+	OriginHost                 datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationRealm           datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	AuthApplicationID          uint32                       `avp:"Auth-Application-Id"`
+	CCRequestType              gy.RequestType               `avp:"CC-Request-Type"`
+	CCRequestNumber            uint32                       `avp:"CC-Request-Number"`
+	DestinationHost            datatype.DiameterIdentity    `avp:"Destination-Host,omitempty"`
+	OriginStateID              uint32                       `avp:"Origin-State-Id,omitempty"`
+	SubscriptionID             []gy.SubscriptionID          `avp:"Subscription-Id"`
+	NetworkRequestSupport      *int32                       `avp:"Network-Request-Support"`
+	TerminationCause           int32                        `avp:"Termination-Cause,omitempty"`
+	UserEquipmentInfo          *gy.UserEquipmentInfo        `avp:"User-Equipment-Info"`
+	QoSInformation             *QoSInformation              `avp:"QoS-Information"`
+	FramedIPAddress            net.IP                       `avp:"Framed-IP-Address,omitempty"`
+	IPCANType                  *int32                       `avp:"IP-CAN-Type"`
+	RATType                    *int32                       `avp:"RAT-Type"`
+	CalledStationID            string                       `avp:"Called-Station-Id,omitempty"`
+	DefaultEPSBearerQoS        *DefaultEPSBearerQoS         `avp:"Default-EPS-Bearer-QoS"`
+	EventTrigger               []EventTrigger               `avp:"Event-Trigger"`
+	UsageMonitoringInformation []UsageMonitoringInformation `avp:"Usage-Monitoring-Information"`
+}
+
+// Subscriber returns the first Subscription-Id-Data of the request.
+func (ccr *CCR) Subscriber() string {
+	for _, id := range ccr.SubscriptionID {
+		if len(id.Data) > 0 {
+			return id.Data
+		}
+	}
+	return ""
+}
+
+// CCA is a Gx Credit-Control-Answer message.
+// See 3GPP TS 29.212 section 5.6.3.
+type CCA struct {
+	SessionID                  string                       `avp:"Session-Id"`
+	AuthApplicationID          uint32                       `avp:"Auth-Application-Id"`
+	OriginHost                 datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	ResultCode                 uint32                       `avp:"Result-Code"`
+	CCRequestType              gy.RequestType               `avp:"CC-Request-Type"`
+	CCRequestNumber            uint32                       `avp:"CC-Request-Number"`
+	OriginStateID              uint32                       `avp:"Origin-State-Id,omitempty"`
+	EventTrigger               []EventTrigger               `avp:"Event-Trigger"`
+	ChargingRuleRemove         []ChargingRuleRemove         `avp:"Charging-Rule-Remove"`
+	ChargingRuleInstall        []ChargingRuleInstall        `avp:"Charging-Rule-Install"`
+	QoSInformation             *QoSInformation              `avp:"QoS-Information"`
+	RevalidationTime           *time.Time                   `avp:"Revalidation-Time"`
+	UsageMonitoringInformation []UsageMonitoringInformation `avp:"Usage-Monitoring-Information"`
+}
+
+// RAR is a Gx Re-Auth-Request message.
+// See 3GPP TS 29.212 section 5.6.4.
+type RAR struct {
+	SessionID                  string                       `avp:"Session-Id"`
+	AuthApplicationID          uint32                       `avp:"Auth-Application-Id"`
+	OriginHost                 datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationRealm           datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	DestinationHost            datatype.DiameterIdentity    `avp:"Destination-Host"`
+	ReAuthRequestType          int32                        `avp:"Re-Auth-Request-Type"`
+	SessionReleaseCause        *int32                       `avp:"Session-Release-Cause"`
+	OriginStateID              uint32                       `avp:"Origin-State-Id,omitempty"`
+	EventTrigger               []EventTrigger               `avp:"Event-Trigger"`
+	ChargingRuleRemove         []ChargingRuleRemove         `avp:"Charging-Rule-Remove"`
+	ChargingRuleInstall        []ChargingRuleInstall        `avp:"Charging-Rule-Install"`
+	QoSInformation             *QoSInformation              `avp:"QoS-Information"`
+	RevalidationTime           *time.Time                   `avp:"Revalidation-Time"`
+	UsageMonitoringInformation []UsageMonitoringInformation `avp:"Usage-Monitoring-Information"`
+}
+
+// RAA is a Gx Re-Auth-Answer message.
+// See 3GPP TS 29.212 section 5.6.5.
+type RAA struct {
+	SessionID     string                    `avp:"Session-Id"`
+	OriginHost    datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm   datatype.DiameterIdentity `avp:"Origin-Realm"`
+	ResultCode    uint32                    `avp:"Result-Code"`
+	OriginStateID uint32                    `avp:"Origin-State-Id,omitempty"`
+	ErrorMessage  string                    `avp:"Error-Message,omitempty"`
+}
@@ -0,0 +1,227 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// ErrUnknownSession is returned by Server.ReAuth when the session is not
+// active.
+var ErrUnknownSession = errors.New("gx: unknown session")
+
+// A Policy makes the policy decisions of a Server.
+type Policy interface {
+	// Decide is called for every CCR. It fills in the policy of the
+	// CCA, such as Charging-Rule-Install and Event-Trigger, and may
+	// change its Result-Code, which is DIAMETER_SUCCESS on entry.
+	Decide(ccr *CCR, cca *CCA)
+}
+
+// The PolicyFunc type is an adapter to allow the use of ordinary
+// functions as Policy.
+type PolicyFunc func(ccr *CCR, cca *CCA)
+
+// Decide calls f(ccr, cca).
+func (f PolicyFunc) Decide(ccr *CCR, cca *CCA) {
+	f(ccr, cca)
+}
+
+// Server is the PCRF side of Gx. It answers CCRs using its Policy and
+// keeps track of the active IP-CAN sessions until they are terminated or
+// their connection is closed, so that policy changes can be pushed to
+// the PCEF with ReAuth.
+//
+// Server implements the diam.Handler interface and must be registered
+// for CCRIndex and RAAIndex on the connection's handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Policy      Policy        // Optional. Without it all sessions are accepted.
+	Timeout     time.Duration // Defaults to DefaultTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	sessions map[string]*serverSession
+	watched  map[diam.Conn]bool // Connections of sessions
+}
+
+type serverSession struct {
+	conn diam.Conn
+	ccr  *CCR // CCR-Initial
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	if m.Header.CommandCode != diam.CreditControl {
+		return
+	}
+	var ccr CCR
+	cca := &CCA{
+		OriginHost:        s.OriginHost,
+		OriginRealm:       s.OriginRealm,
+		AuthApplicationID: diam.GX_CHARGING_CONTROL_APP_ID,
+	}
+	if err := m.Unmarshal(&ccr); err != nil {
+		cca.ResultCode = diam.UnableToComply
+	} else {
+		s.creditControl(c, &ccr, cca)
+	}
+	a := m.Answer(0)
+	err := a.Marshal(cca)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && s.ErrorReporter != nil {
+		s.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write CCA: %v", err),
+		})
+	}
+}
+
+func (s *Server) creditControl(c diam.Conn, ccr *CCR, cca *CCA) {
+	cca.SessionID = ccr.SessionID
+	cca.CCRequestType = ccr.CCRequestType
+	cca.CCRequestNumber = ccr.CCRequestNumber
+	cca.ResultCode = diam.Success
+	if len(ccr.SessionID) == 0 {
+		cca.ResultCode = diam.MissingAVP
+		return
+	}
+	s.mu.Lock()
+	_, active := s.sessions[ccr.SessionID]
+	s.mu.Unlock()
+	if !active && ccr.CCRequestType != gy.InitialRequest {
+		cca.ResultCode = diam.UnknownSessionID
+		return
+	}
+	if s.Policy != nil {
+		s.Policy.Decide(ccr, cca)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case ccr.CCRequestType == gy.TerminationRequest:
+		delete(s.sessions, ccr.SessionID)
+	case ccr.CCRequestType == gy.InitialRequest && cca.ResultCode == diam.Success:
+		if s.sessions == nil {
+			s.sessions = make(map[string]*serverSession)
+		}
+		s.sessions[ccr.SessionID] = &serverSession{conn: c, ccr: ccr}
+		s.watch(c)
+	}
+}
+
+// watch forgets the sessions of the connection c when it is closed.
+// It must be called with s.mu held.
+func (s *Server) watch(c diam.Conn) {
+	cn, ok := c.(diam.CloseNotifier)
+	if !ok || s.watched[c] {
+		return
+	}
+	if s.watched == nil {
+		s.watched = make(map[diam.Conn]bool)
+	}
+	s.watched[c] = true
+	go func() {
+		<-cn.CloseNotify()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.watched, c)
+		for id, ss := range s.sessions {
+			if ss.conn == c {
+				delete(s.sessions, id)
+			}
+		}
+	}()
+}
+
+// Sessions returns the Session-Id of all active sessions, sorted.
+func (s *Server) Sessions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Session returns the CCR-Initial of an active session.
+func (s *Server) Session(id string) (*CCR, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ss, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	return ss.ccr, true
+}
+
+// ReAuth pushes the policy changes in rar to the PCEF of an active
+// session and waits for its answer. Session-Id, routing AVPs and
+// Auth-Application-Id are filled in by the Server.
+//
+// The session is forgotten when the PCEF answers with
+// DIAMETER_UNKNOWN_SESSION_ID.
+func (s *Server) ReAuth(sessionID string, rar *RAR) (*RAA, error) {
+	s.mu.Lock()
+	ss, ok := s.sessions[sessionID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	// Fill in a copy, leaving the caller's RAR untouched.
+	var r RAR
+	if rar != nil {
+		r = *rar
+	}
+	rar = &r
+	rar.SessionID = sessionID
+	rar.AuthApplicationID = diam.GX_CHARGING_CONTROL_APP_ID
+	rar.OriginHost = s.OriginHost
+	rar.OriginRealm = s.OriginRealm
+	rar.DestinationHost = ss.ccr.OriginHost
+	rar.DestinationRealm = ss.ccr.OriginRealm
+	m := diam.NewRequest(diam.ReAuth, diam.GX_CHARGING_CONTROL_APP_ID, ss.conn.Dictionary())
+	if err := m.Marshal(rar); err != nil {
+		return nil, err
+	}
+	a, err := s.pending.Exchange(ss.conn, m, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	var raa RAA
+	if err = a.Unmarshal(&raa); err != nil {
+		return nil, err
+	}
+	if raa.ResultCode == diam.UnknownSessionID {
+		s.mu.Lock()
+		if s.sessions[sessionID] == ss {
+			delete(s.sessions, sessionID)
+		}
+		s.mu.Unlock()
+	}
+	return &raa, nil
+}
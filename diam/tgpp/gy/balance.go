@@ -0,0 +1,218 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gy
+
+import (
+	"errors"
+	"sync"
+)
+
+var (
+	// ErrUnknownSubscriber is returned by a Balance when the subscriber
+	// does not exist. The Server answers with DIAMETER_USER_UNKNOWN.
+	ErrUnknownSubscriber = errors.New("unknown subscriber")
+
+	// ErrCreditLimitReached is returned by a Balance when none of the
+	// requested units can be reserved. The Server answers the MSCC with
+	// DIAMETER_CREDIT_LIMIT_REACHED.
+	ErrCreditLimitReached = errors.New("credit limit reached")
+)
+
+// RateRequest carries the parameters of a single rating decision.
+type RateRequest struct {
+	SessionID         string
+	Subscriber        string
+	ServiceContextID  string
+	RatingGroup       uint32
+	ServiceIdentifier uint32
+	Requested         ServiceUnit // Empty when the client let the OCS decide.
+}
+
+// Rate is the result of a rating decision.
+type Rate struct {
+	Units        ServiceUnit // Units to reserve from the balance.
+	ValidityTime uint32      // Validity-Time of the grant in seconds, or 0.
+}
+
+// A Rater decides how many units to reserve for a request.
+type Rater interface {
+	Rate(r *RateRequest) (*Rate, error)
+}
+
+// A Balance holds subscriber credit and performs reservations against it.
+// Implementations must be safe for concurrent use.
+type Balance interface {
+	// Reserve sets aside up to the given units for the subscriber and
+	// rating group, and returns what was actually reserved. The final
+	// flag is set when the reservation exhausts the balance, which
+	// makes the Server add a Final-Unit-Indication to the grant.
+	Reserve(subscriber string, ratingGroup uint32, units ServiceUnit) (granted ServiceUnit, final bool, err error)
+
+	// Commit settles a reservation: used units are debited and the
+	// remainder of the reserved units is returned to the balance.
+	Commit(subscriber string, ratingGroup uint32, reserved, used ServiceUnit) error
+}
+
+// FixedRater is a Rater that grants the requested units, or a fixed
+// quota when the request does not specify any.
+type FixedRater struct {
+	Quota        ServiceUnit            // Default quota.
+	RatingGroup  map[uint32]ServiceUnit // Optional per rating group quota.
+	ValidityTime uint32                 // Validity-Time for all grants.
+}
+
+// Rate implements the Rater interface.
+func (r *FixedRater) Rate(req *RateRequest) (*Rate, error) {
+	units := req.Requested
+	if units.IsZero() {
+		var ok bool
+		if units, ok = r.RatingGroup[req.RatingGroup]; !ok {
+			units = r.Quota
+		}
+	}
+	return &Rate{Units: units, ValidityTime: r.ValidityTime}, nil
+}
+
+// MemoryBalance is an in-memory Balance. Units are kept per subscriber
+// and rating group; rating group 0 is used as a shared bucket for rating
+// groups that have no balance of their own.
+type MemoryBalance struct {
+	mu  sync.Mutex
+	acc map[string]map[uint32]*ServiceUnit
+}
+
+// NewMemoryBalance allocates and initializes a MemoryBalance.
+func NewMemoryBalance() *MemoryBalance {
+	return &MemoryBalance{acc: make(map[string]map[uint32]*ServiceUnit)}
+}
+
+// Set replaces the available units of the subscriber and rating group.
+func (b *MemoryBalance) Set(subscriber string, ratingGroup uint32, units ServiceUnit) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rg, ok := b.acc[subscriber]
+	if !ok {
+		rg = make(map[uint32]*ServiceUnit)
+		b.acc[subscriber] = rg
+	}
+	rg[ratingGroup] = &units
+}
+
+// Get returns the available units of the subscriber and rating group.
+func (b *MemoryBalance) Get(subscriber string, ratingGroup uint32) (ServiceUnit, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	u, err := b.bucket(subscriber, ratingGroup)
+	if err != nil {
+		return ServiceUnit{}, err
+	}
+	return *u, nil
+}
+
+// Delete removes the subscriber and all its balances.
+func (b *MemoryBalance) Delete(subscriber string) {
+	b.mu.Lock()
+	delete(b.acc, subscriber)
+	b.mu.Unlock()
+}
+
+func (b *MemoryBalance) bucket(subscriber string, ratingGroup uint32) (*ServiceUnit, error) {
+	rg, ok := b.acc[subscriber]
+	if !ok {
+		return nil, ErrUnknownSubscriber
+	}
+	if u, ok := rg[ratingGroup]; ok {
+		return u, nil
+	}
+	if u, ok := rg[0]; ok {
+		return u, nil
+	}
+	return &ServiceUnit{}, nil
+}
+
+// Reserve implements the Balance interface.
+func (b *MemoryBalance) Reserve(subscriber string, ratingGroup uint32, units ServiceUnit) (ServiceUnit, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	avail, err := b.bucket(subscriber, ratingGroup)
+	if err != nil {
+		return ServiceUnit{}, false, err
+	}
+	granted := units.min(*avail)
+	if granted.IsZero() && !units.IsZero() {
+		return ServiceUnit{}, false, ErrCreditLimitReached
+	}
+	*avail = avail.sub(granted)
+	return granted, units.exhausted(*avail), nil
+}
+
+// Commit implements the Balance interface.
+func (b *MemoryBalance) Commit(subscriber string, ratingGroup uint32, reserved, used ServiceUnit) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	avail, err := b.bucket(subscriber, ratingGroup)
+	if err != nil {
+		return err
+	}
+	*avail = avail.add(reserved).sub(used)
+	return nil
+}
+
+// add returns the sum of u and v.
+func (u ServiceUnit) add(v ServiceUnit) ServiceUnit {
+	return ServiceUnit{
+		Time:                 u.Time + v.Time,
+		TotalOctets:          u.TotalOctets + v.TotalOctets,
+		InputOctets:          u.InputOctets + v.InputOctets,
+		OutputOctets:         u.OutputOctets + v.OutputOctets,
+		ServiceSpecificUnits: u.ServiceSpecificUnits + v.ServiceSpecificUnits,
+	}
+}
+
+// sub returns u minus v, floored at zero.
+func (u ServiceUnit) sub(v ServiceUnit) ServiceUnit {
+	return ServiceUnit{
+		Time:                 uint32(sub64(uint64(u.Time), uint64(v.Time))),
+		TotalOctets:          sub64(u.TotalOctets, v.TotalOctets),
+		InputOctets:          sub64(u.InputOctets, v.InputOctets),
+		OutputOctets:         sub64(u.OutputOctets, v.OutputOctets),
+		ServiceSpecificUnits: sub64(u.ServiceSpecificUnits, v.ServiceSpecificUnits),
+	}
+}
+
+// min returns, for each unit type set in u, the lesser of u and v.
+func (u ServiceUnit) min(v ServiceUnit) ServiceUnit {
+	return ServiceUnit{
+		Time:                 uint32(min64(uint64(u.Time), uint64(v.Time))),
+		TotalOctets:          min64(u.TotalOctets, v.TotalOctets),
+		InputOctets:          min64(u.InputOctets, v.InputOctets),
+		OutputOctets:         min64(u.OutputOctets, v.OutputOctets),
+		ServiceSpecificUnits: min64(u.ServiceSpecificUnits, v.ServiceSpecificUnits),
+	}
+}
+
+// exhausted reports whether any unit type set in u has no units left in
+// the remaining balance v.
+func (u ServiceUnit) exhausted(v ServiceUnit) bool {
+	return (u.Time > 0 && v.Time == 0) ||
+		(u.TotalOctets > 0 && v.TotalOctets == 0) ||
+		(u.InputOctets > 0 && v.InputOctets == 0) ||
+		(u.OutputOctets > 0 && v.OutputOctets == 0) ||
+		(u.ServiceSpecificUnits > 0 && v.ServiceSpecificUnits == 0)
+}
+
+func sub64(a, b uint64) uint64 {
+	if b > a {
+		return 0
+	}
+	return a - b
+}
+
+func min64(a, b uint64) uint64 {
+	if b < a {
+		return b
+	}
+	return a
+}
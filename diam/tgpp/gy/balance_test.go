@@ -0,0 +1,77 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gy
+
+import "testing"
+
+func TestMemoryBalance_ReserveCommit(t *testing.T) {
+	b := NewMemoryBalance()
+	b.Set("alice", 1, ServiceUnit{TotalOctets: 1000})
+	got, final, err := b.Reserve("alice", 1, ServiceUnit{TotalOctets: 400})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if final || got.TotalOctets != 400 {
+		t.Fatalf("Unexpected grant: %+v final=%t", got, final)
+	}
+	if err = b.Commit("alice", 1, got, ServiceUnit{TotalOctets: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := b.Get("alice", 1); u.TotalOctets != 900 {
+		t.Fatalf("Unexpected balance. Want 900, have %d", u.TotalOctets)
+	}
+}
+
+func TestMemoryBalance_Final(t *testing.T) {
+	b := NewMemoryBalance()
+	b.Set("alice", 0, ServiceUnit{Time: 30})
+	got, final, err := b.Reserve("alice", 7, ServiceUnit{Time: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !final || got.Time != 30 {
+		t.Fatalf("Unexpected grant: %+v final=%t", got, final)
+	}
+	if _, _, err = b.Reserve("alice", 7, ServiceUnit{Time: 60}); err != ErrCreditLimitReached {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrCreditLimitReached, err)
+	}
+
+	// A reservation that uses up the balance exactly is final too.
+	b.Set("alice", 0, ServiceUnit{Time: 60, TotalOctets: 10})
+	got, final, err = b.Reserve("alice", 7, ServiceUnit{Time: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !final || got.Time != 60 {
+		t.Fatalf("Unexpected grant: %+v final=%t", got, final)
+	}
+}
+
+func TestMemoryBalance_UnknownSubscriber(t *testing.T) {
+	b := NewMemoryBalance()
+	if _, _, err := b.Reserve("bob", 1, ServiceUnit{Time: 1}); err != ErrUnknownSubscriber {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrUnknownSubscriber, err)
+	}
+}
+
+func TestFixedRater(t *testing.T) {
+	r := &FixedRater{
+		Quota:        ServiceUnit{Time: 60},
+		RatingGroup:  map[uint32]ServiceUnit{2: {TotalOctets: 10}},
+		ValidityTime: 300,
+	}
+	rate, _ := r.Rate(&RateRequest{RatingGroup: 1})
+	if rate.Units.Time != 60 || rate.ValidityTime != 300 {
+		t.Fatalf("Unexpected rate: %+v", rate)
+	}
+	rate, _ = r.Rate(&RateRequest{RatingGroup: 2})
+	if rate.Units.TotalOctets != 10 {
+		t.Fatalf("Unexpected rate: %+v", rate)
+	}
+	rate, _ = r.Rate(&RateRequest{RatingGroup: 2, Requested: ServiceUnit{Time: 5}})
+	if rate.Units.Time != 5 {
+		t.Fatalf("Unexpected rate: %+v", rate)
+	}
+}
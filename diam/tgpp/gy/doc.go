@@ -0,0 +1,26 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package gy provides an online charging server (OCS) framework for the
+// 3GPP Gy/Ro interfaces, built on the Credit-Control application (RFC 4006).
+//
+// The Server parses Multiple-Services-Credit-Control blocks from incoming
+// Credit-Control-Request messages and asks a pluggable Rater and Balance
+// to grant or deny units. Reservations are tracked per session and rating
+// group, and are settled when usage is reported or the session terminates.
+// The client of each active session is remembered, so the Server can send
+// it a Re-Auth-Request with ReAuth.
+//
+// MemoryBalance is an in-memory Balance that makes the Server usable as a
+// test OCS:
+//
+//	ocs := &gy.Server{
+//		OriginHost:  "ocs.example.com",
+//		OriginRealm: "example.com",
+//		Rater:       &gy.FixedRater{Quota: gy.ServiceUnit{TotalOctets: 1 << 20}},
+//		Balance:     balance,
+//	}
+//	mux := sm.New(settings)
+//	mux.HandleIdx(gy.CCRIndex, ocs)
+package gy
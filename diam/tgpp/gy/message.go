@@ -0,0 +1,239 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gy
+
+import (
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// CCRIndex is the command index of Credit-Control-Request messages in the
+// Credit-Control application, for use with ServeMux.HandleIdx.
+var CCRIndex = diam.CommandIndex{
+	AppID:   diam.CHARGING_CONTROL_APP_ID,
+	Code:    diam.CreditControl,
+	Request: true,
+}
+
+// RAAIndex is the command index of Re-Auth-Answer messages in the
+// Credit-Control application, for use with ServeMux.HandleIdx.
+var RAAIndex = diam.CommandIndex{
+	AppID:   diam.CHARGING_CONTROL_APP_ID,
+	Code:    diam.ReAuth,
+	Request: false,
+}
+
+// Result codes of the Credit-Control application. See RFC 4006 section 9.
+const (
+	EndUserServiceDenied       = 4010
+	CreditControlNotApplicable = 4011
+	CreditLimitReached         = 4012
+	UserUnknown                = 5030
+	RatingFailed               = 5031
+)
+
+// RequestType is the value of the CC-Request-Type AVP.
+type RequestType int32
+
+// CC-Request-Type values. See RFC 4006 section 8.3.
+const (
+	InitialRequest     RequestType = 1
+	UpdateRequest      RequestType = 2
+	TerminationRequest RequestType = 3
+	EventRequest       RequestType = 4
+)
+
+// String returns the name of the request type as it appears in the
+// dictionary.
+func (t RequestType) String() string {
+	switch t {
+	case InitialRequest:
+		return "INITIAL_REQUEST"
+	case UpdateRequest:
+		return "UPDATE_REQUEST"
+	case TerminationRequest:
+		return "TERMINATION_REQUEST"
+	case EventRequest:
+		return "EVENT_REQUEST"
+	}
+	return "UNKNOWN"
+}
+
+// Re-Auth-Request-Type values. See RFC 6733 section 8.12.
+const (
+	AuthorizeOnly         = 0
+	AuthorizeAuthenticate = 1
+)
+
+// Requested-Action values. See RFC 4006 section 8.41.
+const (
+	DirectDebiting = 0
+	RefundAccount  = 1
+	CheckBalance   = 2
+	PriceEnquiry   = 3
+)
+
+// Final-Unit-Action values. See RFC 4006 section 8.35.
+const (
+	Terminate      = 0
+	Redirect       = 1
+	RestrictAccess = 2
+)
+
+// Credit-Control-Failure-Handling values. See RFC 4006 section 8.14.
+const (
+	FailureHandlingTerminate         = 0
+	FailureHandlingContinue          = 1
+	FailureHandlingRetryAndTerminate = 2
+)
+
+// Subscription-Id-Type values. See RFC 4006 section 8.47.
+const (
+	EndUserE164    = 0
+	EndUserIMSI    = 1
+	EndUserSIPURI  = 2
+	EndUserNAI     = 3
+	EndUserPrivate = 4
+)
+
+// ServiceUnit is the content of the Requested-Service-Unit,
+// Granted-Service-Unit and Used-Service-Unit AVPs.
+//
+// Only unit types are supported; CC-Money is not.
+type ServiceUnit struct {
+	Time                 uint32 `avp:"CC-Time,omitempty"`
+	TotalOctets          uint64 `avp:"CC-Total-Octets,omitempty"`
+	InputOctets          uint64 `avp:"CC-Input-Octets,omitempty"`
+	OutputOctets         uint64 `avp:"CC-Output-Octets,omitempty"`
+	ServiceSpecificUnits uint64 `avp:"CC-Service-Specific-Units,omitempty"`
+}
+
+// IsZero reports whether no units are set.
+func (u ServiceUnit) IsZero() bool {
+	return u == ServiceUnit{}
+}
+
+// RedirectServer is the Redirect-Server grouped AVP.
+type RedirectServer struct {
+	RedirectAddressType   int32  `avp:"Redirect-Address-Type"`
+	RedirectServerAddress string `avp:"Redirect-Server-Address"`
+}
+
+// FinalUnitIndication is the Final-Unit-Indication grouped AVP.
+type FinalUnitIndication struct {
+	FinalUnitAction       int32                   `avp:"Final-Unit-Action"`
+	RestrictionFilterRule []datatype.IPFilterRule `avp:"Restriction-Filter-Rule"`
+	FilterID              []string                `avp:"Filter-Id"`
+	RedirectServer        *RedirectServer         `avp:"Redirect-Server"`
+}
+
+// MSCC is the Multiple-Services-Credit-Control grouped AVP.
+type MSCC struct {
+	GrantedServiceUnit   *ServiceUnit         `avp:"Granted-Service-Unit"`
+	RequestedServiceUnit *ServiceUnit         `avp:"Requested-Service-Unit"`
+	UsedServiceUnit      []ServiceUnit        `avp:"Used-Service-Unit"`
+	ServiceIdentifier    []uint32             `avp:"Service-Identifier"`
+	RatingGroup          *uint32              `avp:"Rating-Group"`
+	ValidityTime         uint32               `avp:"Validity-Time,omitempty"`
+	ResultCode           uint32               `avp:"Result-Code,omitempty"`
+	FinalUnitIndication  *FinalUnitIndication `avp:"Final-Unit-Indication"`
+}
+
+// Used returns the sum of all Used-Service-Unit AVPs in the MSCC.
+func (m *MSCC) Used() ServiceUnit {
+	var u ServiceUnit
+	for _, v := range m.UsedServiceUnit {
+		u = u.add(v)
+	}
+	return u
+}
+
+// SubscriptionID is the Subscription-Id grouped AVP.
+type SubscriptionID struct {
+	Type int32  `avp:"Subscription-Id-Type"`
+	Data string `avp:"Subscription-Id-Data"`
+}
+
+// UserEquipmentInfo is the User-Equipment-Info grouped AVP.
+type UserEquipmentInfo struct {
+	Type  int32                `avp:"User-Equipment-Info-Type"`
+	Value datatype.OctetString `avp:"User-Equipment-Info-Value"`
+}
+
+// CCR is a Credit-Control-Request message.
+// See RFC 4006 section 3.1 and 3GPP TS 32.299 section 6.4.2.
+type CCR struct {
+	SessionID                 string                    `avp:"Session-Id"`
+	OriginHost                datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm               datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm          datatype.DiameterIdentity `avp:"Destination-Realm"`
+	AuthApplicationID         uint32                    `avp:"Auth-Application-Id"`
+	ServiceContextID          string                    `avp:"Service-Context-Id"`
+	CCRequestType             RequestType               `avp:"CC-Request-Type"`
+	CCRequestNumber           uint32                    `avp:"CC-Request-Number"`
+	DestinationHost           datatype.DiameterIdentity `avp:"Destination-Host,omitempty"`
+	UserName                  string                    `avp:"User-Name,omitempty"`
+	OriginStateID             uint32                    `avp:"Origin-State-Id,omitempty"`
+	EventTimestamp            *time.Time                `avp:"Event-Timestamp"`
+	SubscriptionID            []SubscriptionID          `avp:"Subscription-Id"`
+	TerminationCause          int32                     `avp:"Termination-Cause,omitempty"`
+	RequestedAction           *int32                    `avp:"Requested-Action"`
+	MultipleServicesIndicator *int32                    `avp:"Multiple-Services-Indicator"`
+	MSCC                      []MSCC                    `avp:"Multiple-Services-Credit-Control"`
+	UserEquipmentInfo         *UserEquipmentInfo        `avp:"User-Equipment-Info"`
+}
+
+// Subscriber returns the identity used to look up the subscriber's
+// balance: the first Subscription-Id-Data in the request, or the
+// User-Name when no Subscription-Id is present.
+func (ccr *CCR) Subscriber() string {
+	for _, id := range ccr.SubscriptionID {
+		if len(id.Data) > 0 {
+			return id.Data
+		}
+	}
+	return ccr.UserName
+}
+
+// CCA is a Credit-Control-Answer message.
+// See RFC 4006 section 3.2 and 3GPP TS 32.299 section 6.4.3.
+type CCA struct {
+	SessionID                    string                    `avp:"Session-Id"`
+	ResultCode                   uint32                    `avp:"Result-Code"`
+	OriginHost                   datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm                  datatype.DiameterIdentity `avp:"Origin-Realm"`
+	AuthApplicationID            uint32                    `avp:"Auth-Application-Id"`
+	CCRequestType                RequestType               `avp:"CC-Request-Type"`
+	CCRequestNumber              uint32                    `avp:"CC-Request-Number"`
+	OriginStateID                uint32                    `avp:"Origin-State-Id,omitempty"`
+	CreditControlFailureHandling *int32                    `avp:"Credit-Control-Failure-Handling"`
+	MSCC                         []MSCC                    `avp:"Multiple-Services-Credit-Control"`
+}
+
+// RAR is a Re-Auth-Request message sent by the OCS to make the client
+// reauthorize the credit of a session, or of one of its rating groups
+// or services. See RFC 4006 section 5.5.
+type RAR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	ReAuthRequestType int32                     `avp:"Re-Auth-Request-Type"`
+	ServiceIdentifier *uint32                   `avp:"Service-Identifier"`
+	RatingGroup       *uint32                   `avp:"Rating-Group"`
+}
+
+// RAA is a Re-Auth-Answer message.
+type RAA struct {
+	SessionID    string                    `avp:"Session-Id"`
+	ResultCode   uint32                    `avp:"Result-Code"`
+	OriginHost   datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm  datatype.DiameterIdentity `avp:"Origin-Realm"`
+	ErrorMessage string                    `avp:"Error-Message,omitempty"`
+}
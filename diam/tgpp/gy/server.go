@@ -0,0 +1,384 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+)
+
+// ErrUnknownSession is returned by Server.ReAuth when the session is not
+// active.
+var ErrUnknownSession = errors.New("gy: unknown session")
+
+// DefaultTimeout is how long the Server waits for answers when no
+// Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+// Reservation is a set of units reserved for a session and rating group.
+type Reservation struct {
+	Subscriber  string
+	RatingGroup uint32
+	Units       ServiceUnit
+}
+
+// Server is an online charging server for Credit-Control-Request messages.
+// It implements the diam.Handler interface.
+//
+// Requests with CC-Request-Type INITIAL_REQUEST and UPDATE_REQUEST settle
+// the Used-Service-Unit of each MSCC against the outstanding reservation
+// and reserve new units when a Requested-Service-Unit is present.
+// TERMINATION_REQUEST settles the reported usage and releases every
+// reservation left for the session. EVENT_REQUEST with Requested-Action
+// DIRECT_DEBITING reserves and debits the requested units at once.
+//
+// The Server keeps track of the client of each active session, until its
+// connection is closed, so that it can ask for reauthorization with ReAuth. To use ReAuth, the Server
+// must also be registered for RAAIndex.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Rater       Rater   // Decides how many units to grant
+	Balance     Balance // Holds subscriber credit
+
+	// FinalUnitAction is sent in the Final-Unit-Indication of grants
+	// that exhaust the subscriber's balance. Defaults to TERMINATE.
+	FinalUnitAction int32
+
+	// CreditControlFailureHandling, if non-nil, is sent in every CCA
+	// to tell the client what to do when the Server can't be reached.
+	CreditControlFailureHandling *int32
+
+	Timeout time.Duration // Defaults to DefaultTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	// It is typically the sm.StateMachine the Server is registered with.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	sessions map[string]map[uint32]*Reservation // Session-Id → Rating-Group
+	clients  map[string]*client                 // Session-Id
+	watched  map[diam.Conn]bool                 // Connections of clients
+}
+
+type client struct {
+	conn diam.Conn
+	ccr  *CCR // First CCR of the session on conn
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	a, ccr := s.answerCCR(m)
+	if ccr != nil {
+		s.mu.Lock()
+		if s.clients == nil {
+			s.clients = make(map[string]*client)
+		}
+		if _, ok := s.clients[ccr.SessionID]; !ok {
+			s.clients[ccr.SessionID] = &client{conn: c, ccr: ccr}
+			s.watch(c)
+		}
+		s.mu.Unlock()
+	}
+	if _, err := a.WriteTo(c); err != nil && s.ErrorReporter != nil {
+		s.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write CCA: %v", err),
+		})
+	}
+}
+
+// watch forgets the clients of the connection c when it is closed.
+// It must be called with s.mu held.
+func (s *Server) watch(c diam.Conn) {
+	cn, ok := c.(diam.CloseNotifier)
+	if !ok || s.watched[c] {
+		return
+	}
+	if s.watched == nil {
+		s.watched = make(map[diam.Conn]bool)
+	}
+	s.watched[c] = true
+	go func() {
+		<-cn.CloseNotify()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.watched, c)
+		for id, cl := range s.clients {
+			if cl.conn == c {
+				delete(s.clients, id)
+			}
+		}
+	}()
+}
+
+// Answer processes the Credit-Control-Request m and returns its answer.
+func (s *Server) Answer(m *diam.Message) *diam.Message {
+	a, _ := s.answerCCR(m)
+	return a
+}
+
+// answerCCR returns the answer to the Credit-Control-Request m, and the
+// request when its session remains active.
+func (s *Server) answerCCR(m *diam.Message) (*diam.Message, *CCR) {
+	var ccr CCR
+	cca := &CCA{
+		OriginHost:                   s.OriginHost,
+		OriginRealm:                  s.OriginRealm,
+		CreditControlFailureHandling: s.CreditControlFailureHandling,
+	}
+	if err := m.Unmarshal(&ccr); err != nil {
+		cca.ResultCode = diam.UnableToComply
+		return s.answer(m, cca), nil
+	}
+	cca.SessionID = ccr.SessionID
+	cca.AuthApplicationID = ccr.AuthApplicationID
+	cca.CCRequestType = ccr.CCRequestType
+	cca.CCRequestNumber = ccr.CCRequestNumber
+	cca.ResultCode = diam.Success
+	if len(ccr.SessionID) == 0 {
+		cca.ResultCode = diam.MissingAVP
+		return s.answer(m, cca), nil
+	}
+	sub := ccr.Subscriber()
+	for i := range ccr.MSCC {
+		mscc, err := s.creditControl(&ccr, sub, &ccr.MSCC[i])
+		if err == ErrUnknownSubscriber {
+			cca.ResultCode = UserUnknown
+			cca.MSCC = nil
+			break
+		}
+		cca.MSCC = append(cca.MSCC, *mscc)
+	}
+	if ccr.CCRequestType == TerminationRequest || ccr.CCRequestType == EventRequest || cca.ResultCode == UserUnknown {
+		s.ReleaseSession(ccr.SessionID)
+		return s.answer(m, cca), nil
+	}
+	return s.answer(m, cca), &ccr
+}
+
+func (s *Server) answer(m *diam.Message, cca *CCA) *diam.Message {
+	a := m.Answer(0)
+	if err := a.Marshal(cca); err != nil {
+		a = m.Answer(diam.UnableToComply)
+	}
+	return a
+}
+
+// creditControl handles a single MSCC from the request and returns the
+// MSCC for the answer. Errors of the Balance other than
+// ErrUnknownSubscriber are answered with DIAMETER_UNABLE_TO_COMPLY in the
+// MSCC.
+func (s *Server) creditControl(ccr *CCR, sub string, req *MSCC) (*MSCC, error) {
+	var rg uint32
+	if req.RatingGroup != nil {
+		rg = *req.RatingGroup
+	}
+	ans := &MSCC{
+		RatingGroup:       req.RatingGroup,
+		ServiceIdentifier: req.ServiceIdentifier,
+		ResultCode:        diam.Success,
+	}
+	if err := s.settle(ccr.SessionID, rg, req.Used()); err == ErrUnknownSubscriber {
+		return nil, err
+	} else if err != nil {
+		ans.ResultCode = diam.UnableToComply
+		return ans, nil
+	}
+	switch ccr.CCRequestType {
+	case TerminationRequest:
+		return ans, nil
+	case EventRequest:
+		if ccr.RequestedAction != nil && *ccr.RequestedAction != DirectDebiting {
+			ans.ResultCode = CreditControlNotApplicable
+			return ans, nil
+		}
+	}
+	if req.RequestedServiceUnit == nil {
+		return ans, nil
+	}
+	rr := &RateRequest{
+		SessionID:        ccr.SessionID,
+		Subscriber:       sub,
+		ServiceContextID: ccr.ServiceContextID,
+		RatingGroup:      rg,
+		Requested:        *req.RequestedServiceUnit,
+	}
+	if len(req.ServiceIdentifier) > 0 {
+		rr.ServiceIdentifier = req.ServiceIdentifier[0]
+	}
+	rate, err := s.Rater.Rate(rr)
+	if err != nil {
+		ans.ResultCode = RatingFailed
+		return ans, nil
+	}
+	granted, final, err := s.Balance.Reserve(sub, rg, rate.Units)
+	switch err {
+	case nil:
+	case ErrCreditLimitReached:
+		ans.ResultCode = CreditLimitReached
+		return ans, nil
+	case ErrUnknownSubscriber:
+		return nil, err
+	default:
+		ans.ResultCode = diam.UnableToComply
+		return ans, nil
+	}
+	if ccr.CCRequestType == EventRequest {
+		if err = s.Balance.Commit(sub, rg, granted, granted); err == ErrUnknownSubscriber {
+			return nil, err
+		} else if err != nil {
+			ans.ResultCode = diam.UnableToComply
+			return ans, nil
+		}
+	} else {
+		s.reserve(ccr.SessionID, &Reservation{
+			Subscriber:  sub,
+			RatingGroup: rg,
+			Units:       granted,
+		})
+	}
+	ans.GrantedServiceUnit = &granted
+	ans.ValidityTime = rate.ValidityTime
+	if final {
+		ans.FinalUnitIndication = &FinalUnitIndication{
+			FinalUnitAction: s.FinalUnitAction,
+		}
+	}
+	return ans, nil
+}
+
+// settle commits the used units against the outstanding reservation of
+// the session and rating group, and removes the reservation.
+func (s *Server) settle(sessionID string, rg uint32, used ServiceUnit) error {
+	s.mu.Lock()
+	r, ok := s.sessions[sessionID][rg]
+	if ok {
+		delete(s.sessions[sessionID], rg)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return s.Balance.Commit(r.Subscriber, rg, r.Units, used)
+}
+
+func (s *Server) reserve(sessionID string, r *Reservation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sessions == nil {
+		s.sessions = make(map[string]map[uint32]*Reservation)
+	}
+	rgs, ok := s.sessions[sessionID]
+	if !ok {
+		rgs = make(map[uint32]*Reservation)
+		s.sessions[sessionID] = rgs
+	}
+	rgs[r.RatingGroup] = r
+}
+
+// Reservations returns the outstanding reservations of a session.
+func (s *Server) Reservations(sessionID string) []Reservation {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var rs []Reservation
+	for _, r := range s.sessions[sessionID] {
+		rs = append(rs, *r)
+	}
+	return rs
+}
+
+// ReleaseSession returns all units reserved for the session to the
+// balance and forgets the session. It is called on TERMINATION_REQUEST,
+// and may be used to clean up sessions that were never terminated.
+func (s *Server) ReleaseSession(sessionID string) {
+	s.mu.Lock()
+	rgs := s.sessions[sessionID]
+	delete(s.sessions, sessionID)
+	delete(s.clients, sessionID)
+	s.mu.Unlock()
+	for rg, r := range rgs {
+		s.Balance.Commit(r.Subscriber, rg, r.Units, ServiceUnit{})
+	}
+}
+
+// Sessions returns the Session-Id of all active sessions, sorted.
+func (s *Server) Sessions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.clients))
+	for id := range s.clients {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Session returns the first CCR of an active session.
+func (s *Server) Session(sessionID string) (*CCR, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.clients[sessionID]
+	if !ok {
+		return nil, false
+	}
+	return c.ccr, true
+}
+
+// ReAuth sends a RAR to the client of an active session and waits for
+// its answer. Session-Id, routing AVPs and Auth-Application-Id are filled
+// in by the Server; rar may be nil to reauthorize the whole session.
+//
+// The session is released when the client answers with
+// DIAMETER_UNKNOWN_SESSION_ID.
+func (s *Server) ReAuth(sessionID string, rar *RAR) (*RAA, error) {
+	s.mu.Lock()
+	c, ok := s.clients[sessionID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	// Fill in a copy, leaving the caller's RAR untouched.
+	var r RAR
+	if rar != nil {
+		r = *rar
+	}
+	rar = &r
+	rar.SessionID = sessionID
+	rar.OriginHost = s.OriginHost
+	rar.OriginRealm = s.OriginRealm
+	rar.DestinationHost = c.ccr.OriginHost
+	rar.DestinationRealm = c.ccr.OriginRealm
+	rar.AuthApplicationID = diam.CHARGING_CONTROL_APP_ID
+	m := diam.NewRequest(diam.ReAuth, diam.CHARGING_CONTROL_APP_ID, c.conn.Dictionary())
+	if err := m.Marshal(rar); err != nil {
+		return nil, err
+	}
+	a, err := s.pending.Exchange(c.conn, m, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	var raa RAA
+	if err = a.Unmarshal(&raa); err != nil {
+		return nil, err
+	}
+	if raa.ResultCode == diam.UnknownSessionID {
+		s.ReleaseSession(sessionID)
+	}
+	return &raa, nil
+}
@@ -0,0 +1,366 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package gy
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+)
+
+func newTestServer() (*Server, *MemoryBalance) {
+	b := NewMemoryBalance()
+	b.Set("001010000000001", 0, ServiceUnit{TotalOctets: 1500})
+	return &Server{
+		OriginHost:  "ocs",
+		OriginRealm: "test",
+		Rater:       &FixedRater{Quota: ServiceUnit{TotalOctets: 1000}, ValidityTime: 60},
+		Balance:     b,
+	}, b
+}
+
+func newCCR(typ RequestType, n uint32, mscc ...MSCC) *diam.Message {
+	m := diam.NewRequest(diam.CreditControl, diam.CHARGING_CONTROL_APP_ID, dict.Default)
+	m.Marshal(&CCR{
+		SessionID:         "pgw;1",
+		OriginHost:        "pgw",
+		OriginRealm:       "test",
+		DestinationRealm:  "test",
+		AuthApplicationID: diam.CHARGING_CONTROL_APP_ID,
+		ServiceContextID:  "32251@3gpp.org",
+		CCRequestType:     typ,
+		CCRequestNumber:   n,
+		SubscriptionID:    []SubscriptionID{{Type: EndUserIMSI, Data: "001010000000001"}},
+		MSCC:              mscc,
+	})
+	return m
+}
+
+func rg(n uint32) *uint32 { return &n }
+
+func answerOf(t *testing.T, m *diam.Message) *CCA {
+	t.Helper()
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var cca CCA
+	if err = dec.Unmarshal(&cca); err != nil {
+		t.Fatal(err)
+	}
+	return &cca
+}
+
+func TestServer_Lifecycle(t *testing.T) {
+	s, b := newTestServer()
+
+	cca := answerOf(t, s.Answer(newCCR(InitialRequest, 0, MSCC{
+		RatingGroup:          rg(10),
+		RequestedServiceUnit: &ServiceUnit{},
+	})))
+	if cca.ResultCode != diam.Success || len(cca.MSCC) != 1 {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	gsu := cca.MSCC[0].GrantedServiceUnit
+	if gsu == nil || gsu.TotalOctets != 1000 {
+		t.Fatalf("Unexpected GSU: %+v", gsu)
+	}
+	if cca.MSCC[0].ValidityTime != 60 || cca.MSCC[0].FinalUnitIndication != nil {
+		t.Fatalf("Unexpected MSCC: %+v", cca.MSCC[0])
+	}
+	if rs := s.Reservations("pgw;1"); len(rs) != 1 || rs[0].Units.TotalOctets != 1000 {
+		t.Fatalf("Unexpected reservations: %+v", rs)
+	}
+
+	// Report 800 octets and ask for more: only 700 are left.
+	cca = answerOf(t, s.Answer(newCCR(UpdateRequest, 1, MSCC{
+		RatingGroup:          rg(10),
+		UsedServiceUnit:      []ServiceUnit{{TotalOctets: 800}},
+		RequestedServiceUnit: &ServiceUnit{},
+	})))
+	mscc := cca.MSCC[0]
+	if mscc.GrantedServiceUnit.TotalOctets != 700 {
+		t.Fatalf("Unexpected GSU: %+v", mscc.GrantedServiceUnit)
+	}
+	if mscc.FinalUnitIndication == nil || mscc.FinalUnitIndication.FinalUnitAction != Terminate {
+		t.Fatalf("Missing Final-Unit-Indication: %+v", mscc)
+	}
+
+	cca = answerOf(t, s.Answer(newCCR(UpdateRequest, 2, MSCC{
+		RatingGroup:          rg(10),
+		UsedServiceUnit:      []ServiceUnit{{TotalOctets: 700}},
+		RequestedServiceUnit: &ServiceUnit{},
+	})))
+	if cca.MSCC[0].ResultCode != CreditLimitReached {
+		t.Fatalf("Unexpected MSCC Result-Code: %d", cca.MSCC[0].ResultCode)
+	}
+
+	cca = answerOf(t, s.Answer(newCCR(TerminationRequest, 3, MSCC{RatingGroup: rg(10)})))
+	if cca.ResultCode != diam.Success || cca.CCRequestType != TerminationRequest {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if rs := s.Reservations("pgw;1"); len(rs) != 0 {
+		t.Fatalf("Unexpected reservations: %+v", rs)
+	}
+	if u, _ := b.Get("001010000000001", 10); u.TotalOctets != 0 {
+		t.Fatalf("Unexpected balance: %+v", u)
+	}
+}
+
+func TestServer_ReleaseOnTermination(t *testing.T) {
+	s, b := newTestServer()
+	s.Answer(newCCR(InitialRequest, 0, MSCC{
+		RatingGroup:          rg(1),
+		RequestedServiceUnit: &ServiceUnit{},
+	}))
+	s.Answer(newCCR(TerminationRequest, 1, MSCC{
+		RatingGroup:     rg(1),
+		UsedServiceUnit: []ServiceUnit{{TotalOctets: 100}},
+	}))
+	if u, _ := b.Get("001010000000001", 1); u.TotalOctets != 1400 {
+		t.Fatalf("Unexpected balance. Want 1400, have %d", u.TotalOctets)
+	}
+}
+
+func TestServer_UnknownUser(t *testing.T) {
+	s, _ := newTestServer()
+	s.Balance = NewMemoryBalance()
+	cca := answerOf(t, s.Answer(newCCR(InitialRequest, 0, MSCC{
+		RatingGroup:          rg(1),
+		RequestedServiceUnit: &ServiceUnit{},
+	})))
+	if cca.ResultCode != UserUnknown {
+		t.Fatalf("Unexpected Result-Code. Want %d, have %d", UserUnknown, cca.ResultCode)
+	}
+}
+
+func TestServer_Event(t *testing.T) {
+	s, b := newTestServer()
+	cca := answerOf(t, s.Answer(newCCR(EventRequest, 0, MSCC{
+		RatingGroup:          rg(1),
+		RequestedServiceUnit: &ServiceUnit{TotalOctets: 500},
+	})))
+	if cca.MSCC[0].GrantedServiceUnit.TotalOctets != 500 {
+		t.Fatalf("Unexpected MSCC: %+v", cca.MSCC[0])
+	}
+	if u, _ := b.Get("001010000000001", 1); u.TotalOctets != 1000 {
+		t.Fatalf("Unexpected balance. Want 1000, have %d", u.TotalOctets)
+	}
+	if rs := s.Reservations("pgw;1"); len(rs) != 0 {
+		t.Fatalf("Unexpected reservations: %+v", rs)
+	}
+}
+
+// failingBalance is a Balance whose storage is unavailable for commits.
+type failingBalance struct {
+	*MemoryBalance
+}
+
+func (b failingBalance) Commit(subscriber string, ratingGroup uint32, reserved, used ServiceUnit) error {
+	return errors.New("db down")
+}
+
+func TestServer_BalanceError(t *testing.T) {
+	s, b := newTestServer()
+	s.Balance = failingBalance{b}
+	cca := answerOf(t, s.Answer(newCCR(EventRequest, 0, MSCC{
+		RatingGroup:          rg(1),
+		RequestedServiceUnit: &ServiceUnit{TotalOctets: 500},
+	})))
+	if cca.ResultCode != diam.Success || len(cca.MSCC) != 1 || cca.MSCC[0].ResultCode != diam.UnableToComply {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+	if cca.MSCC[0].GrantedServiceUnit != nil {
+		t.Fatalf("Unexpected GSU: %+v", cca.MSCC[0].GrantedServiceUnit)
+	}
+
+	s.Answer(newCCR(InitialRequest, 0, MSCC{
+		RatingGroup:          rg(2),
+		RequestedServiceUnit: &ServiceUnit{},
+	}))
+	cca = answerOf(t, s.Answer(newCCR(UpdateRequest, 1, MSCC{
+		RatingGroup:     rg(2),
+		UsedServiceUnit: []ServiceUnit{{TotalOctets: 100}},
+	})))
+	if len(cca.MSCC) != 1 || cca.MSCC[0].ResultCode != diam.UnableToComply {
+		t.Fatalf("Unexpected CCA: %+v", cca)
+	}
+}
+
+func TestServer_Network(t *testing.T) {
+	s, _ := newTestServer()
+	settings := &sm.Settings{
+		OriginHost:       "ocs",
+		OriginRealm:      "test",
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+	}
+	mux := sm.New(settings)
+	mux.HandleIdx(CCRIndex, s)
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	cmux := sm.New(&sm.Settings{
+		OriginHost:       "pgw",
+		OriginRealm:      "test",
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	})
+	done := make(chan *diam.Message, 1)
+	cmux.HandleFunc("CCA", func(c diam.Conn, m *diam.Message) { done <- m })
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.CHARGING_CONTROL_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	m := newCCR(InitialRequest, 0, MSCC{RatingGroup: rg(1), RequestedServiceUnit: &ServiceUnit{}})
+	if _, err = m.WriteTo(c); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case a := <-done:
+		var cca CCA
+		if err = a.Unmarshal(&cca); err != nil {
+			t.Fatal(err)
+		}
+		if cca.ResultCode != diam.Success || cca.SessionID != "pgw;1" {
+			t.Fatalf("Unexpected CCA: %+v", cca)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for CCA")
+	}
+}
+
+func TestServer_ReAuth(t *testing.T) {
+	s, _ := newTestServer()
+	s.Timeout = time.Second
+	mux := sm.New(&sm.Settings{
+		OriginHost:       "ocs",
+		OriginRealm:      "test",
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+	})
+	mux.HandleIdx(CCRIndex, s)
+	mux.HandleIdx(RAAIndex, s)
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	cmux := sm.New(&sm.Settings{
+		OriginHost:       "pgw",
+		OriginRealm:      "test",
+		VendorID:         13,
+		ProductName:      "go-diameter",
+		FirmwareRevision: 1,
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	})
+	ccas := make(chan *diam.Message, 1)
+	rars := make(chan *RAR, 1)
+	cmux.HandleFunc("CCA", func(c diam.Conn, m *diam.Message) { ccas <- m })
+	rarIdx := diam.CommandIndex{AppID: diam.CHARGING_CONTROL_APP_ID, Code: diam.ReAuth, Request: true}
+	cmux.HandleIdx(rarIdx, diam.HandlerFunc(func(c diam.Conn, m *diam.Message) {
+		var rar RAR
+		if err := m.Unmarshal(&rar); err != nil {
+			t.Error(err)
+		}
+		rars <- &rar
+		a := m.Answer(diam.Success)
+		a.Marshal(&RAA{SessionID: rar.SessionID, ResultCode: diam.Success, OriginHost: "pgw", OriginRealm: "test"})
+		a.WriteTo(c)
+	}))
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.CHARGING_CONTROL_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	if _, err = s.ReAuth("pgw;1", nil); err != ErrUnknownSession {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrUnknownSession, err)
+	}
+	if _, err = newCCR(InitialRequest, 0, MSCC{RatingGroup: rg(1), RequestedServiceUnit: &ServiceUnit{}}).WriteTo(c); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ccas:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for CCA")
+	}
+	if ids := s.Sessions(); len(ids) != 1 || ids[0] != "pgw;1" {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	req := &RAR{RatingGroup: rg(1)}
+	raa, err := s.ReAuth("pgw;1", req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raa.ResultCode != diam.Success {
+		t.Fatalf("Unexpected RAA: %+v", raa)
+	}
+	if req.SessionID != "" || req.DestinationHost != "" {
+		t.Fatalf("ReAuth modified the RAR: %+v", req)
+	}
+	if rar := <-rars; rar.DestinationHost != "pgw" || rar.RatingGroup == nil || *rar.RatingGroup != 1 {
+		t.Fatalf("Unexpected RAR: %+v", rar)
+	}
+
+	if _, err = newCCR(TerminationRequest, 1).WriteTo(c); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ccas:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for CCA")
+	}
+	if ids := s.Sessions(); len(ids) != 0 {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+
+	// Clients are forgotten when their connection is closed.
+	if _, err = newCCR(InitialRequest, 2, MSCC{RatingGroup: rg(1), RequestedServiceUnit: &ServiceUnit{}}).WriteTo(c); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-ccas:
+	case <-time.After(time.Second):
+		t.Fatal("Timeout waiting for CCA")
+	}
+	if ids := s.Sessions(); len(ids) != 1 {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	c.Close()
+	for deadline := time.Now().Add(time.Second); len(s.Sessions()) > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Unexpected sessions after close: %v", s.Sessions())
+		}
+	}
+}
@@ -0,0 +1,317 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package rx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+)
+
+// ErrSessionClosed is returned by Session methods after the session
+// has been terminated.
+var ErrSessionClosed = errors.New("rx: session closed")
+
+// DefaultTimeout is how long the Client and Server wait for answers
+// when no Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+// Client is the AF side of Rx, such as a P-CSCF. It opens AF sessions
+// with Establish, keeps track of the media components authorized for
+// each of them, and handles the RAR and ASR messages sent by the PCRF.
+//
+// Client implements the diam.Handler interface and must be registered
+// for AAAIndex, STAIndex, RARIndex and ASRIndex on the connection's
+// handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// OnReAuth, if non-nil, is called for every RAR with the
+	// Specific-Action notifications of the session. It returns the
+	// Result-Code of the RAA; when nil, the Client answers with
+	// DIAMETER_SUCCESS. It runs on the connection's read goroutine
+	// and must not block.
+	OnReAuth func(s *Session, rar *RAR) uint32
+
+	// OnAbort, if non-nil, is called after the PCRF aborted a session
+	// with ASR and the Client terminated it with STR.
+	OnAbort func(s *Session, asr *ASR)
+
+	// ErrorReporter, if non-nil, receives errors writing answers and
+	// terminating aborted sessions.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// Session is an AF session established by the Client.
+type Session struct {
+	ID string
+
+	client *Client
+	conn   diam.Conn
+	reqMu  sync.Mutex // Serializes requests of the session.
+
+	mu     sync.Mutex
+	closed bool
+	media  map[uint32]MediaComponentDescription
+}
+
+// Establish sends an initial AAR built from aar over c and returns the
+// new session. Session-Id is generated when empty, and the routing AVPs,
+// Auth-Application-Id and Rx-Request-Type are filled in by the Client.
+//
+// If the PCRF rejects the session the returned Session is nil and the
+// reason is available in the AAA.
+func (cli *Client) Establish(c diam.Conn, aar *AAR) (*Session, *AAA, error) {
+	if aar == nil {
+		aar = &AAR{}
+	}
+	if len(aar.SessionID) == 0 {
+		aar.SessionID = sessionid.New(cli.OriginHost)
+	}
+	s := &Session{
+		ID:     aar.SessionID,
+		client: cli,
+		conn:   c,
+		media:  make(map[uint32]MediaComponentDescription),
+	}
+	cli.mu.Lock()
+	if cli.sessions == nil {
+		cli.sessions = make(map[string]*Session)
+	}
+	cli.sessions[s.ID] = s
+	cli.mu.Unlock()
+	aaa, err := s.aa(RxInitialRequest, aar)
+	if err != nil || aaa.ResultCode != diam.Success {
+		s.close()
+		return nil, aaa, err
+	}
+	return s, aaa, nil
+}
+
+// Session returns the active session with the given Session-Id, or nil.
+func (cli *Client) Session(id string) *Session {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	return cli.sessions[id]
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.ReAuth:
+		cli.reAuth(c, m)
+	case diam.AbortSession:
+		cli.abort(c, m)
+	}
+}
+
+func (cli *Client) reAuth(c diam.Conn, m *diam.Message) {
+	var rar RAR
+	raa := &RAA{
+		OriginHost:  cli.OriginHost,
+		OriginRealm: cli.OriginRealm,
+		ResultCode:  diam.Success,
+	}
+	if err := m.Unmarshal(&rar); err != nil {
+		raa.ResultCode = diam.UnableToComply
+	}
+	raa.SessionID = rar.SessionID
+	if raa.ResultCode == diam.Success {
+		if s := cli.Session(rar.SessionID); s == nil {
+			raa.ResultCode = diam.UnknownSessionID
+		} else if cli.OnReAuth != nil {
+			raa.ResultCode = cli.OnReAuth(s, &rar)
+		}
+	}
+	cli.answer(c, m, raa)
+}
+
+func (cli *Client) abort(c diam.Conn, m *diam.Message) {
+	var asr ASR
+	asa := &ASA{
+		OriginHost:  cli.OriginHost,
+		OriginRealm: cli.OriginRealm,
+		ResultCode:  diam.Success,
+	}
+	if err := m.Unmarshal(&asr); err != nil {
+		asa.ResultCode = diam.UnableToComply
+	}
+	asa.SessionID = asr.SessionID
+	s := cli.Session(asr.SessionID)
+	if s == nil && asa.ResultCode == diam.Success {
+		asa.ResultCode = diam.UnknownSessionID
+	}
+	cli.answer(c, m, asa)
+	if s == nil || asa.ResultCode != diam.Success {
+		return
+	}
+	// The STA arrives on this goroutine, so wait for it elsewhere.
+	go func() {
+		if _, err := s.Terminate(TerminationAdministrative); err != nil && cli.ErrorReporter != nil {
+			cli.ErrorReporter.Error(&diam.ErrorReport{
+				Conn:    c,
+				Message: m,
+				Error:   fmt.Errorf("failed to terminate aborted session: %v", err),
+			})
+		}
+		if cli.OnAbort != nil {
+			cli.OnAbort(s, &asr)
+		}
+	}()
+}
+
+func (cli *Client) answer(c diam.Conn, m *diam.Message, v interface{}) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && cli.ErrorReporter != nil {
+		cli.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
+
+// Modify sends an AAR built from aar to update the media of the session.
+// Media components are merged with the ones already provisioned, and
+// components with Flow-Status REMOVED are dropped.
+func (s *Session) Modify(aar *AAR) (*AAA, error) {
+	if aar == nil {
+		aar = &AAR{}
+	}
+	return s.aa(RxUpdateRequest, aar)
+}
+
+func (s *Session) aa(typ int32, aar *AAR) (*AAA, error) {
+	s.reqMu.Lock()
+	defer s.reqMu.Unlock()
+	if s.isClosed() {
+		return nil, ErrSessionClosed
+	}
+	cli := s.client
+	aar.SessionID = s.ID
+	aar.AuthApplicationID = diam.RX_APP_ID
+	aar.OriginHost = cli.OriginHost
+	aar.OriginRealm = cli.OriginRealm
+	aar.DestinationRealm = cli.DestinationRealm
+	aar.DestinationHost = cli.DestinationHost
+	aar.RxRequestType = &typ
+	var aaa AAA
+	if err := s.exchange(diam.AA, aar, &aaa); err != nil {
+		return nil, err
+	}
+	if aaa.ResultCode == diam.Success {
+		s.mu.Lock()
+		mergeMedia(s.media, aar.MediaComponentDescription)
+		s.mu.Unlock()
+	}
+	return &aaa, nil
+}
+
+// Terminate sends an STR with the given Termination-Cause and closes
+// the session, regardless of the answer.
+func (s *Session) Terminate(cause int32) (*STA, error) {
+	s.reqMu.Lock()
+	defer s.reqMu.Unlock()
+	if s.isClosed() {
+		return nil, ErrSessionClosed
+	}
+	defer s.close()
+	cli := s.client
+	str := &STR{
+		SessionID:         s.ID,
+		OriginHost:        cli.OriginHost,
+		OriginRealm:       cli.OriginRealm,
+		DestinationRealm:  cli.DestinationRealm,
+		DestinationHost:   cli.DestinationHost,
+		AuthApplicationID: diam.RX_APP_ID,
+		TerminationCause:  cause,
+	}
+	var sta STA
+	if err := s.exchange(diam.SessionTermination, str, &sta); err != nil {
+		return nil, err
+	}
+	return &sta, nil
+}
+
+// exchange sends the request req with the given command code and
+// decodes the answer into ans.
+func (s *Session) exchange(code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.RX_APP_ID, s.conn.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := s.client.pending.Exchange(s.conn, m, s.client.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
+
+func (s *Session) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	cli := s.client
+	cli.mu.Lock()
+	if cli.sessions[s.ID] == s {
+		delete(cli.sessions, s.ID)
+	}
+	cli.mu.Unlock()
+}
+
+func (s *Session) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Media returns the media components authorized for the session,
+// ordered by Media-Component-Number.
+func (s *Session) Media() []MediaComponentDescription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	media := make([]MediaComponentDescription, 0, len(s.media))
+	for _, m := range s.media {
+		media = append(media, m)
+	}
+	sort.Slice(media, func(i, j int) bool {
+		return media[i].MediaComponentNumber < media[j].MediaComponentNumber
+	})
+	return media
+}
+
+// mergeMedia updates the media components in m with mcd.
+func mergeMedia(m map[uint32]MediaComponentDescription, mcd []MediaComponentDescription) {
+	for _, c := range mcd {
+		if c.FlowStatus != nil && *c.FlowStatus == FlowRemoved {
+			delete(m, c.MediaComponentNumber)
+			continue
+		}
+		m[c.MediaComponentNumber] = c
+	}
+}
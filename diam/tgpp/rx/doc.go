@@ -0,0 +1,37 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package rx implements the Rx application between the AF and the PCRF,
+// as specified in 3GPP TS 29.214.
+//
+// It provides typed AAR/AAA, RAR/RAA, STR/STA and ASR/ASA messages for
+// use with Message.Marshal and Message.Unmarshal, an AF Client that
+// provisions the media of AF sessions and handles Specific-Action
+// notifications, and a PCRF Server skeleton.
+//
+// A P-CSCF provisioning a voice call:
+//
+//	af := &rx.Client{
+//		OriginHost:       "pcscf.example.com",
+//		OriginRealm:      "example.com",
+//		DestinationRealm: "example.com",
+//		OnReAuth: func(s *rx.Session, rar *rx.RAR) uint32 {
+//			log.Println(s.ID, rar.SpecificAction)
+//			return diam.Success
+//		},
+//	}
+//	mux := sm.New(settings)
+//	mux.HandleIdx(rx.AAAIndex, af)
+//	mux.HandleIdx(rx.STAIndex, af)
+//	mux.HandleIdx(rx.RARIndex, af)
+//	mux.HandleIdx(rx.ASRIndex, af)
+//	...
+//	s, aaa, err := af.Establish(conn, &rx.AAR{
+//		FramedIPAddress: ueIP,
+//		MediaComponentDescription: []rx.MediaComponentDescription{...},
+//		SpecificAction: []rx.SpecificAction{rx.IndicationOfLossOfBearer},
+//	})
+//	...
+//	s.Terminate(rx.TerminationLogout)
+package rx
@@ -0,0 +1,312 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package rx
+
+import (
+	"net"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// Command indexes of the Rx application, for use with ServeMux.HandleIdx.
+var (
+	AARIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.AA, Request: true}
+	AAAIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.AA, Request: false}
+	RARIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.ReAuth, Request: true}
+	RAAIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.ReAuth, Request: false}
+	STRIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.SessionTermination, Request: true}
+	STAIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.SessionTermination, Request: false}
+	ASRIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.AbortSession, Request: true}
+	ASAIndex = diam.CommandIndex{AppID: diam.RX_APP_ID, Code: diam.AbortSession, Request: false}
+)
+
+// Experimental-Result-Code values of the Rx application.
+// See 3GPP TS 29.214 section 5.5.3.
+const (
+	InvalidServiceInformation             = 5061
+	FilterRestrictions                    = 5062
+	RequestedServiceNotAuthorized         = 5063
+	DuplicatedAFSession                   = 5064
+	IPCANSessionNotAvailable              = 5065
+	UnauthorizedNonEmergencySession       = 5066
+	UnauthorizedSponsoredDataConnectivity = 5067
+	TemporaryNetworkFailure               = 5068
+)
+
+// SpecificAction is the value of the Specific-Action AVP.
+type SpecificAction int32
+
+// Specific-Action values. See 3GPP TS 29.214 section 5.3.13.
+const (
+	ChargingCorrelationExchange               SpecificAction = 1
+	IndicationOfLossOfBearer                  SpecificAction = 2
+	IndicationOfRecoveryOfBearer              SpecificAction = 3
+	IndicationOfReleaseOfBearer               SpecificAction = 4
+	IPCANChange                               SpecificAction = 6
+	IndicationOfOutOfCredit                   SpecificAction = 7
+	IndicationOfSuccessfulResourcesAllocation SpecificAction = 8
+	IndicationOfFailedResourcesAllocation     SpecificAction = 9
+	IndicationOfLimitedPCCDeployment          SpecificAction = 10
+	UsageReport                               SpecificAction = 11
+	AccessNetworkInfoReport                   SpecificAction = 12
+)
+
+var specificActionNames = map[SpecificAction]string{
+	ChargingCorrelationExchange:               "CHARGING_CORRELATION_EXCHANGE",
+	IndicationOfLossOfBearer:                  "INDICATION_OF_LOSS_OF_BEARER",
+	IndicationOfRecoveryOfBearer:              "INDICATION_OF_RECOVERY_OF_BEARER",
+	IndicationOfReleaseOfBearer:               "INDICATION_OF_RELEASE_OF_BEARER",
+	IPCANChange:                               "IP-CAN_CHANGE",
+	IndicationOfOutOfCredit:                   "INDICATION_OF_OUT_OF_CREDIT",
+	IndicationOfSuccessfulResourcesAllocation: "INDICATION_OF_SUCCESSFUL_RESOURCES_ALLOCATION",
+	IndicationOfFailedResourcesAllocation:     "INDICATION_OF_FAILED_RESOURCES_ALLOCATION",
+	IndicationOfLimitedPCCDeployment:          "INDICATION_OF_LIMITED_PCC_DEPLOYMENT",
+	UsageReport:                               "USAGE_REPORT",
+	AccessNetworkInfoReport:                   "ACCESS_NETWORK_INFO_REPORT",
+}
+
+// String returns the name of the specific action as it appears in the
+// dictionary.
+func (a SpecificAction) String() string {
+	if n, ok := specificActionNames[a]; ok {
+		return n
+	}
+	return "UNKNOWN"
+}
+
+// Media-Type values. See 3GPP TS 29.214 section 5.3.19.
+const (
+	MediaAudio       = 0
+	MediaVideo       = 1
+	MediaData        = 2
+	MediaApplication = 3
+	MediaControl     = 4
+	MediaText        = 5
+	MediaMessage     = 6
+)
+
+// Flow-Status values. See 3GPP TS 29.214 section 5.3.11.
+const (
+	FlowEnabledUplink   = 0
+	FlowEnabledDownlink = 1
+	FlowEnabled         = 2
+	FlowDisabled        = 3
+	FlowRemoved         = 4
+)
+
+// Flow-Usage values. See 3GPP TS 29.214 section 5.3.12.
+const (
+	FlowUsageNoInformation = 0
+	FlowUsageRTCP          = 1
+	FlowUsageAFSignalling  = 2
+)
+
+// Abort-Cause values. See 3GPP TS 29.214 section 5.3.1.
+const (
+	BearerReleased              = 0
+	InsufficientServerResources = 1
+	InsufficientBearerResources = 2
+	PSToCSHandover              = 3
+)
+
+// Rx-Request-Type values. See 3GPP TS 29.214 section 5.3.31.
+const (
+	RxInitialRequest = 0
+	RxUpdateRequest  = 1
+)
+
+// Termination-Cause values. See RFC 6733 section 8.15.
+const (
+	TerminationLogout             = 1
+	TerminationServiceNotProvided = 2
+	TerminationBadAnswer          = 3
+	TerminationAdministrative     = 4
+	TerminationLinkBroken         = 5
+	TerminationAuthExpired        = 6
+	TerminationUserMoved          = 7
+	TerminationSessionTimeout     = 8
+)
+
+// Re-Auth-Request-Type values. See RFC 6733 section 8.12.
+const (
+	AuthorizeOnly         = 0
+	AuthorizeAuthenticate = 1
+)
+
+// MediaSubComponent is the Media-Sub-Component grouped AVP, which
+// describes a single IP flow of a media component.
+type MediaSubComponent struct {
+	FlowNumber              uint32                  `avp:"Flow-Number"`
+	FlowDescription         []datatype.IPFilterRule `avp:"Flow-Description"`
+	FlowStatus              *int32                  `avp:"Flow-Status"`
+	FlowUsage               *int32                  `avp:"Flow-Usage"`
+	MaxRequestedBandwidthUL uint32                  `avp:"Max-Requested-Bandwidth-UL,omitempty"`
+	MaxRequestedBandwidthDL uint32                  `avp:"Max-Requested-Bandwidth-DL,omitempty"`
+	ToSTrafficClass         datatype.OctetString    `avp:"ToS-Traffic-Class,omitempty"`
+}
+
+// MediaComponentDescription is the Media-Component-Description grouped
+// AVP, which describes a media component of an AF session.
+type MediaComponentDescription struct {
+	MediaComponentNumber    uint32              `avp:"Media-Component-Number"`
+	MediaSubComponent       []MediaSubComponent `avp:"Media-Sub-Component"`
+	AFApplicationIdentifier string              `avp:"AF-Application-Identifier,omitempty"`
+	MediaType               *int32              `avp:"Media-Type"`
+	MaxRequestedBandwidthUL uint32              `avp:"Max-Requested-Bandwidth-UL,omitempty"`
+	MaxRequestedBandwidthDL uint32              `avp:"Max-Requested-Bandwidth-DL,omitempty"`
+	MinRequestedBandwidthUL uint32              `avp:"Min-Requested-Bandwidth-UL,omitempty"`
+	MinRequestedBandwidthDL uint32              `avp:"Min-Requested-Bandwidth-DL,omitempty"`
+	FlowStatus              *int32              `avp:"Flow-Status"`
+	ReservationPriority     *int32              `avp:"Reservation-Priority"`
+	RSBandwidth             uint32              `avp:"RS-Bandwidth,omitempty"`
+	RRBandwidth             uint32              `avp:"RR-Bandwidth,omitempty"`
+	CodecData               []string            `avp:"Codec-Data"`
+}
+
+// Flows is the Flows grouped AVP, which identifies IP flows by media
+// component and flow number.
+type Flows struct {
+	MediaComponentNumber uint32   `avp:"Media-Component-Number"`
+	FlowNumber           []uint32 `avp:"Flow-Number"`
+	FinalUnitAction      *int32   `avp:"Final-Unit-Action"`
+}
+
+// AccessNetworkChargingIdentifier is the
+// Access-Network-Charging-Identifier grouped AVP.
+type AccessNetworkChargingIdentifier struct {
+	Value datatype.OctetString `avp:"Access-Network-Charging-Identifier-Value"`
+	Flows []Flows              `avp:"Flows"`
+}
+
+// AcceptableServiceInfo is the Acceptable-Service-Info grouped AVP.
+type AcceptableServiceInfo struct {
+	MediaComponentDescription []MediaComponentDescription `avp:"Media-Component-Description"`
+	MaxRequestedBandwidthDL   uint32                      `avp:"Max-Requested-Bandwidth-DL,omitempty"`
+	MaxRequestedBandwidthUL   uint32                      `avp:"Max-Requested-Bandwidth-UL,omitempty"`
+}
+
+// AAR is an Rx AA-Request message.
+// See 3GPP TS 29.214 section 5.6.1.
+type AAR struct {
+	SessionID                 string                      `avp:"Session-Id"`
+	AuthApplicationID         uint32                      `avp:"Auth-Application-Id"`
+	OriginHost                datatype.DiameterIdentity   `avp:"Origin-Host"`
+	OriginRealm               datatype.DiameterIdentity   `avp:"Origin-Realm"`
+	DestinationRealm          datatype.DiameterIdentity   `avp:"Destination-Realm"`
+	DestinationHost           datatype.DiameterIdentity   `avp:"Destination-Host,omitempty"`
+	AFApplicationIdentifier   string                      `avp:"AF-Application-Identifier,omitempty"`
+	MediaComponentDescription []MediaComponentDescription `avp:"Media-Component-Description"`
+	ServiceInfoStatus         *int32                      `avp:"Service-Info-Status"`
+	AFChargingIdentifier      datatype.OctetString        `avp:"AF-Charging-Identifier,omitempty"`
+	SpecificAction            []SpecificAction            `avp:"Specific-Action"`
+	SubscriptionID            []gy.SubscriptionID         `avp:"Subscription-Id"`
+	ReservationPriority       *int32                      `avp:"Reservation-Priority"`
+	FramedIPAddress           net.IP                      `avp:"Framed-IP-Address,omitempty"`
+	CalledStationID           string                      `avp:"Called-Station-Id,omitempty"`
+	RxRequestType             *int32                      `avp:"Rx-Request-Type"`
+	OriginStateID             uint32                      `avp:"Origin-State-Id,omitempty"`
+}
+
+// AAA is an Rx AA-Answer message.
+// See 3GPP TS 29.214 section 5.6.2.
+type AAA struct {
+	SessionID                       string                            `avp:"Session-Id"`
+	AuthApplicationID               uint32                            `avp:"Auth-Application-Id"`
+	OriginHost                      datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                     datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	ResultCode                      uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult              *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AccessNetworkChargingIdentifier []AccessNetworkChargingIdentifier `avp:"Access-Network-Charging-Identifier"`
+	AcceptableServiceInfo           *AcceptableServiceInfo            `avp:"Acceptable-Service-Info"`
+	IPCANType                       *int32                            `avp:"IP-CAN-Type"`
+	RATType                         *int32                            `avp:"RAT-Type"`
+	ErrorMessage                    string                            `avp:"Error-Message,omitempty"`
+	OriginStateID                   uint32                            `avp:"Origin-State-Id,omitempty"`
+}
+
+// RAR is an Rx Re-Auth-Request message, sent by the PCRF to notify the
+// AF of the events in Specific-Action.
+// See 3GPP TS 29.214 section 5.6.3.
+type RAR struct {
+	SessionID                       string                            `avp:"Session-Id"`
+	OriginHost                      datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                     datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationRealm                datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	DestinationHost                 datatype.DiameterIdentity         `avp:"Destination-Host"`
+	AuthApplicationID               uint32                            `avp:"Auth-Application-Id"`
+	ReAuthRequestType               int32                             `avp:"Re-Auth-Request-Type"`
+	SpecificAction                  []SpecificAction                  `avp:"Specific-Action"`
+	AccessNetworkChargingIdentifier []AccessNetworkChargingIdentifier `avp:"Access-Network-Charging-Identifier"`
+	Flows                           []Flows                           `avp:"Flows"`
+	SubscriptionID                  []gy.SubscriptionID               `avp:"Subscription-Id"`
+	AbortCause                      *int32                            `avp:"Abort-Cause"`
+	IPCANType                       *int32                            `avp:"IP-CAN-Type"`
+	RATType                         *int32                            `avp:"RAT-Type"`
+	OriginStateID                   uint32                            `avp:"Origin-State-Id,omitempty"`
+}
+
+// RAA is an Rx Re-Auth-Answer message.
+// See 3GPP TS 29.214 section 5.6.4.
+type RAA struct {
+	SessionID                 string                      `avp:"Session-Id"`
+	OriginHost                datatype.DiameterIdentity   `avp:"Origin-Host"`
+	OriginRealm               datatype.DiameterIdentity   `avp:"Origin-Realm"`
+	ResultCode                uint32                      `avp:"Result-Code,omitempty"`
+	ExperimentalResult        *base.ExperimentalResult    `avp:"Experimental-Result"`
+	MediaComponentDescription []MediaComponentDescription `avp:"Media-Component-Description"`
+	OriginStateID             uint32                      `avp:"Origin-State-Id,omitempty"`
+	ErrorMessage              string                      `avp:"Error-Message,omitempty"`
+}
+
+// STR is an Rx Session-Termination-Request message.
+// See 3GPP TS 29.214 section 5.6.5.
+type STR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	TerminationCause  int32                     `avp:"Termination-Cause"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host,omitempty"`
+	OriginStateID     uint32                    `avp:"Origin-State-Id,omitempty"`
+}
+
+// STA is an Rx Session-Termination-Answer message.
+// See 3GPP TS 29.214 section 5.6.6.
+type STA struct {
+	SessionID     string                    `avp:"Session-Id"`
+	OriginHost    datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm   datatype.DiameterIdentity `avp:"Origin-Realm"`
+	ResultCode    uint32                    `avp:"Result-Code,omitempty"`
+	ErrorMessage  string                    `avp:"Error-Message,omitempty"`
+	OriginStateID uint32                    `avp:"Origin-State-Id,omitempty"`
+}
+
+// ASR is an Rx Abort-Session-Request message.
+// See 3GPP TS 29.214 section 5.6.7.
+type ASR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	AbortCause        *int32                    `avp:"Abort-Cause"`
+	OriginStateID     uint32                    `avp:"Origin-State-Id,omitempty"`
+}
+
+// ASA is an Rx Abort-Session-Answer message.
+// See 3GPP TS 29.214 section 5.6.8.
+type ASA struct {
+	SessionID     string                    `avp:"Session-Id"`
+	OriginHost    datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm   datatype.DiameterIdentity `avp:"Origin-Realm"`
+	ResultCode    uint32                    `avp:"Result-Code,omitempty"`
+	OriginStateID uint32                    `avp:"Origin-State-Id,omitempty"`
+	ErrorMessage  string                    `avp:"Error-Message,omitempty"`
+}
@@ -0,0 +1,244 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package rx
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+func i32(v int32) *int32 { return &v }
+
+func voice(n uint32) MediaComponentDescription {
+	return MediaComponentDescription{
+		MediaComponentNumber: n,
+		MediaSubComponent: []MediaSubComponent{{
+			FlowNumber: 1,
+			FlowDescription: []datatype.IPFilterRule{
+				"permit out 17 from 10.0.0.1 49152 to 10.45.0.2 50000",
+				"permit in 17 from 10.45.0.2 50000 to 10.0.0.1 49152",
+			},
+		}, {
+			FlowNumber: 2,
+			FlowUsage:  i32(FlowUsageRTCP),
+		}},
+		MediaType:               i32(MediaAudio),
+		MaxRequestedBandwidthUL: 41000,
+		MaxRequestedBandwidthDL: 41000,
+		FlowStatus:              i32(FlowEnabled),
+		CodecData:               []string{"uplink\noffer\nm=audio 49152 RTP/AVP 116"},
+	}
+}
+
+func TestAAR_MarshalUnmarshal(t *testing.T) {
+	want := &AAR{
+		SessionID:                 "pcscf;1",
+		AuthApplicationID:         diam.RX_APP_ID,
+		OriginHost:                "pcscf",
+		OriginRealm:               "test",
+		DestinationRealm:          "test",
+		AFApplicationIdentifier:   "IMS Services",
+		MediaComponentDescription: []MediaComponentDescription{voice(1)},
+		SpecificAction:            []SpecificAction{IndicationOfLossOfBearer, IndicationOfReleaseOfBearer},
+		SubscriptionID:            []gy.SubscriptionID{{Type: gy.EndUserSIPURI, Data: "sip:alice@test"}},
+		FramedIPAddress:           net.IP{10, 45, 0, 2},
+		RxRequestType:             i32(RxInitialRequest),
+	}
+	m := diam.NewRequest(diam.AA, diam.RX_APP_ID, dict.Default)
+	if err := m.Marshal(want); err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have AAR
+	if err = dec.Unmarshal(&have); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, &have) {
+		t.Fatalf("Unexpected AAR.\nWant %+v\nHave %+v", want, &have)
+	}
+}
+
+func TestClientServer(t *testing.T) {
+	terminated := make(chan *STR, 1)
+	pcrf := &Server{
+		OriginHost:  "pcrf",
+		OriginRealm: "test",
+		Timeout:     time.Second,
+		Authorizer: AuthorizerFunc(func(aar *AAR, aaa *AAA) {
+			if *aar.RxRequestType == RxInitialRequest && len(aar.FramedIPAddress) == 0 {
+				aaa.ExperimentalResult = &base.ExperimentalResult{
+					VendorID: base.Vendor3GPP,
+					Code:     IPCANSessionNotAvailable,
+				}
+			}
+		}),
+		OnTerminate: func(str *STR) { terminated <- str },
+	}
+	mux := sm.New(smtest.Settings("pcrf"))
+	for _, idx := range []diam.CommandIndex{AARIndex, STRIndex, RAAIndex, ASAIndex} {
+		mux.HandleIdx(idx, pcrf)
+	}
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	notified := make(chan *RAR, 1)
+	aborted := make(chan *Session, 1)
+	af := &Client{
+		OriginHost:       "pcscf",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		OnReAuth: func(s *Session, rar *RAR) uint32 {
+			notified <- rar
+			return diam.Success
+		},
+		OnAbort: func(s *Session, asr *ASR) { aborted <- s },
+	}
+	cmux := sm.New(smtest.Settings("pcscf"))
+	for _, idx := range []diam.CommandIndex{AAAIndex, STAIndex, RARIndex, ASRIndex} {
+		cmux.HandleIdx(idx, af)
+	}
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.RX_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s, aaa, err := af.Establish(c, &AAR{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil || aaa.ExperimentalResult == nil || aaa.ExperimentalResult.Code != IPCANSessionNotAvailable {
+		t.Fatalf("Unexpected AAA: %+v", aaa)
+	}
+
+	s, aaa, err = af.Establish(c, &AAR{
+		FramedIPAddress:           net.IP{10, 45, 0, 2},
+		MediaComponentDescription: []MediaComponentDescription{voice(1)},
+		SpecificAction:            []SpecificAction{IndicationOfLossOfBearer},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil || aaa.ResultCode != diam.Success {
+		t.Fatalf("Unexpected AAA: %+v", aaa)
+	}
+	if aar, ok := pcrf.Session(s.ID); !ok || aar.OriginHost != "pcscf" {
+		t.Fatalf("Unexpected session: %+v", aar)
+	}
+
+	// Add a second media component, then remove the first one.
+	aaa, err = s.Modify(&AAR{MediaComponentDescription: []MediaComponentDescription{voice(2)}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aaa.ResultCode != diam.Success {
+		t.Fatalf("Unexpected AAA: %+v", aaa)
+	}
+	if _, err = s.Modify(&AAR{MediaComponentDescription: []MediaComponentDescription{{
+		MediaComponentNumber: 1,
+		FlowStatus:           i32(FlowRemoved),
+	}}}); err != nil {
+		t.Fatal(err)
+	}
+	if m := s.Media(); len(m) != 1 || m[0].MediaComponentNumber != 2 {
+		t.Fatalf("Unexpected AF media: %+v", m)
+	}
+	if m := pcrf.Media(s.ID); len(m) != 1 || m[0].MediaComponentNumber != 2 {
+		t.Fatalf("Unexpected PCRF media: %+v", m)
+	}
+
+	rar := &RAR{
+		SpecificAction: []SpecificAction{IndicationOfLossOfBearer},
+		Flows:          []Flows{{MediaComponentNumber: 2, FlowNumber: []uint32{1}}},
+	}
+	raa, err := pcrf.Notify(s.ID, rar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rar.SessionID != "" || rar.DestinationHost != "" {
+		t.Fatalf("Notify modified the RAR: %+v", rar)
+	}
+	if raa.ResultCode != diam.Success {
+		t.Fatalf("Unexpected RAA: %+v", raa)
+	}
+	select {
+	case rar := <-notified:
+		if len(rar.SpecificAction) != 1 || rar.SpecificAction[0] != IndicationOfLossOfBearer {
+			t.Fatalf("Unexpected RAR: %+v", rar)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnReAuth was not called")
+	}
+
+	asa, err := pcrf.Abort(s.ID, InsufficientBearerResources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asa.ResultCode != diam.Success {
+		t.Fatalf("Unexpected ASA: %+v", asa)
+	}
+	select {
+	case str := <-terminated:
+		if str.SessionID != s.ID || str.TerminationCause != TerminationAdministrative {
+			t.Fatalf("Unexpected STR: %+v", str)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Session was not terminated")
+	}
+	select {
+	case <-aborted:
+	case <-time.After(time.Second):
+		t.Fatal("OnAbort was not called")
+	}
+	if ids := pcrf.Sessions(); len(ids) != 0 {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	if _, err = s.Terminate(TerminationLogout); err != ErrSessionClosed {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrSessionClosed, err)
+	}
+	if _, err = pcrf.Notify(s.ID, nil); err != ErrUnknownSession {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrUnknownSession, err)
+	}
+	// Sessions are forgotten when their connection is closed.
+	if s, _, err = af.Establish(c, &AAR{FramedIPAddress: net.IP{10, 45, 0, 3}}); err != nil || s == nil {
+		t.Fatalf("Establish failed: %v", err)
+	}
+	if ids := pcrf.Sessions(); len(ids) != 1 {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	c.Close()
+	for deadline := time.Now().Add(time.Second); len(pcrf.Sessions()) > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Unexpected sessions after close: %v", pcrf.Sessions())
+		}
+	}
+}
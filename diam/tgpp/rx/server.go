@@ -0,0 +1,316 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package rx
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+)
+
+// ErrUnknownSession is returned by Server.Notify and Server.Abort when
+// the session is not active.
+var ErrUnknownSession = errors.New("rx: unknown session")
+
+// An Authorizer authorizes the service information of AF sessions.
+type Authorizer interface {
+	// Authorize is called for every AAR. It may change the Result-Code
+	// of the AAA, which is DIAMETER_SUCCESS on entry, or set an
+	// Experimental-Result, in which case the Result-Code is cleared.
+	Authorize(aar *AAR, aaa *AAA)
+}
+
+// The AuthorizerFunc type is an adapter to allow the use of ordinary
+// functions as Authorizer.
+type AuthorizerFunc func(aar *AAR, aaa *AAA)
+
+// Authorize calls f(aar, aaa).
+func (f AuthorizerFunc) Authorize(aar *AAR, aaa *AAA) {
+	f(aar, aaa)
+}
+
+// Server is a skeleton of the PCRF side of Rx. It answers AARs using its
+// Authorizer, keeps track of the media of active AF sessions until they
+// are terminated or their connection is closed, and can notify the AF
+// with RAR or abort its sessions with ASR.
+//
+// Server implements the diam.Handler interface and must be registered
+// for AARIndex, STRIndex, RAAIndex and ASAIndex on the connection's
+// handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Authorizer  Authorizer    // Optional. Without it all sessions are accepted.
+	Timeout     time.Duration // Defaults to DefaultTimeout.
+
+	// OnTerminate, if non-nil, is called when the AF terminates a
+	// session with STR.
+	OnTerminate func(str *STR)
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	sessions map[string]*serverSession
+	watched  map[diam.Conn]bool // Connections of sessions
+}
+
+type serverSession struct {
+	conn  diam.Conn
+	aar   *AAR // Initial AAR
+	media map[uint32]MediaComponentDescription
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.AA:
+		s.answer(c, m, s.aa(c, m))
+	case diam.SessionTermination:
+		s.answer(c, m, s.terminate(m))
+	}
+}
+
+func (s *Server) aa(c diam.Conn, m *diam.Message) *AAA {
+	var aar AAR
+	aaa := &AAA{
+		AuthApplicationID: diam.RX_APP_ID,
+		OriginHost:        s.OriginHost,
+		OriginRealm:       s.OriginRealm,
+	}
+	if err := m.Unmarshal(&aar); err != nil {
+		aaa.ResultCode = diam.UnableToComply
+		return aaa
+	}
+	aaa.SessionID = aar.SessionID
+	aaa.ResultCode = diam.Success
+	if len(aar.SessionID) == 0 {
+		aaa.ResultCode = diam.MissingAVP
+		return aaa
+	}
+	s.mu.Lock()
+	ss, active := s.sessions[aar.SessionID]
+	s.mu.Unlock()
+	if !active && aar.RxRequestType != nil && *aar.RxRequestType == RxUpdateRequest {
+		aaa.ResultCode = diam.UnknownSessionID
+		return aaa
+	}
+	if s.Authorizer != nil {
+		s.Authorizer.Authorize(&aar, aaa)
+	}
+	if aaa.ExperimentalResult != nil {
+		aaa.ResultCode = 0
+	}
+	if aaa.ResultCode != diam.Success {
+		return aaa
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !active {
+		ss = &serverSession{
+			conn:  c,
+			aar:   &aar,
+			media: make(map[uint32]MediaComponentDescription),
+		}
+		if s.sessions == nil {
+			s.sessions = make(map[string]*serverSession)
+		}
+		s.sessions[aar.SessionID] = ss
+		s.watch(c)
+	}
+	mergeMedia(ss.media, aar.MediaComponentDescription)
+	return aaa
+}
+
+// watch forgets the sessions of the connection c when it is closed.
+// It must be called with s.mu held.
+func (s *Server) watch(c diam.Conn) {
+	cn, ok := c.(diam.CloseNotifier)
+	if !ok || s.watched[c] {
+		return
+	}
+	if s.watched == nil {
+		s.watched = make(map[diam.Conn]bool)
+	}
+	s.watched[c] = true
+	go func() {
+		<-cn.CloseNotify()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.watched, c)
+		for id, ss := range s.sessions {
+			if ss.conn == c {
+				delete(s.sessions, id)
+			}
+		}
+	}()
+}
+
+func (s *Server) terminate(m *diam.Message) *STA {
+	var str STR
+	sta := &STA{
+		OriginHost:  s.OriginHost,
+		OriginRealm: s.OriginRealm,
+		ResultCode:  diam.Success,
+	}
+	if err := m.Unmarshal(&str); err != nil {
+		sta.ResultCode = diam.UnableToComply
+		return sta
+	}
+	sta.SessionID = str.SessionID
+	s.mu.Lock()
+	_, active := s.sessions[str.SessionID]
+	delete(s.sessions, str.SessionID)
+	s.mu.Unlock()
+	if !active {
+		sta.ResultCode = diam.UnknownSessionID
+		return sta
+	}
+	if s.OnTerminate != nil {
+		s.OnTerminate(&str)
+	}
+	return sta
+}
+
+func (s *Server) answer(c diam.Conn, m *diam.Message, v interface{}) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && s.ErrorReporter != nil {
+		s.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
+
+// Sessions returns the Session-Id of all active sessions, sorted.
+func (s *Server) Sessions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Session returns the initial AAR of an active session.
+func (s *Server) Session(id string) (*AAR, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ss, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	return ss.aar, true
+}
+
+// Media returns the media components of an active session, ordered by
+// Media-Component-Number.
+func (s *Server) Media(id string) []MediaComponentDescription {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ss, ok := s.sessions[id]
+	if !ok {
+		return nil
+	}
+	media := make([]MediaComponentDescription, 0, len(ss.media))
+	for _, m := range ss.media {
+		media = append(media, m)
+	}
+	sort.Slice(media, func(i, j int) bool {
+		return media[i].MediaComponentNumber < media[j].MediaComponentNumber
+	})
+	return media
+}
+
+// Notify sends a RAR carrying the Specific-Action notifications in rar
+// to the AF of an active session and waits for its answer. Session-Id,
+// routing AVPs and Auth-Application-Id are filled in by the Server.
+func (s *Server) Notify(sessionID string, rar *RAR) (*RAA, error) {
+	ss, err := s.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	// Fill in a copy, leaving the caller's RAR untouched.
+	var r RAR
+	if rar != nil {
+		r = *rar
+	}
+	rar = &r
+	rar.SessionID = sessionID
+	rar.OriginHost = s.OriginHost
+	rar.OriginRealm = s.OriginRealm
+	rar.DestinationHost = ss.aar.OriginHost
+	rar.DestinationRealm = ss.aar.OriginRealm
+	rar.AuthApplicationID = diam.RX_APP_ID
+	var raa RAA
+	if err = s.exchange(ss, diam.ReAuth, rar, &raa); err != nil {
+		return nil, err
+	}
+	return &raa, nil
+}
+
+// Abort sends an ASR with the given Abort-Cause to the AF of an active
+// session and waits for its answer. The session remains active until
+// the AF terminates it with STR.
+func (s *Server) Abort(sessionID string, cause int32) (*ASA, error) {
+	ss, err := s.session(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	asr := &ASR{
+		SessionID:         sessionID,
+		OriginHost:        s.OriginHost,
+		OriginRealm:       s.OriginRealm,
+		DestinationHost:   ss.aar.OriginHost,
+		DestinationRealm:  ss.aar.OriginRealm,
+		AuthApplicationID: diam.RX_APP_ID,
+		AbortCause:        &cause,
+	}
+	var asa ASA
+	if err = s.exchange(ss, diam.AbortSession, asr, &asa); err != nil {
+		return nil, err
+	}
+	return &asa, nil
+}
+
+func (s *Server) session(id string) (*serverSession, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ss, ok := s.sessions[id]
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	return ss, nil
+}
+
+func (s *Server) exchange(ss *serverSession, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.RX_APP_ID, ss.conn.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := s.pending.Exchange(ss.conn, m, s.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
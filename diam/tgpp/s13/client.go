@@ -0,0 +1,73 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s13
+
+import (
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// DefaultTimeout is how long the Client waits for answers when no
+// Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+func vendorSpecificApplicationID() *base.VendorSpecificApplicationID {
+	return base.NewVendorSpecificApplicationID(diam.TGPP_S13_APP_ID)
+}
+
+// Client is the MME side of S13. It sends ECRs to the EIR.
+//
+// Client implements the diam.Handler interface and must be registered
+// for ECAIndex on the connection's handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	pending pending.Table
+}
+
+// CheckIdentity sends an ECR built from ecr over c and waits for the
+// ECA. Session-Id is generated when empty, and the routing AVPs,
+// Vendor-Specific-Application-Id and Auth-Session-State are filled in by
+// the Client.
+func (cli *Client) CheckIdentity(c diam.Conn, ecr *ECR) (*ECA, error) {
+	if len(ecr.SessionID) == 0 {
+		ecr.SessionID = sessionid.New(cli.OriginHost)
+	}
+	ecr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	ecr.AuthSessionState = base.NoStateMaintained
+	ecr.OriginHost = cli.OriginHost
+	ecr.OriginRealm = cli.OriginRealm
+	ecr.DestinationRealm = cli.DestinationRealm
+	ecr.DestinationHost = cli.DestinationHost
+	m := diam.NewRequest(diam.MEIdentityCheck, diam.TGPP_S13_APP_ID, c.Dictionary())
+	if err := m.Marshal(ecr); err != nil {
+		return nil, err
+	}
+	a, err := cli.pending.Exchange(c, m, cli.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	var eca ECA
+	if err = a.Unmarshal(&eca); err != nil {
+		return nil, err
+	}
+	return &eca, nil
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+	}
+}
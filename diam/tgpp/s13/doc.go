@@ -0,0 +1,32 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package s13 implements the S13 application between the MME and the
+// EIR, as specified in 3GPP TS 29.272.
+//
+// It provides typed ECR and ECA messages for use with Message.Marshal
+// and Message.Unmarshal, an MME Client that sends ME-Identity-Check
+// requests, and an EIR Server that answers them with the
+// Equipment-Status decided by a Checker, such as a List of
+// whitelisted, blacklisted and greylisted IMEIs loaded from a file.
+//
+// An EIR backed by a list file:
+//
+//	list, err := s13.LoadList("eir.list")
+//	...
+//	eir := &s13.Server{
+//		OriginHost:  "eir.example.com",
+//		OriginRealm: "example.com",
+//		Checker:     list,
+//	}
+//	mux := sm.New(settings)
+//	mux.HandleIdx(s13.ECRIndex, eir)
+//
+// An MME checking a terminal:
+//
+//	eca, err := mme.CheckIdentity(conn, &s13.ECR{
+//		UserName:            imsi,
+//		TerminalInformation: s13.TerminalInformation{IMEI: imei, SoftwareVersion: "01"},
+//	})
+package s13
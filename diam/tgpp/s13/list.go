@@ -0,0 +1,155 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s13
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// List is a Checker backed by an ordered list of equipment rules. The
+// first rule that matches the IMEI and Software-Version of a terminal
+// decides its status.
+//
+// The text format of a List has one rule per line, with the status,
+// the IMEI and an optional Software-Version separated by spaces:
+//
+//	# status   imei             [software-version]
+//	blacklist  35358601234567
+//	greylist   35358601*        01
+//	whitelist  35358601*
+//	whitelist  *
+//
+// The status is whitelist (or allow), blacklist (or deny) or greylist
+// (or grey). An IMEI ending in * matches every IMEI with that prefix,
+// such as all the terminals of a TAC. IMEIs are compared without their
+// 15th digit, if any. Empty lines and lines starting with # are ignored.
+type List struct {
+	mu    sync.RWMutex
+	rules []listRule
+}
+
+type listRule struct {
+	status          int32
+	imei            string
+	prefix          bool
+	softwareVersion string
+}
+
+var listStatus = map[string]int32{
+	"whitelist": Whitelisted,
+	"allow":     Whitelisted,
+	"blacklist": Blacklisted,
+	"deny":      Blacklisted,
+	"greylist":  Greylisted,
+	"grey":      Greylisted,
+}
+
+// LoadList reads a List from the named file.
+func LoadList(name string) (*List, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ParseList(f)
+}
+
+// ParseList reads a List in text format from r.
+func ParseList(r io.Reader) (*List, error) {
+	l := &List{}
+	s := bufio.NewScanner(r)
+	for n := 1; s.Scan(); n++ {
+		line := strings.TrimSpace(s.Text())
+		if len(line) == 0 || line[0] == '#' {
+			continue
+		}
+		f := strings.Fields(line)
+		if len(f) > 3 {
+			return nil, fmt.Errorf("s13: line %d: too many fields", n)
+		}
+		status, ok := listStatus[strings.ToLower(f[0])]
+		if !ok {
+			return nil, fmt.Errorf("s13: line %d: unknown status %q", n, f[0])
+		}
+		if len(f) < 2 {
+			return nil, fmt.Errorf("s13: line %d: missing IMEI", n)
+		}
+		var sv string
+		if len(f) == 3 {
+			sv = f[2]
+		}
+		if err := l.Add(status, f[1], sv); err != nil {
+			return nil, fmt.Errorf("s13: line %d: %v", n, err)
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Add appends a rule to the list. The imei may end in * to match a
+// prefix, and softwareVersion may be empty to match any version.
+func (l *List) Add(status int32, imei, softwareVersion string) error {
+	if status < Whitelisted || status > Greylisted {
+		return fmt.Errorf("invalid equipment status %d", status)
+	}
+	r := listRule{status: status, softwareVersion: softwareVersion}
+	r.imei = strings.TrimSuffix(imei, "*")
+	r.prefix = len(r.imei) != len(imei)
+	if strings.IndexFunc(r.imei, notDigit) >= 0 {
+		return fmt.Errorf("invalid IMEI %q", imei)
+	}
+	r.imei = trimIMEI(r.imei)
+	l.mu.Lock()
+	l.rules = append(l.rules, r)
+	l.mu.Unlock()
+	return nil
+}
+
+// Len returns the number of rules in the list.
+func (l *List) Len() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return len(l.rules)
+}
+
+// Check implements the Checker interface.
+func (l *List) Check(ecr *ECR) (int32, bool) {
+	ti := &ecr.TerminalInformation
+	if len(ti.IMEI) == 0 {
+		return 0, false
+	}
+	imei := trimIMEI(ti.IMEI)
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	for _, r := range l.rules {
+		if r.prefix && !strings.HasPrefix(imei, r.imei) || !r.prefix && imei != r.imei {
+			continue
+		}
+		if len(r.softwareVersion) > 0 && r.softwareVersion != ti.SoftwareVersion {
+			continue
+		}
+		return r.status, true
+	}
+	return 0, false
+}
+
+// trimIMEI removes the check or spare digit of a 15 digit IMEI.
+func trimIMEI(imei string) string {
+	if len(imei) > 14 {
+		return imei[:14]
+	}
+	return imei
+}
+
+func notDigit(r rune) bool {
+	return r < '0' || r > '9'
+}
@@ -0,0 +1,62 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s13
+
+import (
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// Command indexes of the S13 application, for use with ServeMux.HandleIdx.
+var (
+	ECRIndex = diam.CommandIndex{AppID: diam.TGPP_S13_APP_ID, Code: diam.MEIdentityCheck, Request: true}
+	ECAIndex = diam.CommandIndex{AppID: diam.TGPP_S13_APP_ID, Code: diam.MEIdentityCheck, Request: false}
+)
+
+// Equipment-Status values. See 3GPP TS 29.272 section 7.3.51.
+const (
+	Whitelisted = 0
+	Blacklisted = 1
+	Greylisted  = 2
+)
+
+// ErrorEquipmentUnknown is the Experimental-Result-Code sent when the
+// EIR does not know the equipment. See 3GPP TS 29.272 section 7.4.4.
+const ErrorEquipmentUnknown = 5422
+
+// TerminalInformation is the Terminal-Information grouped AVP.
+type TerminalInformation struct {
+	IMEI            string               `avp:"IMEI,omitempty"`
+	MEID            datatype.OctetString `avp:"TGPP2-MEID,omitempty"`
+	SoftwareVersion string               `avp:"Software-Version,omitempty"`
+}
+
+// ECR is an S13 ME-Identity-Check-Request message.
+// See 3GPP TS 29.272 section 7.2.19.
+type ECR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	TerminalInformation         TerminalInformation               `avp:"Terminal-Information"`
+	UserName                    string                            `avp:"User-Name,omitempty"`
+}
+
+// ECA is an S13 ME-Identity-Check-Answer message.
+// See 3GPP TS 29.272 section 7.2.20.
+type ECA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	EquipmentStatus             *int32                            `avp:"Equipment-Status"`
+}
@@ -0,0 +1,123 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s13
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+const testList = `
+# Stolen terminal.
+blacklist 35358601234567
+greylist  35358601*  01
+allow     35358601*
+deny      49015420*
+`
+
+func TestList(t *testing.T) {
+	l, err := ParseList(strings.NewReader(testList))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Len() != 4 {
+		t.Fatalf("Unexpected number of rules: %d", l.Len())
+	}
+	for _, tc := range []struct {
+		imei, sv string
+		status   int32
+		ok       bool
+	}{
+		{"35358601234567", "02", Blacklisted, true},
+		{"353586012345670", "", Blacklisted, true}, // With check digit.
+		{"35358601000000", "01", Greylisted, true},
+		{"35358601000000", "02", Whitelisted, true},
+		{"49015420323751", "", Blacklisted, true},
+		{"12345678901234", "", 0, false},
+		{"", "", 0, false},
+	} {
+		ecr := &ECR{TerminalInformation: TerminalInformation{IMEI: tc.imei, SoftwareVersion: tc.sv}}
+		status, ok := l.Check(ecr)
+		if status != tc.status || ok != tc.ok {
+			t.Errorf("Unexpected status for %s/%s. Want %d/%t, have %d/%t",
+				tc.imei, tc.sv, tc.status, tc.ok, status, ok)
+		}
+	}
+	for _, bad := range []string{
+		"whitelist",
+		"redlist 35358601234567",
+		"allow 3535860123456A",
+		"allow 35358601234567 01 extra",
+	} {
+		if _, err = ParseList(strings.NewReader(bad)); err == nil {
+			t.Errorf("Invalid list %q was accepted", bad)
+		}
+	}
+}
+
+func TestClientServer(t *testing.T) {
+	l, err := ParseList(strings.NewReader(testList))
+	if err != nil {
+		t.Fatal(err)
+	}
+	eir := &Server{OriginHost: "eir", OriginRealm: "test", Checker: l}
+	mme := &Client{
+		OriginHost:       "mme",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+	}
+	c, done := smtest.Connect(t,
+		smtest.Peer{Host: "eir", Handler: eir, Commands: []diam.CommandIndex{ECRIndex}},
+		smtest.Peer{Host: "mme", Handler: mme, Commands: []diam.CommandIndex{ECAIndex}},
+		base.Vendor3GPP, diam.TGPP_S13_APP_ID)
+	defer done()
+
+	check := func(t *testing.T, imei, sv string) *ECA {
+		t.Helper()
+		eca, err := mme.CheckIdentity(c, &ECR{
+			UserName:            "001010000000001",
+			TerminalInformation: TerminalInformation{IMEI: imei, SoftwareVersion: sv},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return eca
+	}
+
+	t.Run("Listed", func(t *testing.T) {
+		eca := check(t, "35358601000000", "01")
+		if eca.ResultCode != diam.Success || eca.EquipmentStatus == nil || *eca.EquipmentStatus != Greylisted {
+			t.Fatalf("Unexpected ECA: %+v", eca)
+		}
+		if !strings.HasPrefix(eca.SessionID, "mme;") || eca.OriginHost != "eir" || eca.AuthSessionState != base.NoStateMaintained {
+			t.Fatalf("Unexpected ECA: %+v", eca)
+		}
+		if eca = check(t, "35358601234567", ""); eca.EquipmentStatus == nil || *eca.EquipmentStatus != Blacklisted {
+			t.Fatalf("Unexpected ECA: %+v", eca)
+		}
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		eca := check(t, "12345678901234", "")
+		if eca.ResultCode != 0 || eca.EquipmentStatus != nil ||
+			eca.ExperimentalResult == nil || eca.ExperimentalResult.Code != ErrorEquipmentUnknown {
+			t.Fatalf("Unexpected ECA: %+v", eca)
+		}
+	})
+
+	t.Run("UnknownStatus", func(t *testing.T) {
+		whitelisted := int32(Whitelisted)
+		eir.UnknownStatus = &whitelisted
+		if eca := check(t, "12345678901234", ""); eca.ResultCode != diam.Success || *eca.EquipmentStatus != Whitelisted {
+			t.Fatalf("Unexpected ECA: %+v", eca)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s13
+
+import (
+	"fmt"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// A Checker decides the status of the equipment in an ECR.
+type Checker interface {
+	// Check returns the Equipment-Status of the terminal, and false
+	// when the equipment is unknown.
+	Check(ecr *ECR) (status int32, ok bool)
+}
+
+// The CheckerFunc type is an adapter to allow the use of ordinary
+// functions as Checker.
+type CheckerFunc func(ecr *ECR) (int32, bool)
+
+// Check calls f(ecr).
+func (f CheckerFunc) Check(ecr *ECR) (int32, bool) {
+	return f(ecr)
+}
+
+// Server is the EIR side of S13. It answers ECRs with the
+// Equipment-Status decided by its Checker.
+//
+// Server implements the diam.Handler interface and must be registered
+// for ECRIndex on the connection's handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Checker     Checker
+
+	// UnknownStatus, if non-nil, is the Equipment-Status of equipment
+	// the Checker does not know. When nil, unknown equipment is answered
+	// with DIAMETER_ERROR_EQUIPMENT_UNKNOWN.
+	UnknownStatus *int32
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 || m.Header.CommandCode != diam.MEIdentityCheck {
+		return
+	}
+	var ecr ECR
+	eca := &ECA{
+		VendorSpecificApplicationID: vendorSpecificApplicationID(),
+		AuthSessionState:            base.NoStateMaintained,
+		OriginHost:                  s.OriginHost,
+		OriginRealm:                 s.OriginRealm,
+	}
+	if err := m.Unmarshal(&ecr); err != nil {
+		eca.ResultCode = diam.UnableToComply
+	} else {
+		s.check(&ecr, eca)
+	}
+	eca.SessionID = ecr.SessionID
+	a := m.Answer(0)
+	err := a.Marshal(eca)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && s.ErrorReporter != nil {
+		s.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write ECA: %v", err),
+		})
+	}
+}
+
+func (s *Server) check(ecr *ECR, eca *ECA) {
+	if s.Checker == nil {
+		eca.ResultCode = diam.UnableToComply
+		return
+	}
+	status, ok := s.Checker.Check(ecr)
+	switch {
+	case ok:
+		eca.EquipmentStatus = &status
+	case s.UnknownStatus != nil:
+		status = *s.UnknownStatus
+		eca.EquipmentStatus = &status
+	default:
+		eca.ExperimentalResult = &base.ExperimentalResult{
+			VendorID: base.Vendor3GPP,
+			Code:     ErrorEquipmentUnknown,
+		}
+		return
+	}
+	eca.ResultCode = diam.Success
+}
@@ -0,0 +1,261 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s6a
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// DefaultTimeout is how long the Client and Server wait for answers
+// when no Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+func vendorSpecificApplicationID() *VendorSpecificApplicationID {
+	return base.NewVendorSpecificApplicationID(diam.TGPP_S6A_APP_ID)
+}
+
+// Client is the MME side of S6a. It sends AIR, ULR, PUR and NOR to the
+// HSS and answers the CLR, IDR, DSR and RSR sent by the HSS.
+//
+// Requests are completed by the Client before being sent: Session-Id is
+// generated when empty, and the routing AVPs, Vendor-Specific-Application-Id
+// and Auth-Session-State are filled in, along with the flags that identify
+// the sender as an MME.
+//
+// Client implements the diam.Handler interface and must be registered
+// for AIAIndex, ULAIndex, PUAIndex, NOAIndex, CLRIndex, IDRIndex,
+// DSRIndex and RSRIndex on the connection's handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	VisitedPLMNID    datatype.OctetString      // Used when AIR or ULR have none.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// The following functions, if non-nil, are called for requests sent
+	// by the HSS. The answer is filled in with DIAMETER_SUCCESS on entry
+	// and may be changed; setting an Experimental-Result clears the
+	// Result-Code. They run on the connection's read goroutine and must
+	// not block.
+	OnCancelLocation       func(clr *CLR, cla *CLA)
+	OnInsertSubscriberData func(idr *IDR, ida *IDA)
+	OnDeleteSubscriberData func(dsr *DSR, dsa *DSA)
+	OnReset                func(rsr *RSR, rsa *RSA)
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending pending.Table
+}
+
+// AuthenticationInformation sends an AIR built from air over c and
+// waits for the AIA. One E-UTRAN vector is requested when air requests
+// none.
+func (cli *Client) AuthenticationInformation(c diam.Conn, air *AIR) (*AIA, error) {
+	if len(air.SessionID) == 0 {
+		air.SessionID = sessionid.New(cli.OriginHost)
+	}
+	air.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	air.AuthSessionState = NoStateMaintained
+	air.OriginHost = cli.OriginHost
+	air.OriginRealm = cli.OriginRealm
+	air.DestinationRealm = cli.DestinationRealm
+	air.DestinationHost = cli.DestinationHost
+	if len(air.VisitedPLMNID) == 0 {
+		air.VisitedPLMNID = cli.VisitedPLMNID
+	}
+	if air.RequestedEUTRANAuthInfo == nil && air.RequestedUTRANGERANAuthInfo == nil {
+		air.RequestedEUTRANAuthInfo = &RequestedAuthInfo{NumberOfRequestedVectors: 1}
+	}
+	var aia AIA
+	if err := cli.exchange(c, diam.AuthenticationInformation, air, &aia); err != nil {
+		return nil, err
+	}
+	return &aia, nil
+}
+
+// UpdateLocation sends a ULR built from ulr over c and waits for the
+// ULA. The S6a/S6d-Indicator is set in ULR-Flags and RAT-Type defaults
+// to EUTRAN.
+func (cli *Client) UpdateLocation(c diam.Conn, ulr *ULR) (*ULA, error) {
+	if len(ulr.SessionID) == 0 {
+		ulr.SessionID = sessionid.New(cli.OriginHost)
+	}
+	ulr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	ulr.AuthSessionState = NoStateMaintained
+	ulr.OriginHost = cli.OriginHost
+	ulr.OriginRealm = cli.OriginRealm
+	ulr.DestinationRealm = cli.DestinationRealm
+	ulr.DestinationHost = cli.DestinationHost
+	if len(ulr.VisitedPLMNID) == 0 {
+		ulr.VisitedPLMNID = cli.VisitedPLMNID
+	}
+	if ulr.RATType == 0 {
+		ulr.RATType = RATEUTRAN
+	}
+	ulr.ULRFlags |= ULRS6aS6dIndicator
+	var ula ULA
+	if err := cli.exchange(c, diam.UpdateLocation, ulr, &ula); err != nil {
+		return nil, err
+	}
+	return &ula, nil
+}
+
+// PurgeUE sends a PUR for the given user over c and waits for the PUA.
+// The UE Purged in MME bit is set in PUR-Flags.
+func (cli *Client) PurgeUE(c diam.Conn, pur *PUR) (*PUA, error) {
+	if len(pur.SessionID) == 0 {
+		pur.SessionID = sessionid.New(cli.OriginHost)
+	}
+	pur.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	pur.AuthSessionState = NoStateMaintained
+	pur.OriginHost = cli.OriginHost
+	pur.OriginRealm = cli.OriginRealm
+	pur.DestinationRealm = cli.DestinationRealm
+	pur.DestinationHost = cli.DestinationHost
+	pur.PURFlags |= PURUEPurgedInMME
+	var pua PUA
+	if err := cli.exchange(c, diam.PurgeUE, pur, &pua); err != nil {
+		return nil, err
+	}
+	return &pua, nil
+}
+
+// Notify sends a NOR built from nor over c and waits for the NOA. The
+// S6a/S6d-Indicator is set in NOR-Flags.
+func (cli *Client) Notify(c diam.Conn, nor *NOR) (*NOA, error) {
+	if len(nor.SessionID) == 0 {
+		nor.SessionID = sessionid.New(cli.OriginHost)
+	}
+	nor.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	nor.AuthSessionState = NoStateMaintained
+	nor.OriginHost = cli.OriginHost
+	nor.OriginRealm = cli.OriginRealm
+	nor.DestinationRealm = cli.DestinationRealm
+	nor.DestinationHost = cli.DestinationHost
+	nor.NORFlags |= NORS6aS6dIndicator
+	var noa NOA
+	if err := cli.exchange(c, diam.Notify, nor, &noa); err != nil {
+		return nil, err
+	}
+	return &noa, nil
+}
+
+func (cli *Client) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.TGPP_S6A_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := cli.pending.Exchange(c, m, cli.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.CancelLocation:
+		var clr CLR
+		cla := &CLA{}
+		if decode(m, &clr, &cla.ResultCode) && cli.OnCancelLocation != nil {
+			cli.OnCancelLocation(&clr, cla)
+		}
+		cla.SessionID = clr.SessionID
+		cla.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		cla.AuthSessionState = NoStateMaintained
+		cla.OriginHost = cli.OriginHost
+		cla.OriginRealm = cli.OriginRealm
+		if cla.ExperimentalResult != nil {
+			cla.ResultCode = 0
+		}
+		answer(c, m, cla, cli.ErrorReporter)
+	case diam.InsertSubscriberData:
+		var idr IDR
+		ida := &IDA{}
+		if decode(m, &idr, &ida.ResultCode) && cli.OnInsertSubscriberData != nil {
+			cli.OnInsertSubscriberData(&idr, ida)
+		}
+		ida.SessionID = idr.SessionID
+		ida.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		ida.AuthSessionState = NoStateMaintained
+		ida.OriginHost = cli.OriginHost
+		ida.OriginRealm = cli.OriginRealm
+		if ida.ExperimentalResult != nil {
+			ida.ResultCode = 0
+		}
+		answer(c, m, ida, cli.ErrorReporter)
+	case diam.DeleteSubscriberData:
+		var dsr DSR
+		dsa := &DSA{}
+		if decode(m, &dsr, &dsa.ResultCode) && cli.OnDeleteSubscriberData != nil {
+			cli.OnDeleteSubscriberData(&dsr, dsa)
+		}
+		dsa.SessionID = dsr.SessionID
+		dsa.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		dsa.AuthSessionState = NoStateMaintained
+		dsa.OriginHost = cli.OriginHost
+		dsa.OriginRealm = cli.OriginRealm
+		if dsa.ExperimentalResult != nil {
+			dsa.ResultCode = 0
+		}
+		answer(c, m, dsa, cli.ErrorReporter)
+	case diam.Reset:
+		var rsr RSR
+		rsa := &RSA{}
+		if decode(m, &rsr, &rsa.ResultCode) && cli.OnReset != nil {
+			cli.OnReset(&rsr, rsa)
+		}
+		rsa.SessionID = rsr.SessionID
+		rsa.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		rsa.AuthSessionState = NoStateMaintained
+		rsa.OriginHost = cli.OriginHost
+		rsa.OriginRealm = cli.OriginRealm
+		if rsa.ExperimentalResult != nil {
+			rsa.ResultCode = 0
+		}
+		answer(c, m, rsa, cli.ErrorReporter)
+	}
+}
+
+// decode decodes the request m into req and sets the Result-Code of
+// its answer, reporting whether the request is valid.
+func decode(m *diam.Message, req interface{}, resultCode *uint32) bool {
+	if err := m.Unmarshal(req); err != nil {
+		*resultCode = diam.UnableToComply
+		return false
+	}
+	*resultCode = diam.Success
+	return true
+}
+
+// answer writes the answer v to the request m.
+func answer(c diam.Conn, m *diam.Message, v interface{}, er diam.ErrorReporter) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && er != nil {
+		er.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
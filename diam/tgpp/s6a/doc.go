@@ -0,0 +1,41 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package s6a implements the S6a application between the MME and the
+// HSS, as specified in 3GPP TS 29.272.
+//
+// It provides typed messages for all S6a commands (ULR/ULA, AIR/AIA,
+// CLR/CLA, IDR/IDA, DSR/DSA, PUR/PUA, RSR/RSA and NOR/NOA), including
+// Subscription-Data and the authentication vectors, for use with
+// Message.Marshal and Message.Unmarshal. It also provides an MME Client
+// and an HSS Server that fill in the mandatory AVPs and flags of the
+// messages they send.
+//
+// The messages implement diam.AVPMarshaler and diam.AVPUnmarshaler with
+// methods generated by diam-gen, so that they are encoded and decoded
+// without reflection. Run go generate after changing them.
+//
+// An MME attaching a subscriber:
+//
+//	plmn, _ := s6a.EncodePLMNID("001", "01")
+//	mme := &s6a.Client{
+//		OriginHost:       "mme.example.com",
+//		OriginRealm:      "example.com",
+//		DestinationRealm: "example.com",
+//		VisitedPLMNID:    plmn,
+//	}
+//	mux := sm.New(settings)
+//	for _, idx := range []diam.CommandIndex{
+//		s6a.AIAIndex, s6a.ULAIndex, s6a.PUAIndex, s6a.NOAIndex,
+//		s6a.CLRIndex, s6a.IDRIndex, s6a.DSRIndex, s6a.RSRIndex,
+//	} {
+//		mux.HandleIdx(idx, mme)
+//	}
+//	...
+//	aia, err := mme.AuthenticationInformation(conn, &s6a.AIR{UserName: imsi})
+//	...
+//	ula, err := mme.UpdateLocation(conn, &s6a.ULR{UserName: imsi})
+package s6a
+
+//go:generate go run github.com/fiorix/go-diameter/v4/cmd/diam-gen -source message.go -apps 16777251 -o message_codec.go
@@ -0,0 +1,697 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s6a
+
+import (
+	"net"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// Command indexes of the S6a application, for use with ServeMux.HandleIdx.
+var (
+	ULRIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.UpdateLocation, Request: true}
+	ULAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.UpdateLocation, Request: false}
+	AIRIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.AuthenticationInformation, Request: true}
+	AIAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.AuthenticationInformation, Request: false}
+	CLRIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.CancelLocation, Request: true}
+	CLAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.CancelLocation, Request: false}
+	IDRIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.InsertSubscriberData, Request: true}
+	IDAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.InsertSubscriberData, Request: false}
+	DSRIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.DeleteSubscriberData, Request: true}
+	DSAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.DeleteSubscriberData, Request: false}
+	PURIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.PurgeUE, Request: true}
+	PUAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.PurgeUE, Request: false}
+	RSRIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.Reset, Request: true}
+	RSAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.Reset, Request: false}
+	NORIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.Notify, Request: true}
+	NOAIndex = diam.CommandIndex{AppID: diam.TGPP_S6A_APP_ID, Code: diam.Notify, Request: false}
+)
+
+// Vendor3GPP is the Vendor-Id of 3GPP.
+const Vendor3GPP = base.Vendor3GPP
+
+// NoStateMaintained is the Auth-Session-State of all S6a messages.
+// See 3GPP TS 29.272 section 7.1.
+const NoStateMaintained = base.NoStateMaintained
+
+// Experimental-Result-Code values. See 3GPP TS 29.272 section 7.4.
+const (
+	UserUnknown                   = 5001
+	RoamingNotAllowed             = 5004
+	UnknownEPSSubscription        = 5420
+	RATNotAllowed                 = 5421
+	EquipmentUnknown              = 5422
+	UnknownServingNode            = 5423
+	AuthenticationDataUnavailable = 4181
+	CamelSubscriptionPresent      = 4182
+)
+
+// RAT-Type values used on S6a. See 3GPP TS 29.212 section 5.3.31.
+const (
+	RATUTRAN  = 1000
+	RATGERAN  = 1001
+	RATEUTRAN = 1004
+)
+
+// ULR-Flags bits. See 3GPP TS 29.272 section 7.3.7.
+const (
+	ULRSingleRegistrationIndication  = 1 << 0
+	ULRS6aS6dIndicator               = 1 << 1
+	ULRSkipSubscriberData            = 1 << 2
+	ULRGPRSSubscriptionDataIndicator = 1 << 3
+	ULRNodeTypeIndicator             = 1 << 4
+	ULRInitialAttachIndicator        = 1 << 5
+	ULRPSLCSNotSupportedByUE         = 1 << 6
+	ULRSMSOnlyIndication             = 1 << 7
+)
+
+// ULA-Flags bits. See 3GPP TS 29.272 section 7.3.8.
+const (
+	ULASeparationIndication = 1 << 0
+	ULAMMERegisteredForSMS  = 1 << 1
+)
+
+// CLR-Flags bits. See 3GPP TS 29.272 section 7.3.152.
+const (
+	CLRS6aS6dIndicator  = 1 << 0
+	CLRReattachRequired = 1 << 1
+)
+
+// IDR-Flags bits. See 3GPP TS 29.272 section 7.3.103.
+const (
+	IDRUEReachabilityRequest   = 1 << 0
+	IDRTADSDataRequest         = 1 << 1
+	IDREPSUserStateRequest     = 1 << 2
+	IDREPSLocationInfoRequest  = 1 << 3
+	IDRCurrentLocationRequest  = 1 << 4
+	IDRLocalTimeZoneRequest    = 1 << 5
+	IDRRemoveSMSRegistration   = 1 << 6
+	IDRRAT                     = 1 << 7
+	IDRPCSCFRestorationRequest = 1 << 8
+)
+
+// IDA-Flags and DSA-Flags bits. See 3GPP TS 29.272 sections 7.3.47 and
+// 7.3.27.
+const (
+	NetworkNodeAreaRestricted = 1 << 0
+)
+
+// DSR-Flags bits. See 3GPP TS 29.272 section 7.3.25.
+const (
+	DSRRegionalSubscriptionWithdrawal              = 1 << 0
+	DSRCompleteAPNConfigurationProfileWithdrawal   = 1 << 1
+	DSRSubscribedChargingCharacteristicsWithdrawal = 1 << 2
+	DSRPDNSubscriptionContextsWithdrawal           = 1 << 3
+	DSRSTNSRWithdrawal                             = 1 << 4
+	DSRCompletePDPContextListWithdrawal            = 1 << 5
+	DSRPDPContextsWithdrawal                       = 1 << 6
+	DSRRoamingRestrictedWithdrawal                 = 1 << 7
+	DSRTraceDataWithdrawal                         = 1 << 8
+	DSRCSGDeleted                                  = 1 << 9
+	DSRAPNOIReplacementWithdrawal                  = 1 << 10
+	DSRGMLCListWithdrawal                          = 1 << 11
+	DSRLCSWithdrawal                               = 1 << 12
+	DSRSMSWithdrawal                               = 1 << 13
+	DSRSubscribedPeriodicRAUTAUTimerWithdrawal     = 1 << 14
+	DSRSubscribedVSRVCCWithdrawal                  = 1 << 15
+	DSRAMSISDNWithdrawal                           = 1 << 16
+)
+
+// PUR-Flags bits. See 3GPP TS 29.272 section 7.3.149.
+const (
+	PURUEPurgedInMME  = 1 << 0
+	PURUEPurgedInSGSN = 1 << 1
+)
+
+// PUA-Flags bits. See 3GPP TS 29.272 section 7.3.48.
+const (
+	PUAFreezeMTMSI = 1 << 0
+	PUAFreezePTMSI = 1 << 1
+)
+
+// NOR-Flags bits. See 3GPP TS 29.272 section 7.3.49.
+const (
+	NORSingleRegistrationIndication   = 1 << 0
+	NORSGSNAreaRestricted             = 1 << 1
+	NORReadyForSMFromSGSN             = 1 << 2
+	NORUEReachableFromMME             = 1 << 3
+	NORUEReachableFromSGSN            = 1 << 5
+	NORReadyForSMFromMME              = 1 << 6
+	NORHomogeneousSupportOfIMSVoPS    = 1 << 7
+	NORS6aS6dIndicator                = 1 << 8
+	NORRemovalOfMMERegistrationForSMS = 1 << 9
+)
+
+// Cancellation-Type values. See 3GPP TS 29.272 section 7.3.24.
+const (
+	MMEUpdateProcedure     = 0
+	SGSNUpdateProcedure    = 1
+	SubscriptionWithdrawal = 2
+	UpdateProcedureIWF     = 3
+	InitialAttachProcedure = 4
+)
+
+// Subscriber-Status values. See 3GPP TS 29.272 section 7.3.29.
+const (
+	ServiceGranted            = 0
+	OperatorDeterminedBarring = 1
+)
+
+// Network-Access-Mode values. See 3GPP TS 29.272 section 7.3.21.
+const (
+	PacketAndCircuit = 0
+	OnlyPacket       = 2
+)
+
+// All-APN-Configurations-Included-Indicator values.
+// See 3GPP TS 29.272 section 7.3.33.
+const (
+	AllAPNConfigurationsIncluded           = 0
+	ModifiedAddedAPNConfigurationsIncluded = 1
+)
+
+// PDN-Type values. See 3GPP TS 29.272 section 7.3.62.
+const (
+	PDNTypeIPv4       = 0
+	PDNTypeIPv6       = 1
+	PDNTypeIPv4v6     = 2
+	PDNTypeIPv4OrIPv6 = 3
+)
+
+// Alert-Reason values. See 3GPP TS 29.272 section 7.3.83.
+const (
+	UEPresent         = 0
+	UEMemoryAvailable = 1
+)
+
+// ExperimentalResult is the Experimental-Result grouped AVP.
+type ExperimentalResult = base.ExperimentalResult
+
+// VendorSpecificApplicationID is the Vendor-Specific-Application-Id
+// grouped AVP.
+type VendorSpecificApplicationID = base.VendorSpecificApplicationID
+
+// SupportedFeatures is the Supported-Features grouped AVP.
+type SupportedFeatures = base.SupportedFeatures
+
+// TerminalInformation is the Terminal-Information grouped AVP.
+type TerminalInformation struct {
+	IMEI            string `avp:"IMEI,omitempty"`
+	SoftwareVersion string `avp:"Software-Version,omitempty"`
+}
+
+// AMBR is the AMBR grouped AVP.
+type AMBR struct {
+	MaxRequestedBandwidthUL  uint32 `avp:"Max-Requested-Bandwidth-UL"`
+	MaxRequestedBandwidthDL  uint32 `avp:"Max-Requested-Bandwidth-DL"`
+	ExtendedMaxRequestedBWUL uint32 `avp:"Extended-Max-Requested-BW-UL,omitempty"`
+	ExtendedMaxRequestedBWDL uint32 `avp:"Extended-Max-Requested-BW-DL,omitempty"`
+}
+
+// AllocationRetentionPriority is the Allocation-Retention-Priority
+// grouped AVP.
+type AllocationRetentionPriority struct {
+	PriorityLevel           uint32 `avp:"Priority-Level"`
+	PreemptionCapability    *int32 `avp:"Pre-emption-Capability"`
+	PreemptionVulnerability *int32 `avp:"Pre-emption-Vulnerability"`
+}
+
+// EPSSubscribedQoSProfile is the EPS-Subscribed-QoS-Profile grouped AVP.
+type EPSSubscribedQoSProfile struct {
+	QCI                         int32                       `avp:"QoS-Class-Identifier"`
+	AllocationRetentionPriority AllocationRetentionPriority `avp:"Allocation-Retention-Priority"`
+}
+
+// MIP6AgentInfo is the MIP6-Agent-Info grouped AVP, which identifies
+// the PDN GW of a static APN allocation.
+type MIP6AgentInfo struct {
+	MIPHomeAgentAddress []net.IP          `avp:"MIP-Home-Agent-Address"`
+	MIPHomeAgentHost    *MIPHomeAgentHost `avp:"MIP-Home-Agent-Host"`
+}
+
+// MIPHomeAgentHost is the MIP-Home-Agent-Host grouped AVP.
+type MIPHomeAgentHost struct {
+	DestinationRealm datatype.DiameterIdentity `avp:"Destination-Realm"`
+	DestinationHost  datatype.DiameterIdentity `avp:"Destination-Host"`
+}
+
+// SpecificAPNInfo is the Specific-APN-Info grouped AVP.
+type SpecificAPNInfo struct {
+	ServiceSelection         string               `avp:"Service-Selection"`
+	MIP6AgentInfo            MIP6AgentInfo        `avp:"MIP6-Agent-Info"`
+	VisitedNetworkIdentifier datatype.OctetString `avp:"Visited-Network-Identifier,omitempty"`
+}
+
+// APNConfiguration is the APN-Configuration grouped AVP.
+type APNConfiguration struct {
+	ContextIdentifier           uint32                   `avp:"Context-Identifier"`
+	ServedPartyIPAddress        []net.IP                 `avp:"Served-Party-IP-Address"`
+	PDNType                     int32                    `avp:"PDN-Type"`
+	ServiceSelection            string                   `avp:"Service-Selection"`
+	EPSSubscribedQoSProfile     *EPSSubscribedQoSProfile `avp:"EPS-Subscribed-QoS-Profile"`
+	VPLMNDynamicAddressAllowed  *int32                   `avp:"VPLMN-Dynamic-Address-Allowed"`
+	MIP6AgentInfo               *MIP6AgentInfo           `avp:"MIP6-Agent-Info"`
+	VisitedNetworkIdentifier    datatype.OctetString     `avp:"Visited-Network-Identifier,omitempty"`
+	PDNGWAllocationType         *int32                   `avp:"PDN-GW-Allocation-Type"`
+	TGPPChargingCharacteristics string                   `avp:"TGPP-Charging-Characteristics,omitempty"`
+	AMBR                        *AMBR                    `avp:"AMBR"`
+	SpecificAPNInfo             []SpecificAPNInfo        `avp:"Specific-APN-Info"`
+	APNOIReplacement            string                   `avp:"APN-OI-Replacement,omitempty"`
+	SIPTOPermission             *int32                   `avp:"SIPTO-Permission"`
+	LIPAPermission              *int32                   `avp:"LIPA-Permission"`
+}
+
+// APNConfigurationProfile is the APN-Configuration-Profile grouped AVP.
+type APNConfigurationProfile struct {
+	ContextIdentifier                     uint32             `avp:"Context-Identifier"`
+	AllAPNConfigurationsIncludedIndicator int32              `avp:"All-APN-Configurations-Included-Indicator"`
+	APNConfiguration                      []APNConfiguration `avp:"APN-Configuration"`
+}
+
+// PDPContext is the PDP-Context grouped AVP.
+type PDPContext struct {
+	ContextIdentifier           uint32               `avp:"Context-Identifier"`
+	PDPType                     datatype.OctetString `avp:"PDP-Type"`
+	PDPAddress                  net.IP               `avp:"PDP-Address,omitempty"`
+	QoSSubscribed               datatype.OctetString `avp:"QoS-Subscribed"`
+	VPLMNDynamicAddressAllowed  *int32               `avp:"VPLMN-Dynamic-Address-Allowed"`
+	ServiceSelection            string               `avp:"Service-Selection"`
+	TGPPChargingCharacteristics string               `avp:"TGPP-Charging-Characteristics,omitempty"`
+	ExtPDPType                  datatype.OctetString `avp:"Ext-PDP-Type,omitempty"`
+	ExtPDPAddress               net.IP               `avp:"Ext-PDP-Address,omitempty"`
+	AMBR                        *AMBR                `avp:"AMBR"`
+	SIPTOPermission             *int32               `avp:"SIPTO-Permission"`
+	LIPAPermission              *int32               `avp:"LIPA-Permission"`
+}
+
+// GPRSSubscriptionData is the GPRS-Subscription-Data grouped AVP.
+type GPRSSubscriptionData struct {
+	CompleteDataListIncludedIndicator int32        `avp:"Complete-Data-List-Included-Indicator"`
+	PDPContext                        []PDPContext `avp:"PDP-Context"`
+}
+
+// CSGSubscriptionData is the CSG-Subscription-Data grouped AVP.
+type CSGSubscriptionData struct {
+	CSGID            uint32     `avp:"CSG-Id"`
+	ExpirationDate   *time.Time `avp:"Expiration-Date"`
+	ServiceSelection []string   `avp:"Service-Selection"`
+}
+
+// TraceData is the Trace-Data grouped AVP.
+type TraceData struct {
+	TraceReference        datatype.OctetString `avp:"Trace-Reference"`
+	TraceDepth            int32                `avp:"Trace-Depth"`
+	TraceNETypeList       datatype.OctetString `avp:"Trace-NE-Type-List"`
+	TraceInterfaceList    datatype.OctetString `avp:"Trace-Interface-List,omitempty"`
+	TraceEventList        datatype.OctetString `avp:"Trace-Event-List"`
+	OMCID                 datatype.OctetString `avp:"OMC-Id,omitempty"`
+	TraceCollectionEntity net.IP               `avp:"Trace-Collection-Entity"`
+}
+
+// SSInfo is the SS-Code and SS-Status pair carried by the
+// Call-Barring-Info and MO-LR grouped AVPs.
+type SSInfo struct {
+	SSCode   datatype.OctetString `avp:"SS-Code"`
+	SSStatus datatype.OctetString `avp:"SS-Status"`
+}
+
+// ExternalClient is the External-Client grouped AVP.
+type ExternalClient struct {
+	ClientIdentity       datatype.OctetString `avp:"Client-Identity"`
+	GMLCRestriction      *int32               `avp:"GMLC-Restriction"`
+	NotificationToUEUser *int32               `avp:"Notification-To-UE-User"`
+}
+
+// LCSPrivacyException is the LCS-PrivacyException grouped AVP.
+type LCSPrivacyException struct {
+	SSCode               datatype.OctetString `avp:"SS-Code"`
+	SSStatus             datatype.OctetString `avp:"SS-Status"`
+	NotificationToUEUser *int32               `avp:"Notification-To-UE-User"`
+	ExternalClient       []ExternalClient     `avp:"External-Client"`
+	PLMNClient           []int32              `avp:"PLMN-Client"`
+}
+
+// LCSInfo is the LCS-Info grouped AVP.
+type LCSInfo struct {
+	GMLCNumber          []datatype.OctetString `avp:"GMLC-Number"`
+	LCSPrivacyException []LCSPrivacyException  `avp:"LCS-PrivacyException"`
+	MOLR                []SSInfo               `avp:"MO-LR"`
+}
+
+// TeleserviceList is the Teleservice-List grouped AVP.
+type TeleserviceList struct {
+	TSCode datatype.OctetString `avp:"TS-Code"`
+}
+
+// SubscriptionData is the Subscription-Data grouped AVP, the EPS
+// subscription of a user sent by the HSS in ULA and IDR.
+type SubscriptionData struct {
+	SubscriberStatus                         *int32                   `avp:"Subscriber-Status"`
+	MSISDN                                   datatype.OctetString     `avp:"MSISDN,omitempty"`
+	STNSR                                    datatype.OctetString     `avp:"STN-SR,omitempty"`
+	ICSIndicator                             *int32                   `avp:"ICS-Indicator"`
+	NetworkAccessMode                        *int32                   `avp:"Network-Access-Mode"`
+	OperatorDeterminedBarring                *uint32                  `avp:"Operator-Determined-Barring"`
+	HPLMNODB                                 *uint32                  `avp:"HPLMN-ODB"`
+	RegionalSubscriptionZoneCode             []datatype.OctetString   `avp:"Regional-Subscription-Zone-Code"`
+	AccessRestrictionData                    *uint32                  `avp:"Access-Restriction-Data"`
+	APNOIReplacement                         string                   `avp:"APN-OI-Replacement,omitempty"`
+	LCSInfo                                  *LCSInfo                 `avp:"LCS-Info"`
+	TeleserviceList                          *TeleserviceList         `avp:"Teleservice-List"`
+	CallBarringInfo                          []SSInfo                 `avp:"Call-Barring-Info"`
+	TGPPChargingCharacteristics              string                   `avp:"TGPP-Charging-Characteristics,omitempty"`
+	AMBR                                     *AMBR                    `avp:"AMBR"`
+	APNConfigurationProfile                  *APNConfigurationProfile `avp:"APN-Configuration-Profile"`
+	RATFrequencySelectionPriorityID          *uint32                  `avp:"RAT-Frequency-Selection-Priority-ID"`
+	TraceData                                *TraceData               `avp:"Trace-Data"`
+	GPRSSubscriptionData                     *GPRSSubscriptionData    `avp:"GPRS-Subscription-Data"`
+	CSGSubscriptionData                      []CSGSubscriptionData    `avp:"CSG-Subscription-Data"`
+	RoamingRestrictedDueToUnsupportedFeature *int32                   `avp:"Roaming-Restricted-Due-To-Unsupported-Feature"`
+	SubscribedPeriodicRAUTAUTimer            *uint32                  `avp:"Subscribed-Periodic-RAU-TAU-Timer"`
+	MPSPriority                              *uint32                  `avp:"MPS-Priority"`
+	VPLMNLIPAAllowed                         *int32                   `avp:"VPLMN-LIPA-Allowed"`
+	RelayNodeIndicator                       *int32                   `avp:"Relay-Node-Indicator"`
+	MDTUserConsent                           *int32                   `avp:"MDT-User-Consent"`
+}
+
+// RequestedAuthInfo is the Requested-EUTRAN-Authentication-Info and
+// Requested-UTRAN-GERAN-Authentication-Info grouped AVP.
+type RequestedAuthInfo struct {
+	NumberOfRequestedVectors   uint32               `avp:"Number-Of-Requested-Vectors,omitempty"`
+	ImmediateResponsePreferred *uint32              `avp:"Immediate-Response-Preferred"`
+	ResynchronizationInfo      datatype.OctetString `avp:"Re-synchronization-Info,omitempty"`
+}
+
+// EUTRANVector is the E-UTRAN-Vector grouped AVP, an EPS authentication
+// vector.
+type EUTRANVector struct {
+	ItemNumber uint32               `avp:"Item-Number,omitempty"`
+	RAND       datatype.OctetString `avp:"RAND"`
+	XRES       datatype.OctetString `avp:"XRES"`
+	AUTN       datatype.OctetString `avp:"AUTN"`
+	KASME      datatype.OctetString `avp:"KASME"`
+}
+
+// UTRANVector is the UTRAN-Vector grouped AVP, a UMTS authentication
+// vector.
+type UTRANVector struct {
+	ItemNumber         uint32               `avp:"Item-Number,omitempty"`
+	RAND               datatype.OctetString `avp:"RAND"`
+	XRES               datatype.OctetString `avp:"XRES"`
+	AUTN               datatype.OctetString `avp:"AUTN"`
+	ConfidentialityKey datatype.OctetString `avp:"Confidentiality-Key"`
+	IntegrityKey       datatype.OctetString `avp:"Integrity-Key"`
+}
+
+// GERANVector is the GERAN-Vector grouped AVP, a GSM authentication
+// triplet.
+type GERANVector struct {
+	ItemNumber uint32               `avp:"Item-Number,omitempty"`
+	RAND       datatype.OctetString `avp:"RAND"`
+	SRES       datatype.OctetString `avp:"SRES"`
+	Kc         datatype.OctetString `avp:"Kc"`
+}
+
+// AuthenticationInfo is the Authentication-Info grouped AVP.
+type AuthenticationInfo struct {
+	EUTRANVector []EUTRANVector `avp:"E-UTRAN-Vector"`
+	UTRANVector  []UTRANVector  `avp:"UTRAN-Vector"`
+	GERANVector  []GERANVector  `avp:"GERAN-Vector"`
+}
+
+// ActiveAPN is the Active-APN grouped AVP.
+type ActiveAPN struct {
+	ContextIdentifier        uint32               `avp:"Context-Identifier"`
+	ServiceSelection         string               `avp:"Service-Selection,omitempty"`
+	MIP6AgentInfo            *MIP6AgentInfo       `avp:"MIP6-Agent-Info"`
+	VisitedNetworkIdentifier datatype.OctetString `avp:"Visited-Network-Identifier,omitempty"`
+	SpecificAPNInfo          []SpecificAPNInfo    `avp:"Specific-APN-Info"`
+}
+
+// ULR is an Update-Location-Request message, sent by the MME to register
+// itself as serving a user. See 3GPP TS 29.272 section 7.2.3.
+type ULR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	UserName                    string                       `avp:"User-Name"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	TerminalInformation         *TerminalInformation         `avp:"Terminal-Information"`
+	RATType                     int32                        `avp:"RAT-Type"`
+	ULRFlags                    uint32                       `avp:"ULR-Flags"`
+	UESRVCCCapability           *int32                       `avp:"UE-SRVCC-Capability"`
+	VisitedPLMNID               datatype.OctetString         `avp:"Visited-PLMN-Id"`
+	SGSNNumber                  datatype.OctetString         `avp:"SGSN-Number,omitempty"`
+	HomogeneousSupportOfIMSVoPS *int32                       `avp:"Homogeneous-Support-of-IMS-Voice-Over-PS-Sessions"`
+	GMLCAddress                 net.IP                       `avp:"GMLC-Address,omitempty"`
+	ActiveAPN                   []ActiveAPN                  `avp:"Active-APN"`
+}
+
+// ULA is an Update-Location-Answer message.
+// See 3GPP TS 29.272 section 7.2.4.
+type ULA struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result"`
+	ErrorDiagnostic             *int32                       `avp:"Error-Diagnostic"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	ULAFlags                    uint32                       `avp:"ULA-Flags,omitempty"`
+	SubscriptionData            *SubscriptionData            `avp:"Subscription-Data"`
+}
+
+// AIR is an Authentication-Information-Request message, sent by the MME
+// to request authentication vectors. See 3GPP TS 29.272 section 7.2.5.
+type AIR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	UserName                    string                       `avp:"User-Name"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	RequestedEUTRANAuthInfo     *RequestedAuthInfo           `avp:"Requested-EUTRAN-Authentication-Info"`
+	RequestedUTRANGERANAuthInfo *RequestedAuthInfo           `avp:"Requested-UTRAN-GERAN-Authentication-Info"`
+	VisitedPLMNID               datatype.OctetString         `avp:"Visited-PLMN-Id"`
+}
+
+// AIA is an Authentication-Information-Answer message.
+// See 3GPP TS 29.272 section 7.2.6.
+type AIA struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result"`
+	ErrorDiagnostic             *int32                       `avp:"Error-Diagnostic"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	AuthenticationInfo          *AuthenticationInfo          `avp:"Authentication-Info"`
+}
+
+// CLR is a Cancel-Location-Request message, sent by the HSS to remove a
+// user from an MME. See 3GPP TS 29.272 section 7.2.7.
+type CLR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	UserName                    string                       `avp:"User-Name"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	CancellationType            int32                        `avp:"Cancellation-Type"`
+	CLRFlags                    uint32                       `avp:"CLR-Flags,omitempty"`
+}
+
+// CLA is a Cancel-Location-Answer message.
+// See 3GPP TS 29.272 section 7.2.8.
+type CLA struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	ResultCode                  uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+}
+
+// IDR is an Insert-Subscriber-Data-Request message, sent by the HSS to
+// update the subscription data of a user in the MME.
+// See 3GPP TS 29.272 section 7.2.9.
+type IDR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	UserName                    string                       `avp:"User-Name"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	SubscriptionData            SubscriptionData             `avp:"Subscription-Data"`
+	IDRFlags                    uint32                       `avp:"IDR-Flags,omitempty"`
+	ResetID                     []datatype.OctetString       `avp:"Reset-ID"`
+}
+
+// IDA is an Insert-Subscriber-Data-Answer message.
+// See 3GPP TS 29.272 section 7.2.10.
+type IDA struct {
+	SessionID                       string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID     *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	SupportedFeatures               []SupportedFeatures          `avp:"Supported-Features"`
+	ResultCode                      uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult              *ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState                int32                        `avp:"Auth-Session-State"`
+	OriginHost                      datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                     datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	IMSVoiceOverPSSessionsSupported *int32                       `avp:"IMS-Voice-Over-PS-Sessions-Supported"`
+	LastUEActivityTime              *time.Time                   `avp:"Last-UE-Activity-Time"`
+	RATType                         *int32                       `avp:"RAT-Type"`
+	IDAFlags                        uint32                       `avp:"IDA-Flags,omitempty"`
+}
+
+// DSR is a Delete-Subscriber-Data-Request message, sent by the HSS to
+// remove parts of the subscription data of a user from the MME.
+// See 3GPP TS 29.272 section 7.2.11.
+type DSR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	UserName                    string                       `avp:"User-Name"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	DSRFlags                    uint32                       `avp:"DSR-Flags"`
+	ContextIdentifier           []uint32                     `avp:"Context-Identifier"`
+	TraceReference              datatype.OctetString         `avp:"Trace-Reference,omitempty"`
+	TSCode                      []datatype.OctetString       `avp:"TS-Code"`
+	SSCode                      []datatype.OctetString       `avp:"SS-Code"`
+}
+
+// DSA is a Delete-Subscriber-Data-Answer message.
+// See 3GPP TS 29.272 section 7.2.12.
+type DSA struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	ResultCode                  uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DSAFlags                    uint32                       `avp:"DSA-Flags,omitempty"`
+}
+
+// PUR is a Purge-UE-Request message, sent by the MME when it deletes the
+// subscription data of a user. See 3GPP TS 29.272 section 7.2.13.
+type PUR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	UserName                    string                       `avp:"User-Name"`
+	PURFlags                    uint32                       `avp:"PUR-Flags,omitempty"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// PUA is a Purge-UE-Answer message.
+// See 3GPP TS 29.272 section 7.2.14.
+type PUA struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	ResultCode                  uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	PUAFlags                    uint32                       `avp:"PUA-Flags,omitempty"`
+}
+
+// RSR is a Reset-Request message, sent by the HSS after a restart to
+// the MMEs serving its users. See 3GPP TS 29.272 section 7.2.15.
+type RSR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	UserID                      []string                     `avp:"User-Id"`
+}
+
+// RSA is a Reset-Answer message.
+// See 3GPP TS 29.272 section 7.2.16.
+type RSA struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	ResultCode                  uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+}
+
+// NOR is a Notify-Request message, sent by the MME to inform the HSS of
+// events such as the PDN GW selected for an APN.
+// See 3GPP TS 29.272 section 7.2.17.
+type NOR struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	UserName                    string                       `avp:"User-Name"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+	TerminalInformation         *TerminalInformation         `avp:"Terminal-Information"`
+	MIP6AgentInfo               *MIP6AgentInfo               `avp:"MIP6-Agent-Info"`
+	VisitedNetworkIdentifier    datatype.OctetString         `avp:"Visited-Network-Identifier,omitempty"`
+	ContextIdentifier           *uint32                      `avp:"Context-Identifier"`
+	ServiceSelection            string                       `avp:"Service-Selection,omitempty"`
+	AlertReason                 *int32                       `avp:"Alert-Reason"`
+	UESRVCCCapability           *int32                       `avp:"UE-SRVCC-Capability"`
+	NORFlags                    uint32                       `avp:"NOR-Flags,omitempty"`
+	HomogeneousSupportOfIMSVoPS *int32                       `avp:"Homogeneous-Support-of-IMS-Voice-Over-PS-Sessions"`
+}
+
+// NOA is a Notify-Answer message.
+// See 3GPP TS 29.272 section 7.2.18.
+type NOA struct {
+	SessionID                   string                       `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                       `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                        `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	SupportedFeatures           []SupportedFeatures          `avp:"Supported-Features"`
+}
@@ -0,0 +1,3435 @@
+// Code generated by diam-gen. DO NOT EDIT.
+
+package s6a
+
+import (
+	"net"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *TerminalInformation) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	if len(s.IMEI) > 0 {
+		avps = append(avps, diam.NewAVP(1402, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.IMEI)))
+	}
+	if len(s.SoftwareVersion) > 0 {
+		avps = append(avps, diam.NewAVP(1403, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.SoftwareVersion)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *TerminalInformation) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1402: // IMEI
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.IMEI = string(v)
+			}
+		case 10415<<32 | 1403: // Software-Version
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[1] {
+				seen[1] = true
+				s.SoftwareVersion = string(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *AMBR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 4)
+	avps = append(avps, diam.NewAVP(516, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.MaxRequestedBandwidthUL)))
+	avps = append(avps, diam.NewAVP(515, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.MaxRequestedBandwidthDL)))
+	if s.ExtendedMaxRequestedBWUL != 0 {
+		avps = append(avps, diam.NewAVP(555, avp.Vbit, 10415, datatype.Unsigned32(s.ExtendedMaxRequestedBWUL)))
+	}
+	if s.ExtendedMaxRequestedBWDL != 0 {
+		avps = append(avps, diam.NewAVP(554, avp.Vbit, 10415, datatype.Unsigned32(s.ExtendedMaxRequestedBWDL)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *AMBR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [4]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 516: // Max-Requested-Bandwidth-UL
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.MaxRequestedBandwidthUL = uint32(v)
+			}
+		case 10415<<32 | 515: // Max-Requested-Bandwidth-DL
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[1] {
+				seen[1] = true
+				s.MaxRequestedBandwidthDL = uint32(v)
+			}
+		case 10415<<32 | 555: // Extended-Max-Requested-BW-UL
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ExtendedMaxRequestedBWUL = uint32(v)
+			}
+		case 10415<<32 | 554: // Extended-Max-Requested-BW-DL
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[3] {
+				seen[3] = true
+				s.ExtendedMaxRequestedBWDL = uint32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *AllocationRetentionPriority) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	avps = append(avps, diam.NewAVP(1046, avp.Vbit, 10415, datatype.Unsigned32(s.PriorityLevel)))
+	if s.PreemptionCapability != nil {
+		avps = append(avps, diam.NewAVP(1047, avp.Vbit, 10415, datatype.Enumerated(*s.PreemptionCapability)))
+	}
+	if s.PreemptionVulnerability != nil {
+		avps = append(avps, diam.NewAVP(1048, avp.Vbit, 10415, datatype.Enumerated(*s.PreemptionVulnerability)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *AllocationRetentionPriority) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1046: // Priority-Level
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.PriorityLevel = uint32(v)
+			}
+		case 10415<<32 | 1047: // Pre-emption-Capability
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[1] {
+				seen[1] = true
+				e := int32(v)
+				s.PreemptionCapability = &e
+			}
+		case 10415<<32 | 1048: // Pre-emption-Vulnerability
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				e := int32(v)
+				s.PreemptionVulnerability = &e
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *EPSSubscribedQoSProfile) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(1028, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(s.QCI)))
+	{
+		g, err := s.AllocationRetentionPriority.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1034, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *EPSSubscribedQoSProfile) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1028: // QoS-Class-Identifier
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[0] {
+				seen[0] = true
+				s.QCI = int32(v)
+			}
+		case 10415<<32 | 1034: // Allocation-Retention-Priority
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				if err := s.AllocationRetentionPriority.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *MIP6AgentInfo) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	for _, v := range s.MIPHomeAgentAddress {
+		avps = append(avps, diam.NewAVP(334, avp.Mbit, 0, datatype.Address(v)))
+	}
+	if s.MIPHomeAgentHost != nil {
+		g, err := s.MIPHomeAgentHost.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(348, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *MIP6AgentInfo) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.MIPHomeAgentAddress = s.MIPHomeAgentAddress[:0]
+	var seen [1]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 334: // MIP-Home-Agent-Address
+			if v, ok := a.Data.(datatype.Address); ok {
+				s.MIPHomeAgentAddress = append(s.MIPHomeAgentAddress, net.IP(v))
+			}
+		case 348: // MIP-Home-Agent-Host
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[0] {
+				seen[0] = true
+				s.MIPHomeAgentHost = new(MIPHomeAgentHost)
+				if err := s.MIPHomeAgentHost.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *MIPHomeAgentHost) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *MIPHomeAgentHost) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[0] {
+				seen[0] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[1] {
+				seen[1] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *SpecificAPNInfo) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	avps = append(avps, diam.NewAVP(493, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.ServiceSelection)))
+	{
+		g, err := s.MIP6AgentInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(486, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if len(s.VisitedNetworkIdentifier) > 0 {
+		avps = append(avps, diam.NewAVP(600, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.VisitedNetworkIdentifier)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *SpecificAPNInfo) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 493: // Service-Selection
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.ServiceSelection = string(v)
+			}
+		case 10415<<32 | 486: // MIP6-Agent-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				if err := s.MIP6AgentInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 600: // Visited-Network-Identifier
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[2] {
+				seen[2] = true
+				s.VisitedNetworkIdentifier = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *APNConfiguration) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 15)
+	avps = append(avps, diam.NewAVP(1423, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ContextIdentifier)))
+	for _, v := range s.ServedPartyIPAddress {
+		avps = append(avps, diam.NewAVP(848, avp.Mbit|avp.Vbit, 10415, datatype.Address(v)))
+	}
+	avps = append(avps, diam.NewAVP(1456, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(s.PDNType)))
+	avps = append(avps, diam.NewAVP(493, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.ServiceSelection)))
+	if s.EPSSubscribedQoSProfile != nil {
+		g, err := s.EPSSubscribedQoSProfile.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1431, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.VPLMNDynamicAddressAllowed != nil {
+		avps = append(avps, diam.NewAVP(1432, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.VPLMNDynamicAddressAllowed)))
+	}
+	if s.MIP6AgentInfo != nil {
+		g, err := s.MIP6AgentInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(486, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if len(s.VisitedNetworkIdentifier) > 0 {
+		avps = append(avps, diam.NewAVP(600, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.VisitedNetworkIdentifier)))
+	}
+	if s.PDNGWAllocationType != nil {
+		avps = append(avps, diam.NewAVP(1438, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.PDNGWAllocationType)))
+	}
+	if len(s.TGPPChargingCharacteristics) > 0 {
+		avps = append(avps, diam.NewAVP(13, avp.Vbit, 10415, datatype.UTF8String(s.TGPPChargingCharacteristics)))
+	}
+	if s.AMBR != nil {
+		g, err := s.AMBR.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1435, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.SpecificAPNInfo {
+		g, err := s.SpecificAPNInfo[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1472, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if len(s.APNOIReplacement) > 0 {
+		avps = append(avps, diam.NewAVP(1427, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.APNOIReplacement)))
+	}
+	if s.SIPTOPermission != nil {
+		avps = append(avps, diam.NewAVP(1613, avp.Vbit, 10415, datatype.Enumerated(*s.SIPTOPermission)))
+	}
+	if s.LIPAPermission != nil {
+		avps = append(avps, diam.NewAVP(1618, avp.Vbit, 10415, datatype.Enumerated(*s.LIPAPermission)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *APNConfiguration) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.ServedPartyIPAddress = s.ServedPartyIPAddress[:0]
+	s.SpecificAPNInfo = s.SpecificAPNInfo[:0]
+	var seen [13]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1423: // Context-Identifier
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.ContextIdentifier = uint32(v)
+			}
+		case 10415<<32 | 848: // Served-Party-IP-Address
+			if v, ok := a.Data.(datatype.Address); ok {
+				s.ServedPartyIPAddress = append(s.ServedPartyIPAddress, net.IP(v))
+			}
+		case 10415<<32 | 1456: // PDN-Type
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[1] {
+				seen[1] = true
+				s.PDNType = int32(v)
+			}
+		case 10415<<32 | 493: // Service-Selection
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[2] {
+				seen[2] = true
+				s.ServiceSelection = string(v)
+			}
+		case 10415<<32 | 1431: // EPS-Subscribed-QoS-Profile
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.EPSSubscribedQoSProfile = new(EPSSubscribedQoSProfile)
+				if err := s.EPSSubscribedQoSProfile.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1432: // VPLMN-Dynamic-Address-Allowed
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				e := int32(v)
+				s.VPLMNDynamicAddressAllowed = &e
+			}
+		case 10415<<32 | 486: // MIP6-Agent-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[5] {
+				seen[5] = true
+				s.MIP6AgentInfo = new(MIP6AgentInfo)
+				if err := s.MIP6AgentInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 600: // Visited-Network-Identifier
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[6] {
+				seen[6] = true
+				s.VisitedNetworkIdentifier = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1438: // PDN-GW-Allocation-Type
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[7] {
+				seen[7] = true
+				e := int32(v)
+				s.PDNGWAllocationType = &e
+			}
+		case 10415<<32 | 13: // TGPP-Charging-Characteristics
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[8] {
+				seen[8] = true
+				s.TGPPChargingCharacteristics = string(v)
+			}
+		case 10415<<32 | 1435: // AMBR
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[9] {
+				seen[9] = true
+				s.AMBR = new(AMBR)
+				if err := s.AMBR.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1472: // Specific-APN-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SpecificAPNInfo
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SpecificAPNInfo = append(s.SpecificAPNInfo, e)
+			}
+		case 10415<<32 | 1427: // APN-OI-Replacement
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[10] {
+				seen[10] = true
+				s.APNOIReplacement = string(v)
+			}
+		case 10415<<32 | 1613: // SIPTO-Permission
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[11] {
+				seen[11] = true
+				e := int32(v)
+				s.SIPTOPermission = &e
+			}
+		case 10415<<32 | 1618: // LIPA-Permission
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[12] {
+				seen[12] = true
+				e := int32(v)
+				s.LIPAPermission = &e
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *APNConfigurationProfile) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	avps = append(avps, diam.NewAVP(1423, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ContextIdentifier)))
+	avps = append(avps, diam.NewAVP(1428, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(s.AllAPNConfigurationsIncludedIndicator)))
+	for i := range s.APNConfiguration {
+		g, err := s.APNConfiguration[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1430, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *APNConfigurationProfile) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.APNConfiguration = s.APNConfiguration[:0]
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1423: // Context-Identifier
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.ContextIdentifier = uint32(v)
+			}
+		case 10415<<32 | 1428: // All-APN-Configurations-Included-Indicator
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[1] {
+				seen[1] = true
+				s.AllAPNConfigurationsIncludedIndicator = int32(v)
+			}
+		case 10415<<32 | 1430: // APN-Configuration
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e APNConfiguration
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.APNConfiguration = append(s.APNConfiguration, e)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *PDPContext) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 12)
+	avps = append(avps, diam.NewAVP(1423, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ContextIdentifier)))
+	avps = append(avps, diam.NewAVP(1470, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.PDPType)))
+	if len(s.PDPAddress) > 0 {
+		avps = append(avps, diam.NewAVP(1227, avp.Mbit|avp.Vbit, 10415, datatype.Address(s.PDPAddress)))
+	}
+	avps = append(avps, diam.NewAVP(1404, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.QoSSubscribed)))
+	if s.VPLMNDynamicAddressAllowed != nil {
+		avps = append(avps, diam.NewAVP(1432, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.VPLMNDynamicAddressAllowed)))
+	}
+	avps = append(avps, diam.NewAVP(493, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.ServiceSelection)))
+	if len(s.TGPPChargingCharacteristics) > 0 {
+		avps = append(avps, diam.NewAVP(13, avp.Vbit, 10415, datatype.UTF8String(s.TGPPChargingCharacteristics)))
+	}
+	if len(s.ExtPDPType) > 0 {
+		avps = append(avps, diam.NewAVP(1620, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.ExtPDPType)))
+	}
+	if len(s.ExtPDPAddress) > 0 {
+		avps = append(avps, diam.NewAVP(1621, avp.Mbit|avp.Vbit, 10415, datatype.Address(s.ExtPDPAddress)))
+	}
+	if s.AMBR != nil {
+		g, err := s.AMBR.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1435, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.SIPTOPermission != nil {
+		avps = append(avps, diam.NewAVP(1613, avp.Vbit, 10415, datatype.Enumerated(*s.SIPTOPermission)))
+	}
+	if s.LIPAPermission != nil {
+		avps = append(avps, diam.NewAVP(1618, avp.Vbit, 10415, datatype.Enumerated(*s.LIPAPermission)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *PDPContext) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [12]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1423: // Context-Identifier
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.ContextIdentifier = uint32(v)
+			}
+		case 10415<<32 | 1470: // PDP-Type
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.PDPType = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1227: // PDP-Address
+			if v, ok := a.Data.(datatype.Address); ok && !seen[2] {
+				seen[2] = true
+				s.PDPAddress = net.IP(v)
+			}
+		case 10415<<32 | 1404: // QoS-Subscribed
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[3] {
+				seen[3] = true
+				s.QoSSubscribed = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1432: // VPLMN-Dynamic-Address-Allowed
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				e := int32(v)
+				s.VPLMNDynamicAddressAllowed = &e
+			}
+		case 10415<<32 | 493: // Service-Selection
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[5] {
+				seen[5] = true
+				s.ServiceSelection = string(v)
+			}
+		case 10415<<32 | 13: // TGPP-Charging-Characteristics
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[6] {
+				seen[6] = true
+				s.TGPPChargingCharacteristics = string(v)
+			}
+		case 10415<<32 | 1620: // Ext-PDP-Type
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[7] {
+				seen[7] = true
+				s.ExtPDPType = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1621: // Ext-PDP-Address
+			if v, ok := a.Data.(datatype.Address); ok && !seen[8] {
+				seen[8] = true
+				s.ExtPDPAddress = net.IP(v)
+			}
+		case 10415<<32 | 1435: // AMBR
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[9] {
+				seen[9] = true
+				s.AMBR = new(AMBR)
+				if err := s.AMBR.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1613: // SIPTO-Permission
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[10] {
+				seen[10] = true
+				e := int32(v)
+				s.SIPTOPermission = &e
+			}
+		case 10415<<32 | 1618: // LIPA-Permission
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[11] {
+				seen[11] = true
+				e := int32(v)
+				s.LIPAPermission = &e
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *GPRSSubscriptionData) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(1468, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(s.CompleteDataListIncludedIndicator)))
+	for i := range s.PDPContext {
+		g, err := s.PDPContext[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1469, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *GPRSSubscriptionData) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.PDPContext = s.PDPContext[:0]
+	var seen [1]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1468: // Complete-Data-List-Included-Indicator
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[0] {
+				seen[0] = true
+				s.CompleteDataListIncludedIndicator = int32(v)
+			}
+		case 10415<<32 | 1469: // PDP-Context
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e PDPContext
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.PDPContext = append(s.PDPContext, e)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *CSGSubscriptionData) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	avps = append(avps, diam.NewAVP(1437, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.CSGID)))
+	if s.ExpirationDate != nil {
+		avps = append(avps, diam.NewAVP(1439, avp.Mbit|avp.Vbit, 10415, datatype.Time(*s.ExpirationDate)))
+	}
+	for _, v := range s.ServiceSelection {
+		avps = append(avps, diam.NewAVP(493, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(v)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *CSGSubscriptionData) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.ServiceSelection = s.ServiceSelection[:0]
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1437: // CSG-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.CSGID = uint32(v)
+			}
+		case 10415<<32 | 1439: // Expiration-Date
+			if v, ok := a.Data.(datatype.Time); ok && !seen[1] {
+				seen[1] = true
+				e := time.Time(v)
+				s.ExpirationDate = &e
+			}
+		case 10415<<32 | 493: // Service-Selection
+			if v, ok := a.Data.(datatype.UTF8String); ok {
+				s.ServiceSelection = append(s.ServiceSelection, string(v))
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *TraceData) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 7)
+	avps = append(avps, diam.NewAVP(1459, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.TraceReference)))
+	avps = append(avps, diam.NewAVP(1462, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(s.TraceDepth)))
+	avps = append(avps, diam.NewAVP(1463, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.TraceNETypeList)))
+	if len(s.TraceInterfaceList) > 0 {
+		avps = append(avps, diam.NewAVP(1464, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.TraceInterfaceList)))
+	}
+	avps = append(avps, diam.NewAVP(1465, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.TraceEventList)))
+	if len(s.OMCID) > 0 {
+		avps = append(avps, diam.NewAVP(1466, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.OMCID)))
+	}
+	avps = append(avps, diam.NewAVP(1452, avp.Mbit|avp.Vbit, 10415, datatype.Address(s.TraceCollectionEntity)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *TraceData) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [7]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1459: // Trace-Reference
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[0] {
+				seen[0] = true
+				s.TraceReference = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1462: // Trace-Depth
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[1] {
+				seen[1] = true
+				s.TraceDepth = int32(v)
+			}
+		case 10415<<32 | 1463: // Trace-NE-Type-List
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[2] {
+				seen[2] = true
+				s.TraceNETypeList = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1464: // Trace-Interface-List
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[3] {
+				seen[3] = true
+				s.TraceInterfaceList = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1465: // Trace-Event-List
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[4] {
+				seen[4] = true
+				s.TraceEventList = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1466: // OMC-Id
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[5] {
+				seen[5] = true
+				s.OMCID = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1452: // Trace-Collection-Entity
+			if v, ok := a.Data.(datatype.Address); ok && !seen[6] {
+				seen[6] = true
+				s.TraceCollectionEntity = net.IP(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *SSInfo) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(1476, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.SSCode)))
+	avps = append(avps, diam.NewAVP(1477, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.SSStatus)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *SSInfo) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1476: // SS-Code
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[0] {
+				seen[0] = true
+				s.SSCode = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1477: // SS-Status
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.SSStatus = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ExternalClient) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	avps = append(avps, diam.NewAVP(1480, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.ClientIdentity)))
+	if s.GMLCRestriction != nil {
+		avps = append(avps, diam.NewAVP(1481, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.GMLCRestriction)))
+	}
+	if s.NotificationToUEUser != nil {
+		avps = append(avps, diam.NewAVP(1478, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.NotificationToUEUser)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ExternalClient) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1480: // Client-Identity
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[0] {
+				seen[0] = true
+				s.ClientIdentity = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1481: // GMLC-Restriction
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[1] {
+				seen[1] = true
+				e := int32(v)
+				s.GMLCRestriction = &e
+			}
+		case 10415<<32 | 1478: // Notification-To-UE-User
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				e := int32(v)
+				s.NotificationToUEUser = &e
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *LCSPrivacyException) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 5)
+	avps = append(avps, diam.NewAVP(1476, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.SSCode)))
+	avps = append(avps, diam.NewAVP(1477, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.SSStatus)))
+	if s.NotificationToUEUser != nil {
+		avps = append(avps, diam.NewAVP(1478, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.NotificationToUEUser)))
+	}
+	for i := range s.ExternalClient {
+		g, err := s.ExternalClient[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1479, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for _, v := range s.PLMNClient {
+		avps = append(avps, diam.NewAVP(1482, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(v)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *LCSPrivacyException) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.ExternalClient = s.ExternalClient[:0]
+	s.PLMNClient = s.PLMNClient[:0]
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1476: // SS-Code
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[0] {
+				seen[0] = true
+				s.SSCode = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1477: // SS-Status
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.SSStatus = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1478: // Notification-To-UE-User
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				e := int32(v)
+				s.NotificationToUEUser = &e
+			}
+		case 10415<<32 | 1479: // External-Client
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e ExternalClient
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.ExternalClient = append(s.ExternalClient, e)
+			}
+		case 10415<<32 | 1482: // PLMN-Client
+			if v, ok := a.Data.(datatype.Enumerated); ok {
+				s.PLMNClient = append(s.PLMNClient, int32(v))
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *LCSInfo) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	for _, v := range s.GMLCNumber {
+		avps = append(avps, diam.NewAVP(1474, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(v)))
+	}
+	for i := range s.LCSPrivacyException {
+		g, err := s.LCSPrivacyException[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1475, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.MOLR {
+		g, err := s.MOLR[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1485, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *LCSInfo) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.GMLCNumber = s.GMLCNumber[:0]
+	s.LCSPrivacyException = s.LCSPrivacyException[:0]
+	s.MOLR = s.MOLR[:0]
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1474: // GMLC-Number
+			if v, ok := a.Data.(datatype.OctetString); ok {
+				s.GMLCNumber = append(s.GMLCNumber, datatype.OctetString(v))
+			}
+		case 10415<<32 | 1475: // LCS-PrivacyException
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e LCSPrivacyException
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.LCSPrivacyException = append(s.LCSPrivacyException, e)
+			}
+		case 10415<<32 | 1485: // MO-LR
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SSInfo
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.MOLR = append(s.MOLR, e)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *TeleserviceList) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 1)
+	avps = append(avps, diam.NewAVP(1487, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.TSCode)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *TeleserviceList) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [1]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1487: // TS-Code
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[0] {
+				seen[0] = true
+				s.TSCode = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *SubscriptionData) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 26)
+	if s.SubscriberStatus != nil {
+		avps = append(avps, diam.NewAVP(1424, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.SubscriberStatus)))
+	}
+	if len(s.MSISDN) > 0 {
+		avps = append(avps, diam.NewAVP(701, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.MSISDN)))
+	}
+	if len(s.STNSR) > 0 {
+		avps = append(avps, diam.NewAVP(1433, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.STNSR)))
+	}
+	if s.ICSIndicator != nil {
+		avps = append(avps, diam.NewAVP(1491, avp.Vbit, 10415, datatype.Enumerated(*s.ICSIndicator)))
+	}
+	if s.NetworkAccessMode != nil {
+		avps = append(avps, diam.NewAVP(1417, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.NetworkAccessMode)))
+	}
+	if s.OperatorDeterminedBarring != nil {
+		avps = append(avps, diam.NewAVP(1425, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(*s.OperatorDeterminedBarring)))
+	}
+	if s.HPLMNODB != nil {
+		avps = append(avps, diam.NewAVP(1418, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(*s.HPLMNODB)))
+	}
+	for _, v := range s.RegionalSubscriptionZoneCode {
+		avps = append(avps, diam.NewAVP(1446, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(v)))
+	}
+	if s.AccessRestrictionData != nil {
+		avps = append(avps, diam.NewAVP(1426, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(*s.AccessRestrictionData)))
+	}
+	if len(s.APNOIReplacement) > 0 {
+		avps = append(avps, diam.NewAVP(1427, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.APNOIReplacement)))
+	}
+	if s.LCSInfo != nil {
+		g, err := s.LCSInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1473, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.TeleserviceList != nil {
+		g, err := s.TeleserviceList.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1486, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.CallBarringInfo {
+		g, err := s.CallBarringInfo[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1488, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if len(s.TGPPChargingCharacteristics) > 0 {
+		avps = append(avps, diam.NewAVP(13, avp.Vbit, 10415, datatype.UTF8String(s.TGPPChargingCharacteristics)))
+	}
+	if s.AMBR != nil {
+		g, err := s.AMBR.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1435, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.APNConfigurationProfile != nil {
+		g, err := s.APNConfigurationProfile.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1429, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.RATFrequencySelectionPriorityID != nil {
+		avps = append(avps, diam.NewAVP(1440, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(*s.RATFrequencySelectionPriorityID)))
+	}
+	if s.TraceData != nil {
+		g, err := s.TraceData.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1458, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.GPRSSubscriptionData != nil {
+		g, err := s.GPRSSubscriptionData.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1467, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.CSGSubscriptionData {
+		g, err := s.CSGSubscriptionData[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1436, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.RoamingRestrictedDueToUnsupportedFeature != nil {
+		avps = append(avps, diam.NewAVP(1457, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.RoamingRestrictedDueToUnsupportedFeature)))
+	}
+	if s.SubscribedPeriodicRAUTAUTimer != nil {
+		avps = append(avps, diam.NewAVP(1619, avp.Vbit, 10415, datatype.Unsigned32(*s.SubscribedPeriodicRAUTAUTimer)))
+	}
+	if s.MPSPriority != nil {
+		avps = append(avps, diam.NewAVP(1616, avp.Vbit, 10415, datatype.Unsigned32(*s.MPSPriority)))
+	}
+	if s.VPLMNLIPAAllowed != nil {
+		avps = append(avps, diam.NewAVP(1617, avp.Vbit, 10415, datatype.Enumerated(*s.VPLMNLIPAAllowed)))
+	}
+	if s.RelayNodeIndicator != nil {
+		avps = append(avps, diam.NewAVP(1633, avp.Vbit, 10415, datatype.Enumerated(*s.RelayNodeIndicator)))
+	}
+	if s.MDTUserConsent != nil {
+		avps = append(avps, diam.NewAVP(1634, avp.Vbit, 10415, datatype.Enumerated(*s.MDTUserConsent)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *SubscriptionData) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.RegionalSubscriptionZoneCode = s.RegionalSubscriptionZoneCode[:0]
+	s.CallBarringInfo = s.CallBarringInfo[:0]
+	s.CSGSubscriptionData = s.CSGSubscriptionData[:0]
+	var seen [23]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1424: // Subscriber-Status
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[0] {
+				seen[0] = true
+				e := int32(v)
+				s.SubscriberStatus = &e
+			}
+		case 10415<<32 | 701: // MSISDN
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.MSISDN = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1433: // STN-SR
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[2] {
+				seen[2] = true
+				s.STNSR = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1491: // ICS-Indicator
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[3] {
+				seen[3] = true
+				e := int32(v)
+				s.ICSIndicator = &e
+			}
+		case 10415<<32 | 1417: // Network-Access-Mode
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				e := int32(v)
+				s.NetworkAccessMode = &e
+			}
+		case 10415<<32 | 1425: // Operator-Determined-Barring
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[5] {
+				seen[5] = true
+				e := uint32(v)
+				s.OperatorDeterminedBarring = &e
+			}
+		case 10415<<32 | 1418: // HPLMN-ODB
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[6] {
+				seen[6] = true
+				e := uint32(v)
+				s.HPLMNODB = &e
+			}
+		case 10415<<32 | 1446: // Regional-Subscription-Zone-Code
+			if v, ok := a.Data.(datatype.OctetString); ok {
+				s.RegionalSubscriptionZoneCode = append(s.RegionalSubscriptionZoneCode, datatype.OctetString(v))
+			}
+		case 10415<<32 | 1426: // Access-Restriction-Data
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[7] {
+				seen[7] = true
+				e := uint32(v)
+				s.AccessRestrictionData = &e
+			}
+		case 10415<<32 | 1427: // APN-OI-Replacement
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[8] {
+				seen[8] = true
+				s.APNOIReplacement = string(v)
+			}
+		case 10415<<32 | 1473: // LCS-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[9] {
+				seen[9] = true
+				s.LCSInfo = new(LCSInfo)
+				if err := s.LCSInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1486: // Teleservice-List
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[10] {
+				seen[10] = true
+				s.TeleserviceList = new(TeleserviceList)
+				if err := s.TeleserviceList.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1488: // Call-Barring-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SSInfo
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.CallBarringInfo = append(s.CallBarringInfo, e)
+			}
+		case 10415<<32 | 13: // TGPP-Charging-Characteristics
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[11] {
+				seen[11] = true
+				s.TGPPChargingCharacteristics = string(v)
+			}
+		case 10415<<32 | 1435: // AMBR
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[12] {
+				seen[12] = true
+				s.AMBR = new(AMBR)
+				if err := s.AMBR.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1429: // APN-Configuration-Profile
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[13] {
+				seen[13] = true
+				s.APNConfigurationProfile = new(APNConfigurationProfile)
+				if err := s.APNConfigurationProfile.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1440: // RAT-Frequency-Selection-Priority-ID
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[14] {
+				seen[14] = true
+				e := uint32(v)
+				s.RATFrequencySelectionPriorityID = &e
+			}
+		case 10415<<32 | 1458: // Trace-Data
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[15] {
+				seen[15] = true
+				s.TraceData = new(TraceData)
+				if err := s.TraceData.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1467: // GPRS-Subscription-Data
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[16] {
+				seen[16] = true
+				s.GPRSSubscriptionData = new(GPRSSubscriptionData)
+				if err := s.GPRSSubscriptionData.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1436: // CSG-Subscription-Data
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e CSGSubscriptionData
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.CSGSubscriptionData = append(s.CSGSubscriptionData, e)
+			}
+		case 10415<<32 | 1457: // Roaming-Restricted-Due-To-Unsupported-Feature
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[17] {
+				seen[17] = true
+				e := int32(v)
+				s.RoamingRestrictedDueToUnsupportedFeature = &e
+			}
+		case 10415<<32 | 1619: // Subscribed-Periodic-RAU-TAU-Timer
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[18] {
+				seen[18] = true
+				e := uint32(v)
+				s.SubscribedPeriodicRAUTAUTimer = &e
+			}
+		case 10415<<32 | 1616: // MPS-Priority
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[19] {
+				seen[19] = true
+				e := uint32(v)
+				s.MPSPriority = &e
+			}
+		case 10415<<32 | 1617: // VPLMN-LIPA-Allowed
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[20] {
+				seen[20] = true
+				e := int32(v)
+				s.VPLMNLIPAAllowed = &e
+			}
+		case 10415<<32 | 1633: // Relay-Node-Indicator
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[21] {
+				seen[21] = true
+				e := int32(v)
+				s.RelayNodeIndicator = &e
+			}
+		case 10415<<32 | 1634: // MDT-User-Consent
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[22] {
+				seen[22] = true
+				e := int32(v)
+				s.MDTUserConsent = &e
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *RequestedAuthInfo) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	if s.NumberOfRequestedVectors != 0 {
+		avps = append(avps, diam.NewAVP(1410, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.NumberOfRequestedVectors)))
+	}
+	if s.ImmediateResponsePreferred != nil {
+		avps = append(avps, diam.NewAVP(1412, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(*s.ImmediateResponsePreferred)))
+	}
+	if len(s.ResynchronizationInfo) > 0 {
+		avps = append(avps, diam.NewAVP(1411, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.ResynchronizationInfo)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *RequestedAuthInfo) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1410: // Number-Of-Requested-Vectors
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.NumberOfRequestedVectors = uint32(v)
+			}
+		case 10415<<32 | 1412: // Immediate-Response-Preferred
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[1] {
+				seen[1] = true
+				e := uint32(v)
+				s.ImmediateResponsePreferred = &e
+			}
+		case 10415<<32 | 1411: // Re-synchronization-Info
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[2] {
+				seen[2] = true
+				s.ResynchronizationInfo = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *EUTRANVector) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 5)
+	if s.ItemNumber != 0 {
+		avps = append(avps, diam.NewAVP(1419, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ItemNumber)))
+	}
+	avps = append(avps, diam.NewAVP(1447, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.RAND)))
+	avps = append(avps, diam.NewAVP(1448, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.XRES)))
+	avps = append(avps, diam.NewAVP(1449, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.AUTN)))
+	avps = append(avps, diam.NewAVP(1450, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.KASME)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *EUTRANVector) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [5]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1419: // Item-Number
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.ItemNumber = uint32(v)
+			}
+		case 10415<<32 | 1447: // RAND
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.RAND = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1448: // XRES
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[2] {
+				seen[2] = true
+				s.XRES = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1449: // AUTN
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[3] {
+				seen[3] = true
+				s.AUTN = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1450: // KASME
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[4] {
+				seen[4] = true
+				s.KASME = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *UTRANVector) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 6)
+	if s.ItemNumber != 0 {
+		avps = append(avps, diam.NewAVP(1419, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ItemNumber)))
+	}
+	avps = append(avps, diam.NewAVP(1447, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.RAND)))
+	avps = append(avps, diam.NewAVP(1448, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.XRES)))
+	avps = append(avps, diam.NewAVP(1449, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.AUTN)))
+	avps = append(avps, diam.NewAVP(625, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.ConfidentialityKey)))
+	avps = append(avps, diam.NewAVP(626, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.IntegrityKey)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *UTRANVector) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [6]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1419: // Item-Number
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.ItemNumber = uint32(v)
+			}
+		case 10415<<32 | 1447: // RAND
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.RAND = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1448: // XRES
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[2] {
+				seen[2] = true
+				s.XRES = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1449: // AUTN
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[3] {
+				seen[3] = true
+				s.AUTN = datatype.OctetString(v)
+			}
+		case 10415<<32 | 625: // Confidentiality-Key
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[4] {
+				seen[4] = true
+				s.ConfidentialityKey = datatype.OctetString(v)
+			}
+		case 10415<<32 | 626: // Integrity-Key
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[5] {
+				seen[5] = true
+				s.IntegrityKey = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *GERANVector) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 4)
+	if s.ItemNumber != 0 {
+		avps = append(avps, diam.NewAVP(1419, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ItemNumber)))
+	}
+	avps = append(avps, diam.NewAVP(1447, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.RAND)))
+	avps = append(avps, diam.NewAVP(1454, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.SRES)))
+	avps = append(avps, diam.NewAVP(1453, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.Kc)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *GERANVector) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [4]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1419: // Item-Number
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.ItemNumber = uint32(v)
+			}
+		case 10415<<32 | 1447: // RAND
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.RAND = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1454: // SRES
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[2] {
+				seen[2] = true
+				s.SRES = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1453: // Kc
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[3] {
+				seen[3] = true
+				s.Kc = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *AuthenticationInfo) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	for i := range s.EUTRANVector {
+		g, err := s.EUTRANVector[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1414, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.UTRANVector {
+		g, err := s.UTRANVector[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1415, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.GERANVector {
+		g, err := s.GERANVector[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1416, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *AuthenticationInfo) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.EUTRANVector = s.EUTRANVector[:0]
+	s.UTRANVector = s.UTRANVector[:0]
+	s.GERANVector = s.GERANVector[:0]
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1414: // E-UTRAN-Vector
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e EUTRANVector
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.EUTRANVector = append(s.EUTRANVector, e)
+			}
+		case 10415<<32 | 1415: // UTRAN-Vector
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e UTRANVector
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.UTRANVector = append(s.UTRANVector, e)
+			}
+		case 10415<<32 | 1416: // GERAN-Vector
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e GERANVector
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.GERANVector = append(s.GERANVector, e)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ActiveAPN) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 5)
+	avps = append(avps, diam.NewAVP(1423, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ContextIdentifier)))
+	if len(s.ServiceSelection) > 0 {
+		avps = append(avps, diam.NewAVP(493, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.ServiceSelection)))
+	}
+	if s.MIP6AgentInfo != nil {
+		g, err := s.MIP6AgentInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(486, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if len(s.VisitedNetworkIdentifier) > 0 {
+		avps = append(avps, diam.NewAVP(600, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.VisitedNetworkIdentifier)))
+	}
+	for i := range s.SpecificAPNInfo {
+		g, err := s.SpecificAPNInfo[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1472, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ActiveAPN) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SpecificAPNInfo = s.SpecificAPNInfo[:0]
+	var seen [4]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1423: // Context-Identifier
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.ContextIdentifier = uint32(v)
+			}
+		case 10415<<32 | 493: // Service-Selection
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[1] {
+				seen[1] = true
+				s.ServiceSelection = string(v)
+			}
+		case 10415<<32 | 486: // MIP6-Agent-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[2] {
+				seen[2] = true
+				s.MIP6AgentInfo = new(MIP6AgentInfo)
+				if err := s.MIP6AgentInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 600: // Visited-Network-Identifier
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[3] {
+				seen[3] = true
+				s.VisitedNetworkIdentifier = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1472: // Specific-APN-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SpecificAPNInfo
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SpecificAPNInfo = append(s.SpecificAPNInfo, e)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ULR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 18)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if len(s.DestinationHost) > 0 {
+		avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	}
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.TerminalInformation != nil {
+		g, err := s.TerminalInformation.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1401, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(1032, avp.Vbit, 10415, datatype.Enumerated(s.RATType)))
+	avps = append(avps, diam.NewAVP(1405, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ULRFlags)))
+	if s.UESRVCCCapability != nil {
+		avps = append(avps, diam.NewAVP(1615, avp.Vbit, 10415, datatype.Enumerated(*s.UESRVCCCapability)))
+	}
+	avps = append(avps, diam.NewAVP(1407, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.VisitedPLMNID)))
+	if len(s.SGSNNumber) > 0 {
+		avps = append(avps, diam.NewAVP(1489, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.SGSNNumber)))
+	}
+	if s.HomogeneousSupportOfIMSVoPS != nil {
+		avps = append(avps, diam.NewAVP(1493, avp.Vbit, 10415, datatype.Enumerated(*s.HomogeneousSupportOfIMSVoPS)))
+	}
+	if len(s.GMLCAddress) > 0 {
+		avps = append(avps, diam.NewAVP(2405, avp.Mbit|avp.Vbit, 10415, datatype.Address(s.GMLCAddress)))
+	}
+	for i := range s.ActiveAPN {
+		g, err := s.ActiveAPN[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1612, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ULR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	s.ActiveAPN = s.ActiveAPN[:0]
+	var seen [16]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[7] {
+				seen[7] = true
+				s.UserName = string(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1401: // Terminal-Information
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[8] {
+				seen[8] = true
+				s.TerminalInformation = new(TerminalInformation)
+				if err := s.TerminalInformation.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1032: // RAT-Type
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[9] {
+				seen[9] = true
+				s.RATType = int32(v)
+			}
+		case 10415<<32 | 1405: // ULR-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[10] {
+				seen[10] = true
+				s.ULRFlags = uint32(v)
+			}
+		case 10415<<32 | 1615: // UE-SRVCC-Capability
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[11] {
+				seen[11] = true
+				e := int32(v)
+				s.UESRVCCCapability = &e
+			}
+		case 10415<<32 | 1407: // Visited-PLMN-Id
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[12] {
+				seen[12] = true
+				s.VisitedPLMNID = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1489: // SGSN-Number
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[13] {
+				seen[13] = true
+				s.SGSNNumber = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1493: // Homogeneous-Support-of-IMS-Voice-Over-PS-Sessions
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[14] {
+				seen[14] = true
+				e := int32(v)
+				s.HomogeneousSupportOfIMSVoPS = &e
+			}
+		case 10415<<32 | 2405: // GMLC-Address
+			if v, ok := a.Data.(datatype.Address); ok && !seen[15] {
+				seen[15] = true
+				s.GMLCAddress = net.IP(v)
+			}
+		case 10415<<32 | 1612: // Active-APN
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e ActiveAPN
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.ActiveAPN = append(s.ActiveAPN, e)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ULA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 11)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ErrorDiagnostic != nil {
+		avps = append(avps, diam.NewAVP(1614, avp.Vbit, 10415, datatype.Enumerated(*s.ErrorDiagnostic)))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ULAFlags != 0 {
+		avps = append(avps, diam.NewAVP(1406, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.ULAFlags)))
+	}
+	if s.SubscriptionData != nil {
+		g, err := s.SubscriptionData.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1400, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ULA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [10]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1614: // Error-Diagnostic
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				e := int32(v)
+				s.ErrorDiagnostic = &e
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[5] {
+				seen[5] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[7] {
+				seen[7] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1406: // ULA-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[8] {
+				seen[8] = true
+				s.ULAFlags = uint32(v)
+			}
+		case 10415<<32 | 1400: // Subscription-Data
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[9] {
+				seen[9] = true
+				s.SubscriptionData = new(SubscriptionData)
+				if err := s.SubscriptionData.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *AIR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 12)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if len(s.DestinationHost) > 0 {
+		avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	}
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.RequestedEUTRANAuthInfo != nil {
+		g, err := s.RequestedEUTRANAuthInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1408, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.RequestedUTRANGERANAuthInfo != nil {
+		g, err := s.RequestedUTRANGERANAuthInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1409, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(1407, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.VisitedPLMNID)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *AIR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [11]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[7] {
+				seen[7] = true
+				s.UserName = string(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1408: // Requested-EUTRAN-Authentication-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[8] {
+				seen[8] = true
+				s.RequestedEUTRANAuthInfo = new(RequestedAuthInfo)
+				if err := s.RequestedEUTRANAuthInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1409: // Requested-UTRAN-GERAN-Authentication-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[9] {
+				seen[9] = true
+				s.RequestedUTRANGERANAuthInfo = new(RequestedAuthInfo)
+				if err := s.RequestedUTRANGERANAuthInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1407: // Visited-PLMN-Id
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[10] {
+				seen[10] = true
+				s.VisitedPLMNID = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *AIA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 10)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ErrorDiagnostic != nil {
+		avps = append(avps, diam.NewAVP(1614, avp.Vbit, 10415, datatype.Enumerated(*s.ErrorDiagnostic)))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.AuthenticationInfo != nil {
+		g, err := s.AuthenticationInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1413, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *AIA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [9]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1614: // Error-Diagnostic
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				e := int32(v)
+				s.ErrorDiagnostic = &e
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[5] {
+				seen[5] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[7] {
+				seen[7] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1413: // Authentication-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[8] {
+				seen[8] = true
+				s.AuthenticationInfo = new(AuthenticationInfo)
+				if err := s.AuthenticationInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *CLR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 11)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(1420, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(s.CancellationType)))
+	if s.CLRFlags != 0 {
+		avps = append(avps, diam.NewAVP(1638, avp.Vbit, 10415, datatype.Unsigned32(s.CLRFlags)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *CLR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [10]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[7] {
+				seen[7] = true
+				s.UserName = string(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1420: // Cancellation-Type
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[8] {
+				seen[8] = true
+				s.CancellationType = int32(v)
+			}
+		case 10415<<32 | 1638: // CLR-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[9] {
+				seen[9] = true
+				s.CLRFlags = uint32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *CLA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 8)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *CLA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [7]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *IDR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 12)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	{
+		g, err := s.SubscriptionData.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1400, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.IDRFlags != 0 {
+		avps = append(avps, diam.NewAVP(1490, avp.Vbit, 10415, datatype.Unsigned32(s.IDRFlags)))
+	}
+	for _, v := range s.ResetID {
+		avps = append(avps, diam.NewAVP(1670, avp.Vbit, 10415, datatype.OctetString(v)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *IDR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	s.ResetID = s.ResetID[:0]
+	var seen [10]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[7] {
+				seen[7] = true
+				s.UserName = string(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1400: // Subscription-Data
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[8] {
+				seen[8] = true
+				if err := s.SubscriptionData.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 1490: // IDR-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[9] {
+				seen[9] = true
+				s.IDRFlags = uint32(v)
+			}
+		case 10415<<32 | 1670: // Reset-ID
+			if v, ok := a.Data.(datatype.OctetString); ok {
+				s.ResetID = append(s.ResetID, datatype.OctetString(v))
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *IDA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 12)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if s.IMSVoiceOverPSSessionsSupported != nil {
+		avps = append(avps, diam.NewAVP(1492, avp.Vbit, 10415, datatype.Enumerated(*s.IMSVoiceOverPSSessionsSupported)))
+	}
+	if s.LastUEActivityTime != nil {
+		avps = append(avps, diam.NewAVP(1494, avp.Vbit, 10415, datatype.Time(*s.LastUEActivityTime)))
+	}
+	if s.RATType != nil {
+		avps = append(avps, diam.NewAVP(1032, avp.Vbit, 10415, datatype.Enumerated(*s.RATType)))
+	}
+	if s.IDAFlags != 0 {
+		avps = append(avps, diam.NewAVP(1441, avp.Vbit, 10415, datatype.Unsigned32(s.IDAFlags)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *IDA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [11]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 1492: // IMS-Voice-Over-PS-Sessions-Supported
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[7] {
+				seen[7] = true
+				e := int32(v)
+				s.IMSVoiceOverPSSessionsSupported = &e
+			}
+		case 10415<<32 | 1494: // Last-UE-Activity-Time
+			if v, ok := a.Data.(datatype.Time); ok && !seen[8] {
+				seen[8] = true
+				e := time.Time(v)
+				s.LastUEActivityTime = &e
+			}
+		case 10415<<32 | 1032: // RAT-Type
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[9] {
+				seen[9] = true
+				e := int32(v)
+				s.RATType = &e
+			}
+		case 10415<<32 | 1441: // IDA-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[10] {
+				seen[10] = true
+				s.IDAFlags = uint32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *DSR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 14)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(1421, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.DSRFlags)))
+	for _, v := range s.ContextIdentifier {
+		avps = append(avps, diam.NewAVP(1423, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(v)))
+	}
+	if len(s.TraceReference) > 0 {
+		avps = append(avps, diam.NewAVP(1459, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.TraceReference)))
+	}
+	for _, v := range s.TSCode {
+		avps = append(avps, diam.NewAVP(1487, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(v)))
+	}
+	for _, v := range s.SSCode {
+		avps = append(avps, diam.NewAVP(1476, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(v)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *DSR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	s.ContextIdentifier = s.ContextIdentifier[:0]
+	s.TSCode = s.TSCode[:0]
+	s.SSCode = s.SSCode[:0]
+	var seen [10]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[7] {
+				seen[7] = true
+				s.UserName = string(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1421: // DSR-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[8] {
+				seen[8] = true
+				s.DSRFlags = uint32(v)
+			}
+		case 10415<<32 | 1423: // Context-Identifier
+			if v, ok := a.Data.(datatype.Unsigned32); ok {
+				s.ContextIdentifier = append(s.ContextIdentifier, uint32(v))
+			}
+		case 10415<<32 | 1459: // Trace-Reference
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[9] {
+				seen[9] = true
+				s.TraceReference = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1487: // TS-Code
+			if v, ok := a.Data.(datatype.OctetString); ok {
+				s.TSCode = append(s.TSCode, datatype.OctetString(v))
+			}
+		case 10415<<32 | 1476: // SS-Code
+			if v, ok := a.Data.(datatype.OctetString); ok {
+				s.SSCode = append(s.SSCode, datatype.OctetString(v))
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *DSA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 9)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if s.DSAFlags != 0 {
+		avps = append(avps, diam.NewAVP(1422, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.DSAFlags)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *DSA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [8]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 1422: // DSA-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[7] {
+				seen[7] = true
+				s.DSAFlags = uint32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *PUR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 10)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if len(s.DestinationHost) > 0 {
+		avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	}
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	if s.PURFlags != 0 {
+		avps = append(avps, diam.NewAVP(1635, avp.Vbit, 10415, datatype.Unsigned32(s.PURFlags)))
+	}
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *PUR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [9]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[7] {
+				seen[7] = true
+				s.UserName = string(v)
+			}
+		case 10415<<32 | 1635: // PUR-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[8] {
+				seen[8] = true
+				s.PURFlags = uint32(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *PUA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 9)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if s.PUAFlags != 0 {
+		avps = append(avps, diam.NewAVP(1442, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.PUAFlags)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *PUA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [8]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 1442: // PUA-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[7] {
+				seen[7] = true
+				s.PUAFlags = uint32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *RSR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 9)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if len(s.DestinationHost) > 0 {
+		avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	}
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	for _, v := range s.UserID {
+		avps = append(avps, diam.NewAVP(1444, avp.Vbit, 10415, datatype.UTF8String(v)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *RSR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	s.UserID = s.UserID[:0]
+	var seen [7]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1444: // User-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok {
+				s.UserID = append(s.UserID, string(v))
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *RSA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 8)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *RSA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [7]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *NOR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 18)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if len(s.DestinationHost) > 0 {
+		avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	}
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.TerminalInformation != nil {
+		g, err := s.TerminalInformation.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1401, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.MIP6AgentInfo != nil {
+		g, err := s.MIP6AgentInfo.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(486, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	if len(s.VisitedNetworkIdentifier) > 0 {
+		avps = append(avps, diam.NewAVP(600, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.VisitedNetworkIdentifier)))
+	}
+	if s.ContextIdentifier != nil {
+		avps = append(avps, diam.NewAVP(1423, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(*s.ContextIdentifier)))
+	}
+	if len(s.ServiceSelection) > 0 {
+		avps = append(avps, diam.NewAVP(493, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.ServiceSelection)))
+	}
+	if s.AlertReason != nil {
+		avps = append(avps, diam.NewAVP(1434, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.AlertReason)))
+	}
+	if s.UESRVCCCapability != nil {
+		avps = append(avps, diam.NewAVP(1615, avp.Vbit, 10415, datatype.Enumerated(*s.UESRVCCCapability)))
+	}
+	if s.NORFlags != 0 {
+		avps = append(avps, diam.NewAVP(1443, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(s.NORFlags)))
+	}
+	if s.HomogeneousSupportOfIMSVoPS != nil {
+		avps = append(avps, diam.NewAVP(1493, avp.Vbit, 10415, datatype.Enumerated(*s.HomogeneousSupportOfIMSVoPS)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *NOR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [17]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[7] {
+				seen[7] = true
+				s.UserName = string(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		case 10415<<32 | 1401: // Terminal-Information
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[8] {
+				seen[8] = true
+				s.TerminalInformation = new(TerminalInformation)
+				if err := s.TerminalInformation.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 486: // MIP6-Agent-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[9] {
+				seen[9] = true
+				s.MIP6AgentInfo = new(MIP6AgentInfo)
+				if err := s.MIP6AgentInfo.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 10415<<32 | 600: // Visited-Network-Identifier
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[10] {
+				seen[10] = true
+				s.VisitedNetworkIdentifier = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1423: // Context-Identifier
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[11] {
+				seen[11] = true
+				e := uint32(v)
+				s.ContextIdentifier = &e
+			}
+		case 10415<<32 | 493: // Service-Selection
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[12] {
+				seen[12] = true
+				s.ServiceSelection = string(v)
+			}
+		case 10415<<32 | 1434: // Alert-Reason
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[13] {
+				seen[13] = true
+				e := int32(v)
+				s.AlertReason = &e
+			}
+		case 10415<<32 | 1615: // UE-SRVCC-Capability
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[14] {
+				seen[14] = true
+				e := int32(v)
+				s.UESRVCCCapability = &e
+			}
+		case 10415<<32 | 1443: // NOR-Flags
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[15] {
+				seen[15] = true
+				s.NORFlags = uint32(v)
+			}
+		case 10415<<32 | 1493: // Homogeneous-Support-of-IMS-Voice-Over-PS-Sessions
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[16] {
+				seen[16] = true
+				e := int32(v)
+				s.HomogeneousSupportOfIMSVoPS = &e
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *NOA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 8)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != 0 {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	for i := range s.SupportedFeatures {
+		g, err := s.SupportedFeatures[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(628, avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *NOA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.SupportedFeatures = s.SupportedFeatures[:0]
+	var seen [7]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = string(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.ResultCode = uint32(v)
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				s.AuthSessionState = int32(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 628: // Supported-Features
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e SupportedFeatures
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.SupportedFeatures = append(s.SupportedFeatures, e)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s6a
+
+import (
+	"errors"
+
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// ErrInvalidPLMNID is returned when a PLMN identity can't be encoded or
+// decoded.
+var ErrInvalidPLMNID = errors.New("s6a: invalid PLMN ID")
+
+// EncodePLMNID encodes a 3-digit Mobile Country Code and a 2 or 3-digit
+// Mobile Network Code as the 3 octets of Visited-PLMN-Id.
+// See 3GPP TS 29.272 section 7.3.9.
+func EncodePLMNID(mcc, mnc string) (datatype.OctetString, error) {
+	if len(mcc) != 3 || len(mnc) < 2 || len(mnc) > 3 || !digits(mcc) || !digits(mnc) {
+		return "", ErrInvalidPLMNID
+	}
+	mnc3 := byte(0xf)
+	if len(mnc) == 3 {
+		mnc3 = mnc[2] - '0'
+	}
+	return datatype.OctetString([]byte{
+		(mcc[1]-'0')<<4 | (mcc[0] - '0'),
+		mnc3<<4 | (mcc[2] - '0'),
+		(mnc[1]-'0')<<4 | (mnc[0] - '0'),
+	}), nil
+}
+
+// DecodePLMNID decodes the Mobile Country Code and Mobile Network Code
+// of a Visited-PLMN-Id.
+func DecodePLMNID(b datatype.OctetString) (mcc, mnc string, err error) {
+	if len(b) != 3 {
+		return "", "", ErrInvalidPLMNID
+	}
+	d := []byte{
+		b[0] & 0xf, b[0] >> 4, b[1] & 0xf, // MCC
+		b[2] & 0xf, b[2] >> 4, b[1] >> 4, // MNC
+	}
+	n := len(d)
+	if d[5] == 0xf {
+		n--
+	}
+	for i := 0; i < n; i++ {
+		if d[i] > 9 {
+			return "", "", ErrInvalidPLMNID
+		}
+		d[i] += '0'
+	}
+	return string(d[:3]), string(d[3:n]), nil
+}
+
+func digits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
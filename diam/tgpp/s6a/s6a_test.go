@@ -0,0 +1,418 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s6a
+
+import (
+	"bytes"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+)
+
+func roundTrip(t *testing.T, m *diam.Message, in, out interface{}) {
+	t.Helper()
+	if err := m.Marshal(in); err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dec.Unmarshal(out); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func int32p(v int32) *int32 { return &v }
+
+func newTestULA() *ULA {
+	return &ULA{
+		SessionID:                   "mme;1",
+		VendorSpecificApplicationID: vendorSpecificApplicationID(),
+		ResultCode:                  diam.Success,
+		AuthSessionState:            NoStateMaintained,
+		OriginHost:                  "hss",
+		OriginRealm:                 "test",
+		ULAFlags:                    ULASeparationIndication,
+		SubscriptionData: &SubscriptionData{
+			SubscriberStatus:  int32p(ServiceGranted),
+			MSISDN:            "\x21\x43\x65",
+			NetworkAccessMode: int32p(OnlyPacket),
+			AMBR:              &AMBR{MaxRequestedBandwidthUL: 50000000, MaxRequestedBandwidthDL: 100000000},
+			APNConfigurationProfile: &APNConfigurationProfile{
+				ContextIdentifier:                     1,
+				AllAPNConfigurationsIncludedIndicator: AllAPNConfigurationsIncluded,
+				APNConfiguration: []APNConfiguration{{
+					ContextIdentifier: 1,
+					PDNType:           PDNTypeIPv4,
+					ServiceSelection:  "internet",
+					ServedPartyIPAddress: []net.IP{
+						net.ParseIP("10.0.0.1").To4(),
+					},
+					EPSSubscribedQoSProfile: &EPSSubscribedQoSProfile{
+						QCI: 9,
+						AllocationRetentionPriority: AllocationRetentionPriority{
+							PriorityLevel:           8,
+							PreemptionCapability:    int32p(1),
+							PreemptionVulnerability: int32p(0),
+						},
+					},
+					AMBR: &AMBR{MaxRequestedBandwidthUL: 10000000, MaxRequestedBandwidthDL: 20000000},
+				}},
+			},
+		},
+	}
+}
+
+// reflectULA is a ULA without the generated methods, which Marshal and
+// Unmarshal handle with reflection.
+type reflectULA ULA
+
+func TestULA_MarshalUnmarshal(t *testing.T) {
+	want := newTestULA()
+	m := diam.NewRequest(diam.UpdateLocation, diam.TGPP_S6A_APP_ID, dict.Default).Answer(0)
+	var have ULA
+	roundTrip(t, m, want, &have)
+	if !reflect.DeepEqual(want, &have) {
+		t.Fatalf("Unexpected ULA.\nWant %+v\nHave %+v", want, &have)
+	}
+
+	// The generated methods encode like reflection.
+	codec, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.Marshal((*reflectULA)(want)); err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(codec, b) {
+		t.Fatalf("Unexpected encoding.\nWant %x\nHave %x", b, codec)
+	}
+}
+
+func BenchmarkULA_Marshal(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		v    interface{}
+	}{
+		{"Codec", newTestULA()},
+		{"Reflect", (*reflectULA)(newTestULA())},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			m := diam.NewRequest(diam.UpdateLocation, diam.TGPP_S6A_APP_ID, dict.Default).Answer(0)
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if err := m.Marshal(bc.v); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkULA_Unmarshal(b *testing.B) {
+	m := diam.NewRequest(diam.UpdateLocation, diam.TGPP_S6A_APP_ID, dict.Default).Answer(0)
+	if err := m.Marshal(newTestULA()); err != nil {
+		b.Fatal(err)
+	}
+	buf, err := m.Serialize()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if m, err = diam.ReadMessage(bytes.NewReader(buf), dict.Default); err != nil {
+		b.Fatal(err)
+	}
+	for _, bc := range []struct {
+		name string
+		new  func() interface{}
+	}{
+		{"Codec", func() interface{} { return new(ULA) }},
+		{"Reflect", func() interface{} { return new(reflectULA) }},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				if err := m.Unmarshal(bc.new()); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestAIA_MarshalUnmarshal(t *testing.T) {
+	want := &AIA{
+		SessionID:                   "mme;2",
+		VendorSpecificApplicationID: vendorSpecificApplicationID(),
+		ResultCode:                  diam.Success,
+		AuthSessionState:            NoStateMaintained,
+		OriginHost:                  "hss",
+		OriginRealm:                 "test",
+		AuthenticationInfo: &AuthenticationInfo{
+			EUTRANVector: []EUTRANVector{{
+				ItemNumber: 1,
+				RAND:       datatype.OctetString(bytes.Repeat([]byte{1}, 16)),
+				XRES:       datatype.OctetString(bytes.Repeat([]byte{2}, 8)),
+				AUTN:       datatype.OctetString(bytes.Repeat([]byte{3}, 16)),
+				KASME:      datatype.OctetString(bytes.Repeat([]byte{4}, 32)),
+			}},
+		},
+	}
+	m := diam.NewRequest(diam.AuthenticationInformation, diam.TGPP_S6A_APP_ID, dict.Default).Answer(0)
+	var have AIA
+	roundTrip(t, m, want, &have)
+	if !reflect.DeepEqual(want, &have) {
+		t.Fatalf("Unexpected AIA.\nWant %+v\nHave %+v", want, &have)
+	}
+}
+
+func TestPLMNID(t *testing.T) {
+	for _, tc := range []struct {
+		mcc, mnc string
+		want     datatype.OctetString
+	}{
+		{"001", "01", "\x00\xf1\x10"},
+		{"310", "410", "\x13\x00\x14"},
+		{"724", "05", "\x27\xf4\x50"},
+	} {
+		b, err := EncodePLMNID(tc.mcc, tc.mnc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b != tc.want {
+			t.Fatalf("Unexpected PLMN ID for %s/%s. Want %x, have %x", tc.mcc, tc.mnc, tc.want, b)
+		}
+		mcc, mnc, err := DecodePLMNID(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if mcc != tc.mcc || mnc != tc.mnc {
+			t.Fatalf("Unexpected MCC/MNC. Want %s/%s, have %s/%s", tc.mcc, tc.mnc, mcc, mnc)
+		}
+	}
+	if _, err := EncodePLMNID("01", "01"); err != ErrInvalidPLMNID {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrInvalidPLMNID, err)
+	}
+	if _, _, err := DecodePLMNID("\xaa\xf1\x10"); err != ErrInvalidPLMNID {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrInvalidPLMNID, err)
+	}
+}
+
+type testBackend struct{}
+
+func (testBackend) AuthenticationInformation(air *AIR, aia *AIA) {
+	if air.UserName != "001010000000001" {
+		aia.ExperimentalResult = &ExperimentalResult{VendorID: Vendor3GPP, Code: UserUnknown}
+		return
+	}
+	info := &AuthenticationInfo{}
+	for i := uint32(1); i <= air.RequestedEUTRANAuthInfo.NumberOfRequestedVectors; i++ {
+		info.EUTRANVector = append(info.EUTRANVector, EUTRANVector{
+			ItemNumber: i,
+			RAND:       datatype.OctetString(bytes.Repeat([]byte{byte(i)}, 16)),
+			XRES:       datatype.OctetString(bytes.Repeat([]byte{byte(i)}, 8)),
+			AUTN:       datatype.OctetString(bytes.Repeat([]byte{byte(i)}, 16)),
+			KASME:      datatype.OctetString(bytes.Repeat([]byte{byte(i)}, 32)),
+		})
+	}
+	aia.AuthenticationInfo = info
+}
+
+func (testBackend) UpdateLocation(ulr *ULR, ula *ULA) {
+	if ulr.UserName != "001010000000001" {
+		ula.ExperimentalResult = &ExperimentalResult{VendorID: Vendor3GPP, Code: UserUnknown}
+		return
+	}
+	ula.SubscriptionData = &SubscriptionData{
+		SubscriberStatus: int32p(ServiceGranted),
+		APNConfigurationProfile: &APNConfigurationProfile{
+			ContextIdentifier: 1,
+			APNConfiguration: []APNConfiguration{{
+				ContextIdentifier: 1,
+				PDNType:           PDNTypeIPv4,
+				ServiceSelection:  "internet",
+			}},
+		},
+	}
+}
+
+func (testBackend) PurgeUE(pur *PUR, pua *PUA) {}
+
+func (testBackend) Notify(nor *NOR, noa *NOA) {}
+
+func dialMME(t *testing.T, addr string, mme *Client) diam.Conn {
+	t.Helper()
+	mux := sm.New(smtest.Settings(string(mme.OriginHost)))
+	for _, idx := range []diam.CommandIndex{
+		AIAIndex, ULAIndex, PUAIndex, NOAIndex,
+		CLRIndex, IDRIndex, DSRIndex, RSRIndex,
+	} {
+		mux.HandleIdx(idx, mme)
+	}
+	cli := &sm.Client{
+		Handler: mux,
+		VendorSpecificApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.VendorSpecificApplicationID, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.TGPP_S6A_APP_ID)),
+					diam.NewAVP(avp.VendorID, avp.Mbit, 0, datatype.Unsigned32(Vendor3GPP)),
+				},
+			}),
+		},
+	}
+	c, err := cli.Dial(addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestClientServer(t *testing.T) {
+	hss := &Server{
+		OriginHost:  "hss",
+		OriginRealm: "test",
+		Backend:     testBackend{},
+		Timeout:     time.Second,
+	}
+	mux := sm.New(smtest.Settings("hss"))
+	for _, idx := range []diam.CommandIndex{
+		AIRIndex, ULRIndex, PURIndex, NORIndex,
+		CLAIndex, IDAIndex, DSAIndex, RSAIndex,
+	} {
+		mux.HandleIdx(idx, hss)
+	}
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	plmn, err := EncodePLMNID("001", "01")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cancelled := make(chan *CLR, 1)
+	inserted := make(chan *IDR, 1)
+	mme1 := &Client{
+		OriginHost:       "mme1",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		VisitedPLMNID:    plmn,
+		Timeout:          time.Second,
+		OnCancelLocation: func(clr *CLR, cla *CLA) { cancelled <- clr },
+		OnInsertSubscriberData: func(idr *IDR, ida *IDA) {
+			inserted <- idr
+			ida.IDAFlags = NetworkNodeAreaRestricted
+		},
+	}
+	c1 := dialMME(t, srv.Addr, mme1)
+	defer c1.Close()
+
+	aia, err := mme1.AuthenticationInformation(c1, &AIR{UserName: "001010000000002"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aia.ResultCode != 0 || aia.ExperimentalResult == nil || aia.ExperimentalResult.Code != UserUnknown {
+		t.Fatalf("Unexpected AIA: %+v", aia)
+	}
+	aia, err = mme1.AuthenticationInformation(c1, &AIR{
+		UserName:                "001010000000001",
+		RequestedEUTRANAuthInfo: &RequestedAuthInfo{NumberOfRequestedVectors: 2},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aia.ResultCode != diam.Success || aia.AuthenticationInfo == nil || len(aia.AuthenticationInfo.EUTRANVector) != 2 {
+		t.Fatalf("Unexpected AIA: %+v", aia)
+	}
+
+	ula, err := mme1.UpdateLocation(c1, &ULR{UserName: "001010000000001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ula.ResultCode != diam.Success || ula.SubscriptionData == nil || ula.SubscriptionData.APNConfigurationProfile == nil {
+		t.Fatalf("Unexpected ULA: %+v", ula)
+	}
+	ulr, ok := hss.Registration("001010000000001")
+	if !ok {
+		t.Fatal("User is not registered")
+	}
+	if ulr.OriginHost != "mme1" || ulr.ULRFlags&ULRS6aS6dIndicator == 0 || ulr.RATType != RATEUTRAN || ulr.VisitedPLMNID != plmn {
+		t.Fatalf("Unexpected ULR: %+v", ulr)
+	}
+
+	idr := &IDR{
+		SubscriptionData: SubscriptionData{NetworkAccessMode: int32p(OnlyPacket)},
+	}
+	ida, err := hss.InsertSubscriberData("001010000000001", idr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idr.SessionID != "" || idr.UserName != "" || idr.DestinationHost != "" {
+		t.Fatalf("InsertSubscriberData modified the IDR: %+v", idr)
+	}
+	if ida.ResultCode != diam.Success || ida.IDAFlags != NetworkNodeAreaRestricted {
+		t.Fatalf("Unexpected IDA: %+v", ida)
+	}
+	if idr := <-inserted; idr.SubscriptionData.NetworkAccessMode == nil || *idr.SubscriptionData.NetworkAccessMode != OnlyPacket {
+		t.Fatalf("Unexpected IDR: %+v", idr)
+	}
+	if _, err = hss.InsertSubscriberData("001010000000002", &IDR{}); err != ErrUnknownUser {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrUnknownUser, err)
+	}
+
+	// Registering at another MME cancels the location at the first.
+	mme2 := &Client{
+		OriginHost:       "mme2",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		VisitedPLMNID:    plmn,
+		Timeout:          time.Second,
+	}
+	c2 := dialMME(t, srv.Addr, mme2)
+	defer c2.Close()
+	if ula, err = mme2.UpdateLocation(c2, &ULR{UserName: "001010000000001"}); err != nil {
+		t.Fatal(err)
+	}
+	if ula.ResultCode != diam.Success {
+		t.Fatalf("Unexpected ULA: %+v", ula)
+	}
+	select {
+	case clr := <-cancelled:
+		if clr.CancellationType != MMEUpdateProcedure || clr.CLRFlags&CLRS6aS6dIndicator == 0 || clr.DestinationHost != "mme1" {
+			t.Fatalf("Unexpected CLR: %+v", clr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnCancelLocation was not called")
+	}
+
+	pua, err := mme2.PurgeUE(c2, &PUR{UserName: "001010000000001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pua.ResultCode != diam.Success {
+		t.Fatalf("Unexpected PUA: %+v", pua)
+	}
+	if users := hss.Registrations(); len(users) != 0 {
+		t.Fatalf("Unexpected registrations: %v", users)
+	}
+	if _, err = hss.CancelLocation("001010000000001", SubscriptionWithdrawal); err != ErrUnknownUser {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrUnknownUser, err)
+	}
+}
@@ -0,0 +1,347 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s6a
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+)
+
+// ErrUnknownUser is returned by the Server when a user is not
+// registered at any MME.
+var ErrUnknownUser = errors.New("s6a: unknown user")
+
+// A Backend answers the requests that MMEs send to the HSS.
+//
+// Each method is called with the decoded request and its answer, which
+// is filled in with DIAMETER_SUCCESS on entry. Methods may change the
+// Result-Code or set an Experimental-Result, in which case the
+// Result-Code is cleared.
+type Backend interface {
+	AuthenticationInformation(air *AIR, aia *AIA)
+	UpdateLocation(ulr *ULR, ula *ULA)
+	PurgeUE(pur *PUR, pua *PUA)
+	Notify(nor *NOR, noa *NOA)
+}
+
+// Server is the HSS side of S6a. It answers AIR, ULR, PUR and NOR using
+// its Backend, keeps track of the MME each user is registered at, and
+// can send CLR, IDR, DSR and RSR to those MMEs.
+//
+// When a user registers at a new MME, the Server cancels its location
+// at the previous one with a CLR of type MME_UPDATE_PROCEDURE.
+//
+// Server implements the diam.Handler interface and must be registered
+// for AIRIndex, ULRIndex, PURIndex, NORIndex, CLAIndex, IDAIndex,
+// DSAIndex and RSAIndex on the connection's handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Backend     Backend
+	Timeout     time.Duration // Defaults to DefaultTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers and
+	// cancelling the location of users at their previous MME.
+	ErrorReporter diam.ErrorReporter
+
+	pending       pending.Table
+	mu            sync.Mutex
+	registrations map[string]*registration // By User-Name.
+}
+
+type registration struct {
+	conn diam.Conn
+	ulr  *ULR
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.AuthenticationInformation:
+		var air AIR
+		aia := &AIA{}
+		if decode(m, &air, &aia.ResultCode) {
+			s.backend(func(b Backend) { b.AuthenticationInformation(&air, aia) }, &aia.ResultCode)
+		}
+		aia.SessionID = air.SessionID
+		aia.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		aia.AuthSessionState = NoStateMaintained
+		aia.OriginHost = s.OriginHost
+		aia.OriginRealm = s.OriginRealm
+		if aia.ExperimentalResult != nil {
+			aia.ResultCode = 0
+		}
+		answer(c, m, aia, s.ErrorReporter)
+	case diam.UpdateLocation:
+		var ulr ULR
+		ula := &ULA{}
+		if decode(m, &ulr, &ula.ResultCode) {
+			s.backend(func(b Backend) { b.UpdateLocation(&ulr, ula) }, &ula.ResultCode)
+		}
+		ula.SessionID = ulr.SessionID
+		ula.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		ula.AuthSessionState = NoStateMaintained
+		ula.OriginHost = s.OriginHost
+		ula.OriginRealm = s.OriginRealm
+		if ula.ExperimentalResult != nil {
+			ula.ResultCode = 0
+		}
+		if ula.ResultCode == diam.Success {
+			s.register(c, m, &ulr)
+		}
+		answer(c, m, ula, s.ErrorReporter)
+	case diam.PurgeUE:
+		var pur PUR
+		pua := &PUA{}
+		if decode(m, &pur, &pua.ResultCode) {
+			s.backend(func(b Backend) { b.PurgeUE(&pur, pua) }, &pua.ResultCode)
+		}
+		pua.SessionID = pur.SessionID
+		pua.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		pua.AuthSessionState = NoStateMaintained
+		pua.OriginHost = s.OriginHost
+		pua.OriginRealm = s.OriginRealm
+		if pua.ExperimentalResult != nil {
+			pua.ResultCode = 0
+		}
+		if pua.ResultCode == diam.Success {
+			s.mu.Lock()
+			if r, ok := s.registrations[pur.UserName]; ok && r.ulr.OriginHost == pur.OriginHost {
+				delete(s.registrations, pur.UserName)
+			}
+			s.mu.Unlock()
+		}
+		answer(c, m, pua, s.ErrorReporter)
+	case diam.Notify:
+		var nor NOR
+		noa := &NOA{}
+		if decode(m, &nor, &noa.ResultCode) {
+			s.backend(func(b Backend) { b.Notify(&nor, noa) }, &noa.ResultCode)
+		}
+		noa.SessionID = nor.SessionID
+		noa.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		noa.AuthSessionState = NoStateMaintained
+		noa.OriginHost = s.OriginHost
+		noa.OriginRealm = s.OriginRealm
+		if noa.ExperimentalResult != nil {
+			noa.ResultCode = 0
+		}
+		answer(c, m, noa, s.ErrorReporter)
+	}
+}
+
+// backend calls f with the Backend, or answers DIAMETER_UNABLE_TO_COMPLY
+// when there is none.
+func (s *Server) backend(f func(b Backend), resultCode *uint32) {
+	if s.Backend == nil {
+		*resultCode = diam.UnableToComply
+		return
+	}
+	f(s.Backend)
+}
+
+// register records the MME of a user after a successful ULR, and
+// cancels the location of the user at its previous MME.
+func (s *Server) register(c diam.Conn, m *diam.Message, ulr *ULR) {
+	s.mu.Lock()
+	prev, ok := s.registrations[ulr.UserName]
+	if s.registrations == nil {
+		s.registrations = make(map[string]*registration)
+	}
+	s.registrations[ulr.UserName] = &registration{conn: c, ulr: ulr}
+	s.mu.Unlock()
+	if !ok || prev.ulr.OriginHost == ulr.OriginHost {
+		return
+	}
+	// The CLA may arrive on this goroutine, so wait for it elsewhere.
+	go func() {
+		_, err := s.cancelLocation(prev, &CLR{
+			UserName:         ulr.UserName,
+			CancellationType: MMEUpdateProcedure,
+		})
+		if err != nil && s.ErrorReporter != nil {
+			s.ErrorReporter.Error(&diam.ErrorReport{
+				Conn:    c,
+				Message: m,
+				Error:   fmt.Errorf("failed to cancel location at %s: %v", string(prev.ulr.OriginHost), err),
+			})
+		}
+	}()
+}
+
+// Registrations returns the User-Name of all registered users, sorted.
+func (s *Server) Registrations() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	users := make([]string, 0, len(s.registrations))
+	for user := range s.registrations {
+		users = append(users, user)
+	}
+	sort.Strings(users)
+	return users
+}
+
+// Registration returns the last successful ULR of a registered user.
+func (s *Server) Registration(user string) (*ULR, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.registrations[user]
+	if !ok {
+		return nil, false
+	}
+	return r.ulr, true
+}
+
+func (s *Server) registration(user string) (*registration, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	r, ok := s.registrations[user]
+	if !ok {
+		return nil, ErrUnknownUser
+	}
+	return r, nil
+}
+
+// CancelLocation sends a CLR with the given Cancellation-Type to the
+// MME of a registered user and waits for its answer. The user is no
+// longer registered afterwards.
+func (s *Server) CancelLocation(user string, cancellationType int32) (*CLA, error) {
+	r, err := s.registration(user)
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	if s.registrations[user] == r {
+		delete(s.registrations, user)
+	}
+	s.mu.Unlock()
+	return s.cancelLocation(r, &CLR{UserName: user, CancellationType: cancellationType})
+}
+
+func (s *Server) cancelLocation(r *registration, clr *CLR) (*CLA, error) {
+	clr.SessionID = sessionid.New(s.OriginHost)
+	clr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	clr.AuthSessionState = NoStateMaintained
+	clr.OriginHost = s.OriginHost
+	clr.OriginRealm = s.OriginRealm
+	clr.DestinationHost = r.ulr.OriginHost
+	clr.DestinationRealm = r.ulr.OriginRealm
+	clr.CLRFlags |= CLRS6aS6dIndicator
+	var cla CLA
+	if err := s.exchange(r.conn, diam.CancelLocation, clr, &cla); err != nil {
+		return nil, err
+	}
+	return &cla, nil
+}
+
+// InsertSubscriberData sends an IDR carrying the subscription data in
+// idr to the MME of a registered user and waits for its answer.
+// Session-Id, routing AVPs and User-Name are filled in by the Server.
+func (s *Server) InsertSubscriberData(user string, idr *IDR) (*IDA, error) {
+	r, err := s.registration(user)
+	if err != nil {
+		return nil, err
+	}
+	// Fill in a copy, leaving the caller's IDR untouched.
+	var req IDR
+	if idr != nil {
+		req = *idr
+	}
+	idr = &req
+	idr.SessionID = sessionid.New(s.OriginHost)
+	idr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	idr.AuthSessionState = NoStateMaintained
+	idr.OriginHost = s.OriginHost
+	idr.OriginRealm = s.OriginRealm
+	idr.DestinationHost = r.ulr.OriginHost
+	idr.DestinationRealm = r.ulr.OriginRealm
+	idr.UserName = user
+	var ida IDA
+	if err = s.exchange(r.conn, diam.InsertSubscriberData, idr, &ida); err != nil {
+		return nil, err
+	}
+	return &ida, nil
+}
+
+// DeleteSubscriberData sends a DSR with the DSR-Flags and identifiers in
+// dsr to the MME of a registered user and waits for its answer.
+// Session-Id, routing AVPs and User-Name are filled in by the Server.
+func (s *Server) DeleteSubscriberData(user string, dsr *DSR) (*DSA, error) {
+	r, err := s.registration(user)
+	if err != nil {
+		return nil, err
+	}
+	// Fill in a copy, leaving the caller's DSR untouched.
+	var req DSR
+	if dsr != nil {
+		req = *dsr
+	}
+	dsr = &req
+	dsr.SessionID = sessionid.New(s.OriginHost)
+	dsr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	dsr.AuthSessionState = NoStateMaintained
+	dsr.OriginHost = s.OriginHost
+	dsr.OriginRealm = s.OriginRealm
+	dsr.DestinationHost = r.ulr.OriginHost
+	dsr.DestinationRealm = r.ulr.OriginRealm
+	dsr.UserName = user
+	var dsa DSA
+	if err = s.exchange(r.conn, diam.DeleteSubscriberData, dsr, &dsa); err != nil {
+		return nil, err
+	}
+	return &dsa, nil
+}
+
+// Reset sends an RSR to every MME with registered users and waits for
+// their answers, returning the first error.
+func (s *Server) Reset() error {
+	s.mu.Lock()
+	mmes := make(map[datatype.DiameterIdentity]*registration)
+	for _, r := range s.registrations {
+		mmes[r.ulr.OriginHost] = r
+	}
+	s.mu.Unlock()
+	var firstErr error
+	for _, r := range mmes {
+		rsr := &RSR{
+			SessionID:                   sessionid.New(s.OriginHost),
+			VendorSpecificApplicationID: vendorSpecificApplicationID(),
+			AuthSessionState:            NoStateMaintained,
+			OriginHost:                  s.OriginHost,
+			OriginRealm:                 s.OriginRealm,
+			DestinationHost:             r.ulr.OriginHost,
+			DestinationRealm:            r.ulr.OriginRealm,
+		}
+		var rsa RSA
+		if err := s.exchange(r.conn, diam.Reset, rsr, &rsa); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (s *Server) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.TGPP_S6A_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := s.pending.Exchange(c, m, s.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
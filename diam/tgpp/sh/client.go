@@ -0,0 +1,182 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sh
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// DefaultTimeout is how long the Client and Server wait for answers
+// when no Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+func vendorSpecificApplicationID() *base.VendorSpecificApplicationID {
+	return base.NewVendorSpecificApplicationID(diam.TGPP_SH_APP_ID)
+}
+
+// Client is the application server side of Sh. It sends UDR, PUR and
+// SNR to the HSS and answers the PNR sent by the HSS.
+//
+// Requests are completed by the Client before being sent: Session-Id is
+// generated when empty, and the routing AVPs, Vendor-Specific-Application-Id
+// and Auth-Session-State are filled in.
+//
+// Client implements the diam.Handler interface and must be registered
+// for UDAIndex, PUAIndex, SNAIndex and PNRIndex on the connection's
+// handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// OnPushNotification, if non-nil, is called for PNRs sent by the
+	// HSS. The answer is filled in with DIAMETER_SUCCESS on entry and
+	// may be changed; setting an Experimental-Result clears the
+	// Result-Code. It runs on the connection's read goroutine and must
+	// not block.
+	OnPushNotification func(pnr *PNR, pna *PNA)
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending pending.Table
+}
+
+// UserData sends a UDR built from udr over c and waits for the UDA.
+// Data-Reference defaults to RepositoryData when udr has none.
+func (cli *Client) UserData(c diam.Conn, udr *UDR) (*UDA, error) {
+	if len(udr.SessionID) == 0 {
+		udr.SessionID = sessionid.New(cli.OriginHost)
+	}
+	udr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	udr.AuthSessionState = base.NoStateMaintained
+	udr.OriginHost = cli.OriginHost
+	udr.OriginRealm = cli.OriginRealm
+	udr.DestinationRealm = cli.DestinationRealm
+	udr.DestinationHost = cli.DestinationHost
+	if len(udr.DataReference) == 0 {
+		udr.DataReference = []int32{RefRepositoryData}
+	}
+	var uda UDA
+	if err := cli.exchange(c, diam.UserData, udr, &uda); err != nil {
+		return nil, err
+	}
+	return &uda, nil
+}
+
+// ProfileUpdate sends a PUR built from pur over c and waits for the
+// PUA.
+func (cli *Client) ProfileUpdate(c diam.Conn, pur *PUR) (*PUA, error) {
+	if len(pur.SessionID) == 0 {
+		pur.SessionID = sessionid.New(cli.OriginHost)
+	}
+	pur.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	pur.AuthSessionState = base.NoStateMaintained
+	pur.OriginHost = cli.OriginHost
+	pur.OriginRealm = cli.OriginRealm
+	pur.DestinationRealm = cli.DestinationRealm
+	pur.DestinationHost = cli.DestinationHost
+	var pua PUA
+	if err := cli.exchange(c, diam.ProfileUpdate, pur, &pua); err != nil {
+		return nil, err
+	}
+	return &pua, nil
+}
+
+// SubscribeNotifications sends a SNR built from snr over c and waits
+// for the SNA. Data-Reference defaults to RepositoryData when snr has
+// none.
+func (cli *Client) SubscribeNotifications(c diam.Conn, snr *SNR) (*SNA, error) {
+	if len(snr.SessionID) == 0 {
+		snr.SessionID = sessionid.New(cli.OriginHost)
+	}
+	snr.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	snr.AuthSessionState = base.NoStateMaintained
+	snr.OriginHost = cli.OriginHost
+	snr.OriginRealm = cli.OriginRealm
+	snr.DestinationRealm = cli.DestinationRealm
+	snr.DestinationHost = cli.DestinationHost
+	if len(snr.DataReference) == 0 {
+		snr.DataReference = []int32{RefRepositoryData}
+	}
+	var sna SNA
+	if err := cli.exchange(c, diam.SubscribeNotifications, snr, &sna); err != nil {
+		return nil, err
+	}
+	return &sna, nil
+}
+
+func (cli *Client) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.TGPP_SH_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := cli.pending.Exchange(c, m, cli.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+		return
+	}
+	if m.Header.CommandCode != diam.PushNotification {
+		return
+	}
+	var pnr PNR
+	pna := &PNA{}
+	if decode(m, &pnr, &pna.ResultCode) && cli.OnPushNotification != nil {
+		cli.OnPushNotification(&pnr, pna)
+	}
+	pna.SessionID = pnr.SessionID
+	pna.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	pna.AuthSessionState = base.NoStateMaintained
+	pna.OriginHost = cli.OriginHost
+	pna.OriginRealm = cli.OriginRealm
+	if pna.ExperimentalResult != nil {
+		pna.ResultCode = 0
+	}
+	answer(c, m, pna, cli.ErrorReporter)
+}
+
+// decode decodes the request m into req and sets the Result-Code of
+// its answer, reporting whether the request is valid.
+func decode(m *diam.Message, req interface{}, resultCode *uint32) bool {
+	if err := m.Unmarshal(req); err != nil {
+		*resultCode = diam.UnableToComply
+		return false
+	}
+	*resultCode = diam.Success
+	return true
+}
+
+// answer writes the answer v to the request m.
+func answer(c diam.Conn, m *diam.Message, v interface{}, er diam.ErrorReporter) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && er != nil {
+		er.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package sh implements the Sh and Dh applications between IMS
+// application servers and the HSS or SLF, as specified in 3GPP TS
+// 29.328 and 29.329.
+//
+// It provides typed UDR/UDA, PUR/PUA, SNR/SNA and PNR/PNA messages for
+// use with Message.Marshal and Message.Unmarshal, an application server
+// Client and an HSS Server that fill in the mandatory AVPs of the
+// messages they send, and ShData, which parses and serialises the
+// Sh-Data XML document carried in User-Data.
+//
+// An application server reading and updating its transparent data:
+//
+//	as := &sh.Client{
+//		OriginHost:       "as.ims.example.com",
+//		OriginRealm:      "ims.example.com",
+//		DestinationRealm: "ims.example.com",
+//	}
+//	mux := sm.New(settings)
+//	for _, idx := range []diam.CommandIndex{
+//		sh.UDAIndex, sh.PUAIndex, sh.SNAIndex, sh.PNRIndex,
+//	} {
+//		mux.HandleIdx(idx, as)
+//	}
+//	...
+//	uda, err := as.UserData(conn, &sh.UDR{
+//		UserIdentity:      sh.UserIdentity{PublicIdentity: "sip:alice@ims.example.com"},
+//		ServiceIndication: []datatype.OctetString{"call-forwarding"},
+//	})
+//	...
+//	data, err := uda.ShData()
+//	...
+//	rd := data.Repository("call-forwarding")
+//	rd.SequenceNumber++
+//	b, err := data.Marshal()
+//	...
+//	pua, err := as.ProfileUpdate(conn, &sh.PUR{
+//		UserIdentity:  sh.UserIdentity{PublicIdentity: "sip:alice@ims.example.com"},
+//		DataReference: sh.RefRepositoryData,
+//		UserData:      datatype.OctetString(b),
+//	})
+package sh
@@ -0,0 +1,278 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sh
+
+import (
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// Command indexes of the Sh application, for use with
+// ServeMux.HandleIdx.
+var (
+	UDRIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.UserData, Request: true}
+	UDAIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.UserData, Request: false}
+	PURIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.ProfileUpdate, Request: true}
+	PUAIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.ProfileUpdate, Request: false}
+	SNRIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.SubscribeNotifications, Request: true}
+	SNAIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.SubscribeNotifications, Request: false}
+	PNRIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.PushNotification, Request: true}
+	PNAIndex = diam.CommandIndex{AppID: diam.TGPP_SH_APP_ID, Code: diam.PushNotification, Request: false}
+)
+
+// Experimental-Result-Code values. See 3GPP TS 29.329 section 6.2
+// and 3GPP TS 29.229 section 6.2.
+const (
+	UserUnknown              = 5001
+	IdentitiesDontMatch      = 5002
+	TooMuchData              = 5008
+	FeatureUnsupported       = 5011
+	UserDataNotRecognized    = 5100
+	OperationNotAllowed      = 5101
+	UserDataCannotBeRead     = 5102
+	UserDataCannotBeModified = 5103
+	UserDataCannotBeNotified = 5104
+	TransparentDataOutOfSync = 5105
+	SubsDataAbsent           = 5106
+	NoSubscriptionToData     = 5107
+	DSAINotAvailable         = 5108
+)
+
+// Data-Reference values, naming the kind of user data in UDR, PUR and
+// SNR. See 3GPP TS 29.329 section 6.3.4.
+const (
+	RefRepositoryData                    = 0
+	RefIMSPublicIdentity                 = 10
+	RefIMSUserState                      = 11
+	RefSCSCFName                         = 12
+	RefInitialFilterCriteria             = 13
+	RefLocationInformation               = 14
+	RefUserState                         = 15
+	RefChargingInformation               = 16
+	RefMSISDN                            = 17
+	RefPSIActivation                     = 18
+	RefDSAI                              = 19
+	RefServiceLevelTraceInfo             = 21
+	RefIPAddressSecureBindingInformation = 22
+	RefServicePriorityLevel              = 23
+	RefSMSRegistrationInfo               = 24
+	RefUEReachabilityForIP               = 25
+	RefTADSInformation                   = 26
+	RefSTNSR                             = 27
+	RefUESRVCCCapability                 = 28
+	RefExtendedPriority                  = 29
+	RefCSRN                              = 30
+	RefReferenceLocationInformation      = 31
+	RefIMSI                              = 32
+	RefIMSPrivateUserIdentity            = 33
+	RefIMEISV                            = 34
+)
+
+// Subs-Req-Type values. See 3GPP TS 29.329 section 6.3.6.
+const (
+	Subscribe   = 0
+	Unsubscribe = 1
+)
+
+// Requested-Domain values. See 3GPP TS 29.329 section 6.3.7.
+const (
+	CSDomain = 0
+	PSDomain = 1
+)
+
+// Identity-Set values. See 3GPP TS 29.329 section 6.3.10.
+const (
+	AllIdentities        = 0
+	RegisteredIdentities = 1
+	ImplicitIdentities   = 2
+	AliasIdentities      = 3
+)
+
+// Send-Data-Indication values. See 3GPP TS 29.329 section 6.3.17.
+const (
+	UserDataNotRequested = 0
+	UserDataRequested    = 1
+)
+
+// UserIdentity is the User-Identity grouped AVP. Exactly one of its
+// fields is set.
+type UserIdentity struct {
+	PublicIdentity string               `avp:"Public-Identity,omitempty"`
+	MSISDN         datatype.OctetString `avp:"MSISDN,omitempty"`
+}
+
+// RepositoryDataID is the Repository-Data-ID grouped AVP.
+type RepositoryDataID struct {
+	ServiceIndication datatype.OctetString `avp:"Service-Indication"`
+	SequenceNumber    uint32               `avp:"Sequence-Number"`
+}
+
+// CallReferenceInfo is the Call-Reference-Info grouped AVP.
+type CallReferenceInfo struct {
+	CallReferenceNumber datatype.OctetString `avp:"Call-Reference-Number"`
+	ASNumber            datatype.OctetString `avp:"AS-Number"`
+}
+
+// UDR is a User-Data-Request message, sent by an application server to
+// read user data from the HSS. See 3GPP TS 29.329 section 6.1.1.
+type UDR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	UserIdentity                UserIdentity                      `avp:"User-Identity"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	WildcardedIMPU              string                            `avp:"Wildcarded-IMPU,omitempty"`
+	ServerName                  string                            `avp:"Server-Name,omitempty"`
+	ServiceIndication           []datatype.OctetString            `avp:"Service-Indication"`
+	DataReference               []int32                           `avp:"Data-Reference"`
+	IdentitySet                 []int32                           `avp:"Identity-Set"`
+	RequestedDomain             *int32                            `avp:"Requested-Domain"`
+	CurrentLocation             *int32                            `avp:"Current-Location"`
+	DSAITag                     []datatype.OctetString            `avp:"DSAI-Tag"`
+	SessionPriority             *int32                            `avp:"Session-Priority"`
+	UserName                    string                            `avp:"User-Name,omitempty"`
+	RequestedNodes              uint32                            `avp:"Requested-Nodes,omitempty"`
+	ServingNodeIndication       *int32                            `avp:"Serving-Node-Indication"`
+	PrepagingSupported          *int32                            `avp:"Pre-paging-Supported"`
+	LocalTimeZoneIndication     *int32                            `avp:"Local-Time-Zone-Indication"`
+	UDRFlags                    uint32                            `avp:"UDR-Flags,omitempty"`
+	CallReferenceInfo           *CallReferenceInfo                `avp:"Call-Reference-Info"`
+}
+
+// UDA is a User-Data-Answer message. UserData carries the Sh-Data XML
+// document; see ParseShData. See 3GPP TS 29.329 section 6.1.2.
+type UDA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	WildcardedIMPU              string                            `avp:"Wildcarded-IMPU,omitempty"`
+	UserData                    datatype.OctetString              `avp:"User-Data-Sh,omitempty"`
+}
+
+// PUR is a Profile-Update-Request message, sent by an application
+// server to update user data in the HSS. See 3GPP TS 29.329 section 6.1.3.
+type PUR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	UserIdentity                UserIdentity                      `avp:"User-Identity"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	WildcardedIMPU              string                            `avp:"Wildcarded-IMPU,omitempty"`
+	UserName                    string                            `avp:"User-Name,omitempty"`
+	DataReference               int32                             `avp:"Data-Reference"`
+	UserData                    datatype.OctetString              `avp:"User-Data-Sh"`
+}
+
+// PUA is a Profile-Update-Answer message.
+// See 3GPP TS 29.329 section 6.1.4.
+type PUA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	WildcardedIMPU              string                            `avp:"Wildcarded-IMPU,omitempty"`
+	RepositoryDataID            *RepositoryDataID                 `avp:"Repository-Data-ID"`
+	DataReference               *int32                            `avp:"Data-Reference"`
+}
+
+// SNR is a Subscribe-Notifications-Request message, sent by an
+// application server to subscribe to changes of user data.
+// See 3GPP TS 29.329 section 6.1.5.
+type SNR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	UserIdentity                UserIdentity                      `avp:"User-Identity"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	WildcardedIMPU              string                            `avp:"Wildcarded-IMPU,omitempty"`
+	ServiceIndication           []datatype.OctetString            `avp:"Service-Indication"`
+	SendDataIndication          *int32                            `avp:"Send-Data-Indication"`
+	ServerName                  string                            `avp:"Server-Name,omitempty"`
+	SubsReqType                 int32                             `avp:"Subs-Req-Type"`
+	DataReference               []int32                           `avp:"Data-Reference"`
+	IdentitySet                 []int32                           `avp:"Identity-Set"`
+	ExpiryTime                  *time.Time                        `avp:"Expiry-Time"`
+	DSAITag                     []datatype.OctetString            `avp:"DSAI-Tag"`
+	OneTimeNotification         *int32                            `avp:"One-Time-Notification"`
+	UserName                    string                            `avp:"User-Name,omitempty"`
+}
+
+// SNA is a Subscribe-Notifications-Answer message.
+// See 3GPP TS 29.329 section 6.1.6.
+type SNA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	WildcardedIMPU              string                            `avp:"Wildcarded-IMPU,omitempty"`
+	UserData                    datatype.OctetString              `avp:"User-Data-Sh,omitempty"`
+	ExpiryTime                  *time.Time                        `avp:"Expiry-Time"`
+}
+
+// PNR is a Push-Notification-Request message, sent by the HSS to
+// notify a subscribed application server of changed user data.
+// See 3GPP TS 29.329 section 6.1.7.
+type PNR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+	UserIdentity                UserIdentity                      `avp:"User-Identity"`
+	WildcardedPublicIdentity    string                            `avp:"Wildcarded-Public-Identity,omitempty"`
+	WildcardedIMPU              string                            `avp:"Wildcarded-IMPU,omitempty"`
+	UserName                    string                            `avp:"User-Name,omitempty"`
+	UserData                    datatype.OctetString              `avp:"User-Data-Sh"`
+}
+
+// PNA is a Push-Notification-Answer message.
+// See 3GPP TS 29.329 section 6.1.8.
+type PNA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
@@ -0,0 +1,261 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sh
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// ErrNoSubscribers is returned by Server.PushNotification when no
+// application server is subscribed to a user identity.
+var ErrNoSubscribers = errors.New("sh: no subscribers")
+
+// A Backend answers the requests that application servers send to the
+// HSS.
+//
+// Each method is called with the decoded request and its answer, which
+// is filled in with DIAMETER_SUCCESS on entry. Methods may change the
+// Result-Code or set an Experimental-Result, in which case the
+// Result-Code is cleared.
+type Backend interface {
+	UserData(udr *UDR, uda *UDA)
+	ProfileUpdate(pur *PUR, pua *PUA)
+	SubscribeNotifications(snr *SNR, sna *SNA)
+}
+
+// Server is the HSS side of Sh. It answers UDR, PUR and SNR using its
+// Backend, keeps track of the application servers subscribed to each
+// user identity, and can send them PNRs.
+//
+// A successful SNR with Subs-Req-Type Subscribe adds a subscription for
+// its User-Identity and Origin-Host, replacing any previous one, and
+// Unsubscribe removes it. Subscriptions are dropped once their
+// Expiry-Time has passed, and after one notification when
+// One-Time-Notification was requested.
+//
+// Server implements the diam.Handler interface and must be registered
+// for UDRIndex, PURIndex, SNRIndex and PNAIndex on the connection's
+// handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Backend     Backend
+	Timeout     time.Duration // Defaults to DefaultTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending       pending.Table
+	mu            sync.Mutex
+	subscriptions map[string]map[datatype.DiameterIdentity]*subscription // By user identity and Origin-Host.
+}
+
+type subscription struct {
+	conn diam.Conn
+	snr  *SNR
+}
+
+// key returns the Public-Identity, or the MSISDN when there is none.
+func (u *UserIdentity) key() string {
+	if len(u.PublicIdentity) > 0 {
+		return u.PublicIdentity
+	}
+	return string(u.MSISDN)
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.UserData:
+		var udr UDR
+		uda := &UDA{}
+		if decode(m, &udr, &uda.ResultCode) {
+			s.backend(func(b Backend) { b.UserData(&udr, uda) }, &uda.ResultCode)
+		}
+		uda.SessionID = udr.SessionID
+		uda.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		uda.AuthSessionState = base.NoStateMaintained
+		uda.OriginHost = s.OriginHost
+		uda.OriginRealm = s.OriginRealm
+		if uda.ExperimentalResult != nil {
+			uda.ResultCode = 0
+		}
+		answer(c, m, uda, s.ErrorReporter)
+	case diam.ProfileUpdate:
+		var pur PUR
+		pua := &PUA{}
+		if decode(m, &pur, &pua.ResultCode) {
+			s.backend(func(b Backend) { b.ProfileUpdate(&pur, pua) }, &pua.ResultCode)
+		}
+		pua.SessionID = pur.SessionID
+		pua.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		pua.AuthSessionState = base.NoStateMaintained
+		pua.OriginHost = s.OriginHost
+		pua.OriginRealm = s.OriginRealm
+		if pua.ExperimentalResult != nil {
+			pua.ResultCode = 0
+		}
+		answer(c, m, pua, s.ErrorReporter)
+	case diam.SubscribeNotifications:
+		var snr SNR
+		sna := &SNA{}
+		if decode(m, &snr, &sna.ResultCode) {
+			sna.ExpiryTime = snr.ExpiryTime
+			s.backend(func(b Backend) { b.SubscribeNotifications(&snr, sna) }, &sna.ResultCode)
+		}
+		sna.SessionID = snr.SessionID
+		sna.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		sna.AuthSessionState = base.NoStateMaintained
+		sna.OriginHost = s.OriginHost
+		sna.OriginRealm = s.OriginRealm
+		if sna.ExperimentalResult != nil {
+			sna.ResultCode = 0
+		}
+		if sna.ResultCode == diam.Success {
+			// The Backend may shorten the subscription.
+			snr.ExpiryTime = sna.ExpiryTime
+			s.subscribe(c, &snr)
+		}
+		answer(c, m, sna, s.ErrorReporter)
+	}
+}
+
+// backend calls f with the Backend, or answers DIAMETER_UNABLE_TO_COMPLY
+// when there is none.
+func (s *Server) backend(f func(b Backend), resultCode *uint32) {
+	if s.Backend == nil {
+		*resultCode = diam.UnableToComply
+		return
+	}
+	f(s.Backend)
+}
+
+// subscribe updates the subscriptions after a successful SNR.
+func (s *Server) subscribe(c diam.Conn, snr *SNR) {
+	id := snr.UserIdentity.key()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if snr.SubsReqType == Unsubscribe {
+		delete(s.subscriptions[id], snr.OriginHost)
+		if len(s.subscriptions[id]) == 0 {
+			delete(s.subscriptions, id)
+		}
+		return
+	}
+	if s.subscriptions == nil {
+		s.subscriptions = make(map[string]map[datatype.DiameterIdentity]*subscription)
+	}
+	subs, ok := s.subscriptions[id]
+	if !ok {
+		subs = make(map[datatype.DiameterIdentity]*subscription)
+		s.subscriptions[id] = subs
+	}
+	subs[snr.OriginHost] = &subscription{conn: c, snr: snr}
+}
+
+// Subscriptions returns the user identities that application servers
+// are subscribed to, sorted.
+func (s *Server) Subscriptions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.subscriptions))
+	for id := range s.subscriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Subscribers returns the SNRs of the application servers subscribed
+// to a user identity, ordered by Origin-Host.
+func (s *Server) Subscribers(identity string) []*SNR {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var snrs []*SNR
+	for _, sub := range s.subscriptions[identity] {
+		snrs = append(snrs, sub.snr)
+	}
+	sort.Slice(snrs, func(i, j int) bool { return snrs[i].OriginHost < snrs[j].OriginHost })
+	return snrs
+}
+
+// PushNotification sends a PNR carrying the Sh-Data XML document data
+// to every application server subscribed to a user identity, and waits
+// for their answers. It returns the answers received and the first
+// error.
+func (s *Server) PushNotification(identity string, data datatype.OctetString) ([]*PNA, error) {
+	now := time.Now()
+	s.mu.Lock()
+	var subs []*subscription
+	for host, sub := range s.subscriptions[identity] {
+		if sub.snr.ExpiryTime != nil && now.After(*sub.snr.ExpiryTime) {
+			delete(s.subscriptions[identity], host)
+			continue
+		}
+		subs = append(subs, sub)
+		if sub.snr.OneTimeNotification != nil {
+			delete(s.subscriptions[identity], host)
+		}
+	}
+	if len(s.subscriptions[identity]) == 0 {
+		delete(s.subscriptions, identity)
+	}
+	s.mu.Unlock()
+	if len(subs) == 0 {
+		return nil, ErrNoSubscribers
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].snr.OriginHost < subs[j].snr.OriginHost })
+	var (
+		pnas     []*PNA
+		firstErr error
+	)
+	for _, sub := range subs {
+		pnr := &PNR{
+			SessionID:                   sessionid.New(s.OriginHost),
+			VendorSpecificApplicationID: vendorSpecificApplicationID(),
+			AuthSessionState:            base.NoStateMaintained,
+			OriginHost:                  s.OriginHost,
+			OriginRealm:                 s.OriginRealm,
+			DestinationHost:             sub.snr.OriginHost,
+			DestinationRealm:            sub.snr.OriginRealm,
+			UserIdentity:                sub.snr.UserIdentity,
+			UserData:                    data,
+		}
+		var pna PNA
+		if err := s.exchange(sub.conn, diam.PushNotification, pnr, &pna); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		pnas = append(pnas, &pna)
+	}
+	return pnas, firstErr
+}
+
+func (s *Server) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.TGPP_SH_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := s.pending.Exchange(c, m, s.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
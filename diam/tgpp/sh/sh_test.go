@@ -0,0 +1,305 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sh
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+const testShData = `<?xml version="1.0" encoding="UTF-8"?>
+<Sh-Data>
+  <PublicIdentifiers>
+    <IMSPublicIdentity>sip:alice@ims.test</IMSPublicIdentity>
+    <IMSPublicIdentity>tel:+15551234567</IMSPublicIdentity>
+    <MSISDN>15551234567</MSISDN>
+  </PublicIdentifiers>
+  <RepositoryData>
+    <ServiceIndication>call-forwarding</ServiceIndication>
+    <SequenceNumber>3</SequenceNumber>
+    <ServiceData><CF xmlns="urn:test"><Target>sip:voicemail@ims.test</Target></CF></ServiceData>
+  </RepositoryData>
+  <Sh-IMS-Data>
+    <S-CSCFName>sip:scscf.ims.test:6060</S-CSCFName>
+    <IFCs>
+      <InitialFilterCriteria>
+        <Priority>1</Priority>
+        <TriggerPoint>
+          <ConditionTypeCNF>1</ConditionTypeCNF>
+          <SPT>
+            <ConditionNegated>0</ConditionNegated>
+            <Group>0</Group>
+            <Method>INVITE</Method>
+          </SPT>
+          <SPT>
+            <ConditionNegated>0</ConditionNegated>
+            <Group>1</Group>
+            <SessionCase>0</SessionCase>
+          </SPT>
+        </TriggerPoint>
+        <ApplicationServer>
+          <ServerName>sip:as.ims.test</ServerName>
+          <DefaultHandling>0</DefaultHandling>
+        </ApplicationServer>
+      </InitialFilterCriteria>
+    </IFCs>
+    <IMSUserState>1</IMSUserState>
+    <ChargingInformation>
+      <PrimaryChargingCollectionFunctionName>aaa://ccf.ims.test</PrimaryChargingCollectionFunctionName>
+    </ChargingInformation>
+    <Extension>
+      <PSIActivation>1</PSIActivation>
+      <Extension>
+        <DSAI><DSAI-Tag>vm</DSAI-Tag><DSAI-Value>0</DSAI-Value></DSAI>
+      </Extension>
+    </Extension>
+  </Sh-IMS-Data>
+  <CSUserState>2</CSUserState>
+  <Vendor-Specific-Element attr="x"><Value>42</Value></Vendor-Specific-Element>
+</Sh-Data>`
+
+func TestShData(t *testing.T) {
+	d, err := ParseShData([]byte(testShData))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.PublicIdentifiers == nil || len(d.PublicIdentifiers.IMSPublicIdentity) != 2 || d.PublicIdentifiers.MSISDN[0] != "15551234567" {
+		t.Fatalf("Unexpected PublicIdentifiers: %+v", d.PublicIdentifiers)
+	}
+	rd := d.Repository("call-forwarding")
+	if rd == nil || rd.SequenceNumber != 3 || !strings.Contains(string(rd.ServiceData.Inner), "sip:voicemail@ims.test") {
+		t.Fatalf("Unexpected RepositoryData: %+v", rd)
+	}
+	if d.Repository("unknown") != nil {
+		t.Fatal("Unexpected RepositoryData for unknown Service-Indication")
+	}
+	ims := d.IMSData
+	if ims == nil || ims.SCSCFName != "sip:scscf.ims.test:6060" || *ims.IMSUserState != Registered {
+		t.Fatalf("Unexpected Sh-IMS-Data: %+v", ims)
+	}
+	if len(ims.IFCs) != 1 || len(ims.IFCs[0].TriggerPoint.SPT) != 2 || ims.IFCs[0].TriggerPoint.SPT[0].Method != "INVITE" {
+		t.Fatalf("Unexpected IFCs: %+v", ims.IFCs)
+	}
+	if ims.IFCs[0].ApplicationServer.ServerName != "sip:as.ims.test" || *ims.IFCs[0].TriggerPoint.SPT[1].SessionCase != 0 {
+		t.Fatalf("Unexpected IFC: %+v", ims.IFCs[0])
+	}
+	if *ims.PSIActivation != 1 || len(ims.DSAI) != 1 || ims.DSAI[0].DSAITag != "vm" {
+		t.Fatalf("Unexpected extensions: %+v", ims)
+	}
+	if *d.CSUserState != 2 || len(d.Other) != 1 || d.Other[0].XMLName.Local != "Vendor-Specific-Element" {
+		t.Fatalf("Unexpected Sh-Data: %+v", d)
+	}
+
+	rd.SequenceNumber++
+	b, err := d.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	d2, err := ParseShData(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d2.Repository("call-forwarding").SequenceNumber != 4 {
+		t.Fatalf("Unexpected SequenceNumber after round trip:\n%s", b)
+	}
+	d.RepositoryData[0].SequenceNumber = 4
+	if !reflect.DeepEqual(d.IMSData, d2.IMSData) || !reflect.DeepEqual(d.PublicIdentifiers, d2.PublicIdentifiers) {
+		t.Fatalf("Round trip changed the document:\n%s", b)
+	}
+	if !strings.Contains(string(b), `<Vendor-Specific-Element attr="x"><Value>42</Value></Vendor-Specific-Element>`) {
+		t.Fatalf("Unknown element was not preserved:\n%s", b)
+	}
+
+	if _, err = ParseShData(nil); err != ErrNoUserData {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err = ParseShData([]byte("<Sh-Data><PublicIdentifiers>")); err == nil {
+		t.Fatal("Malformed document was accepted")
+	}
+}
+
+type testBackend struct {
+	mu   sync.Mutex
+	data map[string]*RepositoryData // By Service-Indication.
+}
+
+func (b *testBackend) UserData(udr *UDR, uda *UDA) {
+	if udr.UserIdentity.PublicIdentity != "sip:alice@ims.test" {
+		uda.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: UserUnknown}
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	d := &ShData{}
+	for _, si := range udr.ServiceIndication {
+		if rd, ok := b.data[string(si)]; ok {
+			d.RepositoryData = append(d.RepositoryData, *rd)
+		}
+	}
+	v, _ := d.Marshal()
+	uda.UserData = datatype.OctetString(v)
+}
+
+func (b *testBackend) ProfileUpdate(pur *PUR, pua *PUA) {
+	d, err := pur.ShData()
+	if err != nil || pur.DataReference != RefRepositoryData || len(d.RepositoryData) != 1 {
+		pua.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: UserDataNotRecognized}
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	rd := d.RepositoryData[0]
+	if prev, ok := b.data[rd.ServiceIndication]; ok && rd.SequenceNumber != prev.SequenceNumber+1 {
+		pua.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: TransparentDataOutOfSync}
+		return
+	}
+	b.data[rd.ServiceIndication] = &rd
+}
+
+func (b *testBackend) SubscribeNotifications(snr *SNR, sna *SNA) {}
+
+func TestClientServer(t *testing.T) {
+	hss := &Server{
+		OriginHost:  "hss",
+		OriginRealm: "test",
+		Backend:     &testBackend{data: make(map[string]*RepositoryData)},
+		Timeout:     time.Second,
+	}
+	pnrc := make(chan *PNR, 1)
+	as := &Client{
+		OriginHost:       "as",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		OnPushNotification: func(pnr *PNR, pna *PNA) {
+			pnrc <- pnr
+		},
+	}
+	c, done := smtest.Connect(t,
+		smtest.Peer{Host: "hss", Handler: hss, Commands: []diam.CommandIndex{UDRIndex, PURIndex, SNRIndex, PNAIndex}},
+		smtest.Peer{Host: "as", Handler: as, Commands: []diam.CommandIndex{UDAIndex, PUAIndex, SNAIndex, PNRIndex}},
+		base.Vendor3GPP, diam.TGPP_SH_APP_ID)
+	defer done()
+
+	alice := UserIdentity{PublicIdentity: "sip:alice@ims.test"}
+
+	// The subtests below share the repository data of ProfileUpdate.
+	t.Run("ProfileUpdate", func(t *testing.T) {
+		update := func(seq uint32) *PUA {
+			t.Helper()
+			d := &ShData{RepositoryData: []RepositoryData{{
+				ServiceIndication: "call-forwarding",
+				SequenceNumber:    seq,
+				ServiceData:       &RawXML{Inner: []byte("<Target>sip:voicemail@ims.test</Target>")},
+			}}}
+			b, err := d.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+			pua, err := as.ProfileUpdate(c, &PUR{UserIdentity: alice, DataReference: RefRepositoryData, UserData: datatype.OctetString(b)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return pua
+		}
+		if pua := update(0); pua.ResultCode != diam.Success || !strings.HasPrefix(pua.SessionID, "as;") {
+			t.Fatalf("Unexpected PUA: %+v", pua)
+		}
+		if pua := update(5); pua.ExperimentalResult == nil || pua.ExperimentalResult.Code != TransparentDataOutOfSync {
+			t.Fatalf("Unexpected PUA: %+v", pua)
+		}
+	})
+
+	t.Run("UserData", func(t *testing.T) {
+		uda, err := as.UserData(c, &UDR{UserIdentity: alice, ServiceIndication: []datatype.OctetString{"call-forwarding"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		d, err := uda.ShData()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rd := d.Repository("call-forwarding"); rd == nil || rd.SequenceNumber != 0 || string(rd.ServiceData.Inner) != "<Target>sip:voicemail@ims.test</Target>" {
+			t.Fatalf("Unexpected RepositoryData: %+v", rd)
+		}
+		if uda, err = as.UserData(c, &UDR{UserIdentity: UserIdentity{MSISDN: "15550000000"}}); err != nil {
+			t.Fatal(err)
+		}
+		if uda.ExperimentalResult == nil || uda.ExperimentalResult.Code != UserUnknown {
+			t.Fatalf("Unexpected UDA: %+v", uda)
+		}
+	})
+
+	t.Run("PushNotification", func(t *testing.T) {
+		if _, err := hss.PushNotification(alice.PublicIdentity, "<Sh-Data/>"); err != ErrNoSubscribers {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expiry := time.Now().Add(time.Hour).Truncate(time.Second)
+		sna, err := as.SubscribeNotifications(c, &SNR{
+			UserIdentity:      alice,
+			ServiceIndication: []datatype.OctetString{"call-forwarding"},
+			SubsReqType:       Subscribe,
+			ExpiryTime:        &expiry,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sna.ResultCode != diam.Success || sna.ExpiryTime == nil || !sna.ExpiryTime.Equal(expiry) {
+			t.Fatalf("Unexpected SNA: %+v", sna)
+		}
+		if ids := hss.Subscriptions(); !reflect.DeepEqual(ids, []string{alice.PublicIdentity}) {
+			t.Fatalf("Unexpected subscriptions: %v", ids)
+		}
+		if snrs := hss.Subscribers(alice.PublicIdentity); len(snrs) != 1 || snrs[0].OriginHost != "as" {
+			t.Fatalf("Unexpected subscribers: %+v", snrs)
+		}
+
+		pnas, err := hss.PushNotification(alice.PublicIdentity, "<Sh-Data/>")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(pnas) != 1 || pnas[0].ResultCode != diam.Success || pnas[0].OriginHost != "as" {
+			t.Fatalf("Unexpected PNAs: %+v", pnas)
+		}
+		select {
+		case pnr := <-pnrc:
+			if pnr.UserIdentity != alice || pnr.DestinationHost != "as" || string(pnr.UserData) != "<Sh-Data/>" {
+				t.Fatalf("Unexpected PNR: %+v", pnr)
+			}
+		default:
+			t.Fatal("No PNR received")
+		}
+
+		sna, err = as.SubscribeNotifications(c, &SNR{UserIdentity: alice, SubsReqType: Unsubscribe})
+		if err != nil || sna.ResultCode != diam.Success {
+			t.Fatalf("Unexpected SNA: %+v, %v", sna, err)
+		}
+		if ids := hss.Subscriptions(); len(ids) != 0 {
+			t.Fatalf("Unexpected subscriptions: %v", ids)
+		}
+	})
+
+	t.Run("OneTimeNotification", func(t *testing.T) {
+		once := int32(0)
+		if _, err := as.SubscribeNotifications(c, &SNR{UserIdentity: alice, OneTimeNotification: &once}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := hss.PushNotification(alice.PublicIdentity, "<Sh-Data/>"); err != nil {
+			t.Fatal(err)
+		}
+		<-pnrc
+		if _, err := hss.PushNotification(alice.PublicIdentity, "<Sh-Data/>"); err != ErrNoSubscribers {
+			t.Fatalf("Unexpected error after one-time notification: %v", err)
+		}
+	})
+}
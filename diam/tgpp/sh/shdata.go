@@ -0,0 +1,204 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sh
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+)
+
+// ErrNoUserData is returned when a message carries no User-Data.
+var ErrNoUserData = errors.New("sh: no user data")
+
+// IMSUserState values. See 3GPP TS 29.328 section 7.6.
+const (
+	NotRegistered           = 0
+	Registered              = 1
+	RegisteredUnregServices = 2
+	AuthenticationPending   = 3
+)
+
+// ShData is the Sh-Data XML document carried in the User-Data AVP, as
+// specified in 3GPP TS 29.328 Annex D. Elements that are not modelled
+// are kept in Other, so that documents survive a parse and serialise
+// round trip.
+type ShData struct {
+	XMLName               xml.Name           `xml:"Sh-Data"`
+	PublicIdentifiers     *PublicIdentifiers `xml:"PublicIdentifiers"`
+	RepositoryData        []RepositoryData   `xml:"RepositoryData"`
+	IMSData               *IMSData           `xml:"Sh-IMS-Data"`
+	CSLocationInformation *RawXML            `xml:"CSLocationInformation"`
+	PSLocationInformation *RawXML            `xml:"PSLocationInformation"`
+	CSUserState           *int               `xml:"CSUserState"`
+	PSUserState           *int               `xml:"PSUserState"`
+	Extension             *ShDataExtension   `xml:"Sh-Data-Extension"`
+	Other                 []RawElement       `xml:",any"`
+}
+
+// PublicIdentifiers is a set of IMS public identities and MSISDNs.
+type PublicIdentifiers struct {
+	IMSPublicIdentity []string `xml:"IMSPublicIdentity"`
+	MSISDN            []string `xml:"MSISDN"`
+}
+
+// RepositoryData is the transparent data an application server keeps
+// in the HSS for a Service-Indication. SequenceNumber must be
+// incremented on every update.
+type RepositoryData struct {
+	ServiceIndication string  `xml:"ServiceIndication"`
+	SequenceNumber    uint32  `xml:"SequenceNumber"`
+	ServiceData       *RawXML `xml:"ServiceData"`
+}
+
+// IMSData is the Sh-IMS-Data element.
+type IMSData struct {
+	SCSCFName           string                  `xml:"S-CSCFName,omitempty"`
+	IFCs                []InitialFilterCriteria `xml:"IFCs>InitialFilterCriteria"`
+	IMSUserState        *int                    `xml:"IMSUserState"`
+	ChargingInformation *ChargingInformation    `xml:"ChargingInformation"`
+	PSIActivation       *int                    `xml:"Extension>PSIActivation"`
+	DSAI                []DSAI                  `xml:"Extension>Extension>DSAI"`
+}
+
+// InitialFilterCriteria decides which SIP requests are routed to an
+// application server. See 3GPP TS 29.228 Annex E.
+type InitialFilterCriteria struct {
+	Priority             int               `xml:"Priority"`
+	TriggerPoint         *TriggerPoint     `xml:"TriggerPoint"`
+	ApplicationServer    ApplicationServer `xml:"ApplicationServer"`
+	ProfilePartIndicator *int              `xml:"ProfilePartIndicator"`
+}
+
+// TriggerPoint is the condition under which an InitialFilterCriteria
+// applies. With ConditionTypeCNF set, the SPTs of each group are ORed
+// and the groups ANDed; otherwise the reverse.
+type TriggerPoint struct {
+	ConditionTypeCNF int   `xml:"ConditionTypeCNF"`
+	SPT              []SPT `xml:"SPT"`
+}
+
+// SPT is a Service Point Trigger. Exactly one of RequestURI, Method,
+// SIPHeader, SessionCase and SessionDescription is set.
+type SPT struct {
+	ConditionNegated   int                 `xml:"ConditionNegated"`
+	Group              []int               `xml:"Group"`
+	RequestURI         string              `xml:"RequestURI,omitempty"`
+	Method             string              `xml:"Method,omitempty"`
+	SIPHeader          *SIPHeader          `xml:"SIPHeader"`
+	SessionCase        *int                `xml:"SessionCase"`
+	SessionDescription *SessionDescription `xml:"SessionDescription"`
+	RegistrationType   []int               `xml:"Extension>RegistrationType"`
+}
+
+// SIPHeader matches a SIP header and, optionally, its content.
+type SIPHeader struct {
+	Header  string `xml:"Header"`
+	Content string `xml:"Content,omitempty"`
+}
+
+// SessionDescription matches an SDP line and, optionally, its content.
+type SessionDescription struct {
+	Line    string `xml:"Line"`
+	Content string `xml:"Content,omitempty"`
+}
+
+// ApplicationServer is the application server an InitialFilterCriteria
+// routes to.
+type ApplicationServer struct {
+	ServerName      string `xml:"ServerName"`
+	DefaultHandling *int   `xml:"DefaultHandling"`
+	ServiceInfo     string `xml:"ServiceInfo,omitempty"`
+}
+
+// ChargingInformation holds the addresses of the charging functions.
+type ChargingInformation struct {
+	PrimaryEventChargingFunctionName        string `xml:"PrimaryEventChargingFunctionName,omitempty"`
+	SecondaryEventChargingFunctionName      string `xml:"SecondaryEventChargingFunctionName,omitempty"`
+	PrimaryChargingCollectionFunctionName   string `xml:"PrimaryChargingCollectionFunctionName,omitempty"`
+	SecondaryChargingCollectionFunctionName string `xml:"SecondaryChargingCollectionFunctionName,omitempty"`
+}
+
+// DSAI is a Dynamic Service Activation Info element. DSAIValue is 0
+// when the service is active and 1 when inactive.
+type DSAI struct {
+	DSAITag   string `xml:"DSAI-Tag"`
+	DSAIValue int    `xml:"DSAI-Value"`
+}
+
+// ShDataExtension is the Sh-Data-Extension element, which carries the
+// identity sets requested with Identity-Set.
+type ShDataExtension struct {
+	RegisteredIdentities *PublicIdentifiers `xml:"RegisteredIdentities"`
+	ImplicitIdentities   *PublicIdentifiers `xml:"ImplicitIdentities"`
+	AllIdentities        *PublicIdentifiers `xml:"AllIdentities"`
+	AliasIdentities      *PublicIdentifiers `xml:"AliasIdentities"`
+	Other                []RawElement       `xml:",any"`
+}
+
+// RawXML holds the content of an element as raw XML.
+type RawXML struct {
+	Inner []byte `xml:",innerxml"`
+}
+
+// RawElement is an element kept as raw XML.
+type RawElement struct {
+	XMLName xml.Name
+	Attr    []xml.Attr `xml:",any,attr"`
+	Inner   []byte     `xml:",innerxml"`
+}
+
+// ParseShData parses the Sh-Data XML document b.
+func ParseShData(b []byte) (*ShData, error) {
+	if len(bytes.TrimSpace(b)) == 0 {
+		return nil, ErrNoUserData
+	}
+	var d ShData
+	if err := xml.Unmarshal(b, &d); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Marshal serialises the document, with an XML declaration, for use as
+// User-Data.
+func (d *ShData) Marshal() ([]byte, error) {
+	b, err := xml.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), b...), nil
+}
+
+// Repository returns the RepositoryData of a Service-Indication, or
+// nil when there is none.
+func (d *ShData) Repository(serviceIndication string) *RepositoryData {
+	for i := range d.RepositoryData {
+		if d.RepositoryData[i].ServiceIndication == serviceIndication {
+			return &d.RepositoryData[i]
+		}
+	}
+	return nil
+}
+
+// ShData parses the User-Data of the answer.
+func (uda *UDA) ShData() (*ShData, error) {
+	return ParseShData([]byte(uda.UserData))
+}
+
+// ShData parses the User-Data of the answer.
+func (sna *SNA) ShData() (*ShData, error) {
+	return ParseShData([]byte(sna.UserData))
+}
+
+// ShData parses the User-Data of the request.
+func (pur *PUR) ShData() (*ShData, error) {
+	return ParseShData([]byte(pur.UserData))
+}
+
+// ShData parses the User-Data of the request.
+func (pnr *PNR) ShData() (*ShData, error) {
+	return ParseShData([]byte(pnr.UserData))
+}
@@ -0,0 +1,182 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package swx
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// DefaultTimeout is how long the Client and Server wait for answers
+// when no Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+func vendorSpecificApplicationID() *base.VendorSpecificApplicationID {
+	return base.NewVendorSpecificApplicationID(diam.TGPP_SWX_APP_ID)
+}
+
+// Client is the 3GPP AAA server side of SWx. It sends MAR and SAR to
+// the HSS and answers the RTR and PPR sent by the HSS.
+//
+// Requests are completed by the Client before being sent: Session-Id is
+// generated when empty, and the routing AVPs, Vendor-Specific-Application-Id
+// and Auth-Session-State are filled in.
+//
+// Client implements the diam.Handler interface and must be registered
+// for MAAIndex, SAAIndex, RTRIndex and PPRIndex on the connection's
+// handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// The following functions, if non-nil, are called for requests sent
+	// by the HSS. The answer is filled in with DIAMETER_SUCCESS on entry
+	// and may be changed; setting an Experimental-Result clears the
+	// Result-Code. They run on the connection's read goroutine and must
+	// not block.
+	OnRegistrationTermination func(rtr *RTR, rta *RTA)
+	OnPushProfile             func(ppr *PPR, ppa *PPA)
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending pending.Table
+}
+
+// MultimediaAuth sends a MAR built from mar over c and waits for the
+// MAA. One EAP-AKA' vector is requested when mar requests none.
+func (cli *Client) MultimediaAuth(c diam.Conn, mar *MAR) (*MAA, error) {
+	if len(mar.SessionID) == 0 {
+		mar.SessionID = sessionid.New(cli.OriginHost)
+	}
+	mar.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	mar.AuthSessionState = base.NoStateMaintained
+	mar.OriginHost = cli.OriginHost
+	mar.OriginRealm = cli.OriginRealm
+	mar.DestinationRealm = cli.DestinationRealm
+	mar.DestinationHost = cli.DestinationHost
+	if mar.SIPNumberAuthItems == 0 {
+		mar.SIPNumberAuthItems = 1
+	}
+	if mar.SIPAuthDataItem == nil {
+		mar.SIPAuthDataItem = &SIPAuthDataItem{SIPAuthenticationScheme: SchemeEAPAKAPrime}
+	}
+	var maa MAA
+	if err := cli.exchange(c, diam.MultimediaAuth, mar, &maa); err != nil {
+		return nil, err
+	}
+	return &maa, nil
+}
+
+// ServerAssignment sends a SAR built from sar over c and waits for the
+// SAA. Server-Assignment-Type defaults to REGISTRATION when sar has
+// NO_ASSIGNMENT.
+func (cli *Client) ServerAssignment(c diam.Conn, sar *SAR) (*SAA, error) {
+	if len(sar.SessionID) == 0 {
+		sar.SessionID = sessionid.New(cli.OriginHost)
+	}
+	sar.VendorSpecificApplicationID = vendorSpecificApplicationID()
+	sar.AuthSessionState = base.NoStateMaintained
+	sar.OriginHost = cli.OriginHost
+	sar.OriginRealm = cli.OriginRealm
+	sar.DestinationRealm = cli.DestinationRealm
+	sar.DestinationHost = cli.DestinationHost
+	if sar.ServerAssignmentType == NoAssignment {
+		sar.ServerAssignmentType = Registration
+	}
+	var saa SAA
+	if err := cli.exchange(c, diam.ServerAssignment, sar, &saa); err != nil {
+		return nil, err
+	}
+	return &saa, nil
+}
+
+func (cli *Client) exchange(c diam.Conn, code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.TGPP_SWX_APP_ID, c.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := cli.pending.Exchange(c, m, cli.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.RegistrationTermination:
+		var rtr RTR
+		rta := &RTA{}
+		if decode(m, &rtr, &rta.ResultCode) && cli.OnRegistrationTermination != nil {
+			cli.OnRegistrationTermination(&rtr, rta)
+		}
+		rta.SessionID = rtr.SessionID
+		rta.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		rta.AuthSessionState = base.NoStateMaintained
+		rta.OriginHost = cli.OriginHost
+		rta.OriginRealm = cli.OriginRealm
+		if rta.ExperimentalResult != nil {
+			rta.ResultCode = 0
+		}
+		answer(c, m, rta, cli.ErrorReporter)
+	case diam.PushProfile:
+		var ppr PPR
+		ppa := &PPA{}
+		if decode(m, &ppr, &ppa.ResultCode) && cli.OnPushProfile != nil {
+			cli.OnPushProfile(&ppr, ppa)
+		}
+		ppa.SessionID = ppr.SessionID
+		ppa.VendorSpecificApplicationID = vendorSpecificApplicationID()
+		ppa.AuthSessionState = base.NoStateMaintained
+		ppa.OriginHost = cli.OriginHost
+		ppa.OriginRealm = cli.OriginRealm
+		if ppa.ExperimentalResult != nil {
+			ppa.ResultCode = 0
+		}
+		answer(c, m, ppa, cli.ErrorReporter)
+	}
+}
+
+// decode decodes the request m into req and sets the Result-Code of
+// its answer, reporting whether the request is valid.
+func decode(m *diam.Message, req interface{}, resultCode *uint32) bool {
+	if err := m.Unmarshal(req); err != nil {
+		*resultCode = diam.UnableToComply
+		return false
+	}
+	*resultCode = diam.Success
+	return true
+}
+
+// answer writes the answer v to the request m.
+func answer(c diam.Conn, m *diam.Message, v interface{}, er diam.ErrorReporter) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && er != nil {
+		er.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
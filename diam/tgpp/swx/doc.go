@@ -0,0 +1,34 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package swx implements the SWx application between the 3GPP AAA
+// server and the HSS, as specified in 3GPP TS 29.273.
+//
+// It provides typed MAR/MAA, SAR/SAA, RTR/RTA and PPR/PPA messages,
+// including the EAP-AKA' authentication vectors and Non-3GPP-User-Data,
+// for use with Message.Marshal and Message.Unmarshal. It also provides
+// a 3GPP AAA server Client and an HSS Server that fill in the mandatory
+// AVPs of the messages they send.
+//
+// A 3GPP AAA server authenticating and registering a subscriber:
+//
+//	aaa := &swx.Client{
+//		OriginHost:       "aaa.example.com",
+//		OriginRealm:      "example.com",
+//		DestinationRealm: "example.com",
+//		OnRegistrationTermination: func(rtr *swx.RTR, rta *swx.RTA) {
+//			log.Println("deregistered", rtr.UserName)
+//		},
+//	}
+//	mux := sm.New(settings)
+//	for _, idx := range []diam.CommandIndex{
+//		swx.MAAIndex, swx.SAAIndex, swx.RTRIndex, swx.PPRIndex,
+//	} {
+//		mux.HandleIdx(idx, aaa)
+//	}
+//	...
+//	maa, err := aaa.MultimediaAuth(conn, &swx.MAR{UserName: nai})
+//	...
+//	saa, err := aaa.ServerAssignment(conn, &swx.SAR{UserName: nai})
+package swx
@@ -0,0 +1,250 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package swx
+
+import (
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+)
+
+// Command indexes of the SWx application, for use with ServeMux.HandleIdx.
+var (
+	MARIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.MultimediaAuth, Request: true}
+	MAAIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.MultimediaAuth, Request: false}
+	SARIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.ServerAssignment, Request: true}
+	SAAIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.ServerAssignment, Request: false}
+	RTRIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.RegistrationTermination, Request: true}
+	RTAIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.RegistrationTermination, Request: false}
+	PPRIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.PushProfile, Request: true}
+	PPAIndex = diam.CommandIndex{AppID: diam.TGPP_SWX_APP_ID, Code: diam.PushProfile, Request: false}
+)
+
+// Experimental-Result-Code values. See 3GPP TS 29.273 section 8.2.5
+// and 3GPP TS 29.229 section 6.2.2.
+const (
+	UserUnknown               = 5001
+	IdentityNotRegistered     = 5003
+	RoamingNotAllowed         = 5004
+	IdentityAlreadyRegistered = 5005
+	UserNoNon3GPPSubscription = 5450
+	UserNoAPNSubscription     = 5451
+	RATTypeNotAllowed         = 5452
+)
+
+// SIP-Authentication-Scheme values used on SWx.
+// See 3GPP TS 29.273 section 8.2.3.1.
+const (
+	SchemeEAPAKA      = "EAP-AKA"
+	SchemeEAPAKAPrime = "EAP-AKA'"
+)
+
+// Server-Assignment-Type values. See 3GPP TS 29.229 section 6.3.15.
+const (
+	NoAssignment                         = 0
+	Registration                         = 1
+	ReRegistration                       = 2
+	UnregisteredUser                     = 3
+	TimeoutDeregistration                = 4
+	UserDeregistration                   = 5
+	TimeoutDeregistrationStoreServerName = 6
+	UserDeregistrationStoreServerName    = 7
+	AdministrativeDeregistration         = 8
+	AuthenticationFailure                = 9
+	AuthenticationTimeout                = 10
+	DeregistrationTooMuchData            = 11
+	AAAUserDataRequest                   = 12
+	PGWUpdate                            = 13
+	Restoration                          = 14
+)
+
+// Reason-Code values. See 3GPP TS 29.229 section 6.3.17.
+const (
+	PermanentTermination = 0
+	NewServerAssigned    = 1
+	ServerChange         = 2
+	RemoveSCSCF          = 3
+)
+
+// Non-3GPP-IP-Access values. See 3GPP TS 29.273 section 8.2.3.3.
+const (
+	Non3GPPSubscriptionAllowed = 0
+	Non3GPPSubscriptionBarred  = 1
+)
+
+// Non-3GPP-IP-Access-APN values. See 3GPP TS 29.273 section 8.2.3.4.
+const (
+	Non3GPPAPNsEnable  = 0
+	Non3GPPAPNsDisable = 1
+)
+
+// SIPAuthDataItem is the SIP-Auth-Data-Item grouped AVP. In an MAA it
+// carries an authentication vector: SIP-Authenticate is RAND||AUTN,
+// SIP-Authorization is XRES, and the keys are CK' and IK' for EAP-AKA'.
+// In an MAR it carries the resynchronization info as RAND||AUTS in
+// SIP-Authorization.
+type SIPAuthDataItem struct {
+	SIPItemNumber           *uint32              `avp:"SIP-Item-Number"`
+	SIPAuthenticationScheme string               `avp:"SIP-Authentication-Scheme,omitempty"`
+	SIPAuthenticate         datatype.OctetString `avp:"SIP-Authenticate,omitempty"`
+	SIPAuthorization        datatype.OctetString `avp:"SIP-Authorization,omitempty"`
+	ConfidentialityKey      datatype.OctetString `avp:"Confidentiality-Key,omitempty"`
+	IntegrityKey            datatype.OctetString `avp:"Integrity-Key,omitempty"`
+}
+
+// DeregistrationReason is the Deregistration-Reason grouped AVP.
+type DeregistrationReason struct {
+	ReasonCode int32  `avp:"Reason-Code"`
+	ReasonInfo string `avp:"Reason-Info,omitempty"`
+}
+
+// Non3GPPUserData is the Non-3GPP-User-Data grouped AVP, which carries
+// the non-3GPP access subscription of a user.
+type Non3GPPUserData struct {
+	SubscriptionID              *gy.SubscriptionID     `avp:"Subscription-Id"`
+	Non3GPPIPAccess             *int32                 `avp:"Non-3GPP-IP-Access"`
+	Non3GPPIPAccessAPN          *int32                 `avp:"Non-3GPP-IP-Access-APN"`
+	RATType                     []int32                `avp:"RAT-Type"`
+	SessionTimeout              uint32                 `avp:"Session-Timeout,omitempty"`
+	MIP6FeatureVector           uint64                 `avp:"MIP6-Feature-Vector,omitempty"`
+	AMBR                        *s6a.AMBR              `avp:"AMBR"`
+	TGPPChargingCharacteristics string                 `avp:"TGPP-Charging-Characteristics,omitempty"`
+	ContextIdentifier           uint32                 `avp:"Context-Identifier,omitempty"`
+	APNOIReplacement            string                 `avp:"APN-OI-Replacement,omitempty"`
+	APNConfiguration            []s6a.APNConfiguration `avp:"APN-Configuration"`
+}
+
+// MAR is a Multimedia-Auth-Request message, sent by the 3GPP AAA server
+// to request authentication vectors. See 3GPP TS 29.273 section 8.2.2.1.
+type MAR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	RATType                     *int32                            `avp:"RAT-Type"`
+	ANID                        string                            `avp:"ANID,omitempty"`
+	VisitedNetworkIdentifier    datatype.OctetString              `avp:"Visited-Network-Identifier,omitempty"`
+	TerminalInformation         *s6a.TerminalInformation          `avp:"Terminal-Information"`
+	SIPAuthDataItem             *SIPAuthDataItem                  `avp:"SIP-Auth-Data-Item"`
+	SIPNumberAuthItems          uint32                            `avp:"SIP-Number-Auth-Items"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// MAA is a Multimedia-Auth-Answer message.
+// See 3GPP TS 29.273 section 8.2.2.1.
+type MAA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	SIPNumberAuthItems          uint32                            `avp:"SIP-Number-Auth-Items,omitempty"`
+	SIPAuthDataItem             []SIPAuthDataItem                 `avp:"SIP-Auth-Data-Item"`
+	TGPPAAAServerName           datatype.DiameterIdentity         `avp:"TGPP-AAA-Server-Name,omitempty"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// SAR is a Server-Assignment-Request message, sent by the 3GPP AAA
+// server to register itself for a user or to download its non-3GPP
+// subscription. See 3GPP TS 29.273 section 8.2.2.3.
+type SAR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	ServiceSelection            string                            `avp:"Service-Selection,omitempty"`
+	ContextIdentifier           uint32                            `avp:"Context-Identifier,omitempty"`
+	VisitedNetworkIdentifier    datatype.OctetString              `avp:"Visited-Network-Identifier,omitempty"`
+	UserName                    string                            `avp:"User-Name"`
+	ServerAssignmentType        int32                             `avp:"Server-Assignment-Type"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// SAA is a Server-Assignment-Answer message.
+// See 3GPP TS 29.273 section 8.2.2.3.
+type SAA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	Non3GPPUserData             *Non3GPPUserData                  `avp:"Non-3GPP-User-Data"`
+	TGPPAAAServerName           datatype.DiameterIdentity         `avp:"TGPP-AAA-Server-Name,omitempty"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// RTR is a Registration-Termination-Request message, sent by the HSS to
+// deregister a user from the 3GPP AAA server.
+// See 3GPP TS 29.273 section 8.2.2.2.
+type RTR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	DeregistrationReason        DeregistrationReason              `avp:"Deregistration-Reason"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// RTA is a Registration-Termination-Answer message.
+// See 3GPP TS 29.273 section 8.2.2.2.
+type RTA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// PPR is a Push-Profile-Request message, sent by the HSS to update the
+// non-3GPP subscription of a user at the 3GPP AAA server.
+// See 3GPP TS 29.273 section 8.2.2.4.
+type PPR struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity         `avp:"Destination-Host"`
+	DestinationRealm            datatype.DiameterIdentity         `avp:"Destination-Realm"`
+	UserName                    string                            `avp:"User-Name"`
+	Non3GPPUserData             *Non3GPPUserData                  `avp:"Non-3GPP-User-Data"`
+	PPRFlags                    uint32                            `avp:"PPR-Flags,omitempty"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
+
+// PPA is a Push-Profile-Answer message.
+// See 3GPP TS 29.273 section 8.2.2.4.
+type PPA struct {
+	SessionID                   string                            `avp:"Session-Id"`
+	VendorSpecificApplicationID *base.VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id"`
+	ResultCode                  uint32                            `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *base.ExperimentalResult          `avp:"Experimental-Result"`
+	AuthSessionState            int32                             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity         `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity         `avp:"Origin-Realm"`
+	SupportedFeatures           []base.SupportedFeatures          `avp:"Supported-Features"`
+}
@@ -0,0 +1,186 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package swx
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/s6a"
+)
+
+const testUser = "0001010000000001@nai.epc.mnc001.mcc001.3gppnetwork.org"
+
+type testBackend struct{}
+
+func (testBackend) MultimediaAuth(mar *MAR, maa *MAA) {
+	if mar.UserName != testUser {
+		maa.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: UserUnknown}
+		return
+	}
+	for i := uint32(0); i < mar.SIPNumberAuthItems; i++ {
+		n := i + 1
+		maa.SIPAuthDataItem = append(maa.SIPAuthDataItem, SIPAuthDataItem{
+			SIPItemNumber:           &n,
+			SIPAuthenticationScheme: mar.SIPAuthDataItem.SIPAuthenticationScheme,
+			SIPAuthenticate:         datatype.OctetString(strings.Repeat("r", 32)),
+			SIPAuthorization:        datatype.OctetString("xres0123"),
+			ConfidentialityKey:      datatype.OctetString(strings.Repeat("c", 16)),
+			IntegrityKey:            datatype.OctetString(strings.Repeat("i", 16)),
+		})
+	}
+	maa.SIPNumberAuthItems = uint32(len(maa.SIPAuthDataItem))
+}
+
+func (testBackend) ServerAssignment(sar *SAR, saa *SAA) {
+	if sar.UserName != testUser {
+		saa.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: UserUnknown}
+		return
+	}
+	access := int32(Non3GPPSubscriptionAllowed)
+	saa.Non3GPPUserData = &Non3GPPUserData{
+		SubscriptionID:  &gy.SubscriptionID{Type: gy.EndUserE164, Data: "15551234567"},
+		Non3GPPIPAccess: &access,
+		AMBR:            &s6a.AMBR{MaxRequestedBandwidthUL: 1000000, MaxRequestedBandwidthDL: 2000000},
+	}
+}
+
+func TestClientServer(t *testing.T) {
+	hss := &Server{OriginHost: "hss", OriginRealm: "test", Backend: testBackend{}, Timeout: time.Second}
+	rtrc := make(chan *RTR, 1)
+	pprc := make(chan *PPR, 1)
+	aaa := &Client{
+		OriginHost:       "aaa",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		OnRegistrationTermination: func(rtr *RTR, rta *RTA) {
+			rtrc <- rtr
+		},
+		OnPushProfile: func(ppr *PPR, ppa *PPA) {
+			pprc <- ppr
+		},
+	}
+	c, done := smtest.Connect(t,
+		smtest.Peer{Host: "hss", Handler: hss, Commands: []diam.CommandIndex{MARIndex, SARIndex, RTAIndex, PPAIndex}},
+		smtest.Peer{Host: "aaa", Handler: aaa, Commands: []diam.CommandIndex{MAAIndex, SAAIndex, RTRIndex, PPRIndex}},
+		base.Vendor3GPP, diam.TGPP_SWX_APP_ID)
+	defer done()
+
+	t.Run("MultimediaAuth", func(t *testing.T) {
+		rat := int32(s6a.RATEUTRAN)
+		maa, err := aaa.MultimediaAuth(c, &MAR{
+			UserName:            testUser,
+			RATType:             &rat,
+			SIPNumberAuthItems:  2,
+			TerminalInformation: &s6a.TerminalInformation{IMEI: "35358601234567"},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if maa.ResultCode != diam.Success || maa.SIPNumberAuthItems != 2 || len(maa.SIPAuthDataItem) != 2 {
+			t.Fatalf("Unexpected MAA: %+v", maa)
+		}
+		if v := maa.SIPAuthDataItem[1]; v.SIPAuthenticationScheme != SchemeEAPAKAPrime || *v.SIPItemNumber != 2 || len(v.SIPAuthenticate) != 32 {
+			t.Fatalf("Unexpected vector: %+v", v)
+		}
+		if !strings.HasPrefix(maa.SessionID, "aaa;") || maa.OriginHost != "hss" || maa.UserName != testUser {
+			t.Fatalf("Unexpected MAA: %+v", maa)
+		}
+		if maa, err = aaa.MultimediaAuth(c, &MAR{UserName: "unknown"}); err != nil {
+			t.Fatal(err)
+		}
+		if maa.ResultCode != 0 || maa.ExperimentalResult == nil || maa.ExperimentalResult.Code != UserUnknown {
+			t.Fatalf("Unexpected MAA: %+v", maa)
+		}
+	})
+
+	// The subtests below share the registration made by ServerAssignment.
+	t.Run("ServerAssignment", func(t *testing.T) {
+		saa, err := aaa.ServerAssignment(c, &SAR{UserName: testUser})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if saa.ResultCode != diam.Success || saa.Non3GPPUserData == nil || saa.Non3GPPUserData.AMBR == nil {
+			t.Fatalf("Unexpected SAA: %+v", saa)
+		}
+		if d := saa.Non3GPPUserData; d.SubscriptionID.Data != "15551234567" || *d.Non3GPPIPAccess != Non3GPPSubscriptionAllowed {
+			t.Fatalf("Unexpected Non-3GPP-User-Data: %+v", d)
+		}
+		if users := hss.Registrations(); !reflect.DeepEqual(users, []string{testUser}) {
+			t.Fatalf("Unexpected registrations: %v", users)
+		}
+		if sar, ok := hss.Registration(testUser); !ok || sar.ServerAssignmentType != Registration {
+			t.Fatalf("Unexpected registration: %+v", sar)
+		}
+	})
+
+	t.Run("PushProfile", func(t *testing.T) {
+		access := int32(Non3GPPSubscriptionBarred)
+		ppr := &PPR{Non3GPPUserData: &Non3GPPUserData{Non3GPPIPAccess: &access}}
+		ppa, err := hss.PushProfile(testUser, ppr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ppr.SessionID != "" || ppr.UserName != "" {
+			t.Fatalf("PushProfile modified the PPR: %+v", ppr)
+		}
+		if ppa.ResultCode != diam.Success || ppa.OriginHost != "aaa" {
+			t.Fatalf("Unexpected PPA: %+v", ppa)
+		}
+		select {
+		case ppr := <-pprc:
+			if ppr.UserName != testUser || ppr.DestinationHost != "aaa" || *ppr.Non3GPPUserData.Non3GPPIPAccess != Non3GPPSubscriptionBarred {
+				t.Fatalf("Unexpected PPR: %+v", ppr)
+			}
+		default:
+			t.Fatal("No PPR received")
+		}
+	})
+
+	t.Run("RegistrationTermination", func(t *testing.T) {
+		rta, err := hss.RegistrationTermination(testUser, PermanentTermination, "subscription withdrawn")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rta.ResultCode != diam.Success {
+			t.Fatalf("Unexpected RTA: %+v", rta)
+		}
+		select {
+		case rtr := <-rtrc:
+			if rtr.UserName != testUser || rtr.DeregistrationReason.ReasonInfo != "subscription withdrawn" {
+				t.Fatalf("Unexpected RTR: %+v", rtr)
+			}
+		default:
+			t.Fatal("No RTR received")
+		}
+		if users := hss.Registrations(); len(users) != 0 {
+			t.Fatalf("Unexpected registrations: %v", users)
+		}
+		if _, err = hss.PushProfile(testUser, &PPR{}); err != ErrUnknownUser {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("UserDeregistration", func(t *testing.T) {
+		saa, err := aaa.ServerAssignment(c, &SAR{UserName: testUser})
+		if err != nil || saa.ResultCode != diam.Success {
+			t.Fatalf("Unexpected SAA: %+v, %v", saa, err)
+		}
+		if saa, err = aaa.ServerAssignment(c, &SAR{UserName: testUser, ServerAssignmentType: UserDeregistration}); err != nil || saa.ResultCode != diam.Success {
+			t.Fatalf("Unexpected SAA: %+v, %v", saa, err)
+		}
+		if users := hss.Registrations(); len(users) != 0 {
+			t.Fatalf("Unexpected registrations: %v", users)
+		}
+	})
+}
@@ -0,0 +1,292 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/internal/sessionid"
+)
+
+// ErrSessionClosed is returned by Session methods after the session
+// has been terminated.
+var ErrSessionClosed = errors.New("sy: session closed")
+
+// DefaultTimeout is how long the Client and Server wait for answers
+// when no Timeout is configured.
+const DefaultTimeout = pending.DefaultTimeout
+
+// Client is the PCRF side of Sy. It subscribes to the policy counters of
+// subscribers with Subscribe, keeps track of their status, and handles
+// the Spending-Status-Notification messages sent by the OCS.
+//
+// Client implements the diam.Handler interface and must be registered
+// for SLAIndex, SNRIndex and STAIndex on the connection's handler.
+type Client struct {
+	OriginHost       datatype.DiameterIdentity
+	OriginRealm      datatype.DiameterIdentity
+	DestinationRealm datatype.DiameterIdentity
+	DestinationHost  datatype.DiameterIdentity // Optional.
+	Timeout          time.Duration             // Defaults to DefaultTimeout.
+
+	// OnNotify, if non-nil, is called for every SNR after the status
+	// of the session's policy counters has been updated. It returns the
+	// Result-Code of the SNA; when nil, the Client answers with
+	// DIAMETER_SUCCESS. It runs on the connection's read goroutine
+	// and must not block.
+	OnNotify func(s *Session, snr *SNR) uint32
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	sessions map[string]*Session
+}
+
+// Session is an Sy session established by the Client, holding the
+// subscriptions of one subscriber.
+type Session struct {
+	ID string
+
+	client *Client
+	conn   diam.Conn
+	reqMu  sync.Mutex // Serializes requests of the session.
+
+	mu       sync.Mutex
+	closed   bool
+	counters map[string]PolicyCounterStatusReport
+}
+
+// Subscribe sends an initial SLR built from slr over c and returns the
+// new session. Session-Id is generated when empty, and the routing AVPs,
+// Auth-Application-Id and SL-Request-Type are filled in by the Client.
+// Without Policy-Counter-Identifier the OCS reports all the policy
+// counters of the subscriber.
+//
+// If the OCS rejects the request the returned Session is nil and the
+// reason is available in the SLA.
+func (cli *Client) Subscribe(c diam.Conn, slr *SLR) (*Session, *SLA, error) {
+	if slr == nil {
+		slr = &SLR{}
+	}
+	if len(slr.SessionID) == 0 {
+		slr.SessionID = sessionid.New(cli.OriginHost)
+	}
+	s := &Session{
+		ID:       slr.SessionID,
+		client:   cli,
+		conn:     c,
+		counters: make(map[string]PolicyCounterStatusReport),
+	}
+	cli.mu.Lock()
+	if cli.sessions == nil {
+		cli.sessions = make(map[string]*Session)
+	}
+	cli.sessions[s.ID] = s
+	cli.mu.Unlock()
+	sla, err := s.spendingLimit(InitialRequest, slr)
+	if err != nil || sla.ResultCode != diam.Success {
+		s.close()
+		return nil, sla, err
+	}
+	return s, sla, nil
+}
+
+// Session returns the active session with the given Session-Id, or nil.
+func (cli *Client) Session(id string) *Session {
+	cli.mu.Lock()
+	defer cli.mu.Unlock()
+	return cli.sessions[id]
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (cli *Client) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		cli.pending.Deliver(m)
+		return
+	}
+	if m.Header.CommandCode == diam.SpendingStatusNotification {
+		cli.notify(c, m)
+	}
+}
+
+func (cli *Client) notify(c diam.Conn, m *diam.Message) {
+	var snr SNR
+	sna := &SNA{
+		OriginHost:  cli.OriginHost,
+		OriginRealm: cli.OriginRealm,
+		ResultCode:  diam.Success,
+	}
+	if err := m.Unmarshal(&snr); err != nil {
+		sna.ResultCode = diam.UnableToComply
+	}
+	sna.SessionID = snr.SessionID
+	if sna.ResultCode == diam.Success {
+		if s := cli.Session(snr.SessionID); s == nil {
+			sna.ResultCode = diam.UnknownSessionID
+		} else {
+			s.mu.Lock()
+			for _, r := range snr.PolicyCounterStatusReport {
+				s.counters[r.ID] = r
+			}
+			s.mu.Unlock()
+			if cli.OnNotify != nil {
+				sna.ResultCode = cli.OnNotify(s, &snr)
+			}
+		}
+	}
+	a := m.Answer(0)
+	err := a.Marshal(sna)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && cli.ErrorReporter != nil {
+		cli.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
+
+// Update sends an intermediate SLR with the complete list of policy
+// counters the PCRF wants to be subscribed to. The status of counters
+// not in the list is discarded. Without counters the session subscribes
+// to all the policy counters of the subscriber.
+func (s *Session) Update(counters ...string) (*SLA, error) {
+	sla, err := s.spendingLimit(IntermediateRequest, &SLR{PolicyCounterIdentifier: counters})
+	if err != nil || sla.ResultCode != diam.Success || len(counters) == 0 {
+		return sla, err
+	}
+	keep := make(map[string]bool, len(counters))
+	for _, id := range counters {
+		keep[id] = true
+	}
+	s.mu.Lock()
+	for id := range s.counters {
+		if !keep[id] {
+			delete(s.counters, id)
+		}
+	}
+	s.mu.Unlock()
+	return sla, nil
+}
+
+func (s *Session) spendingLimit(typ int32, slr *SLR) (*SLA, error) {
+	s.reqMu.Lock()
+	defer s.reqMu.Unlock()
+	if s.isClosed() {
+		return nil, ErrSessionClosed
+	}
+	cli := s.client
+	slr.SessionID = s.ID
+	slr.AuthApplicationID = diam.DIAMETER_SY_APP_ID
+	slr.OriginHost = cli.OriginHost
+	slr.OriginRealm = cli.OriginRealm
+	slr.DestinationRealm = cli.DestinationRealm
+	slr.DestinationHost = cli.DestinationHost
+	slr.SLRequestType = typ
+	var sla SLA
+	if err := s.exchange(diam.SpendingLimit, slr, &sla); err != nil {
+		return nil, err
+	}
+	if sla.ResultCode == diam.Success {
+		s.mu.Lock()
+		for _, r := range sla.PolicyCounterStatusReport {
+			s.counters[r.ID] = r
+		}
+		s.mu.Unlock()
+	}
+	return &sla, nil
+}
+
+// Terminate sends an STR to cancel all the subscriptions of the session
+// and closes it, regardless of the answer.
+func (s *Session) Terminate() (*STA, error) {
+	s.reqMu.Lock()
+	defer s.reqMu.Unlock()
+	if s.isClosed() {
+		return nil, ErrSessionClosed
+	}
+	defer s.close()
+	cli := s.client
+	str := &STR{
+		SessionID:         s.ID,
+		OriginHost:        cli.OriginHost,
+		OriginRealm:       cli.OriginRealm,
+		DestinationRealm:  cli.DestinationRealm,
+		DestinationHost:   cli.DestinationHost,
+		AuthApplicationID: diam.DIAMETER_SY_APP_ID,
+		TerminationCause:  TerminationLogout,
+	}
+	var sta STA
+	if err := s.exchange(diam.SessionTermination, str, &sta); err != nil {
+		return nil, err
+	}
+	return &sta, nil
+}
+
+// exchange sends the request req with the given command code and
+// decodes the answer into ans.
+func (s *Session) exchange(code uint32, req, ans interface{}) error {
+	m := diam.NewRequest(code, diam.DIAMETER_SY_APP_ID, s.conn.Dictionary())
+	if err := m.Marshal(req); err != nil {
+		return err
+	}
+	a, err := s.client.pending.Exchange(s.conn, m, s.client.Timeout)
+	if err != nil {
+		return err
+	}
+	return a.Unmarshal(ans)
+}
+
+func (s *Session) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	cli := s.client
+	cli.mu.Lock()
+	if cli.sessions[s.ID] == s {
+		delete(cli.sessions, s.ID)
+	}
+	cli.mu.Unlock()
+}
+
+func (s *Session) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+// Counters returns the last known status of the policy counters of the
+// session, ordered by Policy-Counter-Identifier.
+func (s *Session) Counters() []PolicyCounterStatusReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counters := make([]PolicyCounterStatusReport, 0, len(s.counters))
+	for _, c := range s.counters {
+		counters = append(counters, c)
+	}
+	sort.Slice(counters, func(i, j int) bool {
+		return counters[i].ID < counters[j].ID
+	})
+	return counters
+}
+
+// Counter returns the last known status of a policy counter.
+func (s *Session) Counter(id string) (PolicyCounterStatusReport, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.counters[id]
+	return c, ok
+}
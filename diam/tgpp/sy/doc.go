@@ -0,0 +1,39 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package sy implements the Sy application between the PCRF and the OCS,
+// as specified in 3GPP TS 29.219.
+//
+// It provides typed SLR/SLA, SNR/SNA and STR/STA messages for use with
+// Message.Marshal and Message.Unmarshal, a PCRF Client that subscribes
+// to policy counters and receives their status changes, and an OCS
+// Server skeleton that keeps the policy counters of each subscriber.
+//
+// SL-Request-Type is sent with the 3GPP vendor and the M and V flags, as
+// the specification requires. Earlier versions of the default dictionary
+// sent it without vendor, and such AVPs are still decoded.
+//
+// A PCRF subscribing to the policy counters of a subscriber:
+//
+//	pcrf := &sy.Client{
+//		OriginHost:       "pcrf.example.com",
+//		OriginRealm:      "example.com",
+//		DestinationRealm: "example.com",
+//		OnNotify: func(s *sy.Session, snr *sy.SNR) uint32 {
+//			log.Println(s.ID, snr.PolicyCounterStatusReport)
+//			return diam.Success
+//		},
+//	}
+//	mux := sm.New(settings)
+//	mux.HandleIdx(sy.SLAIndex, pcrf)
+//	mux.HandleIdx(sy.SNRIndex, pcrf)
+//	mux.HandleIdx(sy.STAIndex, pcrf)
+//	...
+//	s, sla, err := pcrf.Subscribe(conn, &sy.SLR{
+//		SubscriptionID: []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: imsi}},
+//		PolicyCounterIdentifier: []string{"monthly-data"},
+//	})
+//	...
+//	s.Terminate()
+package sy
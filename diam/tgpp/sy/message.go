@@ -0,0 +1,152 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sy
+
+import (
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+// Command indexes of the Sy application, for use with ServeMux.HandleIdx.
+var (
+	SLRIndex = diam.CommandIndex{AppID: diam.DIAMETER_SY_APP_ID, Code: diam.SpendingLimit, Request: true}
+	SLAIndex = diam.CommandIndex{AppID: diam.DIAMETER_SY_APP_ID, Code: diam.SpendingLimit, Request: false}
+	SNRIndex = diam.CommandIndex{AppID: diam.DIAMETER_SY_APP_ID, Code: diam.SpendingStatusNotification, Request: true}
+	SNAIndex = diam.CommandIndex{AppID: diam.DIAMETER_SY_APP_ID, Code: diam.SpendingStatusNotification, Request: false}
+	STRIndex = diam.CommandIndex{AppID: diam.DIAMETER_SY_APP_ID, Code: diam.SessionTermination, Request: true}
+	STAIndex = diam.CommandIndex{AppID: diam.DIAMETER_SY_APP_ID, Code: diam.SessionTermination, Request: false}
+)
+
+// Experimental-Result-Code values. See 3GPP TS 29.219 section 5.5.
+const (
+	UserUnknown               = 5030
+	NoAvailablePolicyCounters = 4241
+	UnknownPolicyCounters     = 5570
+)
+
+// SL-Request-Type values. See 3GPP TS 29.219 section 5.3.6.
+const (
+	InitialRequest      = 0
+	IntermediateRequest = 1
+)
+
+// SN-Request-Type values. See 3GPP TS 29.219 section 5.3.9.
+const (
+	NormalRequest     = 0
+	AggregatedRequest = 1
+)
+
+// TerminationLogout is the Termination-Cause sent by Session.Terminate.
+// See RFC 6733 section 8.15.
+const TerminationLogout = 1
+
+// PendingPolicyCounterInformation is the Pending-Policy-Counter-Information
+// grouped AVP, a status that a policy counter takes at a given time.
+type PendingPolicyCounterInformation struct {
+	Status     string    `avp:"Policy-Counter-Status"`
+	ChangeTime time.Time `avp:"Pending-Policy-Counter-Change-Time"`
+}
+
+// PolicyCounterStatusReport is the Policy-Counter-Status-Report grouped
+// AVP, the current and pending statuses of a policy counter.
+type PolicyCounterStatusReport struct {
+	ID      string                            `avp:"Policy-Counter-Identifier"`
+	Status  string                            `avp:"Policy-Counter-Status"`
+	Pending []PendingPolicyCounterInformation `avp:"Pending-Policy-Counter-Information"`
+}
+
+// SLR is a Spending-Limit-Request message, sent by the PCRF to subscribe
+// to the status of policy counters. See 3GPP TS 29.219 section 5.6.2.
+type SLR struct {
+	SessionID               string                    `avp:"Session-Id"`
+	AuthApplicationID       uint32                    `avp:"Auth-Application-Id"`
+	OriginHost              datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm             datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm        datatype.DiameterIdentity `avp:"Destination-Realm"`
+	SLRequestType           int32                     `avp:"SL-Request-Type"`
+	DestinationHost         datatype.DiameterIdentity `avp:"Destination-Host,omitempty"`
+	OriginStateID           uint32                    `avp:"Origin-State-Id,omitempty"`
+	SubscriptionID          []gy.SubscriptionID       `avp:"Subscription-Id"`
+	PolicyCounterIdentifier []string                  `avp:"Policy-Counter-Identifier"`
+}
+
+// Subscriber returns the Subscription-Id-Data of the first Subscription-Id
+// in the request, or an empty string.
+func (slr *SLR) Subscriber() string {
+	for _, id := range slr.SubscriptionID {
+		if len(id.Data) > 0 {
+			return id.Data
+		}
+	}
+	return ""
+}
+
+// SLA is a Spending-Limit-Answer message.
+// See 3GPP TS 29.219 section 5.6.3.
+type SLA struct {
+	SessionID                 string                      `avp:"Session-Id"`
+	OriginHost                datatype.DiameterIdentity   `avp:"Origin-Host"`
+	OriginRealm               datatype.DiameterIdentity   `avp:"Origin-Realm"`
+	ResultCode                uint32                      `avp:"Result-Code,omitempty"`
+	ExperimentalResult        *base.ExperimentalResult    `avp:"Experimental-Result"`
+	PolicyCounterStatusReport []PolicyCounterStatusReport `avp:"Policy-Counter-Status-Report"`
+	ErrorMessage              string                      `avp:"Error-Message,omitempty"`
+	OriginStateID             uint32                      `avp:"Origin-State-Id,omitempty"`
+}
+
+// SNR is a Spending-Status-Notification-Request message, sent by the OCS
+// when the status of subscribed policy counters changes.
+// See 3GPP TS 29.219 section 5.6.4.
+type SNR struct {
+	SessionID                 string                      `avp:"Session-Id"`
+	AuthApplicationID         uint32                      `avp:"Auth-Application-Id"`
+	OriginHost                datatype.DiameterIdentity   `avp:"Origin-Host"`
+	OriginRealm               datatype.DiameterIdentity   `avp:"Origin-Realm"`
+	DestinationRealm          datatype.DiameterIdentity   `avp:"Destination-Realm"`
+	DestinationHost           datatype.DiameterIdentity   `avp:"Destination-Host"`
+	OriginStateID             uint32                      `avp:"Origin-State-Id,omitempty"`
+	PolicyCounterStatusReport []PolicyCounterStatusReport `avp:"Policy-Counter-Status-Report"`
+	SNRequestType             *int32                      `avp:"SN-Request-Type"`
+}
+
+// SNA is a Spending-Status-Notification-Answer message.
+// See 3GPP TS 29.219 section 5.6.5.
+type SNA struct {
+	SessionID          string                    `avp:"Session-Id"`
+	OriginHost         datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm        datatype.DiameterIdentity `avp:"Origin-Realm"`
+	ResultCode         uint32                    `avp:"Result-Code,omitempty"`
+	ExperimentalResult *base.ExperimentalResult  `avp:"Experimental-Result"`
+	ErrorMessage       string                    `avp:"Error-Message,omitempty"`
+	OriginStateID      uint32                    `avp:"Origin-State-Id,omitempty"`
+}
+
+// STR is an Sy Session-Termination-Request message, sent by the PCRF to
+// cancel all its subscriptions. See 3GPP TS 29.219 section 5.6.6.
+type STR struct {
+	SessionID         string                    `avp:"Session-Id"`
+	OriginHost        datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm       datatype.DiameterIdentity `avp:"Origin-Realm"`
+	DestinationRealm  datatype.DiameterIdentity `avp:"Destination-Realm"`
+	AuthApplicationID uint32                    `avp:"Auth-Application-Id"`
+	TerminationCause  int32                     `avp:"Termination-Cause"`
+	DestinationHost   datatype.DiameterIdentity `avp:"Destination-Host,omitempty"`
+	OriginStateID     uint32                    `avp:"Origin-State-Id,omitempty"`
+}
+
+// STA is an Sy Session-Termination-Answer message.
+// See 3GPP TS 29.219 section 5.6.7.
+type STA struct {
+	SessionID     string                    `avp:"Session-Id"`
+	ResultCode    uint32                    `avp:"Result-Code"`
+	OriginHost    datatype.DiameterIdentity `avp:"Origin-Host"`
+	OriginRealm   datatype.DiameterIdentity `avp:"Origin-Realm"`
+	ErrorMessage  string                    `avp:"Error-Message,omitempty"`
+	OriginStateID uint32                    `avp:"Origin-State-Id,omitempty"`
+}
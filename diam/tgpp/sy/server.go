@@ -0,0 +1,327 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/internal/pending"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/base"
+)
+
+// ErrUnknownSession is returned by Server.Notify when the session is
+// not active.
+var ErrUnknownSession = errors.New("sy: unknown session")
+
+// Server is a skeleton of the OCS side of Sy. It keeps the status of the
+// policy counters of each subscriber, answers SLRs with the status of
+// the requested counters, and pushes an SNR to the subscribed sessions
+// whenever a counter changes. Sessions are kept until they are
+// terminated or their connection is closed.
+//
+// Subscribers are identified by the Subscription-Id-Data of the first
+// Subscription-Id in the initial SLR, and are unknown to the Server
+// until they have at least one policy counter set with SetCounter.
+//
+// Server implements the diam.Handler interface and must be registered
+// for SLRIndex, STRIndex and SNAIndex on the connection's handler.
+type Server struct {
+	OriginHost  datatype.DiameterIdentity
+	OriginRealm datatype.DiameterIdentity
+	Timeout     time.Duration // Defaults to DefaultTimeout.
+
+	// ErrorReporter, if non-nil, receives errors writing answers.
+	ErrorReporter diam.ErrorReporter
+
+	pending  pending.Table
+	mu       sync.Mutex
+	counters map[string]map[string]PolicyCounterStatusReport // By subscriber.
+	sessions map[string]*serverSession
+	watched  map[diam.Conn]bool // Connections of sessions
+}
+
+type serverSession struct {
+	conn       diam.Conn
+	slr        *SLR // Initial SLR
+	subscriber string
+	counters   map[string]bool // Subscribed counters, nil for all.
+}
+
+func (ss *serverSession) subscribed(id string) bool {
+	return ss.counters == nil || ss.counters[id]
+}
+
+// ServeDIAM implements the diam.Handler interface.
+func (s *Server) ServeDIAM(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag == 0 {
+		s.pending.Deliver(m)
+		return
+	}
+	switch m.Header.CommandCode {
+	case diam.SpendingLimit:
+		s.answer(c, m, s.spendingLimit(c, m))
+	case diam.SessionTermination:
+		s.answer(c, m, s.terminate(m))
+	}
+}
+
+func (s *Server) spendingLimit(c diam.Conn, m *diam.Message) *SLA {
+	var slr SLR
+	sla := &SLA{
+		OriginHost:  s.OriginHost,
+		OriginRealm: s.OriginRealm,
+	}
+	if err := m.Unmarshal(&slr); err != nil {
+		sla.ResultCode = diam.UnableToComply
+		return sla
+	}
+	sla.SessionID = slr.SessionID
+	if len(slr.SessionID) == 0 {
+		sla.ResultCode = diam.MissingAVP
+		return sla
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ss, active := s.sessions[slr.SessionID]
+	subscriber := slr.Subscriber()
+	if slr.SLRequestType == IntermediateRequest {
+		if !active {
+			sla.ResultCode = diam.UnknownSessionID
+			return sla
+		}
+		subscriber = ss.subscriber
+	}
+	known := s.counters[subscriber]
+	if len(known) == 0 {
+		sla.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: UserUnknown}
+		return sla
+	}
+	var subscribed map[string]bool
+	if len(slr.PolicyCounterIdentifier) > 0 {
+		subscribed = make(map[string]bool, len(slr.PolicyCounterIdentifier))
+		for _, id := range slr.PolicyCounterIdentifier {
+			if _, ok := known[id]; !ok {
+				sla.ExperimentalResult = &base.ExperimentalResult{VendorID: base.Vendor3GPP, Code: UnknownPolicyCounters}
+				return sla
+			}
+			subscribed[id] = true
+		}
+	}
+	if active {
+		ss.counters = subscribed
+	} else {
+		ss = &serverSession{
+			conn:       c,
+			slr:        &slr,
+			subscriber: subscriber,
+			counters:   subscribed,
+		}
+		if s.sessions == nil {
+			s.sessions = make(map[string]*serverSession)
+		}
+		s.sessions[slr.SessionID] = ss
+		s.watch(c)
+	}
+	for _, r := range known {
+		if ss.subscribed(r.ID) {
+			sla.PolicyCounterStatusReport = append(sla.PolicyCounterStatusReport, r)
+		}
+	}
+	sortReports(sla.PolicyCounterStatusReport)
+	sla.ResultCode = diam.Success
+	return sla
+}
+
+// watch forgets the sessions of the connection c when it is closed.
+// It must be called with s.mu held.
+func (s *Server) watch(c diam.Conn) {
+	cn, ok := c.(diam.CloseNotifier)
+	if !ok || s.watched[c] {
+		return
+	}
+	if s.watched == nil {
+		s.watched = make(map[diam.Conn]bool)
+	}
+	s.watched[c] = true
+	go func() {
+		<-cn.CloseNotify()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.watched, c)
+		for id, ss := range s.sessions {
+			if ss.conn == c {
+				delete(s.sessions, id)
+			}
+		}
+	}()
+}
+
+func (s *Server) terminate(m *diam.Message) *STA {
+	var str STR
+	sta := &STA{
+		OriginHost:  s.OriginHost,
+		OriginRealm: s.OriginRealm,
+		ResultCode:  diam.Success,
+	}
+	if err := m.Unmarshal(&str); err != nil {
+		sta.ResultCode = diam.UnableToComply
+		return sta
+	}
+	sta.SessionID = str.SessionID
+	s.mu.Lock()
+	_, active := s.sessions[str.SessionID]
+	delete(s.sessions, str.SessionID)
+	s.mu.Unlock()
+	if !active {
+		sta.ResultCode = diam.UnknownSessionID
+	}
+	return sta
+}
+
+func (s *Server) answer(c diam.Conn, m *diam.Message, v interface{}) {
+	a := m.Answer(0)
+	err := a.Marshal(v)
+	if err == nil {
+		_, err = a.WriteTo(c)
+	}
+	if err != nil && s.ErrorReporter != nil {
+		s.ErrorReporter.Error(&diam.ErrorReport{
+			Conn:    c,
+			Message: m,
+			Error:   fmt.Errorf("failed to write answer: %v", err),
+		})
+	}
+}
+
+// Sessions returns the Session-Id of all active sessions, sorted.
+func (s *Server) Sessions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := make([]string, 0, len(s.sessions))
+	for id := range s.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Session returns the initial SLR of an active session.
+func (s *Server) Session(id string) (*SLR, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ss, ok := s.sessions[id]
+	if !ok {
+		return nil, false
+	}
+	return ss.slr, true
+}
+
+// Counters returns the status of the policy counters of a subscriber,
+// ordered by Policy-Counter-Identifier.
+func (s *Server) Counters(subscriber string) []PolicyCounterStatusReport {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counters := make([]PolicyCounterStatusReport, 0, len(s.counters[subscriber]))
+	for _, r := range s.counters[subscriber] {
+		counters = append(counters, r)
+	}
+	sortReports(counters)
+	return counters
+}
+
+// SetCounter sets the status of a policy counter of a subscriber. When
+// the status changes, an SNR is sent to every session subscribed to the
+// counter and SetCounter waits for their answers, returning the first
+// error. Sessions whose PCRF answers DIAMETER_UNKNOWN_SESSION_ID are
+// dropped.
+//
+// SetCounter must not be called from a handler running on the read
+// goroutine of a subscribed session's connection.
+func (s *Server) SetCounter(subscriber string, r PolicyCounterStatusReport) error {
+	s.mu.Lock()
+	known := s.counters[subscriber]
+	if known == nil {
+		known = make(map[string]PolicyCounterStatusReport)
+		if s.counters == nil {
+			s.counters = make(map[string]map[string]PolicyCounterStatusReport)
+		}
+		s.counters[subscriber] = known
+	}
+	prev, exists := known[r.ID]
+	known[r.ID] = r
+	var notify []string
+	if !exists || !reflect.DeepEqual(prev, r) {
+		for id, ss := range s.sessions {
+			if ss.subscriber == subscriber && ss.subscribed(r.ID) {
+				notify = append(notify, id)
+			}
+		}
+	}
+	s.mu.Unlock()
+	sort.Strings(notify)
+	var firstErr error
+	for _, id := range notify {
+		sna, err := s.Notify(id, &SNR{
+			PolicyCounterStatusReport: []PolicyCounterStatusReport{r},
+		})
+		if err == nil && sna.ResultCode == diam.UnknownSessionID {
+			s.mu.Lock()
+			delete(s.sessions, id)
+			s.mu.Unlock()
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Notify sends an SNR carrying the policy counter status reports in snr
+// to the PCRF of an active session and waits for its answer. Session-Id,
+// routing AVPs and Auth-Application-Id are filled in by the Server.
+func (s *Server) Notify(sessionID string, snr *SNR) (*SNA, error) {
+	s.mu.Lock()
+	ss, ok := s.sessions[sessionID]
+	s.mu.Unlock()
+	if !ok {
+		return nil, ErrUnknownSession
+	}
+	// Fill in a copy, leaving the caller's SNR untouched.
+	var r SNR
+	if snr != nil {
+		r = *snr
+	}
+	snr = &r
+	snr.SessionID = sessionID
+	snr.AuthApplicationID = diam.DIAMETER_SY_APP_ID
+	snr.OriginHost = s.OriginHost
+	snr.OriginRealm = s.OriginRealm
+	snr.DestinationHost = ss.slr.OriginHost
+	snr.DestinationRealm = ss.slr.OriginRealm
+	m := diam.NewRequest(diam.SpendingStatusNotification, diam.DIAMETER_SY_APP_ID, ss.conn.Dictionary())
+	if err := m.Marshal(snr); err != nil {
+		return nil, err
+	}
+	a, err := s.pending.Exchange(ss.conn, m, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	var sna SNA
+	if err = a.Unmarshal(&sna); err != nil {
+		return nil, err
+	}
+	return &sna, nil
+}
+
+func sortReports(r []PolicyCounterStatusReport) {
+	sort.Slice(r, func(i, j int) bool { return r[i].ID < r[j].ID })
+}
@@ -0,0 +1,258 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package sy
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/diamtest"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/sm/smtest"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/gy"
+)
+
+func TestSLA_MarshalUnmarshal(t *testing.T) {
+	want := &SLA{
+		SessionID:   "pcrf;1",
+		OriginHost:  "ocs",
+		OriginRealm: "test",
+		ResultCode:  diam.Success,
+		PolicyCounterStatusReport: []PolicyCounterStatusReport{{
+			ID:     "monthly-data",
+			Status: "valid",
+			Pending: []PendingPolicyCounterInformation{{
+				Status:     "expired",
+				ChangeTime: time.Unix(1580515200, 0),
+			}},
+		}},
+	}
+	m := diam.NewRequest(diam.SpendingLimit, diam.DIAMETER_SY_APP_ID, dict.Default).Answer(0)
+	if err := m.Marshal(want); err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var have SLA
+	if err = dec.Unmarshal(&have); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(want, &have) {
+		t.Fatalf("Unexpected SLA.\nWant %+v\nHave %+v", want, &have)
+	}
+}
+
+// TestSLR_SLRequestType checks that SL-Request-Type is sent with the
+// 3GPP vendor, and decoded from both that and the vendorless encoding of
+// earlier versions of the dictionary.
+func TestSLR_SLRequestType(t *testing.T) {
+	m := diam.NewRequest(diam.SpendingLimit, diam.DIAMETER_SY_APP_ID, dict.Default)
+	if err := m.Marshal(&SLR{SessionID: "pcrf;1", SLRequestType: IntermediateRequest}); err != nil {
+		t.Fatal(err)
+	}
+	a, err := m.FindAVP(avp.SLRequestType, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.VendorID != 10415 || a.Flags != avp.Mbit|avp.Vbit {
+		t.Fatalf("Unexpected SL-Request-Type: %s", a)
+	}
+	for _, vendor := range []uint32{10415, 0} {
+		m := diam.NewRequest(diam.SpendingLimit, diam.DIAMETER_SY_APP_ID, dict.Default)
+		m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("pcrf;1"))
+		flags := uint8(avp.Mbit)
+		if vendor > 0 {
+			flags |= avp.Vbit
+		}
+		m.NewAVP(avp.SLRequestType, flags, vendor, datatype.Enumerated(IntermediateRequest))
+		b, err := m.Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dec, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var slr SLR
+		if err = dec.Unmarshal(&slr); err != nil {
+			t.Fatal(err)
+		}
+		if slr.SLRequestType != IntermediateRequest {
+			t.Fatalf("Unexpected SL-Request-Type with vendor %d: %d", vendor, slr.SLRequestType)
+		}
+	}
+}
+
+func TestClientServer(t *testing.T) {
+	ocs := &Server{
+		OriginHost:  "ocs",
+		OriginRealm: "test",
+		Timeout:     time.Second,
+	}
+	for _, r := range []PolicyCounterStatusReport{
+		{ID: "daily", Status: "valid"},
+		{ID: "monthly", Status: "valid"},
+	} {
+		if err := ocs.SetCounter("001010000000001", r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mux := sm.New(smtest.Settings("ocs"))
+	for _, idx := range []diam.CommandIndex{SLRIndex, STRIndex, SNAIndex} {
+		mux.HandleIdx(idx, ocs)
+	}
+	srv := diamtest.NewServer(mux, dict.Default)
+	defer srv.Close()
+
+	notified := make(chan *SNR, 1)
+	pcrf := &Client{
+		OriginHost:       "pcrf",
+		OriginRealm:      "test",
+		DestinationRealm: "test",
+		Timeout:          time.Second,
+		OnNotify: func(s *Session, snr *SNR) uint32 {
+			notified <- snr
+			return diam.Success
+		},
+	}
+	cmux := sm.New(smtest.Settings("pcrf"))
+	for _, idx := range []diam.CommandIndex{SLAIndex, SNRIndex, STAIndex} {
+		cmux.HandleIdx(idx, pcrf)
+	}
+	cli := &sm.Client{
+		Handler: cmux,
+		AuthApplicationID: []*diam.AVP{
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(diam.DIAMETER_SY_APP_ID)),
+		},
+	}
+	c, err := cli.Dial(srv.Addr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	s, sla, err := pcrf.Subscribe(c, &SLR{
+		SubscriptionID: []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: "001010000000002"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s != nil || sla.ExperimentalResult == nil || sla.ExperimentalResult.Code != UserUnknown {
+		t.Fatalf("Unexpected SLA: %+v", sla)
+	}
+
+	slr := &SLR{
+		SubscriptionID:          []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: "001010000000001"}},
+		PolicyCounterIdentifier: []string{"monthly", "yearly"},
+	}
+	if _, sla, err = pcrf.Subscribe(c, slr); err != nil {
+		t.Fatal(err)
+	}
+	if sla.ExperimentalResult == nil || sla.ExperimentalResult.Code != UnknownPolicyCounters {
+		t.Fatalf("Unexpected SLA: %+v", sla)
+	}
+
+	slr.SessionID = ""
+	slr.PolicyCounterIdentifier = []string{"monthly"}
+	s, sla, err = pcrf.Subscribe(c, slr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s == nil || sla.ResultCode != diam.Success {
+		t.Fatalf("Unexpected SLA: %+v", sla)
+	}
+	if r := s.Counters(); len(r) != 1 || r[0].ID != "monthly" || r[0].Status != "valid" {
+		t.Fatalf("Unexpected counters: %+v", r)
+	}
+	if ids := ocs.Sessions(); len(ids) != 1 || ids[0] != s.ID {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+
+	// Changes to counters the session is not subscribed to are not sent.
+	if err = ocs.SetCounter("001010000000001", PolicyCounterStatusReport{ID: "daily", Status: "exhausted"}); err != nil {
+		t.Fatal(err)
+	}
+	if err = ocs.SetCounter("001010000000001", PolicyCounterStatusReport{ID: "monthly", Status: "exhausted"}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case snr := <-notified:
+		if len(snr.PolicyCounterStatusReport) != 1 || snr.PolicyCounterStatusReport[0].ID != "monthly" {
+			t.Fatalf("Unexpected SNR: %+v", snr)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("OnNotify was not called")
+	}
+	if r, ok := s.Counter("monthly"); !ok || r.Status != "exhausted" {
+		t.Fatalf("Unexpected counter: %+v", r)
+	}
+
+	// Subscribe to all counters.
+	if sla, err = s.Update(); err != nil {
+		t.Fatal(err)
+	}
+	if sla.ResultCode != diam.Success || len(sla.PolicyCounterStatusReport) != 2 {
+		t.Fatalf("Unexpected SLA: %+v", sla)
+	}
+	if r, ok := s.Counter("daily"); !ok || r.Status != "exhausted" {
+		t.Fatalf("Unexpected counter: %+v", r)
+	}
+	if _, err = s.Update("daily"); err != nil {
+		t.Fatal(err)
+	}
+	if r := s.Counters(); len(r) != 1 || r[0].ID != "daily" {
+		t.Fatalf("Unexpected counters: %+v", r)
+	}
+
+	sta, err := s.Terminate()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sta.ResultCode != diam.Success {
+		t.Fatalf("Unexpected STA: %+v", sta)
+	}
+	if ids := ocs.Sessions(); len(ids) != 0 {
+		t.Fatalf("Unexpected sessions: %v", ids)
+	}
+	if _, err = s.Update(); err != ErrSessionClosed {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrSessionClosed, err)
+	}
+	if _, err = ocs.Notify(s.ID, nil); err != ErrUnknownSession {
+		t.Fatalf("Unexpected error. Want %v, have %v", ErrUnknownSession, err)
+	}
+	if s, _, err = pcrf.Subscribe(c, &SLR{
+		SubscriptionID: []gy.SubscriptionID{{Type: gy.EndUserIMSI, Data: "001010000000001"}},
+	}); err != nil || s == nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+	snr := &SNR{PolicyCounterStatusReport: []PolicyCounterStatusReport{{ID: "daily", Status: "valid"}}}
+	if _, err = ocs.Notify(s.ID, snr); err != nil {
+		t.Fatal(err)
+	}
+	<-notified
+	if snr.SessionID != "" || snr.DestinationHost != "" {
+		t.Fatalf("Notify modified the SNR: %+v", snr)
+	}
+
+	// Sessions are forgotten when their connection is closed.
+	c.Close()
+	for deadline := time.Now().Add(time.Second); len(ocs.Sessions()) > 0; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("Unexpected sessions after close: %v", ocs.Sessions())
+		}
+	}
+}
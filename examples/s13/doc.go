@@ -0,0 +1,13 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+// Package s13 holds the typed messages of the 3GPP S13 application
+// (3GPP TS 29.272), generated by diam-gen from the default dictionary.
+//
+// It is generated with -codec, so the messages also implement
+// diam.AVPMarshaler and diam.AVPUnmarshaler, and Marshal and Unmarshal
+// don't use reflection for them.
+package s13
+
+//go:generate go run github.com/fiorix/go-diameter/v4/cmd/diam-gen -codec -apps 16777252 -o s13.go
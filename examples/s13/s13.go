@@ -0,0 +1,529 @@
+// Code generated by diam-gen. DO NOT EDIT.
+
+package s13
+
+import (
+	"strconv"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/avp"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+)
+
+// ECR is the ME-Identity-Check-Request (code 324, application 16777252).
+type ECR struct {
+	SessionID                   datatype.UTF8String          `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id,omitempty"`
+	AuthSessionState            AuthSessionState             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	DestinationHost             datatype.DiameterIdentity    `avp:"Destination-Host,omitempty"`
+	DestinationRealm            datatype.DiameterIdentity    `avp:"Destination-Realm"`
+	TerminalInformation         *TerminalInformation         `avp:"Terminal-Information"`
+	UserName                    datatype.UTF8String          `avp:"User-Name"`
+	ProxyInfo                   []ProxyInfo                  `avp:"Proxy-Info,omitempty"`
+	RouteRecord                 []datatype.DiameterIdentity  `avp:"Route-Record,omitempty"`
+}
+
+// NewECR returns a new ECR with the given mandatory AVPs.
+func NewECR(sessionID datatype.UTF8String, authSessionState AuthSessionState, originHost datatype.DiameterIdentity, originRealm datatype.DiameterIdentity, destinationRealm datatype.DiameterIdentity, terminalInformation *TerminalInformation, userName datatype.UTF8String) *ECR {
+	return &ECR{
+		SessionID:           sessionID,
+		AuthSessionState:    authSessionState,
+		OriginHost:          originHost,
+		OriginRealm:         originRealm,
+		DestinationRealm:    destinationRealm,
+		TerminalInformation: terminalInformation,
+		UserName:            userName,
+	}
+}
+
+// ECA is the ME-Identity-Check-Answer (code 324, application 16777252).
+type ECA struct {
+	SessionID                   datatype.UTF8String          `avp:"Session-Id"`
+	VendorSpecificApplicationID *VendorSpecificApplicationID `avp:"Vendor-Specific-Application-Id,omitempty"`
+	ResultCode                  *datatype.Unsigned32         `avp:"Result-Code,omitempty"`
+	ExperimentalResult          *ExperimentalResult          `avp:"Experimental-Result,omitempty"`
+	AuthSessionState            AuthSessionState             `avp:"Auth-Session-State"`
+	OriginHost                  datatype.DiameterIdentity    `avp:"Origin-Host"`
+	OriginRealm                 datatype.DiameterIdentity    `avp:"Origin-Realm"`
+	EquipmentStatus             *EquipmentStatus             `avp:"Equipment-Status,omitempty"`
+	FailedAVP                   *diam.AVP                    `avp:"Failed-AVP,omitempty"`
+	ProxyInfo                   []ProxyInfo                  `avp:"Proxy-Info,omitempty"`
+	RouteRecord                 []datatype.DiameterIdentity  `avp:"Route-Record,omitempty"`
+}
+
+// NewECA returns a new ECA with the given mandatory AVPs.
+func NewECA(sessionID datatype.UTF8String, authSessionState AuthSessionState, originHost datatype.DiameterIdentity, originRealm datatype.DiameterIdentity) *ECA {
+	return &ECA{
+		SessionID:        sessionID,
+		AuthSessionState: authSessionState,
+		OriginHost:       originHost,
+		OriginRealm:      originRealm,
+	}
+}
+
+// ExperimentalResult is the Experimental-Result grouped AVP (code 297).
+type ExperimentalResult struct {
+	VendorID               datatype.Unsigned32 `avp:"Vendor-Id"`
+	ExperimentalResultCode datatype.Unsigned32 `avp:"Experimental-Result-Code"`
+}
+
+// NewExperimentalResult returns a new ExperimentalResult with the given mandatory AVPs.
+func NewExperimentalResult(vendorID datatype.Unsigned32, experimentalResultCode datatype.Unsigned32) *ExperimentalResult {
+	return &ExperimentalResult{
+		VendorID:               vendorID,
+		ExperimentalResultCode: experimentalResultCode,
+	}
+}
+
+// ProxyInfo is the Proxy-Info grouped AVP (code 284).
+type ProxyInfo struct {
+	ProxyHost  datatype.DiameterIdentity `avp:"Proxy-Host"`
+	ProxyState datatype.OctetString      `avp:"Proxy-State"`
+}
+
+// NewProxyInfo returns a new ProxyInfo with the given mandatory AVPs.
+func NewProxyInfo(proxyHost datatype.DiameterIdentity, proxyState datatype.OctetString) *ProxyInfo {
+	return &ProxyInfo{
+		ProxyHost:  proxyHost,
+		ProxyState: proxyState,
+	}
+}
+
+// TerminalInformation is the Terminal-Information grouped AVP (code 1401, vendor 10415).
+type TerminalInformation struct {
+	IMEI            datatype.UTF8String  `avp:"IMEI,omitempty"`
+	TGPP2MEID       datatype.OctetString `avp:"TGPP2-MEID,omitempty"`
+	SoftwareVersion datatype.UTF8String  `avp:"Software-Version,omitempty"`
+}
+
+// NewTerminalInformation returns a new TerminalInformation with the given mandatory AVPs.
+func NewTerminalInformation() *TerminalInformation {
+	return &TerminalInformation{}
+}
+
+// VendorSpecificApplicationID is the Vendor-Specific-Application-Id grouped AVP (code 260).
+type VendorSpecificApplicationID struct {
+	VendorID          *datatype.Unsigned32 `avp:"Vendor-Id,omitempty"`
+	AuthApplicationID datatype.Unsigned32  `avp:"Auth-Application-Id"`
+	AcctApplicationID datatype.Unsigned32  `avp:"Acct-Application-Id"`
+}
+
+// NewVendorSpecificApplicationID returns a new VendorSpecificApplicationID with the given mandatory AVPs.
+func NewVendorSpecificApplicationID(authApplicationID datatype.Unsigned32, acctApplicationID datatype.Unsigned32) *VendorSpecificApplicationID {
+	return &VendorSpecificApplicationID{
+		AuthApplicationID: authApplicationID,
+		AcctApplicationID: acctApplicationID,
+	}
+}
+
+// AuthSessionState is the Auth-Session-State enumerated AVP (code 277).
+type AuthSessionState int32
+
+// AuthSessionState values.
+const (
+	AuthSessionStateStateMaintained   AuthSessionState = 0
+	AuthSessionStateNoStateMaintained AuthSessionState = 1
+)
+
+// String returns the dictionary name of v.
+func (v AuthSessionState) String() string {
+	switch v {
+	case 0:
+		return "STATE_MAINTAINED"
+	case 1:
+		return "NO_STATE_MAINTAINED"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// EquipmentStatus is the Equipment-Status enumerated AVP (code 1445, vendor 10415).
+type EquipmentStatus int32
+
+// EquipmentStatus values.
+const (
+	EquipmentStatusWhitelisted EquipmentStatus = 0
+	EquipmentStatusBlacklisted EquipmentStatus = 1
+	EquipmentStatusGreylisted  EquipmentStatus = 2
+)
+
+// String returns the dictionary name of v.
+func (v EquipmentStatus) String() string {
+	switch v {
+	case 0:
+		return "WHITELISTED"
+	case 1:
+		return "BLACKLISTED"
+	case 2:
+		return "GREYLISTED"
+	}
+	return strconv.Itoa(int(v))
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ECR) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 11)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if len(s.DestinationHost) > 0 {
+		avps = append(avps, diam.NewAVP(293, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationHost)))
+	}
+	avps = append(avps, diam.NewAVP(283, avp.Mbit, 0, datatype.DiameterIdentity(s.DestinationRealm)))
+	if s.TerminalInformation != nil {
+		g, err := s.TerminalInformation.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(1401, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(1, avp.Mbit, 0, datatype.UTF8String(s.UserName)))
+	for i := range s.ProxyInfo {
+		g, err := s.ProxyInfo[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(284, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	for _, v := range s.RouteRecord {
+		avps = append(avps, diam.NewAVP(282, avp.Mbit, 0, datatype.DiameterIdentity(v)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ECR) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.ProxyInfo = s.ProxyInfo[:0]
+	s.RouteRecord = s.RouteRecord[:0]
+	var seen [9]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = datatype.UTF8String(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[2] {
+				seen[2] = true
+				s.AuthSessionState = AuthSessionState(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[3] {
+				seen[3] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[4] {
+				seen[4] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 293: // Destination-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.DestinationHost = datatype.DiameterIdentity(v)
+			}
+		case 283: // Destination-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.DestinationRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 1401: // Terminal-Information
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[7] {
+				seen[7] = true
+				s.TerminalInformation = new(TerminalInformation)
+				if err := s.TerminalInformation.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 1: // User-Name
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[8] {
+				seen[8] = true
+				s.UserName = datatype.UTF8String(v)
+			}
+		case 284: // Proxy-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e ProxyInfo
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.ProxyInfo = append(s.ProxyInfo, e)
+			}
+		case 282: // Route-Record
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok {
+				s.RouteRecord = append(s.RouteRecord, datatype.DiameterIdentity(v))
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ECA) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 11)
+	avps = append(avps, diam.NewAVP(263, avp.Mbit, 0, datatype.UTF8String(s.SessionID)))
+	if s.VendorSpecificApplicationID != nil {
+		g, err := s.VendorSpecificApplicationID.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(260, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	if s.ResultCode != nil {
+		avps = append(avps, diam.NewAVP(268, avp.Mbit, 0, datatype.Unsigned32(*s.ResultCode)))
+	}
+	if s.ExperimentalResult != nil {
+		g, err := s.ExperimentalResult.MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(297, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	avps = append(avps, diam.NewAVP(277, avp.Mbit, 0, datatype.Enumerated(s.AuthSessionState)))
+	avps = append(avps, diam.NewAVP(264, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginHost)))
+	avps = append(avps, diam.NewAVP(296, avp.Mbit, 0, datatype.DiameterIdentity(s.OriginRealm)))
+	if s.EquipmentStatus != nil {
+		avps = append(avps, diam.NewAVP(1445, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(*s.EquipmentStatus)))
+	}
+	if s.FailedAVP != nil {
+		avps = append(avps, s.FailedAVP)
+	}
+	for i := range s.ProxyInfo {
+		g, err := s.ProxyInfo[i].MarshalAVP(m)
+		if err != nil {
+			return nil, err
+		}
+		avps = append(avps, diam.NewAVP(284, avp.Mbit, 0, &diam.GroupedAVP{AVP: g}))
+	}
+	for _, v := range s.RouteRecord {
+		avps = append(avps, diam.NewAVP(282, avp.Mbit, 0, datatype.DiameterIdentity(v)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ECA) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	s.ProxyInfo = s.ProxyInfo[:0]
+	s.RouteRecord = s.RouteRecord[:0]
+	var seen [9]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 263: // Session-Id
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.SessionID = datatype.UTF8String(v)
+			}
+		case 260: // Vendor-Specific-Application-Id
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[1] {
+				seen[1] = true
+				s.VendorSpecificApplicationID = new(VendorSpecificApplicationID)
+				if err := s.VendorSpecificApplicationID.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 268: // Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				e := datatype.Unsigned32(v)
+				s.ResultCode = &e
+			}
+		case 297: // Experimental-Result
+			if g, ok := a.Data.(*diam.GroupedAVP); ok && !seen[3] {
+				seen[3] = true
+				s.ExperimentalResult = new(ExperimentalResult)
+				if err := s.ExperimentalResult.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+			}
+		case 277: // Auth-Session-State
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[4] {
+				seen[4] = true
+				s.AuthSessionState = AuthSessionState(v)
+			}
+		case 264: // Origin-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[5] {
+				seen[5] = true
+				s.OriginHost = datatype.DiameterIdentity(v)
+			}
+		case 296: // Origin-Realm
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[6] {
+				seen[6] = true
+				s.OriginRealm = datatype.DiameterIdentity(v)
+			}
+		case 10415<<32 | 1445: // Equipment-Status
+			if v, ok := a.Data.(datatype.Enumerated); ok && !seen[7] {
+				seen[7] = true
+				e := EquipmentStatus(v)
+				s.EquipmentStatus = &e
+			}
+		case 279: // Failed-AVP
+			if !seen[8] {
+				seen[8] = true
+				s.FailedAVP = a
+			}
+		case 284: // Proxy-Info
+			if g, ok := a.Data.(*diam.GroupedAVP); ok {
+				var e ProxyInfo
+				if err := e.UnmarshalAVP(m, g.AVP); err != nil {
+					return err
+				}
+				s.ProxyInfo = append(s.ProxyInfo, e)
+			}
+		case 282: // Route-Record
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok {
+				s.RouteRecord = append(s.RouteRecord, datatype.DiameterIdentity(v))
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ExperimentalResult) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(266, avp.Mbit, 0, datatype.Unsigned32(s.VendorID)))
+	avps = append(avps, diam.NewAVP(298, avp.Mbit, 0, datatype.Unsigned32(s.ExperimentalResultCode)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ExperimentalResult) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 266: // Vendor-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				s.VendorID = datatype.Unsigned32(v)
+			}
+		case 298: // Experimental-Result-Code
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[1] {
+				seen[1] = true
+				s.ExperimentalResultCode = datatype.Unsigned32(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *ProxyInfo) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 2)
+	avps = append(avps, diam.NewAVP(280, avp.Mbit, 0, datatype.DiameterIdentity(s.ProxyHost)))
+	avps = append(avps, diam.NewAVP(33, avp.Mbit, 0, datatype.OctetString(s.ProxyState)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *ProxyInfo) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [2]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 280: // Proxy-Host
+			if v, ok := a.Data.(datatype.DiameterIdentity); ok && !seen[0] {
+				seen[0] = true
+				s.ProxyHost = datatype.DiameterIdentity(v)
+			}
+		case 33: // Proxy-State
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.ProxyState = datatype.OctetString(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *TerminalInformation) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	if len(s.IMEI) > 0 {
+		avps = append(avps, diam.NewAVP(1402, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.IMEI)))
+	}
+	if len(s.TGPP2MEID) > 0 {
+		avps = append(avps, diam.NewAVP(1471, avp.Mbit|avp.Vbit, 10415, datatype.OctetString(s.TGPP2MEID)))
+	}
+	if len(s.SoftwareVersion) > 0 {
+		avps = append(avps, diam.NewAVP(1403, avp.Mbit|avp.Vbit, 10415, datatype.UTF8String(s.SoftwareVersion)))
+	}
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *TerminalInformation) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 10415<<32 | 1402: // IMEI
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[0] {
+				seen[0] = true
+				s.IMEI = datatype.UTF8String(v)
+			}
+		case 10415<<32 | 1471: // TGPP2-MEID
+			if v, ok := a.Data.(datatype.OctetString); ok && !seen[1] {
+				seen[1] = true
+				s.TGPP2MEID = datatype.OctetString(v)
+			}
+		case 10415<<32 | 1403: // Software-Version
+			if v, ok := a.Data.(datatype.UTF8String); ok && !seen[2] {
+				seen[2] = true
+				s.SoftwareVersion = datatype.UTF8String(v)
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalAVP implements diam.AVPMarshaler.
+func (s *VendorSpecificApplicationID) MarshalAVP(m *diam.Message) ([]*diam.AVP, error) {
+	avps := make([]*diam.AVP, 0, 3)
+	if s.VendorID != nil {
+		avps = append(avps, diam.NewAVP(266, avp.Mbit, 0, datatype.Unsigned32(*s.VendorID)))
+	}
+	avps = append(avps, diam.NewAVP(258, avp.Mbit, 0, datatype.Unsigned32(s.AuthApplicationID)))
+	avps = append(avps, diam.NewAVP(259, avp.Mbit, 0, datatype.Unsigned32(s.AcctApplicationID)))
+	return avps, nil
+}
+
+// UnmarshalAVP implements diam.AVPUnmarshaler.
+func (s *VendorSpecificApplicationID) UnmarshalAVP(m *diam.Message, avps []*diam.AVP) error {
+	var seen [3]bool
+	for _, a := range avps {
+		switch uint64(a.VendorID)<<32 | uint64(a.Code) {
+		case 266: // Vendor-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[0] {
+				seen[0] = true
+				e := datatype.Unsigned32(v)
+				s.VendorID = &e
+			}
+		case 258: // Auth-Application-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[1] {
+				seen[1] = true
+				s.AuthApplicationID = datatype.Unsigned32(v)
+			}
+		case 259: // Acct-Application-Id
+			if v, ok := a.Data.(datatype.Unsigned32); ok && !seen[2] {
+				seen[2] = true
+				s.AcctApplicationID = datatype.Unsigned32(v)
+			}
+		}
+	}
+	return nil
+}
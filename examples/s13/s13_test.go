@@ -0,0 +1,79 @@
+// Copyright 2013-2015 go-diameter authors. All rights reserved.
+// Use of this source code is governed by a BSD-style license that can be
+// found in the LICENSE file.
+
+package s13
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fiorix/go-diameter/v4/diam"
+	"github.com/fiorix/go-diameter/v4/diam/datatype"
+	"github.com/fiorix/go-diameter/v4/diam/dict"
+)
+
+func TestECR(t *testing.T) {
+	ti := NewTerminalInformation()
+	ti.IMEI = "35145120840121"
+	ecr := NewECR("session;1", AuthSessionStateNoStateMaintained, "client", "example.com",
+		"example.net", ti, "001010000000001")
+	ecr.RouteRecord = []datatype.DiameterIdentity{"relay1", "relay2"}
+	if _, ok := interface{}(ecr).(diam.AVPMarshaler); !ok {
+		t.Fatal("ECR has no generated codec")
+	}
+
+	m := diam.NewRequest(diam.MEIdentityCheck, diam.TGPP_S13_APP_ID, dict.Default)
+	if err := m.Marshal(ecr); err != nil {
+		t.Fatal(err)
+	}
+	b, err := m.Serialize()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rm, err := diam.ReadMessage(bytes.NewReader(b), dict.Default)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ECR
+	if err = rm.Unmarshal(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.SessionID != ecr.SessionID || got.AuthSessionState != ecr.AuthSessionState ||
+		got.OriginHost != ecr.OriginHost || got.UserName != ecr.UserName {
+		t.Fatalf("Unexpected ECR: %+v", got)
+	}
+	if got.TerminalInformation == nil || got.TerminalInformation.IMEI != ti.IMEI {
+		t.Fatalf("Unexpected Terminal-Information: %+v", got.TerminalInformation)
+	}
+	if len(got.RouteRecord) != 2 || got.RouteRecord[1] != "relay2" {
+		t.Fatalf("Unexpected Route-Record: %v", got.RouteRecord)
+	}
+	if got.VendorSpecificApplicationID != nil || got.DestinationHost != "" {
+		t.Fatalf("Unexpected optional AVPs: %+v", got)
+	}
+}
+
+func TestECA(t *testing.T) {
+	eca := NewECA("session;1", AuthSessionStateNoStateMaintained, "server", "example.net")
+	status := EquipmentStatusWhitelisted
+	eca.EquipmentStatus = &status
+
+	m := diam.NewRequest(diam.MEIdentityCheck, diam.TGPP_S13_APP_ID, dict.Default).Answer(diam.Success)
+	if err := m.Marshal(eca); err != nil {
+		t.Fatal(err)
+	}
+	var got ECA
+	if err := m.Unmarshal(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.EquipmentStatus == nil || *got.EquipmentStatus != EquipmentStatusWhitelisted {
+		t.Fatalf("Unexpected Equipment-Status: %v", got.EquipmentStatus)
+	}
+	if s := got.EquipmentStatus.String(); s != "WHITELISTED" {
+		t.Fatalf("Unexpected Equipment-Status name: %s", s)
+	}
+	if got.ResultCode != nil {
+		t.Fatalf("Unexpected Result-Code: %v", *got.ResultCode)
+	}
+}
@@ -16,10 +16,12 @@
 package main
 
 import (
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
 
 	_ "net/http/pprof"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/fiorix/go-diameter/v4/diam/avp"
 	"github.com/fiorix/go-diameter/v4/diam/datatype"
 	"github.com/fiorix/go-diameter/v4/diam/sm"
+	"github.com/fiorix/go-diameter/v4/diam/tgpp/aka"
 	"io"
 )
 
@@ -42,8 +45,18 @@ func main() {
 	certFile := flag.String("cert_file", "", "tls certificate file (optional)")
 	keyFile := flag.String("key_file", "", "tls key file (optional)")
 	networkType := flag.String("network_type", "tcp", "protocol type tcp/sctp")
+	k := flag.String("k", "465b5ce8b199b49faa5f0a2ee238a6bc", "subscriber key K in hex, shared by all users")
+	opc := flag.String("opc", "cd63cb71954a9f4e48a5994e37a02baf", "operator key OPc in hex, shared by all users")
 	flag.Parse()
 
+	var err error
+	if authKey, err = hex.DecodeString(*k); err != nil {
+		log.Fatal("invalid -k: ", err)
+	}
+	if authOPc, err = hex.DecodeString(*opc); err != nil {
+		log.Fatal("invalid -opc: ", err)
+	}
+
 	settings := &sm.Settings{
 		OriginHost:       datatype.DiameterIdentity(*host),
 		OriginRealm:      datatype.DiameterIdentity(*realm),
@@ -66,23 +79,59 @@ func main() {
 		go func() { log.Fatal(http.ListenAndServe(*ppaddr, nil)) }()
 	}
 
-	err := listen(*networkType, *addr, *certFile, *keyFile, mux)
+	err = listen(*networkType, *addr, *certFile, *keyFile, mux)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 }
 
-func sendAIA(settings sm.Settings, w io.Writer, m *diam.Message) (n int64, err error) {
+var (
+	authKey, authOPc []byte
+
+	subscribersMu sync.Mutex
+	subscribers   = make(map[string]*aka.Subscriber)
+)
+
+// subscriber returns the authentication state of a user, creating it on
+// first use.
+func subscriber(user string) (*aka.Subscriber, error) {
+	subscribersMu.Lock()
+	defer subscribersMu.Unlock()
+	s, ok := subscribers[user]
+	if !ok {
+		var err error
+		if s, err = aka.NewSubscriber(authKey, authOPc, 0); err != nil {
+			return nil, err
+		}
+		subscribers[user] = s
+	}
+	return s, nil
+}
+
+func sendAIA(settings sm.Settings, w io.Writer, m *diam.Message, user string, plmnID, resyncInfo datatype.OctetString) (n int64, err error) {
+	s, err := subscriber(user)
+	if err != nil {
+		return 0, err
+	}
+	if len(resyncInfo) > 0 {
+		if err = s.ResynchronizationInfo([]byte(resyncInfo)); err != nil {
+			log.Printf("Resynchronization failed for %s: %s", user, err)
+		}
+	}
+	v, err := s.EUTRANVector([]byte(plmnID))
+	if err != nil {
+		return 0, err
+	}
 
 	m.NewAVP(avp.AuthenticationInfo, avp.Mbit, VENDOR_3GPP, &diam.GroupedAVP{
 		AVP: []*diam.AVP{
 			diam.NewAVP(avp.EUTRANVector, avp.Mbit, VENDOR_3GPP, &diam.GroupedAVP{
 				AVP: []*diam.AVP{
-					diam.NewAVP(avp.RAND, avp.Mbit|avp.Vbit, VENDOR_3GPP, datatype.OctetString("\x94\xbf/T\xc3v\xf3\x0e\x87\x83\x06k'\x18Z\x19")),
-					diam.NewAVP(avp.XRES, avp.Mbit|avp.Vbit, VENDOR_3GPP, datatype.OctetString("F\xf0\"\xb9%#\xf58")),
-					diam.NewAVP(avp.AUTN, avp.Mbit|avp.Vbit, VENDOR_3GPP, datatype.OctetString("\xc7G!;\xad~\x80\x00)\x08o%\x11\x0cP_")),
-					diam.NewAVP(avp.KASME, avp.Mbit|avp.Vbit, VENDOR_3GPP, datatype.OctetString("\xbf\x00\xf9\x80h3\"\x0e\xa1\x1c\xfa\x93\x03@\xd6\xf8\x02\xd51Y\xeb\xc4\x9d=\t\x14{\xeb!\xec\xcb:")),
+					diam.NewAVP(avp.RAND, avp.Mbit|avp.Vbit, VENDOR_3GPP, v.RAND),
+					diam.NewAVP(avp.XRES, avp.Mbit|avp.Vbit, VENDOR_3GPP, v.XRES),
+					diam.NewAVP(avp.AUTN, avp.Mbit|avp.Vbit, VENDOR_3GPP, v.AUTN),
+					diam.NewAVP(avp.KASME, avp.Mbit|avp.Vbit, VENDOR_3GPP, v.KASME),
 				},
 			}),
 		},
@@ -127,7 +176,7 @@ func handleAIR(settings sm.Settings) diam.HandlerFunc {
 		a.NewAVP(avp.OriginHost, avp.Mbit, 0, settings.OriginHost)
 		a.NewAVP(avp.OriginRealm, avp.Mbit, 0, settings.OriginRealm)
 		a.NewAVP(avp.OriginStateID, avp.Mbit, 0, settings.OriginStateID)
-		_, err = sendAIA(settings, c, a)
+		_, err = sendAIA(settings, c, a, req.UserName, req.VisitedPLMNID, req.RequestedEUTRANAuthInfo.ResyncInfo)
 		if err != nil {
 			log.Printf("Failed to send AIA: %s", err.Error())
 		}
@@ -177,13 +177,13 @@ var helloDictionary = xml.Header + `
 	<application id="999" type="acct">
 		<command code="111" short="HM" name="Hello-Message">
 			<request>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>
 				<rule avp="User-Name" required="false" max="1"/>
 			</request>
 			<answer>
-				<rule avp="Session-Id" required="true" max="1"/>
+				<rule avp="Session-Id" required="true" max="1" position="1"/>
 				<rule avp="Result-Code" required="true" max="1"/>
 				<rule avp="Origin-Host" required="true" max="1"/>
 				<rule avp="Origin-Realm" required="true" max="1"/>